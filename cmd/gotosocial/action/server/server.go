@@ -116,19 +116,32 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// Set the state storage driver
 	state.Storage = storage
 
+	// Check storage actually works now, rather
+	// than finding out on the first media upload.
+	// Running low on space is only worth a warning.
+	if h := storage.Probe(ctx); !h.Healthy() {
+		if !errors.Is(h.Err, gtsstorage.ErrLowSpace) {
+			return fmt.Errorf("storage health check failed: %w", h.Err)
+		}
+		log.Warnf(ctx, "storage health check: %v", h.Err)
+	}
+
 	// Build HTTP client
 	client := httpclient.New(httpclient.Config{
 		AllowRanges:           config.MustParseIPPrefixes(config.GetHTTPClientAllowIPs()),
 		BlockRanges:           config.MustParseIPPrefixes(config.GetHTTPClientBlockIPs()),
 		Timeout:               config.GetHTTPClientTimeout(),
 		TLSInsecureSkipVerify: config.GetHTTPClientTLSInsecureSkipVerify(),
+		BreakerFailures:       config.GetHTTPClientBreakerFailures(),
+		BreakerBackoff:        config.GetHTTPClientBreakerBackoff(),
 	})
 
 	// Initialize workers.
 	state.Workers.Start()
 	defer state.Workers.Stop()
 
-	// Add a task to the scheduler to sweep caches.
+	// Add a task to the scheduler to sweep caches,
+	// including prepared items held in timelines.
 	// Frequency = 1 * minute
 	// Threshold = 80% capacity
 	_ = state.Workers.Scheduler.AddRecurring(
@@ -137,11 +150,35 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		time.Minute,   // freq
 		func(context.Context, time.Time) {
 			state.Caches.Sweep(60)
+			state.Timelines.Sweep(
+				config.GetCacheTimelinePreparedMax(),
+				int(config.GetCacheTimelineMemoryTarget()),
+			)
+		},
+	)
+
+	// Add a task to the scheduler to probe storage
+	// health, reported in metrics and logged on failure.
+	// Frequency = 5 * minute
+	_ = state.Workers.Scheduler.AddRecurring(
+		"@storagehealth", // id
+		time.Time{},      // start
+		5*time.Minute,    // freq
+		func(ctx context.Context, _ time.Time) {
+			if h := state.Storage.Probe(ctx); !h.Healthy() {
+				log.Warnf(ctx, "storage health check: %v", h.Err)
+			}
 		},
 	)
 
 	// Build handlers used in later initializations.
 	mediaManager := media.NewManager(&state)
+
+	// Pick up processing of any uploaded
+	// media interrupted by a previous stop.
+	if err := mediaManager.ResumeProcessing(ctx); err != nil {
+		log.Errorf(ctx, "error resuming media processing: %v", err)
+	}
 	oauthServer := oauth.New(ctx, dbService)
 	typeConverter := typeutils.NewConverter(&state)
 	visFilter := visibility.NewFilter(&state)
@@ -150,6 +187,20 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	transportController := transport.NewController(&state, federatingDB, &federation.Clock{}, client)
 	federator := federation.NewFederator(&state, federatingDB, transportController, typeConverter, mediaManager)
 
+	// Add a task to the scheduler to retry
+	// queued deliveries that failed earlier.
+	// Frequency = 1 * minute
+	_ = state.Workers.Scheduler.AddRecurring(
+		"@deliveryretry", // id
+		time.Time{},      // start
+		time.Minute,      // freq
+		transportController.RetryDeliveries,
+	)
+
+	// Schedule removal of statuses
+	// quarantined by the spam filter.
+	spamFilter.ScheduleQuarantineExpiry()
+
 	// Decide whether to create a noop email
 	// sender (won't send emails) or a real one.
 	var emailSender email.Sender
@@ -210,13 +261,50 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	state.Workers.ProcessFromClientAPI = processor.Workers().ProcessFromClientAPI
 	state.Workers.ProcessFromFediAPI = processor.Workers().ProcessFromFediAPI
 
+	// Replay any queued side effects that
+	// didn't get processed before last shutdown.
+	if err := processor.Workers().ReplayQueued(ctx); err != nil {
+		return fmt.Errorf("error replaying queued messages: %w", err)
+	}
+
 	// Schedule tasks for all existing poll expiries.
 	if err := processor.Polls().ScheduleAll(ctx); err != nil {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule tasks for all pending account deletions.
+	if err := processor.User().ScheduleDeletions(ctx); err != nil {
+		return fmt.Errorf("error scheduling account deletions: %w", err)
+	}
+
+	// Resume generation of any account archives
+	// interrupted by the last shutdown / restart.
+	if err := processor.Account().ResumeArchives(ctx); err != nil {
+		return fmt.Errorf("error resuming account archives: %w", err)
+	}
+
+	// Schedule periodic account stats regeneration.
+	if err := processor.Account().ScheduleStatsRegeneration(); err != nil {
+		return fmt.Errorf("error scheduling account stats regeneration: %w", err)
+	}
+
+	// Schedule periodic auto-deletion of old statuses.
+	if err := processor.Status().ScheduleAutoDelete(); err != nil {
+		return fmt.Errorf("error scheduling statuses auto-delete: %w", err)
+	}
+
+	// Schedule periodic pruning of inbox activity records.
+	if err := processor.Fedi().ScheduleInboxActivityPruning(); err != nil {
+		return fmt.Errorf("error scheduling inbox activity pruning: %w", err)
+	}
+
+	// Schedule periodic trends calculation.
+	if err := processor.Trends().Schedule(); err != nil {
+		return fmt.Errorf("error scheduling trends: %w", err)
+	}
+
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(&state); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -319,8 +407,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// create required middleware
 	// rate limiting
 	rlLimit := config.GetAdvancedRateLimitRequests()
+	rlAuthLimit := config.GetAdvancedRateLimitAuthRequests()
 	rlExceptions := config.GetAdvancedRateLimitExceptions()
 	clLimit := middleware.RateLimit(rlLimit, rlExceptions)        // client api
+	authLimit := middleware.RateLimit(rlAuthLimit, rlExceptions)  // sign in / oauth (stricter limit)
 	s2sLimit := middleware.RateLimit(rlLimit, rlExceptions)       // server-to-server (AP)
 	fsMainLimit := middleware.RateLimit(rlLimit, rlExceptions)    // fileserver / web templates
 	fsEmojiLimit := middleware.RateLimit(rlLimit*2, rlExceptions) // fileserver (emojis only, use high limit)
@@ -337,7 +427,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	// these should be routed in order;
 	// apply throttling *after* rate limiting
-	authModule.Route(router, clLimit, clThrottle, gzip)
+	authModule.Route(router, authLimit, clThrottle, gzip)
 	clientModule.Route(router, clLimit, clThrottle, gzip)
 	metricsModule.Route(router, clLimit, clThrottle, gzip)
 	fileserverModule.Route(router, fsMainLimit, fsThrottle)
@@ -349,7 +439,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	webModule.Route(router, fsMainLimit, fsThrottle, gzip)
 
 	// Start the GoToSocial server.
-	server := gotosocial.NewServer(dbService, router, cleaner)
+	server := gotosocial.NewServer(&state, router, cleaner, processor)
 	if err := server.Start(ctx); err != nil {
 		return fmt.Errorf("error starting gotosocial service: %s", err)
 	}
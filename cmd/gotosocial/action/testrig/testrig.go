@@ -143,7 +143,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	processor := testrig.NewTestProcessor(&state, federator, emailSender, mediaManager)
 
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(&state); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -245,7 +245,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	cleaner := cleaner.New(&state)
 
-	gts := gotosocial.NewServer(state.DB, router, cleaner)
+	gts := gotosocial.NewServer(&state, router, cleaner, processor)
 	if err := gts.Start(ctx); err != nil {
 		return fmt.Errorf("error starting gotosocial service: %s", err)
 	}
@@ -34,33 +34,62 @@
 //	      read: grants read access to everything
 //	      read:accounts: grants read access to accounts
 //	      read:blocks: grant read access to blocks
+//	      read:bookmarks: grants read access to bookmarks
 //	      read:custom_emojis: grant read access to custom_emojis
 //	      read:favourites: grant read access to favourites
+//	      read:filters: grants read access to filters
 //	      read:follows: grant read access to follows
 //	      read:lists: grant read access to lists
 //	      read:media: grant read access to media
 //	      read:mutes: grant read access to mutes
+//	      read:notifications: grants read access to notifications
+//	      read:reports: grants read access to reports
 //	      read:search: grant read access to searches
 //	      read:statuses: grants read access to statuses
 //	      read:streaming: grants read access to streaming api
 //	      read:user: grants read access to user-level info
-//	      read:notifications: grants read access to notifications
 //	      write: grants write access to everything
 //	      write:accounts: grants write access to accounts
 //	      write:blocks: grants write access to blocks
+//	      write:bookmarks: grants write access to bookmarks
+//	      write:conversations: grants write access to conversations
+//	      write:favourites: grants write access to favourites
+//	      write:filters: grants write access to filters
 //	      write:follows: grants write access to follows
 //	      write:lists: grants write access to lists
 //	      write:media: grants write access to media
 //	      write:mutes: grants write access to mutes
+//	      write:notifications: grants write access to notifications
+//	      write:reports: grants write access to reports
 //	      write:statuses: grants write access to statuses
 //	      write:user: grants write access to user-level info
+//	      follow: grants read and write access to follows, blocks, and mutes
+//	      profile: grants read access to accounts, to verify credentials
+//	      push: grants access to web push subscriptions
 //	      admin: grants admin access to everything
-//	      admin:accounts: grants admin access to accounts
+//	      admin:read: grants admin read access to everything
+//	      admin:read:accounts: grants admin read access to accounts
+//	      admin:read:reports: grants admin read access to reports
+//	      admin:read:domain_allows: grants admin read access to domain allows
+//	      admin:read:domain_blocks: grants admin read access to domain blocks
+//	      admin:read:ip_blocks: grants admin read access to ip blocks
+//	      admin:read:email_domain_blocks: grants admin read access to email domain blocks
+//	      admin:read:canonical_email_blocks: grants admin read access to canonical email blocks
+//	      admin:write: grants admin write access to everything
+//	      admin:write:accounts: grants admin write access to accounts
+//	      admin:write:reports: grants admin write access to reports
+//	      admin:write:domain_allows: grants admin write access to domain allows
+//	      admin:write:domain_blocks: grants admin write access to domain blocks
+//	      admin:write:ip_blocks: grants admin write access to ip blocks
+//	      admin:write:email_domain_blocks: grants admin write access to email domain blocks
+//	      admin:write:canonical_email_blocks: grants admin write access to canonical email blocks
 //	  OAuth2 Application:
 //	    type: oauth2
 //	    flow: application
 //	    tokenUrl: https://example.org/oauth/token
 //	    scopes:
+//	      read: grants read access to everything
+//	      write: grants write access to everything
 //	      write:accounts: grants write access to accounts
 //
 // swagger:meta
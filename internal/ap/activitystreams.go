@@ -77,6 +77,12 @@ const (
 	// See https://www.w3.org/TR/activitystreams-vocabulary/#microsyntaxes
 	// and https://www.w3.org/TR/activitystreams-vocabulary/#dfn-tag
 	TagHashtag = "Hashtag"
+
+	// EmojiReact is not in the AS spec, but is used by
+	// Pleroma / Akkoma to react to a status with an emoji.
+	//
+	// See https://docs.pleroma.social/backend/development/ap_extensions/#emojireacts
+	ActivityEmojiReact = "EmojiReact"
 )
 
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
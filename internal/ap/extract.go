@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -251,6 +252,77 @@ func ExtractInReplyToURI(i WithInReplyTo) *url.URL {
 	return nil
 }
 
+// quoteMediaTypes contains the mediaTypes with which
+// a FEP-e232 object link tag may point at a quoted status.
+var quoteMediaTypes = []string{
+	`application/ld+json; profile="https://www.w3.org/ns/activitystreams"`,
+	"application/activity+json",
+}
+
+// quoteProperties contains the non-standard property
+// names used by various implementations to indicate
+// the URI of a quoted status, in order of preference.
+var quoteProperties = []string{
+	"quoteUri",       // fedibird
+	"quoteUrl",       // akkoma / pleroma
+	"_misskey_quote", // misskey
+}
+
+// ExtractQuoteURI extracts the URI of the status quoted
+// by the given statusable, if any. FEP-e232 object link
+// tags are checked first, then the non-standard quote
+// properties used by other implementations. Will return
+// nil if no valid URI can be found.
+func ExtractQuoteURI(statusable Statusable) *url.URL {
+	if tagsProp := statusable.GetActivityStreamsTag(); tagsProp != nil {
+		for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+			if !iter.IsActivityStreamsLink() {
+				continue
+			}
+
+			link := iter.GetActivityStreamsLink()
+			if link == nil {
+				continue
+			}
+
+			mediaTypeProp := link.GetActivityStreamsMediaType()
+			if mediaTypeProp == nil ||
+				!slices.Contains(quoteMediaTypes, mediaTypeProp.Get()) {
+				continue
+			}
+
+			hrefProp := link.GetActivityStreamsHref()
+			if hrefProp != nil && hrefProp.GetIRI() != nil {
+				// Found one we can use.
+				return hrefProp.GetIRI()
+			}
+		}
+	}
+
+	withUnknown, ok := statusable.(interface {
+		GetUnknownProperties() map[string]interface{}
+	})
+	if !ok {
+		return nil
+	}
+
+	unknown := withUnknown.GetUnknownProperties()
+	for _, key := range quoteProperties {
+		str, ok := unknown[key].(string)
+		if !ok || str == "" {
+			continue
+		}
+
+		uri, err := url.Parse(str)
+		if err == nil && uri.Scheme != "" && uri.Host != "" {
+			// Found one we can use.
+			return uri
+		}
+	}
+
+	return nil
+}
+
 // ExtractItemsURIs extracts each URI it can
 // find for an item from the provided WithItems.
 func ExtractItemsURIs(i WithItems) []*url.URL {
@@ -678,6 +750,64 @@ func ExtractAttachment(i Attachmentable) (*gtsmodel.MediaAttachment, error) {
 	}, nil
 }
 
+// ExtractAudioAttachment extracts a minimal gtsmodel.Attachment
+// from the URL property of the given Audio object (as sent by eg.,
+// Funkwhale), or an error if no usable audio URL is set. The URL
+// property may contain either a bare IRI, in which case the object
+// mediaType must be audio, or a selection of Links, in which case
+// the first Link with an audio mediaType is used.
+func ExtractAudioAttachment(i Attachmentable) (*gtsmodel.MediaAttachment, error) {
+	urlProp := i.GetActivityStreamsUrl()
+	if urlProp == nil {
+		return nil, gtserror.New("url property was nil")
+	}
+
+	var remoteURL *url.URL
+
+	for iter := urlProp.Begin(); iter != urlProp.End(); iter = iter.Next() {
+		switch {
+		case iter.IsIRI():
+			if isAudioMediaType(i) {
+				remoteURL = iter.GetIRI()
+			}
+
+		case iter.IsActivityStreamsLink():
+			link := iter.GetActivityStreamsLink()
+			hrefProp := link.GetActivityStreamsHref()
+			if hrefProp != nil && hrefProp.IsXMLSchemaAnyURI() &&
+				isAudioMediaType(link) {
+				remoteURL = hrefProp.Get()
+			}
+		}
+
+		if remoteURL != nil {
+			// Found it.
+			break
+		}
+	}
+
+	if remoteURL == nil {
+		return nil, gtserror.New("no valid audio URL found")
+	}
+
+	return &gtsmodel.MediaAttachment{
+		RemoteURL:   remoteURL.String(),
+		Description: ExtractName(i),
+		Blurhash:    ExtractBlurhash(i),
+		Processing:  gtsmodel.ProcessingStatusReceived,
+	}, nil
+}
+
+// isAudioMediaType returns whether the
+// mediaType of given type is audio/*.
+func isAudioMediaType(i WithMediaType) bool {
+	mediaTypeProp := i.GetActivityStreamsMediaType()
+	if mediaTypeProp == nil || !mediaTypeProp.IsRFCRfc2045() {
+		return false
+	}
+	return strings.HasPrefix(mediaTypeProp.Get(), "audio/")
+}
+
 // ExtractDescription extracts the image description
 // of an attachmentable, if present. Will try the
 // 'summary' prop first, then fall back to 'name'.
@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractQuoteTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractQuoteTestSuite) statusable(rawJson string) ap.Statusable {
+	t, _ := suite.jsonToType(rawJson)
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type was not statusable")
+	}
+	return statusable
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteLinkTag() {
+	statusable := suite.statusable(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01HQ9QWSX7C6A0ZB0N8Y2R1ETV",
+  "type": "Note",
+  "content": "look at this! RE: https://example.org/@other/1",
+  "tag": [
+    {
+      "type": "Link",
+      "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+      "href": "https://example.org/users/other/statuses/01HQ9QZ6J4N4FD3R4VC0AT9D5J",
+      "name": "RE: https://example.org/@other/1"
+    }
+  ]
+}`)
+
+	uri := ap.ExtractQuoteURI(statusable)
+	suite.NotNil(uri)
+	suite.Equal("https://example.org/users/other/statuses/01HQ9QZ6J4N4FD3R4VC0AT9D5J", uri.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteUnknownProperty() {
+	statusable := suite.statusable(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/notes/9ptbt5bm2d",
+  "type": "Note",
+  "content": "quoting this",
+  "_misskey_quote": "https://example.org/notes/9ptbsyms1a",
+  "quoteUrl": "https://example.org/notes/9ptbsyms1a"
+}`)
+
+	uri := ap.ExtractQuoteURI(statusable)
+	suite.NotNil(uri)
+	suite.Equal("https://example.org/notes/9ptbsyms1a", uri.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteNone() {
+	suite.Nil(ap.ExtractQuoteURI(suite.noteWithHashtags1()))
+}
+
+func TestExtractQuoteTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractQuoteTestSuite{})
+}
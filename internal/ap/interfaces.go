@@ -65,6 +65,7 @@ func ToAccountable(t vocab.Type) (Accountable, bool) {
 func IsStatusable(typeName string) bool {
 	switch typeName {
 	case ObjectArticle,
+		ObjectAudio,
 		ObjectDocument,
 		ObjectImage,
 		ObjectVideo,
@@ -622,6 +623,12 @@ type WithAnyOf interface {
 	SetActivityStreamsAnyOf(vocab.ActivityStreamsAnyOfProperty)
 }
 
+// WithStartTime represents an activity with the startTime property.
+type WithStartTime interface {
+	GetActivityStreamsStartTime() vocab.ActivityStreamsStartTimeProperty
+	SetActivityStreamsStartTime(vocab.ActivityStreamsStartTimeProperty)
+}
+
 // WithEndTime represents an activity with the endTime property.
 type WithEndTime interface {
 	GetActivityStreamsEndTime() vocab.ActivityStreamsEndTimeProperty
@@ -20,6 +20,7 @@ package ap
 import (
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
@@ -117,6 +118,46 @@ func normalizeContent(rawContent interface{}) string {
 	return content
 }
 
+// emojiReactKey is the property set on an incoming EmojiReact
+// once normalized to a Like by NormalizeIncomingEmojiReact.
+const emojiReactKey = "gotosocialEmojiReact"
+
+// NormalizeIncomingEmojiReact rewrites an EmojiReact activity, or
+// an Undo of one, in the raw json object map into a Like, so that
+// it can be resolved as an ActivityStreams type. The Like is marked
+// so that it can be told apart from a regular Like, see IsEmojiReact.
+//
+// noop if the raw json object map is not (an Undo of) an EmojiReact.
+func NormalizeIncomingEmojiReact(rawJSON map[string]interface{}) {
+	normalize := func(raw map[string]interface{}) {
+		// Never trust a marker
+		// sent by the remote.
+		delete(raw, emojiReactKey)
+
+		if raw["type"] == ActivityEmojiReact {
+			raw["type"] = ActivityLike
+			raw[emojiReactKey] = true
+		}
+	}
+
+	normalize(rawJSON)
+
+	if rawJSON["type"] == ActivityUndo {
+		// Also check the undone object.
+		obj, ok := rawJSON["object"].(map[string]interface{})
+		if ok {
+			normalize(obj)
+		}
+	}
+}
+
+// IsEmojiReact returns whether the given Like
+// was normalized from an incoming EmojiReact.
+func IsEmojiReact(like vocab.ActivityStreamsLike) bool {
+	isReact, _ := like.GetUnknownProperties()[emojiReactKey].(bool)
+	return isReact
+}
+
 // NormalizeIncomingContent replaces the Content property of the given
 // item with the normalized versions of the raw 'content' and 'contentMap'
 // values from the raw json object map.
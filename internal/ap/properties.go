@@ -408,6 +408,25 @@ func SetPublished(with WithPublished, published time.Time) {
 	publishProp.Set(published)
 }
 
+// GetStartTime returns the time contained in the StartTime property of 'with'.
+func GetStartTime(with WithStartTime) time.Time {
+	startTimeProp := with.GetActivityStreamsStartTime()
+	if startTimeProp == nil || !startTimeProp.IsXMLSchemaDateTime() {
+		return time.Time{}
+	}
+	return startTimeProp.Get()
+}
+
+// SetStartTime sets the given time on the StartTime property of 'with'.
+func SetStartTime(with WithStartTime, start time.Time) {
+	startTimeProp := with.GetActivityStreamsStartTime()
+	if startTimeProp == nil {
+		startTimeProp = streams.NewActivityStreamsStartTimeProperty()
+		with.SetActivityStreamsStartTime(startTimeProp)
+	}
+	startTimeProp.Set(start)
+}
+
 // GetEndTime returns the time contained in the EndTime property of 'with'.
 func GetEndTime(with WithEndTime) time.Time {
 	endTimeProp := with.GetActivityStreamsEndTime()
@@ -251,6 +251,10 @@ func decodeType(
 	// Done with body.
 	_ = body.Close()
 
+	// EmojiReact isn't a type we can resolve,
+	// so turn it into a (marked) Like first.
+	NormalizeIncomingEmojiReact(raw)
+
 	// Resolve an ActivityStreams type.
 	t, err := streams.ToType(ctx, raw)
 	if err != nil {
@@ -21,9 +21,11 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
@@ -80,6 +82,51 @@ func (suite *ResolveTestSuite) TestResolveNonAPJSONAsAccountable() {
 	suite.Nil(accountable)
 }
 
+func (suite *ResolveTestSuite) TestResolveIncomingEmojiReact() {
+	b := []byte(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/reactions/01HTQ2QF8P5QG0BE4RTHB6Z3GX",
+  "type": "EmojiReact",
+  "actor": "https://example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "content": "🐢"
+}`)
+
+	r := httptest.NewRequest("POST", "http://localhost:8080/users/the_mighty_zork/inbox", bytes.NewReader(b))
+	activity, ok, errWithCode := ap.ResolveIncomingActivity(r)
+	suite.NoError(errWithCode)
+	suite.True(ok)
+
+	// EmojiReact should have been
+	// resolved as a (marked) Like.
+	like, ok := activity.(vocab.ActivityStreamsLike)
+	suite.True(ok)
+	suite.True(ap.IsEmojiReact(like))
+	suite.Equal("🐢", ap.ExtractContent(like).Content)
+}
+
+func (suite *ResolveTestSuite) TestResolveIncomingSpoofedEmojiReact() {
+	b := []byte(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/likes/01HTQ2QF8P5QG0BE4RTHB6Z3GX",
+  "type": "Like",
+  "actor": "https://example.org/users/someone",
+  "object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "gotosocialEmojiReact": true
+}`)
+
+	r := httptest.NewRequest("POST", "http://localhost:8080/users/the_mighty_zork/inbox", bytes.NewReader(b))
+	activity, ok, errWithCode := ap.ResolveIncomingActivity(r)
+	suite.NoError(errWithCode)
+	suite.True(ok)
+
+	// Marker sent by remote
+	// should not be trusted.
+	like, ok := activity.(vocab.ActivityStreamsLike)
+	suite.True(ok)
+	suite.False(ap.IsEmojiReact(like))
+}
+
 func TestResolveTestSuite(t *testing.T) {
 	suite.Run(t, &ResolveTestSuite{})
 }
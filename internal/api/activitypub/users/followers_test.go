@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package users_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/activitypub/users"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FollowersGetTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *FollowersGetTestSuite) TestGetFollowers() {
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	resp := suite.getCollection(
+		suite.userModule.FollowersGETHandler,
+		"foss_satan_dereference_zork_followers",
+		targetAccount,
+		targetAccount.FollowersURI,
+	)
+
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "first": "http://localhost:8080/users/the_mighty_zork/followers?limit=40",
+  "id": "http://localhost:8080/users/the_mighty_zork/followers",
+  "totalItems": 2,
+  "type": "OrderedCollection"
+}`, resp)
+}
+
+func (suite *FollowersGetTestSuite) TestGetFollowersFirstPage() {
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	resp := suite.getCollection(
+		suite.userModule.FollowersGETHandler,
+		"foss_satan_dereference_zork_followers_first",
+		targetAccount,
+		targetAccount.FollowersURI+"?limit=40",
+	)
+
+	// All followers fit on this
+	// page, so no next is given.
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://localhost:8080/users/the_mighty_zork/followers?limit=40",
+  "orderedItems": [
+    "http://localhost:8080/users/admin",
+    "http://localhost:8080/users/1happyturtle"
+  ],
+  "partOf": "http://localhost:8080/users/the_mighty_zork/followers",
+  "prev": "http://localhost:8080/users/the_mighty_zork/followers?limit=40&since_id=01G1TK3PQKFW1BQZ9WVYRTFECK",
+  "totalItems": 2,
+  "type": "OrderedCollectionPage"
+}`, resp)
+}
+
+func (suite *FollowersGetTestSuite) TestGetFollowersHidden() {
+	// Copy the target account and set it to hide
+	// collections, so we don't modify the test model.
+	targetAccount := new(gtsmodel.Account)
+	*targetAccount = *suite.testAccounts["local_account_1"]
+	targetAccount.HideCollections = util.Ptr(true)
+
+	if err := suite.db.UpdateAccount(context.Background(), targetAccount, "hide_collections"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	resp := suite.getCollection(
+		suite.userModule.FollowersGETHandler,
+		"foss_satan_dereference_zork_followers_first",
+		targetAccount,
+		targetAccount.FollowersURI+"?limit=40",
+	)
+
+	// Only the total number of items
+	// should be given, with no items
+	// nor a link to the first page.
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://localhost:8080/users/the_mighty_zork/followers",
+  "totalItems": 2,
+  "type": "OrderedCollection"
+}`, resp)
+}
+
+// getCollection calls the given collection handler for the target account,
+// using the named signed dereference request, and returns the response
+// body as indented JSON.
+func (suite *UserStandardTestSuite) getCollection(
+	handler gin.HandlerFunc,
+	derefKey string,
+	targetAccount *gtsmodel.Account,
+	target string,
+) string {
+	// the dereference we're gonna use
+	derefRequests := testrig.NewTestDereferenceRequests(suite.testAccounts)
+	signedRequest := derefRequests[derefKey]
+
+	// setup request
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, target, nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/activity+json")
+	ctx.Request.Header.Set("Signature", signedRequest.SignatureHeader)
+	ctx.Request.Header.Set("Date", signedRequest.DateHeader)
+
+	// we need to pass the context through signature check first to set appropriate values on it
+	suite.signatureCheck(ctx)
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   users.UsernameKey,
+			Value: targetAccount.Username,
+		},
+	}
+
+	// trigger the function being tested
+	handler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+	dst := new(bytes.Buffer)
+	err = json.Indent(dst, b, "", "  ")
+	suite.NoError(err)
+
+	return dst.String()
+}
+
+func TestFollowersGetTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowersGetTestSuite))
+}
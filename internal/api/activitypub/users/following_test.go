@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package users_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FollowingGetTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *FollowingGetTestSuite) TestGetFollowingFirstPage() {
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	resp := suite.getCollection(
+		suite.userModule.FollowingGETHandler,
+		"foss_satan_dereference_zork_following_first",
+		targetAccount,
+		targetAccount.FollowingURI+"?limit=1",
+	)
+
+	// The page is filled to its limit,
+	// so a next page link is given.
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://localhost:8080/users/the_mighty_zork/following?limit=1",
+  "next": "http://localhost:8080/users/the_mighty_zork/following?limit=1&max_id=01F8PYDCE8XE23GRE5DPZJDZDP",
+  "orderedItems": [
+    "http://localhost:8080/users/1happyturtle"
+  ],
+  "partOf": "http://localhost:8080/users/the_mighty_zork/following",
+  "prev": "http://localhost:8080/users/the_mighty_zork/following?limit=1&since_id=01F8PYDCE8XE23GRE5DPZJDZDP",
+  "totalItems": 2,
+  "type": "OrderedCollectionPage"
+}`, resp)
+}
+
+func TestFollowingGetTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowingGetTestSuite))
+}
@@ -19,23 +19,24 @@ package users
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // OutboxGETHandler swagger:operation GET /users/{username}/outbox s2sOutboxGet
 //
 // Get the public outbox collection for an actor.
 //
-// Note that the response will be a Collection with a page as `first`, as shown below, if `page` is `false`.
+// If no paging parameters are given, the response will be an `OrderedCollection`
+// containing the total number of items and a link to the `first` page.
 //
-// If `page` is `true`, then the response will be a single `CollectionPage` without the wrapping `Collection`.
+// If any of `limit`, `max_id` or `min_id` are given, then the response will be
+// a single `OrderedCollectionPage` with `next` and `prev` links where applicable.
 //
 // HTTP signature is required on the request.
 //
@@ -54,11 +55,12 @@ import (
 //		in: path
 //		required: true
 //	-
-//		name: page
-//		type: boolean
-//		description: Return response as a CollectionPage.
+//		name: limit
+//		type: integer
+//		description: Number of statuses to return in a CollectionPage.
+//		minimum: 1
+//		maximum: 80
 //		in: query
-//		default: false
 //	-
 //		name: min_id
 //		type: string
@@ -105,30 +107,17 @@ func (m *Module) OutboxGETHandler(c *gin.Context) {
 		return
 	}
 
-	var page bool
-	if pageString := c.Query(PageKey); pageString != "" {
-		i, err := strconv.ParseBool(pageString)
-		if err != nil {
-			err := fmt.Errorf("error parsing %s: %s", PageKey, err)
-			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
-			return
-		}
-		page = i
-	}
-
-	minID := ""
-	minIDString := c.Query(MinIDKey)
-	if minIDString != "" {
-		minID = minIDString
-	}
-
-	maxID := ""
-	maxIDString := c.Query(MaxIDKey)
-	if maxIDString != "" {
-		maxID = maxIDString
+	page, errWithCode := paging.ParseIDPage(c,
+		1,  // min limit
+		80, // max limit
+		0,  // default = disabled
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
 	}
 
-	resp, errWithCode := m.processor.Fedi().OutboxGet(c.Request.Context(), requestedUsername, page, maxID, minID)
+	resp, errWithCode := m.processor.Fedi().OutboxGet(c.Request.Context(), requestedUsername, page)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
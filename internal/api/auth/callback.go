@@ -24,6 +24,7 @@ import (
 	"net"
 	"net/http"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gin-contrib/sessions"
@@ -34,11 +35,24 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
+const (
+	// maxUsernameLength is the maximum length
+	// of a username, see the validate package.
+	maxUsernameLength = 64
+
+	// maxUsernameSuffix is the highest number that
+	// will be appended to a taken username before
+	// giving up on finding an available one.
+	maxUsernameSuffix = 100
+)
+
 // extraInfo wraps a form-submitted username and transmitted name
 type extraInfo struct {
 	Username string `form:"username"`
@@ -122,12 +136,30 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
-	user, errWithCode := m.fetchUserForClaims(c.Request.Context(), claims, net.IP(c.ClientIP()), app.ID)
+	user, errWithCode := m.fetchUserForClaims(c.Request.Context(), claims, net.ParseIP(c.ClientIP()), app.ID)
 	if errWithCode != nil {
 		m.clearSession(s)
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
+	if user == nil && config.GetOIDCAutoProvision() {
+		// no user exists yet, and we're set to create
+		// one straight away using their preferred username
+		username, errWithCode := m.usernameForClaims(c.Request.Context(), claims)
+		if errWithCode != nil {
+			m.clearSession(s)
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		user, errWithCode = m.createUserFromOIDC(c.Request.Context(), claims, &extraInfo{Username: username}, net.ParseIP(c.ClientIP()), app.ID)
+		if errWithCode != nil {
+			m.clearSession(s)
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+	}
+
 	if user == nil {
 		// no user exists yet - let's ask them for their preferred username
 		instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
@@ -136,6 +168,13 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 			return
 		}
 
+		// pre-fill the form with an available version
+		// of their preferred username if we can find one
+		preferredUsername, errWithCode := m.usernameForClaims(c.Request.Context(), claims)
+		if errWithCode != nil {
+			preferredUsername = claims.PreferredUsername
+		}
+
 		// store the claims in the session - that way we know the user is authenticated when processing the form later
 		s.Set(sessionClaims, claims)
 		s.Set(sessionAppID, app.ID)
@@ -150,7 +189,7 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 			Instance: instance,
 			Extra: map[string]any{
 				"name":              claims.Name,
-				"preferredUsername": claims.PreferredUsername,
+				"preferredUsername": preferredUsername,
 			},
 		}
 
@@ -165,6 +204,18 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
+	// Bring roles in line with groups
+	// on the provider, if configured.
+	if err := m.syncRoles(c.Request.Context(), user, claims.Groups); err != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := m.processor.User().SignInSucceeded(c.Request.Context(), user, net.ParseIP(c.ClientIP())); err != nil {
+		log.Errorf(c.Request.Context(), "error recording sign in: %v", err)
+	}
+
 	s.Set(sessionUserID, user.ID)
 	if err := s.Save(); err != nil {
 		m.clearSession(s)
@@ -240,7 +291,7 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 	}
 
 	// we're now ready to actually create the user
-	user, errWithCode := m.createUserFromOIDC(c.Request.Context(), claims, form, net.IP(c.ClientIP()), appID)
+	user, errWithCode := m.createUserFromOIDC(c.Request.Context(), claims, form, net.ParseIP(c.ClientIP()), appID)
 	if errWithCode != nil {
 		m.clearSession(s)
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -342,9 +393,10 @@ func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, ex
 	)
 
 	// If one of the claimed groups corresponds to one of
-	// the configured admin OIDC groups, create this user
-	// as an admin.
+	// the configured admin (or moderator) OIDC groups,
+	// create this user as an admin (or moderator).
 	admin := adminGroup(claims.Groups)
+	moderator := moderatorGroup(claims.Groups)
 
 	// Create the user! This will also create an account and
 	// store it in the database, so we don't need to do that.
@@ -358,6 +410,7 @@ func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, ex
 		PreApproved:   preApproved,
 		EmailVerified: emailVerified,
 		Admin:         admin,
+		Moderator:     moderator,
 	})
 	if err != nil {
 		err := gtserror.Newf("db error doing new signup: %w", err)
@@ -367,20 +420,156 @@ func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, ex
 	return user, nil
 }
 
+// usernameForClaims returns a valid, available username
+// for a new account created from the given claims, based
+// on the preferred_username claim, or the local part of
+// the email address if that's not set.
+//
+// If the username is already taken, a number is appended
+// to it, starting from 2 (eg., "someone_2", "someone_3"),
+// until an available username is found.
+func (m *Module) usernameForClaims(ctx context.Context, claims *oidc.Claims) (string, gtserror.WithCode) {
+	base := usernameBase(claims)
+
+	for i := 1; i <= maxUsernameSuffix; i++ {
+		username := base
+		if i > 1 {
+			suffix := "_" + strconv.Itoa(i)
+			username = base[:min(len(base), maxUsernameLength-len(suffix))] + suffix
+		}
+
+		available, err := m.db.IsUsernameAvailable(ctx, username)
+		if err != nil {
+			err := gtserror.Newf("db error checking username availability: %w", err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		if available {
+			return username, nil
+		}
+	}
+
+	const help = "No available username could be found based on the username given to us by your authentication provider"
+	err := gtserror.Newf("no available username found for %s", base)
+	return "", gtserror.NewErrorConflict(err, help)
+}
+
+// usernameBase returns a valid (but not necessarily
+// available) username derived from the given claims,
+// by lowercasing the preferred_username (or local part
+// of the email address), and replacing any characters
+// not allowed in usernames with underscores.
+func usernameBase(claims *oidc.Claims) string {
+	name := claims.PreferredUsername
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z',
+			r >= '0' && r <= '9',
+			r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.ToLower(name))
+
+	if name == "" {
+		return "user"
+	}
+
+	if len(name) > maxUsernameLength {
+		name = name[:maxUsernameLength]
+	}
+
+	return name
+}
+
+// syncRoles updates the admin and moderator roles of the
+// given user to match the given OIDC groups, for each of
+// oidc-admin-groups and oidc-moderator-groups that is set.
+//
+// If neither is set, roles are left as they are, so
+// that they can be managed from within GtS instead.
+func (m *Module) syncRoles(ctx context.Context, user *gtsmodel.User, groups []string) error {
+	var (
+		adminGroups     = config.GetOIDCAdminGroups()
+		moderatorGroups = config.GetOIDCModeratorGroups()
+	)
+
+	if len(adminGroups) == 0 && len(moderatorGroups) == 0 {
+		// Nothing
+		// to sync.
+		return nil
+	}
+
+	admin := *user.Admin
+	if len(adminGroups) != 0 {
+		admin = adminGroup(groups)
+	}
+
+	moderator := *user.Moderator
+	if len(moderatorGroups) != 0 {
+		moderator = moderatorGroup(groups)
+	} else if *user.Admin && !admin {
+		// Admins are moderators too, so if admin
+		// rights are being removed, and moderator
+		// rights aren't managed by groups, then
+		// remove those as well.
+		moderator = false
+	}
+
+	if admin {
+		// Admins are always
+		// moderators too.
+		moderator = true
+	}
+
+	if admin == *user.Admin && moderator == *user.Moderator {
+		// Nothing
+		// changed.
+		return nil
+	}
+
+	log.Infof(ctx,
+		"updating roles of user %s from oidc groups: admin=%t moderator=%t",
+		user.ID, admin, moderator,
+	)
+
+	user.Admin = util.Ptr(admin)
+	user.Moderator = util.Ptr(moderator)
+	if err := m.db.UpdateUser(ctx, user, "admin", "moderator"); err != nil {
+		return gtserror.Newf("db error updating user %s: %w", user.ID, err)
+	}
+
+	return nil
+}
+
 // adminGroup returns true if one of the given OIDC
 // groups is equal to at least one admin OIDC group.
 func adminGroup(groups []string) bool {
-	adminGroups := config.GetOIDCAdminGroups()
-	for _, claimedGroup := range groups {
-		if slices.ContainsFunc(adminGroups, func(allowedGroup string) bool {
-			return strings.EqualFold(claimedGroup, allowedGroup)
+	return inGroups(groups, config.GetOIDCAdminGroups())
+}
+
+// moderatorGroup returns true if one of the given OIDC
+// groups is equal to at least one moderator OIDC group.
+func moderatorGroup(groups []string) bool {
+	return inGroups(groups, config.GetOIDCModeratorGroups())
+}
+
+// inGroups returns true if one of the claimed
+// groups is equal to at least one of the given
+// configured groups, ignoring case.
+func inGroups(claimedGroups []string, configuredGroups []string) bool {
+	for _, claimedGroup := range claimedGroups {
+		if slices.ContainsFunc(configuredGroups, func(configuredGroup string) bool {
+			return strings.EqualFold(claimedGroup, configuredGroup)
 		}) {
 			return true
 		}
 	}
-
-	// User is in no admin groups,
-	// ∴ user is not an admin.
 	return false
 }
 
@@ -1,8 +1,10 @@
 package auth
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -43,3 +45,43 @@ func TestAllowedGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestModeratorGroup(t *testing.T) {
+	testrig.InitTestConfig()
+	for _, test := range []struct {
+		name     string
+		groups   []string
+		expected bool
+	}{
+		{name: "not in moderator group", groups: []string{"group1", "group2", "adminRole"}, expected: false},
+		{name: "in moderator group", groups: []string{"group1", "group2", "modRole"}, expected: true},
+	} {
+		test := test // loopvar capture
+		t.Run(test.name, func(t *testing.T) {
+			if got := moderatorGroup(test.groups); got != test.expected {
+				t.Fatalf("got: %t, wanted: %t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestUsernameBase(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		claims   *oidc.Claims
+		expected string
+	}{
+		{name: "valid preferred username", claims: &oidc.Claims{PreferredUsername: "someone"}, expected: "someone"},
+		{name: "invalid characters", claims: &oidc.Claims{PreferredUsername: "Some.One-Else"}, expected: "some_one_else"},
+		{name: "fall back to email", claims: &oidc.Claims{Email: "someone@example.org"}, expected: "someone"},
+		{name: "nothing to go on", claims: &oidc.Claims{}, expected: "user"},
+		{name: "too long", claims: &oidc.Claims{PreferredUsername: strings.Repeat("a", 70)}, expected: strings.Repeat("a", 64)},
+	} {
+		test := test // loopvar capture
+		t.Run(test.name, func(t *testing.T) {
+			if got := usernameBase(test.claims); got != test.expected {
+				t.Fatalf("got: %s, wanted: %s", got, test.expected)
+			}
+		})
+	}
+}
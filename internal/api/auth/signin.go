@@ -21,13 +21,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -82,7 +85,16 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 // SignInPOSTHandler should be served at https://example.org/auth/sign_in.
 // The idea is to present a sign in page to the user, where they can enter their username and password.
 // The handler will then redirect to the auth handler served at /auth
+//
+// If password sign in is disabled in favour of OIDC, a 403 is returned instead.
 func (m *Module) SignInPOSTHandler(c *gin.Context) {
+	if config.GetOIDCEnabled() && config.GetOIDCDisablePasswordLogin() {
+		const text = "signing in with a password is disabled on this instance, please sign in using OIDC instead"
+		err := errors.New(text)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, text), m.processor.InstanceGetV1)
+		return
+	}
+
 	s := sessions.Default(c)
 
 	form := &signIn{}
@@ -92,7 +104,7 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	userid, errWithCode := m.ValidatePassword(c.Request.Context(), form.Email, form.Password)
+	userid, errWithCode := m.ValidatePassword(c.Request.Context(), form.Email, form.Password, net.ParseIP(c.ClientIP()))
 	if errWithCode != nil {
 		// don't clear session here, so the user can just press back and try again
 		// if they accidentally gave the wrong password or something
@@ -109,11 +121,14 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
 }
 
-// ValidatePassword takes an email address and a password.
+// ValidatePassword takes an email address, a password, and the IP address of the client.
 // The goal is to authenticate the password against the one for that email
 // address stored in the database. If OK, we return the userid (a ulid) for that user,
 // so that it can be used in further Oauth flows to generate a token/retreieve an oauth client from the db.
-func (m *Module) ValidatePassword(ctx context.Context, email string, password string) (string, gtserror.WithCode) {
+//
+// Failed attempts are recorded against the user, and once too many have failed in a row,
+// sign in is locked for a while; during this time a distinct 429 error is returned instead.
+func (m *Module) ValidatePassword(ctx context.Context, email string, password string, ip net.IP) (string, gtserror.WithCode) {
 	if email == "" || password == "" {
 		err := errors.New("email or password was not provided")
 		return incorrectPassword(err)
@@ -125,6 +140,11 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 		return incorrectPassword(err)
 	}
 
+	if lockedUntil, locked := m.processor.User().SignInLocked(user); locked {
+		err := fmt.Errorf("sign in for user %s is locked until %s", user.Email, lockedUntil)
+		return signInLocked(err, lockedUntil)
+	}
+
 	if user.EncryptedPassword == "" {
 		err := fmt.Errorf("encrypted password for user %s was empty for some reason", user.Email)
 		return incorrectPassword(err)
@@ -132,9 +152,23 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
 		err := fmt.Errorf("password hash didn't match for user %s during sign in attempt: %s", user.Email, err)
+
+		if err := m.processor.User().SignInFailed(ctx, user); err != nil {
+			log.Errorf(ctx, "error recording failed sign in: %v", err)
+		}
+
+		if lockedUntil, locked := m.processor.User().SignInLocked(user); locked {
+			// This attempt tipped the user over into lockout.
+			return signInLocked(err, lockedUntil)
+		}
+
 		return incorrectPassword(err)
 	}
 
+	if err := m.processor.User().SignInSucceeded(ctx, user, ip); err != nil {
+		log.Errorf(ctx, "error recording sign in: %v", err)
+	}
+
 	return user.ID, nil
 }
 
@@ -144,3 +178,14 @@ func incorrectPassword(err error) (string, gtserror.WithCode) {
 	safeErr := fmt.Errorf("password/email combination was incorrect")
 	return "", gtserror.NewErrorUnauthorized(err, safeErr.Error(), oauth.HelpfulAdvice)
 }
+
+// signInLocked wraps the given error in a gtserror.WithCode, and returns a
+// safe error message letting the user know that sign in is locked, and until
+// when, so that this can't be mistaken for a simple incorrect password.
+func signInLocked(err error, lockedUntil time.Time) (string, gtserror.WithCode) {
+	safeErr := fmt.Errorf(
+		"too many failed sign in attempts: sign in is locked until %s",
+		lockedUntil.UTC().Format(time.RFC1123),
+	)
+	return "", gtserror.NewErrorTooManyRequests(err, safeErr.Error())
+}
@@ -18,8 +18,6 @@
 package api
 
 import (
-	"time"
-
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
@@ -27,10 +25,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/directory"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/emails"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/exports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	filter "github.com/superseriousbusiness/gotosocial/internal/api/client/filters"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/imports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
@@ -43,7 +45,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -60,10 +65,14 @@ type Client struct {
 	blocks         *blocks.Module         // api/v1/blocks
 	bookmarks      *bookmarks.Module      // api/v1/bookmarks
 	customEmojis   *customemojis.Module   // api/v1/custom_emojis
+	directory      *directory.Module      // api/v1/directory
+	emails         *emails.Module         // api/v1/emails
+	exports        *exports.Module        // api/v1/exports
 	favourites     *favourites.Module     // api/v1/favourites
 	featuredTags   *featuredtags.Module   // api/v1/featured_tags
 	filters        *filter.Module         // api/v1/filters
 	followRequests *followrequests.Module // api/v1/follow_requests
+	imports        *imports.Module        // api/v1/imports
 	instance       *instance.Module       // api/v1/instance
 	lists          *lists.Module          // api/v1/lists
 	markers        *markers.Module        // api/v1/markers
@@ -76,6 +85,8 @@ type Client struct {
 	statuses       *statuses.Module       // api/v1/statuses
 	streaming      *streaming.Module      // api/v1/streaming
 	timelines      *timelines.Module      // api/v1/timelines
+	tokens         *tokens.Module         // api/v1/tokens
+	trends         *trends.Module         // api/v1/trends
 	user           *user.Module           // api/v1/user
 }
 
@@ -102,10 +113,14 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
 	c.customEmojis.Route(h)
+	c.directory.Route(h)
+	c.emails.Route(h)
+	c.exports.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
 	c.filters.Route(h)
 	c.followRequests.Route(h)
+	c.imports.Route(h)
 	c.instance.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
@@ -118,6 +133,8 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.statuses.Route(h)
 	c.streaming.Route(h)
 	c.timelines.Route(h)
+	c.tokens.Route(h)
+	c.trends.Route(h)
 	c.user.Route(h)
 }
 
@@ -132,10 +149,14 @@ func NewClient(db db.DB, p *processing.Processor) *Client {
 		blocks:         blocks.New(p),
 		bookmarks:      bookmarks.New(p),
 		customEmojis:   customemojis.New(p),
+		directory:      directory.New(p),
+		emails:         emails.New(p),
+		exports:        exports.New(p),
 		favourites:     favourites.New(p),
 		featuredTags:   featuredtags.New(p),
 		filters:        filter.New(p),
 		followRequests: followrequests.New(p),
+		imports:        imports.New(p),
 		instance:       instance.New(p),
 		lists:          lists.New(p),
 		markers:        markers.New(p),
@@ -146,8 +167,10 @@ func NewClient(db db.DB, p *processing.Processor) *Client {
 		reports:        reports.New(p),
 		search:         search.New(p),
 		statuses:       statuses.New(p),
-		streaming:      streaming.New(p, time.Second*30, 4096),
+		streaming:      streaming.New(p, config.GetAdvancedStreamingPingInterval(), config.GetAdvancedStreamingMaxMissedPongs(), 4096),
 		timelines:      timelines.New(p),
+		tokens:         tokens.New(p),
+		trends:         trends.New(p),
 		user:           user.New(p),
 	}
 }
@@ -67,6 +67,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: invite invalid, expired, or used up
 //		'500':
 //			description: internal server error
 func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
@@ -76,6 +78,11 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeWriteAccounts); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -87,6 +94,13 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.Invite == "" {
+		// Allow giving the invite code as
+		// a query param with any body type,
+		// eg., "/api/v1/accounts?invite=CODE".
+		form.Invite = c.Query(apiutil.InviteKey)
+	}
+
 	if err := validateNormalizeCreateAccount(form); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -118,7 +132,11 @@ func validateNormalizeCreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	if form.Invite != "" {
+		if !config.GetAccountsInvitesEnabled() {
+			return errors.New("invites are not enabled on this server")
+		}
+	} else if !config.GetAccountsRegistrationOpen() {
 		return errors.New("registration is not open for this server")
 	}
 
@@ -33,6 +33,11 @@ import (
 //
 // Delete your account.
 //
+// If the instance has an account deletion grace period configured, the account will be
+// suspended immediately, and only deleted once the grace period has passed. Until then,
+// the deletion can be cancelled using the link sent to the account's email address, or
+// by an admin unsuspending the account.
+//
 //	---
 //	tags:
 //	- accounts
@@ -72,6 +77,11 @@ func (m *Module) AccountDeletePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeWriteAccounts); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	form := &apimodel.AccountDeleteRequest{}
 	if err := c.ShouldBind(&form); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
@@ -91,7 +101,7 @@ func (m *Module) AccountDeletePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	if errWithCode := m.processor.Account().DeleteSelf(c.Request.Context(), authed.Account); errWithCode != nil {
+	if errWithCode := m.processor.User().DeletionRequest(c.Request.Context(), authed.User, authed.Account); errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
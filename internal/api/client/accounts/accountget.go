@@ -72,6 +72,11 @@ func (m *Module) AccountGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeReadAccounts); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
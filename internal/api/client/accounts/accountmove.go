@@ -82,6 +82,11 @@ func (m *Module) AccountMovePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeWriteAccounts); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	form := &apimodel.AccountMoveRequest{}
 	if err := c.ShouldBind(&form); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
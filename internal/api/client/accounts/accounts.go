@@ -46,9 +46,11 @@ const (
 	ListsPath         = BasePathWithID + "/lists"
 	LookupPath        = BasePath + "/lookup"
 	NotePath          = BasePathWithID + "/note"
+	RefreshPath       = BasePathWithID + "/refresh"
 	RelationshipsPath = BasePath + "/relationships"
 	SearchPath        = BasePath + "/search"
 	StatusesPath      = BasePathWithID + "/statuses"
+	ThemesPath        = BasePath + "/themes"
 	UnblockPath       = BasePathWithID + "/unblock"
 	UnfollowPath      = BasePathWithID + "/unfollow"
 	UpdatePath        = BasePath + "/update_credentials"
@@ -107,10 +109,16 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// account note
 	attachHandler(http.MethodPost, NotePath, m.AccountNotePOSTHandler)
 
+	// force refresh of remote account
+	attachHandler(http.MethodPost, RefreshPath, m.AccountRefreshPOSTHandler)
+
 	// search for accounts
 	attachHandler(http.MethodGet, SearchPath, m.AccountSearchGETHandler)
 	attachHandler(http.MethodGet, LookupPath, m.AccountLookupGETHandler)
 
+	// get available web themes
+	attachHandler(http.MethodGet, ThemesPath, m.AccountThemesGETHandler)
+
 	// migration handlers
 	attachHandler(http.MethodPost, AliasPath, m.AccountAliasPOSTHandler)
 	// attachHandler(http.MethodPost, MovePath, m.AccountMovePOSTHandler) // todo: enable this only when Move is completed
@@ -78,11 +78,23 @@ import (
 //		description: Avatar of the user.
 //		type: file
 //	-
+//		name: avatar_description
+//		in: formData
+//		description: Description of avatar image, for alt-text.
+//		type: string
+//		allowEmptyValue: true
+//	-
 //		name: header
 //		in: formData
 //		description: Header of the user.
 //		type: file
 //	-
+//		name: header_description
+//		in: formData
+//		description: Description of header image, for alt-text.
+//		type: string
+//		allowEmptyValue: true
+//	-
 //		name: locked
 //		in: formData
 //		description: Require manual approval of follow requests.
@@ -108,6 +120,32 @@ import (
 //		description: Default content type to use for authored statuses (text/plain or text/markdown).
 //		type: string
 //	-
+//		name: source[expand_spoilers]
+//		in: formData
+//		description: Expand content warnings by default when reading.
+//		type: boolean
+//	-
+//		name: source[expand_media]
+//		in: formData
+//		description: >-
+//			How to display media attachments when reading.
+//			`default`: hide media marked as sensitive.
+//			`show_all`: always show all media.
+//			`hide_all`: always hide all media.
+//		type: string
+//	-
+//		name: source[disable_animation]
+//		in: formData
+//		description: Serve static versions of animated avatars, headers and emojis.
+//		type: boolean
+//	-
+//		name: source[boost_visibility]
+//		in: formData
+//		description: >-
+//			Default visibility of boosts (public, unlisted or private).
+//			Empty string to unset, in which case boosts use the visibility of the boosted status.
+//		type: string
+//	-
 //		name: custom_css
 //		in: formData
 //		description: >-
@@ -120,6 +158,79 @@ import (
 //		description: Enable RSS feed for this account's Public posts at `/[username]/feed.rss`
 //		type: boolean
 //	-
+//		name: rss_include_sensitive
+//		in: formData
+//		description: Include posts marked as sensitive in this account's RSS feed.
+//		type: boolean
+//	-
+//		name: hide_collections
+//		in: formData
+//		description: Hide this account's followers and following from everyone else. Counts are still shown.
+//		type: boolean
+//	-
+//		name: suppress_follow_request_notifications
+//		in: formData
+//		description: >-
+//			Don't create notifications for new follow requests.
+//			Requests are still queued and can be viewed at /api/v1/follow_requests.
+//		type: boolean
+//	-
+//		name: suppress_status_update_notifications
+//		in: formData
+//		description: Don't create notifications when statuses you boosted or favourited are edited.
+//		type: boolean
+//	-
+//		name: auto_accept_followed_back
+//		in: formData
+//		description: Automatically accept follow requests from accounts you already follow.
+//		type: boolean
+//	-
+//		name: auto_accept_local
+//		in: formData
+//		description: Automatically accept follow requests from accounts on this instance.
+//		type: boolean
+//	-
+//		name: auto_accept_older_than_days
+//		in: formData
+//		description: >-
+//			Automatically accept follow requests from accounts created more than this many days ago.
+//			0 disables this rule.
+//		type: integer
+//		minimum: 0
+//		maximum: 3650
+//	-
+//		name: statuses_auto_delete_after_days
+//		in: formData
+//		description: >-
+//			Automatically delete statuses older than this many days.
+//			0 disables automatic deletion.
+//		type: integer
+//		minimum: 0
+//		maximum: 3650
+//	-
+//		name: statuses_auto_delete_keep_pinned
+//		in: formData
+//		description: Don't automatically delete pinned statuses.
+//		type: boolean
+//	-
+//		name: statuses_auto_delete_keep_bookmarked
+//		in: formData
+//		description: Don't automatically delete statuses you have bookmarked.
+//		type: boolean
+//	-
+//		name: statuses_auto_delete_keep_direct
+//		in: formData
+//		description: Don't automatically delete direct messages.
+//		type: boolean
+//	-
+//		name: theme
+//		in: formData
+//		description: >-
+//			FileName of the theme to use when rendering this account's profile or statuses.
+//			The theme must exist on this server, as indicated by /api/v1/accounts/themes.
+//			Empty string unsets theme and returns to the default GoToSocial theme.
+//		type: string
+//	-
 //		name: fields_attributes
 //		in: formData
 //		description: Profile fields to be added to this account's profile
@@ -153,6 +264,11 @@ func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeWriteAccounts); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -247,15 +363,33 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 			form.DisplayName == nil &&
 			form.Note == nil &&
 			form.Avatar == nil &&
+			form.AvatarDescription == nil &&
 			form.Header == nil &&
+			form.HeaderDescription == nil &&
 			form.Locked == nil &&
 			form.Source.Privacy == nil &&
 			form.Source.Sensitive == nil &&
 			form.Source.Language == nil &&
 			form.Source.StatusContentType == nil &&
+			form.Source.ExpandSpoilers == nil &&
+			form.Source.ExpandMedia == nil &&
+			form.Source.DisableAnimation == nil &&
+			form.Source.BoostVisibility == nil &&
 			form.FieldsAttributes == nil &&
 			form.CustomCSS == nil &&
-			form.EnableRSS == nil) {
+			form.EnableRSS == nil &&
+			form.RSSIncludeSensitive == nil &&
+			form.HideCollections == nil &&
+			form.SuppressFollowRequestNotifications == nil &&
+			form.SuppressStatusUpdateNotifications == nil &&
+			form.AutoAcceptFollowedBack == nil &&
+			form.AutoAcceptLocal == nil &&
+			form.AutoAcceptOlderThanDays == nil &&
+			form.StatusesAutoDeleteAfterDays == nil &&
+			form.StatusesAutoDeleteKeepPinned == nil &&
+			form.StatusesAutoDeleteKeepBookmarked == nil &&
+			form.StatusesAutoDeleteKeepDirect == nil &&
+			form.Theme == nil) {
 		return nil, errors.New("empty form submitted")
 	}
 
@@ -25,12 +25,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -385,6 +388,58 @@ func (suite *AccountUpdateTestSuite) TestUpdateAccountWithImageFormData() {
 	suite.NotEqual("http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg", apimodelAccount.HeaderStatic)
 }
 
+func (suite *AccountUpdateTestSuite) TestUpdateAccountWithImageAndDescriptionFormData() {
+	data := map[string][]string{
+		"header_description": {"a cool new header"},
+	}
+
+	apimodelAccount, err := suite.updateAccountFromFormDataWithFile("header", "../../../../testrig/media/test-jpeg.jpg", data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEqual("http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg", apimodelAccount.Header)
+	suite.Equal("a cool new header", apimodelAccount.HeaderDescription)
+
+	// Avatar should be untouched.
+	suite.Equal("a green goblin looking nasty", apimodelAccount.AvatarDescription)
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountAvatarDescriptionOnlyForm() {
+	data := map[string][]string{
+		"avatar_description": {"<script>alert('boo')</script>a <em>goblin</em>, looking less nasty"},
+	}
+
+	apimodelAccount, err := suite.updateAccountFromForm(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Same avatar, new description.
+	suite.Equal("http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg", apimodelAccount.Avatar)
+	suite.Equal("a goblin, looking less nasty", apimodelAccount.AvatarDescription)
+
+	// Check it was stored.
+	attachment, err := suite.db.GetAttachmentByID(context.Background(), "01F8MH58A357CV5K7R7TJMSH6S")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("a goblin, looking less nasty", attachment.Description)
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountHeaderDescriptionTooLong() {
+	maxChars := config.GetMediaDescriptionMaxChars()
+	data := map[string][]string{
+		"header_description": {strings.Repeat("a", maxChars+1)},
+	}
+
+	expectedBody := fmt.Sprintf(`{"error":"Bad Request: description must be less than %d characters, but submitted description was %d characters"}`, maxChars, maxChars+1)
+	_, err := suite.updateAccountFromFormData(data, http.StatusBadRequest, expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func (suite *AccountUpdateTestSuite) TestUpdateAccountEmptyForm() {
 	data := make(map[string][]string)
 
@@ -495,6 +550,109 @@ func (suite *AccountUpdateTestSuite) TestAccountUpdateCredentialsPATCHHandlerUpd
 	}
 }
 
+func (suite *AccountUpdateTestSuite) TestUpdateAccountSourceReadingPreferencesFormData() {
+	data := map[string][]string{
+		"source[expand_spoilers]": {"true"},
+		"source[expand_media]":    {"hide_all"},
+	}
+
+	apimodelAccount, err := suite.updateAccountFromFormData(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(apimodelAccount.Source.ExpandSpoilers)
+	suite.Equal("hide_all", apimodelAccount.Source.ExpandMedia)
+
+	// Check the account in the database too.
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbAccount.ExpandSpoilers)
+	suite.Equal("hide_all", dbAccount.ExpandMedia)
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountSourceExpandMediaBad() {
+	data := map[string][]string{
+		"source[expand_media]": {"peepeepoopoo"},
+	}
+
+	_, err := suite.updateAccountFromFormData(data, http.StatusBadRequest, `{"error":"Bad Request: expand media 'peepeepoopoo' was not recognized, valid options are 'default', 'show_all', 'hide_all'"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountSourceBoostVisibility() {
+	data := map[string][]string{
+		"source[boost_visibility]": {"unlisted"},
+	}
+
+	apimodelAccount, err := suite.updateAccountFromFormData(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(apimodel.VisibilityUnlisted, apimodelAccount.Source.BoostVisibility)
+
+	// Check the account in the database too.
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.VisibilityUnlocked, dbAccount.BoostVisibility)
+
+	// Unset it again.
+	data = map[string][]string{
+		"source[boost_visibility]": {""},
+	}
+
+	apimodelAccount, err = suite.updateAccountFromFormData(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(apimodelAccount.Source.BoostVisibility)
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountSourceBoostVisibilityBad() {
+	data := map[string][]string{
+		"source[boost_visibility]": {"direct"},
+	}
+
+	_, err := suite.updateAccountFromFormData(data, http.StatusBadRequest, `{"error":"Bad Request: boost visibility 'direct' was not recognized, valid options are 'public', 'unlisted', 'private'"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountStatusesAutoDelete() {
+	data := map[string][]string{
+		"statuses_auto_delete_after_days":  {"30"},
+		"statuses_auto_delete_keep_direct": {"false"},
+	}
+
+	apimodelAccount, err := suite.updateAccountFromFormData(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(30, apimodelAccount.Source.StatusesAutoDeleteAfterDays)
+	suite.True(apimodelAccount.Source.StatusesAutoDeleteKeepPinned)
+	suite.True(apimodelAccount.Source.StatusesAutoDeleteKeepBookmarked)
+	suite.False(apimodelAccount.Source.StatusesAutoDeleteKeepDirect)
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountStatusesAutoDeleteBad() {
+	data := map[string][]string{
+		"statuses_auto_delete_after_days": {"-1"},
+	}
+
+	_, err := suite.updateAccountFromFormData(data, http.StatusBadRequest, `{"error":"Bad Request: statuses auto delete after days must be between 0 and 3650, provided: -1"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountUpdateTestSuite))
 }
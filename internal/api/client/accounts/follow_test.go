@@ -81,6 +81,64 @@ func (suite *FollowTestSuite) TestFollowSelf() {
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *FollowTestSuite) TestFollowUpdateExisting() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["admin_account"]
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, requestingAccount)
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// Turn on the "bell" and
+	// turn off reblogs for an
+	// already-existing follow.
+	form := url.Values{
+		"notify":  []string{"true"},
+		"reblogs": []string{"false"},
+	}
+	ctx.Request = httptest.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("http://localhost:8080%s", strings.Replace(accounts.FollowPath, ":id", targetAccount.ID, 1)),
+		strings.NewReader(form.Encode()),
+	)
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   accounts.IDKey,
+			Value: targetAccount.ID,
+		},
+	}
+
+	// call the handler
+	suite.accountsModule.AccountFollowPOSTHandler(ctx)
+
+	// Updating an existing follow should not error.
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	relationship := new(model.Relationship)
+	if err := json.NewDecoder(result.Body).Decode(relationship); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(relationship.Following)
+	suite.True(relationship.Notifying)
+	suite.False(relationship.ShowingReblogs)
+
+	// Flags should be stored on the follow itself.
+	follow, err := suite.db.GetFollow(context.Background(), requestingAccount.ID, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*follow.Notify)
+	suite.False(*follow.ShowReblogs)
+}
+
 func (suite *FollowTestSuite) TestGetFollowersPageBackwardLimit2() {
 	suite.testGetFollowersPage(2, "backward")
 }
@@ -19,7 +19,6 @@ package accounts
 
 import (
 	"errors"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
@@ -119,6 +118,11 @@ func (m *Module) AccountFollowingGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeReadAccounts); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -147,9 +151,5 @@ func (m *Module) AccountFollowingGETHandler(c *gin.Context) {
 		return
 	}
 
-	if resp.LinkHeader != "" {
-		c.Header("Link", resp.LinkHeader)
-	}
-
-	apiutil.JSON(c, http.StatusOK, resp.Items)
+	apiutil.JSONPage(c, resp)
 }
@@ -26,39 +26,57 @@ import (
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + IDKey
-	AccountsPath            = BasePath + "/accounts"
-	AccountsPathWithID      = AccountsPath + "/:" + IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
+	BasePath                       = "/v1/admin"
+	EmojiPath                      = BasePath + "/custom_emojis"
+	EmojiPathWithID                = EmojiPath + "/:" + IDKey
+	EmojiCategoriesPath            = EmojiPath + "/categories"
+	DomainBlocksPath               = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID         = DomainBlocksPath + "/:" + IDKey
+	DomainAllowsPath               = BasePath + "/domain_allows"
+	DomainAllowsPathWithID         = DomainAllowsPath + "/:" + IDKey
+	DomainKeysExpirePath           = BasePath + "/domain_keys_expire"
+	DeliveriesBacklogPath          = BasePath + "/deliveries/backlog"
+	HeaderAllowsPath               = BasePath + "/header_allows"
+	HeaderAllowsPathWithID         = HeaderAllowsPath + "/:" + IDKey
+	HeaderBlocksPath               = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID         = HeaderBlocksPath + "/:" + IDKey
+	AccountsPath                   = BasePath + "/accounts"
+	AccountsPathWithID             = AccountsPath + "/:" + IDKey
+	AccountsActionPath             = AccountsPathWithID + "/action"
+	AccountsStatusesMaxCharsPath   = AccountsPathWithID + "/statuses_max_chars"
+	IPScrubPath                    = BasePath + "/ip_scrub"
+	InvitesPath                    = BasePath + "/invites"
+	InvitesPathWithID              = InvitesPath + "/:" + IDKey
+	MediaCleanupPath               = BasePath + "/media_cleanup"
+	MediaRefetchPath               = BasePath + "/media_refetch"
+	ReportsPath                    = BasePath + "/reports"
+	ReportsPathWithID              = ReportsPath + "/:" + IDKey
+	ReportsResolvePath             = ReportsPathWithID + "/resolve"
+	EmailPath                      = BasePath + "/email"
+	EmailTestPath                  = EmailPath + "/test"
+	InstanceRulesPath              = BasePath + "/instance/rules"
+	InstanceRulesPathWithID        = InstanceRulesPath + "/:" + IDKey
+	RelaysPath                     = BasePath + "/relays"
+	RelaysPathWithID               = RelaysPath + "/:" + IDKey
+	QuarantinedStatusesPath        = BasePath + "/quarantine/statuses"
+	QuarantinedStatusesPathWithID  = QuarantinedStatusesPath + "/:" + IDKey
+	QuarantinedStatusesApprovePath = QuarantinedStatusesPathWithID + "/approve"
+	QuarantinedStatusesRejectPath  = QuarantinedStatusesPathWithID + "/reject"
+	TrendsTagsPath                 = BasePath + "/trends/tags"
+	TrendsTagsApprovePath          = TrendsTagsPath + "/:" + IDKey + "/approve"
+	TrendsTagsRejectPath           = TrendsTagsPath + "/:" + IDKey + "/reject"
+	MeasuresPath                   = BasePath + "/measures"
+	DimensionsPath                 = BasePath + "/dimensions"
+	DebugPath                      = BasePath + "/debug"
+	DebugAPUrlPath                 = DebugPath + "/apurl"
+	DebugStoragePath               = DebugPath + "/storage"
 
 	IDKey                 = "id"
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
 	LimitKey              = "limit"
+	OffsetKey             = "offset"
 	DomainQueryKey        = "domain"
 	ResolvedKey           = "resolved"
 	AccountIDKey          = "account_id"
@@ -111,12 +129,16 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// domain maintenance stuff
 	attachHandler(http.MethodPost, DomainKeysExpirePath, m.DomainKeysExpirePOSTHandler)
+	attachHandler(http.MethodGet, DeliveriesBacklogPath, m.DeliveriesBacklogGETHandler)
 
 	// accounts stuff
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
+	attachHandler(http.MethodPost, AccountsStatusesMaxCharsPath, m.AccountStatusesMaxCharsPOSTHandler)
+	attachHandler(http.MethodPost, IPScrubPath, m.IPScrubPOSTHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
+	attachHandler(http.MethodGet, MediaCleanupPath, m.MediaCleanupGETHandler)
 	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
 
 	// reports stuff
@@ -134,7 +156,35 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
 	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
 
+	// invites stuff
+	attachHandler(http.MethodGet, InvitesPath, m.InvitesGETHandler)
+	attachHandler(http.MethodPost, InvitesPath, m.InvitePOSTHandler)
+	attachHandler(http.MethodGet, InvitesPathWithID, m.InviteGETHandler)
+	attachHandler(http.MethodDelete, InvitesPathWithID, m.InviteDELETEHandler)
+
+	// relays stuff
+	attachHandler(http.MethodGet, RelaysPath, m.RelaysGETHandler)
+	attachHandler(http.MethodPost, RelaysPath, m.RelayPOSTHandler)
+	attachHandler(http.MethodGet, RelaysPathWithID, m.RelayGETHandler)
+	attachHandler(http.MethodDelete, RelaysPathWithID, m.RelayDELETEHandler)
+
+	// quarantined statuses stuff
+	attachHandler(http.MethodGet, QuarantinedStatusesPath, m.QuarantinedStatusesGETHandler)
+	attachHandler(http.MethodGet, QuarantinedStatusesPathWithID, m.QuarantinedStatusGETHandler)
+	attachHandler(http.MethodPost, QuarantinedStatusesApprovePath, m.QuarantinedStatusApprovePOSTHandler)
+	attachHandler(http.MethodPost, QuarantinedStatusesRejectPath, m.QuarantinedStatusRejectPOSTHandler)
+
+	// trends stuff
+	attachHandler(http.MethodGet, TrendsTagsPath, m.TrendsTagsGETHandler)
+	attachHandler(http.MethodPost, TrendsTagsApprovePath, m.TrendsTagApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsTagsRejectPath, m.TrendsTagRejectPOSTHandler)
+
+	// dashboard stuff
+	attachHandler(http.MethodPost, MeasuresPath, m.MeasuresPOSTHandler)
+	attachHandler(http.MethodPost, DimensionsPath, m.DimensionsPOSTHandler)
+
 	// debug stuff
+	attachHandler(http.MethodGet, DebugStoragePath, m.DebugStorageGETHandler)
 	if debug.DEBUG {
 		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
 	}
@@ -67,7 +67,7 @@ import (
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- admin
+//		- admin:write:domain_blocks
 //
 //	responses:
 //		'202':
@@ -106,6 +106,11 @@ func (m *Module) DomainKeysExpirePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeAdminWriteDomainBlocks); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -143,7 +148,7 @@ func validateDomainKeysExpire(form *apimodel.DomainKeysExpireRequest) error {
 		return errors.New("no domain given")
 	}
 
-	if form.Domain == config.GetHost() || form.Domain == config.GetAccountDomain() {
+	if config.IsLocalHost(form.Domain) {
 		return errors.New("provided domain was this domain, but must be a remote domain")
 	}
 
@@ -75,6 +75,11 @@ func (m *Module) createDomainPermissions(
 		return
 	}
 
+	if errWithCode := authed.RequireScope(domainPermissionScope(permType, true)); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -178,6 +183,11 @@ func (m *Module) deleteDomainPermission(
 		return
 	}
 
+	if errWithCode := authed.RequireScope(domainPermissionScope(permType, true)); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -220,6 +230,11 @@ func (m *Module) getDomainPermission(
 		return
 	}
 
+	if errWithCode := authed.RequireScope(domainPermissionScope(permType, false)); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -268,6 +283,11 @@ func (m *Module) getDomainPermissions(
 		return
 	}
 
+	if errWithCode := authed.RequireScope(domainPermissionScope(permType, false)); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -292,3 +312,18 @@ func (m *Module) getDomainPermissions(
 
 	apiutil.JSON(c, http.StatusOK, domainPerm)
 }
+
+// domainPermissionScope returns the oauth scope required
+// to either read or write domain permissions of the given type.
+func domainPermissionScope(permType gtsmodel.DomainPermissionType, write bool) oauth.Scope {
+	switch {
+	case permType == gtsmodel.DomainPermissionBlock && write:
+		return oauth.ScopeAdminWriteDomainBlocks
+	case permType == gtsmodel.DomainPermissionBlock:
+		return oauth.ScopeAdminReadDomainBlocks
+	case write:
+		return oauth.ScopeAdminWriteDomainAllows
+	default:
+		return oauth.ScopeAdminReadDomainAllows
+	}
+}
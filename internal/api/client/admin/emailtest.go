@@ -31,7 +31,7 @@ import (
 
 // EmailTestPostHandler swagger:operation POST /api/v1/admin/email/test testEmailSend
 //
-// Send a generic test email to a specified email address.
+// Send a generic test email to a specified email address, or to the requesting admin's own address.
 //
 // This can be used to validate an instance's SMTP configuration, and to debug any potential issues.
 //
@@ -52,12 +52,14 @@ import (
 //	-
 //		name: email
 //		in: formData
-//		description: The email address that the test email should be sent to.
+//		description: >-
+//			The email address that the test email should be sent to.
+//			If not set, the email will be sent to the requesting admin's own email address.
 //		type: string
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- admin
+//		- admin:write
 //
 //	responses:
 //		'202':
@@ -74,7 +76,8 @@ import (
 //			description: not acceptable
 //		'422':
 //			description: >-
-//				An smtp occurred while the email attempt was in progress.
+//				An smtp occurred while the email attempt was in progress,
+//				or email sending is not configured on this instance.
 //				Check the returned json for more information. The smtp error
 //				will be included, to help you debug communication with the
 //				smtp server.
@@ -93,6 +96,11 @@ func (m *Module) EmailTestPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeAdminWrite); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -104,6 +112,13 @@ func (m *Module) EmailTestPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.Email == "" {
+		// Default to sending
+		// to the admin's own
+		// email address.
+		form.Email = authed.User.Email
+	}
+
 	email, err := mail.ParseAddress(form.Email)
 	if err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
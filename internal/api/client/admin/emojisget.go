@@ -19,7 +19,6 @@ package admin
 
 import (
 	"fmt"
-	"net/http"
 	"strconv"
 	"strings"
 
@@ -98,6 +97,10 @@ import (
 //			Emoji with the given `[shortcode]@[domain]` will not be included in the result set.
 //		in: query
 //
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:read
+//
 //	responses:
 //		'200':
 //			headers:
@@ -134,6 +137,11 @@ func (m *Module) EmojisGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeAdminRead); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -185,7 +193,7 @@ func (m *Module) EmojisGETHandler(c *gin.Context) {
 	if domain == "" {
 		// default is to show all domains
 		domain = db.EmojiAllDomains
-	} else if domain == "local" || domain == config.GetHost() || domain == config.GetAccountDomain() {
+	} else if domain == "local" || config.IsLocalHost(domain) {
 		// pass empty string for local domain
 		domain = ""
 	}
@@ -213,9 +221,5 @@ func (m *Module) EmojisGETHandler(c *gin.Context) {
 		return
 	}
 
-	if resp.LinkHeader != "" {
-		c.Header("Link", resp.LinkHeader)
-	}
-
-	apiutil.JSON(c, http.StatusOK, resp.Items)
+	apiutil.JSONPage(c, resp)
 }
@@ -35,6 +35,9 @@ import (
 //
 // Also cleans up unused headers + avatars from the media cache and prunes orphaned items from storage.
 //
+// The cleanup is performed before the request completes, and a report of what was cleaned up is returned.
+// If `dry_run` is true, nothing is removed, and the report shows what would have been cleaned up instead.
+//
 //	---
 //	tags:
 //	- admin
@@ -49,13 +52,13 @@ import (
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- admin
+//		- admin:write
 //
 //	responses:
 //		'200':
-//			description: >-
-//				Echos the number of days requested.
-//				The cleanup is performed asynchronously after the request completes.
+//			description: Report of the media cleanup.
+//			schema:
+//				"$ref": "#/definitions/adminMediaCleanupReport"
 //		'400':
 //			description: bad request
 //		'401':
@@ -81,6 +84,11 @@ func (m *Module) MediaCleanupPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeAdminWrite); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	form := &apimodel.MediaCleanupRequest{}
 	if err := c.ShouldBind(form); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
@@ -97,10 +105,78 @@ func (m *Module) MediaCleanupPOSTHandler(c *gin.Context) {
 		remoteCacheDays = 0
 	}
 
-	if errWithCode := m.processor.Admin().MediaPrune(c.Request.Context(), remoteCacheDays); errWithCode != nil {
+	report, errWithCode := m.processor.Admin().MediaPrune(c.Request.Context(), remoteCacheDays, form.DryRun)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, report)
+}
+
+// MediaCleanupGETHandler swagger:operation GET /api/v1/admin/media_cleanup mediaCleanupGet
+//
+// View the report of the most recent media cleanup.
+//
+// This includes both scheduled cleanups and those triggered through the API,
+// since the server was last started. If no cleanup has run yet, 404 is returned.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:read
+//
+//	responses:
+//		'200':
+//			description: Report of the most recent media cleanup.
+//			schema:
+//				"$ref": "#/definitions/adminMediaCleanupReport"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) MediaCleanupGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := authed.RequireScope(oauth.ScopeAdminRead); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	report, errWithCode := m.processor.Admin().MediaCleanupReportGet()
+	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, remoteCacheDays)
+	apiutil.JSON(c, http.StatusOK, report)
 }
@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// parseMetricsPeriod parses the start_at and end_at
+// values of a measures or dimensions request, which
+// may be given either as dates or as full datetimes.
+func parseMetricsPeriod(startAt string, endAt string) (time.Time, time.Time, gtserror.WithCode) {
+	start, errWithCode := parseMetricsTime("start_at", startAt)
+	if errWithCode != nil {
+		return time.Time{}, time.Time{}, errWithCode
+	}
+
+	end, errWithCode := parseMetricsTime("end_at", endAt)
+	if errWithCode != nil {
+		return time.Time{}, time.Time{}, errWithCode
+	}
+
+	return start, end, nil
+}
+
+func parseMetricsTime(key string, value string) (time.Time, gtserror.WithCode) {
+	if value == "" {
+		text := key + " must be set"
+		return time.Time{}, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	text := fmt.Sprintf("%s could not be parsed as a date: %s", key, value)
+	return time.Time{}, gtserror.NewErrorBadRequest(errors.New(text), text)
+}
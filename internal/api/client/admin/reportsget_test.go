@@ -518,7 +518,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "sensitive": false
           }
         ],
         "mentions": [],
@@ -739,7 +740,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "sensitive": false
           }
         ],
         "mentions": [],
@@ -960,7 +962,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
               }
             },
             "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+            "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+            "sensitive": false
           }
         ],
         "mentions": [],
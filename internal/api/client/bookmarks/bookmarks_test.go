@@ -275,6 +275,52 @@ func (suite *BookmarkTestSuite) TestGetBookmarksMultiplePaging() {
 	suite.Equal(`<http://localhost:8080/api/v1/bookmarks?limit=10&max_id=01F8MHD2QCZSZ6WQS2ATVPEYJ9>; rel="next", <http://localhost:8080/api/v1/bookmarks?limit=10&min_id=01GSZPGHY3ACEN11D512V6MR0M>; rel="prev"`, linkHeader)
 }
 
+func (suite *BookmarkTestSuite) TestGetBookmarksMultiplePagingUp() {
+	testAccount := suite.testAccounts["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+	testUser := suite.testUsers["local_account_1"]
+
+	// Add a few extra bookmarks for this account.
+	ctx := context.Background()
+	for _, b := range []*gtsmodel.StatusBookmark{
+		{
+			ID:              "01GSZPDQYE9WZ26T501KMM876V", // oldest
+			AccountID:       testAccount.ID,
+			StatusID:        suite.testStatuses["admin_account_status_2"].ID,
+			TargetAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			ID:              "01GSZPGHY3ACEN11D512V6MR0M",
+			AccountID:       testAccount.ID,
+			StatusID:        suite.testStatuses["admin_account_status_3"].ID,
+			TargetAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			ID:              "01GSZPGY4ZSHNV0PR3HSBB1DDV", // newest
+			AccountID:       testAccount.ID,
+			StatusID:        suite.testStatuses["admin_account_status_4"].ID,
+			TargetAccountID: suite.testAccounts["admin_account"].ID,
+		},
+	} {
+		if err := suite.db.Put(ctx, b); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Page up from the oldest bookmark with a limit of 2: we
+	// should get the 2 bookmarks *immediately* newer than it,
+	// still sorted newest first, not the 2 newest overall.
+	statuses, linkHeader, err := suite.getBookmarks(testAccount, testToken, testUser, http.StatusOK, "", "01F8MHD2QCZSZ6WQS2ATVPEYJ9", 2)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(statuses, 2)
+	suite.Equal(suite.testStatuses["admin_account_status_3"].ID, statuses[0].ID)
+	suite.Equal(suite.testStatuses["admin_account_status_2"].ID, statuses[1].ID)
+	suite.Equal(`<http://localhost:8080/api/v1/bookmarks?limit=2&min_id=01GSZPGHY3ACEN11D512V6MR0M>; rel="next", <http://localhost:8080/api/v1/bookmarks?limit=2&max_id=01GSZPDQYE9WZ26T501KMM876V>; rel="prev"`, linkHeader)
+}
+
 func (suite *BookmarkTestSuite) TestGetBookmarksNone() {
 	testAccount := suite.testAccounts["local_account_1"]
 	testToken := suite.testTokens["local_account_1"]
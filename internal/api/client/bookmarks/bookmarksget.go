@@ -18,14 +18,11 @@
 package bookmarks
 
 import (
-	"fmt"
-	"net/http"
-	"strconv"
-
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 const (
@@ -40,7 +37,14 @@ const (
 
 // BookmarksGETHandler swagger:operation GET /api/v1/bookmarks bookmarksGet
 //
-// Get an array of statuses bookmarked in the instance
+// Get an array of statuses bookmarked by the requesting account.
+//
+// The next and previous queries can be parsed from the returned Link header.
+// Example:
+//
+// ```
+// <https://example.org/api/v1/bookmarks?limit=30&max_id=01FC0SKA48HNSVR6YKZCQGS2V8>; rel="next", <https://example.org/api/v1/bookmarks?limit=30&min_id=01FC0SKW5JK2Q4EVAV2B462YY0>; rel="prev"
+// ````
 //
 //	---
 //	tags:
@@ -49,6 +53,43 @@ const (
 //	produces:
 //	- application/json
 //
+//	parameters:
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only bookmarked statuses *OLDER* than the given max ID.
+//			The status with the specified ID will not be included in the response.
+//			NOTE: the ID is of the internal bookmark, NOT any of the returned statuses.
+//		in: query
+//		required: false
+//	-
+//		name: since_id
+//		type: string
+//		description: >-
+//			Return only bookmarked statuses *NEWER* than the given since ID.
+//			The status with the specified ID will not be included in the response.
+//			NOTE: the ID is of the internal bookmark, NOT any of the returned statuses.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only bookmarked statuses *IMMEDIATELY NEWER* than the given min ID.
+//			The status with the specified ID will not be included in the response.
+//			NOTE: the ID is of the internal bookmark, NOT any of the returned statuses.
+//		in: query
+//		required: false
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of bookmarked statuses to return.
+//		default: 30
+//		minimum: 1
+//		maximum: 80
+//		in: query
+//		required: false
+//
 //	security:
 //	- OAuth2 Bearer:
 //		- read:bookmarks
@@ -64,6 +105,8 @@ const (
 //				Link:
 //					type: string
 //					description: Links to the next and previous queries.
+//		'400':
+//			description: bad request
 //		'401':
 //			description: unauthorized
 //		'406':
@@ -77,49 +120,35 @@ func (m *Module) BookmarksGETHandler(c *gin.Context) {
 		return
 	}
 
-	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+	if errWithCode := authed.RequireScope(oauth.ScopeReadBookmarks); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	limit := 30
-	limitString := c.Query(LimitKey)
-	if limitString != "" {
-		i, err := strconv.ParseInt(limitString, 10, 64)
-		if err != nil {
-			err := fmt.Errorf("error parsing %s: %s", LimitKey, err)
-			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
-			return
-		}
-		limit = int(i)
-	}
-
-	maxID := ""
-	maxIDString := c.Query(MaxIDKey)
-	if maxIDString != "" {
-		maxID = maxIDString
-	}
-
-	minID := ""
-	minIDString := c.Query(MinIDKey)
-	if minIDString != "" {
-		minID = minIDString
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
 	}
 
-	resp, errWithCode := m.processor.Account().BookmarksGet(c.Request.Context(), authed.Account, limit, maxID, minID)
+	page, errWithCode := paging.ParseIDPage(c,
+		1,  // min limit
+		80, // max limit
+		30, // default limit
+	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
+	resp, errWithCode := m.processor.Account().BookmarksGet(
+		c.Request.Context(),
+		authed.Account,
+		page,
+	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	if resp.LinkHeader != "" {
-		c.Header("Link", resp.LinkHeader)
-	}
-
-	apiutil.JSON(c, http.StatusOK, resp.Items)
+	apiutil.JSONPage(c, resp)
 }
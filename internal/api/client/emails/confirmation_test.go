@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emails_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/emails"
+)
+
+type ConfirmationTestSuite struct {
+	EmailsStandardTestSuite
+}
+
+func (suite *ConfirmationTestSuite) TestConfirmationResend() {
+	// Token for the as yet unconfirmed user.
+	token := *suite.testTokens["local_account_1"]
+	token.UserID = suite.testUsers["unconfirmed_account"].ID
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(
+		recorder,
+		&token,
+		"api"+emails.ConfirmationsPath,
+		[]byte(`{"email":"weed_lord421@example.org"}`),
+		"application/json",
+	)
+
+	suite.emailsModule.ConfirmationPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	user, err := suite.db.GetUserByID(context.Background(), token.UserID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("weed_lord421@example.org", user.UnconfirmedEmail)
+	suite.Contains(suite.sentEmails["weed_lord421@example.org"], user.ConfirmationToken)
+}
+
+func (suite *ConfirmationTestSuite) TestConfirmationResendBadEmail() {
+	token := *suite.testTokens["local_account_1"]
+	token.UserID = suite.testUsers["unconfirmed_account"].ID
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(
+		recorder,
+		&token,
+		"api"+emails.ConfirmationsPath,
+		[]byte(`{"email":"not an email"}`),
+		"application/json",
+	)
+
+	suite.emailsModule.ConfirmationPOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *ConfirmationTestSuite) TestConfirmationResendConfirmed() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(
+		recorder,
+		suite.testTokens["local_account_1"],
+		"api"+emails.ConfirmationsPath,
+		[]byte(`{}`),
+		"application/json",
+	)
+
+	suite.emailsModule.ConfirmationPOSTHandler(ctx)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Empty(suite.sentEmails)
+}
+
+func TestConfirmationTestSuite(t *testing.T) {
+	suite.Run(t, &ConfirmationTestSuite{})
+}
@@ -18,6 +18,7 @@
 package favourites_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -71,6 +73,75 @@ func (suite *FavouritesTestSuite) TestGetFavourites() {
 	assert.Equal(suite.T(), "01F8MH75CBF9JFX4ZAD54N0W0R", favs[len(favs)-1].ID)
 }
 
+func (suite *FavouritesTestSuite) getFavourites(query string) ([]model.Status, string) {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_2"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s?%s", favourites.BasePath, query), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.favModule.FavouritesGETHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	favs := []model.Status{}
+	if err := json.Unmarshal(b, &favs); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return favs, result.Header.Get("Link")
+}
+
+func (suite *FavouritesTestSuite) TestGetFavouritesPaging() {
+	// Page down from the newest fave.
+	favs, link := suite.getFavourites("limit=2")
+	suite.Len(favs, 2)
+	suite.Equal("01F8MHCP5P2NWYQ416SBA0XSEV", favs[0].ID)
+	suite.Equal("01F8MHBQCBTDKN6X5VHGMMN4MA", favs[1].ID)
+	suite.Equal(`<http://localhost:8080/api/v1/favourites?limit=2&max_id=01GM43AKBMN4YNXQ1HZHVC1SGB>; rel="next", <http://localhost:8080/api/v1/favourites?limit=2&min_id=01GM43CC47DRPNZZ7BD04BS1YZ>; rel="prev"`, link)
+
+	// Page up from the oldest fave; we should get
+	// the faves immediately newer, newest first.
+	favs, _ = suite.getFavourites("limit=2&min_id=01F8MHD2QCZSZ6WQS2ATVPEYJ9")
+	suite.Len(favs, 2)
+	suite.Equal("01F8MHBQCBTDKN6X5VHGMMN4MA", favs[0].ID)
+	suite.Equal("01F8MHAAY43M6RJ473VQFCVH37", favs[1].ID)
+}
+
+func (suite *FavouritesTestSuite) TestGetFavouritesSkipInvisible() {
+	// local_account_2 blocks local_account_1,
+	// so its faved statuses are no longer visible.
+	if err := suite.db.PutBlock(context.Background(), &gtsmodel.Block{
+		ID:              "01HQBQ5Y3S4KQ7X0N3Y9C1W2JD",
+		URI:             "http://localhost:8080/users/1happyturtle/blocks/01HQBQ5Y3S4KQ7X0N3Y9C1W2JD",
+		AccountID:       suite.testAccounts["local_account_2"].ID,
+		TargetAccountID: suite.testAccounts["local_account_1"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	favs, link := suite.getFavourites("limit=80")
+	suite.Len(favs, 2)
+	suite.Equal("01F8MHAAY43M6RJ473VQFCVH37", favs[0].ID)
+	suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", favs[1].ID)
+
+	// Paging should still be based on all faves.
+	suite.Equal(`<http://localhost:8080/api/v1/favourites?limit=80&max_id=01F8MHD2QCZSZ6WQS2ATVPEYJ9>; rel="next", <http://localhost:8080/api/v1/favourites?limit=80&min_id=01GM43CC47DRPNZZ7BD04BS1YZ>; rel="prev"`, link)
+}
+
 func TestStatusGetTestSuite(t *testing.T) {
 	suite.Run(t, new(FavouritesTestSuite))
 }
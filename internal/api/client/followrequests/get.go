@@ -18,8 +18,6 @@
 package followrequests
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -29,7 +27,8 @@ import (
 
 // FollowRequestGETHandler swagger:operation GET /api/v1/follow_requests getFollowRequests
 //
-// Get an array of accounts that have requested to follow you.
+// Get an array of accounts that have requested to follow you,
+// along with when each request was made and any note sent with it.
 //
 // The next and previous queries can be parsed from the returned Link header.
 // Example:
@@ -96,7 +95,7 @@ import (
 //			schema:
 //				type: array
 //				items:
-//					"$ref": "#/definitions/account"
+//					"$ref": "#/definitions/followRequestAccount"
 //		'400':
 //			description: bad request
 //		'401':
@@ -114,6 +113,11 @@ func (m *Module) FollowRequestGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeReadFollows); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -135,9 +139,5 @@ func (m *Module) FollowRequestGETHandler(c *gin.Context) {
 		return
 	}
 
-	if resp.LinkHeader != "" {
-		c.Header("Link", resp.LinkHeader)
-	}
-
-	apiutil.JSON(c, http.StatusOK, resp.Items)
+	apiutil.JSONPage(c, resp)
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 	"github.com/tomnomnom/linkheader"
 )
 
@@ -50,13 +51,15 @@ func (suite *GetTestSuite) TestGet() {
 	targetAccount := suite.testAccounts["local_account_1"]
 
 	// put a follow request in the database
+	createdAt := testrig.TimeMustParse("2021-10-15T12:00:00Z")
 	fr := &gtsmodel.FollowRequest{
 		ID:              "01FJ1S8DX3STJJ6CEYPMZ1M0R3",
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
 		URI:             fmt.Sprintf("%s/follow/01FJ1S8DX3STJJ6CEYPMZ1M0R3", requestingAccount.URI),
 		AccountID:       requestingAccount.ID,
 		TargetAccountID: targetAccount.ID,
+		Note:            "hi, we met at the conference last week!",
 	}
 
 	err := suite.db.Put(context.Background(), fr)
@@ -102,7 +105,9 @@ func (suite *GetTestSuite) TestGet() {
     "statuses_count": 1,
     "last_status_at": "2023-11-02T10:44:25.000Z",
     "emojis": [],
-    "fields": []
+    "fields": [],
+    "follow_requested_at": "2021-10-15T12:00:00.000Z",
+    "follow_request_note": "hi, we met at the conference last week!"
   }
 ]`, dst.String())
 }
@@ -180,14 +185,14 @@ func (suite *GetTestSuite) testGetPage(limit int, direction string) {
 	switch direction {
 	case "backward":
 		// Set the starting query to page backward from newest.
-		acc := expectAccounts[0].(*model.Account)
+		acc := expectAccounts[0].(*model.FollowRequestAccount)
 		newest, _ := suite.db.GetFollowRequest(ctx, acc.ID, requestingAccount.ID)
 		expectAccounts = expectAccounts[1:]
 		query = fmt.Sprintf("limit=%d&max_id=%s", limit, newest.ID)
 
 	case "forward":
 		// Set the starting query to page forward from the oldest.
-		acc := expectAccounts[len(expectAccounts)-1].(*model.Account)
+		acc := expectAccounts[len(expectAccounts)-1].(*model.FollowRequestAccount)
 		oldest, _ := suite.db.GetFollowRequest(ctx, acc.ID, requestingAccount.ID)
 		expectAccounts = expectAccounts[:len(expectAccounts)-1]
 		query = fmt.Sprintf("limit=%d&min_id=%s", limit, oldest.ID)
@@ -264,7 +269,7 @@ func (suite *GetTestSuite) testGetPage(limit int, direction string) {
 			iface := expect(expectAccounts)
 
 			// Check that expected account matches received.
-			expectAccID := iface.(*model.Account).ID
+			expectAccID := iface.(*model.FollowRequestAccount).ID
 			receivdAccID := accounts[i].ID
 			suite.Equal(expectAccID, receivdAccID, "unexpected account at position in response on page=%d", p)
 
@@ -90,7 +90,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
   ],
   "registrations": true,
   "approval_required": true,
-  "invites_enabled": false,
+  "invites_enabled": true,
   "configuration": {
     "statuses": {
       "max_characters": 5000,
@@ -211,7 +211,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
   ],
   "registrations": true,
   "approval_required": true,
-  "invites_enabled": false,
+  "invites_enabled": true,
   "configuration": {
     "statuses": {
       "max_characters": 5000,
@@ -332,7 +332,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
   ],
   "registrations": true,
   "approval_required": true,
-  "invites_enabled": false,
+  "invites_enabled": true,
   "configuration": {
     "statuses": {
       "max_characters": 5000,
@@ -504,7 +504,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
   ],
   "registrations": true,
   "approval_required": true,
-  "invites_enabled": false,
+  "invites_enabled": true,
   "configuration": {
     "statuses": {
       "max_characters": 5000,
@@ -647,7 +647,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
   ],
   "registrations": true,
   "approval_required": true,
-  "invites_enabled": false,
+  "invites_enabled": true,
   "configuration": {
     "statuses": {
       "max_characters": 5000,
@@ -805,7 +805,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
   ],
   "registrations": true,
   "approval_required": true,
-  "invites_enabled": false,
+  "invites_enabled": true,
   "configuration": {
     "statuses": {
       "max_characters": 5000,
@@ -24,7 +24,6 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
-	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -49,10 +48,24 @@ import (
 //		description: Last status ID read on the home timeline.
 //		in: formData
 //	-
+//		name: home[version]
+//		type: integer
+//		description: >-
+//			Version of the home marker last seen by the client. If this is
+//			older than the stored version, 409 will be returned.
+//		in: formData
+//	-
 //		name: notifications[last_read_id]
 //		type: string
 //		description: Last notification ID read on the notifications timeline.
 //		in: formData
+//	-
+//		name: notifications[version]
+//		type: integer
+//		description: >-
+//			Version of the notifications marker last seen by the client. If this is
+//			older than the stored version, 409 will be returned.
+//		in: formData
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -68,7 +81,7 @@ import (
 //		'401':
 //			description: unauthorized
 //		'409':
-//			description: conflict (when two clients try to update the same timeline at the same time)
+//			description: conflict (when two clients try to update the same timeline at the same time, or the given version is outdated)
 //		'500':
 //			description: internal server error
 func (m *Module) MarkersPOSTHandler(c *gin.Context) {
@@ -78,29 +91,18 @@ func (m *Module) MarkersPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeWriteStatuses); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	form := &apimodel.MarkerPostRequest{}
 	if err := c.ShouldBind(form); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
 
-	markers := make([]*gtsmodel.Marker, 0, apimodel.MarkerNameNumValues)
-	if homeLastReadID := form.HomeLastReadID(); homeLastReadID != "" {
-		markers = append(markers, &gtsmodel.Marker{
-			AccountID:  authed.Account.ID,
-			Name:       gtsmodel.MarkerNameHome,
-			LastReadID: homeLastReadID,
-		})
-	}
-	if notificationsLastReadID := form.NotificationsLastReadID(); notificationsLastReadID != "" {
-		markers = append(markers, &gtsmodel.Marker{
-			AccountID:  authed.Account.ID,
-			Name:       gtsmodel.MarkerNameNotifications,
-			LastReadID: notificationsLastReadID,
-		})
-	}
-
-	marker, errWithCode := m.processor.Markers().Update(c.Request.Context(), markers)
+	marker, errWithCode := m.processor.Markers().Update(c.Request.Context(), authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
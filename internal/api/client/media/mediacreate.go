@@ -34,6 +34,13 @@ import (
 //
 // Upload a new media attachment.
 //
+// With `v1`, the media is fully processed before the request completes.
+//
+// With `v2`, the uploaded file is stored and a partial attachment returned
+// with code 202, without `url` or `preview_url`, while processing continues
+// in the background. Poll `GET /api/v1/media/{id}` until it returns 200 to
+// get the finished attachment.
+//
 //	---
 //	tags:
 //	- media
@@ -84,6 +91,10 @@ import (
 //			description: The newly-created media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'202':
+//			description: The partial media attachment, still being processed (v2 only).
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -108,6 +119,11 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeWriteMedia); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -124,21 +140,28 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if apiVersion == apiutil.APIv2 {
+		// the mastodon v2 media API processes media asynchronously,
+		// returning 202 and a partial attachment without a URL. The
+		// client should call /api/v1/media/:id until it gets the URL.
+		apiAttachment, errWithCode := m.processor.Media().CreateAsync(c.Request.Context(), authed.Account, form)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiAttachment.URL = nil
+		apiAttachment.TextURL = nil
+		apiutil.JSON(c, http.StatusAccepted, apiAttachment)
+		return
+	}
+
 	apiAttachment, errWithCode := m.processor.Media().Create(c.Request.Context(), authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	if apiVersion == apiutil.APIv2 {
-		// the mastodon v2 media API specifies that the URL should be null
-		// and that the client should call /api/v1/media/:id to get the URL
-		//
-		// so even though we have the URL already, remove it now to comply
-		// with the api
-		apiAttachment.URL = nil
-	}
-
 	apiutil.JSON(c, http.StatusOK, apiAttachment)
 }
 
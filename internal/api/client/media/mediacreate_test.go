@@ -269,8 +269,9 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 		panic(err)
 	}
 
-	// check response
-	suite.EqualValues(http.StatusOK, recorder.Code)
+	// check response: the partial attachment is
+	// returned while processing continues async
+	suite.EqualValues(http.StatusAccepted, recorder.Code)
 
 	result := recorder.Result()
 	defer result.Body.Close()
@@ -283,6 +284,42 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 	suite.NoError(err)
 
 	suite.Equal("this is a test image -- a cool background from somewhere", *attachmentReply.Description)
+	suite.NotEmpty(attachmentReply.ID)
+	suite.Nil(attachmentReply.URL)
+	suite.Greater(len(storageKeysAfterRequest), len(storageKeysBeforeRequest)) // original should be stored already
+
+	// wait for processing to finish
+	if !testrig.WaitFor(func() bool {
+		attachment, err := suite.db.GetAttachmentByID(context.Background(), attachmentReply.ID)
+		return err == nil && attachment.Processing == gtsmodel.ProcessingStatusProcessed
+	}) {
+		suite.FailNow("timed out waiting for media to be processed")
+	}
+
+	// now get the finished attachment
+	recorder = httptest.NewRecorder()
+	ctx, _ = testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/media/"+attachmentReply.ID, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(apiutil.APIVersionKey, apiutil.APIv1)
+	ctx.AddParam(mediamodule.IDKey, attachmentReply.ID)
+
+	suite.mediaModule.MediaGETHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result = recorder.Result()
+	defer result.Body.Close()
+	b, err = ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	attachmentReply = &apimodel.Attachment{}
+	err = json.Unmarshal(b, attachmentReply)
+	suite.NoError(err)
+
 	suite.Equal("image", attachmentReply.Type)
 	suite.EqualValues(apimodel.MediaMeta{
 		Original: apimodel.MediaDimensions{
@@ -303,10 +340,45 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 		},
 	}, *attachmentReply.Meta)
 	suite.Equal("LiBzRk#6V[WF_NvzV@WY_3rqV@a$", *attachmentReply.Blurhash)
-	suite.NotEmpty(attachmentReply.ID)
-	suite.Nil(attachmentReply.URL)
+	suite.NotNil(attachmentReply.URL)
 	suite.NotEmpty(attachmentReply.PreviewURL)
-	suite.Equal(len(storageKeysBeforeRequest)+2, len(storageKeysAfterRequest)) // 2 images should be added to storage: the original and the thumbnail
+}
+
+func (suite *MediaCreateTestSuite) TestMediaGetProcessing() {
+	// mark an existing attachment as still processing
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.UpdateAttachment(context.Background(), attachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/media/"+attachment.ID, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(apiutil.APIVersionKey, apiutil.APIv1)
+	ctx.AddParam(mediamodule.IDKey, attachment.ID)
+
+	suite.mediaModule.MediaGETHandler(ctx)
+	suite.EqualValues(http.StatusPartialContent, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	attachmentReply := &apimodel.Attachment{}
+	err = json.Unmarshal(b, attachmentReply)
+	suite.NoError(err)
+
+	suite.Equal(attachment.ID, attachmentReply.ID)
+	suite.Nil(attachmentReply.URL)
 }
 
 func (suite *MediaCreateTestSuite) TestMediaCreateLongDescription() {
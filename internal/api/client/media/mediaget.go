@@ -31,6 +31,10 @@ import (
 //
 // Get a media attachment that you own.
 //
+// If the attachment was uploaded with `POST /api/v2/media` and is still
+// being processed, 206 is returned and `url` is null. If processing failed,
+// 422 is returned, and the media should be uploaded again.
+//
 //	---
 //	tags:
 //	- media
@@ -55,6 +59,10 @@ import (
 //			description: The requested media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'206':
+//			description: The requested media attachment, still being processed.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -63,6 +71,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: processing of the media failed
 //		'500':
 //		   description: internal server error
 func (m *Module) MediaGETHandler(c *gin.Context) {
@@ -80,6 +90,11 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeReadMedia); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -92,11 +107,18 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 		return
 	}
 
-	attachment, errWithCode := m.processor.Media().Get(c.Request.Context(), authed.Account, attachmentID)
+	attachment, processing, errWithCode := m.processor.Media().Get(c.Request.Context(), authed.Account, attachmentID)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
+	if processing {
+		// Still being processed
+		// after a v2 upload.
+		apiutil.JSON(c, http.StatusPartialContent, attachment)
+		return
+	}
+
 	apiutil.JSON(c, http.StatusOK, attachment)
 }
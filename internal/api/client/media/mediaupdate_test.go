@@ -19,6 +19,7 @@ package media_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -193,6 +194,70 @@ func (suite *MediaUpdateTestSuite) TestUpdateImage() {
 	suite.NotEmpty(toUpdate.Thumbnail.URL, attachmentReply.PreviewURL)
 }
 
+func (suite *MediaUpdateTestSuite) TestUpdateImageWhileProcessing() {
+	toUpdate := suite.testAttachments["local_account_1_unattached_1"]
+
+	// mark the attachment as still processing
+	toUpdate.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.UpdateAttachment(context.Background(), toUpdate, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// hold up the media worker, so that processing
+	// can only finish after the update request
+	release := make(chan struct{})
+	suite.state.Workers.Media.Enqueue(func(context.Context) { <-release })
+
+	// queue processing of the attachment
+	if err := suite.mediaManager.ResumeProcessing(context.Background()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// set up the context for the request
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	// create the request
+	buf, w, err := testrig.CreateMultipartFormData("", "", map[string][]string{
+		"id":          {toUpdate.ID},
+		"description": {"new description!"},
+		"focus":       {"-0.1,0.3"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	ctx.Request = httptest.NewRequest(http.MethodPut, fmt.Sprintf("http://localhost:8080/api/v1/media/%s", toUpdate.ID), bytes.NewReader(buf.Bytes())) // the endpoint we're hitting
+	ctx.Request.Header.Set("Content-Type", w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(apiutil.APIVersionKey, apiutil.APIv1)
+	ctx.AddParam(mediamodule.IDKey, toUpdate.ID)
+
+	// do the actual request
+	suite.mediaModule.MediaPUTHandler(ctx)
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	// let processing finish
+	close(release)
+	var attachment *gtsmodel.MediaAttachment
+	if !testrig.WaitFor(func() bool {
+		attachment, err = suite.db.GetAttachmentByID(context.Background(), toUpdate.ID)
+		return err == nil && attachment.Processing == gtsmodel.ProcessingStatusProcessed
+	}) {
+		suite.FailNow("timed out waiting for media to be processed")
+	}
+
+	// the update should have survived processing
+	suite.Equal("new description!", attachment.Description)
+	suite.EqualValues(-0.1, attachment.FileMeta.Focus.X)
+	suite.EqualValues(0.3, attachment.FileMeta.Focus.Y)
+}
+
 func (suite *MediaUpdateTestSuite) TestUpdateImageShortDescription() {
 	// set the min description length
 	config.SetMediaDescriptionMinChars(50)
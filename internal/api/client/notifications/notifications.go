@@ -33,6 +33,18 @@ const (
 	// Use this anywhere you need to know the ID of the notification being queried.
 	BasePathWithID    = BasePath + "/:" + IDKey
 	BasePathWithClear = BasePath + "/clear"
+	// BasePathWithUnreadCount is the path for counting unread notifications.
+	BasePathWithUnreadCount = BasePath + "/unread_count"
+	// PolicyPath is the path for viewing and updating the notification policy.
+	PolicyPath = BasePath + "/policy"
+	// RequestsPath is the path for listing notification requests.
+	RequestsPath = BasePath + "/requests"
+	// RequestsPathWithID is the path for one notification request.
+	RequestsPathWithID = RequestsPath + "/:" + IDKey
+	// RequestsPathWithAccept is the path for accepting one notification request.
+	RequestsPathWithAccept = RequestsPathWithID + "/accept"
+	// RequestsPathWithDismiss is the path for dismissing one notification request.
+	RequestsPathWithDismiss = RequestsPathWithID + "/dismiss"
 
 	// ExcludeTypes is an array specifying notification types to exclude
 	ExcludeTypesKey = "exclude_types[]"
@@ -56,4 +68,11 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.NotificationsGETHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.NotificationGETHandler)
 	attachHandler(http.MethodPost, BasePathWithClear, m.NotificationsClearPOSTHandler)
+	attachHandler(http.MethodGet, BasePathWithUnreadCount, m.NotificationsUnreadCountGETHandler)
+	attachHandler(http.MethodGet, PolicyPath, m.NotificationPolicyGETHandler)
+	attachHandler(http.MethodPatch, PolicyPath, m.NotificationPolicyPATCHHandler)
+	attachHandler(http.MethodGet, RequestsPath, m.NotificationRequestsGETHandler)
+	attachHandler(http.MethodGet, RequestsPathWithID, m.NotificationRequestGETHandler)
+	attachHandler(http.MethodPost, RequestsPathWithAccept, m.NotificationRequestAcceptPOSTHandler)
+	attachHandler(http.MethodPost, RequestsPathWithDismiss, m.NotificationRequestDismissPOSTHandler)
 }
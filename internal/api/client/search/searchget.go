@@ -126,8 +126,8 @@ import (
 //		type: boolean
 //		description: >-
 //			If search type includes accounts, and search query is an arbitrary string, show only accounts
-//			that the requesting account follows. If this is set to `true`, then the GoToSocial instance will
-//			enhance the search by also searching within account notes, not just in usernames and display names.
+//			that the requesting account follows. Otherwise, accounts that the requesting account follows
+//			are ranked first in the first page of results.
 //		default: false
 //		in: query
 //	-
@@ -175,6 +175,11 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeReadSearch); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
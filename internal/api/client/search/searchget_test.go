@@ -876,7 +876,7 @@ func (suite *SearchGetTestSuite) TestSearchAAny() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Len(searchResult.Accounts, 5)
+	suite.Len(searchResult.Accounts, 6)
 	suite.Len(searchResult.Statuses, 6)
 	suite.Len(searchResult.Hashtags, 0)
 }
@@ -999,9 +999,15 @@ func (suite *SearchGetTestSuite) TestSearchAAccounts() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Len(searchResult.Accounts, 5)
+	if !suite.Len(searchResult.Accounts, 6) {
+		suite.FailNow("")
+	}
 	suite.Len(searchResult.Statuses, 0)
 	suite.Len(searchResult.Hashtags, 0)
+
+	// Followed accounts should be ranked first.
+	suite.Equal(suite.testAccounts["local_account_2"].ID, searchResult.Accounts[0].ID)
+	suite.Equal(suite.testAccounts["admin_account"].ID, searchResult.Accounts[1].ID)
 }
 
 func (suite *SearchGetTestSuite) TestSearchAccountsLimit1() {
@@ -1398,7 +1404,7 @@ func (suite *SearchGetTestSuite) TestSearchHashtagV1() {
 		queryType          *string = func() *string { i := "hashtags"; return &i }()
 		following          *bool   = nil
 		expectedHTTPStatus         = http.StatusOK
-		expectedBody               = `{"accounts":[],"statuses":[],"hashtags":[{"name":"welcome","url":"http://localhost:8080/tags/welcome","history":[]}]}`
+		expectedBody               = ""
 	)
 
 	searchResult, err := suite.getSearch(
@@ -1423,6 +1429,16 @@ func (suite *SearchGetTestSuite) TestSearchHashtagV1() {
 	suite.Len(searchResult.Accounts, 0)
 	suite.Len(searchResult.Statuses, 0)
 	suite.Len(searchResult.Hashtags, 1)
+
+	// History is populated with
+	// a week of usage, today first.
+	hashtag, ok := searchResult.Hashtags[0].(map[string]any)
+	if !ok {
+		suite.FailNow("", "expected map, got %T", searchResult.Hashtags[0])
+	}
+	suite.Equal("welcome", hashtag["name"])
+	suite.Equal("http://localhost:8080/tags/welcome", hashtag["url"])
+	suite.Len(hashtag["history"], 7)
 }
 
 func (suite *SearchGetTestSuite) TestSearchHashtagV2() {
@@ -42,6 +42,8 @@ const (
 
 	// RebloggedPath is for seeing who's boosted a given status
 	RebloggedPath = BasePathWithID + "/reblogged_by"
+	// InteractionsPath is for the author of a status to see who's faved and boosted it, and when
+	InteractionsPath = BasePathWithID + "/interactions"
 	// ReblogPath is for boosting/reblogging a given status
 	ReblogPath = BasePathWithID + "/reblog"
 	// UnreblogPath is for undoing a boost/reblog of a given status
@@ -61,9 +63,21 @@ const (
 	PinPath = BasePathWithID + "/pin"
 	// UnpinPath is for undoing a pin and returning a status to the ever-swirling drain of time and entropy
 	UnpinPath = BasePathWithID + "/unpin"
+	// PinsPath is for changing the order of all pinned statuses at once
+	PinsPath = BasePath + "/pins"
 
 	// ContextPath is used for fetching context of posts
 	ContextPath = BasePathWithID + "/context"
+
+	// SourcePath is used for fetching the raw source of a status
+	SourcePath = BasePathWithID + "/source"
+
+	// RefreshPath is used for forcing a refresh of a remote status
+	RefreshPath = BasePathWithID + "/refresh"
+
+	// IdempotencyKeyHeader is the header clients
+	// may set to make status creation retry-safe.
+	IdempotencyKeyHeader = "Idempotency-Key"
 )
 
 type Module struct {
@@ -90,6 +104,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// pin stuff
 	attachHandler(http.MethodPost, PinPath, m.StatusPinPOSTHandler)
 	attachHandler(http.MethodPost, UnpinPath, m.StatusUnpinPOSTHandler)
+	attachHandler(http.MethodPut, PinsPath, m.StatusPinsPUTHandler)
 
 	// mute stuff
 	attachHandler(http.MethodPost, MutePath, m.StatusMutePOSTHandler)
@@ -99,9 +114,16 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, ReblogPath, m.StatusBoostPOSTHandler)
 	attachHandler(http.MethodPost, UnreblogPath, m.StatusUnboostPOSTHandler)
 	attachHandler(http.MethodGet, RebloggedPath, m.StatusBoostedByGETHandler)
+	attachHandler(http.MethodGet, InteractionsPath, m.StatusInteractionsGETHandler)
 	attachHandler(http.MethodPost, BookmarkPath, m.StatusBookmarkPOSTHandler)
 	attachHandler(http.MethodPost, UnbookmarkPath, m.StatusUnbookmarkPOSTHandler)
 
 	// context / status thread
 	attachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+
+	// source of status
+	attachHandler(http.MethodGet, SourcePath, m.StatusSourceGETHandler)
+
+	// force refresh of remote status
+	attachHandler(http.MethodPost, RefreshPath, m.StatusRefreshPOSTHandler)
 }
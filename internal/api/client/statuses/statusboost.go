@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -34,10 +35,19 @@ import (
 // If the target status is rebloggable/boostable, it will be shared with your followers.
 // This is equivalent to an ActivityPub 'Announce' activity.
 //
+// The visibility of the boost can be set with the `visibility` parameter. If it is not
+// set, the default boost visibility of the requesting account is used, falling back
+// to the visibility of the target status. Statuses that are not public or unlisted
+// can only be boosted with the visibility of the target status.
+//
 //	---
 //	tags:
 //	- statuses
 //
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
 //	produces:
 //	- application/json
 //
@@ -48,6 +58,15 @@ import (
 //		description: Target status ID.
 //		in: path
 //		required: true
+//	-
+//		name: visibility
+//		type: string
+//		description: Visibility of the boost.
+//		enum:
+//			- public
+//			- unlisted
+//			- private
+//		in: formData
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -69,6 +88,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: status cannot be boosted with the requested visibility
 //		'500':
 //			description: internal server error
 func (m *Module) StatusBoostPOSTHandler(c *gin.Context) {
@@ -78,6 +99,11 @@ func (m *Module) StatusBoostPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeWriteStatuses); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -90,7 +116,19 @@ func (m *Module) StatusBoostPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	apiStatus, errWithCode := m.processor.Status().BoostCreate(c.Request.Context(), authed.Account, authed.Application, targetStatusID)
+	form := &apimodel.StatusBoostRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiStatus, errWithCode := m.processor.Status().BoostCreate(
+		c.Request.Context(),
+		authed.Account,
+		authed.Application,
+		targetStatusID,
+		form.Visibility,
+	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -39,6 +39,9 @@ import (
 // The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
 // The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
 //
+// Clients may set an Idempotency-Key header to make retries safe: repeating a request with the same
+// key within an hour returns the status created by the first request, rather than a duplicate.
+//
 //	---
 //	tags:
 //	- statuses
@@ -79,6 +82,11 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeWriteStatuses); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -90,6 +98,10 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// Clients may set this to make
+	// retries of this request safe.
+	form.IdempotencyKey = c.GetHeader(IdempotencyKeyHeader)
+
 	// DO NOT COMMIT THIS UNCOMMENTED, IT WILL CAUSE MASS CHAOS.
 	// this is being left in as an ode to kim's shitposting.
 	//
@@ -99,7 +111,7 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	// }
 	// form.Status += "\n\nsent from " + user + "'s iphone\n"
 
-	if err := validateNormalizeCreateStatus(form); err != nil {
+	if err := validateNormalizeCreateStatus(form, authed.Account.MaxStatusChars()); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
@@ -118,12 +130,17 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, apiStatus)
 }
 
+// maxIdempotencyKeyLength is the maximum
+// permitted length of an Idempotency-Key.
+const maxIdempotencyKeyLength = 256
+
 // validateNormalizeCreateStatus checks the form
 // for disallowed combinations of attachments and
-// overlength inputs.
+// overlength inputs, given the posting account's
+// max permitted characters.
 //
 // Side effect: normalizes the post's language tag.
-func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) error {
+func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm, maxChars int) error {
 	hasStatus := form.Status != ""
 	hasMedia := len(form.MediaIDs) != 0
 	hasPoll := form.Poll != nil
@@ -136,7 +153,10 @@ func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) erro
 		return errors.New("can't post media + poll in same status")
 	}
 
-	maxChars := config.GetStatusesMaxChars()
+	if len(form.IdempotencyKey) > maxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key too long, limit is %d characters", maxIdempotencyKeyLength)
+	}
+
 	if length := len([]rune(form.Status)) + len([]rune(form.SpoilerText)); length > maxChars {
 		return fmt.Errorf("status too long, %d characters provided (including spoiler/content warning) but limit is %d", length, maxChars)
 	}
@@ -152,6 +172,18 @@ func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) erro
 		}
 	}
 
+	if form.ContentType != "" {
+		if err := validate.StatusContentType(string(form.ContentType)); err != nil {
+			return err
+		}
+	}
+
+	if form.InteractionPolicy != nil {
+		if err := validate.InteractionPolicy(form.InteractionPolicy); err != nil {
+			return err
+		}
+	}
+
 	if form.Language != "" {
 		language, err := validate.Language(form.Language)
 		if err != nil {
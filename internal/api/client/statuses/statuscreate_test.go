@@ -144,6 +144,153 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdown() {
 	suite.Equal(statusMarkdownExpected, statusReply.Content)
 }
 
+func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdownContentType() {
+	// Account 1 default content type is plain, but
+	// we override it for this status only via the form.
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {statusMarkdown},
+		"visibility":   {string(apimodel.VisibilityPublic)},
+		"content_type": {string(apimodel.StatusContentTypeMarkdown)},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	statusReply := &apimodel.Status{}
+	err = json.Unmarshal(b, statusReply)
+	suite.NoError(err)
+
+	suite.Equal(statusMarkdownExpected, statusReply.Content)
+
+	// Content type should be stored on the status.
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), statusReply.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(string(apimodel.StatusContentTypeMarkdown), dbStatus.ContentType)
+	suite.Equal(statusMarkdown, dbStatus.Text)
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusUnknownContentType() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {"this should not be posted"},
+		"content_type": {"text/html"},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusBadRequest, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	suite.Equal(`{"error":"Bad Request: status content type 'text/html' was not recognized, valid options are 'text/plain', 'text/markdown'"}`, string(b))
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusAccountMaxChars() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// Lower this account's limit below the instance setting.
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["local_account_1"]
+	account.StatusesMaxChars = 10
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, account)
+
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status": {"this should not be posted"},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusBadRequest, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	suite.Equal(`{"error":"Bad Request: status too long, 25 characters provided (including spoiler/content warning) but limit is 10"}`, string(b))
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewStatusIdempotencyKey() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	post := func() *apimodel.Status {
+		recorder := httptest.NewRecorder()
+		ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+		ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+		ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+		ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+		ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+		ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil)
+		ctx.Request.Header.Set("accept", "application/json")
+		ctx.Request.Header.Set(statuses.IdempotencyKeyHeader, "some-unique-key")
+		ctx.Request.Form = url.Values{
+			"status":     {"this should only be posted once"},
+			"visibility": {string(apimodel.VisibilityPublic)},
+		}
+		suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+		suite.EqualValues(http.StatusOK, recorder.Code)
+
+		result := recorder.Result()
+		defer result.Body.Close()
+		b, err := ioutil.ReadAll(result.Body)
+		suite.NoError(err)
+
+		apiStatus := &apimodel.Status{}
+		err = json.Unmarshal(b, apiStatus)
+		suite.NoError(err)
+
+		return apiStatus
+	}
+
+	// Retry should get the original status back.
+	first := post()
+	second := post()
+	suite.NotEmpty(first.ID)
+	suite.Equal(first.ID, second.ID)
+}
+
 // mention an account that is not yet known to the instance -- it should be looked up and put in the db
 func (suite *StatusCreateTestSuite) TestMentionUnknownAccount() {
 	// first remove remote account 1 from the database so it gets looked up again
@@ -19,18 +19,25 @@ package statuses
 
 import (
 	"errors"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // StatusFavedByGETHandler swagger:operation GET /api/v1/statuses/{id}/favourited_by statusFavedBy
 //
 // View accounts that have faved/starred/liked the target status.
 //
+// The next and previous queries can be parsed from the returned Link header.
+// Example:
+//
+// ```
+// <https://example.org/api/v1/statuses/01FC0SKA48HNSVR6YKZCQGS2V8/favourited_by?limit=40&max_id=01FC0SKW5JK2Q4EVAV2B462YY0>; rel="next", <https://example.org/api/v1/statuses/01FC0SKA48HNSVR6YKZCQGS2V8/favourited_by?limit=40&min_id=01FC0SKTBJK2Q4EVAV2B462YY0>; rel="prev"
+// ````
+//
 //	---
 //	tags:
 //	- statuses
@@ -45,6 +52,35 @@ import (
 //		description: Target status ID.
 //		in: path
 //		required: true
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of accounts to return.
+//		default: 40
+//		minimum: 1
+//		maximum: 80
+//		in: query
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only accounts which faved the status *OLDER* than the given fave ID.
+//			The account with the corresponding fave ID will not be included in the response.
+//		in: query
+//	-
+//		name: since_id
+//		type: string
+//		description: >-
+//			Return only accounts which faved the status *NEWER* than the given fave ID.
+//			The account with the corresponding fave ID will not be included in the response.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only accounts which faved the status *IMMEDIATELY NEWER* than the given fave ID.
+//			The account with the corresponding fave ID will not be included in the response.
+//		in: query
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -52,6 +88,10 @@ import (
 //
 //	responses:
 //		'200':
+//			headers:
+//				Link:
+//					type: string
+//					description: Links to the next and previous queries.
 //			schema:
 //				type: array
 //				items:
@@ -75,6 +115,11 @@ func (m *Module) StatusFavedByGETHandler(c *gin.Context) {
 		return
 	}
 
+	if errWithCode := authed.RequireScope(oauth.ScopeReadAccounts); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -87,11 +132,21 @@ func (m *Module) StatusFavedByGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiAccounts, errWithCode := m.processor.Status().FavedBy(c.Request.Context(), authed.Account, targetStatusID)
+	page, errWithCode := paging.ParseIDPage(c,
+		1,  // min limit
+		80, // max limit
+		40, // default limit
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Status().FavedBy(c.Request.Context(), authed.Account, targetStatusID, page)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	c.JSON(http.StatusOK, apiAccounts)
+	apiutil.JSONPage(c, resp)
 }
@@ -18,6 +18,7 @@
 package statuses_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -31,6 +32,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -82,6 +84,127 @@ func (suite *StatusFavedByTestSuite) TestGetFavedBy() {
 	assert.Equal(suite.T(), "the_mighty_zork", accts[0].Username)
 }
 
+func (suite *StatusFavedByTestSuite) TestGetFavedByPaging() {
+	t := suite.testTokens["local_account_2"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	targetStatus := suite.testStatuses["admin_account_status_1"] // this status is faved by local_account_1
+
+	// Have the admin account fave
+	// the status too, more recently.
+	fave := &gtsmodel.StatusFave{
+		ID:              "01HQKQ7SE8YMAZNQ45Z9RFGM1D",
+		AccountID:       suite.testAccounts["admin_account"].ID,
+		TargetAccountID: targetStatus.AccountID,
+		StatusID:        targetStatus.ID,
+		URI:             "http://localhost:8080/users/admin/liked/01HQKQ7SE8YMAZNQ45Z9RFGM1D",
+	}
+	if err := suite.db.PutStatusFave(context.Background(), fave); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	path := strings.Replace(statuses.FavouritedPath, ":id", targetStatus.ID, 1)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_2"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_2"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_2"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s?limit=1", path), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	suite.statusModule.StatusFavedByGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accts := []apimodel.Account{}
+	if err := json.Unmarshal(b, &accts); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Newest fave should be returned first.
+	suite.Len(accts, 1)
+	suite.Equal("admin", accts[0].Username)
+	suite.Equal(
+		`<http://localhost:8080/api/v1/statuses/`+targetStatus.ID+`/favourited_by?limit=1&max_id=01HQKQ7SE8YMAZNQ45Z9RFGM1D>; rel="next", <http://localhost:8080/api/v1/statuses/`+targetStatus.ID+`/favourited_by?limit=1&min_id=01HQKQ7SE8YMAZNQ45Z9RFGM1D>; rel="prev"`,
+		result.Header.Get("link"),
+	)
+}
+
+func (suite *StatusFavedByTestSuite) TestGetFavedByPagingDeletedMaxID() {
+	t := suite.testTokens["local_account_2"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	targetStatus := suite.testStatuses["admin_account_status_1"] // this status is faved by local_account_1
+
+	// Have the admin account fave
+	// the status too, more recently.
+	fave := &gtsmodel.StatusFave{
+		ID:              "01HQKQ7SE8YMAZNQ45Z9RFGM1D",
+		AccountID:       suite.testAccounts["admin_account"].ID,
+		TargetAccountID: targetStatus.AccountID,
+		StatusID:        targetStatus.ID,
+		URI:             "http://localhost:8080/users/admin/liked/01HQKQ7SE8YMAZNQ45Z9RFGM1D",
+	}
+	if err := suite.db.PutStatusFave(context.Background(), fave); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	path := strings.Replace(statuses.FavouritedPath, ":id", targetStatus.ID, 1)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_2"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_2"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_2"])
+
+	// Page from a fave in between the two
+	// that has since been deleted; this should
+	// carry on from there, not from the top.
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s?limit=1&max_id=01HQKQ7SE8YMAZNQ45Z9RFGM1C", path), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	suite.statusModule.StatusFavedByGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accts := []apimodel.Account{}
+	if err := json.Unmarshal(b, &accts); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(accts, 1)
+	suite.Equal("the_mighty_zork", accts[0].Username)
+}
+
 func TestStatusFavedByTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusFavedByTestSuite))
 }
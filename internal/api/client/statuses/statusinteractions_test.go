@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusInteractionsTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusInteractionsTestSuite) getInteractions(requester string, targetStatusID string) (*httptest.ResponseRecorder, []*apimodel.StatusInteraction) {
+	t := suite.testTokens[requester]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requester])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requester])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s", strings.Replace(statuses.InteractionsPath, ":id", targetStatusID, 1)), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam("id", targetStatusID)
+
+	suite.statusModule.StatusInteractionsGETHandler(ctx)
+
+	if recorder.Code != http.StatusOK {
+		return recorder, nil
+	}
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	interactions := []*apimodel.StatusInteraction{}
+	if err := json.Unmarshal(b, &interactions); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder, interactions
+}
+
+func (suite *StatusInteractionsTestSuite) TestInteractionsAsAuthor() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	recorder, interactions := suite.getInteractions("local_account_1", targetStatus.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// Should have the boost and the
+	// fave by admin, newest first.
+	if !suite.Len(interactions, 2) {
+		suite.FailNow("should have had 2 interactions")
+	}
+
+	suite.Equal(suite.testStatuses["admin_account_status_4"].ID, interactions[0].ID)
+	suite.Equal("reblog", interactions[0].Type)
+	suite.Equal(suite.testAccounts["admin_account"].ID, interactions[0].Account.ID)
+
+	suite.Equal("01F8Q0486ANTDWKG02A7DS1Q24", interactions[1].ID) // admin_account_local_account_1_status_1 fave
+	suite.Equal("favourite", interactions[1].Type)
+	suite.Equal(suite.testAccounts["admin_account"].ID, interactions[1].Account.ID)
+}
+
+func (suite *StatusInteractionsTestSuite) TestInteractionsAsAdmin() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// Admins get no special treatment.
+	recorder, _ := suite.getInteractions("admin_account", targetStatus.ID)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func TestStatusInteractionsTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusInteractionsTestSuite))
+}
@@ -19,6 +19,7 @@ package statuses_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -126,8 +128,10 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
     "url": "http://localhost:8080/@the_mighty_zork",
     "avatar": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg",
     "avatar_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg",
+    "avatar_description": "a green goblin looking nasty",
     "header": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
     "header_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
+    "header_description": "A very old-school screenshot of the original team fortress mod for quake ",
     "followers_count": 2,
     "following_count": 2,
     "statuses_count": 7,
@@ -189,8 +193,10 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
     "url": "http://localhost:8080/@the_mighty_zork",
     "avatar": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg",
     "avatar_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg",
+    "avatar_description": "a green goblin looking nasty",
     "header": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
     "header_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
+    "header_description": "A very old-school screenshot of the original team fortress mod for quake ",
     "followers_count": 2,
     "following_count": 2,
     "statuses_count": 7,
@@ -212,6 +218,50 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
 }`, unmuted)
 }
 
+func (suite *StatusMuteTestSuite) TestMuteUnmuteStatusNoThreadID() {
+	var (
+		ctx          = context.Background()
+		targetStatus = suite.testStatuses["local_account_1_status_1"]
+		path         = fmt.Sprintf("http://localhost:8080/api%s", strings.ReplaceAll(statuses.MutePath, ":id", targetStatus.ID))
+	)
+
+	// Clear the thread ID of the status,
+	// as if it was created before threads
+	// were tracked in the database.
+	targetStatus.ThreadID = ""
+	if err := suite.db.UpdateStatus(ctx, targetStatus, "thread_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Mute the status, ensure `muted` is `true`.
+	code, muted := suite.post(path, suite.statusModule.StatusMutePOSTHandler, targetStatus.ID)
+	suite.Equal(http.StatusOK, code)
+
+	apiStatus := new(apimodel.Status)
+	if err := json.Unmarshal([]byte(muted), apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(apiStatus.Muted)
+
+	// Status should have been given a thread ID.
+	dbStatus, err := suite.db.GetStatusByID(ctx, targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(dbStatus.ThreadID)
+
+	// Unmute the status, ensure `muted` is `false`.
+	path = fmt.Sprintf("http://localhost:8080/api%s", strings.ReplaceAll(statuses.UnmutePath, ":id", targetStatus.ID))
+	code, unmuted := suite.post(path, suite.statusModule.StatusUnmutePOSTHandler, targetStatus.ID)
+	suite.Equal(http.StatusOK, code)
+
+	apiStatus = new(apimodel.Status)
+	if err := json.Unmarshal([]byte(unmuted), apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(apiStatus.Muted)
+}
+
 func TestStatusMuteTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusMuteTestSuite))
 }
@@ -192,6 +192,34 @@ func (suite *StatusPinTestSuite) TestPinStatusTooManyPins() {
 	}
 }
 
+func (suite *StatusPinTestSuite) TestPinStatusConfiguredLimit() {
+	config.SetStatusesMaxPinned(1)
+
+	if _, err := suite.createPin(http.StatusOK, "", suite.testStatuses["local_account_1_status_1"].ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := suite.createPin(
+		http.StatusUnprocessableEntity,
+		`{"error":"Unprocessable Entity: status pin limit exceeded, you've already pinned 1 status(es) out of 1"}`,
+		suite.testStatuses["local_account_1_status_5"].ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *StatusPinTestSuite) TestPinStatusPinningDisabled() {
+	config.SetStatusesMaxPinned(0)
+
+	if _, err := suite.createPin(
+		http.StatusUnprocessableEntity,
+		`{"error":"Unprocessable Entity: pinning statuses is disabled on this instance"}`,
+		suite.testStatuses["local_account_1_status_1"].ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func TestStatusPinTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusPinTestSuite))
 }
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusPinsTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusPinsTestSuite) pin(statusKey string, at time.Time) *gtsmodel.Status {
+	status := &gtsmodel.Status{}
+	*status = *suite.testStatuses[statusKey]
+	status.PinnedAt = at
+
+	if err := suite.db.UpdateStatus(context.Background(), status, "pinned_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+func (suite *StatusPinsTestSuite) reorderPins(
+	expectedHTTPStatus int,
+	statusIDs ...string,
+) []*apimodel.Status {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	form := url.Values{"status_ids[]": statusIDs}
+	ctx.Request = httptest.NewRequest(
+		http.MethodPut,
+		config.GetProtocol()+"://"+config.GetHost()+"/api/"+statuses.PinsPath,
+		strings.NewReader(form.Encode()),
+	)
+	ctx.Request.Header.Set("content-type", "application/x-www-form-urlencoded")
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.statusModule.StatusPinsPUTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Equal(expectedHTTPStatus, recorder.Code) || expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	resp := []*apimodel.Status{}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp
+}
+
+func (suite *StatusPinsTestSuite) TestReorderPins() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	// Pinned status 1 most recently,
+	// so it's currently on top.
+	status5 := suite.pin("local_account_1_status_5", time.Now().Add(-time.Hour))
+	status1 := suite.pin("local_account_1_status_1", time.Now())
+
+	resp := suite.reorderPins(http.StatusOK, status5.ID, status1.ID)
+	if suite.Len(resp, 2) {
+		suite.Equal(status5.ID, resp[0].ID)
+		suite.Equal(status1.ID, resp[1].ID)
+	}
+
+	pinned, err := suite.db.GetAccountPinnedStatuses(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(pinned, 2) {
+		suite.Equal(status5.ID, pinned[0].ID)
+		suite.Equal(status1.ID, pinned[1].ID)
+	}
+}
+
+func (suite *StatusPinsTestSuite) TestReorderPinsMismatch() {
+	status5 := suite.pin("local_account_1_status_5", time.Now().Add(-time.Hour))
+	status1 := suite.pin("local_account_1_status_1", time.Now())
+
+	// Missing one.
+	suite.reorderPins(http.StatusUnprocessableEntity, status5.ID)
+
+	// Duplicate.
+	suite.reorderPins(http.StatusUnprocessableEntity, status1.ID, status1.ID)
+
+	// Not pinned.
+	suite.reorderPins(
+		http.StatusUnprocessableEntity,
+		status5.ID, status1.ID,
+		suite.testStatuses["local_account_1_status_2"].ID,
+	)
+}
+
+func TestStatusPinsTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPinsTestSuite))
+}
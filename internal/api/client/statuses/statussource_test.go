@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusSourceTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusSourceTestSuite) getSource(
+	requester string,
+	targetStatusID string,
+	expectedHTTPStatus int,
+) *apimodel.StatusSource {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[requester])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[requester]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[requester])
+
+	ctx.Request = httptest.NewRequest(http.MethodGet, config.GetProtocol()+"://"+config.GetHost()+"/api/"+statuses.BasePath+"/"+targetStatusID+"/source", nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(statuses.IDKey, targetStatusID)
+
+	suite.statusModule.StatusSourceGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code != expectedHTTPStatus {
+		suite.FailNow("", "expected %d got %d (body %s)", expectedHTTPStatus, recorder.Code, string(b))
+	}
+
+	if expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	source := &apimodel.StatusSource{}
+	if err := json.Unmarshal(b, source); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return source
+}
+
+func (suite *StatusSourceTestSuite) TestGetOwnStatusSource() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	source := suite.getSource("local_account_1", targetStatus.ID, http.StatusOK)
+
+	suite.Equal(targetStatus.ID, source.ID)
+	suite.Equal("hello everyone!", source.Text)
+	suite.Equal("introduction post", source.SpoilerText)
+
+	// No content type stored on this
+	// status, so should fall back to plain.
+	suite.Equal(apimodel.StatusContentTypePlain, source.ContentType)
+}
+
+func (suite *StatusSourceTestSuite) TestGetOwnStatusSourceMarkdown() {
+	targetStatus := &gtsmodel.Status{}
+	*targetStatus = *suite.testStatuses["local_account_1_status_1"]
+	targetStatus.ContentType = string(apimodel.StatusContentTypeMarkdown)
+
+	if err := suite.db.UpdateStatus(
+		context.Background(),
+		targetStatus,
+		"content_type",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	source := suite.getSource("local_account_1", targetStatus.ID, http.StatusOK)
+	suite.Equal(apimodel.StatusContentTypeMarkdown, source.ContentType)
+}
+
+func (suite *StatusSourceTestSuite) TestGetOtherAccountStatusSource() {
+	// Status is visible to local_account_1,
+	// but it's not theirs, so should 404.
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	suite.getSource("local_account_1", targetStatus.ID, http.StatusNotFound)
+}
+
+func TestStatusSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusSourceTestSuite))
+}
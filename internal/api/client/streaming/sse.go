@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package streaming
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	streampkg "github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// handleSSEConn writes messages coming from the processor via
+// the given stream into the response as server-sent events.
+// This function also handles sending `:thump` heartbeat
+// comments into the response to keep it alive when no
+// other activity occurs.
+//
+// This is a blocking function; will return only on write error,
+// when the stream is closed, or when the client goes away.
+func (m *Module) handleSSEConn(c *gin.Context, l *log.Entry, stream *streampkg.Stream) {
+	l.Info("opened server-sent events connection")
+
+	defer func() {
+		stream.Close()
+		l.Info("closed server-sent events connection")
+	}()
+
+	// Request context is canceled
+	// when the client goes away.
+	ctx := c.Request.Context()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// Write initial comment so that
+	// the client knows we're ready.
+	if !writeSSE(c, ":)\n") {
+		return
+	}
+
+	for {
+		// Wrap context with timeout to send a heartbeat.
+		pingctx, cncl := context.WithTimeout(ctx, m.dTicker)
+
+		// Block on receipt of msg.
+		msg, ok := stream.Recv(pingctx)
+
+		// Check if cancel because heartbeat.
+		pinged := (pingctx.Err() != nil)
+		cncl()
+
+		switch {
+		case !ok && ctx.Err() != nil:
+			// Client went away.
+			return
+
+		case !ok && pinged:
+			// Send a keep-alive heartbeat comment.
+			l.Trace("writing server-sent events heartbeat")
+			if !writeSSE(c, ":thump\n") {
+				return
+			}
+			continue
+
+		case !ok:
+			// Stream was closed,
+			// (maybe consumer died).
+			return
+		}
+
+		l.Tracef("writing server-sent event: %+v", msg)
+
+		// Received a new message from the processor.
+		if !writeSSE(c, "event: "+msg.Event+"\ndata: "+msg.Payload+"\n\n") {
+			return
+		}
+	}
+}
+
+// writeSSE writes the given string into the response
+// and flushes it, returning false on write error.
+func writeSSE(c *gin.Context, s string) bool {
+	if _, err := io.WriteString(c.Writer, s); err != nil {
+		log.Debugf(c.Request.Context(), "error writing server-sent event: %v", err)
+		return false
+	}
+	c.Writer.Flush()
+	return true
+}
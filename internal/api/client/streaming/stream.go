@@ -19,7 +19,10 @@ package streaming
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"slices"
+	"sync/atomic"
 	"time"
 
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
@@ -44,9 +47,17 @@ import (
 //
 // As long as the connection is open, various message types will be streamed into it.
 //
-// GoToSocial will ping the connection every 30 seconds to check whether the client is still receiving.
+// Once the connection is open, the client can subscribe to or unsubscribe from additional stream types
+// over the same connection, by sending JSON messages like `{"type":"subscribe","stream":"list","list":"01H3YF48G8B7KTPQFS8D2QBVG8"}`
+// or `{"type":"unsubscribe","stream":"public"}`. Outgoing messages include the `stream` array so that they can be demultiplexed.
+// If a message is rejected, an `error` event is sent back, with a payload like `{"error":"Bad Request: unknown stream type: foo","status":400}`.
 //
-// If the ping fails, or something else goes wrong during transmission, then the connection will be dropped, and the client will be expected to start it again.
+// GoToSocial will ping the connection periodically (every 30 seconds by default) to check whether the client is still receiving.
+//
+// If the ping fails, the client fails to respond to several pings in a row, or something else goes wrong during transmission, then the connection will be dropped, and the client will be expected to start it again.
+//
+// If the request is not a websocket upgrade request, messages will instead be streamed as server-sent events, with periodic `:thump` heartbeat comments.
+// Server-sent events clients can also use Mastodon-style paths to select the stream type, eg., `/api/v1/streaming/public/local`.
 //
 //	---
 //	tags:
@@ -80,8 +91,10 @@ import (
 //			`hashtag:local`: receive local updates for a given hashtag.
 //			`list`: receive updates for a certain list of accounts.
 //			`direct`: receive updates for direct messages.
+//			`user:notification`: receive notifications only.
+//
+//			If not set, the client should subscribe to stream types after the connection is open.
 //		in: query
-//		required: true
 //	-
 //		name: list
 //		type: string
@@ -96,6 +109,22 @@ import (
 //			Name of the tag to subscribe to.
 //			Only used if stream type is 'hashtag' or 'hashtag:local'.
 //		in: query
+//	-
+//		name: types[]
+//		type: array
+//		items:
+//			type: string
+//		description: |-
+//			Types of notifications to stream.
+//			If set, only notifications of these types will be streamed.
+//		in: query
+//	-
+//		name: exclude_types[]
+//		type: array
+//		items:
+//			type: string
+//		description: Types of notifications not to stream.
+//		in: query
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -118,6 +147,7 @@ import (
 //							- hashtag:local
 //							- list
 //							- direct
+//							- user:notification
 //					event:
 //						description: |-
 //							The type of event being received.
@@ -125,12 +155,18 @@ import (
 //							`update`: a new status has been received.
 //							`notification`: a new notification has been received.
 //							`delete`: a status has been deleted.
+//							`status.update`: a status has been edited.
+//							`conversation`: a direct conversation has been updated.
+//							`notifications_merged`: notifications have changed in bulk and should be refetched.
 //							`filters_changed`: not implemented.
 //						type: string
 //						enum:
 //						- update
 //						- notification
 //						- delete
+//						- status.update
+//						- conversation
+//						- notifications_merged
 //						- filters_changed
 //					payload:
 //						description: |-
@@ -142,6 +178,7 @@ import (
 //							If `event` = `update`, then the payload will be a JSON string of a status.
 //							If `event` = `notification`, then the payload will be a JSON string of a notification.
 //							If `event` = `delete`, then the payload will be a status ID.
+//							If `event` = `conversation`, then the payload will be a JSON string of a conversation.
 //						type: string
 //						example: "{\"id\":\"01FC3TZ5CFG6H65GCKCJRKA669\",\"created_at\":\"2021-08-02T16:25:52Z\",\"sensitive\":false,\"spoiler_text\":\"\",\"visibility\":\"public\",\"language\":\"en\",\"uri\":\"https://gts.superseriousbusiness.org/users/dumpsterqueer/statuses/01FC3TZ5CFG6H65GCKCJRKA669\",\"url\":\"https://gts.superseriousbusiness.org/@dumpsterqueer/statuses/01FC3TZ5CFG6H65GCKCJRKA669\",\"replies_count\":0,\"reblogs_count\":0,\"favourites_count\":0,\"favourited\":false,\"reblogged\":false,\"muted\":false,\"bookmarked\":fals…//gts.superseriousbusiness.org/fileserver/01JNN207W98SGG3CBJ76R5MVDN/header/original/019036W043D8FXPJKSKCX7G965.png\",\"header_static\":\"https://gts.superseriousbusiness.org/fileserver/01JNN207W98SGG3CBJ76R5MVDN/header/small/019036W043D8FXPJKSKCX7G965.png\",\"followers_count\":33,\"following_count\":28,\"statuses_count\":126,\"last_status_at\":\"2021-08-02T16:25:52Z\",\"emojis\":[],\"fields\":[]},\"media_attachments\":[],\"mentions\":[],\"tags\":[],\"emojis\":[],\"card\":null,\"poll\":null,\"text\":\"a\"}"
 //		'401':
@@ -181,41 +218,94 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 			return
 		}
 
-		// Set the auth'ed account.
+		if errWithCode := authed.RequireScope(oauth.ScopeReadStreaming); errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		// Set the auth'ed account
+		// and the token it used.
 		account = authed.Account
+		token = authed.Token.GetAccess()
 	}
 
-	// Get the initial requested stream type, if there is one.
+	// Server-sent events are used if
+	// this isn't a websocket request.
+	sse := !websocket.IsWebSocketUpgrade(c.Request)
+
+	// Get the initial requested stream type, if there is one,
+	// falling back to the type implied by the request path for
+	// Mastodon-style paths like `/api/v1/streaming/public/local`.
+	//
+	// If there isn't one, the client is expected to subscribe to
+	// stream types later on using websocket control messages.
 	streamType := c.Query(StreamQueryKey)
+	if streamType == "" {
+		streamType = pathStreamType(c.FullPath())
+	}
+
+	var streamTypes []string
+	if streamType != "" {
+		// By appending other query params to the streamType, we
+		// can allow streaming for specific list IDs or hashtags.
+		// The streamType in this case will end up looking like
+		// `hashtag:example` or `list:01H3YF48G8B7KTPQFS8D2QBVG8`.
+		if list := c.Query(StreamListKey); list != "" {
+			streamType += ":" + list
+		} else if tag := c.Query(StreamTagKey); tag != "" {
+			streamType += ":" + tag
+		}
 
-	// By appending other query params to the streamType, we
-	// can allow streaming for specific list IDs or hashtags.
-	// The streamType in this case will end up looking like
-	// `hashtag:example` or `list:01H3YF48G8B7KTPQFS8D2QBVG8`.
-	if list := c.Query(StreamListKey); list != "" {
-		streamType += ":" + list
-	} else if tag := c.Query(StreamTagKey); tag != "" {
-		streamType += ":" + tag
+		streamTypes = append(streamTypes, streamType)
+	}
+
+	if sse && len(streamTypes) == 0 {
+		// Server-sent events can't subscribe to stream
+		// types later on, so one needs to be given now.
+		const text = "no stream type specified"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
 	}
 
 	// Open a stream with the processor; this lets processor
 	// functions pass messages into a channel, which we can
 	// then read from and put into a websockets connection.
 	stream, errWithCode := m.processor.Stream().Open(
-		c.Request.Context(), // this ctx is only used for logging
+		c.Request.Context(),
 		account,
-		streamType,
+		streamTypes...,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
+	// Mark the stream with the token it was opened
+	// with, so it gets closed if the token is revoked.
+	stream.SetToken(token)
+
+	// Clients can choose to only receive, or not
+	// to receive, certain types of notifications.
+	types := c.QueryArray(StreamTypesKey)
+	excludeTypes := c.QueryArray(StreamExcludeTypesKey)
+	if len(types) > 0 || len(excludeTypes) > 0 {
+		stream.FilterNotifications(types, excludeTypes)
+	}
+
 	l := log.
 		WithContext(c.Request.Context()).
 		WithField("streamID", id.NewULID()).
 		WithField("username", account.Username)
 
+	if sse {
+		// Server-sent events are written
+		// directly into the response, so
+		// this blocks until stream closed.
+		m.handleSSEConn(c, &l, stream)
+		return
+	}
+
 	// Upgrade the incoming HTTP request. This hijacks the
 	// underlying connection and reuses it for the websocket
 	// (non-http) protocol.
@@ -234,7 +324,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// This prevents the upgrade handler from holding open any
 	// throttle / rate-limit request tokens which could become
 	// problematic on instances with multiple users.
-	go m.handleWSConn(&l, wsConn, stream)
+	go m.handleWSConn(&l, wsConn, account, stream)
 }
 
 // handleWSConn handles a two-way websocket streaming connection.
@@ -242,34 +332,69 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 // into the connection. If any errors are encountered while reading
 // or writing (including expected errors like clients leaving), the
 // connection will be closed.
-func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *streampkg.Stream) {
+func (m *Module) handleWSConn(
+	l *log.Entry,
+	wsConn *websocket.Conn,
+	account *gtsmodel.Account,
+	stream *streampkg.Stream,
+) {
 	l.Info("opened websocket connection")
 
 	// Create new async context with cancel.
 	ctx, cncl := context.WithCancel(context.Background())
 
+	// Track the amount of pings sent since
+	// we last received a pong from the client,
+	// so we can drop dead connections. Pong
+	// handler is called from the read loop.
+	missedPongs := new(atomic.Int32)
+	wsConn.SetPongHandler(func(string) error {
+		missedPongs.Store(0)
+		return nil
+	})
+
 	go func() {
 		defer cncl()
 
 		// Read messages from websocket to server.
-		m.readFromWSConn(ctx, wsConn, stream, l)
+		m.readFromWSConn(ctx, wsConn, account, stream, l)
 	}()
 
 	go func() {
 		defer cncl()
 
 		// Write messages from processor in websocket conn.
-		m.writeToWSConn(ctx, wsConn, stream, m.dTicker, l)
+		m.writeToWSConn(ctx, wsConn, stream, missedPongs, l)
 	}()
 
 	// Wait for ctx
 	// to be closed.
 	<-ctx.Done()
 
+	// Check whether the stream was closed on our
+	// side (eg., token revoked, or shutting down),
+	// rather than the connection going away.
+	var closeCode int
+	select {
+	case <-stream.Done():
+		closeCode = websocket.CloseGoingAway
+	default:
+		closeCode = websocket.CloseNormalClosure
+	}
+
 	// Close stream
 	// straightaway.
 	stream.Close()
 
+	// Send the client a close frame rather than just
+	// dropping the connection, so it knows to reconnect.
+	// If the client already went away this will fail.
+	deadline := time.Now().Add(m.dTicker)
+	msg := websocket.FormatCloseMessage(closeCode, "")
+	if err := wsConn.WriteControl(websocket.CloseMessage, msg, deadline); err != nil {
+		l.Debugf("error writing websocket close: %v", err)
+	}
+
 	// Tidy up underlying websocket connection.
 	if err := wsConn.Close(); err != nil {
 		l.Errorf("error closing websocket connection: %v", err)
@@ -280,13 +405,16 @@ func (m *Module) handleWSConn(l *log.Entry, wsConn *websocket.Conn, stream *stre
 
 // readFromWSConn reads control messages coming in from the given
 // websockets connection, and modifies the subscription StreamTypes
-// of the given stream accordingly after acquiring a lock on it.
+// of the given stream accordingly. This allows clients to multiplex
+// several stream types over one websocket connection, by sending
+// frames like `{"type":"subscribe","stream":"list","list":"<id>"}`.
 //
 // This is a blocking function; will return only on read error or
 // if the given context is canceled.
 func (m *Module) readFromWSConn(
 	ctx context.Context,
 	wsConn *websocket.Conn,
+	account *gtsmodel.Account,
 	stream *streampkg.Stream,
 	l *log.Entry,
 ) {
@@ -319,10 +447,12 @@ func (m *Module) readFromWSConn(
 		// and usually interesting, so log this at info.
 		l.Infof("received websocket message: %+v", msg)
 
-		// Ignore if the updateStreamType is unknown (or missing),
+		// Reject if the updateStreamType is unknown (or missing),
 		// so a bad client can't cause extra memory allocations
-		if !slices.Contains(streampkg.AllStatusTimelines, msg.Stream) {
+		if !slices.Contains(streampkg.AllTimelines, msg.Stream) {
 			l.Warnf("unknown 'stream' field: %v", msg)
+			const text = "unknown stream type"
+			m.sendWSError(ctx, stream, msg.Stream, gtserror.NewErrorBadRequest(errors.New(text), text+": "+msg.Stream))
 			continue
 		}
 
@@ -335,21 +465,58 @@ func (m *Module) readFromWSConn(
 
 		switch msg.Type {
 		case "subscribe":
-			stream.Subscribe(msg.Stream)
+			// Check whether stream type is valid and permitted
+			// for account (e.g. list is owned by them) first.
+			errWithCode := m.processor.Stream().Subscribe(ctx,
+				account,
+				stream,
+				msg.Stream,
+			)
+			if errWithCode != nil {
+				l.Warnf("error subscribing to %s: %v", msg.Stream, errWithCode)
+				m.sendWSError(ctx, stream, msg.Stream, errWithCode)
+			}
 		case "unsubscribe":
-			stream.Unsubscribe(msg.Stream)
+			// Unsubscribing from a stream type that
+			// was never subscribed to is a no-op.
+			m.processor.Stream().Unsubscribe(stream, msg.Stream)
 		default:
 			l.Warnf("invalid 'type' field: %v", msg)
+			const text = "invalid message type"
+			m.sendWSError(ctx, stream, msg.Stream, gtserror.NewErrorBadRequest(errors.New(text), text+": "+msg.Type))
 		}
 	}
 
 	l.Debug("finished websocket read")
 }
 
+// sendWSError queues an error event on the given stream,
+// to let the client know a message it sent was rejected.
+// The event is written to the websocket connection along
+// with regular messages, by writeToWSConn.
+func (m *Module) sendWSError(
+	ctx context.Context,
+	stream *streampkg.Stream,
+	streamType string,
+	errWithCode gtserror.WithCode,
+) {
+	payload, err := json.Marshal(map[string]any{
+		"error":  errWithCode.Safe(),
+		"status": errWithCode.Code(),
+	})
+	if err != nil {
+		log.Errorf(ctx, "error marshaling websocket error: %v", err)
+		return
+	}
+
+	stream.SendError(ctx, streamType, string(payload))
+}
+
 // writeToWSConn receives messages coming from the processor via the
 // given stream, and writes them into the given websockets connection.
 // This function also handles sending ping messages into the websockets
-// connection to keep it alive when no other activity occurs.
+// connection to keep it alive when no other activity occurs, and will
+// return if the client misses too many pings in a row.
 //
 // This is a blocking function; will return only on write error or
 // if the given context is canceled.
@@ -357,12 +524,12 @@ func (m *Module) writeToWSConn(
 	ctx context.Context,
 	wsConn *websocket.Conn,
 	stream *streampkg.Stream,
-	ping time.Duration,
+	missedPongs *atomic.Int32,
 	l *log.Entry,
 ) {
 	for {
 		// Wrap context with timeout to send a ping.
-		pingctx, cncl := context.WithTimeout(ctx, ping)
+		pingctx, cncl := context.WithTimeout(ctx, m.dTicker)
 
 		// Block on receipt of msg.
 		msg, ok := stream.Recv(pingctx)
@@ -372,30 +539,47 @@ func (m *Module) writeToWSConn(
 		cncl()
 
 		switch {
+		case !ok && ctx.Err() != nil:
+			// Parent context
+			// was canceled.
+			l.Debug("finished websocket write")
+			return
+
 		case !ok && pinged:
+			// Check whether client has been
+			// responding to our previous pings.
+			missed := int(missedPongs.Load())
+			if m.maxMissedPongs > 0 && missed >= m.maxMissedPongs {
+				l.Infof("client missed %d pings, closing connection", missed)
+				return
+			}
+
 			// The ping context timed out!
 			l.Trace("writing websocket ping")
 
 			// Wrapped context time-out, send a keep-alive "ping".
-			if err := wsConn.WriteControl(websocket.PingMessage, nil, time.Time{}); err != nil {
+			deadline := time.Now().Add(m.dTicker)
+			if err := wsConn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
 				l.Debugf("error writing websocket ping: %v", err)
-				break
+				return
 			}
 
+			missedPongs.Add(1)
+			continue
+
 		case !ok:
-			// Stream was
-			// closed.
+			// Stream was closed,
+			// (maybe consumer died).
+			l.Debug("stream closed")
 			return
 		}
 
-		l.Trace("writing websocket message: %+v", msg)
+		l.Tracef("writing websocket message: %+v", msg)
 
 		// Received a new message from the processor.
 		if err := wsConn.WriteJSON(msg); err != nil {
 			l.Debugf("error writing websocket message: %v", err)
-			break
+			return
 		}
 	}
-
-	l.Debug("finished websocket write")
 }
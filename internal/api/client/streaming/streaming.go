@@ -19,6 +19,7 @@ package streaming
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -27,29 +28,33 @@ import (
 )
 
 const (
-	BasePath            = "/v1/streaming"          // path for the streaming api, minus the 'api' prefix
-	StreamQueryKey      = "stream"                 // type of stream being requested
-	StreamListKey       = "list"                   // id of list being requested
-	StreamTagKey        = "tag"                    // name of tag being requested
-	AccessTokenQueryKey = "access_token"           // oauth access token
-	AccessTokenHeader   = "Sec-Websocket-Protocol" //nolint:gosec
+	BasePath              = "/v1/streaming"          // path for the streaming api, minus the 'api' prefix
+	StreamQueryKey        = "stream"                 // type of stream being requested
+	StreamListKey         = "list"                   // id of list being requested
+	StreamTagKey          = "tag"                    // name of tag being requested
+	StreamTypesKey        = "types[]"                // notification types to include
+	StreamExcludeTypesKey = "exclude_types[]"        // notification types to exclude
+	AccessTokenQueryKey   = "access_token"           // oauth access token
+	AccessTokenHeader     = "Sec-Websocket-Protocol" //nolint:gosec
 )
 
 type Module struct {
-	processor *processing.Processor
-	dTicker   time.Duration
-	wsUpgrade websocket.Upgrader
+	processor      *processing.Processor
+	dTicker        time.Duration
+	maxMissedPongs int
+	wsUpgrade      websocket.Upgrader
 }
 
-func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Module {
+func New(processor *processing.Processor, dTicker time.Duration, maxMissedPongs int, wsBuf int) *Module {
 	// We expect CORS requests for websockets,
 	// (via eg., semaphore.social) so be lenient.
 	// TODO: make this customizable?
 	checkOrigin := func(r *http.Request) bool { return true }
 
 	return &Module{
-		processor: processor,
-		dTicker:   dTicker,
+		processor:      processor,
+		dTicker:        dTicker,
+		maxMissedPongs: maxMissedPongs,
 		wsUpgrade: websocket.Upgrader{
 			ReadBufferSize:  wsBuf,
 			WriteBufferSize: wsBuf,
@@ -60,4 +65,32 @@ func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Mod
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.StreamGETHandler)
+
+	// Mastodon-style paths, used by
+	// server-sent events clients.
+	for _, path := range streamPaths {
+		attachHandler(http.MethodGet, BasePath+path, m.StreamGETHandler)
+	}
+}
+
+// streamPaths are Mastodon-style subpaths
+// of BasePath, which imply the stream type.
+var streamPaths = []string{
+	"/user",
+	"/user/notification",
+	"/public",
+	"/public/local",
+	"/direct",
+	"/list",
+}
+
+// pathStreamType returns the stream type implied by the
+// given route path, eg., `/api/v1/streaming/public/local`
+// gives `public:local`. Empty string if none is implied.
+func pathStreamType(fullPath string) string {
+	_, path, ok := strings.Cut(fullPath, BasePath+"/")
+	if !ok {
+		return ""
+	}
+	return strings.ReplaceAll(path, "/", ":")
 }
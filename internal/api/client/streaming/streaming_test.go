@@ -19,6 +19,7 @@ package streaming_test
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -110,7 +112,7 @@ func (suite *StreamingTestSuite) SetupTest() {
 	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
 	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", nil)
 	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
-	suite.streamingModule = streaming.New(suite.processor, 1, 4096)
+	suite.streamingModule = streaming.New(suite.processor, 1, 2, 4096)
 }
 
 func (suite *StreamingTestSuite) TearDownTest() {
@@ -245,6 +247,46 @@ func (suite *StreamingTestSuite) TestSecurityHeader() {
 	}
 }
 
+func (suite *StreamingTestSuite) TestServerSentEventsHeartbeat() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// Client "goes away" after a short while.
+	reqCtx, cncl := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cncl()
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/%s?stream=user", streaming.BasePath), nil).WithContext(reqCtx)
+	ctx.Request.Header.Set("accept", "text/event-stream")
+	ctx.Request.Header.Set(streaming.AccessTokenHeader, oauthToken.Access)
+
+	// Blocks until client goes away.
+	suite.streamingModule.StreamGETHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/event-stream", recorder.Header().Get("Content-Type"))
+
+	// Stream should have been opened and then
+	// heartbeats sent, since ticker is tiny.
+	suite.True(strings.HasPrefix(recorder.Body.String(), ":)\n:thump\n"))
+}
+
+func (suite *StreamingTestSuite) TestServerSentEventsNoStreamType() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/%s", streaming.BasePath), nil)
+	ctx.Request.Header.Set("accept", "text/event-stream")
+	ctx.Request.Header.Set(streaming.AccessTokenHeader, oauthToken.Access)
+
+	suite.streamingModule.StreamGETHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
 func TestStreamingTestSuite(t *testing.T) {
 	suite.Run(t, new(StreamingTestSuite))
 }
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type DeleteTestSuite struct {
+	TokensStandardTestSuite
+}
+
+func (suite *DeleteTestSuite) delete(tokenID string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, "api/v1/tokens/"+tokenID)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   tokens.IDKey,
+			Value: tokenID,
+		},
+	}
+
+	suite.tokensModule.TokenDELETEHandler(ctx)
+	return recorder
+}
+
+func (suite *DeleteTestSuite) TestDeleteToken() {
+	var (
+		ctx     = context.Background()
+		token   = suite.testTokens["local_account_1"]
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	// Open a stream authorized with the token.
+	str, errWithCode := suite.processor.Stream().Open(ctx, account, stream.TimelineHome)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	str.SetToken(token.Access)
+
+	recorder := suite.delete(token.ID)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// Token should be gone.
+	_, err := suite.db.GetTokenByID(ctx, token.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	// Stream should be closed.
+	select {
+	case <-str.Done():
+	default:
+		suite.FailNow("stream was not closed")
+	}
+}
+
+func (suite *DeleteTestSuite) TestDeleteOtherUsersToken() {
+	token := suite.testTokens["local_account_2"]
+
+	recorder := suite.delete(token.ID)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	// Token should still be there.
+	_, err := suite.db.GetTokenByID(context.Background(), token.ID)
+	suite.NoError(err)
+}
+
+func TestDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &DeleteTestSuite{})
+}
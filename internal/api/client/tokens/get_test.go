@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type GetTestSuite struct {
+	TokensStandardTestSuite
+}
+
+func (suite *GetTestSuite) TestGetTokens() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, "api/v1/tokens")
+
+	suite.tokensModule.TokensGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// Token values themselves
+	// should never be returned.
+	suite.NotContains(string(b), suite.testTokens["local_account_1"].Access)
+
+	tokens := []*apimodel.TokenInfo{}
+	suite.NoError(json.Unmarshal(b, &tokens))
+
+	// Only the user's own access token should be
+	// included, not the authorization code token.
+	suite.Len(tokens, 1)
+	suite.Equal(suite.testTokens["local_account_1"].ID, tokens[0].ID)
+	suite.Equal("read write follow push", tokens[0].Scope)
+	suite.Equal("2022-06-10T15:22:08.000Z", tokens[0].CreatedAt)
+	suite.Equal("really cool gts application", tokens[0].Application.Name)
+}
+
+func TestGetTestSuite(t *testing.T) {
+	suite.Run(t, &GetTestSuite{})
+}
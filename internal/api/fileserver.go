@@ -18,6 +18,8 @@
 package api
 
 import (
+	"slices"
+
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/fileserver"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -31,8 +33,9 @@ type Fileserver struct {
 	fileserver *fileserver.Module
 }
 
-// Attach cache middleware appropriate for file serving.
-func useFSCacheMiddleware(grp *gin.RouterGroup) {
+// Attach cache middleware appropriate for
+// file serving of the given media types.
+func useFSCacheMiddleware(grp *gin.RouterGroup, mediaTypes ...media.Type) {
 	// If we're using local storage or proxying s3 (ie., serving
 	// from here) we can set a long max-age + immutable on file
 	// requests to reflect that we never host different files at
@@ -46,18 +49,31 @@ func useFSCacheMiddleware(grp *gin.RouterGroup) {
 	// cache expired links. This is done within fileserver/servefile.go
 	// so we should not set the middleware here in that case.
 	//
+	// Media types configured to always be proxied are served from
+	// here even with non-proxying s3. If a group serves a mix of
+	// types, the redirect handler overwrites the header set here.
+	//
 	// See:
 	//
 	// - https://developer.mozilla.org/en-US/docs/Web/HTTP/Caching#avoiding_revalidation
 	// - https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control#immutable
 	servingFromHere := config.GetStorageBackend() == "local" || config.GetStorageS3Proxy()
+	for _, mediaType := range mediaTypes {
+		if slices.Contains(config.GetStorageS3ProxyMediaTypes(), string(mediaType)) {
+			servingFromHere = true
+		}
+	}
+
 	if !servingFromHere {
 		return
 	}
 
 	grp.Use(middleware.CacheControl(middleware.CacheControlConfig{
 		Directives: []string{"private", "max-age=604800", "immutable"},
-		Vary:       []string{"Range"}, // Cache partial ranges separately.
+		Vary: []string{
+			"Range",  // Cache partial ranges separately.
+			"Accept", // WebP thumbnails have JPEG fallbacks.
+		},
 	}))
 }
 
@@ -75,7 +91,12 @@ func (f *Fileserver) Route(
 	// Attach provided +
 	// cache middlewares.
 	fsGroup.Use(m...)
-	useFSCacheMiddleware(fsGroup)
+	useFSCacheMiddleware(fsGroup,
+		media.TypeAttachment,
+		media.TypeHeader,
+		media.TypeAvatar,
+		media.TypeEmoji,
+	)
 
 	f.fileserver.Route(fsGroup.Handle)
 }
@@ -120,7 +141,7 @@ func (f *Fileserver) RouteEmojis(
 	// Attach provided +
 	// cache middlewares.
 	fsEmojiGroup.Use(m...)
-	useFSCacheMiddleware(fsEmojiGroup)
+	useFSCacheMiddleware(fsEmojiGroup, media.TypeEmoji)
 
 	f.fileserver.Route(fsEmojiGroup.Handle)
 }
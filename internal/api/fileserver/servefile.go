@@ -31,6 +31,7 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -79,12 +80,25 @@ func (m *Module) ServeFile(c *gin.Context) {
 	// Acquire context from gin request.
 	ctx := c.Request.Context()
 
-	content, errWithCode := m.processor.Media().GetFile(ctx, authed.Account, &apimodel.GetContentRequestForm{
-		AccountID: accountID,
-		MediaType: mediaType,
-		MediaSize: mediaSize,
-		FileName:  fileName,
-	})
+	var (
+		content     *apimodel.Content
+		errWithCode gtserror.WithCode
+	)
+
+	if mediaType == string(media.TypeArchive) {
+		// Account archives aren't media, and are
+		// instead authorized by the token in the
+		// (time-limited) link sent to their owner.
+		content, errWithCode = m.processor.Account().ArchiveFileGet(ctx, accountID, fileName, c.Query("token"))
+	} else {
+		content, errWithCode = m.processor.Media().GetFile(ctx, authed.Account, &apimodel.GetContentRequestForm{
+			AccountID:  accountID,
+			MediaType:  mediaType,
+			MediaSize:  mediaSize,
+			FileName:   fileName,
+			PreferJPEG: preferJPEG(c, fileName),
+		})
+	}
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -115,6 +129,24 @@ func (m *Module) ServeFile(c *gin.Context) {
 		return
 	}
 
+	// Set cache validators for the content, and
+	// advertise that we support byte range requests.
+	c.Header("Accept-Ranges", "bytes")
+	if content.ContentETag != "" {
+		c.Header("ETag", content.ContentETag)
+	}
+	if !content.ContentUpdated.IsZero() {
+		c.Header("Last-Modified", content.ContentUpdated.UTC().Format(http.TimeFormat))
+	}
+
+	// If the requester already has this exact
+	// content cached, there's no need to send it.
+	if notModified(c.Request, content) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
 	// if this is a head request, just return info + throw the reader away
 	if c.Request.Method == http.MethodHead {
 		c.Header("Content-Type", contentType)
@@ -123,9 +155,11 @@ func (m *Module) ServeFile(c *gin.Context) {
 		return
 	}
 
-	// Look for a provided range header.
+	// Look for a provided range header, ignoring
+	// it if the content has changed since the
+	// requester's partial copy (via If-Range).
 	rng := c.GetHeader("Range")
-	if rng == "" {
+	if rng == "" || !ifRange(c.Request, content) {
 		// This is a simple query for the whole file, so do a read from whole reader.
 		c.DataFromReader(http.StatusOK, content.ContentLength, contentType, content.Content, nil)
 		return
@@ -142,6 +176,19 @@ func (m *Module) ServeFile(c *gin.Context) {
 	)
 }
 
+// preferJPEG returns whether the requester should be served
+// the JPEG copy of a WebP thumbnail, where one is kept; ie.,
+// if it asked for a .jpg, or its Accept header prefers JPEG.
+func preferJPEG(c *gin.Context, fileName string) bool {
+	if strings.HasSuffix(fileName, ".jpg") ||
+		strings.HasSuffix(fileName, ".jpeg") {
+		return true
+	}
+
+	format, err := apiutil.NegotiateAccept(c, "image/webp", "image/jpeg")
+	return err == nil && format == "image/jpeg"
+}
+
 // serveFileRange serves the range of a file from a given source reader, without the
 // need for implementation of io.Seeker. Instead we read the first 'start' many bytes
 // into a discard reader. Code is adapted from https://codeberg.org/gruf/simplehttp.
@@ -179,47 +226,70 @@ func serveFileRange(rw http.ResponseWriter, r *http.Request, src io.Reader, rng
 		startRng, endRng string
 	)
 
-	if startRng = rng[:i]; len(startRng) > 0 {
-		// Parse the start of this byte range
-		start, err = strconv.ParseInt(startRng, 10, 64)
-		if err != nil {
+	if startRng = rng[:i]; len(startRng) == 0 {
+		// No start supplied, this is a suffix range
+		// asking for the final 'n' bytes of the file.
+		n, err := strconv.ParseInt(rng[i+1:], 10, 64)
+		if err != nil || n < 0 {
 			http.Error(rw, "Bad Range Header", http.StatusBadRequest)
 			return
 		}
 
-		if start < 0 {
-			// This range starts *before* the file start, why did they send this lol
-			rw.Header().Set("Content-Range", "bytes *"+strconv.FormatInt(size, 10))
+		if n == 0 {
+			// A zero length suffix can never be satisfied
+			rw.Header().Set("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
 			http.Error(rw, "Unsatisfiable Range", http.StatusRequestedRangeNotSatisfiable)
 			return
 		}
-	} else {
-		// No start supplied, implying file start
-		startRng = "0"
-	}
 
-	if endRng = rng[i+1:]; len(endRng) > 0 {
-		// Parse the end of this byte range
-		end, err = strconv.ParseInt(endRng, 10, 64)
+		if n > size {
+			// Suffix longer than file, serve the whole file
+			// https://www.rfc-editor.org/rfc/rfc9110#section-14.1.2-8
+			n = size
+		}
+
+		start = size - n
+		startRng = strconv.FormatInt(start, 10)
+		endRng = strconv.FormatInt(end, 10)
+	} else {
+		// Parse the start of this byte range
+		start, err = strconv.ParseInt(startRng, 10, 64)
 		if err != nil {
 			http.Error(rw, "Bad Range Header", http.StatusBadRequest)
 			return
 		}
 
-		if end >= size {
-			// According to the http spec if end >= size the server should return the rest of the file
-			// https://www.rfc-editor.org/rfc/rfc9110#section-14.1.2-6
-			end = size - 1
+		if start < 0 {
+			// This range starts *before* the file start, why did they send this lol
+			rw.Header().Set("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
+			http.Error(rw, "Unsatisfiable Range", http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+
+		if endRng = rng[i+1:]; len(endRng) > 0 {
+			// Parse the end of this byte range
+			end, err = strconv.ParseInt(endRng, 10, 64)
+			if err != nil {
+				http.Error(rw, "Bad Range Header", http.StatusBadRequest)
+				return
+			}
+
+			if end >= size {
+				// According to the http spec if end >= size the server should return the rest of the file
+				// https://www.rfc-editor.org/rfc/rfc9110#section-14.1.2-6
+				end = size - 1
+				endRng = strconv.FormatInt(end, 10)
+			}
+		} else {
+			// No end supplied, implying file end
 			endRng = strconv.FormatInt(end, 10)
 		}
-	} else {
-		// No end supplied, implying file end
-		endRng = strconv.FormatInt(end, 10)
 	}
 
-	if start >= end {
-		// This range starts _after_ their range end, unsatisfiable and nonsense!
-		rw.Header().Set("Content-Range", "bytes *"+strconv.FormatInt(size, 10))
+	if start > end || start >= size {
+		// This range starts _after_ their range end or
+		// the end of the file, unsatisfiable and nonsense!
+		rw.Header().Set("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
 		http.Error(rw, "Unsatisfiable Range", http.StatusRequestedRangeNotSatisfiable)
 		return
 	}
@@ -250,3 +320,70 @@ func serveFileRange(rw http.ResponseWriter, r *http.Request, src io.Reader, rng
 		return
 	}
 }
+
+// notModified returns whether the conditional headers of
+// the request indicate the requester already holds the
+// current version of content, in which case a 304 can be
+// returned instead. If-None-Match takes precedence over
+// If-Modified-Since, as per RFC 9110 section 13.2.2.
+func notModified(r *http.Request, content *apimodel.Content) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatch(inm, content.ContentETag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || content.ContentUpdated.IsZero() {
+		return false
+	}
+
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		// Invalid dates must be ignored.
+		return false
+	}
+
+	// HTTP dates only have second precision.
+	updated := content.ContentUpdated.Truncate(time.Second)
+	return !updated.After(t)
+}
+
+// ifRange returns whether a range request should be served
+// as such, according to any If-Range header; when the content
+// has changed, the full file should be served instead.
+func ifRange(r *http.Request, content *apimodel.Content) bool {
+	ir := r.Header.Get("If-Range")
+	if ir == "" {
+		return true
+	}
+
+	if strings.HasPrefix(ir, `"`) {
+		// Entity tags here require strong comparison.
+		return content.ContentETag != "" && ir == content.ContentETag
+	}
+
+	t, err := http.ParseTime(ir)
+	if err != nil || content.ContentUpdated.IsZero() {
+		return false
+	}
+
+	updated := content.ContentUpdated.Truncate(time.Second)
+	return updated.Equal(t)
+}
+
+// etagMatch returns whether any of the comma separated
+// entity tags in header weakly match the given etag.
+func etagMatch(header string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
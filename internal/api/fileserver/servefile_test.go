@@ -22,6 +22,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -43,12 +45,27 @@ func (suite *ServeFileTestSuite) GetFile(
 	mediaType media.Type,
 	mediaSize media.Size,
 	filename string,
+) (code int, headers http.Header, body []byte) {
+	return suite.GetFileWithHeaders(accountID, mediaType, mediaSize, filename, nil)
+}
+
+// GetFileWithHeaders is like GetFile, but sets the
+// given additional headers on the request first.
+func (suite *ServeFileTestSuite) GetFileWithHeaders(
+	accountID string,
+	mediaType media.Type,
+	mediaSize media.Size,
+	filename string,
+	reqHeaders map[string]string,
 ) (code int, headers http.Header, body []byte) {
 	recorder := httptest.NewRecorder()
 
 	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
 	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/whatever", nil)
 	ctx.Request.Header.Set("accept", "*/*")
+	for k, v := range reqHeaders {
+		ctx.Request.Header.Set(k, v)
+	}
 	ctx.AddParam(fileserver.AccountIDKey, accountID)
 	ctx.AddParam(fileserver.MediaTypeKey, string(mediaType))
 	ctx.AddParam(fileserver.MediaSizeKey, string(mediaSize))
@@ -126,6 +143,58 @@ func (suite *ServeFileTestSuite) TestServeSmallLocalFileOK() {
 	suite.Equal(fileInStorage, body)
 }
 
+func (suite *ServeFileTestSuite) TestServeSmallLocalFileWebPFallback() {
+	ctx := context.Background()
+
+	targetAttachment := &gtsmodel.MediaAttachment{}
+	*targetAttachment = *suite.testAttachments["admin_account_status_1_attachment_1"]
+	jpegInStorage, err := suite.storage.Get(ctx, targetAttachment.Thumbnail.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Swap in a webp thumbnail, keeping the
+	// existing jpeg as its fallback copy.
+	webpInStorage := []byte("not really a webp")
+	targetAttachment.Thumbnail.Path = strings.TrimSuffix(targetAttachment.Thumbnail.Path, ".jpg") + ".webp"
+	targetAttachment.Thumbnail.ContentType = "image/webp"
+	targetAttachment.Thumbnail.FileSize = len(webpInStorage)
+	if _, err := suite.storage.Put(ctx, targetAttachment.Thumbnail.Path, webpInStorage); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := suite.db.UpdateAttachment(ctx, targetAttachment,
+		"thumbnail_path",
+		"thumbnail_content_type",
+		"thumbnail_file_size",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, test := range []struct {
+		accept      string
+		filename    string
+		contentType string
+		body        []byte
+	}{
+		{"*/*", targetAttachment.ID + ".webp", "image/webp", webpInStorage},
+		{"image/avif,image/webp,*/*", targetAttachment.ID + ".webp", "image/webp", webpInStorage},
+		{"image/jpeg,image/png", targetAttachment.ID + ".webp", "image/jpeg", jpegInStorage},
+		{"*/*", targetAttachment.ID + ".jpg", "image/jpeg", jpegInStorage},
+	} {
+		code, headers, body := suite.GetFileWithHeaders(
+			targetAttachment.AccountID,
+			media.TypeAttachment,
+			media.SizeSmall,
+			test.filename,
+			map[string]string{"Accept": test.accept},
+		)
+
+		suite.Equal(http.StatusOK, code, test.accept)
+		suite.Equal(test.contentType, headers.Get("content-type"), test.accept)
+		suite.Equal(test.body, body, test.accept)
+	}
+}
+
 func (suite *ServeFileTestSuite) TestServeOriginalRemoteFileOK() {
 	targetAttachment := &gtsmodel.MediaAttachment{}
 	*targetAttachment = *suite.testAttachments["remote_account_1_status_1_attachment_1"]
@@ -266,6 +335,104 @@ func (suite *ServeFileTestSuite) TestServeFileNotFound() {
 	suite.Equal(http.StatusNotFound, code)
 }
 
+func (suite *ServeFileTestSuite) TestServeFileNotModified() {
+	targetAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	code, headers, _ := suite.GetFile(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		targetAttachment.ID+".jpg",
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("bytes", headers.Get("accept-ranges"))
+
+	etag := headers.Get("etag")
+	suite.NotEmpty(etag)
+	lastModified := headers.Get("last-modified")
+	suite.NotEmpty(lastModified)
+
+	// A matching etag should get a 304 with no body.
+	code, headers, body := suite.GetFileWithHeaders(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		targetAttachment.ID+".jpg",
+		map[string]string{"If-None-Match": etag},
+	)
+	suite.Equal(http.StatusNotModified, code)
+	suite.Equal(etag, headers.Get("etag"))
+	suite.Empty(body)
+
+	// As should a matching modification date.
+	code, _, body = suite.GetFileWithHeaders(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		targetAttachment.ID+".jpg",
+		map[string]string{"If-Modified-Since": lastModified},
+	)
+	suite.Equal(http.StatusNotModified, code)
+	suite.Empty(body)
+
+	// But a different etag should get the file.
+	code, _, body = suite.GetFileWithHeaders(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		targetAttachment.ID+".jpg",
+		map[string]string{"If-None-Match": `"nope"`},
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.NotEmpty(body)
+}
+
+func (suite *ServeFileTestSuite) TestServeFileRange() {
+	targetAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	fileInStorage, err := suite.storage.Get(context.Background(), targetAttachment.File.Path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	size := strconv.Itoa(len(fileInStorage))
+
+	for _, test := range []struct {
+		rng    string
+		code   int
+		cRange string
+		body   []byte
+	}{
+		{"bytes=0-9", http.StatusPartialContent, "bytes 0-9/" + size, fileInStorage[:10]},
+		{"bytes=5-5", http.StatusPartialContent, "bytes 5-5/" + size, fileInStorage[5:6]},
+		{"bytes=10-", http.StatusPartialContent, "bytes 10-" + strconv.Itoa(len(fileInStorage)-1) + "/" + size, fileInStorage[10:]},
+		{"bytes=-10", http.StatusPartialContent, "bytes " + strconv.Itoa(len(fileInStorage)-10) + "-" + strconv.Itoa(len(fileInStorage)-1) + "/" + size, fileInStorage[len(fileInStorage)-10:]},
+		{"bytes=" + size + "-", http.StatusRequestedRangeNotSatisfiable, "bytes */" + size, nil},
+	} {
+		code, headers, body := suite.GetFileWithHeaders(
+			targetAttachment.AccountID,
+			media.TypeAttachment,
+			media.SizeOriginal,
+			targetAttachment.ID+".jpg",
+			map[string]string{"Range": test.rng},
+		)
+		suite.Equal(test.code, code, test.rng)
+		suite.Equal(test.cRange, headers.Get("content-range"), test.rng)
+		if test.body != nil {
+			suite.Equal(test.body, body, test.rng)
+		}
+	}
+
+	// A range with a stale If-Range should get the whole file.
+	code, _, body := suite.GetFileWithHeaders(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		targetAttachment.ID+".jpg",
+		map[string]string{"Range": "bytes=0-9", "If-Range": `"stale"`},
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(fileInStorage, body)
+}
+
 func TestServeFileTestSuite(t *testing.T) {
 	suite.Run(t, new(ServeFileTestSuite))
 }
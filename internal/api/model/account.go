@@ -62,6 +62,9 @@ type Account struct {
 	// Only relevant when the account's main avatar is a video or a gif.
 	// example: https://example.org/media/some_user/avatar/static/avatar.png
 	AvatarStatic string `json:"avatar_static"`
+	// Description of the account's avatar, for use as alt text.
+	// example: A cute drawing of a smiling sloth.
+	AvatarDescription string `json:"avatar_description,omitempty"`
 	// Web location of the account's header image.
 	// example: https://example.org/media/some_user/header/original/header.jpeg
 	Header string `json:"header"`
@@ -69,6 +72,9 @@ type Account struct {
 	// Only relevant when the account's main header is a video or a gif.
 	// example: https://example.org/media/some_user/header/static/header.png
 	HeaderStatic string `json:"header_static"`
+	// Description of the account's header, for use as alt text.
+	// example: A sunlit field with purple flowers.
+	HeaderDescription string `json:"header_description,omitempty"`
 	// Number of accounts following this account, according to our instance.
 	FollowersCount int `json:"followers_count"`
 	// Number of account's followed by this account, according to our instance.
@@ -93,6 +99,9 @@ type Account struct {
 	CustomCSS string `json:"custom_css,omitempty"`
 	// Account has enabled RSS feed.
 	EnableRSS bool `json:"enable_rss,omitempty"`
+	// Filename of the web theme chosen by this account, if any.
+	// example: light.css
+	Theme string `json:"theme,omitempty"`
 	// Role of the account on this instance.
 	// Omitted for remote accounts.
 	Role *AccountRole `json:"role,omitempty"`
@@ -132,6 +141,10 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Code of an invite to sign up with. Signing up with a valid invite skips
+	// manual approval, and is possible even when registrations are closed.
+	// swagger:parameters
+	Invite string `form:"invite" json:"invite" xml:"invite"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -152,8 +165,12 @@ type UpdateCredentialsRequest struct {
 	Note *string `form:"note" json:"note"`
 	// Avatar image encoded using multipart/form-data.
 	Avatar *multipart.FileHeader `form:"avatar" json:"-"`
+	// Description of avatar image, for alt-text.
+	AvatarDescription *string `form:"avatar_description" json:"avatar_description"`
 	// Header image encoded using multipart/form-data
 	Header *multipart.FileHeader `form:"header" json:"-"`
+	// Description of header image, for alt-text.
+	HeaderDescription *string `form:"header_description" json:"header_description"`
 	// Require manual approval of follow requests.
 	Locked *bool `form:"locked" json:"locked"`
 	// New Source values for this account.
@@ -166,6 +183,32 @@ type UpdateCredentialsRequest struct {
 	CustomCSS *string `form:"custom_css" json:"custom_css"`
 	// Enable RSS feed of public toots for this account at /@[username]/feed.rss
 	EnableRSS *bool `form:"enable_rss" json:"enable_rss"`
+	// Include statuses marked as sensitive in the RSS feed of this account.
+	RSSIncludeSensitive *bool `form:"rss_include_sensitive" json:"rss_include_sensitive"`
+	// Hide this account's followers and following from everyone else.
+	HideCollections *bool `form:"hide_collections" json:"hide_collections"`
+	// Don't create notifications for new follow requests. Requests are still queued.
+	SuppressFollowRequestNotifications *bool `form:"suppress_follow_request_notifications" json:"suppress_follow_request_notifications"`
+	// Don't create notifications when statuses this account boosted or favourited are edited.
+	SuppressStatusUpdateNotifications *bool `form:"suppress_status_update_notifications" json:"suppress_status_update_notifications"`
+	// Automatically accept follow requests from accounts this account follows.
+	AutoAcceptFollowedBack *bool `form:"auto_accept_followed_back" json:"auto_accept_followed_back"`
+	// Automatically accept follow requests from accounts on this instance.
+	AutoAcceptLocal *bool `form:"auto_accept_local" json:"auto_accept_local"`
+	// Automatically accept follow requests from accounts created more
+	// than this many days ago. 0 to disable.
+	AutoAcceptOlderThanDays *int `form:"auto_accept_older_than_days" json:"auto_accept_older_than_days"`
+	// Automatically delete statuses older than this many days. 0 to disable.
+	StatusesAutoDeleteAfterDays *int `form:"statuses_auto_delete_after_days" json:"statuses_auto_delete_after_days"`
+	// Don't automatically delete pinned statuses.
+	StatusesAutoDeleteKeepPinned *bool `form:"statuses_auto_delete_keep_pinned" json:"statuses_auto_delete_keep_pinned"`
+	// Don't automatically delete statuses you have bookmarked.
+	StatusesAutoDeleteKeepBookmarked *bool `form:"statuses_auto_delete_keep_bookmarked" json:"statuses_auto_delete_keep_bookmarked"`
+	// Don't automatically delete direct messages.
+	StatusesAutoDeleteKeepDirect *bool `form:"statuses_auto_delete_keep_direct" json:"statuses_auto_delete_keep_direct"`
+	// Filename of the web theme to use when rendering this account's profile or statuses.
+	// Empty string to unset and use the default theme.
+	Theme *string `form:"theme" json:"theme"`
 }
 
 // UpdateSource is to be used specifically in an UpdateCredentialsRequest.
@@ -180,6 +223,15 @@ type UpdateSource struct {
 	Language *string `form:"language" json:"language"`
 	// Default format for authored statuses (text/plain or text/markdown).
 	StatusContentType *string `form:"status_content_type" json:"status_content_type"`
+	// Expand content warnings by default when reading.
+	ExpandSpoilers *bool `form:"expand_spoilers" json:"expand_spoilers"`
+	// How to display media attachments when reading (default, show_all or hide_all).
+	ExpandMedia *string `form:"expand_media" json:"expand_media"`
+	// Serve static versions of animated avatars, headers and emojis.
+	DisableAnimation *bool `form:"disable_animation" json:"disable_animation"`
+	// Default visibility of boosts (public, unlisted or private).
+	// Empty string to unset and use the boosted status' visibility.
+	BoostVisibility *string `form:"boost_visibility" json:"boost_visibility"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
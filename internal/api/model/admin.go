@@ -72,6 +72,10 @@ type AdminAccountInfo struct {
 	CreatedByApplicationID string `json:"created_by_application_id,omitempty"`
 	// The ID of the account that invited this user
 	InvitedByAccountID string `json:"invited_by_account_id,omitempty"`
+	// Max permitted characters for statuses posted by this
+	// account, if set to override the instance setting.
+	// Omitted from json if not set.
+	StatusesMaxChars int `json:"statuses_max_chars,omitempty"`
 }
 
 // AdminReport models the admin view of a report.
@@ -178,6 +182,17 @@ type AdminActionRequest struct {
 	TargetID string `form:"-" json:"-" xml:"-"`
 }
 
+// AdminAccountStatusesMaxCharsRequest models a request to
+// override the max permitted characters of statuses posted
+// by a local account.
+//
+// swagger:ignore
+type AdminAccountStatusesMaxCharsRequest struct {
+	// Max permitted characters, including content
+	// warning. 0 to use the instance setting again.
+	StatusesMaxChars *int `form:"statuses_max_chars" json:"statuses_max_chars" xml:"statuses_max_chars"`
+}
+
 // AdminActionResponse models the server
 // response to an admin action.
 //
@@ -189,6 +204,18 @@ type AdminActionResponse struct {
 	ActionID string `json:"action_id"`
 }
 
+// AdminIPScrubResponse models the server
+// response to an IP scrub request.
+//
+// swagger:model adminIPScrubResponse
+type AdminIPScrubResponse struct {
+	// Number of users whose stored
+	// IP addresses were scrubbed.
+	//
+	// example: 5
+	Scrubbed int `json:"scrubbed"`
+}
+
 // MediaCleanupRequest models admin media cleanup parameters
 //
 // swagger:parameters mediaCleanup
@@ -196,11 +223,67 @@ type MediaCleanupRequest struct {
 	// Number of days of remote media to keep. Native values will be treated as 0.
 	// If value is not specified, the value of media-remote-cache-days in the server config will be used.
 	RemoteCacheDays *int `form:"remote_cache_days" json:"remote_cache_days" xml:"remote_cache_days"`
+	// Only report what would be cleaned up, without removing anything.
+	DryRun bool `form:"dry_run" json:"dry_run" xml:"dry_run"`
+}
+
+// AdminMediaCleanupReport models the outcome of a media cleanup run.
+//
+// swagger:model adminMediaCleanupReport
+type AdminMediaCleanupReport struct {
+	// Whether this was a dry run, in which
+	// case nothing was actually removed.
+	DryRun bool `json:"dry_run"`
+	// Number of days of remote media that was kept.
+	//
+	// example: 7
+	RemoteCacheDays int `json:"remote_cache_days"`
+	// Time the run started (ISO 8601 Datetime).
+	//
+	// example: 2021-07-30T09:20:25+00:00
+	StartedAt string `json:"started_at"`
+	// Time the run finished (ISO 8601 Datetime).
+	//
+	// example: 2021-07-30T09:21:25+00:00
+	FinishedAt string `json:"finished_at"`
+	// Local files in storage with no related database entry.
+	OrphanedLocal AdminMediaCleanupReportEntry `json:"orphaned_local"`
+	// Remote status attachments uncached for not being fetched recently.
+	StaleRemoteAttachments AdminMediaCleanupReportEntry `json:"stale_remote_attachments"`
+	// Remote avatars and headers uncached for not being fetched recently.
+	StaleAvatarsHeaders AdminMediaCleanupReportEntry `json:"stale_avatars_headers"`
+	// Media attachments deleted for being unused by any status or account.
+	UnusedMedia AdminMediaCleanupReportEntry `json:"unused_media"`
+	// Remote emoji uncached for not being fetched recently.
+	StaleRemoteEmoji AdminMediaCleanupReportEntry `json:"stale_remote_emoji"`
+	// Remote emoji deleted for being unused by any status or account.
+	UnusedEmoji AdminMediaCleanupReportEntry `json:"unused_emoji"`
+	// Combined size in bytes of all files cleaned up
+	// (or in a dry run, that would be cleaned up).
+	//
+	// example: 1048576
+	BytesReclaimed int64 `json:"bytes_reclaimed"`
+}
+
+// AdminMediaCleanupReportEntry models the files
+// cleaned up in one category of a media cleanup run.
+//
+// swagger:model adminMediaCleanupReportEntry
+type AdminMediaCleanupReportEntry struct {
+	// Number of files cleaned up.
+	//
+	// example: 10
+	Count int `json:"count"`
+	// Combined size in bytes of the files.
+	//
+	// example: 104857
+	Bytes int64 `json:"bytes"`
 }
 
 // AdminSendTestEmailRequest models a test email send request (woah).
 type AdminSendTestEmailRequest struct {
 	// Email address to send the test email to.
+	// Defaults to the requesting admin's address.
 	Email string `form:"email" json:"email" xml:"email"`
 }
 
@@ -229,3 +312,33 @@ type DebugAPUrlResponse struct {
 	// may be an error, may be both!
 	ResponseBody string `json:"response_body"`
 }
+
+// DebugStorageResponse provides the
+// outcome of a storage health probe.
+//
+// swagger:model debugStorageResponse
+type DebugStorageResponse struct {
+	// Storage backend that was probed.
+	//
+	// example: s3
+	Backend string `json:"backend"`
+	// Whether the probe succeeded.
+	Healthy bool `json:"healthy"`
+	// Time the probe was started (ISO 8601 Datetime).
+	//
+	// example: 2021-07-30T09:20:25+00:00
+	CheckedAt string `json:"checked_at"`
+	// Time taken by the probe, in milliseconds.
+	//
+	// example: 42
+	LatencyMS int64 `json:"latency_ms"`
+	// Free space in bytes on the local storage filesystem.
+	// Omitted if not known, or not using local storage.
+	//
+	// example: 10737418240
+	FreeBytes *int64 `json:"free_bytes,omitempty"`
+	// Why the probe failed, if it did.
+	//
+	// example: storage rejected credentials (check storage-s3-access-key and storage-s3-secret-key, and that they may access storage-s3-bucket): error writing probe object: Access Denied.
+	Error string `json:"error,omitempty"`
+}
@@ -64,3 +64,15 @@ type Card struct {
 	// A hash computed by the BlurHash algorithm, for generating colorful preview thumbnails when media has not been downloaded yet.
 	Blurhash string `json:"blurhash"`
 }
+
+// TrendsLink represents a link that is trending on this instance.
+//
+// swagger:model trendsLink
+type TrendsLink struct {
+	Card
+
+	// History of this link's usage in trending
+	// public statuses over the past week, starting
+	// with today.
+	History []History `json:"history"`
+}
@@ -32,6 +32,9 @@ type Content struct {
 	ContentLength int64
 	// Time when the content was last updated.
 	ContentUpdated time.Time
+	// Quoted entity tag identifying this
+	// exact version of the content.
+	ContentETag string
 	// Actual content
 	Content io.ReadCloser
 	// Resource URL to forward to if the file can be fetched from the storage directly (e.g signed S3 URL)
@@ -48,4 +51,7 @@ type GetContentRequestForm struct {
 	MediaSize string
 	// Filename of the content
 	FileName string
+	// PreferJPEG is set if the requester would rather
+	// be served the JPEG copy of a WebP thumbnail.
+	PreferJPEG bool
 }
@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AccountArchive represents an export of all of
+// the requesting account's data, as a zip archive.
+//
+// swagger:model accountArchive
+type AccountArchive struct {
+	// The ID of the archive.
+	// example: 01HT0GQ2RSB0DGT2TGSAH5QSQ6
+	ID string `json:"id"`
+	// When the archive was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// How far along generation of the archive is.
+	// One of: outbox, bundle, complete, failed.
+	// example: complete
+	State string `json:"state"`
+	// Number of statuses written to the archive so far.
+	StatusesCount int `json:"statuses_count"`
+	// Link from which the archive can be downloaded, once complete.
+	// example: https://example.org/fileserver/01FBVD42CQ3ZEEVMW180SBX03B/archive/original/01HT0GQ2RSB0DGT2TGSAH5QSQ6.zip?token=ee24f71d-e615-43f9-afae-385c0799b7fa
+	URL *string `json:"url"`
+	// When the download link expires (ISO 8601 Datetime), once complete.
+	// example: 2021-08-06T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+}
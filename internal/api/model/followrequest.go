@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// FollowRequestAccount models an account that has requested to
+// follow the requesting account, along with details of the request.
+//
+// swagger:model followRequestAccount
+type FollowRequestAccount struct {
+	*Account
+	// When the follow request was made (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FollowRequestedAt string `json:"follow_requested_at"`
+	// Note or reason sent along with the follow
+	// request by the requester's server, if any.
+	//
+	// Omitted from json if empty / not set.
+	FollowRequestNote string `json:"follow_request_note,omitempty"`
+}
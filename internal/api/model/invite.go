@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminInvite represents an invite to sign up on this instance, as visible to admins.
+//
+// swagger:model adminInvite
+type AdminInvite struct {
+	// The ID of the invite in the database.
+	// example: 01H88S6XYXH8VSB8CZWB2W3NPP
+	ID string `json:"id"`
+	// Code to give when signing up with this invite.
+	// example: 3kqzd8h6f1nvx2a7
+	Code string `json:"code"`
+	// ID of the account that created this invite.
+	// example: 01H88SBJ8XGTF5CAVW5SKSN9FD
+	CreatedByAccountID string `json:"created_by_account_id"`
+	// Time the invite was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time the invite expires (ISO 8601 Datetime), or null if it never expires.
+	// example: 2021-08-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+	// Maximum number of times this invite can be used, or 0 for no limit.
+	// example: 10
+	MaxUses int `json:"max_uses"`
+	// Number of times this invite has been used.
+	// example: 3
+	Uses int `json:"uses"`
+	// Role granted to users signing up with this invite, if any.
+	// example: moderator
+	Role string `json:"role,omitempty"`
+	// Invite is expired or used up, and can no longer be used to sign up.
+	Expired bool `json:"expired"`
+}
+
+// AdminInviteCreateRequest represents a request to create a new invite, made through the admin API.
+//
+// swagger:ignore
+type AdminInviteCreateRequest struct {
+	// Maximum number of times the invite can be used, or 0 for no limit.
+	MaxUses int `form:"max_uses" json:"max_uses" xml:"max_uses"`
+	// Number of seconds from now that the invite should expire, or 0 to never expire.
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+	// Role to grant to users signing up with the invite, if any.
+	// Must be one of "moderator" or "admin" if set.
+	Role string `form:"role" json:"role" xml:"role"`
+}
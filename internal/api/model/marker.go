@@ -51,13 +51,17 @@ const (
 type MarkerPostRequest struct {
 	Home                        *MarkerPostRequestMarker `json:"home"`
 	FormHomeLastReadID          string                   `form:"home[last_read_id]"`
+	FormHomeVersion             *int                     `form:"home[version]"`
 	Notifications               *MarkerPostRequestMarker `json:"notifications"`
 	FormNotificationsLastReadID string                   `form:"notifications[last_read_id]"`
+	FormNotificationsVersion    *int                     `form:"notifications[version]"`
 }
 
 type MarkerPostRequestMarker struct {
 	// The ID of the most recently viewed entity.
 	LastReadID string `json:"last_read_id"`
+	// The version of the marker the client last saw, if known.
+	Version *int `json:"version"`
 }
 
 // HomeLastReadID should be used instead of Home or FormHomeLastReadID.
@@ -75,3 +79,19 @@ func (r *MarkerPostRequest) NotificationsLastReadID() string {
 	}
 	return r.FormNotificationsLastReadID
 }
+
+// HomeVersion should be used instead of Home or FormHomeVersion.
+func (r *MarkerPostRequest) HomeVersion() *int {
+	if r.Home != nil {
+		return r.Home.Version
+	}
+	return r.FormHomeVersion
+}
+
+// NotificationsVersion should be used instead of Notifications or FormNotificationsVersion.
+func (r *MarkerPostRequest) NotificationsVersion() *int {
+	if r.Notifications != nil {
+		return r.Notifications.Version
+	}
+	return r.FormNotificationsVersion
+}
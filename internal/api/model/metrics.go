@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminMeasure represents one quantitative
+// measure of the instance over a period of time.
+//
+// swagger:model adminMeasure
+type AdminMeasure struct {
+	// The key of the measure.
+	// example: new_users
+	Key string `json:"key"`
+	// Unit of the measure, if any.
+	Unit *string `json:"unit"`
+	// Total value of the measure over the whole period.
+	// example: 12
+	Total string `json:"total"`
+	// Total value of the measure over the period of
+	// equal length immediately preceding this one.
+	// example: 7
+	PreviousTotal string `json:"previous_total"`
+	// Value of the measure for each day in the period.
+	Data []AdminMeasureData `json:"data"`
+}
+
+// AdminMeasureData represents the value of
+// a measure on one day.
+//
+// swagger:model adminMeasureData
+type AdminMeasureData struct {
+	// Midnight (UTC) on the day that this value covers (ISO 8601 Datetime).
+	// example: 2021-07-30T00:00:00.000Z
+	Date string `json:"date"`
+	// Value of the measure on this day.
+	// example: 3
+	Value string `json:"value"`
+}
+
+// AdminDimension represents one qualitative
+// breakdown of the instance over a period of time.
+//
+// swagger:model adminDimension
+type AdminDimension struct {
+	// The key of the dimension.
+	// example: languages
+	Key string `json:"key"`
+	// Top entries of the dimension, largest first.
+	Data []AdminDimensionData `json:"data"`
+}
+
+// AdminDimensionData represents one
+// entry in a dimension.
+//
+// swagger:model adminDimensionData
+type AdminDimensionData struct {
+	// The key of this entry.
+	// example: en
+	Key string `json:"key"`
+	// Human-readable key of this entry.
+	// example: English
+	HumanKey string `json:"human_key"`
+	// Value of this entry.
+	// example: 42
+	Value string `json:"value"`
+}
+
+// AdminMeasuresRequest models an admin measures request.
+//
+// swagger:ignore
+type AdminMeasuresRequest struct {
+	// Keys of the measures to get.
+	Keys []string `form:"keys[]" json:"keys" xml:"keys"`
+	// Start of the period, as a date (eg., 2006-01-02).
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period (inclusive), as a date (eg., 2006-01-02).
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+}
+
+// AdminDimensionsRequest models an admin dimensions request.
+//
+// swagger:ignore
+type AdminDimensionsRequest struct {
+	// Keys of the dimensions to get.
+	Keys []string `form:"keys[]" json:"keys" xml:"keys"`
+	// Start of the period, as a date (eg., 2006-01-02).
+	StartAt string `form:"start_at" json:"start_at" xml:"start_at"`
+	// End of the period (inclusive), as a date (eg., 2006-01-02).
+	EndAt string `form:"end_at" json:"end_at" xml:"end_at"`
+	// Maximum number of entries to return per dimension.
+	Limit int `form:"limit" json:"limit" xml:"limit"`
+}
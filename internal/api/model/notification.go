@@ -33,6 +33,7 @@ type Notification struct {
 	// 	favourite = Someone favourited one of your statuses
 	// 	poll = A poll you have voted in or created has ended
 	// 	status = Someone you enabled notifications for has posted a status
+	// 	update = A status you boosted or favourited has been edited
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
@@ -65,3 +66,12 @@ func (n *Notification) GetBoostOfID() string {
 func (n *Notification) GetBoostOfAccountID() string {
 	return ""
 }
+
+// NotificationsUnreadCount represents the amount of
+// notifications which the requester has not yet read.
+//
+// swagger:model notificationsUnreadCount
+type NotificationsUnreadCount struct {
+	// Amount of unread notifications, capped at the requested limit.
+	Count int `json:"count"`
+}
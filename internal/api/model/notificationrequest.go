@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// NotificationPolicy represents which notifications
+// are filtered into notification requests for the
+// requesting account, instead of being shown directly.
+//
+// swagger:model notificationPolicy
+type NotificationPolicy struct {
+	// Filter notifications from accounts you don't follow.
+	FilterNotFollowing bool `json:"filter_not_following"`
+	// Filter notifications from accounts created in the last 30 days.
+	FilterNewAccounts bool `json:"filter_new_accounts"`
+	// Summary of filtered notifications.
+	Summary NotificationPolicySummary `json:"summary"`
+}
+
+// NotificationPolicySummary summarizes
+// currently pending notification requests.
+//
+// swagger:model notificationPolicySummary
+type NotificationPolicySummary struct {
+	// Number of pending notification requests.
+	PendingRequestsCount int `json:"pending_requests_count"`
+	// Number of notifications held in pending notification requests.
+	PendingNotificationsCount int `json:"pending_notifications_count"`
+}
+
+// UpdateNotificationPolicyRequest models an update to the notification policy of an account.
+//
+// swagger:ignore
+type UpdateNotificationPolicyRequest struct {
+	// Filter notifications from accounts you don't follow.
+	FilterNotFollowing *bool `form:"filter_not_following" json:"filter_not_following"`
+	// Filter notifications from accounts created in the last 30 days.
+	FilterNewAccounts *bool `form:"filter_new_accounts" json:"filter_new_accounts"`
+}
+
+// NotificationRequest represents a group of notifications
+// from one account that were filtered out by the requesting
+// account's notification policy.
+//
+// swagger:model notificationRequest
+type NotificationRequest struct {
+	// The id of the notification request in the database.
+	ID string `json:"id"`
+	// When the first filtered notification from this account was received (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// When the last filtered notification from this account was received (ISO 8601 Datetime).
+	UpdatedAt string `json:"updated_at"`
+	// The account that the filtered notifications originate from.
+	Account *Account `json:"account"`
+	// Number of filtered notifications from this account.
+	// Provided as a string, for compatibility with Mastodon.
+	NotificationsCount string `json:"notifications_count"`
+}
@@ -128,3 +128,21 @@ type WebPollOption struct {
 	// String-formatted version of VoteShare.
 	VoteShareStr string
 }
+
+// WebPoll models template-ready poll
+// information not found on the Poll itself.
+//
+// swagger:ignore
+type WebPoll struct {
+	// Poll no longer accepts votes, either
+	// because it expired or was closed.
+	Expired bool
+
+	// Human-readable expiry of the poll,
+	// eg., "closes in 3 hours" or "closed".
+	ExpiryStr string
+
+	// Total number of accounts that
+	// have voted in the poll so far.
+	VotersCount int
+}
@@ -39,3 +39,18 @@ type Preferences struct {
 	// Whether gifs should automatically play.
 	ReadingAutoPlayGifs bool `json:"reading:autoplay:gifs"`
 }
+
+// Values for the reading:expand:media preference.
+const (
+	ExpandMediaDefault = "default"  // Hide media marked as sensitive.
+	ExpandMediaShowAll = "show_all" // Always show all media.
+	ExpandMediaHideAll = "hide_all" // Always hide all media.
+)
+
+// ExpandMediaSupported is the list of values
+// that may be used for reading:expand:media.
+var ExpandMediaSupported = []string{
+	ExpandMediaDefault,
+	ExpandMediaShowAll,
+	ExpandMediaHideAll,
+}
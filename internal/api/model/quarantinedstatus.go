@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminQuarantinedStatus represents an incoming status which was
+// identified as spam, and is held in quarantine for admin review.
+//
+// swagger:model adminQuarantinedStatus
+type AdminQuarantinedStatus struct {
+	// The ID of the quarantined status in the database.
+	// example: 01H88S6XYXH8VSB8CZWB2W3NPP
+	ID string `json:"id"`
+	// Time the status was quarantined (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ActivityPub URI of the status.
+	// example: https://example.org/users/someone/statuses/01H88S6XYXH8VSB8CZWB2W3NPP
+	URI string `json:"uri"`
+	// The remote account that sent the status.
+	Account *AdminAccountInfo `json:"account"`
+	// The local account the status was sent to.
+	ReceivingAccount *AdminAccountInfo `json:"receiving_account"`
+	// Why the status was identified as spam.
+	// example: status has attachment(s)
+	Reason string `json:"reason"`
+	// Subject, summary, or content warning for the status.
+	SpoilerText string `json:"spoiler_text"`
+	// The content of the status, as sanitized HTML.
+	Content string `json:"content"`
+}
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminRelay represents a relay followed by this instance, as visible to admins.
+//
+// swagger:model adminRelay
+type AdminRelay struct {
+	// The ID of the relay in the database.
+	// example: 01H88S6XYXH8VSB8CZWB2W3NPP
+	ID string `json:"id"`
+	// Inbox URL of the relay.
+	// example: https://relay.example.org/inbox
+	InboxURL string `json:"inbox_url"`
+	// State of the follow of this relay, either pending, accepted, or removed.
+	// example: accepted
+	State string `json:"state"`
+	// Time the relay was added (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// AdminRelayCreateRequest represents a request to follow a new relay, made through the admin API.
+//
+// swagger:ignore
+type AdminRelayCreateRequest struct {
+	// Inbox URL of the relay.
+	InboxURL string `form:"inbox_url" json:"inbox_url" xml:"inbox_url"`
+}
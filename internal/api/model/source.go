@@ -43,4 +43,49 @@ type Source struct {
 	//
 	// Omitted from json if empty / not set.
 	AlsoKnownAsURIs []string `json:"also_known_as_uris,omitempty"`
+	// Whether content warnings should be expanded by default when reading.
+	ExpandSpoilers bool `json:"expand_spoilers"`
+	// How media attachments should be displayed when reading.
+	//    default = Hide media marked as sensitive
+	//    show_all = Always show all media by default, regardless of sensitivity
+	//    hide_all = Always hide all media by default, regardless of sensitivity
+	ExpandMedia string `json:"expand_media"`
+	// Whether static versions of animated avatars, headers and emojis are served to this account.
+	DisableAnimation bool `json:"disable_animation"`
+	// Default visibility of boosts made by this account (public, unlisted or private).
+	//
+	// Omitted from json if not set, in which case boosts use the visibility of the boosted status.
+	BoostVisibility Visibility `json:"boost_visibility,omitempty"`
+	// Followers and following of this account are hidden from everyone else.
+	HideCollections bool `json:"hide_collections"`
+	// New follow requests do not create notifications for this account.
+	SuppressFollowRequestNotifications bool `json:"suppress_follow_request_notifications"`
+	// Edits of statuses boosted or favourited by this account do not create notifications for it.
+	SuppressStatusUpdateNotifications bool `json:"suppress_status_update_notifications"`
+	// Follow requests from accounts this account follows are accepted automatically.
+	AutoAcceptFollowedBack bool `json:"auto_accept_followed_back"`
+	// Follow requests from accounts on this instance are accepted automatically.
+	AutoAcceptLocal bool `json:"auto_accept_local"`
+	// Follow requests from accounts created more than this
+	// many days ago are accepted automatically. 0 if disabled.
+	AutoAcceptOlderThanDays int `json:"auto_accept_older_than_days"`
+	// Statuses of this account older than this many
+	// days are deleted automatically. 0 if disabled.
+	StatusesAutoDeleteAfterDays int `json:"statuses_auto_delete_after_days"`
+	// Pinned statuses are not deleted automatically.
+	StatusesAutoDeleteKeepPinned bool `json:"statuses_auto_delete_keep_pinned"`
+	// Statuses bookmarked by this account are not deleted automatically.
+	StatusesAutoDeleteKeepBookmarked bool `json:"statuses_auto_delete_keep_bookmarked"`
+	// Direct messages are not deleted automatically.
+	StatusesAutoDeleteKeepDirect bool `json:"statuses_auto_delete_keep_direct"`
+	// Statuses marked as sensitive are included in this account's RSS feed.
+	//
+	// Omitted from json if false.
+	RSSIncludeSensitive bool `json:"rss_include_sensitive,omitempty"`
+	// Max permitted characters (including content warning) for statuses
+	// posted by this account, if set to differ from the instance setting
+	// by an admin. Clients should otherwise use the instance configuration.
+	//
+	// Omitted from json if not set.
+	StatusesMaxChars int `json:"statuses_max_chars,omitempty"`
 }
@@ -72,12 +72,23 @@ type Status struct {
 	Bookmarked bool `json:"bookmarked"`
 	// This status has been pinned by the account viewing it (only relevant for your own statuses).
 	Pinned bool `json:"pinned"`
+	// This status is local-only, and will not be federated beyond this instance.
+	LocalOnly bool `json:"local_only,omitempty"`
+	// Policy describing who may interact with this status.
+	// Omitted if anyone may interact with this status.
+	InteractionPolicy *StatusInteractionPolicy `json:"interaction_policy,omitempty"`
 	// The content of this status. Should be HTML, but might also be plaintext in some cases.
 	// example: <p>Hey this is a status!</p>
 	Content string `json:"content"`
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog"`
+	// The status that this status quotes, if it is
+	// available and visible to the account viewing it.
+	Quote *Status `json:"quote,omitempty"`
+	// This status quotes another status, but the quoted status
+	// could not be fetched, or is not visible to the account viewing it.
+	QuoteUnavailable bool `json:"quote_unavailable,omitempty"`
 	// The application used to post this status, if visible.
 	Application *Application `json:"application,omitempty"`
 	// The account that authored this status.
@@ -96,6 +107,10 @@ type Status struct {
 	// The poll attached to the status.
 	// nullable: true
 	Poll *Poll `json:"poll"`
+	// Emoji reactions to this status (Misskey / Pleroma style),
+	// grouped by emoji. Only set if reactions are enabled on
+	// this instance, and the status has been reacted to.
+	Reactions []StatusReaction `json:"reactions,omitempty"`
 	// Plain-text source of a status. Returned instead of content when status is deleted,
 	// so the user may redraft from the source text without the client having to reverse-engineer
 	// the original text from the HTML content.
@@ -117,6 +132,13 @@ type Status struct {
 	// swagger:ignore
 	WebPollOptions []WebPollOption `json:"-"`
 
+	// Template-ready poll state, such as
+	// whether it's closed and a readable
+	// expiry. Nil for non-web statuses.
+	//
+	// swagger:ignore
+	WebPoll *WebPoll `json:"-"`
+
 	// Status is from a local account.
 	// Always false for non-web statuses.
 	//
@@ -161,6 +183,23 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusBoostRequest models a request to boost a status.
+//
+// swagger:ignore
+type StatusBoostRequest struct {
+	// Visibility of the boost (public, unlisted or private).
+	// Empty to use the boosting account's default boost visibility.
+	Visibility Visibility `form:"visibility" json:"visibility" xml:"visibility"`
+}
+
+// StatusPinsRequest models a request to change the order of pinned statuses.
+//
+// swagger:ignore
+type StatusPinsRequest struct {
+	// IDs of all pinned statuses, in the desired order, first on top.
+	StatusIDs []string `form:"status_ids[]" json:"status_ids" xml:"status_ids"`
+}
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:model statusCreateRequest
@@ -233,6 +272,13 @@ const (
 type AdvancedStatusCreateForm struct {
 	StatusCreateRequest
 	AdvancedVisibilityFlagsForm
+
+	// Idempotency key of this request. Retries of a request with
+	// the same key within an hour return the status created by the
+	// first, rather than creating a duplicate. Taken from header.
+	// in: header
+	// name: Idempotency-Key
+	IdempotencyKey string `form:"-" json:"-" xml:"-"`
 }
 
 // AdvancedVisibilityFlagsForm allows a few more advanced flags to be set on new statuses, in addition
@@ -242,12 +288,64 @@ type AdvancedStatusCreateForm struct {
 type AdvancedVisibilityFlagsForm struct {
 	// This status will be federated beyond the local timeline(s).
 	Federated *bool `form:"federated" json:"federated" xml:"federated"`
+	// This status will not be federated beyond this instance.
+	// Equivalent to setting federated to false.
+	LocalOnly *bool `form:"local_only" json:"local_only" xml:"local_only"`
 	// This status can be boosted/reblogged.
 	Boostable *bool `form:"boostable" json:"boostable" xml:"boostable"`
 	// This status can be replied to.
 	Replyable *bool `form:"replyable" json:"replyable" xml:"replyable"`
 	// This status can be liked/faved.
 	Likeable *bool `form:"likeable" json:"likeable" xml:"likeable"`
+	// Policy describing who may interact with this status.
+	// Where set, this further restricts boostable, replyable, and likeable.
+	InteractionPolicy *StatusInteractionPolicy `form:"interaction_policy" json:"interaction_policy" xml:"interaction_policy"`
+}
+
+// StatusInteractionPolicy describes which accounts
+// may reply to, boost, or like/fave a status.
+//
+// swagger:model statusInteractionPolicy
+type StatusInteractionPolicy struct {
+	// Which accounts may reply to this status.
+	Replies InteractionPolicyValue `form:"replies" json:"replies" xml:"replies"`
+	// Which accounts may boost this status.
+	Boosts InteractionPolicyValue `form:"boosts" json:"boosts" xml:"boosts"`
+	// Which accounts may like/fave this status.
+	Likes InteractionPolicyValue `form:"likes" json:"likes" xml:"likes"`
+}
+
+// InteractionPolicyValue describes which accounts may perform a certain interaction with a status.
+// Can be one of anyone, followers, mentioned, or nobody. Empty will default to anyone.
+//
+// swagger:enum interactionPolicyValue
+// swagger:type string
+type InteractionPolicyValue string
+
+const (
+	// InteractionPolicyAnyone permits any account that can see the status.
+	InteractionPolicyAnyone InteractionPolicyValue = "anyone"
+	// InteractionPolicyFollowers permits only followers of the author, and mentioned accounts.
+	InteractionPolicyFollowers InteractionPolicyValue = "followers"
+	// InteractionPolicyMentioned permits only accounts mentioned in the status.
+	InteractionPolicyMentioned InteractionPolicyValue = "mentioned"
+	// InteractionPolicyNobody permits no interactions.
+	InteractionPolicyNobody InteractionPolicyValue = "nobody"
+)
+
+// StatusSource represents the raw source of a status,
+// as originally submitted by the author when it was created.
+//
+// swagger:model statusSource
+type StatusSource struct {
+	// ID of the status.
+	ID string `json:"id"`
+	// Plain-text source of the status, before formatting.
+	Text string `json:"text"`
+	// Content warning / subject of the status.
+	SpoilerText string `json:"spoiler_text"`
+	// Content type used to parse the raw text of the status.
+	ContentType StatusContentType `json:"content_type"`
 }
 
 // StatusContentType is the content type with which to parse the submitted status.
@@ -263,3 +361,11 @@ const (
 	StatusContentTypeMarkdown StatusContentType = "text/markdown"
 	StatusContentTypeDefault                    = StatusContentTypePlain
 )
+
+// StatusContentTypesSupported is the list of content types that
+// may be used to parse a submitted status. It is advertised by
+// the instance endpoint, and used to validate submitted content types.
+var StatusContentTypesSupported = []string{
+	string(StatusContentTypePlain),
+	string(StatusContentTypeMarkdown),
+}
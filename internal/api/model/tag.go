@@ -27,8 +27,23 @@ type Tag struct {
 	// Web link to the hashtag.
 	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
-	// History of this hashtag's usage.
-	// Currently just a stub, if provided will always be an empty array.
-	// example: []
-	History *[]any `json:"history,omitempty"`
+	// History of this hashtag's usage in public statuses
+	// over the past week, starting with today.
+	History *[]History `json:"history,omitempty"`
+}
+
+// AdminTag represents a hashtag, along
+// with information only visible to admins.
+//
+// swagger:model adminTag
+type AdminTag struct {
+	Tag
+
+	// The ID of the hashtag in the database.
+	// example: 01F8MH75CBF9JFX4ZAD54N0W0R
+	ID string `json:"id"`
+	// Hashtag has been approved to be shown in trends.
+	Trendable bool `json:"trendable"`
+	// Hashtag is useable on this instance.
+	Usable bool `json:"usable"`
 }
@@ -33,3 +33,27 @@ type Token struct {
 	// example: 1627644520
 	CreatedAt int64 `json:"created_at"`
 }
+
+// TokenInfo represents an OAuth access token that
+// a user has authorized an application to use,
+// without revealing the token value itself.
+//
+// swagger:model tokenInfo
+type TokenInfo struct {
+	// Database ID of this token.
+	// example: 01F8MGTQW4DKTDF8SW5CT9HYGA
+	ID string `json:"id"`
+	// When the token was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the token was last used to authorize a request (ISO 8601 Datetime).
+	// Only updated about once per hour, so this is approximate.
+	// Omitted if the token has never been used.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsed string `json:"last_used,omitempty"`
+	// OAuth scopes granted by this token, space-separated.
+	// example: read write admin
+	Scope string `json:"scope"`
+	// Application that the token was granted to.
+	Application *Application `json:"application"`
+}
@@ -34,3 +34,15 @@ type PasswordChangeRequest struct {
 	// required: true
 	NewPassword string `form:"new_password" json:"new_password" xml:"new_password" validation:"required"`
 }
+
+// EmailConfirmationRequest models parameters
+// for resending an email confirmation email.
+//
+// swagger:parameters emailConfirmationResend
+type EmailConfirmationRequest struct {
+	// If set, change the (unconfirmed) email address of the account to this
+	// before sending, eg., to correct a typo made when creating the account.
+	//
+	// in: formData
+	Email string `form:"email" json:"email" xml:"email"`
+}
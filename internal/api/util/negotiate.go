@@ -52,6 +52,11 @@ var HTMLAcceptHeaders = []string{
 	TextHTML,
 }
 
+// CSVAcceptHeaders is a slice of offers that just contains text/csv types.
+var CSVAcceptHeaders = []string{
+	TextCSV,
+}
+
 // HTMLOrActivityPubHeaders matches text/html first, then activitypub types.
 // This is useful for user URLs that a user might go to in their browser,
 // but which should also be able to serve ActivityPub as a fallback.
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// pageIDs pages the given IDs (newest
+// first) the way a processor would,
+// and returns the result via JSONPage.
+func pageIDs(t *testing.T, ids []string, query string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "https://example.org/api/v1/things?"+query, nil)
+
+	page, errWithCode := paging.ParseIDPage(c, 1, 80, 40)
+	if errWithCode != nil {
+		t.Fatal(errWithCode)
+	}
+
+	if page.GetOrder().Ascending() {
+		// Page expects input
+		// in the page order.
+		asc := make([]string, len(ids))
+		for i, id := range ids {
+			asc[len(ids)-1-i] = id
+		}
+		ids = asc
+	}
+
+	ids = page.Page(ids)
+	if len(ids) == 0 {
+		apiutil.JSONPage(c, paging.EmptyResponse())
+		return recorder
+	}
+
+	items := make([]interface{}, len(ids))
+	for i, id := range ids {
+		items[i] = id
+	}
+
+	lo, hi := ids[len(ids)-1], ids[0]
+	apiutil.JSONPage(c, paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/things",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}))
+	return recorder
+}
+
+func TestJSONPage(t *testing.T) {
+	config.SetProtocol("https")
+	config.SetHost("example.org")
+
+	ids := []string{
+		"01HTR0J9ZQ7GQ3H1D2KFA6W8Z5",
+		"01HTR0HVG5Y3XQ0Q8S0QW7C8ZB",
+		"01HTR0H7FYX1XK7JY4W8M3Q2AD",
+	}
+
+	for _, test := range []struct {
+		name   string
+		ids    []string
+		query  string
+		link   string
+		result string
+	}{
+		{
+			name:   "empty",
+			ids:    nil,
+			query:  "limit=3",
+			link:   "",
+			result: `[]`,
+		},
+		{
+			name:   "exactly full",
+			ids:    ids,
+			query:  "limit=3",
+			link:   `<https://example.org/api/v1/things?limit=3&max_id=01HTR0H7FYX1XK7JY4W8M3Q2AD>; rel="next", <https://example.org/api/v1/things?limit=3&since_id=01HTR0J9ZQ7GQ3H1D2KFA6W8Z5>; rel="prev"`,
+			result: `["01HTR0J9ZQ7GQ3H1D2KFA6W8Z5","01HTR0HVG5Y3XQ0Q8S0QW7C8ZB","01HTR0H7FYX1XK7JY4W8M3Q2AD"]`,
+		},
+		{
+			name:   "partial",
+			ids:    ids,
+			query:  "limit=2&max_id=01HTR0J9ZQ7GQ3H1D2KFA6W8Z5",
+			link:   `<https://example.org/api/v1/things?limit=2&max_id=01HTR0H7FYX1XK7JY4W8M3Q2AD>; rel="next", <https://example.org/api/v1/things?limit=2&since_id=01HTR0HVG5Y3XQ0Q8S0QW7C8ZB>; rel="prev"`,
+			result: `["01HTR0HVG5Y3XQ0Q8S0QW7C8ZB","01HTR0H7FYX1XK7JY4W8M3Q2AD"]`,
+		},
+		{
+			name:   "paging up",
+			ids:    ids,
+			query:  "limit=2&min_id=01HTR0H7FYX1XK7JY4W8M3Q2AD",
+			link:   `<https://example.org/api/v1/things?limit=2&min_id=01HTR0J9ZQ7GQ3H1D2KFA6W8Z5>; rel="next", <https://example.org/api/v1/things?limit=2&max_id=01HTR0HVG5Y3XQ0Q8S0QW7C8ZB>; rel="prev"`,
+			result: `["01HTR0J9ZQ7GQ3H1D2KFA6W8Z5","01HTR0HVG5Y3XQ0Q8S0QW7C8ZB"]`,
+		},
+		{
+			name:   "beyond the end",
+			ids:    ids,
+			query:  "limit=3&max_id=01HTR0H7FYX1XK7JY4W8M3Q2AD",
+			link:   "",
+			result: `[]`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := pageIDs(t, test.ids, test.query)
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", recorder.Code)
+			}
+
+			if link := recorder.Header().Get("Link"); link != test.link {
+				t.Errorf("unexpected link header:\nexpected: %s\nactual:   %s", test.link, link)
+			}
+
+			if result := recorder.Body.String(); result != test.result {
+				t.Errorf("unexpected result:\nexpected: %s\nactual:   %s", test.result, result)
+			}
+		})
+	}
+}
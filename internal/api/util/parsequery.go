@@ -45,6 +45,11 @@ const (
 
 	OnlyOtherAccountsKey = "only_other_accounts"
 
+	/* Directory keys */
+
+	DirectoryOffsetKey = "offset"
+	DirectoryOrderKey  = "order"
+
 	/* Search keys */
 
 	SearchExcludeUnreviewedKey = "exclude_unreviewed"
@@ -59,6 +64,10 @@ const (
 
 	TagNameKey = "tag_name"
 
+	/* Trends keys */
+
+	TrendsOffsetKey = "offset"
+
 	/* Web endpoint keys */
 
 	WebUsernameKey = "username"
@@ -68,6 +77,17 @@ const (
 
 	DomainPermissionExportKey = "export"
 	DomainPermissionImportKey = "import"
+
+	/* Sign up keys */
+
+	InviteKey = "invite"
+)
+
+const (
+	/* Directory order values */
+
+	DirectoryOrderActive = "active"
+	DirectoryOrderNew    = "new"
 )
 
 /*
@@ -107,6 +127,29 @@ func ParseSearchOffset(value string, defaultValue int, max, min int) (int, gtser
 	return parseInt(value, defaultValue, max, min, SearchOffsetKey)
 }
 
+func ParseDirectoryOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, DirectoryOffsetKey)
+}
+
+func ParseDirectoryOrder(value string, defaultValue string) (string, gtserror.WithCode) {
+	switch value {
+	case "":
+		return defaultValue, nil
+	case DirectoryOrderActive, DirectoryOrderNew:
+		return value, nil
+	default:
+		err := fmt.Errorf(
+			"invalid %s, valid values are [%s, %s]",
+			DirectoryOrderKey, DirectoryOrderActive, DirectoryOrderNew,
+		)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+}
+
+func ParseTrendsOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, TrendsOffsetKey)
+}
+
 func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, SearchResolveKey)
 }
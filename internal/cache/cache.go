@@ -41,6 +41,11 @@ type Caches struct {
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
 
+	// StatusCore provides access to the cache of
+	// viewer-independent frontend status models.
+	// (used by the type converter).
+	StatusCore StatusCoreCache
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -53,10 +58,12 @@ func (c *Caches) Init() {
 	c.initAccount()
 	c.initAccountCounts()
 	c.initAccountNote()
+	c.initAccountStats()
 	c.initApplication()
 	c.initBlock()
 	c.initBlockIDs()
 	c.initBoostOfIDs()
+	c.initDerefFailure()
 	c.initDomainAllow()
 	c.initDomainBlock()
 	c.initEmoji()
@@ -65,7 +72,9 @@ func (c *Caches) Init() {
 	c.initFollowIDs()
 	c.initFollowRequest()
 	c.initFollowRequestIDs()
+	c.initIdempotencyKeys()
 	c.initInReplyToIDs()
+	c.initInboxActivities()
 	c.initInstance()
 	c.initList()
 	c.initListEntry()
@@ -82,10 +91,14 @@ func (c *Caches) Init() {
 	c.initTag()
 	c.initThreadMute()
 	c.initStatusFaveIDs()
+	c.initStatusReaction()
+	c.initStatusReactionIDs()
 	c.initTombstone()
 	c.initUser()
 	c.initWebfinger()
+	c.initFinger()
 	c.initVisibility()
+	c.initStatusCore()
 }
 
 // Start will start any caches that require a background
@@ -96,6 +109,22 @@ func (c *Caches) Start() {
 	tryUntil("starting *gtsmodel.Webfinger cache", 5, func() bool {
 		return c.GTS.Webfinger.Start(5 * time.Minute)
 	})
+
+	tryUntil("starting webfinger result cache", 5, func() bool {
+		return c.GTS.Finger.Start(5 * time.Minute)
+	})
+
+	tryUntil("starting dereference failure cache", 5, func() bool {
+		return c.GTS.DerefFailure.Start(5 * time.Minute)
+	})
+
+	tryUntil("starting idempotency key cache", 5, func() bool {
+		return c.GTS.IdempotencyKeys.Start(5 * time.Minute)
+	})
+
+	tryUntil("starting inbox activity cache", 5, func() bool {
+		return c.GTS.InboxActivities.Start(5 * time.Minute)
+	})
 }
 
 // Stop will stop any caches that require a background
@@ -104,6 +133,10 @@ func (c *Caches) Stop() {
 	log.Infof(nil, "stop: %p", c)
 
 	tryUntil("stopping *gtsmodel.Webfinger cache", 5, c.GTS.Webfinger.Stop)
+	tryUntil("stopping webfinger result cache", 5, c.GTS.Finger.Stop)
+	tryUntil("stopping dereference failure cache", 5, c.GTS.DerefFailure.Stop)
+	tryUntil("stopping idempotency key cache", 5, c.GTS.IdempotencyKeys.Stop)
+	tryUntil("stopping inbox activity cache", 5, c.GTS.InboxActivities.Stop)
 }
 
 // Sweep will sweep all the available caches to ensure none
@@ -115,6 +148,7 @@ func (c *Caches) Stop() {
 func (c *Caches) Sweep(threshold float64) {
 	c.GTS.Account.Trim(threshold)
 	c.GTS.AccountNote.Trim(threshold)
+	c.GTS.AccountStats.Trim(threshold)
 	c.GTS.Block.Trim(threshold)
 	c.GTS.BlockIDs.Trim(threshold)
 	c.GTS.Emoji.Trim(threshold)
@@ -134,9 +168,11 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.Report.Trim(threshold)
 	c.GTS.Status.Trim(threshold)
 	c.GTS.StatusFave.Trim(threshold)
+	c.GTS.StatusReaction.Trim(threshold)
 	c.GTS.Tag.Trim(threshold)
 	c.GTS.ThreadMute.Trim(threshold)
 	c.GTS.Tombstone.Trim(threshold)
 	c.GTS.User.Trim(threshold)
 	c.Visibility.Trim(threshold)
+	c.StatusCore.Trim(threshold)
 }
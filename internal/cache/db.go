@@ -43,6 +43,9 @@ type GTSCaches struct {
 		Pinned   int
 	}]
 
+	// AccountStats provides access to the gtsmodel AccountStats database cache.
+	AccountStats structr.Cache[*gtsmodel.AccountStats]
+
 	// Application provides access to the gtsmodel Application database cache.
 	Application structr.Cache[*gtsmodel.Application]
 
@@ -132,6 +135,12 @@ type GTSCaches struct {
 	// StatusFaveIDs provides access to the status fave IDs list database cache.
 	StatusFaveIDs *SliceCache[string]
 
+	// StatusReaction provides access to the gtsmodel StatusReaction database cache.
+	StatusReaction structr.Cache[*gtsmodel.StatusReaction]
+
+	// StatusReactionIDs provides access to the status reaction IDs list database cache.
+	StatusReactionIDs *SliceCache[string]
+
 	// Tag provides access to the gtsmodel Tag database cache.
 	Tag structr.Cache[*gtsmodel.Tag]
 
@@ -147,6 +156,70 @@ type GTSCaches struct {
 	// Webfinger provides access to the webfinger URL cache.
 	// TODO: move out of GTS caches since unrelated to DB.
 	Webfinger *ttl.Cache[string, string] // TTL=24hr, sweep=5min
+
+	// Finger provides access to the webfinger result cache,
+	// keyed by "username@domain" of the fingered account.
+	// TODO: move out of GTS caches since unrelated to DB.
+	Finger *ttl.Cache[string, CachedFinger] // TTL=5min, sweep=5min
+
+	// DerefFailure provides access to the cache of recently
+	// failed dereferences, keyed by the dereferenced URI.
+	// TODO: move out of GTS caches since unrelated to DB.
+	DerefFailure *ttl.Cache[string, CachedDerefFailure] // TTL=1hr, sweep=5min
+
+	// IdempotencyKeys provides access to the cache of status
+	// IDs created with a client provided idempotency key,
+	// keyed by "accountID/key" of the creating account.
+	// TODO: move out of GTS caches since unrelated to DB.
+	IdempotencyKeys *ttl.Cache[string, string] // TTL=1hr, sweep=5min
+
+	// InboxActivities provides access to the cache of recently
+	// received inbox activities, keyed as "type receiver actor iri".
+	// TODO: move out of GTS caches since unrelated to DB.
+	InboxActivities *ttl.Cache[string, struct{}] // TTL=1hr, sweep=5min
+}
+
+// CachedFinger represents a cached webfinger lookup result.
+type CachedFinger struct {
+	// Domain is the discovered account domain.
+	Domain string
+
+	// URI is the discovered account ActivityPub URI.
+	URI string
+}
+
+// DerefFailureClass classifies the reason
+// for a failed dereference of a remote URI.
+type DerefFailureClass string
+
+const (
+	// DerefFailureUnavailable indicates that the remote
+	// could not be reached, timed out, or had a server
+	// error. This is usually transient.
+	DerefFailureUnavailable DerefFailureClass = "unavailable"
+
+	// DerefFailureForbidden indicates that the
+	// remote refused access to the given URI.
+	DerefFailureForbidden DerefFailureClass = "forbidden"
+
+	// DerefFailureNotFound indicates that the given URI
+	// (or its host) does not exist, or is gone.
+	DerefFailureNotFound DerefFailureClass = "not found"
+)
+
+// CachedDerefFailure represents a cached failed dereference.
+type CachedDerefFailure struct {
+	// Class is the class of failure.
+	Class DerefFailureClass
+
+	// StatusCode is the HTTP status
+	// code of the failure, if any.
+	StatusCode int
+
+	// Expires is when the failure should
+	// no longer prevent a new dereference.
+	// This may be earlier than cache TTL.
+	Expires time.Time
 }
 
 // NOTE:
@@ -248,6 +321,32 @@ func (c *Caches) initAccountNote() {
 	})
 }
 
+func (c *Caches) initAccountStats() {
+	// Simply use size of accounts cache,
+	// as this cache will be very small.
+	cap := c.GTS.Account.Cap()
+	if cap == 0 {
+		panic("must be initialized before accounts")
+	}
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.AccountStats) *gtsmodel.AccountStats {
+		s2 := new(gtsmodel.AccountStats)
+		*s2 = *s1
+		return s2
+	}
+
+	c.GTS.AccountStats.Init(structr.Config[*gtsmodel.AccountStats]{
+		Indices: []structr.IndexConfig{
+			{Fields: "AccountID"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		CopyValue: copyF,
+	})
+}
+
 func (c *Caches) initApplication() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -885,6 +984,7 @@ func (c *Caches) initStatus() {
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
+		s2.QuoteOf = nil
 		s2.Poll = nil
 		s2.Attachments = nil
 		s2.Tags = nil
@@ -961,6 +1061,58 @@ func (c *Caches) initStatusFaveIDs() {
 	)}
 }
 
+func (c *Caches) initStatusReaction() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofStatusReaction(), // model in-mem size.
+		config.GetCacheStatusReactionMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(r1 *gtsmodel.StatusReaction) *gtsmodel.StatusReaction {
+		r2 := new(gtsmodel.StatusReaction)
+		*r2 = *r1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/statusreaction.go.
+		r2.Account = nil
+		r2.TargetAccount = nil
+		r2.Status = nil
+		r2.Emoji = nil
+
+		return r2
+	}
+
+	c.GTS.StatusReaction.Init(structr.Config[*gtsmodel.StatusReaction]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URI"},
+			{Fields: "AccountID", Multiple: true},
+			{Fields: "StatusID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		CopyValue:  copyF,
+		Invalidate: c.OnInvalidateStatusReaction,
+	})
+}
+
+func (c *Caches) initStatusReactionIDs() {
+	// Calculate maximum cache size.
+	cap := calculateSliceCacheMax(
+		config.GetCacheStatusReactionIDsMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.StatusReactionIDs = &SliceCache[string]{Cache: simple.New[string, []string](
+		0,
+		cap,
+	)}
+}
+
 func (c *Caches) initTag() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1068,6 +1220,7 @@ func (c *Caches) initUser() {
 			{Fields: "Email"},
 			{Fields: "ConfirmationToken"},
 			{Fields: "ExternalID"},
+			{Fields: "DeletionCancelToken"},
 		},
 		MaxSize:    cap,
 		IgnoreErr:  ignoreErrors,
@@ -1092,3 +1245,71 @@ func (c *Caches) initWebfinger() {
 		24*time.Hour,
 	)
 }
+
+func (c *Caches) initFinger() {
+	// Calculate maximum cache size.
+	cap := calculateCacheMax(
+		sizeofURIStr, 2*sizeofURIStr,
+		config.GetCacheFingerMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.Finger = new(ttl.Cache[string, CachedFinger])
+	c.GTS.Finger.Init(
+		0,
+		cap,
+		5*time.Minute,
+	)
+}
+
+func (c *Caches) initIdempotencyKeys() {
+	// Calculate maximum cache size.
+	cap := calculateCacheMax(
+		2*sizeofIDStr, sizeofIDStr,
+		config.GetCacheIdempotencyKeyMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.IdempotencyKeys = new(ttl.Cache[string, string])
+	c.GTS.IdempotencyKeys.Init(
+		0,
+		cap,
+		time.Hour,
+	)
+}
+
+func (c *Caches) initInboxActivities() {
+	// Calculate maximum cache size.
+	cap := calculateCacheMax(
+		sizeofIDStr+2*sizeofURIStr, 0,
+		config.GetCacheInboxActivityMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.InboxActivities = new(ttl.Cache[string, struct{}])
+	c.GTS.InboxActivities.Init(
+		0,
+		cap,
+		time.Hour,
+	)
+}
+
+func (c *Caches) initDerefFailure() {
+	// Calculate maximum cache size.
+	cap := calculateCacheMax(
+		sizeofURIStr, sizeofDerefFailure(),
+		config.GetCacheDerefFailureMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.DerefFailure = new(ttl.Cache[string, CachedDerefFailure])
+	c.GTS.DerefFailure.Init(
+		0,
+		cap,
+		time.Hour,
+	)
+}
@@ -134,6 +134,7 @@ func (c *Caches) OnInvalidateMedia(media *gtsmodel.MediaAttachment) {
 	if media.StatusID != "" {
 		// Invalidate cache of attaching status.
 		c.GTS.Status.Invalidate("ID", media.StatusID)
+		c.StatusCore.Invalidate("StatusID", media.StatusID)
 	}
 }
 
@@ -160,6 +161,9 @@ func (c *Caches) OnInvalidateStatus(status *gtsmodel.Status) {
 	// Invalidate status ID cached visibility.
 	c.Visibility.Invalidate("ItemID", status.ID)
 
+	// Invalidate converted status model.
+	c.StatusCore.Invalidate("StatusID", status.ID)
+
 	for _, id := range status.AttachmentIDs {
 		// Invalidate each media by the IDs we're aware of.
 		// This must be done as the status table is aware of
@@ -173,11 +177,17 @@ func (c *Caches) OnInvalidateStatus(status *gtsmodel.Status) {
 	if status.BoostOfID != "" {
 		// Invalidate boost ID list of the original status.
 		c.GTS.BoostOfIDs.Invalidate(status.BoostOfID)
+
+		// Invalidate converted original (contains no. boosts).
+		c.StatusCore.Invalidate("StatusID", status.BoostOfID)
 	}
 
 	if status.InReplyToID != "" {
 		// Invalidate in reply to ID list of original status.
 		c.GTS.InReplyToIDs.Invalidate(status.InReplyToID)
+
+		// Invalidate converted original (contains no. replies).
+		c.StatusCore.Invalidate("StatusID", status.InReplyToID)
 	}
 
 	if status.PollID != "" {
@@ -189,6 +199,14 @@ func (c *Caches) OnInvalidateStatus(status *gtsmodel.Status) {
 func (c *Caches) OnInvalidateStatusFave(fave *gtsmodel.StatusFave) {
 	// Invalidate status fave ID list for this status.
 	c.GTS.StatusFaveIDs.Invalidate(fave.StatusID)
+
+	// Invalidate converted status (contains no. faves).
+	c.StatusCore.Invalidate("StatusID", fave.StatusID)
+}
+
+func (c *Caches) OnInvalidateStatusReaction(reaction *gtsmodel.StatusReaction) {
+	// Invalidate status reaction ID list for this status.
+	c.GTS.StatusReactionIDs.Invalidate(reaction.StatusID)
 }
 
 func (c *Caches) OnInvalidateUser(user *gtsmodel.User) {
@@ -25,6 +25,7 @@ import (
 	"codeberg.org/gruf/go-cache/v3/simple"
 	"github.com/DmitriyVTitov/size"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -175,13 +176,17 @@ func totalOfRatios() float64 {
 		config.GetCacheBlockMemRatio() +
 		config.GetCacheBlockIDsMemRatio() +
 		config.GetCacheBoostOfIDsMemRatio() +
+		config.GetCacheDerefFailureMemRatio() +
 		config.GetCacheEmojiMemRatio() +
 		config.GetCacheEmojiCategoryMemRatio() +
+		config.GetCacheFingerMemRatio() +
 		config.GetCacheFollowMemRatio() +
 		config.GetCacheFollowIDsMemRatio() +
 		config.GetCacheFollowRequestMemRatio() +
 		config.GetCacheFollowRequestIDsMemRatio() +
+		config.GetCacheIdempotencyKeyMemRatio() +
 		config.GetCacheInReplyToIDsMemRatio() +
+		config.GetCacheInboxActivityMemRatio() +
 		config.GetCacheInstanceMemRatio() +
 		config.GetCacheListMemRatio() +
 		config.GetCacheListEntryMemRatio() +
@@ -195,50 +200,70 @@ func totalOfRatios() float64 {
 		config.GetCacheStatusMemRatio() +
 		config.GetCacheStatusFaveMemRatio() +
 		config.GetCacheStatusFaveIDsMemRatio() +
+		config.GetCacheStatusReactionMemRatio() +
+		config.GetCacheStatusReactionIDsMemRatio() +
 		config.GetCacheTagMemRatio() +
 		config.GetCacheThreadMuteMemRatio() +
 		config.GetCacheTombstoneMemRatio() +
 		config.GetCacheUserMemRatio() +
 		config.GetCacheWebfingerMemRatio() +
-		config.GetCacheVisibilityMemRatio()
+		config.GetCacheVisibilityMemRatio() +
+		config.GetCacheStatusCoreMemRatio()
 }
 
 func sizeofAccount() uintptr {
 	return uintptr(size.Of(&gtsmodel.Account{
-		ID:                      exampleID,
-		Username:                exampleUsername,
-		AvatarMediaAttachmentID: exampleID,
-		HeaderMediaAttachmentID: exampleID,
-		DisplayName:             exampleUsername,
-		Note:                    exampleText,
-		NoteRaw:                 exampleText,
-		Memorial:                func() *bool { ok := false; return &ok }(),
-		CreatedAt:               exampleTime,
-		UpdatedAt:               exampleTime,
-		FetchedAt:               exampleTime,
-		Bot:                     func() *bool { ok := true; return &ok }(),
-		Locked:                  func() *bool { ok := true; return &ok }(),
-		Discoverable:            func() *bool { ok := false; return &ok }(),
-		Privacy:                 gtsmodel.VisibilityFollowersOnly,
-		Sensitive:               func() *bool { ok := true; return &ok }(),
-		Language:                "fr",
-		URI:                     exampleURI,
-		URL:                     exampleURI,
-		InboxURI:                exampleURI,
-		OutboxURI:               exampleURI,
-		FollowersURI:            exampleURI,
-		FollowingURI:            exampleURI,
-		FeaturedCollectionURI:   exampleURI,
-		ActorType:               ap.ActorPerson,
-		PrivateKey:              &rsa.PrivateKey{},
-		PublicKey:               &rsa.PublicKey{},
-		PublicKeyURI:            exampleURI,
-		SensitizedAt:            exampleTime,
-		SilencedAt:              exampleTime,
-		SuspendedAt:             exampleTime,
-		HideCollections:         func() *bool { ok := true; return &ok }(),
-		SuspensionOrigin:        exampleID,
-		EnableRSS:               func() *bool { ok := true; return &ok }(),
+		ID:                                 exampleID,
+		Username:                           exampleUsername,
+		AvatarMediaAttachmentID:            exampleID,
+		HeaderMediaAttachmentID:            exampleID,
+		DisplayName:                        exampleUsername,
+		Note:                               exampleText,
+		NoteRaw:                            exampleText,
+		Memorial:                           func() *bool { ok := false; return &ok }(),
+		CreatedAt:                          exampleTime,
+		UpdatedAt:                          exampleTime,
+		FetchedAt:                          exampleTime,
+		Bot:                                func() *bool { ok := true; return &ok }(),
+		Locked:                             func() *bool { ok := true; return &ok }(),
+		Discoverable:                       func() *bool { ok := false; return &ok }(),
+		Privacy:                            gtsmodel.VisibilityFollowersOnly,
+		Sensitive:                          func() *bool { ok := true; return &ok }(),
+		Language:                           "fr",
+		URI:                                exampleURI,
+		URL:                                exampleURI,
+		InboxURI:                           exampleURI,
+		OutboxURI:                          exampleURI,
+		FollowersURI:                       exampleURI,
+		FollowingURI:                       exampleURI,
+		FeaturedCollectionURI:              exampleURI,
+		ActorType:                          ap.ActorPerson,
+		PrivateKey:                         &rsa.PrivateKey{},
+		PublicKey:                          &rsa.PublicKey{},
+		PublicKeyURI:                       exampleURI,
+		SensitizedAt:                       exampleTime,
+		SilencedAt:                         exampleTime,
+		SuspendedAt:                        exampleTime,
+		HideCollections:                    func() *bool { ok := true; return &ok }(),
+		SuppressFollowRequestNotifications: func() *bool { ok := true; return &ok }(),
+		SuppressStatusUpdateNotifications:  func() *bool { ok := true; return &ok }(),
+		AutoAcceptFollowedBack:             func() *bool { ok := true; return &ok }(),
+		AutoAcceptLocal:                    func() *bool { ok := true; return &ok }(),
+		AutoAcceptOlderThanDays:            30,
+		StatusesAutoDeleteAfterDays:        90,
+		StatusesAutoDeleteKeepPinned:       func() *bool { ok := true; return &ok }(),
+		StatusesAutoDeleteKeepBookmarked:   func() *bool { ok := true; return &ok }(),
+		StatusesAutoDeleteKeepDirect:       func() *bool { ok := true; return &ok }(),
+		NotificationsFilterNotFollowing:    func() *bool { ok := true; return &ok }(),
+		NotificationsFilterNewAccounts:     func() *bool { ok := true; return &ok }(),
+		SuspensionOrigin:                   exampleID,
+		EnableRSS:                          func() *bool { ok := true; return &ok }(),
+		RSSIncludeSensitive:                func() *bool { ok := true; return &ok }(),
+		ExpandSpoilers:                     func() *bool { ok := true; return &ok }(),
+		BoostVisibility:                    gtsmodel.VisibilityUnlocked,
+		ExpandMedia:                        "show_all",
+		DisableAnimation:                   func() *bool { ok := true; return &ok }(),
+		Theme:                              "light.css",
 	}))
 }
 
@@ -276,6 +301,14 @@ func sizeofBlock() uintptr {
 	}))
 }
 
+func sizeofDerefFailure() uintptr {
+	return uintptr(size.Of(&CachedDerefFailure{
+		Class:      DerefFailureUnavailable,
+		StatusCode: 503,
+		Expires:    exampleTime,
+	}))
+}
+
 func sizeofEmoji() uintptr {
 	return uintptr(size.Of(&gtsmodel.Emoji{
 		ID:                     exampleID,
@@ -332,6 +365,7 @@ func sizeofFollowRequest() uintptr {
 		ShowReblogs:     func() *bool { ok := true; return &ok }(),
 		URI:             exampleURI,
 		Notify:          func() *bool { ok := false; return &ok }(),
+		Note:            exampleText,
 	}))
 }
 
@@ -437,6 +471,7 @@ func sizeofNotification() uintptr {
 		OriginAccountID:  exampleID,
 		StatusID:         exampleID,
 		Read:             func() *bool { ok := false; return &ok }(),
+		Filtered:         func() *bool { ok := false; return &ok }(),
 	}))
 }
 
@@ -485,6 +520,7 @@ func sizeofStatus() uintptr {
 		URL:                      exampleURI,
 		Content:                  exampleText,
 		Text:                     exampleText,
+		ContentType:              "text/markdown",
 		AttachmentIDs:            []string{exampleID, exampleID, exampleID},
 		TagIDs:                   []string{exampleID, exampleID, exampleID},
 		MentionIDs:               []string{},
@@ -524,6 +560,20 @@ func sizeofStatusFave() uintptr {
 	}))
 }
 
+func sizeofStatusReaction() uintptr {
+	return uintptr(size.Of(&gtsmodel.StatusReaction{
+		ID:              exampleID,
+		CreatedAt:       exampleTime,
+		UpdatedAt:       exampleTime,
+		AccountID:       exampleID,
+		TargetAccountID: exampleID,
+		StatusID:        exampleID,
+		Content:         ":blobcat:",
+		EmojiID:         exampleID,
+		URI:             exampleURI,
+	}))
+}
+
 func sizeofTag() uintptr {
 	return uintptr(size.Of(&gtsmodel.Tag{
 		ID:        exampleID,
@@ -532,6 +582,7 @@ func sizeofTag() uintptr {
 		UpdatedAt: exampleTime,
 		Useable:   func() *bool { ok := true; return &ok }(),
 		Listable:  func() *bool { ok := true; return &ok }(),
+		Trendable: func() *bool { ok := true; return &ok }(),
 	}))
 }
 
@@ -564,6 +615,22 @@ func sizeofVisibility() uintptr {
 	}))
 }
 
+func sizeofStatusCore() uintptr {
+	return uintptr(size.Of(&CachedStatusCore{
+		StatusID:  exampleID,
+		UpdatedAt: exampleTime,
+		Status: &apimodel.Status{
+			ID:          exampleID,
+			CreatedAt:   exampleTime.Format(time.RFC3339),
+			SpoilerText: exampleText,
+			URI:         exampleURI,
+			URL:         exampleURI,
+			Content:     exampleText,
+			Text:        exampleText,
+		},
+	}))
+}
+
 func sizeofUser() uintptr {
 	return uintptr(size.Of(&gtsmodel.User{
 		ID:                     exampleID,
@@ -574,6 +641,7 @@ func sizeofUser() uintptr {
 		EncryptedPassword:      exampleTextSmall,
 		CurrentSignInAt:        exampleTime,
 		LastSignInAt:           exampleTime,
+		SignInLockedUntil:      exampleTime,
 		InviteID:               exampleID,
 		ChosenLanguages:        []string{"en", "fr", "jp"},
 		FilteredLanguages:      []string{"en", "fr", "jp"},
@@ -591,5 +659,7 @@ func sizeofUser() uintptr {
 		ResetPasswordToken:     exampleTextSmall,
 		ResetPasswordSentAt:    exampleTime,
 		ExternalID:             exampleID,
+		DeletionScheduledAt:    exampleTime,
+		DeletionCancelToken:    exampleTextSmall,
 	}))
 }
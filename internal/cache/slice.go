@@ -50,3 +50,41 @@ func (c *SliceCache[T]) Load(key string, load func() ([]T, error)) ([]T, error)
 	// Return data clone for safety.
 	return slices.Clone(data), nil
 }
+
+// LoadIn will attempt to load existing slices from the cache for each of the given keys, calling the provided load function once with all uncached keys and caching the results. Uncached keys missing from the load result are cached as empty.
+func (c *SliceCache[T]) LoadIn(keys []string, load func(uncached []string) (map[string][]T, error)) (map[string][]T, error) {
+	data := make(map[string][]T, len(keys))
+	uncached := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		// Look for slice in cache under this key.
+		if slice, ok := c.Get(key); ok {
+			data[key] = slices.Clone(slice)
+			continue
+		}
+		uncached = append(uncached, key)
+	}
+
+	if len(uncached) == 0 {
+		// All cached.
+		return data, nil
+	}
+
+	// Load all uncached keys.
+	loaded, err := load(uncached)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range uncached {
+		slice := loaded[key]
+
+		// Store the data.
+		c.Set(key, slice)
+
+		// Return data clone for safety.
+		data[key] = slices.Clone(slice)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"time"
+
+	"codeberg.org/gruf/go-structr"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+type StatusCoreCache struct {
+	structr.Cache[*CachedStatusCore]
+}
+
+func (c *Caches) initStatusCore() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofStatusCore(), // model in-mem size.
+		config.GetCacheStatusCoreMemRatio(),
+	)
+
+	log.Infof(nil, "StatusCore cache size = %d", cap)
+
+	copyF := func(s1 *CachedStatusCore) *CachedStatusCore {
+		s2 := new(CachedStatusCore)
+		*s2 = *s1
+
+		// NOTE: the frontend status itself is not
+		// copied here, it's treated as read-only
+		// and callers must copy before modifying.
+		return s2
+	}
+
+	c.StatusCore.Init(structr.Config[*CachedStatusCore]{
+		Indices: []structr.IndexConfig{
+			{Fields: "StatusID"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		CopyValue: copyF,
+	})
+}
+
+// CachedStatusCore represents the viewer-independent
+// part of a status' frontend representation, i.e.
+// everything but the requesting account's interactions,
+// poll votes, and any boosted or quoted status, as well
+// as the author account, application and emojis which
+// may change independently of the status itself.
+type CachedStatusCore struct {
+	// StatusID is the ID of the converted status.
+	StatusID string
+
+	// UpdatedAt is the last-updated time of
+	// the status at the time of conversion.
+	UpdatedAt time.Time
+
+	// Status is the converted
+	// frontend status core.
+	Status *apimodel.Status
+}
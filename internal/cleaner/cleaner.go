@@ -20,6 +20,8 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"codeberg.org/gruf/go-store/v2/storage"
@@ -38,6 +40,14 @@ type Cleaner struct {
 	state *state.State
 	emoji Emoji
 	media Media
+
+	// runMu ensures only one
+	// Run() executes at a time.
+	runMu sync.Mutex
+
+	// last is the report of
+	// the last finished Run().
+	last atomic.Pointer[Report]
 }
 
 func New(state *state.State) *Cleaner {
@@ -58,6 +68,35 @@ func (c *Cleaner) Media() *Media {
 	return &c.media
 }
 
+// Run will execute all cleaner.Media and cleaner.Emoji utilities synchronously,
+// keeping remote media fetched within maxRemoteDays, and return a report of
+// what was cleaned up. Only one run may execute at a time; others will wait.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (c *Cleaner) Run(ctx context.Context, maxRemoteDays int) *Report {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+
+	report := &Report{
+		DryRun:          gtscontext.DryRun(ctx),
+		RemoteCacheDays: maxRemoteDays,
+		StartedAt:       time.Now(),
+	}
+
+	ctx = withReport(ctx, report)
+	c.Media().All(ctx, maxRemoteDays)
+	c.Emoji().All(ctx, maxRemoteDays)
+	report.FinishedAt = time.Now()
+
+	c.last.Store(report)
+	return report
+}
+
+// LastReport returns the report of the most recently finished
+// Run since the server started, or nil if there hasn't been one.
+func (c *Cleaner) LastReport() *Report {
+	return c.last.Load()
+}
+
 // haveFiles returns whether all of the provided files exist within current storage.
 func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error) {
 	for _, file := range files {
@@ -150,9 +189,8 @@ func (c *Cleaner) ScheduleJobs() error {
 
 	fn := func(ctx context.Context, start time.Time) {
 		log.Info(ctx, "starting media clean")
-		c.Media().All(ctx, config.GetMediaRemoteCacheDays())
-		c.Emoji().All(ctx, config.GetMediaRemoteCacheDays())
-		log.Infof(ctx, "finished media clean after %s", time.Since(start))
+		report := c.Run(ctx, config.GetMediaRemoteCacheDays())
+		log.Infof(ctx, "finished media clean after %s, reclaimed %d bytes", time.Since(start), report.BytesReclaimed())
 	}
 
 	log.Infof(nil,
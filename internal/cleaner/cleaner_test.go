@@ -18,11 +18,14 @@
 package cleaner_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -70,6 +73,35 @@ func (suite *CleanerTestSuite) TearDownTest() {
 	testrig.StopWorkers(&suite.state)
 }
 
+func (suite *CleanerTestSuite) TestRunDryRunReport() {
+	ctx := gtscontext.SetDryRun(context.Background())
+
+	// Nothing should have run yet.
+	suite.Nil(suite.cleaner.LastReport())
+
+	// Count media before the dry run.
+	before, err := suite.state.DB.GetAttachments(ctx, &paging.Page{Limit: 200})
+	suite.NoError(err)
+
+	report := suite.cleaner.Run(ctx, 0)
+	suite.True(report.DryRun)
+	suite.Zero(report.RemoteCacheDays)
+	suite.False(report.FinishedAt.Before(report.StartedAt))
+
+	// With no cache days kept, remote
+	// attachments should have been found.
+	suite.NotZero(report.StaleRemoteAttachments.Count)
+	suite.NotZero(report.BytesReclaimed())
+
+	// Report should now be the last one.
+	suite.Same(report, suite.cleaner.LastReport())
+
+	// Dry run means no media were removed.
+	after, err := suite.state.DB.GetAttachments(ctx, &paging.Page{Limit: 200})
+	suite.NoError(err)
+	suite.Len(after, len(before))
+}
+
 // mapvals extracts a slice of values from the values contained within the map.
 func mapvals[Key comparable, Val any](m map[Key]Val) []Val {
 	var i int
@@ -129,6 +129,10 @@ func (e *Emoji) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 				// Update
 				// count.
 				total++
+
+				if r := reportFrom(ctx); r != nil {
+					r.StaleRemoteEmoji.add(emojiSize(emoji))
+				}
 			}
 		}
 	}
@@ -227,6 +231,10 @@ func (e *Emoji) PruneUnused(ctx context.Context) (int, error) {
 				// Update
 				// count.
 				total++
+
+				if r := reportFrom(ctx); r != nil {
+					r.UnusedEmoji.add(emojiSize(emoji))
+				}
 			}
 		}
 	}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -112,6 +113,14 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 		if orphaned {
 			// Add this orphaned entry.
 			files = append(files, path)
+
+			if r := reportFrom(ctx); r != nil {
+				size, err := m.fileSize(ctx, path)
+				if err != nil {
+					log.Warnf(ctx, "error getting size of %s: %v", path, err)
+				}
+				r.OrphanedLocal.add(size)
+			}
 		}
 
 		return nil
@@ -125,6 +134,7 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 
 // PruneUnused will delete all unused media attachments from the database and storage driver.
 // Media is marked as unused if not attached to any status, account or account is suspended.
+// Local media not yet attached to a status is only pruned once older than media-unattached-max-age.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) PruneUnused(ctx context.Context) (int, error) {
 	var (
@@ -165,6 +175,10 @@ func (m *Media) PruneUnused(ctx context.Context) (int, error) {
 				// Update
 				// count.
 				total++
+
+				if r := reportFrom(ctx); r != nil {
+					r.UnusedMedia.add(mediaSize(media))
+				}
 			}
 		}
 	}
@@ -211,6 +225,14 @@ func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, er
 				// Update
 				// count.
 				total++
+
+				if r := reportFrom(ctx); r != nil {
+					if *media.Avatar || *media.Header {
+						r.StaleAvatarsHeaders.add(mediaSize(media))
+					} else {
+						r.StaleRemoteAttachments.add(mediaSize(media))
+					}
+				}
 			}
 		}
 	}
@@ -376,6 +398,14 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	if media.StatusID == "" && media.RemoteURL == "" &&
+		time.Since(media.CreatedAt) < config.GetMediaUnattachedMaxAge() {
+		// Local media that was only recently uploaded may be
+		// yet to be attached to a status, give it some time.
+		l.Debug("skipping as recently uploaded")
+		return false, nil
+	}
+
 	// Media totally unused, delete it.
 	l.Debug("deleting unused media")
 	return true, m.delete(ctx, media)
@@ -422,10 +452,7 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	case !*media.Cached && exist:
 		// Remove files if we don't expect them to exist.
 		l.Debug("cached=false exists=true => deleting")
-		_, err := m.removeFiles(ctx,
-			media.Thumbnail.Path,
-			media.File.Path,
-		)
+		_, err := m.removeFiles(ctx, mediaFiles(media)...)
 		return true, err
 
 	default:
@@ -539,10 +566,7 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 	}
 
 	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	_, err := m.removeFiles(ctx, mediaFiles(media)...)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -564,10 +588,7 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 	}
 
 	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	_, err := m.removeFiles(ctx, mediaFiles(media)...)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -580,3 +601,19 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 
 	return nil
 }
+
+// mediaFiles returns the storage paths of the files
+// kept for media: the original, its thumbnail, and
+// the thumbnail's JPEG fallback copy if it has one.
+func mediaFiles(attachment *gtsmodel.MediaAttachment) []string {
+	files := []string{
+		attachment.File.Path,
+		attachment.Thumbnail.Path,
+	}
+
+	if fallback := media.ThumbnailFallbackPath(attachment.Thumbnail.Path); fallback != "" {
+		files = append(files, fallback)
+	}
+
+	return files
+}
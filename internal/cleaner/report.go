@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Report contains the outcome of one cleaning
+// run, with counts and sizes of the files that
+// were (or in a dry run, would be) cleaned up,
+// broken down by category.
+type Report struct {
+	// Whether this was a dry run,
+	// ie., nothing was removed.
+	DryRun bool
+
+	// Number of days of remote media kept.
+	RemoteCacheDays int
+
+	// When the run started and finished.
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	// Local files in storage with
+	// no related database entry.
+	OrphanedLocal ReportEntry
+
+	// Remote status attachments uncached
+	// for not being fetched recently.
+	StaleRemoteAttachments ReportEntry
+
+	// Remote avatars and headers uncached
+	// for not being fetched recently.
+	StaleAvatarsHeaders ReportEntry
+
+	// Media attachments deleted for
+	// being unused by any status/account.
+	UnusedMedia ReportEntry
+
+	// Remote emoji uncached for
+	// not being fetched recently.
+	StaleRemoteEmoji ReportEntry
+
+	// Remote emoji deleted for being
+	// unused by any status/account.
+	UnusedEmoji ReportEntry
+}
+
+// ReportEntry contains the number of files
+// cleaned up in one category of a Report,
+// along with their combined size in bytes.
+type ReportEntry struct {
+	Count int
+	Bytes int64
+}
+
+// add adds one item of given size to the entry.
+func (e *ReportEntry) add(bytes int) {
+	e.Count++
+	e.Bytes += int64(bytes)
+}
+
+// BytesReclaimed returns the combined size of
+// files cleaned up across all categories.
+func (r *Report) BytesReclaimed() int64 {
+	return r.OrphanedLocal.Bytes +
+		r.StaleRemoteAttachments.Bytes +
+		r.StaleAvatarsHeaders.Bytes +
+		r.UnusedMedia.Bytes +
+		r.StaleRemoteEmoji.Bytes +
+		r.UnusedEmoji.Bytes
+}
+
+type reportKey struct{}
+
+// withReport returns a context that
+// records cleaning outcomes to report.
+func withReport(ctx context.Context, report *Report) context.Context {
+	return context.WithValue(ctx, reportKey{}, report)
+}
+
+// reportFrom returns the report set on
+// context with withReport, if any.
+func reportFrom(ctx context.Context) *Report {
+	report, _ := ctx.Value(reportKey{}).(*Report)
+	return report
+}
+
+// mediaSize returns the combined size
+// of the given media's stored files.
+func mediaSize(media *gtsmodel.MediaAttachment) int {
+	return media.File.FileSize + media.Thumbnail.FileSize
+}
+
+// emojiSize returns the combined size
+// of the given emoji's stored files.
+func emojiSize(emoji *gtsmodel.Emoji) int {
+	return emoji.ImageFileSize + emoji.ImageStaticFileSize
+}
+
+// fileSize returns the size of the file at path in storage. As the
+// storage driver can't stat files, this reads the file out in full,
+// so should only be used where needed (ie., for orphaned files).
+func (c *Cleaner) fileSize(ctx context.Context, path string) (int, error) {
+	rc, err := c.state.Storage.GetStream(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	n, err := io.Copy(io.Discard, rc)
+	return int(n), err
+}
@@ -54,44 +54,64 @@ type Configuration struct {
 	ConfigPath         string   `name:"config-path" usage:"Path to a file containing gotosocial configuration. Values set in this file will be overwritten by values set as env vars or arguments"`
 	Host               string   `name:"host" usage:"Hostname to use for the server (eg., example.org, gotosocial.whatever.com). DO NOT change this on a server that's already run!"`
 	AccountDomain      string   `name:"account-domain" usage:"Domain to use in account names (eg., example.org, whatever.com). If not set, will default to the setting for host. DO NOT change this on a server that's already run!"`
+	HostAliases        []string `name:"host-aliases" usage:"Additional hostnames that this server is reachable on, to be treated as local alongside host and account-domain (eg., www.example.org)."`
 	Protocol           string   `name:"protocol" usage:"Protocol to use for the REST api of the server (only use http if you are debugging or behind a reverse proxy!)"`
 	BindAddress        string   `name:"bind-address" usage:"Bind address to use for the GoToSocial server (eg., 0.0.0.0, 172.138.0.9, [::], localhost). For ipv6, enclose the address in square brackets, eg [2001:db8::fed1]. Default binds to all interfaces."`
 	Port               int      `name:"port" usage:"Port to use for GoToSocial. Change this to 443 if you're running the binary directly on the host machine."`
 	TrustedProxies     []string `name:"trusted-proxies" usage:"Proxies to trust when parsing x-forwarded headers into real IPs."`
 	SoftwareVersion    string   `name:"software-version" usage:""`
 
-	DbType                   string        `name:"db-type" usage:"Database type: eg., postgres"`
-	DbAddress                string        `name:"db-address" usage:"Database ipv4 address, hostname, or filename"`
-	DbPort                   int           `name:"db-port" usage:"Database port"`
-	DbUser                   string        `name:"db-user" usage:"Database username"`
-	DbPassword               string        `name:"db-password" usage:"Database password"`
-	DbDatabase               string        `name:"db-database" usage:"Database name"`
-	DbTLSMode                string        `name:"db-tls-mode" usage:"Database tls mode"`
-	DbTLSCACert              string        `name:"db-tls-ca-cert" usage:"Path to CA cert for db tls connection"`
-	DbMaxOpenConnsMultiplier int           `name:"db-max-open-conns-multiplier" usage:"Multiplier to use per cpu for max open database connections. 0 or less is normalized to 1."`
-	DbSqliteJournalMode      string        `name:"db-sqlite-journal-mode" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_journal_mode"`
-	DbSqliteSynchronous      string        `name:"db-sqlite-synchronous" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_synchronous"`
-	DbSqliteCacheSize        bytesize.Size `name:"db-sqlite-cache-size" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_cache_size"`
-	DbSqliteBusyTimeout      time.Duration `name:"db-sqlite-busy-timeout" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_busy_timeout"`
+	DbType                    string        `name:"db-type" usage:"Database type: eg., postgres"`
+	DbAddress                 string        `name:"db-address" usage:"Database ipv4 address, hostname, or filename"`
+	DbPort                    int           `name:"db-port" usage:"Database port"`
+	DbUser                    string        `name:"db-user" usage:"Database username"`
+	DbPassword                string        `name:"db-password" usage:"Database password"`
+	DbDatabase                string        `name:"db-database" usage:"Database name"`
+	DbTLSMode                 string        `name:"db-tls-mode" usage:"Database tls mode"`
+	DbTLSCACert               string        `name:"db-tls-ca-cert" usage:"Path to CA cert for db tls connection"`
+	DbMaxOpenConnsMultiplier  int           `name:"db-max-open-conns-multiplier" usage:"Multiplier to use per cpu for max open database connections. 0 or less is normalized to 1."`
+	DbReplicaAddress          string        `name:"db-replica-address" usage:"Postgres only: address of a read-only replica to serve timeline, search and count queries from. Leave empty to use the primary database for everything."`
+	DbReplicaPort             int           `name:"db-replica-port" usage:"Postgres only: port of the read-only replica. 0 to use db-port."`
+	DbSqliteJournalMode       string        `name:"db-sqlite-journal-mode" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_journal_mode"`
+	DbSqliteSynchronous       string        `name:"db-sqlite-synchronous" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_synchronous"`
+	DbSqliteCacheSize         bytesize.Size `name:"db-sqlite-cache-size" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_cache_size"`
+	DbSqliteBusyTimeout       time.Duration `name:"db-sqlite-busy-timeout" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_busy_timeout"`
+	DbSqliteJournalSizeLimit  bytesize.Size `name:"db-sqlite-journal-size-limit" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_journal_size_limit"`
+	DbSqliteWALAutocheckpoint int           `name:"db-sqlite-wal-autocheckpoint" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_wal_autocheckpoint"`
+	DbFullTextSearch          bool          `name:"db-full-text-search" usage:"Maintain a full text search index of statuses authored, bookmarked or faved by local accounts, and use it for status searches. Uses additional storage."`
 
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
-
-	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsApprovalRequired bool `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
-	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	InstanceFederationMode                       string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter                 bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and quarantine messages identified as spam"`
+	InstanceFederationSpamFilterNewAccountDays   int                `name:"instance-federation-spam-filter-new-account-days" usage:"Spam filter: accounts created less than this many days ago are considered new, and are subject to the local mentions heuristic. 0 disables the heuristic."`
+	InstanceFederationSpamFilterMaxLocalMentions int                `name:"instance-federation-spam-filter-max-local-mentions" usage:"Spam filter: statuses from new accounts that mention more than this many local accounts which don't follow them are considered spam."`
+	InstanceFederationSpamFilterQuarantineDays   int                `name:"instance-federation-spam-filter-quarantine-days" usage:"Spam filter: number of days to keep statuses identified as spam in quarantine for admin review, before removing them."`
+	InstanceFederationAccountFreshness           time.Duration      `name:"instance-federation-account-freshness" usage:"Duration after which a remote account is considered stale, and is refreshed from its instance when next encountered. Minimum 1h."`
+	InstanceFederationStatusFreshness            time.Duration      `name:"instance-federation-status-freshness" usage:"Duration after which a remote status is considered stale, and is refreshed from its instance when next encountered. Minimum 30m."`
+	InstanceFederationThreadBackfillMaxItems     int                `name:"instance-federation-thread-backfill-max-items" usage:"Maximum number of replies to fetch from remote instances when backfilling a thread. 0 disables backfilling threads when they're viewed."`
+	InstanceExposePeers                          bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended                      bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb                   bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline                 bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceExposePublicRSS                      bool               `name:"instance-expose-public-rss" usage:"Serve an RSS feed at /feed.rss of public posts by local accounts that have enabled RSS for their own profile"`
+	InstanceRSSMaxItems                          int                `name:"instance-rss-max-items" usage:"Maximum number of items that can be requested from an RSS feed using the limit query parameter"`
+	InstanceDeliverToSharedInboxes               bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion                bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                            language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceTrendsEnabled                        bool               `name:"instance-trends-enabled" usage:"Track hashtag usage and calculate trending hashtags, statuses and links, served at /api/v1/trends. If false, trends endpoints return empty arrays."`
+
+	AccountsRegistrationOpen            bool          `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsApprovalRequired            bool          `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
+	AccountsReasonRequired              bool          `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsInvitesEnabled              bool          `name:"accounts-invites-enabled" usage:"Allow admins to create invite links. Signing up with a valid invite skips the approval queue, and works even when registration is closed."`
+	AccountsAllowCustomCSS              bool          `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength             int           `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	AccountsCustomCSSAllowRemoteImports bool          `name:"accounts-custom-css-allow-remote-imports" usage:"Allow custom CSS for accounts to @import stylesheets from other hosts."`
+	AccountsDeletionGracePeriod         time.Duration `name:"accounts-deletion-grace-period" usage:"Duration between a user requesting deletion of their account and the account actually being deleted, during which the deletion can be cancelled. 0 to delete immediately."`
+	AccountsDirectoryShowRemote         bool          `name:"accounts-directory-show-remote" usage:"Include discoverable remote accounts in the profile directory, in addition to local ones."`
+	AccountsIPRetention                 string        `name:"accounts-ip-retention" usage:"How much of the sign up and sign in IP addresses of users to store: 'none' to store nothing, 'truncated' to store only the network part (/24 for IPv4, /48 for IPv6), or 'full' to store complete addresses."`
 
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
@@ -102,20 +122,31 @@ type Configuration struct {
 	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
 	MediaCleanupFrom         string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
 	MediaCleanupEvery        time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
+	MediaUnattachedMaxAge    time.Duration `name:"media-unattached-max-age" usage:"Period after which local media that was uploaded but never attached to a status is removed by cleanup."`
+	MediaTranscodeEnabled    bool          `name:"media-transcode-enabled" usage:"Transcode uploaded webm, mov and mkv videos to mp4 using ffmpeg, instead of rejecting them."`
+	MediaTranscodeFfmpegPath string        `name:"media-transcode-ffmpeg-path" usage:"Path to the ffmpeg binary used for transcoding, or name to look up in PATH."`
+	MediaTranscodeTimeout    time.Duration `name:"media-transcode-timeout" usage:"Max time to spend transcoding a single video before giving up."`
+	MediaDisableAnimation    bool          `name:"media-disable-animation" usage:"Serve static versions of avatars, headers and emojis in place of animated ones."`
+	MediaThumbnailWebP       bool          `name:"media-thumbnail-webp" usage:"Encode attachment thumbnails as WebP using ffmpeg, keeping a JPEG copy for clients that don't accept WebP."`
 
-	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
-	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
-	StorageS3Endpoint    string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
-	StorageS3AccessKey   string `name:"storage-s3-access-key" usage:"S3 Access Key"`
-	StorageS3SecretKey   string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
-	StorageS3UseSSL      bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
-	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
-	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageBackend           string        `name:"storage-backend" usage:"Storage backend to use for media attachments"`
+	StorageLocalBasePath     string        `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
+	StorageLocalMinFreeSpace bytesize.Size `name:"storage-local-min-free-space" usage:"Minimum free space to keep on the storage-local-base-path filesystem. Storage health checks fail when less than this is available. 0 to disable."`
+	StorageS3Endpoint        string        `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
+	StorageS3AccessKey       string        `name:"storage-s3-access-key" usage:"S3 Access Key"`
+	StorageS3SecretKey       string        `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
+	StorageS3UseSSL          bool          `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
+	StorageS3BucketName      string        `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
+	StorageS3Proxy           bool          `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageS3ProxyMediaTypes []string      `name:"storage-s3-proxy-media-types" usage:"Media types (attachment, header, avatar, emoji) to always proxy through GoToSocial, even when storage-s3-proxy is false"`
 
-	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
-	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
-	StatusesPollOptionMaxChars int `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
-	StatusesMediaMaxFiles      int `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	StatusesMaxChars           int  `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
+	StatusesPollMaxOptions     int  `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
+	StatusesPollOptionMaxChars int  `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
+	StatusesPollAllowRevote    bool `name:"statuses-poll-allow-revote" usage:"Allow accounts to change their vote in local multiple-choice polls"`
+	StatusesMediaMaxFiles      int  `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	StatusesMaxPinned          int  `name:"statuses-max-pinned" usage:"Maximum number of statuses an account can pin to their profile. 0 to disable pinning."`
+	StatusesReactionsEnabled   bool `name:"statuses-reactions-enabled" usage:"Show emoji reactions from other instances on statuses, and notify of new reactions."`
 
 	LetsEncryptEnabled      bool   `name:"letsencrypt-enabled" usage:"Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default)."`
 	LetsEncryptPort         int    `name:"letsencrypt-port" usage:"Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port."`
@@ -125,16 +156,20 @@ type Configuration struct {
 	TLSCertificateChain string `name:"tls-certificate-chain" usage:"Filesystem path to the certificate chain including any intermediate CAs and the TLS public key"`
 	TLSCertificateKey   string `name:"tls-certificate-key" usage:"Filesystem path to the TLS private key"`
 
-	OIDCEnabled          bool     `name:"oidc-enabled" usage:"Enabled OIDC authorization for this instance. If set to true, then the other OIDC flags must also be set."`
-	OIDCIdpName          string   `name:"oidc-idp-name" usage:"Name of the OIDC identity provider. Will be shown to the user when logging in."`
-	OIDCSkipVerification bool     `name:"oidc-skip-verification" usage:"Skip verification of tokens returned by the OIDC provider. Should only be set to 'true' for testing purposes, never in a production environment!"`
-	OIDCIssuer           string   `name:"oidc-issuer" usage:"Address of the OIDC issuer. Should be the web address, including protocol, at which the issuer can be reached. Eg., 'https://example.org/auth'"`
-	OIDCClientID         string   `name:"oidc-client-id" usage:"ClientID of GoToSocial, as registered with the OIDC provider."`
-	OIDCClientSecret     string   `name:"oidc-client-secret" usage:"ClientSecret of GoToSocial, as registered with the OIDC provider."`
-	OIDCScopes           []string `name:"oidc-scopes" usage:"OIDC scopes."`
-	OIDCLinkExisting     bool     `name:"oidc-link-existing" usage:"link existing user accounts to OIDC logins based on the stored email value"`
-	OIDCAllowedGroups    []string `name:"oidc-allowed-groups" usage:"Membership of one of the listed groups allows access to GtS. If this is empty, all groups are allowed."`
-	OIDCAdminGroups      []string `name:"oidc-admin-groups" usage:"Membership of one of the listed groups makes someone a GtS admin"`
+	OIDCEnabled              bool     `name:"oidc-enabled" usage:"Enabled OIDC authorization for this instance. If set to true, then the other OIDC flags must also be set."`
+	OIDCIdpName              string   `name:"oidc-idp-name" usage:"Name of the OIDC identity provider. Will be shown to the user when logging in."`
+	OIDCSkipVerification     bool     `name:"oidc-skip-verification" usage:"Skip verification of tokens returned by the OIDC provider. Should only be set to 'true' for testing purposes, never in a production environment!"`
+	OIDCIssuer               string   `name:"oidc-issuer" usage:"Address of the OIDC issuer. Should be the web address, including protocol, at which the issuer can be reached. Eg., 'https://example.org/auth'"`
+	OIDCClientID             string   `name:"oidc-client-id" usage:"ClientID of GoToSocial, as registered with the OIDC provider."`
+	OIDCClientSecret         string   `name:"oidc-client-secret" usage:"ClientSecret of GoToSocial, as registered with the OIDC provider."`
+	OIDCScopes               []string `name:"oidc-scopes" usage:"OIDC scopes."`
+	OIDCLinkExisting         bool     `name:"oidc-link-existing" usage:"link existing user accounts to OIDC logins based on the stored email value"`
+	OIDCAllowedGroups        []string `name:"oidc-allowed-groups" usage:"Membership of one of the listed groups allows access to GtS. If this is empty, all groups are allowed."`
+	OIDCAdminGroups          []string `name:"oidc-admin-groups" usage:"Membership of one of the listed groups makes someone a GtS admin"`
+	OIDCModeratorGroups      []string `name:"oidc-moderator-groups" usage:"Membership of one of the listed groups makes someone a GtS moderator"`
+	OIDCGroupsClaim          string   `name:"oidc-groups-claim" usage:"Name of the claim containing the groups of a user, used for oidc-allowed-groups, oidc-admin-groups, and oidc-moderator-groups. Nested claims can be given using dots, eg., 'realm_access.roles'"`
+	OIDCAutoProvision        bool     `name:"oidc-auto-provision" usage:"Create accounts for new OIDC users straight away using their preferred_username, instead of asking them to choose a username"`
+	OIDCDisablePasswordLogin bool     `name:"oidc-disable-password-login" usage:"Disable signing in with a local password when OIDC is enabled, so that only OIDC sign ins are possible"`
 
 	TracingEnabled           bool   `name:"tracing-enabled" usage:"Enable OTLP Tracing"`
 	TracingTransport         string `name:"tracing-transport" usage:"grpc or http"`
@@ -157,14 +192,25 @@ type Configuration struct {
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
 	SyslogAddress  string `name:"syslog-address" usage:"Address:port to send syslog logs to. Leave empty to connect to local syslog."`
 
-	AdvancedCookiesSamesite      string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
-	AdvancedRateLimitRequests    int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
-	AdvancedRateLimitExceptions  []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
-	AdvancedThrottlingMultiplier int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
-	AdvancedThrottlingRetryAfter time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
-	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
-	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
-	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedCookiesSamesite         string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
+	AdvancedRateLimitRequests       int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
+	AdvancedRateLimitAuthRequests   int           `name:"advanced-rate-limit-auth-requests" usage:"Amount of HTTP requests to sign in + oauth endpoints to permit within a 5 minute window. 0 or less turns rate limiting of these endpoints off."`
+	AdvancedRateLimitExceptions     []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
+	AdvancedThrottlingMultiplier    int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
+	AdvancedThrottlingRetryAfter    time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
+	AdvancedSenderMultiplier        int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+	AdvancedCSPExtraURIs            []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
+	AdvancedHeaderFilterMode        string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedStreamingPingInterval   time.Duration `name:"advanced-streaming-ping-interval" usage:"Interval at which to send keep-alive pings / heartbeats into open streaming connections."`
+	AdvancedStreamingMaxMissedPongs int           `name:"advanced-streaming-max-missed-pongs" usage:"Amount of consecutive websocket pings a client may fail to answer before their connection is closed. 0 or less turns this check off."`
+	AdvancedClientAPIWorkers        int           `name:"advanced-client-api-workers" usage:"Number of workers processing client API side effects. 0 uses 4 per CPU."`
+	AdvancedClientAPIQueueSize      int           `name:"advanced-client-api-queue-size" usage:"Max no. client API side effects queued for processing. 0 uses 100 per worker."`
+	AdvancedFederatorWorkers        int           `name:"advanced-federator-workers" usage:"Number of workers processing federation side effects. 0 uses 4 per CPU."`
+	AdvancedFederatorQueueSize      int           `name:"advanced-federator-queue-size" usage:"Max no. federation side effects queued for processing. 0 uses 100 per worker."`
+	AdvancedMediaWorkers            int           `name:"advanced-media-workers" usage:"Number of workers processing media. 0 uses 8 per CPU."`
+	AdvancedMediaQueueSize          int           `name:"advanced-media-queue-size" usage:"Max no. media queued for processing. 0 uses 10 per worker."`
+	AdvancedShutdownDrainTimeout    time.Duration `name:"advanced-shutdown-drain-timeout" usage:"Max time to wait on shutdown for queued side effects and media processing to finish. 0 doesn't wait."`
+	AdvancedDurableQueue            bool          `name:"advanced-durable-queue" usage:"Journal queued client API / federator side effects to the database, so they can be replayed after a crash."`
 
 	// HTTPClient configuration vars.
 	HTTPClient HTTPClientConfiguration `name:"http-client"`
@@ -189,43 +235,54 @@ type HTTPClientConfiguration struct {
 	BlockIPs              []string      `name:"block-ips"`
 	Timeout               time.Duration `name:"timeout"`
 	TLSInsecureSkipVerify bool          `name:"tls-insecure-skip-verify"`
+	BreakerFailures       int           `name:"breaker-failures"`
+	BreakerBackoff        time.Duration `name:"breaker-backoff"`
 }
 
 type CacheConfiguration struct {
-	MemoryTarget             bytesize.Size `name:"memory-target"`
-	AccountMemRatio          float64       `name:"account-mem-ratio"`
-	AccountNoteMemRatio      float64       `name:"account-note-mem-ratio"`
-	ApplicationMemRatio      float64       `name:"application-mem-ratio"`
-	BlockMemRatio            float64       `name:"block-mem-ratio"`
-	BlockIDsMemRatio         float64       `name:"block-mem-ratio"`
-	BoostOfIDsMemRatio       float64       `name:"boost-of-ids-mem-ratio"`
-	EmojiMemRatio            float64       `name:"emoji-mem-ratio"`
-	EmojiCategoryMemRatio    float64       `name:"emoji-category-mem-ratio"`
-	FollowMemRatio           float64       `name:"follow-mem-ratio"`
-	FollowIDsMemRatio        float64       `name:"follow-ids-mem-ratio"`
-	FollowRequestMemRatio    float64       `name:"follow-request-mem-ratio"`
-	FollowRequestIDsMemRatio float64       `name:"follow-request-ids-mem-ratio"`
-	InReplyToIDsMemRatio     float64       `name:"in-reply-to-ids-mem-ratio"`
-	InstanceMemRatio         float64       `name:"instance-mem-ratio"`
-	ListMemRatio             float64       `name:"list-mem-ratio"`
-	ListEntryMemRatio        float64       `name:"list-entry-mem-ratio"`
-	MarkerMemRatio           float64       `name:"marker-mem-ratio"`
-	MediaMemRatio            float64       `name:"media-mem-ratio"`
-	MentionMemRatio          float64       `name:"mention-mem-ratio"`
-	NotificationMemRatio     float64       `name:"notification-mem-ratio"`
-	PollMemRatio             float64       `name:"poll-mem-ratio"`
-	PollVoteMemRatio         float64       `name:"poll-vote-mem-ratio"`
-	PollVoteIDsMemRatio      float64       `name:"poll-vote-ids-mem-ratio"`
-	ReportMemRatio           float64       `name:"report-mem-ratio"`
-	StatusMemRatio           float64       `name:"status-mem-ratio"`
-	StatusFaveMemRatio       float64       `name:"status-fave-mem-ratio"`
-	StatusFaveIDsMemRatio    float64       `name:"status-fave-ids-mem-ratio"`
-	TagMemRatio              float64       `name:"tag-mem-ratio"`
-	ThreadMuteMemRatio       float64       `name:"thread-mute-mem-ratio"`
-	TombstoneMemRatio        float64       `name:"tombstone-mem-ratio"`
-	UserMemRatio             float64       `name:"user-mem-ratio"`
-	WebfingerMemRatio        float64       `name:"webfinger-mem-ratio"`
-	VisibilityMemRatio       float64       `name:"visibility-mem-ratio"`
+	MemoryTarget              bytesize.Size `name:"memory-target"`
+	TimelineMemoryTarget      bytesize.Size `name:"timeline-memory-target"`
+	TimelinePreparedMax       int           `name:"timeline-prepared-max"`
+	AccountMemRatio           float64       `name:"account-mem-ratio"`
+	AccountNoteMemRatio       float64       `name:"account-note-mem-ratio"`
+	ApplicationMemRatio       float64       `name:"application-mem-ratio"`
+	BlockMemRatio             float64       `name:"block-mem-ratio"`
+	BlockIDsMemRatio          float64       `name:"block-mem-ratio"`
+	BoostOfIDsMemRatio        float64       `name:"boost-of-ids-mem-ratio"`
+	DerefFailureMemRatio      float64       `name:"deref-failure-mem-ratio"`
+	EmojiMemRatio             float64       `name:"emoji-mem-ratio"`
+	EmojiCategoryMemRatio     float64       `name:"emoji-category-mem-ratio"`
+	FingerMemRatio            float64       `name:"finger-mem-ratio"`
+	FollowMemRatio            float64       `name:"follow-mem-ratio"`
+	FollowIDsMemRatio         float64       `name:"follow-ids-mem-ratio"`
+	FollowRequestMemRatio     float64       `name:"follow-request-mem-ratio"`
+	FollowRequestIDsMemRatio  float64       `name:"follow-request-ids-mem-ratio"`
+	IdempotencyKeyMemRatio    float64       `name:"idempotency-key-mem-ratio"`
+	InReplyToIDsMemRatio      float64       `name:"in-reply-to-ids-mem-ratio"`
+	InboxActivityMemRatio     float64       `name:"inbox-activity-mem-ratio"`
+	InstanceMemRatio          float64       `name:"instance-mem-ratio"`
+	ListMemRatio              float64       `name:"list-mem-ratio"`
+	ListEntryMemRatio         float64       `name:"list-entry-mem-ratio"`
+	MarkerMemRatio            float64       `name:"marker-mem-ratio"`
+	MediaMemRatio             float64       `name:"media-mem-ratio"`
+	MentionMemRatio           float64       `name:"mention-mem-ratio"`
+	NotificationMemRatio      float64       `name:"notification-mem-ratio"`
+	PollMemRatio              float64       `name:"poll-mem-ratio"`
+	PollVoteMemRatio          float64       `name:"poll-vote-mem-ratio"`
+	PollVoteIDsMemRatio       float64       `name:"poll-vote-ids-mem-ratio"`
+	ReportMemRatio            float64       `name:"report-mem-ratio"`
+	StatusMemRatio            float64       `name:"status-mem-ratio"`
+	StatusFaveMemRatio        float64       `name:"status-fave-mem-ratio"`
+	StatusFaveIDsMemRatio     float64       `name:"status-fave-ids-mem-ratio"`
+	StatusReactionMemRatio    float64       `name:"status-reaction-mem-ratio"`
+	StatusReactionIDsMemRatio float64       `name:"status-reaction-ids-mem-ratio"`
+	TagMemRatio               float64       `name:"tag-mem-ratio"`
+	ThreadMuteMemRatio        float64       `name:"thread-mute-mem-ratio"`
+	TombstoneMemRatio         float64       `name:"tombstone-mem-ratio"`
+	UserMemRatio              float64       `name:"user-mem-ratio"`
+	WebfingerMemRatio         float64       `name:"webfinger-mem-ratio"`
+	VisibilityMemRatio        float64       `name:"visibility-mem-ratio"`
+	StatusCoreMemRatio        float64       `name:"status-core-mem-ratio"`
 }
 
 // MarshalMap will marshal current Configuration into a map structure (useful for JSON/TOML/YAML).
@@ -29,4 +29,10 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Accounts IP retention determines how much
+	// of users' IP addresses this instance stores.
+	AccountsIPRetentionNone      = "none"
+	AccountsIPRetentionTruncated = "truncated"
+	AccountsIPRetentionFull      = "full"
 )
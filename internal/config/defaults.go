@@ -36,41 +36,61 @@ var Defaults = Configuration{
 	ConfigPath:         "",
 	Host:               "",
 	AccountDomain:      "",
+	HostAliases:        []string{},
 	Protocol:           "https",
 	BindAddress:        "0.0.0.0",
 	Port:               8080,
 	TrustedProxies:     []string{"127.0.0.1/32", "::1"}, // localhost
 
-	DbType:                   "postgres",
-	DbAddress:                "",
-	DbPort:                   5432,
-	DbUser:                   "",
-	DbPassword:               "",
-	DbDatabase:               "gotosocial",
-	DbTLSMode:                "disable",
-	DbTLSCACert:              "",
-	DbMaxOpenConnsMultiplier: 8,
-	DbSqliteJournalMode:      "WAL",
-	DbSqliteSynchronous:      "NORMAL",
-	DbSqliteCacheSize:        8 * bytesize.MiB,
-	DbSqliteBusyTimeout:      time.Minute * 30,
+	DbType:                    "postgres",
+	DbAddress:                 "",
+	DbPort:                    5432,
+	DbUser:                    "",
+	DbPassword:                "",
+	DbDatabase:                "gotosocial",
+	DbTLSMode:                 "disable",
+	DbTLSCACert:               "",
+	DbMaxOpenConnsMultiplier:  8,
+	DbReplicaAddress:          "",
+	DbReplicaPort:             0,
+	DbSqliteJournalMode:       "WAL",
+	DbSqliteSynchronous:       "NORMAL",
+	DbSqliteCacheSize:         8 * bytesize.MiB,
+	DbSqliteBusyTimeout:       time.Minute * 30,
+	DbSqliteJournalSizeLimit:  0,
+	DbSqliteWALAutocheckpoint: 0,
+	DbFullTextSearch:          false,
 
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
+	InstanceFederationMode:                       InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:                 false,
+	InstanceFederationSpamFilterNewAccountDays:   7,
+	InstanceFederationSpamFilterMaxLocalMentions: 2,
+	InstanceFederationSpamFilterQuarantineDays:   7,
+	InstanceFederationAccountFreshness:           6 * time.Hour,
+	InstanceFederationThreadBackfillMaxItems:     100,
+	InstanceFederationStatusFreshness:            2 * time.Hour,
+	InstanceExposePeers:                          false,
+	InstanceExposeSuspended:                      false,
+	InstanceExposeSuspendedWeb:                   false,
+	InstanceExposePublicRSS:                      false,
+	InstanceRSSMaxItems:                          100,
+	InstanceDeliverToSharedInboxes:               true,
+	InstanceLanguages:                            make(language.Languages, 0),
+	InstanceTrendsEnabled:                        true,
 
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   false,
-	AccountsCustomCSSLength:  10000,
+	AccountsRegistrationOpen:            true,
+	AccountsApprovalRequired:            true,
+	AccountsReasonRequired:              true,
+	AccountsInvitesEnabled:              false,
+	AccountsAllowCustomCSS:              false,
+	AccountsCustomCSSLength:             10000,
+	AccountsCustomCSSAllowRemoteImports: false,
+	AccountsDeletionGracePeriod:         7 * 24 * time.Hour,
+	AccountsDirectoryShowRemote:         false,
+	AccountsIPRetention:                 AccountsIPRetentionFull,
 
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
@@ -81,16 +101,26 @@ var Defaults = Configuration{
 	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
 	MediaCleanupFrom:         "00:00",        // Midnight.
 	MediaCleanupEvery:        24 * time.Hour, // 1/day.
+	MediaUnattachedMaxAge:    24 * time.Hour,
+	MediaTranscodeEnabled:    false,
+	MediaTranscodeFfmpegPath: "ffmpeg",
+	MediaTranscodeTimeout:    5 * time.Minute,
+	MediaDisableAnimation:    false,
+	MediaThumbnailWebP:       false,
 
-	StorageBackend:       "local",
-	StorageLocalBasePath: "/gotosocial/storage",
-	StorageS3UseSSL:      true,
-	StorageS3Proxy:       false,
+	StorageBackend:           "local",
+	StorageLocalBasePath:     "/gotosocial/storage",
+	StorageLocalMinFreeSpace: 500 * bytesize.MiB,
+	StorageS3UseSSL:          true,
+	StorageS3Proxy:           false,
 
 	StatusesMaxChars:           5000,
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
+	StatusesPollAllowRevote:    false,
 	StatusesMediaMaxFiles:      6,
+	StatusesMaxPinned:          10,
+	StatusesReactionsEnabled:   false,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
@@ -108,6 +138,7 @@ var Defaults = Configuration{
 	OIDCClientSecret:     "",
 	OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
 	OIDCLinkExisting:     false,
+	OIDCGroupsClaim:      "groups",
 
 	SMTPHost:               "",
 	SMTPPort:               0,
@@ -128,14 +159,25 @@ var Defaults = Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
-	AdvancedRateLimitExceptions:  []string{},
-	AdvancedThrottlingMultiplier: 8, // 8 open requests per CPU
-	AdvancedThrottlingRetryAfter: time.Second * 30,
-	AdvancedSenderMultiplier:     2, // 2 senders per CPU
-	AdvancedCSPExtraURIs:         []string{},
-	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
+	AdvancedCookiesSamesite:         "lax",
+	AdvancedRateLimitRequests:       300, // 1 per second per 5 minutes
+	AdvancedRateLimitAuthRequests:   60,  // 1 per 5 seconds per 5 minutes
+	AdvancedRateLimitExceptions:     []string{},
+	AdvancedThrottlingMultiplier:    8, // 8 open requests per CPU
+	AdvancedThrottlingRetryAfter:    time.Second * 30,
+	AdvancedSenderMultiplier:        2, // 2 senders per CPU
+	AdvancedCSPExtraURIs:            []string{},
+	AdvancedHeaderFilterMode:        RequestHeaderFilterModeDisabled,
+	AdvancedStreamingPingInterval:   time.Second * 30,
+	AdvancedStreamingMaxMissedPongs: 2,
+	AdvancedClientAPIWorkers:        0, // 4 per CPU
+	AdvancedClientAPIQueueSize:      0, // 100 per worker
+	AdvancedFederatorWorkers:        0, // 4 per CPU
+	AdvancedFederatorQueueSize:      0, // 100 per worker
+	AdvancedMediaWorkers:            0, // 8 per CPU
+	AdvancedMediaQueueSize:          0, // 10 per worker
+	AdvancedShutdownDrainTimeout:    time.Second * 30,
+	AdvancedDurableQueue:            false,
 
 	Cache: CacheConfiguration{
 		// Rough memory target that the total
@@ -143,6 +185,13 @@ var Defaults = Configuration{
 		// to remain with. Emphasis on *rough*.
 		MemoryTarget: 100 * bytesize.MiB,
 
+		// Rough memory target for prepared (ie.,
+		// API-model) statuses held by home and
+		// list timelines, and the max number of
+		// prepared statuses held per timeline.
+		TimelineMemoryTarget: 50 * bytesize.MiB,
+		TimelinePreparedMax:  300,
+
 		// These ratios signal what percentage
 		// of the available cache target memory
 		// is allocated to each object type's
@@ -157,39 +206,46 @@ var Defaults = Configuration{
 		// when TODO items in the size.go source
 		// file have been addressed, these should
 		// be able to make some more sense :D
-		AccountMemRatio:          5,
-		AccountNoteMemRatio:      1,
-		ApplicationMemRatio:      0.1,
-		BlockMemRatio:            2,
-		BlockIDsMemRatio:         3,
-		BoostOfIDsMemRatio:       3,
-		EmojiMemRatio:            3,
-		EmojiCategoryMemRatio:    0.1,
-		FollowMemRatio:           2,
-		FollowIDsMemRatio:        4,
-		FollowRequestMemRatio:    2,
-		FollowRequestIDsMemRatio: 2,
-		InReplyToIDsMemRatio:     3,
-		InstanceMemRatio:         1,
-		ListMemRatio:             1,
-		ListEntryMemRatio:        2,
-		MarkerMemRatio:           0.5,
-		MediaMemRatio:            4,
-		MentionMemRatio:          2,
-		NotificationMemRatio:     2,
-		PollMemRatio:             1,
-		PollVoteMemRatio:         2,
-		PollVoteIDsMemRatio:      2,
-		ReportMemRatio:           1,
-		StatusMemRatio:           5,
-		StatusFaveMemRatio:       2,
-		StatusFaveIDsMemRatio:    3,
-		TagMemRatio:              2,
-		ThreadMuteMemRatio:       0.2,
-		TombstoneMemRatio:        0.5,
-		UserMemRatio:             0.25,
-		WebfingerMemRatio:        0.1,
-		VisibilityMemRatio:       2,
+		AccountMemRatio:           5,
+		AccountNoteMemRatio:       1,
+		ApplicationMemRatio:       0.1,
+		BlockMemRatio:             2,
+		BlockIDsMemRatio:          3,
+		BoostOfIDsMemRatio:        3,
+		DerefFailureMemRatio:      0.1,
+		EmojiMemRatio:             3,
+		EmojiCategoryMemRatio:     0.1,
+		FingerMemRatio:            0.1,
+		FollowMemRatio:            2,
+		FollowIDsMemRatio:         4,
+		FollowRequestMemRatio:     2,
+		FollowRequestIDsMemRatio:  2,
+		IdempotencyKeyMemRatio:    0.1,
+		InReplyToIDsMemRatio:      3,
+		InboxActivityMemRatio:     0.5,
+		InstanceMemRatio:          1,
+		ListMemRatio:              1,
+		ListEntryMemRatio:         2,
+		MarkerMemRatio:            0.5,
+		MediaMemRatio:             4,
+		MentionMemRatio:           2,
+		NotificationMemRatio:      2,
+		PollMemRatio:              1,
+		PollVoteMemRatio:          2,
+		PollVoteIDsMemRatio:       2,
+		ReportMemRatio:            1,
+		StatusMemRatio:            5,
+		StatusFaveMemRatio:        2,
+		StatusFaveIDsMemRatio:     3,
+		StatusReactionMemRatio:    0.5,
+		StatusReactionIDsMemRatio: 0.5,
+		TagMemRatio:               2,
+		ThreadMuteMemRatio:        0.2,
+		TombstoneMemRatio:         0.5,
+		UserMemRatio:              0.25,
+		WebfingerMemRatio:         0.1,
+		VisibilityMemRatio:        2,
+		StatusCoreMemRatio:        3,
 	},
 
 	HTTPClient: HTTPClientConfiguration{
@@ -197,6 +253,8 @@ var Defaults = Configuration{
 		BlockIPs:              make([]string, 0),
 		Timeout:               10 * time.Second,
 		TLSInsecureSkipVerify: false,
+		BreakerFailures:       5,
+		BreakerBackoff:        time.Minute,
 	},
 
 	AdminMediaPruneDryRun: true,
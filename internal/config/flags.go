@@ -36,6 +36,7 @@ func (s *ConfigState) AddGlobalFlags(cmd *cobra.Command) {
 		cmd.PersistentFlags().String(LandingPageUserFlag(), cfg.LandingPageUser, fieldtag("LandingPageUser", "usage"))
 		cmd.PersistentFlags().String(HostFlag(), cfg.Host, fieldtag("Host", "usage"))
 		cmd.PersistentFlags().String(AccountDomainFlag(), cfg.AccountDomain, fieldtag("AccountDomain", "usage"))
+		cmd.PersistentFlags().StringSlice(HostAliasesFlag(), cfg.HostAliases, fieldtag("HostAliases", "usage"))
 		cmd.PersistentFlags().String(ProtocolFlag(), cfg.Protocol, fieldtag("Protocol", "usage"))
 		cmd.PersistentFlags().String(LogLevelFlag(), cfg.LogLevel, fieldtag("LogLevel", "usage"))
 		cmd.PersistentFlags().String(LogTimestampFormatFlag(), cfg.LogTimestampFormat, fieldtag("LogTimestampFormat", "usage"))
@@ -52,16 +53,23 @@ func (s *ConfigState) AddGlobalFlags(cmd *cobra.Command) {
 		cmd.PersistentFlags().String(DbTLSModeFlag(), cfg.DbTLSMode, fieldtag("DbTLSMode", "usage"))
 		cmd.PersistentFlags().String(DbTLSCACertFlag(), cfg.DbTLSCACert, fieldtag("DbTLSCACert", "usage"))
 		cmd.PersistentFlags().Int(DbMaxOpenConnsMultiplierFlag(), cfg.DbMaxOpenConnsMultiplier, fieldtag("DbMaxOpenConnsMultiplier", "usage"))
+		cmd.PersistentFlags().String(DbReplicaAddressFlag(), cfg.DbReplicaAddress, fieldtag("DbReplicaAddress", "usage"))
+		cmd.PersistentFlags().Int(DbReplicaPortFlag(), cfg.DbReplicaPort, fieldtag("DbReplicaPort", "usage"))
 		cmd.PersistentFlags().String(DbSqliteJournalModeFlag(), cfg.DbSqliteJournalMode, fieldtag("DbSqliteJournalMode", "usage"))
 		cmd.PersistentFlags().String(DbSqliteSynchronousFlag(), cfg.DbSqliteSynchronous, fieldtag("DbSqliteSynchronous", "usage"))
 		cmd.PersistentFlags().Uint64(DbSqliteCacheSizeFlag(), uint64(cfg.DbSqliteCacheSize), fieldtag("DbSqliteCacheSize", "usage"))
 		cmd.PersistentFlags().Duration(DbSqliteBusyTimeoutFlag(), cfg.DbSqliteBusyTimeout, fieldtag("DbSqliteBusyTimeout", "usage"))
+		cmd.PersistentFlags().Uint64(DbSqliteJournalSizeLimitFlag(), uint64(cfg.DbSqliteJournalSizeLimit), fieldtag("DbSqliteJournalSizeLimit", "usage"))
+		cmd.PersistentFlags().Int(DbSqliteWALAutocheckpointFlag(), cfg.DbSqliteWALAutocheckpoint, fieldtag("DbSqliteWALAutocheckpoint", "usage"))
+		cmd.PersistentFlags().Bool(DbFullTextSearchFlag(), cfg.DbFullTextSearch, fieldtag("DbFullTextSearch", "usage"))
 
 		// HTTPClient
 		cmd.PersistentFlags().StringSlice(HTTPClientAllowIPsFlag(), cfg.HTTPClient.AllowIPs, "no usage string")
 		cmd.PersistentFlags().StringSlice(HTTPClientBlockIPsFlag(), cfg.HTTPClient.BlockIPs, "no usage string")
 		cmd.PersistentFlags().Duration(HTTPClientTimeoutFlag(), cfg.HTTPClient.Timeout, "no usage string")
 		cmd.PersistentFlags().Bool(HTTPClientTLSInsecureSkipVerifyFlag(), cfg.HTTPClient.TLSInsecureSkipVerify, "no usage string")
+		cmd.PersistentFlags().Int(HTTPClientBreakerFailuresFlag(), cfg.HTTPClient.BreakerFailures, "no usage string")
+		cmd.PersistentFlags().Duration(HTTPClientBreakerBackoffFlag(), cfg.HTTPClient.BreakerBackoff, "no usage string")
 	})
 }
 
@@ -85,17 +93,31 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Instance
 		cmd.Flags().String(InstanceFederationModeFlag(), cfg.InstanceFederationMode, fieldtag("InstanceFederationMode", "usage"))
 		cmd.Flags().Bool(InstanceFederationSpamFilterFlag(), cfg.InstanceFederationSpamFilter, fieldtag("InstanceFederationSpamFilter", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamFilterNewAccountDaysFlag(), cfg.InstanceFederationSpamFilterNewAccountDays, fieldtag("InstanceFederationSpamFilterNewAccountDays", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamFilterMaxLocalMentionsFlag(), cfg.InstanceFederationSpamFilterMaxLocalMentions, fieldtag("InstanceFederationSpamFilterMaxLocalMentions", "usage"))
+		cmd.Flags().Int(InstanceFederationSpamFilterQuarantineDaysFlag(), cfg.InstanceFederationSpamFilterQuarantineDays, fieldtag("InstanceFederationSpamFilterQuarantineDays", "usage"))
+		cmd.Flags().Duration(InstanceFederationAccountFreshnessFlag(), cfg.InstanceFederationAccountFreshness, fieldtag("InstanceFederationAccountFreshness", "usage"))
+		cmd.Flags().Duration(InstanceFederationStatusFreshnessFlag(), cfg.InstanceFederationStatusFreshness, fieldtag("InstanceFederationStatusFreshness", "usage"))
+		cmd.Flags().Int(InstanceFederationThreadBackfillMaxItemsFlag(), cfg.InstanceFederationThreadBackfillMaxItems, fieldtag("InstanceFederationThreadBackfillMaxItems", "usage"))
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
+		cmd.Flags().Bool(InstanceExposePublicRSSFlag(), cfg.InstanceExposePublicRSS, fieldtag("InstanceExposePublicRSS", "usage"))
+		cmd.Flags().Int(InstanceRSSMaxItemsFlag(), cfg.InstanceRSSMaxItems, fieldtag("InstanceRSSMaxItems", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
 		cmd.Flags().StringSlice(InstanceLanguagesFlag(), cfg.InstanceLanguages.TagStrs(), fieldtag("InstanceLanguages", "usage"))
+		cmd.Flags().Bool(InstanceTrendsEnabledFlag(), cfg.InstanceTrendsEnabled, fieldtag("InstanceTrendsEnabled", "usage"))
 
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsApprovalRequiredFlag(), cfg.AccountsApprovalRequired, fieldtag("AccountsApprovalRequired", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
+		cmd.Flags().Bool(AccountsInvitesEnabledFlag(), cfg.AccountsInvitesEnabled, fieldtag("AccountsInvitesEnabled", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
+		cmd.Flags().Bool(AccountsCustomCSSAllowRemoteImportsFlag(), cfg.AccountsCustomCSSAllowRemoteImports, fieldtag("AccountsCustomCSSAllowRemoteImports", "usage"))
+		cmd.Flags().Duration(AccountsDeletionGracePeriodFlag(), cfg.AccountsDeletionGracePeriod, fieldtag("AccountsDeletionGracePeriod", "usage"))
+		cmd.Flags().Bool(AccountsDirectoryShowRemoteFlag(), cfg.AccountsDirectoryShowRemote, fieldtag("AccountsDirectoryShowRemote", "usage"))
+		cmd.Flags().String(AccountsIPRetentionFlag(), cfg.AccountsIPRetention, fieldtag("AccountsIPRetention", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
@@ -107,16 +129,26 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
 		cmd.Flags().String(MediaCleanupFromFlag(), cfg.MediaCleanupFrom, fieldtag("MediaCleanupFrom", "usage"))
 		cmd.Flags().Duration(MediaCleanupEveryFlag(), cfg.MediaCleanupEvery, fieldtag("MediaCleanupEvery", "usage"))
+		cmd.Flags().Duration(MediaUnattachedMaxAgeFlag(), cfg.MediaUnattachedMaxAge, fieldtag("MediaUnattachedMaxAge", "usage"))
+		cmd.Flags().Bool(MediaTranscodeEnabledFlag(), cfg.MediaTranscodeEnabled, fieldtag("MediaTranscodeEnabled", "usage"))
+		cmd.Flags().String(MediaTranscodeFfmpegPathFlag(), cfg.MediaTranscodeFfmpegPath, fieldtag("MediaTranscodeFfmpegPath", "usage"))
+		cmd.Flags().Duration(MediaTranscodeTimeoutFlag(), cfg.MediaTranscodeTimeout, fieldtag("MediaTranscodeTimeout", "usage"))
+		cmd.Flags().Bool(MediaDisableAnimationFlag(), cfg.MediaDisableAnimation, fieldtag("MediaDisableAnimation", "usage"))
+		cmd.Flags().Bool(MediaThumbnailWebPFlag(), cfg.MediaThumbnailWebP, fieldtag("MediaThumbnailWebP", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
 		cmd.Flags().String(StorageLocalBasePathFlag(), cfg.StorageLocalBasePath, fieldtag("StorageLocalBasePath", "usage"))
+		cmd.Flags().Uint64(StorageLocalMinFreeSpaceFlag(), uint64(cfg.StorageLocalMinFreeSpace), fieldtag("StorageLocalMinFreeSpace", "usage"))
 
 		// Statuses
 		cmd.Flags().Int(StatusesMaxCharsFlag(), cfg.StatusesMaxChars, fieldtag("StatusesMaxChars", "usage"))
 		cmd.Flags().Int(StatusesPollMaxOptionsFlag(), cfg.StatusesPollMaxOptions, fieldtag("StatusesPollMaxOptions", "usage"))
 		cmd.Flags().Int(StatusesPollOptionMaxCharsFlag(), cfg.StatusesPollOptionMaxChars, fieldtag("StatusesPollOptionMaxChars", "usage"))
+		cmd.Flags().Bool(StatusesPollAllowRevoteFlag(), cfg.StatusesPollAllowRevote, fieldtag("StatusesPollAllowRevote", "usage"))
 		cmd.Flags().Int(StatusesMediaMaxFilesFlag(), cfg.StatusesMediaMaxFiles, fieldtag("StatusesMediaMaxFiles", "usage"))
+		cmd.Flags().Int(StatusesMaxPinnedFlag(), cfg.StatusesMaxPinned, fieldtag("StatusesMaxPinned", "usage"))
+		cmd.Flags().Bool(StatusesReactionsEnabledFlag(), cfg.StatusesReactionsEnabled, fieldtag("StatusesReactionsEnabled", "usage"))
 
 		// LetsEncrypt
 		cmd.Flags().Bool(LetsEncryptEnabledFlag(), cfg.LetsEncryptEnabled, fieldtag("LetsEncryptEnabled", "usage"))
@@ -153,12 +185,23 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Advanced flags
 		cmd.Flags().String(AdvancedCookiesSamesiteFlag(), cfg.AdvancedCookiesSamesite, fieldtag("AdvancedCookiesSamesite", "usage"))
 		cmd.Flags().Int(AdvancedRateLimitRequestsFlag(), cfg.AdvancedRateLimitRequests, fieldtag("AdvancedRateLimitRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitAuthRequestsFlag(), cfg.AdvancedRateLimitAuthRequests, fieldtag("AdvancedRateLimitAuthRequests", "usage"))
 		cmd.Flags().StringSlice(AdvancedRateLimitExceptionsFlag(), cfg.AdvancedRateLimitExceptions, fieldtag("AdvancedRateLimitExceptions", "usage"))
 		cmd.Flags().Int(AdvancedThrottlingMultiplierFlag(), cfg.AdvancedThrottlingMultiplier, fieldtag("AdvancedThrottlingMultiplier", "usage"))
 		cmd.Flags().Duration(AdvancedThrottlingRetryAfterFlag(), cfg.AdvancedThrottlingRetryAfter, fieldtag("AdvancedThrottlingRetryAfter", "usage"))
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
 		cmd.Flags().StringSlice(AdvancedCSPExtraURIsFlag(), cfg.AdvancedCSPExtraURIs, fieldtag("AdvancedCSPExtraURIs", "usage"))
 		cmd.Flags().String(AdvancedHeaderFilterModeFlag(), cfg.AdvancedHeaderFilterMode, fieldtag("AdvancedHeaderFilterMode", "usage"))
+		cmd.Flags().Duration(AdvancedStreamingPingIntervalFlag(), cfg.AdvancedStreamingPingInterval, fieldtag("AdvancedStreamingPingInterval", "usage"))
+		cmd.Flags().Int(AdvancedStreamingMaxMissedPongsFlag(), cfg.AdvancedStreamingMaxMissedPongs, fieldtag("AdvancedStreamingMaxMissedPongs", "usage"))
+		cmd.Flags().Int(AdvancedClientAPIWorkersFlag(), cfg.AdvancedClientAPIWorkers, fieldtag("AdvancedClientAPIWorkers", "usage"))
+		cmd.Flags().Int(AdvancedClientAPIQueueSizeFlag(), cfg.AdvancedClientAPIQueueSize, fieldtag("AdvancedClientAPIQueueSize", "usage"))
+		cmd.Flags().Int(AdvancedFederatorWorkersFlag(), cfg.AdvancedFederatorWorkers, fieldtag("AdvancedFederatorWorkers", "usage"))
+		cmd.Flags().Int(AdvancedFederatorQueueSizeFlag(), cfg.AdvancedFederatorQueueSize, fieldtag("AdvancedFederatorQueueSize", "usage"))
+		cmd.Flags().Int(AdvancedMediaWorkersFlag(), cfg.AdvancedMediaWorkers, fieldtag("AdvancedMediaWorkers", "usage"))
+		cmd.Flags().Int(AdvancedMediaQueueSizeFlag(), cfg.AdvancedMediaQueueSize, fieldtag("AdvancedMediaQueueSize", "usage"))
+		cmd.Flags().Duration(AdvancedShutdownDrainTimeoutFlag(), cfg.AdvancedShutdownDrainTimeout, fieldtag("AdvancedShutdownDrainTimeout", "usage"))
+		cmd.Flags().Bool(AdvancedDurableQueueFlag(), cfg.AdvancedDurableQueue, fieldtag("AdvancedDurableQueue", "usage"))
 
 		cmd.Flags().String(RequestIDHeaderFlag(), cfg.RequestIDHeader, fieldtag("RequestIDHeader", "usage"))
 	})
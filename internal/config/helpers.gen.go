@@ -250,6 +250,31 @@ func GetAccountDomain() string { return global.GetAccountDomain() }
 // SetAccountDomain safely sets the value for global configuration 'AccountDomain' field
 func SetAccountDomain(v string) { global.SetAccountDomain(v) }
 
+// GetHostAliases safely fetches the Configuration value for state's 'HostAliases' field
+func (st *ConfigState) GetHostAliases() (v []string) {
+	st.mutex.RLock()
+	v = st.config.HostAliases
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHostAliases safely sets the Configuration value for state's 'HostAliases' field
+func (st *ConfigState) SetHostAliases(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HostAliases = v
+	st.reloadToViper()
+}
+
+// HostAliasesFlag returns the flag name for the 'HostAliases' field
+func HostAliasesFlag() string { return "host-aliases" }
+
+// GetHostAliases safely fetches the value for global configuration 'HostAliases' field
+func GetHostAliases() []string { return global.GetHostAliases() }
+
+// SetHostAliases safely sets the value for global configuration 'HostAliases' field
+func SetHostAliases(v []string) { global.SetHostAliases(v) }
+
 // GetProtocol safely fetches the Configuration value for state's 'Protocol' field
 func (st *ConfigState) GetProtocol() (v string) {
 	st.mutex.RLock()
@@ -600,6 +625,56 @@ func GetDbMaxOpenConnsMultiplier() int { return global.GetDbMaxOpenConnsMultipli
 // SetDbMaxOpenConnsMultiplier safely sets the value for global configuration 'DbMaxOpenConnsMultiplier' field
 func SetDbMaxOpenConnsMultiplier(v int) { global.SetDbMaxOpenConnsMultiplier(v) }
 
+// GetDbReplicaAddress safely fetches the Configuration value for state's 'DbReplicaAddress' field
+func (st *ConfigState) GetDbReplicaAddress() (v string) {
+	st.mutex.RLock()
+	v = st.config.DbReplicaAddress
+	st.mutex.RUnlock()
+	return
+}
+
+// SetDbReplicaAddress safely sets the Configuration value for state's 'DbReplicaAddress' field
+func (st *ConfigState) SetDbReplicaAddress(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.DbReplicaAddress = v
+	st.reloadToViper()
+}
+
+// DbReplicaAddressFlag returns the flag name for the 'DbReplicaAddress' field
+func DbReplicaAddressFlag() string { return "db-replica-address" }
+
+// GetDbReplicaAddress safely fetches the value for global configuration 'DbReplicaAddress' field
+func GetDbReplicaAddress() string { return global.GetDbReplicaAddress() }
+
+// SetDbReplicaAddress safely sets the value for global configuration 'DbReplicaAddress' field
+func SetDbReplicaAddress(v string) { global.SetDbReplicaAddress(v) }
+
+// GetDbReplicaPort safely fetches the Configuration value for state's 'DbReplicaPort' field
+func (st *ConfigState) GetDbReplicaPort() (v int) {
+	st.mutex.RLock()
+	v = st.config.DbReplicaPort
+	st.mutex.RUnlock()
+	return
+}
+
+// SetDbReplicaPort safely sets the Configuration value for state's 'DbReplicaPort' field
+func (st *ConfigState) SetDbReplicaPort(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.DbReplicaPort = v
+	st.reloadToViper()
+}
+
+// DbReplicaPortFlag returns the flag name for the 'DbReplicaPort' field
+func DbReplicaPortFlag() string { return "db-replica-port" }
+
+// GetDbReplicaPort safely fetches the value for global configuration 'DbReplicaPort' field
+func GetDbReplicaPort() int { return global.GetDbReplicaPort() }
+
+// SetDbReplicaPort safely sets the value for global configuration 'DbReplicaPort' field
+func SetDbReplicaPort(v int) { global.SetDbReplicaPort(v) }
+
 // GetDbSqliteJournalMode safely fetches the Configuration value for state's 'DbSqliteJournalMode' field
 func (st *ConfigState) GetDbSqliteJournalMode() (v string) {
 	st.mutex.RLock()
@@ -700,6 +775,81 @@ func GetDbSqliteBusyTimeout() time.Duration { return global.GetDbSqliteBusyTimeo
 // SetDbSqliteBusyTimeout safely sets the value for global configuration 'DbSqliteBusyTimeout' field
 func SetDbSqliteBusyTimeout(v time.Duration) { global.SetDbSqliteBusyTimeout(v) }
 
+// GetDbSqliteJournalSizeLimit safely fetches the Configuration value for state's 'DbSqliteJournalSizeLimit' field
+func (st *ConfigState) GetDbSqliteJournalSizeLimit() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.DbSqliteJournalSizeLimit
+	st.mutex.RUnlock()
+	return
+}
+
+// SetDbSqliteJournalSizeLimit safely sets the Configuration value for state's 'DbSqliteJournalSizeLimit' field
+func (st *ConfigState) SetDbSqliteJournalSizeLimit(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.DbSqliteJournalSizeLimit = v
+	st.reloadToViper()
+}
+
+// DbSqliteJournalSizeLimitFlag returns the flag name for the 'DbSqliteJournalSizeLimit' field
+func DbSqliteJournalSizeLimitFlag() string { return "db-sqlite-journal-size-limit" }
+
+// GetDbSqliteJournalSizeLimit safely fetches the value for global configuration 'DbSqliteJournalSizeLimit' field
+func GetDbSqliteJournalSizeLimit() bytesize.Size { return global.GetDbSqliteJournalSizeLimit() }
+
+// SetDbSqliteJournalSizeLimit safely sets the value for global configuration 'DbSqliteJournalSizeLimit' field
+func SetDbSqliteJournalSizeLimit(v bytesize.Size) { global.SetDbSqliteJournalSizeLimit(v) }
+
+// GetDbSqliteWALAutocheckpoint safely fetches the Configuration value for state's 'DbSqliteWALAutocheckpoint' field
+func (st *ConfigState) GetDbSqliteWALAutocheckpoint() (v int) {
+	st.mutex.RLock()
+	v = st.config.DbSqliteWALAutocheckpoint
+	st.mutex.RUnlock()
+	return
+}
+
+// SetDbSqliteWALAutocheckpoint safely sets the Configuration value for state's 'DbSqliteWALAutocheckpoint' field
+func (st *ConfigState) SetDbSqliteWALAutocheckpoint(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.DbSqliteWALAutocheckpoint = v
+	st.reloadToViper()
+}
+
+// DbSqliteWALAutocheckpointFlag returns the flag name for the 'DbSqliteWALAutocheckpoint' field
+func DbSqliteWALAutocheckpointFlag() string { return "db-sqlite-wal-autocheckpoint" }
+
+// GetDbSqliteWALAutocheckpoint safely fetches the value for global configuration 'DbSqliteWALAutocheckpoint' field
+func GetDbSqliteWALAutocheckpoint() int { return global.GetDbSqliteWALAutocheckpoint() }
+
+// SetDbSqliteWALAutocheckpoint safely sets the value for global configuration 'DbSqliteWALAutocheckpoint' field
+func SetDbSqliteWALAutocheckpoint(v int) { global.SetDbSqliteWALAutocheckpoint(v) }
+
+// GetDbFullTextSearch safely fetches the Configuration value for state's 'DbFullTextSearch' field
+func (st *ConfigState) GetDbFullTextSearch() (v bool) {
+	st.mutex.RLock()
+	v = st.config.DbFullTextSearch
+	st.mutex.RUnlock()
+	return
+}
+
+// SetDbFullTextSearch safely sets the Configuration value for state's 'DbFullTextSearch' field
+func (st *ConfigState) SetDbFullTextSearch(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.DbFullTextSearch = v
+	st.reloadToViper()
+}
+
+// DbFullTextSearchFlag returns the flag name for the 'DbFullTextSearch' field
+func DbFullTextSearchFlag() string { return "db-full-text-search" }
+
+// GetDbFullTextSearch safely fetches the value for global configuration 'DbFullTextSearch' field
+func GetDbFullTextSearch() bool { return global.GetDbFullTextSearch() }
+
+// SetDbFullTextSearch safely sets the value for global configuration 'DbFullTextSearch' field
+func SetDbFullTextSearch(v bool) { global.SetDbFullTextSearch(v) }
+
 // GetWebTemplateBaseDir safely fetches the Configuration value for state's 'WebTemplateBaseDir' field
 func (st *ConfigState) GetWebTemplateBaseDir() (v string) {
 	st.mutex.RLock()
@@ -800,6 +950,192 @@ func GetInstanceFederationSpamFilter() bool { return global.GetInstanceFederatio
 // SetInstanceFederationSpamFilter safely sets the value for global configuration 'InstanceFederationSpamFilter' field
 func SetInstanceFederationSpamFilter(v bool) { global.SetInstanceFederationSpamFilter(v) }
 
+// GetInstanceFederationSpamFilterNewAccountDays safely fetches the Configuration value for state's 'InstanceFederationSpamFilterNewAccountDays' field
+func (st *ConfigState) GetInstanceFederationSpamFilterNewAccountDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamFilterNewAccountDays
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamFilterNewAccountDays safely sets the Configuration value for state's 'InstanceFederationSpamFilterNewAccountDays' field
+func (st *ConfigState) SetInstanceFederationSpamFilterNewAccountDays(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamFilterNewAccountDays = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamFilterNewAccountDaysFlag returns the flag name for the 'InstanceFederationSpamFilterNewAccountDays' field
+func InstanceFederationSpamFilterNewAccountDaysFlag() string {
+	return "instance-federation-spam-filter-new-account-days"
+}
+
+// GetInstanceFederationSpamFilterNewAccountDays safely fetches the value for global configuration 'InstanceFederationSpamFilterNewAccountDays' field
+func GetInstanceFederationSpamFilterNewAccountDays() int {
+	return global.GetInstanceFederationSpamFilterNewAccountDays()
+}
+
+// SetInstanceFederationSpamFilterNewAccountDays safely sets the value for global configuration 'InstanceFederationSpamFilterNewAccountDays' field
+func SetInstanceFederationSpamFilterNewAccountDays(v int) {
+	global.SetInstanceFederationSpamFilterNewAccountDays(v)
+}
+
+// GetInstanceFederationSpamFilterMaxLocalMentions safely fetches the Configuration value for state's 'InstanceFederationSpamFilterMaxLocalMentions' field
+func (st *ConfigState) GetInstanceFederationSpamFilterMaxLocalMentions() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamFilterMaxLocalMentions
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamFilterMaxLocalMentions safely sets the Configuration value for state's 'InstanceFederationSpamFilterMaxLocalMentions' field
+func (st *ConfigState) SetInstanceFederationSpamFilterMaxLocalMentions(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamFilterMaxLocalMentions = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamFilterMaxLocalMentionsFlag returns the flag name for the 'InstanceFederationSpamFilterMaxLocalMentions' field
+func InstanceFederationSpamFilterMaxLocalMentionsFlag() string {
+	return "instance-federation-spam-filter-max-local-mentions"
+}
+
+// GetInstanceFederationSpamFilterMaxLocalMentions safely fetches the value for global configuration 'InstanceFederationSpamFilterMaxLocalMentions' field
+func GetInstanceFederationSpamFilterMaxLocalMentions() int {
+	return global.GetInstanceFederationSpamFilterMaxLocalMentions()
+}
+
+// SetInstanceFederationSpamFilterMaxLocalMentions safely sets the value for global configuration 'InstanceFederationSpamFilterMaxLocalMentions' field
+func SetInstanceFederationSpamFilterMaxLocalMentions(v int) {
+	global.SetInstanceFederationSpamFilterMaxLocalMentions(v)
+}
+
+// GetInstanceFederationSpamFilterQuarantineDays safely fetches the Configuration value for state's 'InstanceFederationSpamFilterQuarantineDays' field
+func (st *ConfigState) GetInstanceFederationSpamFilterQuarantineDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamFilterQuarantineDays
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamFilterQuarantineDays safely sets the Configuration value for state's 'InstanceFederationSpamFilterQuarantineDays' field
+func (st *ConfigState) SetInstanceFederationSpamFilterQuarantineDays(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamFilterQuarantineDays = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamFilterQuarantineDaysFlag returns the flag name for the 'InstanceFederationSpamFilterQuarantineDays' field
+func InstanceFederationSpamFilterQuarantineDaysFlag() string {
+	return "instance-federation-spam-filter-quarantine-days"
+}
+
+// GetInstanceFederationSpamFilterQuarantineDays safely fetches the value for global configuration 'InstanceFederationSpamFilterQuarantineDays' field
+func GetInstanceFederationSpamFilterQuarantineDays() int {
+	return global.GetInstanceFederationSpamFilterQuarantineDays()
+}
+
+// SetInstanceFederationSpamFilterQuarantineDays safely sets the value for global configuration 'InstanceFederationSpamFilterQuarantineDays' field
+func SetInstanceFederationSpamFilterQuarantineDays(v int) {
+	global.SetInstanceFederationSpamFilterQuarantineDays(v)
+}
+
+// GetInstanceFederationAccountFreshness safely fetches the Configuration value for state's 'InstanceFederationAccountFreshness' field
+func (st *ConfigState) GetInstanceFederationAccountFreshness() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationAccountFreshness
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationAccountFreshness safely sets the Configuration value for state's 'InstanceFederationAccountFreshness' field
+func (st *ConfigState) SetInstanceFederationAccountFreshness(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationAccountFreshness = v
+	st.reloadToViper()
+}
+
+// InstanceFederationAccountFreshnessFlag returns the flag name for the 'InstanceFederationAccountFreshness' field
+func InstanceFederationAccountFreshnessFlag() string {
+	return "instance-federation-account-freshness"
+}
+
+// GetInstanceFederationAccountFreshness safely fetches the value for global configuration 'InstanceFederationAccountFreshness' field
+func GetInstanceFederationAccountFreshness() time.Duration {
+	return global.GetInstanceFederationAccountFreshness()
+}
+
+// SetInstanceFederationAccountFreshness safely sets the value for global configuration 'InstanceFederationAccountFreshness' field
+func SetInstanceFederationAccountFreshness(v time.Duration) {
+	global.SetInstanceFederationAccountFreshness(v)
+}
+
+// GetInstanceFederationStatusFreshness safely fetches the Configuration value for state's 'InstanceFederationStatusFreshness' field
+func (st *ConfigState) GetInstanceFederationStatusFreshness() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationStatusFreshness
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationStatusFreshness safely sets the Configuration value for state's 'InstanceFederationStatusFreshness' field
+func (st *ConfigState) SetInstanceFederationStatusFreshness(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationStatusFreshness = v
+	st.reloadToViper()
+}
+
+// InstanceFederationStatusFreshnessFlag returns the flag name for the 'InstanceFederationStatusFreshness' field
+func InstanceFederationStatusFreshnessFlag() string {
+	return "instance-federation-status-freshness"
+}
+
+// GetInstanceFederationStatusFreshness safely fetches the value for global configuration 'InstanceFederationStatusFreshness' field
+func GetInstanceFederationStatusFreshness() time.Duration {
+	return global.GetInstanceFederationStatusFreshness()
+}
+
+// SetInstanceFederationStatusFreshness safely sets the value for global configuration 'InstanceFederationStatusFreshness' field
+func SetInstanceFederationStatusFreshness(v time.Duration) {
+	global.SetInstanceFederationStatusFreshness(v)
+}
+
+// GetInstanceFederationThreadBackfillMaxItems safely fetches the Configuration value for state's 'InstanceFederationThreadBackfillMaxItems' field
+func (st *ConfigState) GetInstanceFederationThreadBackfillMaxItems() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationThreadBackfillMaxItems
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationThreadBackfillMaxItems safely sets the Configuration value for state's 'InstanceFederationThreadBackfillMaxItems' field
+func (st *ConfigState) SetInstanceFederationThreadBackfillMaxItems(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationThreadBackfillMaxItems = v
+	st.reloadToViper()
+}
+
+// InstanceFederationThreadBackfillMaxItemsFlag returns the flag name for the 'InstanceFederationThreadBackfillMaxItems' field
+func InstanceFederationThreadBackfillMaxItemsFlag() string {
+	return "instance-federation-thread-backfill-max-items"
+}
+
+// GetInstanceFederationThreadBackfillMaxItems safely fetches the value for global configuration 'InstanceFederationThreadBackfillMaxItems' field
+func GetInstanceFederationThreadBackfillMaxItems() int {
+	return global.GetInstanceFederationThreadBackfillMaxItems()
+}
+
+// SetInstanceFederationThreadBackfillMaxItems safely sets the value for global configuration 'InstanceFederationThreadBackfillMaxItems' field
+func SetInstanceFederationThreadBackfillMaxItems(v int) {
+	global.SetInstanceFederationThreadBackfillMaxItems(v)
+}
+
 // GetInstanceExposePeers safely fetches the Configuration value for state's 'InstanceExposePeers' field
 func (st *ConfigState) GetInstanceExposePeers() (v bool) {
 	st.mutex.RLock()
@@ -900,6 +1236,56 @@ func GetInstanceExposePublicTimeline() bool { return global.GetInstanceExposePub
 // SetInstanceExposePublicTimeline safely sets the value for global configuration 'InstanceExposePublicTimeline' field
 func SetInstanceExposePublicTimeline(v bool) { global.SetInstanceExposePublicTimeline(v) }
 
+// GetInstanceExposePublicRSS safely fetches the Configuration value for state's 'InstanceExposePublicRSS' field
+func (st *ConfigState) GetInstanceExposePublicRSS() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceExposePublicRSS
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceExposePublicRSS safely sets the Configuration value for state's 'InstanceExposePublicRSS' field
+func (st *ConfigState) SetInstanceExposePublicRSS(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceExposePublicRSS = v
+	st.reloadToViper()
+}
+
+// InstanceExposePublicRSSFlag returns the flag name for the 'InstanceExposePublicRSS' field
+func InstanceExposePublicRSSFlag() string { return "instance-expose-public-rss" }
+
+// GetInstanceExposePublicRSS safely fetches the value for global configuration 'InstanceExposePublicRSS' field
+func GetInstanceExposePublicRSS() bool { return global.GetInstanceExposePublicRSS() }
+
+// SetInstanceExposePublicRSS safely sets the value for global configuration 'InstanceExposePublicRSS' field
+func SetInstanceExposePublicRSS(v bool) { global.SetInstanceExposePublicRSS(v) }
+
+// GetInstanceRSSMaxItems safely fetches the Configuration value for state's 'InstanceRSSMaxItems' field
+func (st *ConfigState) GetInstanceRSSMaxItems() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceRSSMaxItems
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceRSSMaxItems safely sets the Configuration value for state's 'InstanceRSSMaxItems' field
+func (st *ConfigState) SetInstanceRSSMaxItems(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceRSSMaxItems = v
+	st.reloadToViper()
+}
+
+// InstanceRSSMaxItemsFlag returns the flag name for the 'InstanceRSSMaxItems' field
+func InstanceRSSMaxItemsFlag() string { return "instance-rss-max-items" }
+
+// GetInstanceRSSMaxItems safely fetches the value for global configuration 'InstanceRSSMaxItems' field
+func GetInstanceRSSMaxItems() int { return global.GetInstanceRSSMaxItems() }
+
+// SetInstanceRSSMaxItems safely sets the value for global configuration 'InstanceRSSMaxItems' field
+func SetInstanceRSSMaxItems(v int) { global.SetInstanceRSSMaxItems(v) }
+
 // GetInstanceDeliverToSharedInboxes safely fetches the Configuration value for state's 'InstanceDeliverToSharedInboxes' field
 func (st *ConfigState) GetInstanceDeliverToSharedInboxes() (v bool) {
 	st.mutex.RLock()
@@ -975,6 +1361,31 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceTrendsEnabled safely fetches the Configuration value for state's 'InstanceTrendsEnabled' field
+func (st *ConfigState) GetInstanceTrendsEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceTrendsEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceTrendsEnabled safely sets the Configuration value for state's 'InstanceTrendsEnabled' field
+func (st *ConfigState) SetInstanceTrendsEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceTrendsEnabled = v
+	st.reloadToViper()
+}
+
+// InstanceTrendsEnabledFlag returns the flag name for the 'InstanceTrendsEnabled' field
+func InstanceTrendsEnabledFlag() string { return "instance-trends-enabled" }
+
+// GetInstanceTrendsEnabled safely fetches the value for global configuration 'InstanceTrendsEnabled' field
+func GetInstanceTrendsEnabled() bool { return global.GetInstanceTrendsEnabled() }
+
+// SetInstanceTrendsEnabled safely sets the value for global configuration 'InstanceTrendsEnabled' field
+func SetInstanceTrendsEnabled(v bool) { global.SetInstanceTrendsEnabled(v) }
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1050,6 +1461,31 @@ func GetAccountsReasonRequired() bool { return global.GetAccountsReasonRequired(
 // SetAccountsReasonRequired safely sets the value for global configuration 'AccountsReasonRequired' field
 func SetAccountsReasonRequired(v bool) { global.SetAccountsReasonRequired(v) }
 
+// GetAccountsInvitesEnabled safely fetches the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) GetAccountsInvitesEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitesEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitesEnabled safely sets the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) SetAccountsInvitesEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitesEnabled = v
+	st.reloadToViper()
+}
+
+// AccountsInvitesEnabledFlag returns the flag name for the 'AccountsInvitesEnabled' field
+func AccountsInvitesEnabledFlag() string { return "accounts-invites-enabled" }
+
+// GetAccountsInvitesEnabled safely fetches the value for global configuration 'AccountsInvitesEnabled' field
+func GetAccountsInvitesEnabled() bool { return global.GetAccountsInvitesEnabled() }
+
+// SetAccountsInvitesEnabled safely sets the value for global configuration 'AccountsInvitesEnabled' field
+func SetAccountsInvitesEnabled(v bool) { global.SetAccountsInvitesEnabled(v) }
+
 // GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
 func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
 	st.mutex.RLock()
@@ -1100,65 +1536,169 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
-// GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
-func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
+// GetAccountsCustomCSSAllowRemoteImports safely fetches the Configuration value for state's 'AccountsCustomCSSAllowRemoteImports' field
+func (st *ConfigState) GetAccountsCustomCSSAllowRemoteImports() (v bool) {
 	st.mutex.RLock()
-	v = st.config.MediaImageMaxSize
+	v = st.config.AccountsCustomCSSAllowRemoteImports
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaImageMaxSize safely sets the Configuration value for state's 'MediaImageMaxSize' field
-func (st *ConfigState) SetMediaImageMaxSize(v bytesize.Size) {
+// SetAccountsCustomCSSAllowRemoteImports safely sets the Configuration value for state's 'AccountsCustomCSSAllowRemoteImports' field
+func (st *ConfigState) SetAccountsCustomCSSAllowRemoteImports(v bool) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaImageMaxSize = v
+	st.config.AccountsCustomCSSAllowRemoteImports = v
 	st.reloadToViper()
 }
 
-// MediaImageMaxSizeFlag returns the flag name for the 'MediaImageMaxSize' field
-func MediaImageMaxSizeFlag() string { return "media-image-max-size" }
+// AccountsCustomCSSAllowRemoteImportsFlag returns the flag name for the 'AccountsCustomCSSAllowRemoteImports' field
+func AccountsCustomCSSAllowRemoteImportsFlag() string {
+	return "accounts-custom-css-allow-remote-imports"
+}
 
-// GetMediaImageMaxSize safely fetches the value for global configuration 'MediaImageMaxSize' field
-func GetMediaImageMaxSize() bytesize.Size { return global.GetMediaImageMaxSize() }
+// GetAccountsCustomCSSAllowRemoteImports safely fetches the value for global configuration 'AccountsCustomCSSAllowRemoteImports' field
+func GetAccountsCustomCSSAllowRemoteImports() bool {
+	return global.GetAccountsCustomCSSAllowRemoteImports()
+}
 
-// SetMediaImageMaxSize safely sets the value for global configuration 'MediaImageMaxSize' field
-func SetMediaImageMaxSize(v bytesize.Size) { global.SetMediaImageMaxSize(v) }
+// SetAccountsCustomCSSAllowRemoteImports safely sets the value for global configuration 'AccountsCustomCSSAllowRemoteImports' field
+func SetAccountsCustomCSSAllowRemoteImports(v bool) { global.SetAccountsCustomCSSAllowRemoteImports(v) }
 
-// GetMediaVideoMaxSize safely fetches the Configuration value for state's 'MediaVideoMaxSize' field
-func (st *ConfigState) GetMediaVideoMaxSize() (v bytesize.Size) {
+// GetAccountsDeletionGracePeriod safely fetches the Configuration value for state's 'AccountsDeletionGracePeriod' field
+func (st *ConfigState) GetAccountsDeletionGracePeriod() (v time.Duration) {
 	st.mutex.RLock()
-	v = st.config.MediaVideoMaxSize
+	v = st.config.AccountsDeletionGracePeriod
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaVideoMaxSize safely sets the Configuration value for state's 'MediaVideoMaxSize' field
-func (st *ConfigState) SetMediaVideoMaxSize(v bytesize.Size) {
+// SetAccountsDeletionGracePeriod safely sets the Configuration value for state's 'AccountsDeletionGracePeriod' field
+func (st *ConfigState) SetAccountsDeletionGracePeriod(v time.Duration) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaVideoMaxSize = v
+	st.config.AccountsDeletionGracePeriod = v
 	st.reloadToViper()
 }
 
-// MediaVideoMaxSizeFlag returns the flag name for the 'MediaVideoMaxSize' field
-func MediaVideoMaxSizeFlag() string { return "media-video-max-size" }
+// AccountsDeletionGracePeriodFlag returns the flag name for the 'AccountsDeletionGracePeriod' field
+func AccountsDeletionGracePeriodFlag() string { return "accounts-deletion-grace-period" }
 
-// GetMediaVideoMaxSize safely fetches the value for global configuration 'MediaVideoMaxSize' field
-func GetMediaVideoMaxSize() bytesize.Size { return global.GetMediaVideoMaxSize() }
+// GetAccountsDeletionGracePeriod safely fetches the value for global configuration 'AccountsDeletionGracePeriod' field
+func GetAccountsDeletionGracePeriod() time.Duration { return global.GetAccountsDeletionGracePeriod() }
 
-// SetMediaVideoMaxSize safely sets the value for global configuration 'MediaVideoMaxSize' field
-func SetMediaVideoMaxSize(v bytesize.Size) { global.SetMediaVideoMaxSize(v) }
+// SetAccountsDeletionGracePeriod safely sets the value for global configuration 'AccountsDeletionGracePeriod' field
+func SetAccountsDeletionGracePeriod(v time.Duration) { global.SetAccountsDeletionGracePeriod(v) }
 
-// GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
-func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
+// GetAccountsDirectoryShowRemote safely fetches the Configuration value for state's 'AccountsDirectoryShowRemote' field
+func (st *ConfigState) GetAccountsDirectoryShowRemote() (v bool) {
 	st.mutex.RLock()
-	v = st.config.MediaDescriptionMinChars
+	v = st.config.AccountsDirectoryShowRemote
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaDescriptionMinChars safely sets the Configuration value for state's 'MediaDescriptionMinChars' field
+// SetAccountsDirectoryShowRemote safely sets the Configuration value for state's 'AccountsDirectoryShowRemote' field
+func (st *ConfigState) SetAccountsDirectoryShowRemote(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsDirectoryShowRemote = v
+	st.reloadToViper()
+}
+
+// AccountsDirectoryShowRemoteFlag returns the flag name for the 'AccountsDirectoryShowRemote' field
+func AccountsDirectoryShowRemoteFlag() string { return "accounts-directory-show-remote" }
+
+// GetAccountsDirectoryShowRemote safely fetches the value for global configuration 'AccountsDirectoryShowRemote' field
+func GetAccountsDirectoryShowRemote() bool { return global.GetAccountsDirectoryShowRemote() }
+
+// SetAccountsDirectoryShowRemote safely sets the value for global configuration 'AccountsDirectoryShowRemote' field
+func SetAccountsDirectoryShowRemote(v bool) { global.SetAccountsDirectoryShowRemote(v) }
+
+// GetAccountsIPRetention safely fetches the Configuration value for state's 'AccountsIPRetention' field
+func (st *ConfigState) GetAccountsIPRetention() (v string) {
+	st.mutex.RLock()
+	v = st.config.AccountsIPRetention
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsIPRetention safely sets the Configuration value for state's 'AccountsIPRetention' field
+func (st *ConfigState) SetAccountsIPRetention(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsIPRetention = v
+	st.reloadToViper()
+}
+
+// AccountsIPRetentionFlag returns the flag name for the 'AccountsIPRetention' field
+func AccountsIPRetentionFlag() string { return "accounts-ip-retention" }
+
+// GetAccountsIPRetention safely fetches the value for global configuration 'AccountsIPRetention' field
+func GetAccountsIPRetention() string { return global.GetAccountsIPRetention() }
+
+// SetAccountsIPRetention safely sets the value for global configuration 'AccountsIPRetention' field
+func SetAccountsIPRetention(v string) { global.SetAccountsIPRetention(v) }
+
+// GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
+func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaImageMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaImageMaxSize safely sets the Configuration value for state's 'MediaImageMaxSize' field
+func (st *ConfigState) SetMediaImageMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaImageMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaImageMaxSizeFlag returns the flag name for the 'MediaImageMaxSize' field
+func MediaImageMaxSizeFlag() string { return "media-image-max-size" }
+
+// GetMediaImageMaxSize safely fetches the value for global configuration 'MediaImageMaxSize' field
+func GetMediaImageMaxSize() bytesize.Size { return global.GetMediaImageMaxSize() }
+
+// SetMediaImageMaxSize safely sets the value for global configuration 'MediaImageMaxSize' field
+func SetMediaImageMaxSize(v bytesize.Size) { global.SetMediaImageMaxSize(v) }
+
+// GetMediaVideoMaxSize safely fetches the Configuration value for state's 'MediaVideoMaxSize' field
+func (st *ConfigState) GetMediaVideoMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaVideoMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaVideoMaxSize safely sets the Configuration value for state's 'MediaVideoMaxSize' field
+func (st *ConfigState) SetMediaVideoMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaVideoMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaVideoMaxSizeFlag returns the flag name for the 'MediaVideoMaxSize' field
+func MediaVideoMaxSizeFlag() string { return "media-video-max-size" }
+
+// GetMediaVideoMaxSize safely fetches the value for global configuration 'MediaVideoMaxSize' field
+func GetMediaVideoMaxSize() bytesize.Size { return global.GetMediaVideoMaxSize() }
+
+// SetMediaVideoMaxSize safely sets the value for global configuration 'MediaVideoMaxSize' field
+func SetMediaVideoMaxSize(v bytesize.Size) { global.SetMediaVideoMaxSize(v) }
+
+// GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
+func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaDescriptionMinChars
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaDescriptionMinChars safely sets the Configuration value for state's 'MediaDescriptionMinChars' field
 func (st *ConfigState) SetMediaDescriptionMinChars(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
@@ -1325,6 +1865,156 @@ func GetMediaCleanupEvery() time.Duration { return global.GetMediaCleanupEvery()
 // SetMediaCleanupEvery safely sets the value for global configuration 'MediaCleanupEvery' field
 func SetMediaCleanupEvery(v time.Duration) { global.SetMediaCleanupEvery(v) }
 
+// GetMediaUnattachedMaxAge safely fetches the Configuration value for state's 'MediaUnattachedMaxAge' field
+func (st *ConfigState) GetMediaUnattachedMaxAge() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.MediaUnattachedMaxAge
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaUnattachedMaxAge safely sets the Configuration value for state's 'MediaUnattachedMaxAge' field
+func (st *ConfigState) SetMediaUnattachedMaxAge(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaUnattachedMaxAge = v
+	st.reloadToViper()
+}
+
+// MediaUnattachedMaxAgeFlag returns the flag name for the 'MediaUnattachedMaxAge' field
+func MediaUnattachedMaxAgeFlag() string { return "media-unattached-max-age" }
+
+// GetMediaUnattachedMaxAge safely fetches the value for global configuration 'MediaUnattachedMaxAge' field
+func GetMediaUnattachedMaxAge() time.Duration { return global.GetMediaUnattachedMaxAge() }
+
+// SetMediaUnattachedMaxAge safely sets the value for global configuration 'MediaUnattachedMaxAge' field
+func SetMediaUnattachedMaxAge(v time.Duration) { global.SetMediaUnattachedMaxAge(v) }
+
+// GetMediaTranscodeEnabled safely fetches the Configuration value for state's 'MediaTranscodeEnabled' field
+func (st *ConfigState) GetMediaTranscodeEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeEnabled safely sets the Configuration value for state's 'MediaTranscodeEnabled' field
+func (st *ConfigState) SetMediaTranscodeEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeEnabled = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeEnabledFlag returns the flag name for the 'MediaTranscodeEnabled' field
+func MediaTranscodeEnabledFlag() string { return "media-transcode-enabled" }
+
+// GetMediaTranscodeEnabled safely fetches the value for global configuration 'MediaTranscodeEnabled' field
+func GetMediaTranscodeEnabled() bool { return global.GetMediaTranscodeEnabled() }
+
+// SetMediaTranscodeEnabled safely sets the value for global configuration 'MediaTranscodeEnabled' field
+func SetMediaTranscodeEnabled(v bool) { global.SetMediaTranscodeEnabled(v) }
+
+// GetMediaTranscodeFfmpegPath safely fetches the Configuration value for state's 'MediaTranscodeFfmpegPath' field
+func (st *ConfigState) GetMediaTranscodeFfmpegPath() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeFfmpegPath
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeFfmpegPath safely sets the Configuration value for state's 'MediaTranscodeFfmpegPath' field
+func (st *ConfigState) SetMediaTranscodeFfmpegPath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeFfmpegPath = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeFfmpegPathFlag returns the flag name for the 'MediaTranscodeFfmpegPath' field
+func MediaTranscodeFfmpegPathFlag() string { return "media-transcode-ffmpeg-path" }
+
+// GetMediaTranscodeFfmpegPath safely fetches the value for global configuration 'MediaTranscodeFfmpegPath' field
+func GetMediaTranscodeFfmpegPath() string { return global.GetMediaTranscodeFfmpegPath() }
+
+// SetMediaTranscodeFfmpegPath safely sets the value for global configuration 'MediaTranscodeFfmpegPath' field
+func SetMediaTranscodeFfmpegPath(v string) { global.SetMediaTranscodeFfmpegPath(v) }
+
+// GetMediaTranscodeTimeout safely fetches the Configuration value for state's 'MediaTranscodeTimeout' field
+func (st *ConfigState) GetMediaTranscodeTimeout() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeTimeout
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeTimeout safely sets the Configuration value for state's 'MediaTranscodeTimeout' field
+func (st *ConfigState) SetMediaTranscodeTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeTimeout = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeTimeoutFlag returns the flag name for the 'MediaTranscodeTimeout' field
+func MediaTranscodeTimeoutFlag() string { return "media-transcode-timeout" }
+
+// GetMediaTranscodeTimeout safely fetches the value for global configuration 'MediaTranscodeTimeout' field
+func GetMediaTranscodeTimeout() time.Duration { return global.GetMediaTranscodeTimeout() }
+
+// SetMediaTranscodeTimeout safely sets the value for global configuration 'MediaTranscodeTimeout' field
+func SetMediaTranscodeTimeout(v time.Duration) { global.SetMediaTranscodeTimeout(v) }
+
+// GetMediaDisableAnimation safely fetches the Configuration value for state's 'MediaDisableAnimation' field
+func (st *ConfigState) GetMediaDisableAnimation() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaDisableAnimation
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaDisableAnimation safely sets the Configuration value for state's 'MediaDisableAnimation' field
+func (st *ConfigState) SetMediaDisableAnimation(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaDisableAnimation = v
+	st.reloadToViper()
+}
+
+// MediaDisableAnimationFlag returns the flag name for the 'MediaDisableAnimation' field
+func MediaDisableAnimationFlag() string { return "media-disable-animation" }
+
+// GetMediaDisableAnimation safely fetches the value for global configuration 'MediaDisableAnimation' field
+func GetMediaDisableAnimation() bool { return global.GetMediaDisableAnimation() }
+
+// SetMediaDisableAnimation safely sets the value for global configuration 'MediaDisableAnimation' field
+func SetMediaDisableAnimation(v bool) { global.SetMediaDisableAnimation(v) }
+
+// GetMediaThumbnailWebP safely fetches the Configuration value for state's 'MediaThumbnailWebP' field
+func (st *ConfigState) GetMediaThumbnailWebP() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaThumbnailWebP
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaThumbnailWebP safely sets the Configuration value for state's 'MediaThumbnailWebP' field
+func (st *ConfigState) SetMediaThumbnailWebP(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaThumbnailWebP = v
+	st.reloadToViper()
+}
+
+// MediaThumbnailWebPFlag returns the flag name for the 'MediaThumbnailWebP' field
+func MediaThumbnailWebPFlag() string { return "media-thumbnail-webp" }
+
+// GetMediaThumbnailWebP safely fetches the value for global configuration 'MediaThumbnailWebP' field
+func GetMediaThumbnailWebP() bool { return global.GetMediaThumbnailWebP() }
+
+// SetMediaThumbnailWebP safely sets the value for global configuration 'MediaThumbnailWebP' field
+func SetMediaThumbnailWebP(v bool) { global.SetMediaThumbnailWebP(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.RLock()
@@ -1375,6 +2065,31 @@ func GetStorageLocalBasePath() string { return global.GetStorageLocalBasePath()
 // SetStorageLocalBasePath safely sets the value for global configuration 'StorageLocalBasePath' field
 func SetStorageLocalBasePath(v string) { global.SetStorageLocalBasePath(v) }
 
+// GetStorageLocalMinFreeSpace safely fetches the Configuration value for state's 'StorageLocalMinFreeSpace' field
+func (st *ConfigState) GetStorageLocalMinFreeSpace() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.StorageLocalMinFreeSpace
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageLocalMinFreeSpace safely sets the Configuration value for state's 'StorageLocalMinFreeSpace' field
+func (st *ConfigState) SetStorageLocalMinFreeSpace(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageLocalMinFreeSpace = v
+	st.reloadToViper()
+}
+
+// StorageLocalMinFreeSpaceFlag returns the flag name for the 'StorageLocalMinFreeSpace' field
+func StorageLocalMinFreeSpaceFlag() string { return "storage-local-min-free-space" }
+
+// GetStorageLocalMinFreeSpace safely fetches the value for global configuration 'StorageLocalMinFreeSpace' field
+func GetStorageLocalMinFreeSpace() bytesize.Size { return global.GetStorageLocalMinFreeSpace() }
+
+// SetStorageLocalMinFreeSpace safely sets the value for global configuration 'StorageLocalMinFreeSpace' field
+func SetStorageLocalMinFreeSpace(v bytesize.Size) { global.SetStorageLocalMinFreeSpace(v) }
+
 // GetStorageS3Endpoint safely fetches the Configuration value for state's 'StorageS3Endpoint' field
 func (st *ConfigState) GetStorageS3Endpoint() (v string) {
 	st.mutex.RLock()
@@ -1525,6 +2240,31 @@ func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
 // SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
 func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
 
+// GetStorageS3ProxyMediaTypes safely fetches the Configuration value for state's 'StorageS3ProxyMediaTypes' field
+func (st *ConfigState) GetStorageS3ProxyMediaTypes() (v []string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3ProxyMediaTypes
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3ProxyMediaTypes safely sets the Configuration value for state's 'StorageS3ProxyMediaTypes' field
+func (st *ConfigState) SetStorageS3ProxyMediaTypes(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3ProxyMediaTypes = v
+	st.reloadToViper()
+}
+
+// StorageS3ProxyMediaTypesFlag returns the flag name for the 'StorageS3ProxyMediaTypes' field
+func StorageS3ProxyMediaTypesFlag() string { return "storage-s3-proxy-media-types" }
+
+// GetStorageS3ProxyMediaTypes safely fetches the value for global configuration 'StorageS3ProxyMediaTypes' field
+func GetStorageS3ProxyMediaTypes() []string { return global.GetStorageS3ProxyMediaTypes() }
+
+// SetStorageS3ProxyMediaTypes safely sets the value for global configuration 'StorageS3ProxyMediaTypes' field
+func SetStorageS3ProxyMediaTypes(v []string) { global.SetStorageS3ProxyMediaTypes(v) }
+
 // GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
 func (st *ConfigState) GetStatusesMaxChars() (v int) {
 	st.mutex.RLock()
@@ -1600,6 +2340,31 @@ func GetStatusesPollOptionMaxChars() int { return global.GetStatusesPollOptionMa
 // SetStatusesPollOptionMaxChars safely sets the value for global configuration 'StatusesPollOptionMaxChars' field
 func SetStatusesPollOptionMaxChars(v int) { global.SetStatusesPollOptionMaxChars(v) }
 
+// GetStatusesPollAllowRevote safely fetches the Configuration value for state's 'StatusesPollAllowRevote' field
+func (st *ConfigState) GetStatusesPollAllowRevote() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StatusesPollAllowRevote
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesPollAllowRevote safely sets the Configuration value for state's 'StatusesPollAllowRevote' field
+func (st *ConfigState) SetStatusesPollAllowRevote(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesPollAllowRevote = v
+	st.reloadToViper()
+}
+
+// StatusesPollAllowRevoteFlag returns the flag name for the 'StatusesPollAllowRevote' field
+func StatusesPollAllowRevoteFlag() string { return "statuses-poll-allow-revote" }
+
+// GetStatusesPollAllowRevote safely fetches the value for global configuration 'StatusesPollAllowRevote' field
+func GetStatusesPollAllowRevote() bool { return global.GetStatusesPollAllowRevote() }
+
+// SetStatusesPollAllowRevote safely sets the value for global configuration 'StatusesPollAllowRevote' field
+func SetStatusesPollAllowRevote(v bool) { global.SetStatusesPollAllowRevote(v) }
+
 // GetStatusesMediaMaxFiles safely fetches the Configuration value for state's 'StatusesMediaMaxFiles' field
 func (st *ConfigState) GetStatusesMediaMaxFiles() (v int) {
 	st.mutex.RLock()
@@ -1625,6 +2390,56 @@ func GetStatusesMediaMaxFiles() int { return global.GetStatusesMediaMaxFiles() }
 // SetStatusesMediaMaxFiles safely sets the value for global configuration 'StatusesMediaMaxFiles' field
 func SetStatusesMediaMaxFiles(v int) { global.SetStatusesMediaMaxFiles(v) }
 
+// GetStatusesMaxPinned safely fetches the Configuration value for state's 'StatusesMaxPinned' field
+func (st *ConfigState) GetStatusesMaxPinned() (v int) {
+	st.mutex.RLock()
+	v = st.config.StatusesMaxPinned
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesMaxPinned safely sets the Configuration value for state's 'StatusesMaxPinned' field
+func (st *ConfigState) SetStatusesMaxPinned(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesMaxPinned = v
+	st.reloadToViper()
+}
+
+// StatusesMaxPinnedFlag returns the flag name for the 'StatusesMaxPinned' field
+func StatusesMaxPinnedFlag() string { return "statuses-max-pinned" }
+
+// GetStatusesMaxPinned safely fetches the value for global configuration 'StatusesMaxPinned' field
+func GetStatusesMaxPinned() int { return global.GetStatusesMaxPinned() }
+
+// SetStatusesMaxPinned safely sets the value for global configuration 'StatusesMaxPinned' field
+func SetStatusesMaxPinned(v int) { global.SetStatusesMaxPinned(v) }
+
+// GetStatusesReactionsEnabled safely fetches the Configuration value for state's 'StatusesReactionsEnabled' field
+func (st *ConfigState) GetStatusesReactionsEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StatusesReactionsEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesReactionsEnabled safely sets the Configuration value for state's 'StatusesReactionsEnabled' field
+func (st *ConfigState) SetStatusesReactionsEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesReactionsEnabled = v
+	st.reloadToViper()
+}
+
+// StatusesReactionsEnabledFlag returns the flag name for the 'StatusesReactionsEnabled' field
+func StatusesReactionsEnabledFlag() string { return "statuses-reactions-enabled" }
+
+// GetStatusesReactionsEnabled safely fetches the value for global configuration 'StatusesReactionsEnabled' field
+func GetStatusesReactionsEnabled() bool { return global.GetStatusesReactionsEnabled() }
+
+// SetStatusesReactionsEnabled safely sets the value for global configuration 'StatusesReactionsEnabled' field
+func SetStatusesReactionsEnabled(v bool) { global.SetStatusesReactionsEnabled(v) }
+
 // GetLetsEncryptEnabled safely fetches the Configuration value for state's 'LetsEncryptEnabled' field
 func (st *ConfigState) GetLetsEncryptEnabled() (v bool) {
 	st.mutex.RLock()
@@ -2025,76 +2840,176 @@ func GetOIDCAdminGroups() []string { return global.GetOIDCAdminGroups() }
 // SetOIDCAdminGroups safely sets the value for global configuration 'OIDCAdminGroups' field
 func SetOIDCAdminGroups(v []string) { global.SetOIDCAdminGroups(v) }
 
-// GetTracingEnabled safely fetches the Configuration value for state's 'TracingEnabled' field
-func (st *ConfigState) GetTracingEnabled() (v bool) {
+// GetOIDCModeratorGroups safely fetches the Configuration value for state's 'OIDCModeratorGroups' field
+func (st *ConfigState) GetOIDCModeratorGroups() (v []string) {
 	st.mutex.RLock()
-	v = st.config.TracingEnabled
+	v = st.config.OIDCModeratorGroups
 	st.mutex.RUnlock()
 	return
 }
 
-// SetTracingEnabled safely sets the Configuration value for state's 'TracingEnabled' field
-func (st *ConfigState) SetTracingEnabled(v bool) {
+// SetOIDCModeratorGroups safely sets the Configuration value for state's 'OIDCModeratorGroups' field
+func (st *ConfigState) SetOIDCModeratorGroups(v []string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.TracingEnabled = v
+	st.config.OIDCModeratorGroups = v
 	st.reloadToViper()
 }
 
-// TracingEnabledFlag returns the flag name for the 'TracingEnabled' field
-func TracingEnabledFlag() string { return "tracing-enabled" }
+// OIDCModeratorGroupsFlag returns the flag name for the 'OIDCModeratorGroups' field
+func OIDCModeratorGroupsFlag() string { return "oidc-moderator-groups" }
 
-// GetTracingEnabled safely fetches the value for global configuration 'TracingEnabled' field
-func GetTracingEnabled() bool { return global.GetTracingEnabled() }
+// GetOIDCModeratorGroups safely fetches the value for global configuration 'OIDCModeratorGroups' field
+func GetOIDCModeratorGroups() []string { return global.GetOIDCModeratorGroups() }
 
-// SetTracingEnabled safely sets the value for global configuration 'TracingEnabled' field
-func SetTracingEnabled(v bool) { global.SetTracingEnabled(v) }
+// SetOIDCModeratorGroups safely sets the value for global configuration 'OIDCModeratorGroups' field
+func SetOIDCModeratorGroups(v []string) { global.SetOIDCModeratorGroups(v) }
 
-// GetTracingTransport safely fetches the Configuration value for state's 'TracingTransport' field
-func (st *ConfigState) GetTracingTransport() (v string) {
+// GetOIDCGroupsClaim safely fetches the Configuration value for state's 'OIDCGroupsClaim' field
+func (st *ConfigState) GetOIDCGroupsClaim() (v string) {
 	st.mutex.RLock()
-	v = st.config.TracingTransport
+	v = st.config.OIDCGroupsClaim
 	st.mutex.RUnlock()
 	return
 }
 
-// SetTracingTransport safely sets the Configuration value for state's 'TracingTransport' field
-func (st *ConfigState) SetTracingTransport(v string) {
+// SetOIDCGroupsClaim safely sets the Configuration value for state's 'OIDCGroupsClaim' field
+func (st *ConfigState) SetOIDCGroupsClaim(v string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.TracingTransport = v
+	st.config.OIDCGroupsClaim = v
 	st.reloadToViper()
 }
 
-// TracingTransportFlag returns the flag name for the 'TracingTransport' field
-func TracingTransportFlag() string { return "tracing-transport" }
+// OIDCGroupsClaimFlag returns the flag name for the 'OIDCGroupsClaim' field
+func OIDCGroupsClaimFlag() string { return "oidc-groups-claim" }
 
-// GetTracingTransport safely fetches the value for global configuration 'TracingTransport' field
-func GetTracingTransport() string { return global.GetTracingTransport() }
+// GetOIDCGroupsClaim safely fetches the value for global configuration 'OIDCGroupsClaim' field
+func GetOIDCGroupsClaim() string { return global.GetOIDCGroupsClaim() }
 
-// SetTracingTransport safely sets the value for global configuration 'TracingTransport' field
-func SetTracingTransport(v string) { global.SetTracingTransport(v) }
+// SetOIDCGroupsClaim safely sets the value for global configuration 'OIDCGroupsClaim' field
+func SetOIDCGroupsClaim(v string) { global.SetOIDCGroupsClaim(v) }
 
-// GetTracingEndpoint safely fetches the Configuration value for state's 'TracingEndpoint' field
-func (st *ConfigState) GetTracingEndpoint() (v string) {
+// GetOIDCAutoProvision safely fetches the Configuration value for state's 'OIDCAutoProvision' field
+func (st *ConfigState) GetOIDCAutoProvision() (v bool) {
 	st.mutex.RLock()
-	v = st.config.TracingEndpoint
+	v = st.config.OIDCAutoProvision
 	st.mutex.RUnlock()
 	return
 }
 
-// SetTracingEndpoint safely sets the Configuration value for state's 'TracingEndpoint' field
-func (st *ConfigState) SetTracingEndpoint(v string) {
+// SetOIDCAutoProvision safely sets the Configuration value for state's 'OIDCAutoProvision' field
+func (st *ConfigState) SetOIDCAutoProvision(v bool) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.TracingEndpoint = v
+	st.config.OIDCAutoProvision = v
 	st.reloadToViper()
 }
 
-// TracingEndpointFlag returns the flag name for the 'TracingEndpoint' field
-func TracingEndpointFlag() string { return "tracing-endpoint" }
+// OIDCAutoProvisionFlag returns the flag name for the 'OIDCAutoProvision' field
+func OIDCAutoProvisionFlag() string { return "oidc-auto-provision" }
 
-// GetTracingEndpoint safely fetches the value for global configuration 'TracingEndpoint' field
+// GetOIDCAutoProvision safely fetches the value for global configuration 'OIDCAutoProvision' field
+func GetOIDCAutoProvision() bool { return global.GetOIDCAutoProvision() }
+
+// SetOIDCAutoProvision safely sets the value for global configuration 'OIDCAutoProvision' field
+func SetOIDCAutoProvision(v bool) { global.SetOIDCAutoProvision(v) }
+
+// GetOIDCDisablePasswordLogin safely fetches the Configuration value for state's 'OIDCDisablePasswordLogin' field
+func (st *ConfigState) GetOIDCDisablePasswordLogin() (v bool) {
+	st.mutex.RLock()
+	v = st.config.OIDCDisablePasswordLogin
+	st.mutex.RUnlock()
+	return
+}
+
+// SetOIDCDisablePasswordLogin safely sets the Configuration value for state's 'OIDCDisablePasswordLogin' field
+func (st *ConfigState) SetOIDCDisablePasswordLogin(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCDisablePasswordLogin = v
+	st.reloadToViper()
+}
+
+// OIDCDisablePasswordLoginFlag returns the flag name for the 'OIDCDisablePasswordLogin' field
+func OIDCDisablePasswordLoginFlag() string { return "oidc-disable-password-login" }
+
+// GetOIDCDisablePasswordLogin safely fetches the value for global configuration 'OIDCDisablePasswordLogin' field
+func GetOIDCDisablePasswordLogin() bool { return global.GetOIDCDisablePasswordLogin() }
+
+// SetOIDCDisablePasswordLogin safely sets the value for global configuration 'OIDCDisablePasswordLogin' field
+func SetOIDCDisablePasswordLogin(v bool) { global.SetOIDCDisablePasswordLogin(v) }
+
+// GetTracingEnabled safely fetches the Configuration value for state's 'TracingEnabled' field
+func (st *ConfigState) GetTracingEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.TracingEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTracingEnabled safely sets the Configuration value for state's 'TracingEnabled' field
+func (st *ConfigState) SetTracingEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TracingEnabled = v
+	st.reloadToViper()
+}
+
+// TracingEnabledFlag returns the flag name for the 'TracingEnabled' field
+func TracingEnabledFlag() string { return "tracing-enabled" }
+
+// GetTracingEnabled safely fetches the value for global configuration 'TracingEnabled' field
+func GetTracingEnabled() bool { return global.GetTracingEnabled() }
+
+// SetTracingEnabled safely sets the value for global configuration 'TracingEnabled' field
+func SetTracingEnabled(v bool) { global.SetTracingEnabled(v) }
+
+// GetTracingTransport safely fetches the Configuration value for state's 'TracingTransport' field
+func (st *ConfigState) GetTracingTransport() (v string) {
+	st.mutex.RLock()
+	v = st.config.TracingTransport
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTracingTransport safely sets the Configuration value for state's 'TracingTransport' field
+func (st *ConfigState) SetTracingTransport(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TracingTransport = v
+	st.reloadToViper()
+}
+
+// TracingTransportFlag returns the flag name for the 'TracingTransport' field
+func TracingTransportFlag() string { return "tracing-transport" }
+
+// GetTracingTransport safely fetches the value for global configuration 'TracingTransport' field
+func GetTracingTransport() string { return global.GetTracingTransport() }
+
+// SetTracingTransport safely sets the value for global configuration 'TracingTransport' field
+func SetTracingTransport(v string) { global.SetTracingTransport(v) }
+
+// GetTracingEndpoint safely fetches the Configuration value for state's 'TracingEndpoint' field
+func (st *ConfigState) GetTracingEndpoint() (v string) {
+	st.mutex.RLock()
+	v = st.config.TracingEndpoint
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTracingEndpoint safely sets the Configuration value for state's 'TracingEndpoint' field
+func (st *ConfigState) SetTracingEndpoint(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TracingEndpoint = v
+	st.reloadToViper()
+}
+
+// TracingEndpointFlag returns the flag name for the 'TracingEndpoint' field
+func TracingEndpointFlag() string { return "tracing-endpoint" }
+
+// GetTracingEndpoint safely fetches the value for global configuration 'TracingEndpoint' field
 func GetTracingEndpoint() string { return global.GetTracingEndpoint() }
 
 // SetTracingEndpoint safely sets the value for global configuration 'TracingEndpoint' field
@@ -2419,236 +3334,513 @@ func (st *ConfigState) SetSyslogProtocol(v string) {
 // SyslogProtocolFlag returns the flag name for the 'SyslogProtocol' field
 func SyslogProtocolFlag() string { return "syslog-protocol" }
 
-// GetSyslogProtocol safely fetches the value for global configuration 'SyslogProtocol' field
-func GetSyslogProtocol() string { return global.GetSyslogProtocol() }
+// GetSyslogProtocol safely fetches the value for global configuration 'SyslogProtocol' field
+func GetSyslogProtocol() string { return global.GetSyslogProtocol() }
+
+// SetSyslogProtocol safely sets the value for global configuration 'SyslogProtocol' field
+func SetSyslogProtocol(v string) { global.SetSyslogProtocol(v) }
+
+// GetSyslogAddress safely fetches the Configuration value for state's 'SyslogAddress' field
+func (st *ConfigState) GetSyslogAddress() (v string) {
+	st.mutex.RLock()
+	v = st.config.SyslogAddress
+	st.mutex.RUnlock()
+	return
+}
+
+// SetSyslogAddress safely sets the Configuration value for state's 'SyslogAddress' field
+func (st *ConfigState) SetSyslogAddress(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.SyslogAddress = v
+	st.reloadToViper()
+}
+
+// SyslogAddressFlag returns the flag name for the 'SyslogAddress' field
+func SyslogAddressFlag() string { return "syslog-address" }
+
+// GetSyslogAddress safely fetches the value for global configuration 'SyslogAddress' field
+func GetSyslogAddress() string { return global.GetSyslogAddress() }
+
+// SetSyslogAddress safely sets the value for global configuration 'SyslogAddress' field
+func SetSyslogAddress(v string) { global.SetSyslogAddress(v) }
+
+// GetAdvancedCookiesSamesite safely fetches the Configuration value for state's 'AdvancedCookiesSamesite' field
+func (st *ConfigState) GetAdvancedCookiesSamesite() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedCookiesSamesite
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedCookiesSamesite safely sets the Configuration value for state's 'AdvancedCookiesSamesite' field
+func (st *ConfigState) SetAdvancedCookiesSamesite(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedCookiesSamesite = v
+	st.reloadToViper()
+}
+
+// AdvancedCookiesSamesiteFlag returns the flag name for the 'AdvancedCookiesSamesite' field
+func AdvancedCookiesSamesiteFlag() string { return "advanced-cookies-samesite" }
+
+// GetAdvancedCookiesSamesite safely fetches the value for global configuration 'AdvancedCookiesSamesite' field
+func GetAdvancedCookiesSamesite() string { return global.GetAdvancedCookiesSamesite() }
+
+// SetAdvancedCookiesSamesite safely sets the value for global configuration 'AdvancedCookiesSamesite' field
+func SetAdvancedCookiesSamesite(v string) { global.SetAdvancedCookiesSamesite(v) }
+
+// GetAdvancedRateLimitRequests safely fetches the Configuration value for state's 'AdvancedRateLimitRequests' field
+func (st *ConfigState) GetAdvancedRateLimitRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitRequests safely sets the Configuration value for state's 'AdvancedRateLimitRequests' field
+func (st *ConfigState) SetAdvancedRateLimitRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitRequestsFlag returns the flag name for the 'AdvancedRateLimitRequests' field
+func AdvancedRateLimitRequestsFlag() string { return "advanced-rate-limit-requests" }
+
+// GetAdvancedRateLimitRequests safely fetches the value for global configuration 'AdvancedRateLimitRequests' field
+func GetAdvancedRateLimitRequests() int { return global.GetAdvancedRateLimitRequests() }
+
+// SetAdvancedRateLimitRequests safely sets the value for global configuration 'AdvancedRateLimitRequests' field
+func SetAdvancedRateLimitRequests(v int) { global.SetAdvancedRateLimitRequests(v) }
+
+// GetAdvancedRateLimitAuthRequests safely fetches the Configuration value for state's 'AdvancedRateLimitAuthRequests' field
+func (st *ConfigState) GetAdvancedRateLimitAuthRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitAuthRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitAuthRequests safely sets the Configuration value for state's 'AdvancedRateLimitAuthRequests' field
+func (st *ConfigState) SetAdvancedRateLimitAuthRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitAuthRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitAuthRequestsFlag returns the flag name for the 'AdvancedRateLimitAuthRequests' field
+func AdvancedRateLimitAuthRequestsFlag() string { return "advanced-rate-limit-auth-requests" }
+
+// GetAdvancedRateLimitAuthRequests safely fetches the value for global configuration 'AdvancedRateLimitAuthRequests' field
+func GetAdvancedRateLimitAuthRequests() int { return global.GetAdvancedRateLimitAuthRequests() }
+
+// SetAdvancedRateLimitAuthRequests safely sets the value for global configuration 'AdvancedRateLimitAuthRequests' field
+func SetAdvancedRateLimitAuthRequests(v int) { global.SetAdvancedRateLimitAuthRequests(v) }
+
+// GetAdvancedRateLimitExceptions safely fetches the Configuration value for state's 'AdvancedRateLimitExceptions' field
+func (st *ConfigState) GetAdvancedRateLimitExceptions() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitExceptions
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitExceptions safely sets the Configuration value for state's 'AdvancedRateLimitExceptions' field
+func (st *ConfigState) SetAdvancedRateLimitExceptions(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitExceptions = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitExceptionsFlag returns the flag name for the 'AdvancedRateLimitExceptions' field
+func AdvancedRateLimitExceptionsFlag() string { return "advanced-rate-limit-exceptions" }
+
+// GetAdvancedRateLimitExceptions safely fetches the value for global configuration 'AdvancedRateLimitExceptions' field
+func GetAdvancedRateLimitExceptions() []string { return global.GetAdvancedRateLimitExceptions() }
+
+// SetAdvancedRateLimitExceptions safely sets the value for global configuration 'AdvancedRateLimitExceptions' field
+func SetAdvancedRateLimitExceptions(v []string) { global.SetAdvancedRateLimitExceptions(v) }
+
+// GetAdvancedThrottlingMultiplier safely fetches the Configuration value for state's 'AdvancedThrottlingMultiplier' field
+func (st *ConfigState) GetAdvancedThrottlingMultiplier() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedThrottlingMultiplier
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedThrottlingMultiplier safely sets the Configuration value for state's 'AdvancedThrottlingMultiplier' field
+func (st *ConfigState) SetAdvancedThrottlingMultiplier(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedThrottlingMultiplier = v
+	st.reloadToViper()
+}
+
+// AdvancedThrottlingMultiplierFlag returns the flag name for the 'AdvancedThrottlingMultiplier' field
+func AdvancedThrottlingMultiplierFlag() string { return "advanced-throttling-multiplier" }
+
+// GetAdvancedThrottlingMultiplier safely fetches the value for global configuration 'AdvancedThrottlingMultiplier' field
+func GetAdvancedThrottlingMultiplier() int { return global.GetAdvancedThrottlingMultiplier() }
+
+// SetAdvancedThrottlingMultiplier safely sets the value for global configuration 'AdvancedThrottlingMultiplier' field
+func SetAdvancedThrottlingMultiplier(v int) { global.SetAdvancedThrottlingMultiplier(v) }
+
+// GetAdvancedThrottlingRetryAfter safely fetches the Configuration value for state's 'AdvancedThrottlingRetryAfter' field
+func (st *ConfigState) GetAdvancedThrottlingRetryAfter() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedThrottlingRetryAfter
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedThrottlingRetryAfter safely sets the Configuration value for state's 'AdvancedThrottlingRetryAfter' field
+func (st *ConfigState) SetAdvancedThrottlingRetryAfter(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedThrottlingRetryAfter = v
+	st.reloadToViper()
+}
+
+// AdvancedThrottlingRetryAfterFlag returns the flag name for the 'AdvancedThrottlingRetryAfter' field
+func AdvancedThrottlingRetryAfterFlag() string { return "advanced-throttling-retry-after" }
+
+// GetAdvancedThrottlingRetryAfter safely fetches the value for global configuration 'AdvancedThrottlingRetryAfter' field
+func GetAdvancedThrottlingRetryAfter() time.Duration { return global.GetAdvancedThrottlingRetryAfter() }
+
+// SetAdvancedThrottlingRetryAfter safely sets the value for global configuration 'AdvancedThrottlingRetryAfter' field
+func SetAdvancedThrottlingRetryAfter(v time.Duration) { global.SetAdvancedThrottlingRetryAfter(v) }
+
+// GetAdvancedSenderMultiplier safely fetches the Configuration value for state's 'AdvancedSenderMultiplier' field
+func (st *ConfigState) GetAdvancedSenderMultiplier() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedSenderMultiplier
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedSenderMultiplier safely sets the Configuration value for state's 'AdvancedSenderMultiplier' field
+func (st *ConfigState) SetAdvancedSenderMultiplier(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedSenderMultiplier = v
+	st.reloadToViper()
+}
+
+// AdvancedSenderMultiplierFlag returns the flag name for the 'AdvancedSenderMultiplier' field
+func AdvancedSenderMultiplierFlag() string { return "advanced-sender-multiplier" }
+
+// GetAdvancedSenderMultiplier safely fetches the value for global configuration 'AdvancedSenderMultiplier' field
+func GetAdvancedSenderMultiplier() int { return global.GetAdvancedSenderMultiplier() }
+
+// SetAdvancedSenderMultiplier safely sets the value for global configuration 'AdvancedSenderMultiplier' field
+func SetAdvancedSenderMultiplier(v int) { global.SetAdvancedSenderMultiplier(v) }
+
+// GetAdvancedCSPExtraURIs safely fetches the Configuration value for state's 'AdvancedCSPExtraURIs' field
+func (st *ConfigState) GetAdvancedCSPExtraURIs() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedCSPExtraURIs
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedCSPExtraURIs safely sets the Configuration value for state's 'AdvancedCSPExtraURIs' field
+func (st *ConfigState) SetAdvancedCSPExtraURIs(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedCSPExtraURIs = v
+	st.reloadToViper()
+}
+
+// AdvancedCSPExtraURIsFlag returns the flag name for the 'AdvancedCSPExtraURIs' field
+func AdvancedCSPExtraURIsFlag() string { return "advanced-csp-extra-uris" }
+
+// GetAdvancedCSPExtraURIs safely fetches the value for global configuration 'AdvancedCSPExtraURIs' field
+func GetAdvancedCSPExtraURIs() []string { return global.GetAdvancedCSPExtraURIs() }
+
+// SetAdvancedCSPExtraURIs safely sets the value for global configuration 'AdvancedCSPExtraURIs' field
+func SetAdvancedCSPExtraURIs(v []string) { global.SetAdvancedCSPExtraURIs(v) }
+
+// GetAdvancedHeaderFilterMode safely fetches the Configuration value for state's 'AdvancedHeaderFilterMode' field
+func (st *ConfigState) GetAdvancedHeaderFilterMode() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedHeaderFilterMode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedHeaderFilterMode safely sets the Configuration value for state's 'AdvancedHeaderFilterMode' field
+func (st *ConfigState) SetAdvancedHeaderFilterMode(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedHeaderFilterMode = v
+	st.reloadToViper()
+}
+
+// AdvancedHeaderFilterModeFlag returns the flag name for the 'AdvancedHeaderFilterMode' field
+func AdvancedHeaderFilterModeFlag() string { return "advanced-header-filter-mode" }
+
+// GetAdvancedHeaderFilterMode safely fetches the value for global configuration 'AdvancedHeaderFilterMode' field
+func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilterMode() }
+
+// SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
+func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
+
+// GetAdvancedStreamingPingInterval safely fetches the Configuration value for state's 'AdvancedStreamingPingInterval' field
+func (st *ConfigState) GetAdvancedStreamingPingInterval() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingPingInterval
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingPingInterval safely sets the Configuration value for state's 'AdvancedStreamingPingInterval' field
+func (st *ConfigState) SetAdvancedStreamingPingInterval(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingPingInterval = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingPingIntervalFlag returns the flag name for the 'AdvancedStreamingPingInterval' field
+func AdvancedStreamingPingIntervalFlag() string { return "advanced-streaming-ping-interval" }
+
+// GetAdvancedStreamingPingInterval safely fetches the value for global configuration 'AdvancedStreamingPingInterval' field
+func GetAdvancedStreamingPingInterval() time.Duration {
+	return global.GetAdvancedStreamingPingInterval()
+}
 
-// SetSyslogProtocol safely sets the value for global configuration 'SyslogProtocol' field
-func SetSyslogProtocol(v string) { global.SetSyslogProtocol(v) }
+// SetAdvancedStreamingPingInterval safely sets the value for global configuration 'AdvancedStreamingPingInterval' field
+func SetAdvancedStreamingPingInterval(v time.Duration) { global.SetAdvancedStreamingPingInterval(v) }
 
-// GetSyslogAddress safely fetches the Configuration value for state's 'SyslogAddress' field
-func (st *ConfigState) GetSyslogAddress() (v string) {
+// GetAdvancedStreamingMaxMissedPongs safely fetches the Configuration value for state's 'AdvancedStreamingMaxMissedPongs' field
+func (st *ConfigState) GetAdvancedStreamingMaxMissedPongs() (v int) {
 	st.mutex.RLock()
-	v = st.config.SyslogAddress
+	v = st.config.AdvancedStreamingMaxMissedPongs
 	st.mutex.RUnlock()
 	return
 }
 
-// SetSyslogAddress safely sets the Configuration value for state's 'SyslogAddress' field
-func (st *ConfigState) SetSyslogAddress(v string) {
+// SetAdvancedStreamingMaxMissedPongs safely sets the Configuration value for state's 'AdvancedStreamingMaxMissedPongs' field
+func (st *ConfigState) SetAdvancedStreamingMaxMissedPongs(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.SyslogAddress = v
+	st.config.AdvancedStreamingMaxMissedPongs = v
 	st.reloadToViper()
 }
 
-// SyslogAddressFlag returns the flag name for the 'SyslogAddress' field
-func SyslogAddressFlag() string { return "syslog-address" }
+// AdvancedStreamingMaxMissedPongsFlag returns the flag name for the 'AdvancedStreamingMaxMissedPongs' field
+func AdvancedStreamingMaxMissedPongsFlag() string { return "advanced-streaming-max-missed-pongs" }
 
-// GetSyslogAddress safely fetches the value for global configuration 'SyslogAddress' field
-func GetSyslogAddress() string { return global.GetSyslogAddress() }
+// GetAdvancedStreamingMaxMissedPongs safely fetches the value for global configuration 'AdvancedStreamingMaxMissedPongs' field
+func GetAdvancedStreamingMaxMissedPongs() int { return global.GetAdvancedStreamingMaxMissedPongs() }
 
-// SetSyslogAddress safely sets the value for global configuration 'SyslogAddress' field
-func SetSyslogAddress(v string) { global.SetSyslogAddress(v) }
+// SetAdvancedStreamingMaxMissedPongs safely sets the value for global configuration 'AdvancedStreamingMaxMissedPongs' field
+func SetAdvancedStreamingMaxMissedPongs(v int) { global.SetAdvancedStreamingMaxMissedPongs(v) }
 
-// GetAdvancedCookiesSamesite safely fetches the Configuration value for state's 'AdvancedCookiesSamesite' field
-func (st *ConfigState) GetAdvancedCookiesSamesite() (v string) {
+// GetAdvancedClientAPIWorkers safely fetches the Configuration value for state's 'AdvancedClientAPIWorkers' field
+func (st *ConfigState) GetAdvancedClientAPIWorkers() (v int) {
 	st.mutex.RLock()
-	v = st.config.AdvancedCookiesSamesite
+	v = st.config.AdvancedClientAPIWorkers
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedCookiesSamesite safely sets the Configuration value for state's 'AdvancedCookiesSamesite' field
-func (st *ConfigState) SetAdvancedCookiesSamesite(v string) {
+// SetAdvancedClientAPIWorkers safely sets the Configuration value for state's 'AdvancedClientAPIWorkers' field
+func (st *ConfigState) SetAdvancedClientAPIWorkers(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedCookiesSamesite = v
+	st.config.AdvancedClientAPIWorkers = v
 	st.reloadToViper()
 }
 
-// AdvancedCookiesSamesiteFlag returns the flag name for the 'AdvancedCookiesSamesite' field
-func AdvancedCookiesSamesiteFlag() string { return "advanced-cookies-samesite" }
+// AdvancedClientAPIWorkersFlag returns the flag name for the 'AdvancedClientAPIWorkers' field
+func AdvancedClientAPIWorkersFlag() string { return "advanced-client-api-workers" }
 
-// GetAdvancedCookiesSamesite safely fetches the value for global configuration 'AdvancedCookiesSamesite' field
-func GetAdvancedCookiesSamesite() string { return global.GetAdvancedCookiesSamesite() }
+// GetAdvancedClientAPIWorkers safely fetches the value for global configuration 'AdvancedClientAPIWorkers' field
+func GetAdvancedClientAPIWorkers() int { return global.GetAdvancedClientAPIWorkers() }
 
-// SetAdvancedCookiesSamesite safely sets the value for global configuration 'AdvancedCookiesSamesite' field
-func SetAdvancedCookiesSamesite(v string) { global.SetAdvancedCookiesSamesite(v) }
+// SetAdvancedClientAPIWorkers safely sets the value for global configuration 'AdvancedClientAPIWorkers' field
+func SetAdvancedClientAPIWorkers(v int) { global.SetAdvancedClientAPIWorkers(v) }
 
-// GetAdvancedRateLimitRequests safely fetches the Configuration value for state's 'AdvancedRateLimitRequests' field
-func (st *ConfigState) GetAdvancedRateLimitRequests() (v int) {
+// GetAdvancedClientAPIQueueSize safely fetches the Configuration value for state's 'AdvancedClientAPIQueueSize' field
+func (st *ConfigState) GetAdvancedClientAPIQueueSize() (v int) {
 	st.mutex.RLock()
-	v = st.config.AdvancedRateLimitRequests
+	v = st.config.AdvancedClientAPIQueueSize
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedRateLimitRequests safely sets the Configuration value for state's 'AdvancedRateLimitRequests' field
-func (st *ConfigState) SetAdvancedRateLimitRequests(v int) {
+// SetAdvancedClientAPIQueueSize safely sets the Configuration value for state's 'AdvancedClientAPIQueueSize' field
+func (st *ConfigState) SetAdvancedClientAPIQueueSize(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedRateLimitRequests = v
+	st.config.AdvancedClientAPIQueueSize = v
 	st.reloadToViper()
 }
 
-// AdvancedRateLimitRequestsFlag returns the flag name for the 'AdvancedRateLimitRequests' field
-func AdvancedRateLimitRequestsFlag() string { return "advanced-rate-limit-requests" }
+// AdvancedClientAPIQueueSizeFlag returns the flag name for the 'AdvancedClientAPIQueueSize' field
+func AdvancedClientAPIQueueSizeFlag() string { return "advanced-client-api-queue-size" }
 
-// GetAdvancedRateLimitRequests safely fetches the value for global configuration 'AdvancedRateLimitRequests' field
-func GetAdvancedRateLimitRequests() int { return global.GetAdvancedRateLimitRequests() }
+// GetAdvancedClientAPIQueueSize safely fetches the value for global configuration 'AdvancedClientAPIQueueSize' field
+func GetAdvancedClientAPIQueueSize() int { return global.GetAdvancedClientAPIQueueSize() }
 
-// SetAdvancedRateLimitRequests safely sets the value for global configuration 'AdvancedRateLimitRequests' field
-func SetAdvancedRateLimitRequests(v int) { global.SetAdvancedRateLimitRequests(v) }
+// SetAdvancedClientAPIQueueSize safely sets the value for global configuration 'AdvancedClientAPIQueueSize' field
+func SetAdvancedClientAPIQueueSize(v int) { global.SetAdvancedClientAPIQueueSize(v) }
 
-// GetAdvancedRateLimitExceptions safely fetches the Configuration value for state's 'AdvancedRateLimitExceptions' field
-func (st *ConfigState) GetAdvancedRateLimitExceptions() (v []string) {
+// GetAdvancedFederatorWorkers safely fetches the Configuration value for state's 'AdvancedFederatorWorkers' field
+func (st *ConfigState) GetAdvancedFederatorWorkers() (v int) {
 	st.mutex.RLock()
-	v = st.config.AdvancedRateLimitExceptions
+	v = st.config.AdvancedFederatorWorkers
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedRateLimitExceptions safely sets the Configuration value for state's 'AdvancedRateLimitExceptions' field
-func (st *ConfigState) SetAdvancedRateLimitExceptions(v []string) {
+// SetAdvancedFederatorWorkers safely sets the Configuration value for state's 'AdvancedFederatorWorkers' field
+func (st *ConfigState) SetAdvancedFederatorWorkers(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedRateLimitExceptions = v
+	st.config.AdvancedFederatorWorkers = v
 	st.reloadToViper()
 }
 
-// AdvancedRateLimitExceptionsFlag returns the flag name for the 'AdvancedRateLimitExceptions' field
-func AdvancedRateLimitExceptionsFlag() string { return "advanced-rate-limit-exceptions" }
+// AdvancedFederatorWorkersFlag returns the flag name for the 'AdvancedFederatorWorkers' field
+func AdvancedFederatorWorkersFlag() string { return "advanced-federator-workers" }
 
-// GetAdvancedRateLimitExceptions safely fetches the value for global configuration 'AdvancedRateLimitExceptions' field
-func GetAdvancedRateLimitExceptions() []string { return global.GetAdvancedRateLimitExceptions() }
+// GetAdvancedFederatorWorkers safely fetches the value for global configuration 'AdvancedFederatorWorkers' field
+func GetAdvancedFederatorWorkers() int { return global.GetAdvancedFederatorWorkers() }
 
-// SetAdvancedRateLimitExceptions safely sets the value for global configuration 'AdvancedRateLimitExceptions' field
-func SetAdvancedRateLimitExceptions(v []string) { global.SetAdvancedRateLimitExceptions(v) }
+// SetAdvancedFederatorWorkers safely sets the value for global configuration 'AdvancedFederatorWorkers' field
+func SetAdvancedFederatorWorkers(v int) { global.SetAdvancedFederatorWorkers(v) }
 
-// GetAdvancedThrottlingMultiplier safely fetches the Configuration value for state's 'AdvancedThrottlingMultiplier' field
-func (st *ConfigState) GetAdvancedThrottlingMultiplier() (v int) {
+// GetAdvancedFederatorQueueSize safely fetches the Configuration value for state's 'AdvancedFederatorQueueSize' field
+func (st *ConfigState) GetAdvancedFederatorQueueSize() (v int) {
 	st.mutex.RLock()
-	v = st.config.AdvancedThrottlingMultiplier
+	v = st.config.AdvancedFederatorQueueSize
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedThrottlingMultiplier safely sets the Configuration value for state's 'AdvancedThrottlingMultiplier' field
-func (st *ConfigState) SetAdvancedThrottlingMultiplier(v int) {
+// SetAdvancedFederatorQueueSize safely sets the Configuration value for state's 'AdvancedFederatorQueueSize' field
+func (st *ConfigState) SetAdvancedFederatorQueueSize(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedThrottlingMultiplier = v
+	st.config.AdvancedFederatorQueueSize = v
 	st.reloadToViper()
 }
 
-// AdvancedThrottlingMultiplierFlag returns the flag name for the 'AdvancedThrottlingMultiplier' field
-func AdvancedThrottlingMultiplierFlag() string { return "advanced-throttling-multiplier" }
+// AdvancedFederatorQueueSizeFlag returns the flag name for the 'AdvancedFederatorQueueSize' field
+func AdvancedFederatorQueueSizeFlag() string { return "advanced-federator-queue-size" }
 
-// GetAdvancedThrottlingMultiplier safely fetches the value for global configuration 'AdvancedThrottlingMultiplier' field
-func GetAdvancedThrottlingMultiplier() int { return global.GetAdvancedThrottlingMultiplier() }
+// GetAdvancedFederatorQueueSize safely fetches the value for global configuration 'AdvancedFederatorQueueSize' field
+func GetAdvancedFederatorQueueSize() int { return global.GetAdvancedFederatorQueueSize() }
 
-// SetAdvancedThrottlingMultiplier safely sets the value for global configuration 'AdvancedThrottlingMultiplier' field
-func SetAdvancedThrottlingMultiplier(v int) { global.SetAdvancedThrottlingMultiplier(v) }
+// SetAdvancedFederatorQueueSize safely sets the value for global configuration 'AdvancedFederatorQueueSize' field
+func SetAdvancedFederatorQueueSize(v int) { global.SetAdvancedFederatorQueueSize(v) }
 
-// GetAdvancedThrottlingRetryAfter safely fetches the Configuration value for state's 'AdvancedThrottlingRetryAfter' field
-func (st *ConfigState) GetAdvancedThrottlingRetryAfter() (v time.Duration) {
+// GetAdvancedMediaWorkers safely fetches the Configuration value for state's 'AdvancedMediaWorkers' field
+func (st *ConfigState) GetAdvancedMediaWorkers() (v int) {
 	st.mutex.RLock()
-	v = st.config.AdvancedThrottlingRetryAfter
+	v = st.config.AdvancedMediaWorkers
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedThrottlingRetryAfter safely sets the Configuration value for state's 'AdvancedThrottlingRetryAfter' field
-func (st *ConfigState) SetAdvancedThrottlingRetryAfter(v time.Duration) {
+// SetAdvancedMediaWorkers safely sets the Configuration value for state's 'AdvancedMediaWorkers' field
+func (st *ConfigState) SetAdvancedMediaWorkers(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedThrottlingRetryAfter = v
+	st.config.AdvancedMediaWorkers = v
 	st.reloadToViper()
 }
 
-// AdvancedThrottlingRetryAfterFlag returns the flag name for the 'AdvancedThrottlingRetryAfter' field
-func AdvancedThrottlingRetryAfterFlag() string { return "advanced-throttling-retry-after" }
+// AdvancedMediaWorkersFlag returns the flag name for the 'AdvancedMediaWorkers' field
+func AdvancedMediaWorkersFlag() string { return "advanced-media-workers" }
 
-// GetAdvancedThrottlingRetryAfter safely fetches the value for global configuration 'AdvancedThrottlingRetryAfter' field
-func GetAdvancedThrottlingRetryAfter() time.Duration { return global.GetAdvancedThrottlingRetryAfter() }
+// GetAdvancedMediaWorkers safely fetches the value for global configuration 'AdvancedMediaWorkers' field
+func GetAdvancedMediaWorkers() int { return global.GetAdvancedMediaWorkers() }
 
-// SetAdvancedThrottlingRetryAfter safely sets the value for global configuration 'AdvancedThrottlingRetryAfter' field
-func SetAdvancedThrottlingRetryAfter(v time.Duration) { global.SetAdvancedThrottlingRetryAfter(v) }
+// SetAdvancedMediaWorkers safely sets the value for global configuration 'AdvancedMediaWorkers' field
+func SetAdvancedMediaWorkers(v int) { global.SetAdvancedMediaWorkers(v) }
 
-// GetAdvancedSenderMultiplier safely fetches the Configuration value for state's 'AdvancedSenderMultiplier' field
-func (st *ConfigState) GetAdvancedSenderMultiplier() (v int) {
+// GetAdvancedMediaQueueSize safely fetches the Configuration value for state's 'AdvancedMediaQueueSize' field
+func (st *ConfigState) GetAdvancedMediaQueueSize() (v int) {
 	st.mutex.RLock()
-	v = st.config.AdvancedSenderMultiplier
+	v = st.config.AdvancedMediaQueueSize
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedSenderMultiplier safely sets the Configuration value for state's 'AdvancedSenderMultiplier' field
-func (st *ConfigState) SetAdvancedSenderMultiplier(v int) {
+// SetAdvancedMediaQueueSize safely sets the Configuration value for state's 'AdvancedMediaQueueSize' field
+func (st *ConfigState) SetAdvancedMediaQueueSize(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedSenderMultiplier = v
+	st.config.AdvancedMediaQueueSize = v
 	st.reloadToViper()
 }
 
-// AdvancedSenderMultiplierFlag returns the flag name for the 'AdvancedSenderMultiplier' field
-func AdvancedSenderMultiplierFlag() string { return "advanced-sender-multiplier" }
+// AdvancedMediaQueueSizeFlag returns the flag name for the 'AdvancedMediaQueueSize' field
+func AdvancedMediaQueueSizeFlag() string { return "advanced-media-queue-size" }
 
-// GetAdvancedSenderMultiplier safely fetches the value for global configuration 'AdvancedSenderMultiplier' field
-func GetAdvancedSenderMultiplier() int { return global.GetAdvancedSenderMultiplier() }
+// GetAdvancedMediaQueueSize safely fetches the value for global configuration 'AdvancedMediaQueueSize' field
+func GetAdvancedMediaQueueSize() int { return global.GetAdvancedMediaQueueSize() }
 
-// SetAdvancedSenderMultiplier safely sets the value for global configuration 'AdvancedSenderMultiplier' field
-func SetAdvancedSenderMultiplier(v int) { global.SetAdvancedSenderMultiplier(v) }
+// SetAdvancedMediaQueueSize safely sets the value for global configuration 'AdvancedMediaQueueSize' field
+func SetAdvancedMediaQueueSize(v int) { global.SetAdvancedMediaQueueSize(v) }
 
-// GetAdvancedCSPExtraURIs safely fetches the Configuration value for state's 'AdvancedCSPExtraURIs' field
-func (st *ConfigState) GetAdvancedCSPExtraURIs() (v []string) {
+// GetAdvancedShutdownDrainTimeout safely fetches the Configuration value for state's 'AdvancedShutdownDrainTimeout' field
+func (st *ConfigState) GetAdvancedShutdownDrainTimeout() (v time.Duration) {
 	st.mutex.RLock()
-	v = st.config.AdvancedCSPExtraURIs
+	v = st.config.AdvancedShutdownDrainTimeout
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedCSPExtraURIs safely sets the Configuration value for state's 'AdvancedCSPExtraURIs' field
-func (st *ConfigState) SetAdvancedCSPExtraURIs(v []string) {
+// SetAdvancedShutdownDrainTimeout safely sets the Configuration value for state's 'AdvancedShutdownDrainTimeout' field
+func (st *ConfigState) SetAdvancedShutdownDrainTimeout(v time.Duration) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedCSPExtraURIs = v
+	st.config.AdvancedShutdownDrainTimeout = v
 	st.reloadToViper()
 }
 
-// AdvancedCSPExtraURIsFlag returns the flag name for the 'AdvancedCSPExtraURIs' field
-func AdvancedCSPExtraURIsFlag() string { return "advanced-csp-extra-uris" }
+// AdvancedShutdownDrainTimeoutFlag returns the flag name for the 'AdvancedShutdownDrainTimeout' field
+func AdvancedShutdownDrainTimeoutFlag() string { return "advanced-shutdown-drain-timeout" }
 
-// GetAdvancedCSPExtraURIs safely fetches the value for global configuration 'AdvancedCSPExtraURIs' field
-func GetAdvancedCSPExtraURIs() []string { return global.GetAdvancedCSPExtraURIs() }
+// GetAdvancedShutdownDrainTimeout safely fetches the value for global configuration 'AdvancedShutdownDrainTimeout' field
+func GetAdvancedShutdownDrainTimeout() time.Duration { return global.GetAdvancedShutdownDrainTimeout() }
 
-// SetAdvancedCSPExtraURIs safely sets the value for global configuration 'AdvancedCSPExtraURIs' field
-func SetAdvancedCSPExtraURIs(v []string) { global.SetAdvancedCSPExtraURIs(v) }
+// SetAdvancedShutdownDrainTimeout safely sets the value for global configuration 'AdvancedShutdownDrainTimeout' field
+func SetAdvancedShutdownDrainTimeout(v time.Duration) { global.SetAdvancedShutdownDrainTimeout(v) }
 
-// GetAdvancedHeaderFilterMode safely fetches the Configuration value for state's 'AdvancedHeaderFilterMode' field
-func (st *ConfigState) GetAdvancedHeaderFilterMode() (v string) {
+// GetAdvancedDurableQueue safely fetches the Configuration value for state's 'AdvancedDurableQueue' field
+func (st *ConfigState) GetAdvancedDurableQueue() (v bool) {
 	st.mutex.RLock()
-	v = st.config.AdvancedHeaderFilterMode
+	v = st.config.AdvancedDurableQueue
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAdvancedHeaderFilterMode safely sets the Configuration value for state's 'AdvancedHeaderFilterMode' field
-func (st *ConfigState) SetAdvancedHeaderFilterMode(v string) {
+// SetAdvancedDurableQueue safely sets the Configuration value for state's 'AdvancedDurableQueue' field
+func (st *ConfigState) SetAdvancedDurableQueue(v bool) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AdvancedHeaderFilterMode = v
+	st.config.AdvancedDurableQueue = v
 	st.reloadToViper()
 }
 
-// AdvancedHeaderFilterModeFlag returns the flag name for the 'AdvancedHeaderFilterMode' field
-func AdvancedHeaderFilterModeFlag() string { return "advanced-header-filter-mode" }
+// AdvancedDurableQueueFlag returns the flag name for the 'AdvancedDurableQueue' field
+func AdvancedDurableQueueFlag() string { return "advanced-durable-queue" }
 
-// GetAdvancedHeaderFilterMode safely fetches the value for global configuration 'AdvancedHeaderFilterMode' field
-func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilterMode() }
+// GetAdvancedDurableQueue safely fetches the value for global configuration 'AdvancedDurableQueue' field
+func GetAdvancedDurableQueue() bool { return global.GetAdvancedDurableQueue() }
 
-// SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
-func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
+// SetAdvancedDurableQueue safely sets the value for global configuration 'AdvancedDurableQueue' field
+func SetAdvancedDurableQueue(v bool) { global.SetAdvancedDurableQueue(v) }
 
 // GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
 func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
@@ -2750,6 +3942,56 @@ func GetHTTPClientTLSInsecureSkipVerify() bool { return global.GetHTTPClientTLSI
 // SetHTTPClientTLSInsecureSkipVerify safely sets the value for global configuration 'HTTPClient.TLSInsecureSkipVerify' field
 func SetHTTPClientTLSInsecureSkipVerify(v bool) { global.SetHTTPClientTLSInsecureSkipVerify(v) }
 
+// GetHTTPClientBreakerFailures safely fetches the Configuration value for state's 'HTTPClient.BreakerFailures' field
+func (st *ConfigState) GetHTTPClientBreakerFailures() (v int) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.BreakerFailures
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientBreakerFailures safely sets the Configuration value for state's 'HTTPClient.BreakerFailures' field
+func (st *ConfigState) SetHTTPClientBreakerFailures(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.BreakerFailures = v
+	st.reloadToViper()
+}
+
+// HTTPClientBreakerFailuresFlag returns the flag name for the 'HTTPClient.BreakerFailures' field
+func HTTPClientBreakerFailuresFlag() string { return "httpclient-breaker-failures" }
+
+// GetHTTPClientBreakerFailures safely fetches the value for global configuration 'HTTPClient.BreakerFailures' field
+func GetHTTPClientBreakerFailures() int { return global.GetHTTPClientBreakerFailures() }
+
+// SetHTTPClientBreakerFailures safely sets the value for global configuration 'HTTPClient.BreakerFailures' field
+func SetHTTPClientBreakerFailures(v int) { global.SetHTTPClientBreakerFailures(v) }
+
+// GetHTTPClientBreakerBackoff safely fetches the Configuration value for state's 'HTTPClient.BreakerBackoff' field
+func (st *ConfigState) GetHTTPClientBreakerBackoff() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.BreakerBackoff
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientBreakerBackoff safely sets the Configuration value for state's 'HTTPClient.BreakerBackoff' field
+func (st *ConfigState) SetHTTPClientBreakerBackoff(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.BreakerBackoff = v
+	st.reloadToViper()
+}
+
+// HTTPClientBreakerBackoffFlag returns the flag name for the 'HTTPClient.BreakerBackoff' field
+func HTTPClientBreakerBackoffFlag() string { return "httpclient-breaker-backoff" }
+
+// GetHTTPClientBreakerBackoff safely fetches the value for global configuration 'HTTPClient.BreakerBackoff' field
+func GetHTTPClientBreakerBackoff() time.Duration { return global.GetHTTPClientBreakerBackoff() }
+
+// SetHTTPClientBreakerBackoff safely sets the value for global configuration 'HTTPClient.BreakerBackoff' field
+func SetHTTPClientBreakerBackoff(v time.Duration) { global.SetHTTPClientBreakerBackoff(v) }
+
 // GetCacheMemoryTarget safely fetches the Configuration value for state's 'Cache.MemoryTarget' field
 func (st *ConfigState) GetCacheMemoryTarget() (v bytesize.Size) {
 	st.mutex.RLock()
@@ -2775,6 +4017,56 @@ func GetCacheMemoryTarget() bytesize.Size { return global.GetCacheMemoryTarget()
 // SetCacheMemoryTarget safely sets the value for global configuration 'Cache.MemoryTarget' field
 func SetCacheMemoryTarget(v bytesize.Size) { global.SetCacheMemoryTarget(v) }
 
+// GetCacheTimelineMemoryTarget safely fetches the Configuration value for state's 'Cache.TimelineMemoryTarget' field
+func (st *ConfigState) GetCacheTimelineMemoryTarget() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.Cache.TimelineMemoryTarget
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheTimelineMemoryTarget safely sets the Configuration value for state's 'Cache.TimelineMemoryTarget' field
+func (st *ConfigState) SetCacheTimelineMemoryTarget(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.TimelineMemoryTarget = v
+	st.reloadToViper()
+}
+
+// CacheTimelineMemoryTargetFlag returns the flag name for the 'Cache.TimelineMemoryTarget' field
+func CacheTimelineMemoryTargetFlag() string { return "cache-timeline-memory-target" }
+
+// GetCacheTimelineMemoryTarget safely fetches the value for global configuration 'Cache.TimelineMemoryTarget' field
+func GetCacheTimelineMemoryTarget() bytesize.Size { return global.GetCacheTimelineMemoryTarget() }
+
+// SetCacheTimelineMemoryTarget safely sets the value for global configuration 'Cache.TimelineMemoryTarget' field
+func SetCacheTimelineMemoryTarget(v bytesize.Size) { global.SetCacheTimelineMemoryTarget(v) }
+
+// GetCacheTimelinePreparedMax safely fetches the Configuration value for state's 'Cache.TimelinePreparedMax' field
+func (st *ConfigState) GetCacheTimelinePreparedMax() (v int) {
+	st.mutex.RLock()
+	v = st.config.Cache.TimelinePreparedMax
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheTimelinePreparedMax safely sets the Configuration value for state's 'Cache.TimelinePreparedMax' field
+func (st *ConfigState) SetCacheTimelinePreparedMax(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.TimelinePreparedMax = v
+	st.reloadToViper()
+}
+
+// CacheTimelinePreparedMaxFlag returns the flag name for the 'Cache.TimelinePreparedMax' field
+func CacheTimelinePreparedMaxFlag() string { return "cache-timeline-prepared-max" }
+
+// GetCacheTimelinePreparedMax safely fetches the value for global configuration 'Cache.TimelinePreparedMax' field
+func GetCacheTimelinePreparedMax() int { return global.GetCacheTimelinePreparedMax() }
+
+// SetCacheTimelinePreparedMax safely sets the value for global configuration 'Cache.TimelinePreparedMax' field
+func SetCacheTimelinePreparedMax(v int) { global.SetCacheTimelinePreparedMax(v) }
+
 // GetCacheAccountMemRatio safely fetches the Configuration value for state's 'Cache.AccountMemRatio' field
 func (st *ConfigState) GetCacheAccountMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -2925,6 +4217,31 @@ func GetCacheBoostOfIDsMemRatio() float64 { return global.GetCacheBoostOfIDsMemR
 // SetCacheBoostOfIDsMemRatio safely sets the value for global configuration 'Cache.BoostOfIDsMemRatio' field
 func SetCacheBoostOfIDsMemRatio(v float64) { global.SetCacheBoostOfIDsMemRatio(v) }
 
+// GetCacheDerefFailureMemRatio safely fetches the Configuration value for state's 'Cache.DerefFailureMemRatio' field
+func (st *ConfigState) GetCacheDerefFailureMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.DerefFailureMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheDerefFailureMemRatio safely sets the Configuration value for state's 'Cache.DerefFailureMemRatio' field
+func (st *ConfigState) SetCacheDerefFailureMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.DerefFailureMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheDerefFailureMemRatioFlag returns the flag name for the 'Cache.DerefFailureMemRatio' field
+func CacheDerefFailureMemRatioFlag() string { return "cache-deref-failure-mem-ratio" }
+
+// GetCacheDerefFailureMemRatio safely fetches the value for global configuration 'Cache.DerefFailureMemRatio' field
+func GetCacheDerefFailureMemRatio() float64 { return global.GetCacheDerefFailureMemRatio() }
+
+// SetCacheDerefFailureMemRatio safely sets the value for global configuration 'Cache.DerefFailureMemRatio' field
+func SetCacheDerefFailureMemRatio(v float64) { global.SetCacheDerefFailureMemRatio(v) }
+
 // GetCacheEmojiMemRatio safely fetches the Configuration value for state's 'Cache.EmojiMemRatio' field
 func (st *ConfigState) GetCacheEmojiMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -2975,6 +4292,31 @@ func GetCacheEmojiCategoryMemRatio() float64 { return global.GetCacheEmojiCatego
 // SetCacheEmojiCategoryMemRatio safely sets the value for global configuration 'Cache.EmojiCategoryMemRatio' field
 func SetCacheEmojiCategoryMemRatio(v float64) { global.SetCacheEmojiCategoryMemRatio(v) }
 
+// GetCacheFingerMemRatio safely fetches the Configuration value for state's 'Cache.FingerMemRatio' field
+func (st *ConfigState) GetCacheFingerMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FingerMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFingerMemRatio safely sets the Configuration value for state's 'Cache.FingerMemRatio' field
+func (st *ConfigState) SetCacheFingerMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FingerMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFingerMemRatioFlag returns the flag name for the 'Cache.FingerMemRatio' field
+func CacheFingerMemRatioFlag() string { return "cache-finger-mem-ratio" }
+
+// GetCacheFingerMemRatio safely fetches the value for global configuration 'Cache.FingerMemRatio' field
+func GetCacheFingerMemRatio() float64 { return global.GetCacheFingerMemRatio() }
+
+// SetCacheFingerMemRatio safely sets the value for global configuration 'Cache.FingerMemRatio' field
+func SetCacheFingerMemRatio(v float64) { global.SetCacheFingerMemRatio(v) }
+
 // GetCacheFollowMemRatio safely fetches the Configuration value for state's 'Cache.FollowMemRatio' field
 func (st *ConfigState) GetCacheFollowMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3075,6 +4417,31 @@ func GetCacheFollowRequestIDsMemRatio() float64 { return global.GetCacheFollowRe
 // SetCacheFollowRequestIDsMemRatio safely sets the value for global configuration 'Cache.FollowRequestIDsMemRatio' field
 func SetCacheFollowRequestIDsMemRatio(v float64) { global.SetCacheFollowRequestIDsMemRatio(v) }
 
+// GetCacheIdempotencyKeyMemRatio safely fetches the Configuration value for state's 'Cache.IdempotencyKeyMemRatio' field
+func (st *ConfigState) GetCacheIdempotencyKeyMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.IdempotencyKeyMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheIdempotencyKeyMemRatio safely sets the Configuration value for state's 'Cache.IdempotencyKeyMemRatio' field
+func (st *ConfigState) SetCacheIdempotencyKeyMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.IdempotencyKeyMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheIdempotencyKeyMemRatioFlag returns the flag name for the 'Cache.IdempotencyKeyMemRatio' field
+func CacheIdempotencyKeyMemRatioFlag() string { return "cache-idempotency-key-mem-ratio" }
+
+// GetCacheIdempotencyKeyMemRatio safely fetches the value for global configuration 'Cache.IdempotencyKeyMemRatio' field
+func GetCacheIdempotencyKeyMemRatio() float64 { return global.GetCacheIdempotencyKeyMemRatio() }
+
+// SetCacheIdempotencyKeyMemRatio safely sets the value for global configuration 'Cache.IdempotencyKeyMemRatio' field
+func SetCacheIdempotencyKeyMemRatio(v float64) { global.SetCacheIdempotencyKeyMemRatio(v) }
+
 // GetCacheInReplyToIDsMemRatio safely fetches the Configuration value for state's 'Cache.InReplyToIDsMemRatio' field
 func (st *ConfigState) GetCacheInReplyToIDsMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3100,6 +4467,31 @@ func GetCacheInReplyToIDsMemRatio() float64 { return global.GetCacheInReplyToIDs
 // SetCacheInReplyToIDsMemRatio safely sets the value for global configuration 'Cache.InReplyToIDsMemRatio' field
 func SetCacheInReplyToIDsMemRatio(v float64) { global.SetCacheInReplyToIDsMemRatio(v) }
 
+// GetCacheInboxActivityMemRatio safely fetches the Configuration value for state's 'Cache.InboxActivityMemRatio' field
+func (st *ConfigState) GetCacheInboxActivityMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.InboxActivityMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheInboxActivityMemRatio safely sets the Configuration value for state's 'Cache.InboxActivityMemRatio' field
+func (st *ConfigState) SetCacheInboxActivityMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.InboxActivityMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheInboxActivityMemRatioFlag returns the flag name for the 'Cache.InboxActivityMemRatio' field
+func CacheInboxActivityMemRatioFlag() string { return "cache-inbox-activity-mem-ratio" }
+
+// GetCacheInboxActivityMemRatio safely fetches the value for global configuration 'Cache.InboxActivityMemRatio' field
+func GetCacheInboxActivityMemRatio() float64 { return global.GetCacheInboxActivityMemRatio() }
+
+// SetCacheInboxActivityMemRatio safely sets the value for global configuration 'Cache.InboxActivityMemRatio' field
+func SetCacheInboxActivityMemRatio(v float64) { global.SetCacheInboxActivityMemRatio(v) }
+
 // GetCacheInstanceMemRatio safely fetches the Configuration value for state's 'Cache.InstanceMemRatio' field
 func (st *ConfigState) GetCacheInstanceMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3450,6 +4842,56 @@ func GetCacheStatusFaveIDsMemRatio() float64 { return global.GetCacheStatusFaveI
 // SetCacheStatusFaveIDsMemRatio safely sets the value for global configuration 'Cache.StatusFaveIDsMemRatio' field
 func SetCacheStatusFaveIDsMemRatio(v float64) { global.SetCacheStatusFaveIDsMemRatio(v) }
 
+// GetCacheStatusReactionMemRatio safely fetches the Configuration value for state's 'Cache.StatusReactionMemRatio' field
+func (st *ConfigState) GetCacheStatusReactionMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusReactionMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusReactionMemRatio safely sets the Configuration value for state's 'Cache.StatusReactionMemRatio' field
+func (st *ConfigState) SetCacheStatusReactionMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusReactionMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusReactionMemRatioFlag returns the flag name for the 'Cache.StatusReactionMemRatio' field
+func CacheStatusReactionMemRatioFlag() string { return "cache-status-reaction-mem-ratio" }
+
+// GetCacheStatusReactionMemRatio safely fetches the value for global configuration 'Cache.StatusReactionMemRatio' field
+func GetCacheStatusReactionMemRatio() float64 { return global.GetCacheStatusReactionMemRatio() }
+
+// SetCacheStatusReactionMemRatio safely sets the value for global configuration 'Cache.StatusReactionMemRatio' field
+func SetCacheStatusReactionMemRatio(v float64) { global.SetCacheStatusReactionMemRatio(v) }
+
+// GetCacheStatusReactionIDsMemRatio safely fetches the Configuration value for state's 'Cache.StatusReactionIDsMemRatio' field
+func (st *ConfigState) GetCacheStatusReactionIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusReactionIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusReactionIDsMemRatio safely sets the Configuration value for state's 'Cache.StatusReactionIDsMemRatio' field
+func (st *ConfigState) SetCacheStatusReactionIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusReactionIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusReactionIDsMemRatioFlag returns the flag name for the 'Cache.StatusReactionIDsMemRatio' field
+func CacheStatusReactionIDsMemRatioFlag() string { return "cache-status-reaction-ids-mem-ratio" }
+
+// GetCacheStatusReactionIDsMemRatio safely fetches the value for global configuration 'Cache.StatusReactionIDsMemRatio' field
+func GetCacheStatusReactionIDsMemRatio() float64 { return global.GetCacheStatusReactionIDsMemRatio() }
+
+// SetCacheStatusReactionIDsMemRatio safely sets the value for global configuration 'Cache.StatusReactionIDsMemRatio' field
+func SetCacheStatusReactionIDsMemRatio(v float64) { global.SetCacheStatusReactionIDsMemRatio(v) }
+
 // GetCacheTagMemRatio safely fetches the Configuration value for state's 'Cache.TagMemRatio' field
 func (st *ConfigState) GetCacheTagMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3600,6 +5042,31 @@ func GetCacheVisibilityMemRatio() float64 { return global.GetCacheVisibilityMemR
 // SetCacheVisibilityMemRatio safely sets the value for global configuration 'Cache.VisibilityMemRatio' field
 func SetCacheVisibilityMemRatio(v float64) { global.SetCacheVisibilityMemRatio(v) }
 
+// GetCacheStatusCoreMemRatio safely fetches the Configuration value for state's 'Cache.StatusCoreMemRatio' field
+func (st *ConfigState) GetCacheStatusCoreMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusCoreMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusCoreMemRatio safely sets the Configuration value for state's 'Cache.StatusCoreMemRatio' field
+func (st *ConfigState) SetCacheStatusCoreMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusCoreMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusCoreMemRatioFlag returns the flag name for the 'Cache.StatusCoreMemRatio' field
+func CacheStatusCoreMemRatioFlag() string { return "cache-status-core-mem-ratio" }
+
+// GetCacheStatusCoreMemRatio safely fetches the value for global configuration 'Cache.StatusCoreMemRatio' field
+func GetCacheStatusCoreMemRatio() float64 { return global.GetCacheStatusCoreMemRatio() }
+
+// SetCacheStatusCoreMemRatio safely sets the value for global configuration 'Cache.StatusCoreMemRatio' field
+func SetCacheStatusCoreMemRatio(v float64) { global.SetCacheStatusCoreMemRatio(v) }
+
 // GetAdminAccountUsername safely fetches the Configuration value for state's 'AdminAccountUsername' field
 func (st *ConfigState) GetAdminAccountUsername() (v string) {
 	st.mutex.RLock()
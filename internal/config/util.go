@@ -19,6 +19,7 @@ package config
 
 import (
 	"net/netip"
+	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -37,3 +38,18 @@ func MustParseIPPrefixes(in []string) []netip.Prefix {
 
 	return prefs
 }
+
+// IsLocalHost returns whether the given host (or domain)
+// is one of this instance's own, ie., host, account-domain,
+// or any of the configured host-aliases. This should be
+// used to check if URIs and domains refer to this instance,
+// rather than comparing against host directly.
+func IsLocalHost(host string) bool {
+	if host == "" {
+		return false
+	}
+
+	return host == GetHost() ||
+		host == GetAccountDomain() ||
+		slices.Contains(GetHostAliases(), host)
+}
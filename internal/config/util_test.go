@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ConfigUtilTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ConfigUtilTestSuite) TestIsLocalHost() {
+	testrig.InitTestConfig()
+
+	config.SetHost("social.example.org")
+	config.SetAccountDomain("example.org")
+	config.SetHostAliases([]string{"www.social.example.org"})
+
+	for host, local := range map[string]bool{
+		"social.example.org":     true,
+		"example.org":            true,
+		"www.social.example.org": true,
+		"":                       false,
+		"fossbros-anonymous.io":  false,
+		"www.example.org":        false,
+	} {
+		suite.Equal(local, config.IsLocalHost(host), host)
+	}
+}
+
+func (suite *ConfigUtilTestSuite) TestIsLocalHostNoAccountDomain() {
+	testrig.InitTestConfig()
+
+	config.SetHost("social.example.org")
+	config.SetAccountDomain("")
+
+	suite.True(config.IsLocalHost("social.example.org"))
+	suite.False(config.IsLocalHost(""))
+}
+
+func TestConfigUtilTestSuite(t *testing.T) {
+	suite.Run(t, &ConfigUtilTestSuite{})
+}
@@ -19,6 +19,8 @@ package config
 
 import (
 	"fmt"
+	"os/exec"
+	"time"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -26,6 +28,13 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+const (
+	// Minimum allowed freshness windows
+	// for remote accounts and statuses.
+	minAccountFreshness = time.Hour
+	minStatusFreshness  = 30 * time.Minute
+)
+
 // Validate validates global config settings.
 func Validate() error {
 	// Gather all validation errors in
@@ -98,6 +107,63 @@ func Validate() error {
 		)
 	}
 
+	// `accounts-ip-retention` should be
+	// "none", "truncated", or "full".
+	switch retention := GetAccountsIPRetention(); retention {
+	case AccountsIPRetentionNone,
+		AccountsIPRetentionTruncated,
+		AccountsIPRetentionFull:
+		// No problem.
+
+	default:
+		errf(
+			"%s must be set to one of none, truncated, or full, provided value was %s",
+			AccountsIPRetentionFlag(), retention,
+		)
+	}
+
+	// Ensure `instance-federation-account-freshness`
+	// and `instance-federation-status-freshness` aren't
+	// so low that we'd hammer remote instances with
+	// refresh requests; raise them to minimum if so.
+	if freshness := GetInstanceFederationAccountFreshness(); freshness < minAccountFreshness {
+		log.Warnf(nil,
+			"%s was set to %s, raising to minimum %s",
+			InstanceFederationAccountFreshnessFlag(), freshness, minAccountFreshness,
+		)
+		SetInstanceFederationAccountFreshness(minAccountFreshness)
+	}
+
+	if freshness := GetInstanceFederationStatusFreshness(); freshness < minStatusFreshness {
+		log.Warnf(nil,
+			"%s was set to %s, raising to minimum %s",
+			InstanceFederationStatusFreshnessFlag(), freshness, minStatusFreshness,
+		)
+		SetInstanceFederationStatusFreshness(minStatusFreshness)
+	}
+
+	// Worker pool sizes can be left
+	// unset (0) for defaults, but
+	// otherwise must be positive.
+	for _, pool := range []struct {
+		flag string
+		size int
+	}{
+		{AdvancedClientAPIWorkersFlag(), GetAdvancedClientAPIWorkers()},
+		{AdvancedClientAPIQueueSizeFlag(), GetAdvancedClientAPIQueueSize()},
+		{AdvancedFederatorWorkersFlag(), GetAdvancedFederatorWorkers()},
+		{AdvancedFederatorQueueSizeFlag(), GetAdvancedFederatorQueueSize()},
+		{AdvancedMediaWorkersFlag(), GetAdvancedMediaWorkers()},
+		{AdvancedMediaQueueSizeFlag(), GetAdvancedMediaQueueSize()},
+	} {
+		if pool.size < 0 {
+			errf(
+				"%s must be 0 (default) or greater, provided value was %d",
+				pool.flag, pool.size,
+			)
+		}
+	}
+
 	// Parse `instance-languages`, and
 	// set enriched version into config.
 	parsedLangs, err := language.InitLangs(GetInstanceLanguages().TagStrs())
@@ -112,12 +178,38 @@ func Validate() error {
 		SetInstanceLanguages(parsedLangs)
 	}
 
+	// `media-transcode-ffmpeg-path` must
+	// point to ffmpeg if transcoding.
+	if GetMediaTranscodeEnabled() {
+		if _, err := exec.LookPath(GetMediaTranscodeFfmpegPath()); err != nil {
+			errf(
+				"%s is true, but %s %s could not be found: %v",
+				MediaTranscodeEnabledFlag(), MediaTranscodeFfmpegPathFlag(), GetMediaTranscodeFfmpegPath(), err,
+			)
+		}
+	}
+
 	// `web-assets-base-dir`.
 	webAssetsBaseDir := GetWebAssetBaseDir()
 	if webAssetsBaseDir == "" {
 		errf("%s must be set", WebAssetBaseDirFlag())
 	}
 
+	// `storage-s3-proxy-media-types` should
+	// only contain known media types.
+	for _, mediaType := range GetStorageS3ProxyMediaTypes() {
+		switch mediaType {
+		case "attachment", "header", "avatar", "emoji":
+			// No problem.
+
+		default:
+			errf(
+				"%s values must be one of attachment, header, avatar or emoji, provided value was %s",
+				StorageS3ProxyMediaTypesFlag(), mediaType,
+			)
+		}
+	}
+
 	// Custom / LE TLS settings.
 	//
 	// Only one of custom certs or LE can be set,
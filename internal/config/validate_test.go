@@ -140,6 +140,25 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set\nprotocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadStorageS3ProxyMediaTypes() {
+	testrig.InitTestConfig()
+
+	config.SetStorageS3ProxyMediaTypes([]string{"emoji", "sticker"})
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-s3-proxy-media-types values must be one of attachment, header, avatar or emoji, provided value was sticker")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadWorkerPoolSizes() {
+	testrig.InitTestConfig()
+
+	config.SetAdvancedFederatorWorkers(-1)
+	config.SetAdvancedMediaQueueSize(-10)
+
+	err := config.Validate()
+	suite.EqualError(err, "advanced-federator-workers must be 0 (default) or greater, provided value was -1\nadvanced-media-queue-size must be 0 (default) or greater, provided value was -10")
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
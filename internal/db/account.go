@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Account contains functions related to account getting/setting/creation.
@@ -59,6 +60,11 @@ type Account interface {
 	// PutAccount puts one account in the database.
 	PutAccount(ctx context.Context, account *gtsmodel.Account) error
 
+	// PutAccountUpsert atomically puts one account in the database, unless
+	// an account with the same URI already exists. The stored account is
+	// returned, which is the existing account if the given one lost out.
+	PutAccountUpsert(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.Account, error)
+
 	// UpdateAccount updates one account by ID.
 	UpdateAccount(ctx context.Context, account *gtsmodel.Account, columns ...string) error
 
@@ -89,20 +95,52 @@ type Account interface {
 	// In the case of no statuses, this function will return db.ErrNoEntries.
 	GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, mediaOnly bool, publicOnly bool) ([]*gtsmodel.Status, error)
 
+	// GetAccountOutboxStatuses returns a page of the statuses that make up the ActivityPub outbox of account with
+	// the given id. That is, only public, federated statuses that aren't boosts or replies to other accounts.
+	GetAccountOutboxStatuses(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Status, error)
+
+	// CountAccountOutboxStatuses returns the total number of statuses in the ActivityPub outbox of account with the given id.
+	CountAccountOutboxStatuses(ctx context.Context, accountID string) (int, error)
+
 	// GetAccountPinnedStatuses returns ONLY statuses owned by the give accountID for which a corresponding StatusPin
 	// exists in the database. Statuses which are not pinned will not be returned by this function.
 	//
-	// Statuses will be returned in the order in which they were pinned, from latest pinned to oldest pinned (descending).
+	// Statuses will be returned in pin order, ie., by descending pinned_at, so latest pinned (or reordered to the top) first.
 	//
 	// In the case of no statuses, this function will return db.ErrNoEntries.
 	GetAccountPinnedStatuses(ctx context.Context, accountID string) ([]*gtsmodel.Status, error)
 
 	// GetAccountWebStatuses is similar to GetAccountStatuses, but it's specifically for returning statuses that
 	// should be visible via the web view of an account. So, only public, federated statuses that aren't boosts
-	// or replies.
+	// or replies. If mediaOnly is true, only statuses with attachments are returned.
+	//
+	// In the case of no statuses, this function will return db.ErrNoEntries.
+	GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string, mediaOnly bool) ([]*gtsmodel.Status, error)
+
+	// GetAccountRSSStatuses returns the latest statuses of an account to
+	// include in its RSS feed. These are the same as its web statuses, but
+	// statuses marked as sensitive are left out unless includeSensitive is set.
+	//
+	// In the case of no statuses, this function will return db.ErrNoEntries.
+	GetAccountRSSStatuses(ctx context.Context, accountID string, includeSensitive bool, limit int) ([]*gtsmodel.Status, error)
+
+	// GetLocalRSSStatuses returns the latest web-visible, non-sensitive
+	// statuses of all local, unsuspended accounts that have enabled RSS,
+	// for inclusion in the instance-wide RSS feed.
+	//
+	// In the case of no statuses, this function will return db.ErrNoEntries.
+	GetLocalRSSStatuses(ctx context.Context, limit int) ([]*gtsmodel.Status, error)
+
+	// GetAccountsWithStatusesAutoDelete returns all local, unsuspended
+	// accounts that have automatic deletion of old statuses enabled.
+	GetAccountsWithStatusesAutoDelete(ctx context.Context) ([]*gtsmodel.Account, error)
+
+	// GetAccountAutoDeleteStatuses returns up to limit statuses of the given account,
+	// created before olderThan, that may be deleted automatically according to the
+	// account's statuses auto-delete settings, oldest first. Boosts are not included.
 	//
 	// In the case of no statuses, this function will return db.ErrNoEntries.
-	GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, error)
+	GetAccountAutoDeleteStatuses(ctx context.Context, account *gtsmodel.Account, olderThan time.Time, limit int) ([]*gtsmodel.Status, error)
 
 	// GetAccountLastPosted simply gets the timestamp of the most recent post by the account.
 	//
@@ -114,6 +152,37 @@ type Account interface {
 	// SetAccountHeaderOrAvatar sets the header or avatar for the given accountID to the given media attachment.
 	SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) error
 
+	// GetAccountStats fetches the stats for the account with the given
+	// ID, regenerating them from scratch if they don't exist yet.
+	GetAccountStats(ctx context.Context, accountID string) (*gtsmodel.AccountStats, error)
+
+	// GetAccountStatsRegeneratedBefore fetches up to limit account
+	// stats last regenerated before the given time, oldest first.
+	GetAccountStatsRegeneratedBefore(ctx context.Context, before time.Time, limit int) ([]*gtsmodel.AccountStats, error)
+
+	// RegenerateAccountStats regenerates the stats for the account with
+	// the given ID from scratch, by counting follows and statuses.
+	RegenerateAccountStats(ctx context.Context, accountID string) (*gtsmodel.AccountStats, error)
+
+	// UpdateAccountStatsFollows adjusts the followers and following counts of
+	// the stats for the account with the given ID by the given deltas, which
+	// may be negative. Does nothing if the account has no stats generated yet.
+	UpdateAccountStatsFollows(ctx context.Context, accountID string, followersDelta int, followingDelta int) error
+
+	// UpdateAccountStatsStatuses adjusts the statuses count of the stats for the
+	// account with the given ID by the given delta, which may be negative, and
+	// updates the last status time if the given time is later than stored.
+	// Does nothing if the account has no stats generated yet.
+	UpdateAccountStatsStatuses(ctx context.Context, accountID string, delta int, lastStatusAt time.Time) error
+
+	// GetDirectoryAccounts returns up to limit accounts for the profile directory, skipping the
+	// first offset. Only discoverable, unlocked accounts that are not suspended, moved, or
+	// instance accounts are included. If local is true, only local accounts are returned.
+	//
+	// If active is true, accounts are ordered by most recent status first, with accounts
+	// that have no recorded last status time coming last. Otherwise, newest accounts come first.
+	GetDirectoryAccounts(ctx context.Context, local bool, active bool, limit int, offset int) ([]*gtsmodel.Account, error)
+
 	// GetInstanceAccount returns the instance account for the given domain.
 	// If domain is empty, this instance account will be returned.
 	GetInstanceAccount(ctx context.Context, domain string) (*gtsmodel.Account, error)
@@ -35,4 +35,17 @@ type Application interface {
 
 	// DeleteApplicationByClientID deletes the application with corresponding client_id value from the database.
 	DeleteApplicationByClientID(ctx context.Context, clientID string) error
+
+	// GetTokenByID fetches the oauth token from the database with corresponding ID value.
+	GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error)
+
+	// GetAccessTokens fetches all oauth access tokens belonging to the
+	// user with the given ID, ordered by creation time, newest first.
+	GetAccessTokens(ctx context.Context, userID string) ([]*gtsmodel.Token, error)
+
+	// UpdateToken updates the given columns of the given oauth token in the database.
+	UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error
+
+	// DeleteTokenByID deletes the oauth token with corresponding ID value from the database.
+	DeleteTokenByID(ctx context.Context, id string) error
 }
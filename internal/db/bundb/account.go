@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -335,14 +337,8 @@ func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) e
 		// as the cache does not attempt a mutex lock until AFTER hook.
 		//
 		return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-			// create links between this account and any emojis it uses
-			for _, i := range account.EmojiIDs {
-				if _, err := tx.NewInsert().Model(&gtsmodel.AccountToEmoji{
-					AccountID: account.ID,
-					EmojiID:   i,
-				}).Exec(ctx); err != nil {
-					return err
-				}
+			if err := putAccountEmojis(ctx, tx, account); err != nil {
+				return err
 			}
 
 			// insert the account
@@ -352,6 +348,71 @@ func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) e
 	})
 }
 
+func (a *accountDB) PutAccountUpsert(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.Account, error) {
+	// Set if an account with this
+	// URI was already in the db.
+	var conflict bool
+
+	err := a.state.Caches.GTS.Account.Store(account, func() error {
+		// It is safe to run this database transaction within cache.Store
+		// as the cache does not attempt a mutex lock until AFTER hook.
+		//
+		return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// insert the account first, doing
+			// nothing if its URI already exists
+			res, err := tx.
+				NewInsert().
+				Model(account).
+				On("CONFLICT (?) DO NOTHING", bun.Ident("uri")).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if n == 0 {
+				// Nothing was inserted, return
+				// an error so the given account
+				// isn't stored in the cache.
+				conflict = true
+				return db.ErrAlreadyExists
+			}
+
+			return putAccountEmojis(ctx, tx, account)
+		})
+	})
+
+	if conflict {
+		// Another account with this URI
+		// was stored first, return that.
+		return a.GetAccountByURI(ctx, account.URI)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// putAccountEmojis creates the links between the given new
+// account and the emojis it uses, within the given transaction.
+func putAccountEmojis(ctx context.Context, tx bun.Tx, account *gtsmodel.Account) error {
+	for _, i := range account.EmojiIDs {
+		if _, err := tx.NewInsert().Model(&gtsmodel.AccountToEmoji{
+			AccountID: account.ID,
+			EmojiID:   i,
+		}).Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account, columns ...string) error {
 	account.UpdatedAt = time.Now()
 	if len(columns) > 0 {
@@ -399,6 +460,7 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 
 func (a *accountDB) DeleteAccount(ctx context.Context, id string) error {
 	defer a.state.Caches.GTS.Account.Invalidate("ID", id)
+	defer a.state.Caches.GTS.AccountStats.Invalidate("AccountID", id)
 
 	// Load account into cache before attempting a delete,
 	// as we need it cached in order to trigger the invalidate
@@ -421,6 +483,15 @@ func (a *accountDB) DeleteAccount(ctx context.Context, id string) error {
 			return err
 		}
 
+		// delete the account stats
+		if _, err := tx.
+			NewDelete().
+			Table("account_stats").
+			Where("? = ?", bun.Ident("account_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// delete the account
 		_, err := tx.
 			NewDelete().
@@ -517,6 +588,57 @@ func (a *accountDB) GetAccountsUsingEmoji(ctx context.Context, emojiID string) (
 	return a.GetAccountsByIDs(ctx, accountIDs)
 }
 
+func (a *accountDB) GetDirectoryAccounts(ctx context.Context, local bool, active bool, limit int, offset int) ([]*gtsmodel.Account, error) {
+	var accountIDs []string
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? = ?", bun.Ident("account.discoverable"), true).
+		Where("? = ?", bun.Ident("account.locked"), false).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Where("? IS NULL", bun.Ident("account.moved_to_uri")).
+		// Exclude our own instance account...
+		Where("NOT (? IS NULL AND ? = ?)",
+			bun.Ident("account.domain"),
+			bun.Ident("account.username"), config.GetHost(),
+		).
+		// ...and most remote instance accounts.
+		Where("(? IS NULL OR ? != ?)",
+			bun.Ident("account.domain"),
+			bun.Ident("account.username"), bun.Ident("account.domain"),
+		)
+
+	if local {
+		q = q.Where("? IS NULL", bun.Ident("account.domain"))
+	}
+
+	if active {
+		// Order by last status time, which we only
+		// have for accounts with stats generated.
+		q = q.
+			Join(
+				"LEFT JOIN ? AS ? ON ? = ?",
+				bun.Ident("account_stats"), bun.Ident("stats"),
+				bun.Ident("stats.account_id"), bun.Ident("account.id"),
+			).
+			OrderExpr("? DESC NULLS LAST", bun.Ident("stats.last_status_at"))
+	}
+
+	q = q.
+		Order("account.id DESC").
+		Limit(limit).
+		Offset(offset)
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	// Convert account IDs into account objects.
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) GetAccountFaves(ctx context.Context, accountID string) ([]*gtsmodel.StatusFave, error) {
 	faves := new([]*gtsmodel.StatusFave)
 
@@ -621,27 +743,7 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 	}
 
 	if mediaOnly {
-		// Attachments are stored as a json object; this
-		// implementation differs between SQLite and Postgres,
-		// so we have to be thorough to cover all eventualities
-		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
-			switch a.db.Dialect().Name() {
-			case dialect.PG:
-				return q.
-					Where("? IS NOT NULL", bun.Ident("status.attachments")).
-					Where("? != '{}'", bun.Ident("status.attachments"))
-			case dialect.SQLite:
-				return q.
-					Where("? IS NOT NULL", bun.Ident("status.attachments")).
-					Where("? != ''", bun.Ident("status.attachments")).
-					Where("? != 'null'", bun.Ident("status.attachments")).
-					Where("? != '{}'", bun.Ident("status.attachments")).
-					Where("? != '[]'", bun.Ident("status.attachments"))
-			default:
-				log.Panic(ctx, "db dialect was neither pg nor sqlite")
-				return q
-			}
-		})
+		q = a.whereHasAttachments(ctx, q)
 	}
 
 	if publicOnly {
@@ -695,6 +797,95 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (a *accountDB) GetAccountOutboxStatuses(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Status, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		// Select only IDs from table
+		Column("status.id")
+
+	// Select only outbox statuses.
+	q = whereOutboxStatus(q, accountID)
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order.Ascending() {
+		// Page up.
+		q = q.Order("status.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("status.id DESC")
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want statuses
+	// to be sorted by ID desc, so reverse ids slice.
+	if order.Ascending() {
+		slices.Reverse(statusIDs)
+	}
+
+	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
+
+func (a *accountDB) CountAccountOutboxStatuses(ctx context.Context, accountID string) (int, error) {
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status"))
+
+	// Count only outbox statuses.
+	return whereOutboxStatus(q, accountID).Count(ctx)
+}
+
+// whereOutboxStatus adds where clauses to the given query to
+// select only statuses by the given account that belong in its
+// ActivityPub outbox: public, federated, not boosts, and not
+// replies to (or mentioning) any account other than itself.
+func whereOutboxStatus(q *bun.SelectQuery, accountID string) *bun.SelectQuery {
+	q = q.
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		// Only Public statuses.
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		// Don't include local-only statuses.
+		Where("? = ?", bun.Ident("status.federated"), true).
+		// Don't include boosts.
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				// Do include self replies (threads), but
+				// don't include replies to other people.
+				Where("? = ?", bun.Ident("status.in_reply_to_account_id"), accountID).
+				WhereOr("? IS NULL", bun.Ident("status.in_reply_to_uri"))
+		})
+
+	// Don't include replies that mention other people:
+	// for example, an account's reply to its own reply to someone else.
+	return whereArrayIsNullOrEmpty(q, bun.Ident("status.mentions"))
+}
+
 func (a *accountDB) GetAccountPinnedStatuses(ctx context.Context, accountID string) ([]*gtsmodel.Status, error) {
 	statusIDs := []string{}
 
@@ -704,7 +895,7 @@ func (a *accountDB) GetAccountPinnedStatuses(ctx context.Context, accountID stri
 		Column("status.id").
 		Where("? = ?", bun.Ident("status.account_id"), accountID).
 		Where("? IS NOT NULL", bun.Ident("status.pinned_at")).
-		Order("status.pinned_at DESC")
+		Order("status.pinned_at DESC", "status.id DESC")
 
 	if err := q.Scan(ctx, &statusIDs); err != nil {
 		return nil, err
@@ -717,7 +908,7 @@ func (a *accountDB) GetAccountPinnedStatuses(ctx context.Context, accountID stri
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
-func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, error) {
+func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string, mediaOnly bool) ([]*gtsmodel.Status, error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -740,6 +931,10 @@ func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string,
 		// Don't show local-only statuses on the web view.
 		Where("? = ?", bun.Ident("status.federated"), true)
 
+	if mediaOnly {
+		q = a.whereHasAttachments(ctx, q)
+	}
+
 	// return only statuses LOWER (ie., older) than maxID
 	if maxID == "" {
 		maxID = id.Highest
@@ -751,12 +946,68 @@ func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string,
 		q = q.Limit(limit)
 	}
 
-	if limit > 0 {
-		// limit amount of statuses returned
-		q = q.Limit(limit)
+	q = q.Order("status.id DESC")
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
 	}
 
-	q = q.Order("status.id DESC")
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
+
+// whereHasAttachments adds a where clause to the
+// given query to select only statuses with attachments.
+func (a *accountDB) whereHasAttachments(ctx context.Context, q *bun.SelectQuery) *bun.SelectQuery {
+	// Attachments are stored as a json object; this
+	// implementation differs between SQLite and Postgres,
+	// so we have to be thorough to cover all eventualities
+	return q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		switch a.db.Dialect().Name() {
+		case dialect.PG:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments"))
+		case dialect.SQLite:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != ''", bun.Ident("status.attachments")).
+				Where("? != 'null'", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments")).
+				Where("? != '[]'", bun.Ident("status.attachments"))
+		default:
+			log.Panic(ctx, "db dialect was neither pg nor sqlite")
+			return q
+		}
+	})
+}
+
+func (a *accountDB) GetAccountRSSStatuses(ctx context.Context, accountID string, includeSensitive bool, limit int) ([]*gtsmodel.Status, error) {
+	// Make educated guess for slice size
+	statusIDs := make([]string, 0, limit)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		// Select only IDs from table
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		// Don't show replies or boosts.
+		Where("? IS NULL", bun.Ident("status.in_reply_to_uri")).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		// Only Public statuses.
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		// Don't show local-only statuses.
+		Where("? = ?", bun.Ident("status.federated"), true).
+		Order("status.id DESC").
+		Limit(limit)
+
+	if !includeSensitive {
+		q = q.Where("? = ?", bun.Ident("status.sensitive"), false)
+	}
 
 	if err := q.Scan(ctx, &statusIDs); err != nil {
 		return nil, err
@@ -768,3 +1019,238 @@ func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string,
 
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
+
+func (a *accountDB) GetLocalRSSStatuses(ctx context.Context, limit int) ([]*gtsmodel.Status, error) {
+	// Make educated guess for slice size
+	statusIDs := make([]string, 0, limit)
+
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		// Select only IDs from table
+		Column("status.id").
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("status.account_id"),
+		).
+		// Only local accounts with RSS enabled.
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? = ?", bun.Ident("account.enable_rss"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		// Don't show replies or boosts.
+		Where("? IS NULL", bun.Ident("status.in_reply_to_uri")).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		// Only Public, non-sensitive statuses.
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? = ?", bun.Ident("status.sensitive"), false).
+		// Don't show local-only statuses.
+		Where("? = ?", bun.Ident("status.federated"), true).
+		Order("status.id DESC").
+		Limit(limit).
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
+
+func (a *accountDB) GetAccountsWithStatusesAutoDelete(ctx context.Context) ([]*gtsmodel.Account, error) {
+	accountIDs := []string{}
+
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? IS NULL", bun.Ident("account.domain")).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Where("? > ?", bun.Ident("account.statuses_auto_delete_after_days"), 0).
+		Order("account.id ASC").
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
+func (a *accountDB) GetAccountAutoDeleteStatuses(ctx context.Context, account *gtsmodel.Account, olderThan time.Time, limit int) ([]*gtsmodel.Status, error) {
+	// Make educated guess for slice size
+	statusIDs := make([]string, 0, limit)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		// Select only IDs from table
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.account_id"), account.ID).
+		Where("? < ?", bun.Ident("status.created_at"), olderThan).
+		// Don't include boosts.
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Order("status.id ASC").
+		Limit(limit)
+
+	if util.PtrValueOr(account.StatusesAutoDeleteKeepPinned, true) {
+		q = q.Where("? IS NULL", bun.Ident("status.pinned_at"))
+	}
+
+	if util.PtrValueOr(account.StatusesAutoDeleteKeepDirect, true) {
+		q = q.Where("? != ?", bun.Ident("status.visibility"), gtsmodel.VisibilityDirect)
+	}
+
+	if util.PtrValueOr(account.StatusesAutoDeleteKeepBookmarked, true) {
+		// Leave out statuses the account has bookmarked.
+		bookmarked := a.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+			Column("status_bookmark.status_id").
+			Where("? = ?", bun.Ident("status_bookmark.account_id"), account.ID)
+		q = q.Where("? NOT IN (?)", bun.Ident("status.id"), bookmarked)
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
+
+func (a *accountDB) GetAccountStats(ctx context.Context, accountID string) (*gtsmodel.AccountStats, error) {
+	stats, err := a.state.Caches.GTS.AccountStats.LoadOne("AccountID", func() (*gtsmodel.AccountStats, error) {
+		var stats gtsmodel.AccountStats
+
+		// Not cached! Perform database query.
+		if err := a.db.
+			NewSelect().
+			Model(&stats).
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return &stats, nil
+	}, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if stats == nil {
+		// No stats generated
+		// yet for this account.
+		return a.RegenerateAccountStats(ctx, accountID)
+	}
+
+	return stats, nil
+}
+
+func (a *accountDB) GetAccountStatsRegeneratedBefore(ctx context.Context, before time.Time, limit int) ([]*gtsmodel.AccountStats, error) {
+	stats := make([]*gtsmodel.AccountStats, 0, limit)
+
+	if err := a.db.
+		NewSelect().
+		Model(&stats).
+		Where("? < ?", bun.Ident("regenerated_at"), before).
+		Order("regenerated_at ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (a *accountDB) RegenerateAccountStats(ctx context.Context, accountID string) (*gtsmodel.AccountStats, error) {
+	var (
+		stats = &gtsmodel.AccountStats{AccountID: accountID}
+		err   error
+	)
+
+	stats.FollowersCount, err = a.state.DB.CountAccountFollowers(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error counting followers: %w", err)
+	}
+
+	stats.FollowingCount, err = a.state.DB.CountAccountFollows(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error counting following: %w", err)
+	}
+
+	stats.StatusesCount, err = a.CountAccountStatuses(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error counting statuses: %w", err)
+	}
+
+	stats.LastStatusAt, err = a.GetAccountLastPosted(ctx, accountID, false)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting last posted: %w", err)
+	}
+
+	stats.RegeneratedAt = time.Now()
+
+	// Invalidate any existing cached stats,
+	// as Store() won't replace an existing entry.
+	a.state.Caches.GTS.AccountStats.Invalidate("AccountID", accountID)
+
+	if err := a.state.Caches.GTS.AccountStats.Store(stats, func() error {
+		_, err := a.db.
+			NewInsert().
+			Model(stats).
+			On("CONFLICT (?) DO UPDATE", bun.Ident("account_id")).
+			Set("? = EXCLUDED.?", bun.Ident("regenerated_at"), bun.Ident("regenerated_at")).
+			Set("? = EXCLUDED.?", bun.Ident("followers_count"), bun.Ident("followers_count")).
+			Set("? = EXCLUDED.?", bun.Ident("following_count"), bun.Ident("following_count")).
+			Set("? = EXCLUDED.?", bun.Ident("statuses_count"), bun.Ident("statuses_count")).
+			Set("? = EXCLUDED.?", bun.Ident("last_status_at"), bun.Ident("last_status_at")).
+			Exec(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (a *accountDB) UpdateAccountStatsFollows(ctx context.Context, accountID string, followersDelta int, followingDelta int) error {
+	defer a.state.Caches.GTS.AccountStats.Invalidate("AccountID", accountID)
+
+	_, err := a.db.
+		NewUpdate().
+		Table("account_stats").
+		Set("? = ? + ?", bun.Ident("followers_count"), bun.Ident("followers_count"), followersDelta).
+		Set("? = ? + ?", bun.Ident("following_count"), bun.Ident("following_count"), followingDelta).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountDB) UpdateAccountStatsStatuses(ctx context.Context, accountID string, delta int, lastStatusAt time.Time) error {
+	defer a.state.Caches.GTS.AccountStats.Invalidate("AccountID", accountID)
+
+	q := a.db.
+		NewUpdate().
+		Table("account_stats").
+		Set("? = ? + ?", bun.Ident("statuses_count"), bun.Ident("statuses_count"), delta).
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	if !lastStatusAt.IsZero() {
+		// Only ever move last status time forward,
+		// as statuses may be dereferenced out of order.
+		q = q.Set("? = CASE WHEN ? IS NULL OR ? < ? THEN ? ELSE ? END",
+			bun.Ident("last_status_at"),
+			bun.Ident("last_status_at"),
+			bun.Ident("last_status_at"), lastStatusAt,
+			lastStatusAt,
+			bun.Ident("last_status_at"),
+		)
+	}
+
+	_, err := q.Exec(ctx)
+	return err
+}
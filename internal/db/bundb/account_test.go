@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
@@ -87,6 +88,46 @@ func (suite *AccountTestSuite) TestGetAccountStatusesExcludeRepliesAndReblogsPub
 	suite.Len(statuses, 2)
 }
 
+func (suite *AccountTestSuite) TestGetAccountOutboxStatuses() {
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	total, err := suite.db.CountAccountOutboxStatuses(context.Background(), accountID)
+	suite.NoError(err)
+	suite.Equal(2, total)
+
+	// get the first page
+	statuses, err := suite.db.GetAccountOutboxStatuses(context.Background(), accountID, &paging.Page{Limit: 1})
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal("01HH9KYNQPA416TNJ53NSATP40", statuses[0].ID)
+
+	// get the second page
+	statuses, err = suite.db.GetAccountOutboxStatuses(context.Background(), accountID, &paging.Page{
+		Max:   paging.MaxID(statuses[0].ID),
+		Limit: 1,
+	})
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal("01F8MHAMCHF6Y650WCRSCP4WMY", statuses[0].ID)
+
+	// page back up from the second page
+	statuses, err = suite.db.GetAccountOutboxStatuses(context.Background(), accountID, &paging.Page{
+		Min:   paging.MinID(statuses[0].ID),
+		Limit: 1,
+	})
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal("01HH9KYNQPA416TNJ53NSATP40", statuses[0].ID)
+
+	// try to get the last page (should be empty)
+	statuses, err = suite.db.GetAccountOutboxStatuses(context.Background(), accountID, &paging.Page{
+		Max:   paging.MaxID("01F8MHAMCHF6Y650WCRSCP4WMY"),
+		Limit: 1,
+	})
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
 // populateTestStatus adds mandatory fields to a partially populated status.
 func (suite *AccountTestSuite) populateTestStatus(testAccountKey string, status *gtsmodel.Status, inReplyTo *gtsmodel.Status) *gtsmodel.Status {
 	testAccount := suite.testAccounts[testAccountKey]
@@ -197,6 +238,13 @@ func (suite *AccountTestSuite) TestGetAccountStatusesMediaOnly() {
 	suite.Len(statuses, 1)
 }
 
+func (suite *AccountTestSuite) TestGetAccountWebStatusesMediaOnly() {
+	statuses, err := suite.db.GetAccountWebStatuses(context.Background(), suite.testAccounts["admin_account"].ID, 20, "", true)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+}
+
 func (suite *AccountTestSuite) TestGetAccountBy() {
 	t := suite.T()
 
@@ -421,6 +469,44 @@ func (suite *AccountTestSuite) TestGetAccountLastPostedWebOnly() {
 	suite.EqualValues(1702200240, lastPosted.Unix())
 }
 
+func (suite *AccountTestSuite) TestGetDirectoryAccountsLocal() {
+	accounts, err := suite.db.GetDirectoryAccounts(context.Background(), true, false, 20, 0)
+	suite.NoError(err)
+
+	// Instance account is discoverable and
+	// unlocked, but shouldn't be included.
+	ids := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		ids = append(ids, account.ID)
+	}
+	suite.Equal([]string{
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts["admin_account"].ID,
+	}, ids)
+
+	// Page along by one.
+	accounts, err = suite.db.GetDirectoryAccounts(context.Background(), true, false, 20, 1)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["admin_account"].ID, accounts[0].ID)
+}
+
+func (suite *AccountTestSuite) TestGetDirectoryAccountsRemote() {
+	accounts, err := suite.db.GetDirectoryAccounts(context.Background(), false, true, 20, 0)
+	suite.NoError(err)
+
+	ids := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		suite.True(*account.Discoverable)
+		suite.False(*account.Locked)
+		suite.False(account.IsInstance())
+		ids = append(ids, account.ID)
+	}
+	suite.Contains(ids, suite.testAccounts["remote_account_1"].ID)
+	suite.NotContains(ids, suite.testAccounts["remote_account_2"].ID) // locked
+	suite.NotContains(ids, suite.testAccounts["local_account_2"].ID)  // not discoverable
+}
+
 func (suite *AccountTestSuite) TestInsertAccountWithDefaults() {
 	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	suite.NoError(err)
@@ -482,6 +568,141 @@ func (suite *AccountTestSuite) TestCountAccountPinnedNothingPinned() {
 	suite.Equal(pinned, 0) // This account has nothing pinned.
 }
 
+func (suite *AccountTestSuite) TestGetAccountAutoDeleteStatuses() {
+	ctx := context.Background()
+	olderThan := time.Now()
+
+	// Don't keep anything.
+	testAccount := new(gtsmodel.Account)
+	*testAccount = *suite.testAccounts["admin_account"]
+	testAccount.StatusesAutoDeleteKeepPinned = util.Ptr(false)
+	testAccount.StatusesAutoDeleteKeepBookmarked = util.Ptr(false)
+	testAccount.StatusesAutoDeleteKeepDirect = util.Ptr(false)
+
+	all, err := suite.db.GetAccountAutoDeleteStatuses(ctx, testAccount, olderThan, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var pinned int
+	for _, status := range all {
+		if !status.PinnedAt.IsZero() {
+			pinned++
+		}
+	}
+	suite.Equal(2, pinned) // This account has 2 statuses pinned.
+
+	// Bookmark one of the unpinned statuses.
+	var bookmarked *gtsmodel.Status
+	for _, status := range all {
+		if status.PinnedAt.IsZero() {
+			bookmarked = status
+			break
+		}
+	}
+	if bookmarked == nil {
+		suite.FailNow("no unpinned status to bookmark")
+	}
+
+	if err := suite.db.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+		ID:              "01HT2T5Q6W9YF4C8A0JDRBP6ZN",
+		AccountID:       testAccount.ID,
+		TargetAccountID: testAccount.ID,
+		StatusID:        bookmarked.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Keep pinned and bookmarked statuses.
+	testAccount.StatusesAutoDeleteKeepPinned = util.Ptr(true)
+	testAccount.StatusesAutoDeleteKeepBookmarked = util.Ptr(true)
+
+	kept, err := suite.db.GetAccountAutoDeleteStatuses(ctx, testAccount, olderThan, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(kept, len(all)-3)
+
+	for _, status := range kept {
+		suite.True(status.PinnedAt.IsZero())
+		suite.NotEqual(bookmarked.ID, status.ID)
+	}
+
+	// Nothing is old enough.
+	statuses, err := suite.db.GetAccountAutoDeleteStatuses(ctx, testAccount, time.Time{}, 100)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Empty(statuses)
+}
+
+func (suite *AccountTestSuite) TestGetAccountsWithStatusesAutoDelete() {
+	ctx := context.Background()
+
+	accounts, err := suite.db.GetAccountsWithStatusesAutoDelete(ctx)
+	suite.NoError(err)
+	suite.Empty(accounts)
+
+	testAccount := suite.testAccounts["local_account_1"]
+	testAccount.StatusesAutoDeleteAfterDays = 30
+	if err := suite.db.UpdateAccount(ctx, testAccount, "statuses_auto_delete_after_days"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err = suite.db.GetAccountsWithStatusesAutoDelete(ctx)
+	suite.NoError(err)
+	if suite.Len(accounts, 1) {
+		suite.Equal(testAccount.ID, accounts[0].ID)
+	}
+}
+
+func (suite *AccountTestSuite) TestAccountStats() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Stats should be generated on first get.
+	stats, err := suite.db.GetAccountStats(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.False(stats.RegeneratedAt.IsZero())
+
+	followers, err := suite.db.CountAccountFollowers(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(followers, stats.FollowersCount)
+
+	statuses, err := suite.db.CountAccountStatuses(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(statuses, stats.StatusesCount)
+
+	// Increment counts incrementally.
+	lastStatusAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	err = suite.db.UpdateAccountStatsStatuses(ctx, testAccount.ID, 1, lastStatusAt)
+	suite.NoError(err)
+
+	err = suite.db.UpdateAccountStatsFollows(ctx, testAccount.ID, 1, -1)
+	suite.NoError(err)
+
+	updated, err := suite.db.GetAccountStats(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(stats.StatusesCount+1, updated.StatusesCount)
+	suite.Equal(stats.FollowersCount+1, updated.FollowersCount)
+	suite.Equal(stats.FollowingCount-1, updated.FollowingCount)
+	suite.True(lastStatusAt.Equal(updated.LastStatusAt))
+
+	// Older last status time shouldn't be set.
+	err = suite.db.UpdateAccountStatsStatuses(ctx, testAccount.ID, -1, lastStatusAt.Add(-2*time.Hour))
+	suite.NoError(err)
+
+	updated, err = suite.db.GetAccountStats(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(stats.StatusesCount, updated.StatusesCount)
+	suite.True(lastStatusAt.Equal(updated.LastStatusAt))
+
+	// Regenerating should correct the drift.
+	regenerated, err := suite.db.RegenerateAccountStats(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(stats.FollowersCount, regenerated.FollowersCount)
+	suite.Equal(stats.FollowingCount, regenerated.FollowingCount)
+	suite.Equal(stats.StatusesCount, regenerated.StatusesCount)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
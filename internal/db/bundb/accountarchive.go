@@ -0,0 +1,152 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type accountArchiveDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *accountArchiveDB) GetAccountArchiveByID(ctx context.Context, id string) (*gtsmodel.AccountArchive, error) {
+	var archive gtsmodel.AccountArchive
+
+	if err := a.db.
+		NewSelect().
+		Model(&archive).
+		Where("? = ?", bun.Ident("account_archive.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &archive, nil
+	}
+
+	if archive.Account == nil {
+		// Archive account is not set, fetch from the database.
+		account, err := a.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			archive.AccountID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		archive.Account = account
+	}
+
+	return &archive, nil
+}
+
+func (a *accountArchiveDB) GetAccountArchives(ctx context.Context, accountID string) ([]*gtsmodel.AccountArchive, error) {
+	var ids []string
+
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("account_archives"), bun.Ident("account_archive")).
+		Column("account_archive.id").
+		Where("? = ?", bun.Ident("account_archive.account_id"), accountID).
+		Order("account_archive.id DESC").
+		Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	return a.getAccountArchivesByIDs(ctx, ids)
+}
+
+func (a *accountArchiveDB) GetAccountArchivesByStage(ctx context.Context, stages ...gtsmodel.AccountArchiveStage) ([]*gtsmodel.AccountArchive, error) {
+	var ids []string
+
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("account_archives"), bun.Ident("account_archive")).
+		Column("account_archive.id").
+		Where("? IN (?)", bun.Ident("account_archive.stage"), bun.In(stages)).
+		Order("account_archive.id ASC").
+		Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	return a.getAccountArchivesByIDs(ctx, ids)
+}
+
+func (a *accountArchiveDB) getAccountArchivesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.AccountArchive, error) {
+	archives := make([]*gtsmodel.AccountArchive, 0, len(ids))
+
+	for _, id := range ids {
+		archive, err := a.GetAccountArchiveByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting account archive %q: %v", id, err)
+			continue
+		}
+
+		archives = append(archives, archive)
+	}
+
+	return archives, nil
+}
+
+func (a *accountArchiveDB) PutAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	_, err := a.db.
+		NewInsert().
+		Model(archive).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountArchiveDB) UpdateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive, columns ...string) error {
+	archive.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(archive).
+		Column(columns...).
+		Where("? = ?", bun.Ident("account_archive.id"), archive.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountArchiveDB) DeleteAccountArchiveByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("account_archives"), bun.Ident("account_archive")).
+		Where("? = ?", bun.Ident("account_archive.id"), id).
+		Exec(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
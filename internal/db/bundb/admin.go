@@ -186,11 +186,12 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		AccountID:              account.ID,
 		Account:                account,
 		EncryptedPassword:      string(encryptedPassword),
-		SignUpIP:               newSignup.SignUpIP.To4(),
+		SignUpIP:               util.RetainIP(newSignup.SignUpIP).To4(),
 		Locale:                 newSignup.Locale,
 		UnconfirmedEmail:       newSignup.Email,
 		CreatedByApplicationID: newSignup.AppID,
 		ExternalID:             newSignup.ExternalID,
+		InviteID:               newSignup.InviteID,
 	}
 
 	if newSignup.EmailVerified {
@@ -203,6 +204,9 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		// Make new user mod + admin.
 		user.Moderator = util.Ptr(true)
 		user.Admin = util.Ptr(true)
+	} else if newSignup.Moderator {
+		// Make new user mod.
+		user.Moderator = util.Ptr(true)
 	}
 
 	if newSignup.PreApproved {
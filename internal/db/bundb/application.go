@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -95,3 +96,51 @@ func (a *applicationDB) DeleteApplicationByClientID(ctx context.Context, clientI
 
 	return nil
 }
+
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error) {
+	token := new(gtsmodel.Token)
+	if err := a.db.NewSelect().
+		Model(token).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (a *applicationDB) GetAccessTokens(ctx context.Context, userID string) ([]*gtsmodel.Token, error) {
+	tokens := []*gtsmodel.Token{}
+	if err := a.db.NewSelect().
+		Model(&tokens).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? != ''", bun.Ident("access")).
+		Order("id DESC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (a *applicationDB) UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error {
+	token.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.NewUpdate().
+		Model(token).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), token.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
+	_, err := a.db.NewDelete().
+		Table("tokens").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
@@ -27,7 +27,8 @@ import (
 )
 
 type basicDB struct {
-	db *bun.DB
+	db      *bun.DB
+	replica *bun.DB
 }
 
 func (b *basicDB) Put(ctx context.Context, i interface{}) error {
@@ -130,5 +131,10 @@ func (b *basicDB) IsHealthy(ctx context.Context) error {
 
 func (b *basicDB) Close() error {
 	log.Info(nil, "closing db connection")
+	if b.replica != nil {
+		if err := b.replica.Close(); err != nil {
+			log.Errorf(nil, "error closing db replica connection: %v", err)
+		}
+	}
 	return b.db.Close()
 }
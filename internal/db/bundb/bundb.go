@@ -55,20 +55,29 @@ import (
 // DBService satisfies the DB interface
 type DBService struct {
 	db.Account
+	db.AccountArchive
 	db.Admin
 	db.Application
 	db.Basic
+	db.Delivery
 	db.Domain
 	db.Emoji
 	db.HeaderFilter
+	db.InboxActivity
 	db.Instance
+	db.Invite
 	db.List
 	db.Marker
 	db.Media
 	db.Mention
+	db.Metrics
 	db.Notification
+	db.NotificationRequest
 	db.Poll
+	db.QuarantinedStatus
+	db.QueuedMessage
 	db.Relationship
+	db.Relay
 	db.Report
 	db.Rule
 	db.Search
@@ -76,6 +85,7 @@ type DBService struct {
 	db.Status
 	db.StatusBookmark
 	db.StatusFave
+	db.StatusReaction
 	db.Tag
 	db.Thread
 	db.Timeline
@@ -126,6 +136,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 	var err error
 	t := strings.ToLower(config.GetDbType())
 
+	if config.GetDbReplicaAddress() != "" && t != "postgres" {
+		return nil, fmt.Errorf("'%s' is only supported for postgres", config.DbReplicaAddressFlag())
+	}
+
 	switch t {
 	case "postgres":
 		db, err = pgConn(ctx, state)
@@ -141,24 +155,39 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		return nil, fmt.Errorf("database type %s not supported for bundb", t)
 	}
 
-	// Add database query hooks.
-	db.AddQueryHook(queryHook{})
-	if config.GetTracingEnabled() {
-		db.AddQueryHook(tracing.InstrumentBun())
-	}
-	if config.GetMetricsEnabled() {
-		db.AddQueryHook(metrics.InstrumentBun())
+	// Open the optional read replica.
+	var replica *bun.DB
+	if config.GetDbReplicaAddress() != "" {
+		replica, err = pgReplicaConn(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// table registration is needed for many-to-many, see:
-	// https://bun.uptrace.dev/orm/many-to-many-relation/
-	for _, t := range []interface{}{
-		&gtsmodel.AccountToEmoji{},
-		&gtsmodel.StatusToEmoji{},
-		&gtsmodel.StatusToTag{},
-		&gtsmodel.ThreadToStatus{},
-	} {
-		db.RegisterModel(t)
+	for _, db := range []*bun.DB{db, replica} {
+		if db == nil {
+			continue
+		}
+
+		// Add database query hooks.
+		db.AddQueryHook(queryHook{})
+		if config.GetTracingEnabled() {
+			db.AddQueryHook(tracing.InstrumentBun())
+		}
+		if config.GetMetricsEnabled() {
+			db.AddQueryHook(metrics.InstrumentBun())
+		}
+
+		// table registration is needed for many-to-many, see:
+		// https://bun.uptrace.dev/orm/many-to-many-relation/
+		for _, t := range []interface{}{
+			&gtsmodel.AccountToEmoji{},
+			&gtsmodel.StatusToEmoji{},
+			&gtsmodel.StatusToTag{},
+			&gtsmodel.ThreadToStatus{},
+		} {
+			db.RegisterModel(t)
+		}
 	}
 
 	// perform any pending database migrations: this includes
@@ -173,6 +202,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		AccountArchive: &accountArchiveDB{
+			db:    db,
+			state: state,
+		},
 		Admin: &adminDB{
 			db:    db,
 			state: state,
@@ -182,6 +215,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			state: state,
 		},
 		Basic: &basicDB{
+			db:      db,
+			replica: replica,
+		},
+		Delivery: &deliveryDB{
 			db: db,
 		},
 		Domain: &domainDB{
@@ -196,7 +233,15 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		InboxActivity: &inboxActivityDB{
+			db: db,
+		},
 		Instance: &instanceDB{
+			db:      db,
+			replica: replica,
+			state:   state,
+		},
+		Invite: &inviteDB{
 			db:    db,
 			state: state,
 		},
@@ -216,18 +261,36 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Metrics: &metricsDB{
+			db: db,
+		},
 		Notification: &notificationDB{
 			db:    db,
 			state: state,
 		},
+		NotificationRequest: &notificationRequestDB{
+			db:    db,
+			state: state,
+		},
 		Poll: &pollDB{
 			db:    db,
 			state: state,
 		},
+		QuarantinedStatus: &quarantinedStatusDB{
+			db:    db,
+			state: state,
+		},
+		QueuedMessage: &queuedMessageDB{
+			db: db,
+		},
 		Relationship: &relationshipDB{
 			db:    db,
 			state: state,
 		},
+		Relay: &relayDB{
+			db:    db,
+			state: state,
+		},
 		Report: &reportDB{
 			db:    db,
 			state: state,
@@ -237,35 +300,45 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			state: state,
 		},
 		Search: &searchDB{
-			db:    db,
-			state: state,
+			db:      db,
+			replica: replica,
+			state:   state,
 		},
 		Session: &sessionDB{
 			db: db,
 		},
 		Status: &statusDB{
-			db:    db,
-			state: state,
+			db:      db,
+			replica: replica,
+			state:   state,
 		},
 		StatusBookmark: &statusBookmarkDB{
 			db:    db,
 			state: state,
 		},
 		StatusFave: &statusFaveDB{
+			db:      db,
+			replica: replica,
+			state:   state,
+		},
+		StatusReaction: &statusReactionDB{
 			db:    db,
 			state: state,
 		},
 		Tag: &tagDB{
-			db:    db,
-			state: state,
+			db:      db,
+			replica: replica,
+			state:   state,
 		},
 		Thread: &threadDB{
-			db:    db,
-			state: state,
+			db:      db,
+			replica: replica,
+			state:   state,
 		},
 		Timeline: &timelineDB{
-			db:    db,
-			state: state,
+			db:      db,
+			replica: replica,
+			state:   state,
 		},
 		User: &userDB{
 			db:    db,
@@ -288,6 +361,18 @@ func pgConn(ctx context.Context, state *state.State) (*bun.DB, error) {
 		return nil, fmt.Errorf("could not create bundb postgres options: %w", err)
 	}
 
+	db, err := pgOpen(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "connected to POSTGRES database")
+	return db, nil
+}
+
+// pgOpen opens and pings a new postgres
+// connection pool with the given options.
+func pgOpen(ctx context.Context, opts *pgx.ConnConfig) (*bun.DB, error) {
 	cfg := stdlib.RegisterConnConfig(opts)
 
 	sqldb, err := sql.Open("pgx-gts", cfg)
@@ -309,7 +394,6 @@ func pgConn(ctx context.Context, state *state.State) (*bun.DB, error) {
 		return nil, fmt.Errorf("postgres ping: %w", err)
 	}
 
-	log.Info(ctx, "connected to POSTGRES database")
 	return db, nil
 }
 
@@ -534,6 +618,18 @@ func buildSQLiteAddress(addr string) string {
 		prefs.Add("_pragma", fmt.Sprintf("journal_mode(%s)", mode))
 	}
 
+	if sz := config.GetDbSqliteJournalSizeLimit(); sz > 0 {
+		// Set the user provided SQLite journal size limit (in bytes).
+		// Journal / WAL files are truncated to this size after each
+		// transaction or checkpoint, rather than left to grow.
+		prefs.Add("_pragma", fmt.Sprintf("journal_size_limit(%d)", uint64(sz)))
+	}
+
+	if n := config.GetDbSqliteWALAutocheckpoint(); n > 0 {
+		// Set the user provided SQLite WAL autocheckpoint (in pages).
+		prefs.Add("_pragma", fmt.Sprintf("wal_autocheckpoint(%d)", n))
+	}
+
 	if mode := config.GetDbSqliteSynchronous(); mode != "" {
 		// Set the user provided SQLite synchronous mode.
 		prefs.Add("_pragma", fmt.Sprintf("synchronous(%s)", mode))
@@ -21,6 +21,7 @@ import (
 	"context"
 	"testing"
 
+	"codeberg.org/gruf/go-bytesize"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
@@ -46,6 +47,28 @@ func (suite *BundbNewTestSuite) TestCreateNewSqliteDBNoAddress() {
 	suite.Nil(db)
 }
 
+func (suite *BundbNewTestSuite) TestCreateNewSqliteDBJournalTuning() {
+	if config.GetDbType() != "sqlite" {
+		suite.T().Skip("sqlite only")
+	}
+
+	// create a new db with journal tuning pragmas set
+	config.SetDbSqliteJournalSizeLimit(64 * bytesize.MiB)
+	config.SetDbSqliteWALAutocheckpoint(500)
+	db, err := bundb.NewBunDBService(context.Background(), nil)
+	suite.NoError(err)
+	suite.NotNil(db)
+}
+
+func (suite *BundbNewTestSuite) TestCreateNewSqliteDBWithReplica() {
+	// create a new sqlite db with a replica specified
+	config.SetDbType("sqlite")
+	config.SetDbReplicaAddress("replica.example.org")
+	db, err := bundb.NewBunDBService(context.Background(), nil)
+	suite.EqualError(err, "'db-replica-address' is only supported for postgres")
+	suite.Nil(db)
+}
+
 func TestBundbNewTestSuite(t *testing.T) {
 	suite.Run(t, new(BundbNewTestSuite))
 }
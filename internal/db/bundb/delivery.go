@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type deliveryDB struct {
+	db *bun.DB
+}
+
+func (d *deliveryDB) PutDelivery(ctx context.Context, delivery *gtsmodel.Delivery) error {
+	_, err := d.db.
+		NewInsert().
+		Model(delivery).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) UpdateDelivery(ctx context.Context, delivery *gtsmodel.Delivery, columns ...string) error {
+	_, err := d.db.
+		NewUpdate().
+		Model(delivery).
+		Column(columns...).
+		Where("? = ?", bun.Ident("delivery.id"), delivery.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) DeleteDeliveryByID(ctx context.Context, id string) error {
+	_, err := d.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("deliveries"), bun.Ident("delivery")).
+		Where("? = ?", bun.Ident("delivery.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) GetDeliveriesDue(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.Delivery, error) {
+	deliveries := make([]*gtsmodel.Delivery, 0, limit)
+
+	if err := d.db.
+		NewSelect().
+		Model(&deliveries).
+		Where("? <= ?", bun.Ident("delivery.next_attempt_at"), now).
+		Order("delivery.next_attempt_at ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (d *deliveryDB) CountDeliveriesByDomain(ctx context.Context) (map[string]int, error) {
+	var rows []struct {
+		Domain string
+		Count  int
+	}
+
+	if err := d.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("deliveries"), bun.Ident("delivery")).
+		ColumnExpr("? AS ?", bun.Ident("delivery.domain"), bun.Ident("domain")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Group("delivery.domain").
+		Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Domain] = row.Count
+	}
+
+	return counts, nil
+}
@@ -21,12 +21,15 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"time"
 	_ "unsafe" // linkname shenanigans
 
 	pgx "github.com/jackc/pgx/v5/stdlib"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"modernc.org/sqlite"
 )
 
@@ -306,8 +309,15 @@ func retryOnBusy(ctx context.Context, fn func() error) error {
 
 // retryOnBusySlow is the outlined form of retryOnBusy, to allow the fast path (i.e. only
 // 1 attempt) to be inlined, leaving the slow retry loop to be a separate function call.
-func retryOnBusySlow(ctx context.Context, fn func() error) error {
-	var backoff time.Duration
+func retryOnBusySlow(ctx context.Context, fn func() error) (err error) {
+	var (
+		backoff time.Duration
+		retries int
+		start   = time.Now()
+	)
+
+	// Make busy contention visible to admins.
+	defer func() { busyRetried(ctx, retries, time.Since(start), err) }()
 
 	for i := 0; ; i++ {
 		// backoff according to a multiplier of 2ms * 2^2n,
@@ -338,7 +348,8 @@ func retryOnBusySlow(ctx context.Context, fn func() error) error {
 		}
 
 		// Perform func.
-		err := fn()
+		retries++
+		err = fn()
 
 		if err != errBusy {
 			// May be nil, or may be
@@ -351,6 +362,23 @@ func retryOnBusySlow(ctx context.Context, fn func() error) error {
 	return gtserror.Newf("%w (waited > %s)", db.ErrBusyTimeout, backoff)
 }
 
+// busyRetried logs and records metrics for a query
+// that was retried on a busy database, given the
+// number of retries, total time taken and final error.
+func busyRetried(ctx context.Context, retries int, took time.Duration, err error) {
+	timedOut := errors.Is(err, db.ErrBusyTimeout)
+	metrics.DatabaseBusy(retries, timedOut)
+
+	switch {
+	case timedOut:
+		log.Errorf(ctx, "database busy, gave up after %d retries (%s)", retries, took)
+	case err == nil:
+		log.Warnf(ctx, "database busy, succeeded after %d retries (%s)", retries, took)
+	default:
+		log.Warnf(ctx, "database busy, failed after %d retries (%s): %v", retries, took, err)
+	}
+}
+
 // toNamedValues converts older driver.Value types to driver.NamedValue types.
 func toNamedValues(args []driver.Value) []driver.NamedValue {
 	if args == nil {
@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type inboxActivityDB struct {
+	db *bun.DB
+}
+
+func (i *inboxActivityDB) PutInboxActivity(ctx context.Context, activity *gtsmodel.InboxActivity) error {
+	_, err := i.db.
+		NewInsert().
+		Model(activity).
+		Exec(ctx)
+	return err
+}
+
+func (i *inboxActivityDB) DeleteInboxActivityByKey(ctx context.Context, key string) error {
+	_, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("inbox_activities"), bun.Ident("inbox_activity")).
+		Where("? = ?", bun.Ident("inbox_activity.key"), key).
+		Exec(ctx)
+	return err
+}
+
+func (i *inboxActivityDB) DeleteInboxActivitiesOlderThan(ctx context.Context, olderThan time.Time) (int, error) {
+	res, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("inbox_activities"), bun.Ident("inbox_activity")).
+		Where("? < ?", bun.Ident("inbox_activity.created_at"), olderThan).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	return int(rows), err
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type InboxActivityTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InboxActivityTestSuite) TestPutDeleteInboxActivity() {
+	ctx := context.Background()
+
+	const key = "Follow 01F8MH17FWEB39HZJ76B6VXSKF https://fossbros-anonymous.io/users/foss_satan https://fossbros-anonymous.io/follows/01HRV2Q2ZN3BBAX0MQGQQ1RBNK"
+
+	if err := suite.state.DB.PutInboxActivity(ctx, &gtsmodel.InboxActivity{
+		ID:  id.NewULID(),
+		Key: key,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Putting the same key again should fail.
+	err := suite.state.DB.PutInboxActivity(ctx, &gtsmodel.InboxActivity{
+		ID:  id.NewULID(),
+		Key: key,
+	})
+	suite.True(errors.Is(err, db.ErrAlreadyExists))
+
+	// Once deleted, the key can be put again.
+	if err := suite.state.DB.DeleteInboxActivityByKey(ctx, key); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.state.DB.PutInboxActivity(ctx, &gtsmodel.InboxActivity{
+		ID:  id.NewULID(),
+		Key: key,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *InboxActivityTestSuite) TestDeleteInboxActivitiesOlderThan() {
+	ctx := context.Background()
+	now := time.Now()
+
+	for i, createdAt := range []time.Time{
+		now.Add(-8 * 24 * time.Hour),
+		now.Add(-7*24*time.Hour - time.Minute),
+		now.Add(-time.Hour),
+	} {
+		if err := suite.state.DB.PutInboxActivity(ctx, &gtsmodel.InboxActivity{
+			ID:        id.NewULID(),
+			CreatedAt: createdAt,
+			Key:       "Like 01F8MH17FWEB39HZJ76B6VXSKF https://fossbros-anonymous.io/users/foss_satan https://fossbros-anonymous.io/likes/" + string(rune('a'+i)),
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	pruned, err := suite.state.DB.DeleteInboxActivitiesOlderThan(ctx, now.Add(-7*24*time.Hour))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, pruned)
+
+	// Nothing left to prune.
+	pruned, err = suite.state.DB.DeleteInboxActivitiesOlderThan(ctx, now.Add(-7*24*time.Hour))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(pruned)
+}
+
+func TestInboxActivityTestSuite(t *testing.T) {
+	suite.Run(t, new(InboxActivityTestSuite))
+}
@@ -34,12 +34,13 @@ import (
 )
 
 type instanceDB struct {
-	db    *bun.DB
-	state *state.State
+	db      *bun.DB
+	replica *bun.DB
+	state   *state.State
 }
 
 func (i *instanceDB) CountInstanceUsers(ctx context.Context, domain string) (int, error) {
-	q := i.db.
+	q := readDB(ctx, i.db, i.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
 		Column("account.id").
@@ -62,7 +63,7 @@ func (i *instanceDB) CountInstanceUsers(ctx context.Context, domain string) (int
 }
 
 func (i *instanceDB) CountInstanceStatuses(ctx context.Context, domain string) (int, error) {
-	q := i.db.
+	q := readDB(ctx, i.db, i.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status"))
 
@@ -84,7 +85,7 @@ func (i *instanceDB) CountInstanceStatuses(ctx context.Context, domain string) (
 }
 
 func (i *instanceDB) CountInstanceDomains(ctx context.Context, domain string) (int, error) {
-	q := i.db.
+	q := readDB(ctx, i.db, i.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("instances"), bun.Ident("instance"))
 
@@ -380,3 +381,32 @@ func (i *instanceDB) GetInstanceModeratorAddresses(ctx context.Context) ([]strin
 
 	return addresses, nil
 }
+
+func (i *instanceDB) GetInstanceInboxURIs(ctx context.Context) ([]string, error) {
+	inboxURIs := []string{}
+
+	// Select the shared inbox of each known remote
+	// account, falling back to the personal inbox
+	// if account has no (or unknown) shared inbox.
+	q := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		ColumnExpr(
+			"DISTINCT COALESCE(NULLIF(?, ''), ?)",
+			bun.Ident("account.shared_inbox_uri"),
+			bun.Ident("account.inbox_uri"),
+		).
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Where("? IS NOT NULL", bun.Ident("account.inbox_uri")).
+		Where("? IS NULL", bun.Ident("account.suspended_at"))
+
+	if err := q.Scan(ctx, &inboxURIs); err != nil {
+		return nil, err
+	}
+
+	if len(inboxURIs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return inboxURIs, nil
+}
@@ -128,6 +128,21 @@ func (suite *InstanceTestSuite) TestGetInstanceModeratorAddressesNoAdmin() {
 	suite.Empty(addresses)
 }
 
+func (suite *InstanceTestSuite) TestGetInstanceInboxURIs() {
+	inboxURIs, err := suite.db.GetInstanceInboxURIs(context.Background())
+	suite.NoError(err)
+
+	// Shared inbox is preferred where known.
+	suite.Contains(inboxURIs, "http://fossbros-anonymous.io/inbox")
+	suite.NotContains(inboxURIs, "http://fossbros-anonymous.io/users/foss_satan/inbox")
+
+	// Personal inbox is used otherwise.
+	suite.Contains(inboxURIs, "http://example.org/users/Some_User/inbox")
+
+	// Local inboxes are never included.
+	suite.NotContains(inboxURIs, "http://localhost:8080/users/the_mighty_zork/inbox")
+}
+
 func TestInstanceTestSuite(t *testing.T) {
 	suite.Run(t, new(InstanceTestSuite))
 }
@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) putInvite(maxUses int, expiresAt time.Time) *gtsmodel.Invite {
+	invite := &gtsmodel.Invite{
+		ID:                 id.NewULID(),
+		Code:               id.NewULID(),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		MaxUses:            maxUses,
+		ExpiresAt:          expiresAt,
+	}
+
+	if err := suite.state.DB.PutInvite(context.Background(), invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return invite
+}
+
+func (suite *InviteTestSuite) TestUseInviteMaxUses() {
+	ctx := context.Background()
+	invite := suite.putInvite(2, time.Time{})
+
+	for i := 0; i < 2; i++ {
+		used, err := suite.state.DB.UseInvite(ctx, invite.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.True(used)
+	}
+
+	// Third time is not the charm.
+	used, err := suite.state.DB.UseInvite(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(used)
+
+	dbInvite, err := suite.state.DB.GetInviteByCode(ctx, invite.Code)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, dbInvite.Uses)
+	suite.True(dbInvite.UsedUp())
+}
+
+func (suite *InviteTestSuite) TestReleaseInvite() {
+	ctx := context.Background()
+	invite := suite.putInvite(1, time.Time{})
+
+	used, err := suite.state.DB.UseInvite(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(used)
+
+	// Releasing the use should
+	// make the invite usable again.
+	if err := suite.state.DB.ReleaseInvite(ctx, invite.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	used, err = suite.state.DB.UseInvite(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(used)
+}
+
+func (suite *InviteTestSuite) TestUseInviteUnlimited() {
+	ctx := context.Background()
+	invite := suite.putInvite(0, time.Now().Add(time.Hour))
+
+	for i := 0; i < 5; i++ {
+		used, err := suite.state.DB.UseInvite(ctx, invite.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.True(used)
+	}
+}
+
+func (suite *InviteTestSuite) TestUseInviteExpired() {
+	ctx := context.Background()
+	invite := suite.putInvite(0, time.Now().Add(-time.Minute))
+
+	used, err := suite.state.DB.UseInvite(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(used)
+
+	dbInvite, err := suite.state.DB.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbInvite.Uses)
+	suite.True(dbInvite.Expired())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
@@ -308,3 +308,21 @@ func (m *mediaDB) GetCachedAttachmentsOlderThan(ctx context.Context, olderThan t
 
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
+
+func (m *mediaDB) GetProcessingAttachments(ctx context.Context) ([]*gtsmodel.MediaAttachment, error) {
+	var attachmentIDs []string
+
+	q := m.db.
+		NewSelect().
+		Table("media_attachments").
+		Column("id").
+		Where("processing = ?", gtsmodel.ProcessingStatusProcessing).
+		Where("remote_url IS NULL").
+		Order("id ASC")
+
+	if err := q.Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
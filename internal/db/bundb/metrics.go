@@ -0,0 +1,198 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type metricsDB struct {
+	db *bun.DB
+}
+
+func (m *metricsDB) CountNewUsers(ctx context.Context, start time.Time, end time.Time) (int, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user"))
+	whereBetween(q, "user.created_at", start, end)
+	return q.Count(ctx)
+}
+
+func (m *metricsDB) CountActiveUsers(ctx context.Context, start time.Time, end time.Time) (int, error) {
+	var count int
+
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("COUNT(DISTINCT ?)", bun.Ident("status.account_id")).
+		Where("? = ?", bun.Ident("status.local"), true)
+	whereBetween(q, "status.created_at", start, end)
+
+	if err := q.Scan(ctx, &count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (m *metricsDB) CountInteractions(ctx context.Context, start time.Time, end time.Time) (int, error) {
+	// Count faves of statuses by local accounts.
+	faves := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		Where("? IN (?)", bun.Ident("status_fave.target_account_id"), m.localAccountIDs()).
+		Where("? != ?", bun.Ident("status_fave.account_id"), bun.Ident("status_fave.target_account_id"))
+	whereBetween(faves, "status_fave.created_at", start, end)
+
+	faveCount, err := faves.Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Count boosts of statuses by local accounts.
+	boosts := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? IN (?)", bun.Ident("status.boost_of_account_id"), m.localAccountIDs()).
+		Where("? != ?", bun.Ident("status.account_id"), bun.Ident("status.boost_of_account_id"))
+	whereBetween(boosts, "status.created_at", start, end)
+
+	boostCount, err := boosts.Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Count replies to local accounts. Self-replies
+	// (ie., threads) aren't really interactions.
+	replies := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? IN (?)", bun.Ident("status.in_reply_to_account_id"), m.localAccountIDs()).
+		Where("? != ?", bun.Ident("status.account_id"), bun.Ident("status.in_reply_to_account_id"))
+	whereBetween(replies, "status.created_at", start, end)
+
+	replyCount, err := replies.Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return faveCount + boostCount + replyCount, nil
+}
+
+func (m *metricsDB) CountOpenedReports(ctx context.Context, start time.Time, end time.Time) (int, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report"))
+	whereBetween(q, "report.created_at", start, end)
+	return q.Count(ctx)
+}
+
+func (m *metricsDB) CountResolvedReports(ctx context.Context, start time.Time, end time.Time) (int, error) {
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report"))
+	whereBetween(q, "report.action_taken_at", start, end)
+	return q.Count(ctx)
+}
+
+func (m *metricsDB) CountStatusesByLanguage(ctx context.Context, start time.Time, end time.Time, limit int) (map[string]int, error) {
+	var rows []struct {
+		Language string
+		Count    int
+	}
+
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.language"), bun.Ident("language")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? IS NOT NULL", bun.Ident("status.language")).
+		Group("status.language").
+		OrderExpr("? DESC", bun.Ident("count")).
+		Limit(limit)
+	whereBetween(q, "status.created_at", start, end)
+
+	if err := q.Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Language] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (m *metricsDB) CountStatusesByDomain(ctx context.Context, start time.Time, end time.Time, limit int) (map[string]int, error) {
+	var rows []struct {
+		Domain string
+		Count  int
+	}
+
+	q := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("status.account_id"),
+		).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("domain")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? = ?", bun.Ident("status.local"), false).
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Group("account.domain").
+		OrderExpr("? DESC", bun.Ident("count")).
+		Limit(limit)
+	whereBetween(q, "status.created_at", start, end)
+
+	if err := q.Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Domain] = row.Count
+	}
+
+	return counts, nil
+}
+
+// localAccountIDs returns a subquery
+// selecting the IDs of all local accounts.
+func (m *metricsDB) localAccountIDs() *bun.SelectQuery {
+	return m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		ColumnExpr("?", bun.Ident("account.id")).
+		Where("? IS NULL", bun.Ident("account.domain"))
+}
+
+// whereBetween limits the given query to rows where
+// column falls between start (inclusive) and end (exclusive).
+func whereBetween(q *bun.SelectQuery, column string, start time.Time, end time.Time) {
+	q.
+		Where("? >= ?", bun.Ident(column), start).
+		Where("? < ?", bun.Ident(column), end)
+}
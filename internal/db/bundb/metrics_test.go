@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MetricsTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+var (
+	// Covers all the test models.
+	metricsStart = testrig.TimeMustParse("2000-01-01T00:00:00Z")
+	metricsEnd   = time.Now().Add(time.Hour)
+)
+
+func (suite *MetricsTestSuite) TestCountNewUsers() {
+	count, err := suite.db.CountNewUsers(context.Background(), metricsStart, metricsEnd)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(suite.testUsers), count)
+
+	// Nobody signed up in the future.
+	count, err = suite.db.CountNewUsers(context.Background(), metricsEnd, metricsEnd.Add(time.Hour))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(count)
+}
+
+func (suite *MetricsTestSuite) TestCountActiveUsers() {
+	active := make(map[string]struct{})
+	for _, status := range suite.testStatuses {
+		if *status.Local {
+			active[status.AccountID] = struct{}{}
+		}
+	}
+
+	count, err := suite.db.CountActiveUsers(context.Background(), metricsStart, metricsEnd)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(active), count)
+}
+
+func (suite *MetricsTestSuite) TestCountReports() {
+	var resolved int
+	for _, report := range suite.testReports {
+		if !report.ActionTakenAt.IsZero() {
+			resolved++
+		}
+	}
+
+	opened, err := suite.db.CountOpenedReports(context.Background(), metricsStart, metricsEnd)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(suite.testReports), opened)
+
+	count, err := suite.db.CountResolvedReports(context.Background(), metricsStart, metricsEnd)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(resolved, count)
+}
+
+func (suite *MetricsTestSuite) TestCountInteractions() {
+	count, err := suite.db.CountInteractions(context.Background(), metricsStart, metricsEnd)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(count)
+}
+
+func (suite *MetricsTestSuite) TestCountStatusesByLanguage() {
+	expect := make(map[string]int)
+	for _, status := range suite.testStatuses {
+		if *status.Local && status.BoostOfID == "" && status.Language != "" {
+			expect[status.Language]++
+		}
+	}
+
+	counts, err := suite.db.CountStatusesByLanguage(context.Background(), metricsStart, metricsEnd, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(expect, counts)
+
+	// Only the top language.
+	counts, err = suite.db.CountStatusesByLanguage(context.Background(), metricsStart, metricsEnd, 1)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(counts, 1)
+}
+
+func (suite *MetricsTestSuite) TestCountStatusesByDomain() {
+	counts, err := suite.db.CountStatusesByDomain(context.Background(), metricsStart, metricsEnd, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(counts)
+	suite.NotContains(counts, "")
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Full text search is implemented
+			// differently by SQLite and Postgres,
+			// so the search table differs too.
+			switch d := tx.Dialect().Name(); d {
+
+			case dialect.SQLite:
+				// FTS5 virtual table, indexing text only.
+				if _, err := tx.ExecContext(ctx,
+					"CREATE VIRTUAL TABLE IF NOT EXISTS ? USING fts5(?, ? UNINDEXED, ? UNINDEXED, tokenize = 'unicode61 remove_diacritics 2')",
+					bun.Ident("status_search_entries"),
+					bun.Ident("text"),
+					bun.Ident("status_id"),
+					bun.Ident("account_id"),
+				); err != nil {
+					return err
+				}
+
+			case dialect.PG:
+				// Regular table with a generated tsvector
+				// column, indexed with GIN for text search.
+				if _, err := tx.ExecContext(ctx,
+					"CREATE TABLE IF NOT EXISTS ? ("+
+						"? CHAR(26) NOT NULL, "+
+						"? CHAR(26) NOT NULL, "+
+						"? TEXT NOT NULL, "+
+						"? TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', ?)) STORED, "+
+						"PRIMARY KEY (?, ?))",
+					bun.Ident("status_search_entries"),
+					bun.Ident("status_id"),
+					bun.Ident("account_id"),
+					bun.Ident("text"),
+					bun.Ident("text_tsv"),
+					bun.Ident("text"),
+					bun.Ident("account_id"),
+					bun.Ident("status_id"),
+				); err != nil {
+					return err
+				}
+
+				if _, err := tx.
+					NewCreateIndex().
+					Table("status_search_entries").
+					Index("status_search_entries_text_tsv_idx").
+					Using("GIN").
+					Column("text_tsv").
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+
+				// Eg., delete all entries for a status.
+				if _, err := tx.
+					NewCreateIndex().
+					Table("status_search_entries").
+					Index("status_search_entries_status_id_idx").
+					Column("status_id").
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+
+			default:
+				log.Panicf(ctx, "db conn %s was neither pg nor sqlite", d)
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
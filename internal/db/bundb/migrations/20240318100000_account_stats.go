@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create account stats table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.AccountStats{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index account stats by regeneration time,
+			// used when periodically regenerating stats.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("account_stats").
+				Index("account_stats_regenerated_at_idx").
+				Column("regenerated_at").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Backfill stats for all existing accounts.
+			if _, err := tx.NewRaw(
+				"INSERT INTO ? (?, ?, ?, ?, ?, ?) "+
+					"SELECT ?, ?, "+
+					"(SELECT COUNT(*) FROM ? WHERE ? = ?), "+
+					"(SELECT COUNT(*) FROM ? WHERE ? = ?), "+
+					"(SELECT COUNT(*) FROM ? WHERE ? = ?), "+
+					"(SELECT MAX(?) FROM ? WHERE ? = ?) "+
+					"FROM ? AS ?",
+				bun.Ident("account_stats"),
+				bun.Ident("account_id"),
+				bun.Ident("regenerated_at"),
+				bun.Ident("followers_count"),
+				bun.Ident("following_count"),
+				bun.Ident("statuses_count"),
+				bun.Ident("last_status_at"),
+				bun.Ident("account.id"),
+				time.Now(),
+				bun.Ident("follows"), bun.Ident("follows.target_account_id"), bun.Ident("account.id"),
+				bun.Ident("follows"), bun.Ident("follows.account_id"), bun.Ident("account.id"),
+				bun.Ident("statuses"), bun.Ident("statuses.account_id"), bun.Ident("account.id"),
+				bun.Ident("statuses.created_at"), bun.Ident("statuses"), bun.Ident("statuses.account_id"), bun.Ident("account.id"),
+				bun.Ident("accounts"), bun.Ident("account"),
+			).Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create notification requests
+			// and notification permissions tables.
+			for _, model := range []any{
+				&gtsmodel.NotificationRequest{},
+				&gtsmodel.NotificationPermission{},
+			} {
+				if _, err := tx.
+					NewCreateTable().
+					Model(model).
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Add filtered column
+			// to notifications table.
+			if _, err := tx.
+				NewAddColumn().
+				Table("notifications").
+				ColumnExpr("? BOOLEAN NOT NULL DEFAULT ?", bun.Ident("filtered"), false).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add notification policy
+			// columns to accounts table.
+			for _, column := range []string{
+				"notifications_filter_not_following",
+				"notifications_filter_new_accounts",
+			} {
+				if _, err := tx.
+					NewAddColumn().
+					Table("accounts").
+					ColumnExpr("? BOOLEAN DEFAULT ?", bun.Ident(column), false).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	// replaceUserScope replaces the legacy "user" scope,
+	// previously requested by the settings panel but never
+	// actually enforced, with the equivalent broad scopes.
+	replaceUserScope := func(scopes string) string {
+		var fields []string
+		for _, scope := range strings.Fields(scopes) {
+			if scope == "user" {
+				fields = append(fields, "read", "write")
+				continue
+			}
+			fields = append(fields, scope)
+		}
+		return strings.Join(fields, " ")
+	}
+
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, t := range []struct {
+				table  string
+				column string
+			}{
+				{table: "tokens", column: "scope"},
+				{table: "applications", column: "scopes"},
+			} {
+				var rows []struct {
+					ID     string `bun:"id"`
+					Scopes string `bun:"scopes"`
+				}
+
+				if err := tx.NewSelect().
+					Table(t.table).
+					Column("id").
+					ColumnExpr("? AS ?", bun.Ident(t.column), bun.Ident("scopes")).
+					Where("? LIKE ?", bun.Ident(t.column), "%user%").
+					Scan(ctx, &rows); err != nil {
+					return err
+				}
+
+				for _, row := range rows {
+					scopes := replaceUserScope(row.Scopes)
+					if scopes == row.Scopes {
+						// Just matched
+						// "user" in eg.,
+						// "read:user".
+						continue
+					}
+
+					if _, err := tx.NewUpdate().
+						Table(t.table).
+						Set("? = ?", bun.Ident(t.column), scopes).
+						Where("? = ?", bun.Ident("id"), row.ID).
+						Exec(ctx); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
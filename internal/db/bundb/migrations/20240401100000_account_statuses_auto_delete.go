@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add statuses auto-delete
+			// columns to the accounts table.
+			for _, column := range []struct {
+				name string
+				expr string
+				def  any
+			}{
+				{name: "statuses_auto_delete_after_days", expr: "? INTEGER NOT NULL DEFAULT ?", def: 0},
+				{name: "statuses_auto_delete_keep_pinned", expr: "? BOOLEAN DEFAULT ?", def: true},
+				{name: "statuses_auto_delete_keep_bookmarked", expr: "? BOOLEAN DEFAULT ?", def: true},
+				{name: "statuses_auto_delete_keep_direct", expr: "? BOOLEAN DEFAULT ?", def: true},
+			} {
+				if _, err := tx.
+					NewAddColumn().
+					Table("accounts").
+					ColumnExpr(column.expr, bun.Ident(column.name), column.def).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,151 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Select all poll votes, oldest first,
+			// so the earliest vote by an account in
+			// a poll is the one that gets kept.
+			var votes []*gtsmodel.PollVote
+			if err := tx.NewSelect().
+				Model(&votes).
+				Column("id", "choices", "account_id", "poll_id").
+				Order("created_at ASC", "id ASC").
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			type key struct{ pollID, accountID string }
+			kept := make(map[key]struct{}, len(votes))
+			byPoll := make(map[string][]*gtsmodel.PollVote)
+
+			for _, vote := range votes {
+				k := key{vote.PollID, vote.AccountID}
+				if _, ok := kept[k]; ok {
+					// Duplicate vote by account
+					// in this poll, drop it.
+					if _, err := tx.NewDelete().
+						Table("poll_votes").
+						Where("? = ?", bun.Ident("id"), vote.ID).
+						Exec(ctx); err != nil {
+						return err
+					}
+					continue
+				}
+				kept[k] = struct{}{}
+
+				// Deduplicate choices within the vote.
+				choices := util.Deduplicate(vote.Choices)
+				if len(choices) != len(vote.Choices) {
+					vote.Choices = choices
+					if _, err := tx.NewUpdate().
+						Model(vote).
+						Column("choices").
+						Where("? = ?", bun.Ident("id"), vote.ID).
+						Exec(ctx); err != nil {
+						return err
+					}
+				}
+
+				byPoll[vote.PollID] = append(byPoll[vote.PollID], vote)
+			}
+
+			// Select local polls, whose counts we
+			// keep ourselves from the votes above.
+			// Remote poll counts come from origin.
+			var polls []*gtsmodel.Poll
+			if err := tx.NewSelect().
+				Model(&polls).
+				Column("poll.id", "poll.options", "poll.votes", "poll.voters").
+				Join(
+					"JOIN ? AS ? ON ? = ?",
+					bun.Ident("statuses"), bun.Ident("status"),
+					bun.Ident("status.id"), bun.Ident("poll.status_id"),
+				).
+				Where("? = ?", bun.Ident("status.local"), true).
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			for _, poll := range polls {
+				// Recompute counts from stored votes.
+				counts := make([]int, len(poll.Options))
+				voters := 0
+				for _, vote := range byPoll[poll.ID] {
+					for _, choice := range vote.Choices {
+						if choice >= 0 && choice < len(counts) {
+							counts[choice]++
+						}
+					}
+					voters++
+				}
+
+				if slices.Equal(counts, poll.Votes) &&
+					poll.Voters != nil && *poll.Voters == voters {
+					// Counts already correct.
+					continue
+				}
+
+				poll.Votes = counts
+				poll.Voters = &voters
+				if _, err := tx.NewUpdate().
+					Model(poll).
+					Column("votes", "voters").
+					Where("? = ?", bun.Ident("id"), poll.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Ensure an account can only vote once per poll,
+			// for databases created before this was enforced.
+			if _, err := tx.NewCreateIndex().
+				Table("poll_votes").
+				Index("poll_votes_poll_id_account_id_idx").
+				Column("poll_id", "account_id").
+				Unique().
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
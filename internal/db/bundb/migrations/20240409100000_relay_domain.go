@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add domain column
+			// to the relays table.
+			if _, err := tx.
+				NewAddColumn().
+				Table("relays").
+				ColumnExpr("? VARCHAR", bun.Ident("domain")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Backfill domain of existing
+			// relays from their inbox URIs.
+			var relays []struct {
+				ID       string `bun:"id"`
+				InboxURI string `bun:"inbox_uri"`
+			}
+
+			if err := tx.NewSelect().
+				Table("relays").
+				Column("id", "inbox_uri").
+				Scan(ctx, &relays); err != nil {
+				return err
+			}
+
+			for _, relay := range relays {
+				inbox, err := url.Parse(relay.InboxURI)
+				if err != nil {
+					// Inbox URIs are validated
+					// on creation, just skip.
+					continue
+				}
+
+				if _, err := tx.NewUpdate().
+					Table("relays").
+					Set("? = ?", bun.Ident("domain"), inbox.Host).
+					Where("? = ?", bun.Ident("id"), relay.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Index relays by domain, looked up
+			// for incoming announces from relay
+			// actors we don't yet (or no longer)
+			// have an accepted follow with.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("relays").
+				Index("relays_domain_idx").
+				Column("domain").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Tokens issued through client credentials
+			// without a scope, before scopes were enforced,
+			// were stored with an empty scope. Grant these
+			// the scopes registered for their application,
+			// so that they keep working as they did before.
+			var tokens []struct {
+				ID       string `bun:"id"`
+				ClientID string `bun:"client_id"`
+			}
+
+			if err := tx.NewSelect().
+				Table("tokens").
+				Column("id", "client_id").
+				WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? IS NULL", bun.Ident("scope")).
+						WhereOr("? = ''", bun.Ident("scope"))
+				}).
+				Scan(ctx, &tokens); err != nil {
+				return err
+			}
+
+			// Cache app scopes by client ID, as
+			// one app may have issued many tokens.
+			appScopes := make(map[string]string)
+
+			for _, token := range tokens {
+				scopes, ok := appScopes[token.ClientID]
+				if !ok {
+					if err := tx.NewSelect().
+						Table("applications").
+						Column("scopes").
+						Where("? = ?", bun.Ident("client_id"), token.ClientID).
+						Limit(1).
+						Scan(ctx, &scopes); err != nil && !errors.Is(err, sql.ErrNoRows) {
+						return err
+					}
+
+					if strings.TrimSpace(scopes) == "" {
+						// Same as the default
+						// for new tokens.
+						scopes = "read"
+					}
+
+					appScopes[token.ClientID] = scopes
+				}
+
+				if _, err := tx.NewUpdate().
+					Table("tokens").
+					Set("? = ?", bun.Ident("scope"), scopes).
+					Where("? = ?", bun.Ident("id"), token.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -202,6 +202,33 @@ func (n *notificationDB) PopulateNotification(ctx context.Context, notif *gtsmod
 	return errs.Combine()
 }
 
+func (n *notificationDB) CountAccountNotificationsSince(
+	ctx context.Context,
+	accountID string,
+	sinceID string,
+	limit int,
+) (int, error) {
+	// Select only IDs of notifs for this account HIGHER (ie., newer)
+	// than sinceID, limited so that the (target_account_id, id) index
+	// can be used and we don't end up counting a huge amount of rows.
+	q := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.id").
+		Where("? = ?", bun.Ident("notification.target_account_id"), accountID).
+		Where("? = ?", bun.Ident("notification.filtered"), false).
+		Where("? > ?", bun.Ident("notification.id"), sinceID)
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	return n.db.
+		NewSelect().
+		TableExpr("(?) AS ?", q, bun.Ident("unread")).
+		Count(ctx)
+}
+
 func (n *notificationDB) GetAccountNotifications(
 	ctx context.Context,
 	accountID string,
@@ -254,6 +281,9 @@ func (n *notificationDB) GetAccountNotifications(
 	// Return only notifs for this account.
 	q = q.Where("? = ?", bun.Ident("notification.target_account_id"), accountID)
 
+	// Leave out notifs held in notification requests.
+	q = q.Where("? = ?", bun.Ident("notification.filtered"), false)
+
 	if limit > 0 {
 		q = q.Limit(limit)
 	}
@@ -370,6 +400,63 @@ func (n *notificationDB) DeleteNotifications(ctx context.Context, types []string
 	return err
 }
 
+func (n *notificationDB) UnfilterNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	notifIDs, err := n.getFilteredNotificationIDs(ctx, targetAccountID, originAccountID)
+	if err != nil {
+		return err
+	}
+
+	if len(notifIDs) == 0 {
+		return nil
+	}
+
+	defer func() {
+		// Invalidate all IDs on return.
+		for _, id := range notifIDs {
+			n.state.Caches.GTS.Notification.Invalidate("ID", id)
+		}
+	}()
+
+	_, err = n.db.NewUpdate().
+		Table("notifications").
+		Set("? = ?", bun.Ident("filtered"), false).
+		Where("? IN (?)", bun.Ident("id"), bun.In(notifIDs)).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationDB) DeleteFilteredNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	notifIDs, err := n.getFilteredNotificationIDs(ctx, targetAccountID, originAccountID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range notifIDs {
+		if err := n.DeleteNotificationByID(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *notificationDB) getFilteredNotificationIDs(ctx context.Context, targetAccountID string, originAccountID string) ([]string, error) {
+	var notifIDs []string
+
+	if err := n.db.
+		NewSelect().
+		Column("id").
+		Table("notifications").
+		Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
+		Where("? = ?", bun.Ident("origin_account_id"), originAccountID).
+		Where("? = ?", bun.Ident("filtered"), true).
+		Scan(ctx, &notifIDs); err != nil {
+		return nil, err
+	}
+
+	return notifIDs, nil
+}
+
 func (n *notificationDB) DeleteNotificationsForStatus(ctx context.Context, statusID string) error {
 	var notifIDs []string
 
@@ -114,6 +114,21 @@ func (suite *NotificationTestSuite) TestGetAccountNotificationsWithoutSpam() {
 	}
 }
 
+func (suite *NotificationTestSuite) TestCountAccountNotificationsSince() {
+	suite.spamNotifs()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Count should be capped at the given limit.
+	count, err := suite.db.CountAccountNotificationsSince(context.Background(), testAccount.ID, id.Lowest, 100)
+	suite.NoError(err)
+	suite.Equal(100, count)
+
+	// Nothing should be newer than the highest ID.
+	count, err = suite.db.CountAccountNotificationsSince(context.Background(), testAccount.ID, id.Highest, 100)
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
 func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 	suite.spamNotifs()
 	testAccount := suite.testAccounts["local_account_1"]
@@ -0,0 +1,276 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type notificationRequestDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (n *notificationRequestDB) GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(request *gtsmodel.NotificationRequest) error {
+		return n.db.
+			NewSelect().
+			Model(request).
+			Where("? = ?", bun.Ident("notification_request.id"), id).
+			Scan(ctx)
+	})
+}
+
+func (n *notificationRequestDB) GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(request *gtsmodel.NotificationRequest) error {
+		return n.db.
+			NewSelect().
+			Model(request).
+			Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+			Where("? = ?", bun.Ident("notification_request.from_account_id"), fromAccountID).
+			Scan(ctx)
+	})
+}
+
+func (n *notificationRequestDB) getNotificationRequest(ctx context.Context, dbQuery func(*gtsmodel.NotificationRequest) error) (*gtsmodel.NotificationRequest, error) {
+	var request gtsmodel.NotificationRequest
+
+	if err := dbQuery(&request); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &request, nil
+	}
+
+	if err := n.populateNotificationRequest(ctx, &request); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func (n *notificationRequestDB) populateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error {
+	var (
+		err  error
+		errs gtserror.MultiError
+	)
+
+	if request.Account == nil {
+		// Request account is not set, fetch from the database.
+		request.Account, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			request.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating request account: %w", err)
+		}
+	}
+
+	if request.FromAccount == nil {
+		// Request from account is not set, fetch from the database.
+		request.FromAccount, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			request.FromAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating request from account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (n *notificationRequestDB) GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		ids = make([]string, 0, limit)
+	)
+
+	query := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		Column("notification_request.id").
+		Where("? = ?", bun.Ident("notification_request.account_id"), accountID)
+
+	if maxID != "" {
+		query = query.Where("? < ?", bun.Ident("notification_request.id"), maxID)
+	}
+
+	if minID != "" {
+		query = query.Where("? > ?", bun.Ident("notification_request.id"), minID)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if order.Ascending() {
+		// Page up.
+		query = query.Order("notification_request.id ASC")
+	} else {
+		// Page down.
+		query = query.Order("notification_request.id DESC")
+	}
+
+	if err := query.Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want requests
+	// to be sorted by ID desc, so reverse ids slice.
+	if order.Ascending() {
+		slices.Reverse(ids)
+	}
+
+	requests := make([]*gtsmodel.NotificationRequest, 0, len(ids))
+
+	for _, id := range ids {
+		request, err := n.GetNotificationRequestByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting notification request %q: %v", id, err)
+			continue
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+func (n *notificationRequestDB) CountAccountNotificationRequests(ctx context.Context, accountID string) (int, int, error) {
+	var row struct {
+		Requests      int
+		Notifications int
+	}
+
+	if err := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("requests")).
+		ColumnExpr("COALESCE(SUM(?), 0) AS ?", bun.Ident("notification_request.notifications_count"), bun.Ident("notifications")).
+		Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+		Scan(ctx, &row); err != nil {
+		return 0, 0, err
+	}
+
+	return row.Requests, row.Notifications, nil
+}
+
+func (n *notificationRequestDB) PutNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error {
+	_, err := n.db.
+		NewInsert().
+		Model(request).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationRequestDB) UpdateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest, columns ...string) error {
+	_, err := n.db.
+		NewUpdate().
+		Model(request).
+		Column(columns...).
+		Where("? = ?", bun.Ident("notification_request.id"), request.ID).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationRequestDB) IncrementNotificationRequest(ctx context.Context, id string) error {
+	// Increment in the database rather than on the model,
+	// so concurrent notifications don't lose counts.
+	_, err := n.db.
+		NewUpdate().
+		Table("notification_requests").
+		Set("? = ? + 1",
+			bun.Ident("notifications_count"),
+			bun.Ident("notifications_count"),
+		).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationRequestDB) DeleteNotificationRequestByID(ctx context.Context, id string) error {
+	_, err := n.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("notification_requests"), bun.Ident("notification_request")).
+		Where("? = ?", bun.Ident("notification_request.id"), id).
+		Exec(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (n *notificationRequestDB) IsNotificationPermitted(ctx context.Context, accountID string, fromAccountID string) (bool, error) {
+	return n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notification_permissions"), bun.Ident("notification_permission")).
+		Where("? = ?", bun.Ident("notification_permission.account_id"), accountID).
+		Where("? = ?", bun.Ident("notification_permission.from_account_id"), fromAccountID).
+		Exists(ctx)
+}
+
+func (n *notificationRequestDB) PutNotificationPermission(ctx context.Context, permission *gtsmodel.NotificationPermission) error {
+	_, err := n.db.
+		NewInsert().
+		Model(permission).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("account_id"), bun.Ident("from_account_id")).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationRequestDB) DeleteAccountNotificationRequests(ctx context.Context, accountID string) error {
+	return n.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, table := range []string{
+			"notification_requests",
+			"notification_permissions",
+		} {
+			if _, err := tx.
+				NewDelete().
+				Table(table).
+				WhereOr("? = ?", bun.Ident("account_id"), accountID).
+				WhereOr("? = ?", bun.Ident("from_account_id"), accountID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,193 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type quarantinedStatusDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (q *quarantinedStatusDB) GetQuarantinedStatusByID(ctx context.Context, id string) (*gtsmodel.QuarantinedStatus, error) {
+	var status gtsmodel.QuarantinedStatus
+
+	if err := q.db.
+		NewSelect().
+		Model(&status).
+		Where("? = ?", bun.Ident("quarantined_status.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &status, nil
+	}
+
+	if err := q.populateQuarantinedStatus(ctx, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func (q *quarantinedStatusDB) populateQuarantinedStatus(ctx context.Context, status *gtsmodel.QuarantinedStatus) error {
+	var (
+		err  error
+		errs gtserror.MultiError
+	)
+
+	if status.Account == nil {
+		// Status account is not set, fetch from the database.
+		status.Account, err = q.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status account: %w", err)
+		}
+	}
+
+	if status.ReceivingAccount == nil {
+		// Status receiving account is not set, fetch from the database.
+		status.ReceivingAccount, err = q.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.ReceivingAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status receiving account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (q *quarantinedStatusDB) GetQuarantinedStatuses(ctx context.Context, page *paging.Page) ([]*gtsmodel.QuarantinedStatus, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		ids = make([]string, 0, limit)
+	)
+
+	query := q.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("quarantined_statuses"), bun.Ident("quarantined_status")).
+		Column("quarantined_status.id")
+
+	if maxID != "" {
+		query = query.Where("? < ?", bun.Ident("quarantined_status.id"), maxID)
+	}
+
+	if minID != "" {
+		query = query.Where("? > ?", bun.Ident("quarantined_status.id"), minID)
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if order.Ascending() {
+		// Page up.
+		query = query.Order("quarantined_status.id ASC")
+	} else {
+		// Page down.
+		query = query.Order("quarantined_status.id DESC")
+	}
+
+	if err := query.Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want statuses
+	// to be sorted by ID desc, so reverse ids slice.
+	if order.Ascending() {
+		slices.Reverse(ids)
+	}
+
+	statuses := make([]*gtsmodel.QuarantinedStatus, 0, len(ids))
+
+	for _, id := range ids {
+		status, err := q.GetQuarantinedStatusByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting quarantined status %q: %v", id, err)
+			continue
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (q *quarantinedStatusDB) PutQuarantinedStatus(ctx context.Context, status *gtsmodel.QuarantinedStatus) error {
+	_, err := q.db.
+		NewInsert().
+		Model(status).
+		Exec(ctx)
+	return err
+}
+
+func (q *quarantinedStatusDB) DeleteQuarantinedStatusByID(ctx context.Context, id string) error {
+	_, err := q.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("quarantined_statuses"), bun.Ident("quarantined_status")).
+		Where("? = ?", bun.Ident("quarantined_status.id"), id).
+		Exec(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (q *quarantinedStatusDB) DeleteQuarantinedStatusesOlderThan(ctx context.Context, olderThan time.Time) (int, error) {
+	res, err := q.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("quarantined_statuses"), bun.Ident("quarantined_status")).
+		Where("? < ?", bun.Ident("quarantined_status.created_at"), olderThan).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rows), nil
+}
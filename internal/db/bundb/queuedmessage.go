@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type queuedMessageDB struct {
+	db *bun.DB
+}
+
+func (q *queuedMessageDB) PutQueuedMessage(ctx context.Context, msg *gtsmodel.QueuedMessage) error {
+	_, err := q.db.
+		NewInsert().
+		Model(msg).
+		Exec(ctx)
+	return err
+}
+
+func (q *queuedMessageDB) DeleteQueuedMessageByID(ctx context.Context, id string) error {
+	_, err := q.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("queued_messages"), bun.Ident("queued_message")).
+		Where("? = ?", bun.Ident("queued_message.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (q *queuedMessageDB) GetQueuedMessages(ctx context.Context) ([]*gtsmodel.QueuedMessage, error) {
+	var msgs []*gtsmodel.QueuedMessage
+
+	if err := q.db.
+		NewSelect().
+		Model(&msgs).
+		// IDs are ULIDs, so
+		// this is oldest first.
+		Order("queued_message.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
@@ -66,14 +66,23 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 	}
 
 	// check if requesting has follow requested target
-	rel.Requested, err = r.IsFollowRequested(ctx,
+	followReq, err := r.GetFollowRequest(
+		gtscontext.SetBarebones(ctx),
 		requestingAccount,
 		targetAccount,
 	)
-	if err != nil {
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.Newf("error checking requested: %w", err)
 	}
 
+	if followReq != nil {
+		// follow request exists, so show the
+		// preferences that the follow will have.
+		rel.Requested = true
+		rel.ShowingReblogs = *followReq.ShowReblogs
+		rel.Notifying = *followReq.Notify
+	}
+
 	// check if target has follow requested requesting
 	rel.RequestedBy, err = r.IsFollowRequested(ctx,
 		targetAccount,
@@ -111,6 +120,137 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 	return &rel, nil
 }
 
+func (r *relationshipDB) GetRelationships(ctx context.Context, requestingAccount string, targetAccounts []string) ([]*gtsmodel.Relationship, error) {
+	// Prepare a relationship for each target, keyed
+	// by ID so results below can be slotted in.
+	rels := make([]*gtsmodel.Relationship, len(targetAccounts))
+	relsByID := make(map[string]*gtsmodel.Relationship, len(targetAccounts))
+	for i, targetAccount := range targetAccounts {
+		rel, ok := relsByID[targetAccount]
+		if !ok {
+			rel = &gtsmodel.Relationship{ID: targetAccount}
+			relsByID[targetAccount] = rel
+		}
+		rels[i] = rel
+	}
+
+	if len(relsByID) == 0 {
+		return rels, nil
+	}
+
+	// check which targets the requesting follows
+	var follows []*gtsmodel.Follow
+	if err := r.db.NewSelect().
+		Model(&follows).
+		Where("? = ?", bun.Ident("account_id"), requestingAccount).
+		Where("? IN (?)", bun.Ident("target_account_id"), bun.In(targetAccounts)).
+		Scan(ctx); err != nil {
+		return nil, gtserror.Newf("error fetching follows: %w", err)
+	}
+
+	for _, follow := range follows {
+		rel := relsByID[follow.TargetAccountID]
+		rel.Following = true
+		rel.ShowingReblogs = *follow.ShowReblogs
+		rel.Notifying = *follow.Notify
+	}
+
+	// check which targets follow the requesting
+	var followedBy []string
+	if err := r.db.NewSelect().
+		Table("follows").
+		Column("account_id").
+		Where("? = ?", bun.Ident("target_account_id"), requestingAccount).
+		Where("? IN (?)", bun.Ident("account_id"), bun.In(targetAccounts)).
+		Scan(ctx, &followedBy); err != nil {
+		return nil, gtserror.Newf("error checking followedBy: %w", err)
+	}
+
+	for _, id := range followedBy {
+		relsByID[id].FollowedBy = true
+	}
+
+	// check which targets the requesting has follow requested
+	var followReqs []*gtsmodel.FollowRequest
+	if err := r.db.NewSelect().
+		Model(&followReqs).
+		Where("? = ?", bun.Ident("account_id"), requestingAccount).
+		Where("? IN (?)", bun.Ident("target_account_id"), bun.In(targetAccounts)).
+		Scan(ctx); err != nil {
+		return nil, gtserror.Newf("error checking requested: %w", err)
+	}
+
+	for _, followReq := range followReqs {
+		// follow request exists, so show the
+		// preferences that the follow will have.
+		rel := relsByID[followReq.TargetAccountID]
+		rel.Requested = true
+		rel.ShowingReblogs = *followReq.ShowReblogs
+		rel.Notifying = *followReq.Notify
+	}
+
+	// check which targets have follow requested the requesting
+	var requestedBy []string
+	if err := r.db.NewSelect().
+		Table("follow_requests").
+		Column("account_id").
+		Where("? = ?", bun.Ident("target_account_id"), requestingAccount).
+		Where("? IN (?)", bun.Ident("account_id"), bun.In(targetAccounts)).
+		Scan(ctx, &requestedBy); err != nil {
+		return nil, gtserror.Newf("error checking requestedBy: %w", err)
+	}
+
+	for _, id := range requestedBy {
+		relsByID[id].RequestedBy = true
+	}
+
+	// check which targets the requesting account is blocking
+	var blocking []string
+	if err := r.db.NewSelect().
+		Table("blocks").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), requestingAccount).
+		Where("? IN (?)", bun.Ident("target_account_id"), bun.In(targetAccounts)).
+		Scan(ctx, &blocking); err != nil {
+		return nil, gtserror.Newf("error checking blocking: %w", err)
+	}
+
+	for _, id := range blocking {
+		relsByID[id].Blocking = true
+	}
+
+	// check which targets are blocking the requesting account
+	var blockedBy []string
+	if err := r.db.NewSelect().
+		Table("blocks").
+		Column("account_id").
+		Where("? = ?", bun.Ident("target_account_id"), requestingAccount).
+		Where("? IN (?)", bun.Ident("account_id"), bun.In(targetAccounts)).
+		Scan(ctx, &blockedBy); err != nil {
+		return nil, gtserror.Newf("error checking blockedBy: %w", err)
+	}
+
+	for _, id := range blockedBy {
+		relsByID[id].BlockedBy = true
+	}
+
+	// retrieve notes by the requesting account on the targets
+	var notes []*gtsmodel.AccountNote
+	if err := r.db.NewSelect().
+		Model(&notes).
+		Where("? = ?", bun.Ident("account_id"), requestingAccount).
+		Where("? IN (?)", bun.Ident("target_account_id"), bun.In(targetAccounts)).
+		Scan(ctx); err != nil {
+		return nil, gtserror.Newf("error fetching notes: %w", err)
+	}
+
+	for _, note := range notes {
+		relsByID[note.TargetAccountID].Note = note.Comment
+	}
+
+	return rels, nil
+}
+
 func (r *relationshipDB) GetAccountFollows(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Follow, error) {
 	followIDs, err := r.getAccountFollowIDs(ctx, accountID, page)
 	if err != nil {
@@ -532,6 +532,31 @@ func (suite *RelationshipTestSuite) TestGetRelationship() {
 	suite.Empty(relationship.Note)
 }
 
+func (suite *RelationshipTestSuite) TestGetRelationships() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccountIDs := []string{
+		suite.testAccounts["admin_account"].ID,
+		"01HZZZZZZZZZZZZZZZZZZZZZZZ", // doesn't exist
+		suite.testAccounts["local_account_2"].ID,
+		suite.testAccounts["admin_account"].ID, // repeated
+	}
+
+	relationships, err := suite.db.GetRelationships(context.Background(), requestingAccount.ID, targetAccountIDs)
+	suite.NoError(err)
+	suite.Len(relationships, len(targetAccountIDs))
+
+	// Each relationship should be in input order,
+	// and match what we'd get one at a time.
+	for i, targetAccountID := range targetAccountIDs {
+		expect, err := suite.db.GetRelationship(context.Background(), requestingAccount.ID, targetAccountID)
+		suite.NoError(err)
+		suite.Equal(expect, relationships[i])
+	}
+
+	// Unknown account should have nothing set.
+	suite.Equal(&gtsmodel.Relationship{ID: targetAccountIDs[1]}, relationships[1])
+}
+
 func (suite *RelationshipTestSuite) TestIsFollowingYes() {
 	requestingAccount := suite.testAccounts["local_account_1"]
 	targetAccount := suite.testAccounts["admin_account"]
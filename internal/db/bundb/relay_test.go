@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type RelayTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *RelayTestSuite) TestPutUpdateDeleteRelay() {
+	ctx := context.Background()
+
+	relay := &gtsmodel.Relay{
+		ID:        id.NewULID(),
+		InboxURI:  "https://relay.example.org/inbox",
+		FollowURI: "http://localhost:8080/users/localhost:8080/follow/01HRTH4MGZZ9BTFJ9D8Q5CE5DJ",
+		State:     gtsmodel.RelayStatePending,
+	}
+
+	if err := suite.state.DB.PutRelay(ctx, relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbRelay, err := suite.state.DB.GetRelayByFollowURI(ctx, relay.FollowURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(relay.ID, dbRelay.ID)
+	suite.Equal(gtsmodel.RelayStatePending, dbRelay.State)
+	suite.Empty(dbRelay.AccountID)
+
+	// Accept the relay follow.
+	relay.State = gtsmodel.RelayStateAccepted
+	relay.AccountID = suite.testAccounts["remote_account_1"].ID
+	if err := suite.state.DB.UpdateRelay(ctx, relay, "state", "account_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbRelay, err = suite.state.DB.GetRelayByAccountID(ctx, relay.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(relay.ID, dbRelay.ID)
+	suite.Equal(gtsmodel.RelayStateAccepted, dbRelay.State)
+
+	relays, err := suite.state.DB.GetRelays(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(relays, 1)
+
+	if err := suite.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.state.DB.GetRelayByInboxURI(ctx, relay.InboxURI)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+)
+
+// pgReplicaConn opens a connection pool to the configured postgres
+// read replica. This shares all connection options with the primary
+// database except for the address and port. All transactions on the
+// replica default to read-only, so a write routed here by mistake
+// errors out instead of quietly going to the wrong place.
+func pgReplicaConn(ctx context.Context) (*bun.DB, error) {
+	opts, err := deriveBunDBPGOptions() //nolint:contextcheck
+	if err != nil {
+		return nil, fmt.Errorf("could not create bundb postgres replica options: %w", err)
+	}
+
+	address := config.GetDbReplicaAddress()
+	opts.Host = address
+	if port := config.GetDbReplicaPort(); port > 0 {
+		opts.Port = uint16(port)
+	}
+
+	if opts.TLSConfig != nil && opts.TLSConfig.ServerName != "" {
+		// Verify against the replica's
+		// hostname, not the primary's.
+		opts.TLSConfig = opts.TLSConfig.Clone()
+		opts.TLSConfig.ServerName = address
+	}
+
+	opts.RuntimeParams["default_transaction_read_only"] = "on"
+
+	db, err := pgOpen(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("postgres replica: %w", err)
+	}
+
+	log.Infof(ctx, "connected to POSTGRES read replica at %s", address)
+	return db, nil
+}
+
+// readDB returns the read replica connection pool when one is
+// configured and the context has been hinted as tolerating slightly
+// stale results (see gtscontext.SetReplicaRead), else the primary.
+//
+// Only queries whose results aren't cached should be routed through
+// here, as a stale row read from a lagging replica could otherwise
+// stick around in the cache long after the primary was updated.
+func readDB(ctx context.Context, primary *bun.DB, replica *bun.DB) *bun.DB {
+	if replica != nil && gtscontext.ReplicaRead(ctx) {
+		return replica
+	}
+	return primary
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -57,8 +58,9 @@ import (
 // This isn't ideal, of course, but at least we could cover the most common use case of
 // a caller paging down through results.
 type searchDB struct {
-	db    *bun.DB
-	state *state.State
+	db      *bun.DB
+	replica *bun.DB
+	state   *state.State
 }
 
 // Query example (SQLite):
@@ -90,7 +92,7 @@ func (s *searchDB) SearchForAccounts(
 		frontToBack = true
 	)
 
-	q := s.db.
+	q := readDB(ctx, s.db, s.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
 		// Select only IDs from table.
@@ -138,7 +140,7 @@ func (s *searchDB) SearchForAccounts(
 		// Query looks like arbitrary string.
 		// Search using LIKE for matches of query
 		// string within accountText subquery.
-		subQ := s.accountText(following)
+		subQ := s.accountText()
 		q = whereLike(q, subQ, query)
 	}
 
@@ -199,60 +201,37 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 }
 
 // accountText returns a subquery that selects a concatenation
-// of account username and display name as "account_text". If
-// `following` is true, then account note will also be included
-// in the concatenation.
-func (s *searchDB) accountText(following bool) *bun.SelectQuery {
-	var (
-		accountText = s.db.NewSelect()
-		query       string
-		args        []interface{}
-	)
+// of account username, display name and note as "account_text".
+func (s *searchDB) accountText() *bun.SelectQuery {
+	accountText := s.db.NewSelect()
 
-	if following {
-		// If querying for accounts we follow,
-		// include note in text search params.
-		args = []interface{}{
+	// SQLite and Postgres use different
+	// syntaxes for concatenation. COALESCE
+	// calls ensure that we're not trying
+	// to concatenate null values.
+	switch d := s.db.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		accountText = accountText.ColumnExpr(
+			"? || COALESCE(?, ?) || COALESCE(?, ?) AS ?",
 			bun.Ident("account.username"),
 			bun.Ident("account.display_name"), "",
 			bun.Ident("account.note"), "",
-			bun.Ident("account_text"),
-		}
-	} else {
-		// If querying for accounts we're not following,
-		// don't include note in text search params.
-		args = []interface{}{
+			bun.Ident("account_text"))
+
+	case dialect.PG:
+		accountText = accountText.ColumnExpr(
+			"CONCAT(?, COALESCE(?, ?), COALESCE(?, ?)) AS ?",
 			bun.Ident("account.username"),
 			bun.Ident("account.display_name"), "",
-			bun.Ident("account_text"),
-		}
-	}
-
-	// SQLite and Postgres use different syntaxes for
-	// concatenation, and we also need to use a
-	// different number of placeholders depending on
-	// following/not following. COALESCE calls ensure
-	// that we're not trying to concatenate null values.
-
-	switch d := s.db.Dialect().Name(); {
-
-	case d == dialect.SQLite && following:
-		query = "? || COALESCE(?, ?) || COALESCE(?, ?) AS ?"
-
-	case d == dialect.SQLite && !following:
-		query = "? || COALESCE(?, ?) AS ?"
-
-	case d == dialect.PG && following:
-		query = "CONCAT(?, COALESCE(?, ?), COALESCE(?, ?)) AS ?"
-
-	case d == dialect.PG && !following:
-		query = "CONCAT(?, COALESCE(?, ?)) AS ?"
+			bun.Ident("account.note"), "",
+			bun.Ident("account_text"))
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
 	}
 
-	return accountText.ColumnExpr(query, args...)
+	return accountText
 }
 
 // Query example (SQLite):
@@ -273,6 +252,13 @@ func (s *searchDB) SearchForStatuses(
 	limit int,
 	offset int,
 ) ([]*gtsmodel.Status, error) {
+	if config.GetDbFullTextSearch() {
+		// Search the full text search
+		// index instead of using LIKE.
+		return s.searchForStatusesFullText(ctx,
+			accountID, query, maxID, minID, limit)
+	}
+
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -284,7 +270,7 @@ func (s *searchDB) SearchForStatuses(
 		frontToBack = true
 	)
 
-	q := s.db.
+	q := readDB(ctx, s.db, s.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Select only IDs from table
@@ -419,7 +405,7 @@ func (s *searchDB) SearchForTags(
 		frontToBack = true
 	)
 
-	q := s.db.
+	q := readDB(ctx, s.db, s.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("tags"), bun.Ident("tag")).
 		// Select only IDs from table
@@ -22,7 +22,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
 )
 
 type SearchTestSuite struct {
@@ -91,9 +94,10 @@ func (suite *SearchTestSuite) TestSearchAccountsPostFollowing() {
 func (suite *SearchTestSuite) TestSearchAccountsPostAny() {
 	testAccount := suite.testAccounts["local_account_1"]
 
+	// Notes of accounts not followed are searched too.
 	accounts, err := suite.db.SearchForAccounts(context.Background(), testAccount.ID, "post", "", "", 10, false, 0)
-	suite.NoError(err, db.ErrNoEntries)
-	suite.Empty(accounts)
+	suite.NoError(err)
+	suite.Len(accounts, 2)
 }
 
 func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
@@ -112,6 +116,76 @@ func (suite *SearchTestSuite) TestSearchStatuses() {
 	suite.Len(statuses, 1)
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesFullText() {
+	var (
+		ctx           = context.Background()
+		testAccount   = suite.testAccounts["local_account_1"]
+		testStatus    = suite.testStatuses["admin_account_status_1"]
+		dbService, ok = suite.db.(*bundb.DBService)
+	)
+
+	if !ok {
+		panic("db was not *bundb.DBService")
+	}
+
+	// Test tables are created from models rather
+	// than migrations, so create the search table.
+	var createTable string
+	switch dbService.DB().Dialect().Name() {
+	case dialect.SQLite:
+		createTable = "CREATE VIRTUAL TABLE ? USING fts5(text, status_id UNINDEXED, account_id UNINDEXED)"
+	case dialect.PG:
+		createTable = "CREATE TABLE ? (status_id CHAR(26), account_id CHAR(26), text TEXT, " +
+			"text_tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', text)) STORED)"
+	}
+
+	if _, err := dbService.DB().ExecContext(ctx, createTable, bun.Ident("status_search_entries")); err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer func() {
+		_, _ = dbService.DB().ExecContext(ctx, "DROP TABLE ?", bun.Ident("status_search_entries"))
+	}()
+
+	config.SetDbFullTextSearch(true)
+	defer config.SetDbFullTextSearch(false)
+
+	// Nothing indexed yet.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "welcome", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Index someone else's status, eg., as though bookmarked.
+	err = suite.db.PutStatusSearchEntry(ctx, testAccount.ID, testStatus)
+	suite.NoError(err)
+
+	// Putting again should replace the entry, not duplicate it.
+	err = suite.db.PutStatusSearchEntry(ctx, testAccount.ID, testStatus)
+	suite.NoError(err)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "first welcome", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(testStatus.ID, statuses[0].ID)
+	}
+
+	// Query syntax characters should be treated as text.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, `"first" OR*`, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Entries are only searchable by the indexing account.
+	statuses, err = suite.db.SearchForStatuses(ctx, testStatus.AccountID, "welcome", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	err = suite.db.DeleteStatusSearchEntry(ctx, testAccount.ID, testStatus.ID)
+	suite.NoError(err)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "welcome", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
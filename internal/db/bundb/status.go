@@ -28,14 +28,16 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
 
 type statusDB struct {
-	db    *bun.DB
-	state *state.State
+	db      *bun.DB
+	replica *bun.DB
+	state   *state.State
 }
 
 func (s *statusDB) GetStatusByID(ctx context.Context, id string) (*gtsmodel.Status, error) {
@@ -194,7 +196,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(10)
 	)
 
 	if status.Account == nil {
@@ -256,6 +258,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteOfID != "" && status.QuoteOf == nil {
+		// Status quote is not set, fetch from database.
+		status.QuoteOf, err = s.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			status.QuoteOfID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status quote: %w", err)
+		}
+	}
+
 	if status.PollID != "" && status.Poll == nil {
 		// Status poll is not set, fetch from database.
 		status.Poll, err = s.state.DB.GetPollByID(
@@ -331,76 +344,154 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error
 		// as the cache does not attempt a mutex lock until AFTER hook.
 		//
 		return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-			// create links between this status and any emojis it uses
-			for _, i := range status.EmojiIDs {
-				if _, err := tx.
-					NewInsert().
-					Model(&gtsmodel.StatusToEmoji{
-						StatusID: status.ID,
-						EmojiID:  i,
-					}).
-					On("CONFLICT (?, ?) DO NOTHING", bun.Ident("status_id"), bun.Ident("emoji_id")).
-					Exec(ctx); err != nil {
-					if !errors.Is(err, db.ErrAlreadyExists) {
-						return err
-					}
-				}
+			if err := putStatusLinks(ctx, tx, status); err != nil {
+				return err
 			}
 
-			// create links between this status and any tags it uses
-			for _, i := range status.TagIDs {
-				if _, err := tx.
-					NewInsert().
-					Model(&gtsmodel.StatusToTag{
-						StatusID: status.ID,
-						TagID:    i,
-					}).
-					On("CONFLICT (?, ?) DO NOTHING", bun.Ident("status_id"), bun.Ident("tag_id")).
-					Exec(ctx); err != nil {
-					if !errors.Is(err, db.ErrAlreadyExists) {
-						return err
-					}
-				}
+			// Finally, insert the status
+			_, err := tx.NewInsert().Model(status).Exec(ctx)
+			return err
+		})
+	})
+}
+
+func (s *statusDB) PutStatusUpsert(ctx context.Context, status *gtsmodel.Status) (*gtsmodel.Status, error) {
+	// Set if a status with this
+	// URI was already in the db.
+	var conflict bool
+
+	err := s.state.Caches.GTS.Status.Store(status, func() error {
+		// It is safe to run this database transaction within cache.Store
+		// as the cache does not attempt a mutex lock until AFTER hook.
+		//
+		return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// insert the status first, doing
+			// nothing if its URI already exists
+			res, err := tx.
+				NewInsert().
+				Model(status).
+				On("CONFLICT (?) DO NOTHING", bun.Ident("uri")).
+				Exec(ctx)
+			if err != nil {
+				return err
 			}
 
-			// change the status ID of the media attachments to the new status
-			for _, a := range status.Attachments {
-				a.StatusID = status.ID
-				a.UpdatedAt = time.Now()
-				if _, err := tx.
-					NewUpdate().
-					Model(a).
-					Column("status_id", "updated_at").
-					Where("? = ?", bun.Ident("media_attachment.id"), a.ID).
-					Exec(ctx); err != nil {
-					if !errors.Is(err, db.ErrAlreadyExists) {
-						return err
-					}
-				}
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
 			}
 
-			// If the status is threaded, create
-			// link between thread and status.
-			if status.ThreadID != "" {
-				if _, err := tx.
-					NewInsert().
-					Model(&gtsmodel.ThreadToStatus{
-						ThreadID: status.ThreadID,
-						StatusID: status.ID,
-					}).
-					On("CONFLICT (?, ?) DO NOTHING", bun.Ident("thread_id"), bun.Ident("status_id")).
-					Exec(ctx); err != nil {
-					if !errors.Is(err, db.ErrAlreadyExists) {
-						return err
-					}
-				}
+			if n == 0 {
+				// Nothing was inserted, return
+				// an error so the given status
+				// isn't stored in the cache.
+				conflict = true
+				return db.ErrAlreadyExists
 			}
 
-			// Finally, insert the status
-			_, err := tx.NewInsert().Model(status).Exec(ctx)
-			return err
+			return putStatusLinks(ctx, tx, status)
 		})
 	})
+
+	if conflict {
+		// Another status with this URI
+		// was stored first, return that.
+		return s.GetStatusByURI(ctx, status.URI)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// putStatusLinks creates the links between the given new status
+// and the emojis, tags, media attachments and thread it uses,
+// within the given transaction.
+func putStatusLinks(ctx context.Context, tx bun.Tx, status *gtsmodel.Status) error {
+	// create links between this status and any emojis it uses
+	for _, i := range status.EmojiIDs {
+		if _, err := tx.
+			NewInsert().
+			Model(&gtsmodel.StatusToEmoji{
+				StatusID: status.ID,
+				EmojiID:  i,
+			}).
+			On("CONFLICT (?, ?) DO NOTHING", bun.Ident("status_id"), bun.Ident("emoji_id")).
+			Exec(ctx); err != nil {
+			if !errors.Is(err, db.ErrAlreadyExists) {
+				return err
+			}
+		}
+	}
+
+	// create links between this status and any tags it uses
+	for _, i := range status.TagIDs {
+		if _, err := tx.
+			NewInsert().
+			Model(&gtsmodel.StatusToTag{
+				StatusID: status.ID,
+				TagID:    i,
+			}).
+			On("CONFLICT (?, ?) DO NOTHING", bun.Ident("status_id"), bun.Ident("tag_id")).
+			Exec(ctx); err != nil {
+			if !errors.Is(err, db.ErrAlreadyExists) {
+				return err
+			}
+		}
+	}
+
+	// change the status ID of the media attachments to the new status,
+	// only where owned by the status author and not already attached
+	// elsewhere, so concurrent inserts can't both claim the same media.
+	for _, a := range status.Attachments {
+		a.StatusID = status.ID
+		a.UpdatedAt = time.Now()
+		res, err := tx.
+			NewUpdate().
+			Model(a).
+			Column("status_id", "updated_at").
+			Where("? = ?", bun.Ident("media_attachment.id"), a.ID).
+			Where("? = ?", bun.Ident("media_attachment.account_id"), status.AccountID).
+			WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+				return q.
+					Where("? IS NULL", bun.Ident("media_attachment.status_id")).
+					WhereOr("? = ?", bun.Ident("media_attachment.status_id"), status.ID)
+			}).
+			Where("? IS NULL", bun.Ident("media_attachment.scheduled_status_id")).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			// Media couldn't be claimed,
+			// roll back the whole insert.
+			return &db.MediaClaimError{MediaID: a.ID}
+		}
+	}
+
+	// If the status is threaded, create
+	// link between thread and status.
+	if status.ThreadID != "" {
+		if _, err := tx.
+			NewInsert().
+			Model(&gtsmodel.ThreadToStatus{
+				ThreadID: status.ThreadID,
+				StatusID: status.ID,
+			}).
+			On("CONFLICT (?, ?) DO NOTHING", bun.Ident("thread_id"), bun.Ident("status_id")).
+			Exec(ctx); err != nil {
+			if !errors.Is(err, db.ErrAlreadyExists) {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, columns ...string) error {
@@ -628,6 +719,10 @@ func (s *statusDB) CountStatusReplies(ctx context.Context, statusID string) (int
 	return len(statusIDs), err
 }
 
+func (s *statusDB) CountStatusRepliesIn(ctx context.Context, statusIDs []string) (map[string]int, error) {
+	return countIDsIn(ctx, s.db, s.state.Caches.GTS.InReplyToIDs, "statuses", "in_reply_to_id", statusIDs)
+}
+
 func (s *statusDB) getStatusReplyIDs(ctx context.Context, statusID string) ([]string, error) {
 	return s.state.Caches.GTS.InReplyToIDs.Load(statusID, func() ([]string, error) {
 		var statusIDs []string
@@ -655,6 +750,57 @@ func (s *statusDB) GetStatusBoosts(ctx context.Context, statusID string) ([]*gts
 	return s.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (s *statusDB) GetStatusBoostsPage(ctx context.Context, statusID string, page *paging.Page) ([]*gtsmodel.Status, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.boost_of_id"), statusID)
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order.Ascending() {
+		// Page up.
+		q = q.Order("status.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("status.id DESC")
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want boosts
+	// to be sorted by ID desc, so reverse ids slice.
+	if order.Ascending() {
+		slices.Reverse(statusIDs)
+	}
+
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
 func (s *statusDB) IsStatusBoostedBy(ctx context.Context, statusID string, accountID string) (bool, error) {
 	boost, err := s.GetStatusBoost(
 		gtscontext.SetBarebones(ctx),
@@ -672,6 +818,14 @@ func (s *statusDB) CountStatusBoosts(ctx context.Context, statusID string) (int,
 	return len(statusIDs), err
 }
 
+func (s *statusDB) CountStatusBoostsIn(ctx context.Context, statusIDs []string) (map[string]int, error) {
+	return countIDsIn(ctx, s.db, s.state.Caches.GTS.BoostOfIDs, "statuses", "boost_of_id", statusIDs)
+}
+
+func (s *statusDB) GetBoostedStatusIDsIn(ctx context.Context, statusIDs []string, accountID string) ([]string, error) {
+	return selectAccountValuesIn(ctx, readDB(ctx, s.db, s.replica), "statuses", "boost_of_id", statusIDs, accountID)
+}
+
 func (s *statusDB) getStatusBoostIDs(ctx context.Context, statusID string) ([]string, error) {
 	return s.state.Caches.GTS.BoostOfIDs.Load(statusID, func() ([]string, error) {
 		var statusIDs []string
@@ -691,6 +845,56 @@ func (s *statusDB) getStatusBoostIDs(ctx context.Context, statusID string) ([]st
 	})
 }
 
+func (s *statusDB) GetStatusInteractionCountsSince(ctx context.Context, since time.Time, limit int) (map[string]int, error) {
+	var counts []struct {
+		StatusID string `bun:"status_id"`
+		Count    int    `bun:"count"`
+	}
+
+	// Select statuses with the most faves
+	// created after the given time.
+	if err := readDB(ctx, s.db, s.replica).
+		NewSelect().
+		Table("status_faves").
+		Column("status_id").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? > ?", bun.Ident("created_at"), since).
+		Group("status_id").
+		OrderExpr("? DESC", bun.Ident("count")).
+		Limit(limit).
+		Scan(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	interactions := make(map[string]int, len(counts))
+	for _, c := range counts {
+		interactions[c.StatusID] += c.Count
+	}
+
+	// Select statuses with the most boosts
+	// created after the given time.
+	counts = counts[:0]
+	if err := readDB(ctx, s.db, s.replica).
+		NewSelect().
+		Table("statuses").
+		ColumnExpr("? AS ?", bun.Ident("boost_of_id"), bun.Ident("status_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? IS NOT NULL", bun.Ident("boost_of_id")).
+		Where("? > ?", bun.Ident("created_at"), since).
+		Group("boost_of_id").
+		OrderExpr("? DESC", bun.Ident("count")).
+		Limit(limit).
+		Scan(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	for _, c := range counts {
+		interactions[c.StatusID] += c.Count
+	}
+
+	return interactions, nil
+}
+
 func (s *statusDB) IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, error) {
 	q := s.db.
 		NewSelect().
@@ -699,3 +903,7 @@ func (s *statusDB) IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.St
 		Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID)
 	return exists(ctx, q)
 }
+
+func (s *statusDB) GetBookmarkedStatusIDsIn(ctx context.Context, statusIDs []string, accountID string) ([]string, error) {
+	return selectAccountValuesIn(ctx, readDB(ctx, s.db, s.replica), "status_bookmarks", "status_id", statusIDs, accountID)
+}
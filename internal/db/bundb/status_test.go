@@ -183,6 +183,29 @@ func (suite *StatusTestSuite) TestGetStatusReplies() {
 	}
 }
 
+func (suite *StatusTestSuite) TestCountStatusRepliesIn() {
+	ctx := context.Background()
+	statusIDs := []string{
+		suite.testStatuses["local_account_1_status_1"].ID,
+		suite.testStatuses["local_account_1_status_2"].ID,
+	}
+
+	// Count twice, to check
+	// cached results match.
+	for i := 0; i < 2; i++ {
+		counts, err := suite.db.CountStatusRepliesIn(ctx, statusIDs)
+		suite.NoError(err)
+		suite.Len(counts, 2)
+		suite.Equal(2, counts[statusIDs[0]])
+
+		for _, statusID := range statusIDs {
+			count, err := suite.db.CountStatusReplies(ctx, statusID)
+			suite.NoError(err)
+			suite.Equal(count, counts[statusID])
+		}
+	}
+}
+
 func (suite *StatusTestSuite) TestGetStatusChildren() {
 	targetStatus := suite.testStatuses["local_account_1_status_1"]
 	children, err := suite.db.GetStatusChildren(context.Background(), targetStatus.ID)
@@ -232,6 +255,36 @@ func (suite *StatusTestSuite) TestUpdateStatus() {
 	suite.True(updated.PinnedAt.IsZero())
 }
 
+func (suite *StatusTestSuite) TestPutStatusMediaNotClaimable() {
+	ctx := context.Background()
+
+	// Copy an existing status, but with a new ID,
+	// and attach media already attached elsewhere.
+	attachment := &gtsmodel.MediaAttachment{}
+	*attachment = *suite.testAttachments["local_account_1_status_4_attachment_1"]
+	status := &gtsmodel.Status{}
+	*status = *suite.testStatuses["local_account_1_status_1"]
+	status.ID = "01J0Q9QX8A4CD2SJW1RN2PGBKT"
+	status.URI = "http://localhost:8080/users/the_mighty_zork/statuses/01J0Q9QX8A4CD2SJW1RN2PGBKT"
+	status.URL = "http://localhost:8080/@the_mighty_zork/statuses/01J0Q9QX8A4CD2SJW1RN2PGBKT"
+	status.AttachmentIDs = []string{attachment.ID}
+	status.Attachments = []*gtsmodel.MediaAttachment{attachment}
+
+	err := suite.db.PutStatus(ctx, status)
+
+	var claimErr *db.MediaClaimError
+	suite.ErrorAs(err, &claimErr)
+	suite.Equal(attachment.ID, claimErr.MediaID)
+
+	// Nothing should have been stored.
+	_, err = suite.db.GetStatusByID(ctx, status.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(suite.testStatuses["local_account_1_status_4"].ID, dbAttachment.StatusID)
+}
+
 func (suite *StatusTestSuite) TestPutPopulatedStatus() {
 	ctx := context.Background()
 
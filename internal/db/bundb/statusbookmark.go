@@ -21,9 +21,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 )
@@ -81,25 +83,27 @@ func (s *statusBookmarkDB) GetStatusBookmarkID(ctx context.Context, accountID st
 	return id, nil
 }
 
-func (s *statusBookmarkDB) GetStatusBookmarks(ctx context.Context, accountID string, limit int, maxID string, minID string) ([]*gtsmodel.StatusBookmark, error) {
-	// Ensure reasonable
-	if limit < 0 {
-		limit = 0
+func (s *statusBookmarkDB) GetStatusBookmarks(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.StatusBookmark, error) {
+	if accountID == "" {
+		return nil, errors.New("must provide an account")
 	}
 
-	// Guess size of IDs based on limit.
-	ids := make([]string, 0, limit)
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		ids = make([]string, 0, limit)
+	)
 
 	q := s.db.
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
 		Column("status_bookmark.id").
-		Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID).
-		Order("status_bookmark.id DESC")
-
-	if accountID == "" {
-		return nil, errors.New("must provide an account")
-	}
+		Where("? = ?", bun.Ident("status_bookmark.account_id"), accountID)
 
 	if maxID != "" {
 		q = q.Where("? < ?", bun.Ident("status_bookmark.id"), maxID)
@@ -109,14 +113,28 @@ func (s *statusBookmarkDB) GetStatusBookmarks(ctx context.Context, accountID str
 		q = q.Where("? > ?", bun.Ident("status_bookmark.id"), minID)
 	}
 
-	if limit != 0 {
+	if limit > 0 {
 		q = q.Limit(limit)
 	}
 
+	if order.Ascending() {
+		// Page up.
+		q = q.Order("status_bookmark.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("status_bookmark.id DESC")
+	}
+
 	if err := q.Scan(ctx, &ids); err != nil {
 		return nil, err
 	}
 
+	// If we're paging up, we still want bookmarks
+	// to be sorted by ID desc, so reverse ids slice.
+	if order.Ascending() {
+		slices.Reverse(ids)
+	}
+
 	bookmarks := make([]*gtsmodel.StatusBookmark, 0, len(ids))
 
 	for _, id := range ids {
@@ -29,14 +29,16 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
 
 type statusFaveDB struct {
-	db    *bun.DB
-	state *state.State
+	db      *bun.DB
+	replica *bun.DB
+	state   *state.State
 }
 
 func (s *statusFaveDB) GetStatusFave(ctx context.Context, accountID string, statusID string) (*gtsmodel.StatusFave, error) {
@@ -113,6 +115,112 @@ func (s *statusFaveDB) GetStatusFaves(ctx context.Context, statusID string) ([]*
 		return nil, err
 	}
 
+	return s.getStatusFavesByIDs(ctx, faveIDs)
+}
+
+func (s *statusFaveDB) GetStatusFavesByAccount(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.StatusFave, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		faveIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		Column("status_fave.id").
+		Where("? = ?", bun.Ident("status_fave.account_id"), accountID)
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("status_fave.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("status_fave.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order.Ascending() {
+		// Page up.
+		q = q.Order("status_fave.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("status_fave.id DESC")
+	}
+
+	if err := q.Scan(ctx, &faveIDs); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want faves
+	// to be sorted by ID desc, so reverse ids slice.
+	if order.Ascending() {
+		slices.Reverse(faveIDs)
+	}
+
+	return s.getStatusFavesByIDs(ctx, faveIDs)
+}
+
+func (s *statusFaveDB) GetStatusFavesPage(ctx context.Context, statusID string, page *paging.Page) ([]*gtsmodel.StatusFave, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		faveIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		Column("status_fave.id").
+		Where("? = ?", bun.Ident("status_fave.status_id"), statusID)
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("status_fave.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("status_fave.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order.Ascending() {
+		// Page up.
+		q = q.Order("status_fave.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("status_fave.id DESC")
+	}
+
+	if err := q.Scan(ctx, &faveIDs); err != nil {
+		return nil, err
+	}
+
+	// If we're paging up, we still want faves
+	// to be sorted by ID desc, so reverse ids slice.
+	if order.Ascending() {
+		slices.Reverse(faveIDs)
+	}
+
+	return s.getStatusFavesByIDs(ctx, faveIDs)
+}
+
+func (s *statusFaveDB) getStatusFavesByIDs(ctx context.Context, faveIDs []string) ([]*gtsmodel.StatusFave, error) {
 	// Preallocate at-worst possible length.
 	uncached := make([]string, 0, len(faveIDs))
 
@@ -185,6 +293,14 @@ func (s *statusFaveDB) CountStatusFaves(ctx context.Context, statusID string) (i
 	return len(faveIDs), err
 }
 
+func (s *statusFaveDB) CountStatusFavesIn(ctx context.Context, statusIDs []string) (map[string]int, error) {
+	return countIDsIn(ctx, s.db, s.state.Caches.GTS.StatusFaveIDs, "status_faves", "status_id", statusIDs)
+}
+
+func (s *statusFaveDB) GetFavedStatusIDsIn(ctx context.Context, statusIDs []string, accountID string) ([]string, error) {
+	return selectAccountValuesIn(ctx, readDB(ctx, s.db, s.replica), "status_faves", "status_id", statusIDs, accountID)
+}
+
 func (s *statusFaveDB) getStatusFaveIDs(ctx context.Context, statusID string) ([]string, error) {
 	return s.state.Caches.GTS.StatusFaveIDs.Load(statusID, func() ([]string, error) {
 		var faveIDs []string
@@ -195,6 +311,7 @@ func (s *statusFaveDB) getStatusFaveIDs(ctx context.Context, statusID string) ([
 			Table("status_faves").
 			Column("id").
 			Where("? = ?", bun.Ident("status_id"), statusID).
+			Order("id DESC").
 			Scan(ctx, &faveIDs); err != nil {
 			return nil, err
 		}
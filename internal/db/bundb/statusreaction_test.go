@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusReactionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StatusReactionTestSuite) TestPutGetDeleteStatusReaction() {
+	var (
+		ctx           = context.Background()
+		testAccount   = suite.testAccounts["remote_account_1"]
+		testStatus    = suite.testStatuses["local_account_1_status_1"]
+		testReactions = []*gtsmodel.StatusReaction{
+			{
+				ID:              "01HTQ2QF8P5QG0BE4RTHB6Z3GX",
+				AccountID:       testAccount.ID,
+				TargetAccountID: testStatus.AccountID,
+				StatusID:        testStatus.ID,
+				Content:         "🐢",
+				URI:             "http://fossbros-anonymous.io/reactions/01HTQ2QF8P5QG0BE4RTHB6Z3GX",
+			},
+			{
+				ID:              "01HTQ2RJ6TYQ3B6CZ8JQ9XEJ4K",
+				AccountID:       testAccount.ID,
+				TargetAccountID: testStatus.AccountID,
+				StatusID:        testStatus.ID,
+				Content:         "🦦",
+				URI:             "http://fossbros-anonymous.io/reactions/01HTQ2RJ6TYQ3B6CZ8JQ9XEJ4K",
+			},
+		}
+	)
+
+	for _, reaction := range testReactions {
+		if err := suite.db.PutStatusReaction(ctx, reaction); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Same account reacting with the
+	// same emoji twice should fail.
+	err := suite.db.PutStatusReaction(ctx, &gtsmodel.StatusReaction{
+		ID:              "01HTQ2T0W4ZDN5GJ7WB2GRJ9B8",
+		AccountID:       testAccount.ID,
+		TargetAccountID: testStatus.AccountID,
+		StatusID:        testStatus.ID,
+		Content:         "🐢",
+		URI:             "http://fossbros-anonymous.io/reactions/01HTQ2T0W4ZDN5GJ7WB2GRJ9B8",
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	reaction, err := suite.db.GetStatusReactionByURI(ctx, testReactions[0].URI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testReactions[0].ID, reaction.ID)
+	suite.NotNil(reaction.Account)
+	suite.NotNil(reaction.TargetAccount)
+	suite.NotNil(reaction.Status)
+
+	reactions, err := suite.db.GetStatusReactions(ctx, testStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(reactions, 2)
+	suite.Equal("🐢", reactions[0].Content)
+	suite.Equal("🦦", reactions[1].Content)
+
+	if err := suite.db.DeleteStatusReactionByID(ctx, testReactions[0].ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetStatusReactionByID(ctx, testReactions[0].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	if err := suite.db.DeleteStatusReactionsForStatus(ctx, testStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	reactions, err = suite.db.GetStatusReactions(ctx, testStatus.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(reactions)
+}
+
+func TestStatusReactionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactionTestSuite))
+}
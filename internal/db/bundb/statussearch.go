@@ -0,0 +1,210 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func (s *searchDB) PutStatusSearchEntry(ctx context.Context, accountID string, status *gtsmodel.Status) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// SQLite FTS5 tables can't have unique
+		// constraints, so just replace any
+		// existing entry for this account.
+		if _, err := tx.
+			NewDelete().
+			Table("status_search_entries").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Where("? = ?", bun.Ident("status_id"), status.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO ? (?, ?, ?) VALUES (?, ?, ?)",
+			bun.Ident("status_search_entries"),
+			bun.Ident("account_id"), bun.Ident("status_id"), bun.Ident("text"),
+			accountID, status.ID, statusSearchText(status),
+		)
+		return err
+	})
+}
+
+func (s *searchDB) UpdateStatusSearchEntries(ctx context.Context, status *gtsmodel.Status) error {
+	_, err := s.db.
+		NewUpdate().
+		Table("status_search_entries").
+		Set("? = ?", bun.Ident("text"), statusSearchText(status)).
+		Where("? = ?", bun.Ident("status_id"), status.ID).
+		Exec(ctx)
+	return err
+}
+
+func (s *searchDB) DeleteStatusSearchEntry(ctx context.Context, accountID string, statusID string) error {
+	_, err := s.db.
+		NewDelete().
+		Table("status_search_entries").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+func (s *searchDB) DeleteStatusSearchEntriesForStatus(ctx context.Context, statusID string) error {
+	_, err := s.db.
+		NewDelete().
+		Table("status_search_entries").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+// Query example (SQLite):
+//
+//	SELECT "status_id" FROM "status_search_entries"
+//	WHERE ("account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')
+//	AND ("status_search_entries" MATCH '"hello"')
+//	AND ("status_id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
+//	ORDER BY "status_id" DESC LIMIT 10
+func (s *searchDB) searchForStatusesFullText(
+	ctx context.Context,
+	accountID string,
+	query string,
+	maxID string,
+	minID string,
+	limit int,
+) ([]*gtsmodel.Status, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	var (
+		statusIDs   = make([]string, 0, limit)
+		frontToBack = true
+	)
+
+	q := readDB(ctx, s.db, s.replica).
+		NewSelect().
+		Table("status_search_entries").
+		Column("status_id").
+		// Select only entries indexed
+		// for the searching account.
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	// SQLite and Postgres use different
+	// syntaxes for full text search.
+	switch d := s.db.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		q = q.Where("? MATCH ?",
+			bun.Ident("status_search_entries"),
+			fts5Query(query))
+
+	case dialect.PG:
+		q = q.Where("? @@ plainto_tsquery('simple', ?)",
+			bun.Ident("text_tsv"),
+			query)
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+	}
+
+	// Return only items with a LOWER id than maxID.
+	if maxID == "" {
+		maxID = id.Highest
+	}
+	q = q.Where("? < ?", bun.Ident("status_id"), maxID)
+
+	if minID != "" {
+		// return only statuses HIGHER (ie., newer) than minID
+		q = q.Where("? > ?", bun.Ident("status_id"), minID)
+
+		// page up
+		frontToBack = false
+	}
+
+	if limit > 0 {
+		// Limit amount of statuses returned.
+		q = q.Limit(limit)
+	}
+
+	if frontToBack {
+		// Page down.
+		q = q.Order("status_id DESC")
+	} else {
+		// Page up.
+		q = q.Order("status_id ASC")
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want statuses
+	// to be sorted by ID desc, so reverse ids slice.
+	if !frontToBack {
+		for l, r := 0, len(statusIDs)-1; l < r; l, r = l+1, r-1 {
+			statusIDs[l], statusIDs[r] = statusIDs[r], statusIDs[l]
+		}
+	}
+
+	return s.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
+
+// statusSearchText returns the plaintext content
+// and content warning of the given status, for
+// storage in the full text search index.
+func statusSearchText(status *gtsmodel.Status) string {
+	// Space out html elements so that
+	// words in adjacent elements aren't
+	// joined together once html is removed.
+	content := strings.ReplaceAll(status.Content, "<", " <")
+	content = text.SanitizeToPlaintext(content)
+
+	if status.ContentWarning == "" {
+		return content
+	}
+
+	return status.ContentWarning + "\n" + content
+}
+
+// fts5Query converts the given user query into
+// an SQLite FTS5 query matching all of its terms,
+// quoting each term so that FTS5 syntax characters
+// in the user query are treated as literal text.
+func fts5Query(query string) string {
+	terms := strings.Fields(query)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " ")
+}
@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -28,8 +29,9 @@ import (
 )
 
 type tagDB struct {
-	db    *bun.DB
-	state *state.State
+	db      *bun.DB
+	replica *bun.DB
+	state   *state.State
 }
 
 func (t *tagDB) GetTag(ctx context.Context, id string) (*gtsmodel.Tag, error) {
@@ -141,6 +143,67 @@ func (t *tagDB) PutTag(ctx context.Context, tag *gtsmodel.Tag) error {
 	tag.UpdatedAt = t2.UpdatedAt
 	tag.Useable = t2.Useable
 	tag.Listable = t2.Listable
+	tag.Trendable = t2.Trendable
 
 	return nil
 }
+
+func (t *tagDB) UpdateTag(ctx context.Context, tag *gtsmodel.Tag, columns ...string) error {
+	tag.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return t.state.Caches.GTS.Tag.Store(tag, func() error {
+		_, err := t.db.NewUpdate().
+			Model(tag).
+			Where("? = ?", bun.Ident("tag.id"), tag.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (t *tagDB) PutTagUse(ctx context.Context, use *gtsmodel.TagUse) error {
+	_, err := t.db.NewInsert().Model(use).Exec(ctx)
+	return err
+}
+
+func (t *tagDB) GetTagUsesSince(ctx context.Context, since time.Time) ([]*gtsmodel.TagUse, error) {
+	var uses []*gtsmodel.TagUse
+
+	if err := t.db.
+		NewSelect().
+		Model(&uses).
+		Where("? > ?", bun.Ident("tag_use.created_at"), since).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return uses, nil
+}
+
+func (t *tagDB) GetTagUsesForTagSince(ctx context.Context, tagID string, since time.Time) ([]*gtsmodel.TagUse, error) {
+	var uses []*gtsmodel.TagUse
+
+	if err := readDB(ctx, t.db, t.replica).
+		NewSelect().
+		Model(&uses).
+		Where("? = ?", bun.Ident("tag_use.tag_id"), tagID).
+		Where("? > ?", bun.Ident("tag_use.created_at"), since).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return uses, nil
+}
+
+func (t *tagDB) DeleteTagUsesOlderThan(ctx context.Context, olderThan time.Time) error {
+	_, err := t.db.
+		NewDelete().
+		Table("tag_uses").
+		Where("? < ?", bun.Ident("created_at"), olderThan).
+		Exec(ctx)
+	return err
+}
@@ -28,8 +28,9 @@ import (
 )
 
 type threadDB struct {
-	db    *bun.DB
-	state *state.State
+	db      *bun.DB
+	replica *bun.DB
+	state   *state.State
 }
 
 func (t *threadDB) PutThread(ctx context.Context, thread *gtsmodel.Thread) error {
@@ -97,6 +98,14 @@ func (t *threadDB) IsThreadMutedByAccount(
 	return (mute != nil), nil
 }
 
+func (t *threadDB) GetMutedThreadIDsIn(
+	ctx context.Context,
+	threadIDs []string,
+	accountID string,
+) ([]string, error) {
+	return selectAccountValuesIn(ctx, readDB(ctx, t.db, t.replica), "thread_mutes", "thread_id", threadIDs, accountID)
+}
+
 func (t *threadDB) PutThreadMute(ctx context.Context, threadMute *gtsmodel.ThreadMute) error {
 	return t.state.Caches.GTS.ThreadMute.Store(threadMute, func() error {
 		_, err := t.db.NewInsert().Model(threadMute).Exec(ctx)
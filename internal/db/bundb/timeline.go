@@ -21,7 +21,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"slices"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -34,8 +33,9 @@ import (
 )
 
 type timelineDB struct {
-	db    *bun.DB
-	state *state.State
+	db      *bun.DB
+	replica *bun.DB
+	state   *state.State
 }
 
 func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, error) {
@@ -50,7 +50,7 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 		frontToBack = true
 	)
 
-	q := t.db.
+	q := readDB(ctx, t.db, t.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Select only IDs from table
@@ -170,7 +170,7 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 		frontToBack = true
 	)
 
-	q := t.db.
+	q := readDB(ctx, t.db, t.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Public only.
@@ -249,71 +249,6 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 
 // TODO optimize this query and the logic here, because it's slow as balls -- it takes like a literal second to return with a limit of 20!
 // It might be worth serving it through a timeline instead of raw DB queries, like we do for Home feeds.
-func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, string, string, error) {
-	// Ensure reasonable
-	if limit < 0 {
-		limit = 0
-	}
-
-	// Make educated guess for slice size
-	faves := make([]*gtsmodel.StatusFave, 0, limit)
-
-	fq := t.db.
-		NewSelect().
-		Model(&faves).
-		Where("? = ?", bun.Ident("status_fave.account_id"), accountID).
-		Order("status_fave.id DESC")
-
-	if maxID != "" {
-		fq = fq.Where("? < ?", bun.Ident("status_fave.id"), maxID)
-	}
-
-	if minID != "" {
-		fq = fq.Where("? > ?", bun.Ident("status_fave.id"), minID)
-	}
-
-	if limit > 0 {
-		fq = fq.Limit(limit)
-	}
-
-	err := fq.Scan(ctx)
-	if err != nil {
-		return nil, "", "", err
-	}
-
-	if len(faves) == 0 {
-		return nil, "", "", db.ErrNoEntries
-	}
-
-	// Sort by favourite ID rather than status ID
-	slices.SortFunc(faves, func(a, b *gtsmodel.StatusFave) int {
-		const k = -1
-		switch {
-		case a.ID > b.ID:
-			return +k
-		case a.ID < b.ID:
-			return -k
-		default:
-			return 0
-		}
-	})
-
-	// Convert fave IDs to status IDs.
-	statusIDs := make([]string, len(faves))
-	for i, fave := range faves {
-		statusIDs[i] = fave.StatusID
-	}
-
-	statuses, err := t.state.DB.GetStatusesByIDs(ctx, statusIDs)
-	if err != nil {
-		return nil, "", "", err
-	}
-
-	nextMaxID := faves[len(faves)-1].ID
-	prevMinID := faves[0].ID
-	return statuses, nextMaxID, prevMinID, nil
-}
-
 func (t *timelineDB) GetListTimeline(
 	ctx context.Context,
 	listID string,
@@ -352,7 +287,7 @@ func (t *timelineDB) GetListTimeline(
 	}
 
 	// Select target account IDs from follows.
-	subQ := t.db.
+	subQ := readDB(ctx, t.db, t.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
 		Column("follow.target_account_id").
@@ -360,7 +295,7 @@ func (t *timelineDB) GetListTimeline(
 
 	// Select only status IDs created
 	// by one of the followed accounts.
-	q := t.db.
+	q := readDB(ctx, t.db, t.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Select only IDs from table
@@ -448,7 +383,7 @@ func (t *timelineDB) GetTagTimeline(
 		frontToBack = true
 	)
 
-	q := t.db.
+	q := readDB(ctx, t.db, t.replica).
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
 		Column("status_to_tag.status_id").
@@ -114,6 +114,17 @@ func (u *userDB) GetUserByConfirmationToken(ctx context.Context, token string) (
 	)
 }
 
+func (u *userDB) GetUserByDeletionCancelToken(ctx context.Context, token string) (*gtsmodel.User, error) {
+	return u.getUser(
+		ctx,
+		"DeletionCancelToken",
+		func(user *gtsmodel.User) error {
+			return u.db.NewSelect().Model(user).Where("? = ?", bun.Ident("deletion_cancel_token"), token).Scan(ctx)
+		},
+		token,
+	)
+}
+
 func (u *userDB) getUser(ctx context.Context, lookup string, dbQuery func(*gtsmodel.User) error, keyParts ...any) (*gtsmodel.User, error) {
 	// Fetch user from database cache with loader callback.
 	user, err := u.state.Caches.GTS.User.LoadOne(lookup, func() (*gtsmodel.User, error) {
@@ -178,6 +189,23 @@ func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error) {
 	return u.GetUsersByIDs(ctx, userIDs)
 }
 
+func (u *userDB) GetUsersScheduledForDeletion(ctx context.Context) ([]*gtsmodel.User, error) {
+	var userIDs []string
+
+	// Scan IDs of users with
+	// a deletion scheduled.
+	if err := u.db.NewSelect().
+		Table("users").
+		Column("id").
+		Where("? IS NOT NULL", bun.Ident("deletion_scheduled_at")).
+		Scan(ctx, &userIDs); err != nil {
+		return nil, err
+	}
+
+	// Transform user IDs into user slice.
+	return u.GetUsersByIDs(ctx, userIDs)
+}
+
 func (u *userDB) PutUser(ctx context.Context, user *gtsmodel.User) error {
 	return u.state.Caches.GTS.User.Store(user, func() error {
 		_, err := u.db.
@@ -208,6 +236,49 @@ func (u *userDB) UpdateUser(ctx context.Context, user *gtsmodel.User, columns ..
 	})
 }
 
+func (u *userDB) IncrementUserFailedSignIns(ctx context.Context, userID string, resetBefore time.Time) (int, time.Time, error) {
+	// Ensure cached model is
+	// dropped, as the count and
+	// lock are changed below.
+	defer u.state.Caches.GTS.User.Invalidate("ID", userID)
+
+	now := time.Now()
+
+	// Increment in the database rather than on the
+	// model, so concurrent failures don't lose counts.
+	// A lock that expired long enough ago starts the
+	// count afresh; otherwise the count is kept across
+	// locks, so that each lock is longer than the last.
+	var (
+		count       int
+		lockedUntil bun.NullTime
+	)
+	if err := u.db.
+		NewUpdate().
+		Table("users").
+		Set("? = CASE WHEN ? <= ? THEN 1 ELSE ? + 1 END",
+			bun.Ident("failed_sign_in_count"),
+			bun.Ident("sign_in_locked_until"), resetBefore,
+			bun.Ident("failed_sign_in_count"),
+		).
+		Set("? = CASE WHEN ? <= ? THEN NULL ELSE ? END",
+			bun.Ident("sign_in_locked_until"),
+			bun.Ident("sign_in_locked_until"), resetBefore,
+			bun.Ident("sign_in_locked_until"),
+		).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), userID).
+		Returning("?, ?",
+			bun.Ident("failed_sign_in_count"),
+			bun.Ident("sign_in_locked_until"),
+		).
+		Scan(ctx, &count, &lockedUntil); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return count, lockedUntil.Time, nil
+}
+
 func (u *userDB) DeleteUserByID(ctx context.Context, userID string) error {
 	defer u.state.Caches.GTS.User.Invalidate("ID", userID)
 
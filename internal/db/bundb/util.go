@@ -157,6 +157,73 @@ func loadPagedIDs(cache *cache.SliceCache[string], key string, page *paging.Page
 	return ids, nil
 }
 
+// loadIDsIn loads lists of IDs from given SliceCache for each of the given `keys`, selecting
+// the IDs of all rows in `table` with `column` value in any uncached keys in a single query.
+func loadIDsIn(ctx context.Context, db *bun.DB, cache *cache.SliceCache[string], table string, column string, keys []string) (map[string][]string, error) {
+	return cache.LoadIn(keys, func(uncached []string) (map[string][]string, error) {
+		var rows []struct {
+			Key string `bun:"key"`
+			ID  string `bun:"id"`
+		}
+
+		// IDs not in cache, perform DB query!
+		if err := db.
+			NewSelect().
+			Table(table).
+			ColumnExpr("? AS ?", bun.Ident(column), bun.Ident("key")).
+			Column("id").
+			Where("? IN (?)", bun.Ident(column), bun.In(uncached)).
+			Order("id DESC").
+			Scan(ctx, &rows); err != nil {
+			return nil, err
+		}
+
+		ids := make(map[string][]string, len(uncached))
+		for _, row := range rows {
+			ids[row.Key] = append(ids[row.Key], row.ID)
+		}
+
+		return ids, nil
+	})
+}
+
+// countIDsIn returns the number of IDs loaded by loadIDsIn for each key.
+func countIDsIn(ctx context.Context, db *bun.DB, cache *cache.SliceCache[string], table string, column string, keys []string) (map[string]int, error) {
+	ids, err := loadIDsIn(ctx, db, cache, table, column, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(ids))
+	for key, ids := range ids {
+		counts[key] = len(ids)
+	}
+
+	return counts, nil
+}
+
+// selectAccountValuesIn selects those of the given `values` of `column` in `table`
+// which exist in a row created by the given account ID, i.e. with `account_id`.
+func selectAccountValuesIn(ctx context.Context, db *bun.DB, table string, column string, values []string, accountID string) ([]string, error) {
+	if len(values) == 0 {
+		// Nothing to select.
+		return nil, nil
+	}
+
+	var selected []string
+	if err := db.
+		NewSelect().
+		Table(table).
+		Column(column).
+		Where("? IN (?)", bun.Ident(column), bun.In(values)).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &selected); err != nil {
+		return nil, err
+	}
+
+	return selected, nil
+}
+
 // updateWhere parses []db.Where and adds it to the given update query.
 func updateWhere(q *bun.UpdateQuery, where []db.Where) {
 	for _, w := range where {
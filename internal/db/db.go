@@ -25,20 +25,29 @@ const (
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountArchive
 	Admin
 	Application
 	Basic
+	Delivery
 	Domain
 	Emoji
 	HeaderFilter
+	InboxActivity
 	Instance
+	Invite
 	List
 	Marker
 	Media
 	Mention
+	Metrics
 	Notification
+	NotificationRequest
 	Poll
+	QuarantinedStatus
+	QueuedMessage
 	Relationship
+	Relay
 	Report
 	Rule
 	Search
@@ -46,6 +55,7 @@ type DB interface {
 	Status
 	StatusBookmark
 	StatusFave
+	StatusReaction
 	Tag
 	Thread
 	Timeline
@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delivery handles queueing of failed outgoing deliveries to be retried.
+type Delivery interface {
+	// PutDelivery puts the given delivery in the database.
+	PutDelivery(ctx context.Context, delivery *gtsmodel.Delivery) error
+
+	// UpdateDelivery updates the given delivery in the database. If no columns are given, all columns will be updated.
+	UpdateDelivery(ctx context.Context, delivery *gtsmodel.Delivery, columns ...string) error
+
+	// DeleteDeliveryByID deletes one delivery by its db id.
+	DeleteDeliveryByID(ctx context.Context, id string) error
+
+	// GetDeliveriesDue gets up to limit deliveries due to be attempted again at the given time, oldest due first.
+	GetDeliveriesDue(ctx context.Context, now time.Time, limit int) ([]*gtsmodel.Delivery, error)
+
+	// CountDeliveriesByDomain returns the number of queued deliveries per inbox domain.
+	CountDeliveriesByDomain(ctx context.Context) (map[string]int, error)
+}
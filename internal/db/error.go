@@ -20,6 +20,7 @@ package db
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 )
 
 var (
@@ -34,3 +35,15 @@ var (
 	// to complete the supplied query. This is generally intended to be handled internally by the DB.
 	ErrBusyTimeout = errors.New("busy timeout")
 )
+
+// MediaClaimError is returned when a media attachment could not
+// be claimed by a status on insert, i.e. it is owned by a different
+// account, or it was already attached to another (scheduled) status.
+type MediaClaimError struct {
+	// ID of the unclaimable media attachment.
+	MediaID string
+}
+
+func (e *MediaClaimError) Error() string {
+	return fmt.Sprintf("media %s could not be claimed by status", e.MediaID)
+}
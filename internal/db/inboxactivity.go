@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// InboxActivity handles recording of received inbox activities, so that redundant deliveries can be dropped.
+type InboxActivity interface {
+	// PutInboxActivity puts the given inbox activity in the database.
+	// Returns ErrAlreadyExists if an inbox activity with the same key was already recorded.
+	PutInboxActivity(ctx context.Context, activity *gtsmodel.InboxActivity) error
+
+	// DeleteInboxActivityByKey deletes the inbox activity with the given key, if any.
+	DeleteInboxActivityByKey(ctx context.Context, key string) error
+
+	// DeleteInboxActivitiesOlderThan deletes all inbox activities
+	// recorded before the given time, returning the number deleted.
+	DeleteInboxActivitiesOlderThan(ctx context.Context, olderThan time.Time) (int, error)
+}
@@ -58,4 +58,8 @@ type Instance interface {
 	// GetInstanceModeratorAddresses returns a slice of email addresses belonging to active
 	// (as in, not suspended) moderators + admins on this instance.
 	GetInstanceModeratorAddresses(ctx context.Context) ([]string, error)
+
+	// GetInstanceInboxURIs returns a deduplicated slice of inbox URIs for all known remote,
+	// non-suspended accounts, preferring an account's shared inbox where it has one.
+	GetInstanceInboxURIs(ctx context.Context) ([]string, error)
 }
@@ -51,4 +51,8 @@ type Media interface {
 	// GetCachedAttachmentsOlderThan gets limit n remote attachments (including avatars and headers) older than
 	// the given time. These will be returned in order of attachment.created_at descending (i.e. newest to oldest).
 	GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetProcessingAttachments fetches local media attachments still
+	// marked as being processed, ie., whose processing was interrupted.
+	GetProcessingAttachments(ctx context.Context) ([]*gtsmodel.MediaAttachment, error)
 }
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Metrics contains functions for aggregating activity
+// on the instance over time, for the admin dashboard.
+//
+// All time ranges are from start (inclusive) to end (exclusive).
+type Metrics interface {
+	// CountNewUsers returns the number of local users who signed up in the given time range.
+	CountNewUsers(ctx context.Context, start time.Time, end time.Time) (int, error)
+
+	// CountActiveUsers returns the number of local accounts that
+	// posted, replied, or boosted something in the given time range.
+	CountActiveUsers(ctx context.Context, start time.Time, end time.Time) (int, error)
+
+	// CountInteractions returns the number of faves, boosts, and
+	// replies targeting local accounts in the given time range.
+	CountInteractions(ctx context.Context, start time.Time, end time.Time) (int, error)
+
+	// CountOpenedReports returns the number of reports created in the given time range.
+	CountOpenedReports(ctx context.Context, start time.Time, end time.Time) (int, error)
+
+	// CountResolvedReports returns the number of reports resolved in the given time range.
+	CountResolvedReports(ctx context.Context, start time.Time, end time.Time) (int, error)
+
+	// CountStatusesByLanguage returns the number of local statuses created
+	// in the given time range per language, for at most limit top languages.
+	CountStatusesByLanguage(ctx context.Context, start time.Time, end time.Time, limit int) (map[string]int, error)
+
+	// CountStatusesByDomain returns the number of remote statuses received
+	// in the given time range per domain, for at most limit top domains.
+	CountStatusesByDomain(ctx context.Context, start time.Time, end time.Time, limit int) (map[string]int, error)
+}
@@ -26,10 +26,16 @@ import (
 // Notification contains functions for creating and getting notifications.
 type Notification interface {
 	// GetNotifications returns a slice of notifications that pertain to the given accountID.
+	// Notifications filtered out by the account's notification policy are not included.
 	//
 	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
 	GetAccountNotifications(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, excludeTypes []string) ([]*gtsmodel.Notification, error)
 
+	// CountAccountNotificationsSince counts notifications that pertain to the given
+	// accountID with an ID higher (ie., newer) than sinceID, counting at most limit.
+	// This is useful for getting unread notification counts based on a marker.
+	CountAccountNotificationsSince(ctx context.Context, accountID string, sinceID string, limit int) (int, error)
+
 	// GetNotification returns one notification according to its id.
 	GetNotificationByID(ctx context.Context, id string) (*gtsmodel.Notification, error)
 
@@ -65,6 +71,16 @@ type Notification interface {
 	// At least one parameter must not be an empty string.
 	DeleteNotifications(ctx context.Context, types []string, targetAccountID string, originAccountID string) error
 
+	// UnfilterNotifications marks all notifications targeting targetAccountID
+	// and originating from originAccountID that were filtered out by the
+	// target's notification policy as no longer filtered.
+	UnfilterNotifications(ctx context.Context, targetAccountID string, originAccountID string) error
+
+	// DeleteFilteredNotifications deletes all notifications targeting
+	// targetAccountID and originating from originAccountID that were
+	// filtered out by the target's notification policy.
+	DeleteFilteredNotifications(ctx context.Context, targetAccountID string, originAccountID string) error
+
 	// DeleteNotificationsForStatus deletes all notifications that relate to
 	// the given statusID. This function is useful when a status has been deleted,
 	// and so notifications relating to that status must also be deleted.
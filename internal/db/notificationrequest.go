@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// NotificationRequest handles getting/creation/deletion of notification
+// requests, and of the permissions that let accounts bypass the
+// notification policy of another account.
+type NotificationRequest interface {
+	// GetNotificationRequestByID gets one notification request by its db id.
+	GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error)
+
+	// GetNotificationRequest gets the notification request of the given
+	// account holding notifications originating from fromAccountID.
+	GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error)
+
+	// GetAccountNotificationRequests gets a page of notification
+	// requests of the given account, newest first.
+	GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error)
+
+	// CountAccountNotificationRequests returns the number of notification
+	// requests of the given account, and the total number of notifications
+	// held in them.
+	CountAccountNotificationRequests(ctx context.Context, accountID string) (requests int, notifications int, err error)
+
+	// PutNotificationRequest puts the given notification request in the database.
+	PutNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error
+
+	// UpdateNotificationRequest updates the given notification request in the database. If no columns are given, all columns will be updated.
+	UpdateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest, columns ...string) error
+
+	// IncrementNotificationRequest increments the notifications count of the
+	// notification request with given id in the database, and bumps updated_at.
+	IncrementNotificationRequest(ctx context.Context, id string) error
+
+	// DeleteNotificationRequestByID deletes one notification request by its db id.
+	DeleteNotificationRequestByID(ctx context.Context, id string) error
+
+	// IsNotificationPermitted returns whether the given account has permitted
+	// notifications originating from fromAccountID to bypass its notification policy.
+	IsNotificationPermitted(ctx context.Context, accountID string, fromAccountID string) (bool, error)
+
+	// PutNotificationPermission puts the given notification permission in the database.
+	PutNotificationPermission(ctx context.Context, permission *gtsmodel.NotificationPermission) error
+
+	// DeleteAccountNotificationRequests deletes all notification requests and
+	// notification permissions of, or originating from, the given account.
+	DeleteAccountNotificationRequests(ctx context.Context, accountID string) error
+}
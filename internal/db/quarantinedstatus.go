@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// QuarantinedStatus handles getting/creation/deletion of statuses held back by the spam filter.
+type QuarantinedStatus interface {
+	// GetQuarantinedStatusByID gets one quarantined status by its db id.
+	GetQuarantinedStatusByID(ctx context.Context, id string) (*gtsmodel.QuarantinedStatus, error)
+
+	// GetQuarantinedStatuses gets a page of quarantined statuses, newest first.
+	GetQuarantinedStatuses(ctx context.Context, page *paging.Page) ([]*gtsmodel.QuarantinedStatus, error)
+
+	// PutQuarantinedStatus puts the given quarantined status in the database.
+	PutQuarantinedStatus(ctx context.Context, status *gtsmodel.QuarantinedStatus) error
+
+	// DeleteQuarantinedStatusByID deletes one quarantined status by its db id.
+	DeleteQuarantinedStatusByID(ctx context.Context, id string) error
+
+	// DeleteQuarantinedStatusesOlderThan deletes all quarantined
+	// statuses created before the given time, returning the count.
+	DeleteQuarantinedStatusesOlderThan(ctx context.Context, olderThan time.Time) (int, error)
+}
@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// QueuedMessage handles journaling of worker messages, so they can be replayed after a crash.
+type QueuedMessage interface {
+	// PutQueuedMessage puts the given queued message in the database.
+	PutQueuedMessage(ctx context.Context, msg *gtsmodel.QueuedMessage) error
+
+	// DeleteQueuedMessageByID deletes one queued message by its db id.
+	DeleteQueuedMessageByID(ctx context.Context, id string) error
+
+	// GetQueuedMessages gets all queued messages in the database, oldest first.
+	GetQueuedMessages(ctx context.Context) ([]*gtsmodel.QueuedMessage, error)
+}
@@ -59,6 +59,11 @@ type Relationship interface {
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, error)
 
+	// GetRelationships retrieves the relationships of each of the targetAccounts to the requestingAccount,
+	// using a fixed number of queries regardless of how many targets are given. Relationships are returned
+	// in the same order as targetAccounts; unknown target IDs yield a relationship with all fields false.
+	GetRelationships(ctx context.Context, requestingAccount string, targetAccounts []string) ([]*gtsmodel.Relationship, error)
+
 	// GetFollowByID fetches follow with given ID from the database.
 	GetFollowByID(ctx context.Context, id string) (*gtsmodel.Follow, error)
 
@@ -24,12 +24,26 @@ import (
 )
 
 type Search interface {
-	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
+	// SearchForAccounts uses the given query text to search for accounts by username, display name and note.
+	// If following is true, only accounts that accountID follows will be searched.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
 	// SearchForStatuses uses the given query text to search for statuses created by accountID, or in reply to accountID.
+	// If full text search is enabled, statuses are instead searched for in accountID's full text search entries.
 	SearchForStatuses(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
+	// PutStatusSearchEntry indexes the given status in the full text search entries of accountID, replacing any existing entry.
+	PutStatusSearchEntry(ctx context.Context, accountID string, status *gtsmodel.Status) error
+
+	// UpdateStatusSearchEntries updates the text of all full text search entries for the given status.
+	UpdateStatusSearchEntries(ctx context.Context, status *gtsmodel.Status) error
+
+	// DeleteStatusSearchEntry removes the given status ID from the full text search entries of accountID.
+	DeleteStatusSearchEntry(ctx context.Context, accountID string, statusID string) error
+
+	// DeleteStatusSearchEntriesForStatus removes all full text search entries for the given status ID.
+	DeleteStatusSearchEntriesForStatus(ctx context.Context, statusID string) error
+
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
 }
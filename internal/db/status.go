@@ -19,8 +19,10 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Status contains functions for getting statuses, creating statuses, and checking various other fields on statuses.
@@ -43,9 +45,16 @@ type Status interface {
 	// PopulateStatus ensures that all sub-models of a status are populated (e.g. mentions, attachments, etc).
 	PopulateStatus(ctx context.Context, status *gtsmodel.Status) error
 
-	// PutStatus stores one status in the database.
+	// PutStatus stores one status in the database, attaching its media in the
+	// same transaction. If any media is owned by another account, or already
+	// attached elsewhere, nothing is stored and a *MediaClaimError is returned.
 	PutStatus(ctx context.Context, status *gtsmodel.Status) error
 
+	// PutStatusUpsert atomically stores one status in the database, unless
+	// a status with the same URI already exists. The stored status is
+	// returned, which is the existing status if the given one lost out.
+	PutStatusUpsert(ctx context.Context, status *gtsmodel.Status) (*gtsmodel.Status, error)
+
 	// UpdateStatus updates one status in the database.
 	UpdateStatus(ctx context.Context, status *gtsmodel.Status, columns ...string) error
 
@@ -55,6 +64,10 @@ type Status interface {
 	// GetStatuses gets a slice of statuses corresponding to the given status IDs.
 	GetStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Status, error)
 
+	// GetStatusInteractionCountsSince returns the amount of faves and boosts created after the
+	// given time per status ID, for (at most) the limit most faved and limit most boosted statuses.
+	GetStatusInteractionCountsSince(ctx context.Context, since time.Time, limit int) (map[string]int, error)
+
 	// GetStatusesUsingEmoji fetches all status models using emoji with given ID stored in their 'emojis' column.
 	GetStatusesUsingEmoji(ctx context.Context, emojiID string) ([]*gtsmodel.Status, error)
 
@@ -64,15 +77,29 @@ type Status interface {
 	// CountStatusReplies returns the number of stored *direct* (i.e. in_reply_to_id column) replies to this status ID.
 	CountStatusReplies(ctx context.Context, statusID string) (int, error)
 
+	// CountStatusRepliesIn returns the number of stored *direct* replies to each of the given status IDs, keyed by status ID.
+	CountStatusRepliesIn(ctx context.Context, statusIDs []string) (map[string]int, error)
+
 	// GetStatusBoosts returns all statuses whose boost_of_id column refer to given status ID.
 	GetStatusBoosts(ctx context.Context, statusID string) ([]*gtsmodel.Status, error)
 
+	// GetStatusBoostsPage returns boosts of the given status ID, using the provided (optional)
+	// paging parameters. Boosts are returned sorted by boost ID descending (ie., most
+	// recently boosted first), regardless of paging direction.
+	GetStatusBoostsPage(ctx context.Context, statusID string, page *paging.Page) ([]*gtsmodel.Status, error)
+
 	// CountStatusBoosts returns the number of stored boosts for status ID.
 	CountStatusBoosts(ctx context.Context, statusID string) (int, error)
 
+	// CountStatusBoostsIn returns the number of stored boosts for each of the given status IDs, keyed by status ID.
+	CountStatusBoostsIn(ctx context.Context, statusIDs []string) (map[string]int, error)
+
 	// IsStatusBoostedBy checks whether the given status ID is boosted by account ID.
 	IsStatusBoostedBy(ctx context.Context, statusID string, accountID string) (bool, error)
 
+	// GetBoostedStatusIDsIn returns those of the given status IDs which are boosted by account ID.
+	GetBoostedStatusIDsIn(ctx context.Context, statusIDs []string, accountID string) ([]string, error)
+
 	// GetStatusParents gets the parent statuses of a given status.
 	GetStatusParents(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, error)
 
@@ -81,4 +108,7 @@ type Status interface {
 
 	// IsStatusBookmarkedBy checks if a given status has been bookmarked by a given account ID
 	IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, error)
+
+	// GetBookmarkedStatusIDsIn returns those of the given status IDs which are bookmarked by account ID.
+	GetBookmarkedStatusIDsIn(ctx context.Context, statusIDs []string, accountID string) ([]string, error)
 }
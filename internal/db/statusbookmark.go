@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type StatusBookmark interface {
@@ -32,11 +33,12 @@ type StatusBookmark interface {
 	GetStatusBookmarkID(ctx context.Context, accountID string, statusID string) (string, error)
 
 	// GetStatusBookmarks retrieves status bookmarks created by the given accountID,
-	// and using the provided parameters. If limit is < 0 then no limit will be set.
+	// using the provided (optional) paging parameters. Bookmarks are returned sorted
+	// by bookmark ID descending (newest first), regardless of paging direction.
 	//
 	// This function is primarily useful for paging through bookmarks in a sort of
-	// timeline view.
-	GetStatusBookmarks(ctx context.Context, accountID string, limit int, maxID string, minID string) ([]*gtsmodel.StatusBookmark, error)
+	// timeline view, and (with nil page) for exporting all of an account's bookmarks.
+	GetStatusBookmarks(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.StatusBookmark, error)
 
 	// PutStatusBookmark inserts the given statusBookmark into the database.
 	PutStatusBookmark(ctx context.Context, statusBookmark *gtsmodel.StatusBookmark) error
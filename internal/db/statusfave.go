@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type StatusFave interface {
@@ -30,10 +31,20 @@ type StatusFave interface {
 	// GetStatusFave returns one status fave with the given id.
 	GetStatusFaveByID(ctx context.Context, id string) (*gtsmodel.StatusFave, error)
 
-	// GetStatusFaves returns a slice of faves/likes of the status with given ID.
+	// GetStatusFaves returns a slice of faves/likes of the status with given ID, newest first.
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusFaves(ctx context.Context, statusID string) ([]*gtsmodel.StatusFave, error)
 
+	// GetStatusFavesPage returns a slice of faves/likes of the status with given ID,
+	// using the provided (optional) paging parameters. Faves are returned sorted by fave ID
+	// descending (ie., most recently faved first), regardless of paging direction.
+	GetStatusFavesPage(ctx context.Context, statusID string, page *paging.Page) ([]*gtsmodel.StatusFave, error)
+
+	// GetStatusFavesByAccount returns a slice of faves/likes created by the account with given ID,
+	// using the provided (optional) paging parameters. Faves are returned sorted by fave ID
+	// descending (ie., most recently faved first), regardless of paging direction.
+	GetStatusFavesByAccount(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.StatusFave, error)
+
 	// PopulateStatusFave ensures that all sub-models of a fave are populated (account, status, etc).
 	PopulateStatusFave(ctx context.Context, statusFave *gtsmodel.StatusFave) error
 
@@ -65,6 +76,12 @@ type StatusFave interface {
 	// CountStatusFaves returns the number of status favourites registered for status with ID.
 	CountStatusFaves(ctx context.Context, statusID string) (int, error)
 
+	// CountStatusFavesIn returns the number of status favourites registered for each of the given status IDs, keyed by status ID.
+	CountStatusFavesIn(ctx context.Context, statusIDs []string) (map[string]int, error)
+
 	// IsStatusFavedBy returns whether the status with ID has been favourited by account with ID.
 	IsStatusFavedBy(ctx context.Context, statusID string, accountID string) (bool, error)
+
+	// GetFavedStatusIDsIn returns those of the given status IDs which have been favourited by account with ID.
+	GetFavedStatusIDsIn(ctx context.Context, statusIDs []string, accountID string) ([]string, error)
 }
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -36,4 +37,19 @@ type Tag interface {
 
 	// GetTags gets multiple tags.
 	GetTags(ctx context.Context, ids []string) ([]*gtsmodel.Tag, error)
+
+	// UpdateTag updates the given tag in the database. If no columns are given, all columns will be updated.
+	UpdateTag(ctx context.Context, tag *gtsmodel.Tag, columns ...string) error
+
+	// PutTagUse inserts the given tag use in the database.
+	PutTagUse(ctx context.Context, use *gtsmodel.TagUse) error
+
+	// GetTagUsesSince gets all uses of any tag created after the given time.
+	GetTagUsesSince(ctx context.Context, since time.Time) ([]*gtsmodel.TagUse, error)
+
+	// GetTagUsesForTagSince gets all uses of the given tag ID created after the given time.
+	GetTagUsesForTagSince(ctx context.Context, tagID string, since time.Time) ([]*gtsmodel.TagUse, error)
+
+	// DeleteTagUsesOlderThan deletes all tag uses created before the given time.
+	DeleteTagUsesOlderThan(ctx context.Context, olderThan time.Time) error
 }
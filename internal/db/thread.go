@@ -40,6 +40,10 @@ type Thread interface {
 	// by given account. Empty thread ID will return false early.
 	IsThreadMutedByAccount(ctx context.Context, threadID string, accountID string) (bool, error)
 
+	// GetMutedThreadIDsIn returns those of the
+	// given thread IDs which are muted by account.
+	GetMutedThreadIDsIn(ctx context.Context, threadIDs []string, accountID string) ([]string, error)
+
 	// PutThreadMute inserts a new threadMute.
 	PutThreadMute(ctx context.Context, threadMute *gtsmodel.ThreadMute) error
 
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -43,6 +44,12 @@ type User interface {
 	// GetUserByConfirmationToken returns one user by its confirmation token, or an error if something goes wrong.
 	GetUserByConfirmationToken(ctx context.Context, confirmationToken string) (*gtsmodel.User, error)
 
+	// GetUserByDeletionCancelToken returns one user by its deletion cancel token, or an error if something goes wrong.
+	GetUserByDeletionCancelToken(ctx context.Context, deletionCancelToken string) (*gtsmodel.User, error)
+
+	// GetUsersScheduledForDeletion returns all users whose accounts are scheduled to be deleted.
+	GetUsersScheduledForDeletion(ctx context.Context) ([]*gtsmodel.User, error)
+
 	// PopulateUser populates the struct pointers on the given user.
 	PopulateUser(ctx context.Context, user *gtsmodel.User) error
 
@@ -52,6 +59,12 @@ type User interface {
 	// UpdateUser updates one user by its primary key, updating either only the specified columns, or all of them.
 	UpdateUser(ctx context.Context, user *gtsmodel.User, columns ...string) error
 
+	// IncrementUserFailedSignIns atomically increments the failed sign in count of the user
+	// with the given ID, returning the new count and the time until which sign in was locked
+	// for them, if at all. If a lock expired before resetBefore, the count is reset and the
+	// lock cleared beforehand, as the user's failed sign ins are then considered stale.
+	IncrementUserFailedSignIns(ctx context.Context, userID string, resetBefore time.Time) (int, time.Time, error)
+
 	// DeleteUserByID deletes one user by its ID.
 	DeleteUserByID(ctx context.Context, userID string) error
 }
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+var archiveTemplate = registerTemplate(
+	"email_archive.tmpl",
+	"GoToSocial Account Archive Ready",
+)
+
+// ArchiveData represents data passed into the account archive email template.
+type ArchiveData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Formatted time at which the download link will expire.
+	ExpiryTime string
+	// Link to present to the receiver to click on and download the archive.
+	// Should be a full link with protocol eg., https://example.org/fileserver/.../archive.zip?token=some-long-token
+	DownloadLink string
+}
+
+func (s *sender) SendArchiveEmail(toAddress string, data ArchiveData) error {
+	return s.sendTemplate(archiveTemplate, data, toAddress)
+}
@@ -31,13 +31,13 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
 
-func (s *sender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+func (s *sender) sendTemplate(tmpl emailTemplate, data any, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
-	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
+	if err := s.template.ExecuteTemplate(buf, tmpl.name, data); err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), s.from, toAddresses...)
+	msg, err := assembleMessage(tmpl.subject, buf.String(), s.from, toAddresses...)
 	if err != nil {
 		return err
 	}
@@ -59,7 +59,16 @@ func loadTemplates(templateBaseDir string) (*template.Template, error) {
 	}
 
 	// look for all templates that start with 'email_'
-	return template.ParseGlob(filepath.Join(templateBaseDir, "email_*"))
+	t, err := template.ParseGlob(filepath.Join(templateBaseDir, "email_*"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTemplates(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
 }
 
 // assembleMessage assembles a valid email message following:
@@ -17,9 +17,9 @@
 
 package email
 
-const (
-	confirmTemplate = "email_confirm.tmpl"
-	confirmSubject  = "GoToSocial Email Confirmation"
+var confirmTemplate = registerTemplate(
+	"email_confirm.tmpl",
+	"GoToSocial Email Confirmation",
 )
 
 // ConfirmData represents data passed into the confirm email address template.
@@ -36,5 +36,5 @@ type ConfirmData struct {
 }
 
 func (s *sender) SendConfirmEmail(toAddress string, data ConfirmData) error {
-	return s.sendTemplate(confirmTemplate, confirmSubject, data, toAddress)
+	return s.sendTemplate(confirmTemplate, data, toAddress)
 }
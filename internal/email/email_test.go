@@ -66,6 +66,65 @@ func (suite *EmailTestSuite) TestTemplateReset() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Password Reset\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because a password reset has been requested for your account on https://example.org.\r\n\r\nTo reset your password, paste the following in your browser's address bar:\r\n\r\nhttps://example.org/reset_email?token=ee24f71d-e615-43f9-afae-385c0799b7fa\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateDeletion() {
+	deletionData := email.DeletionData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		DeletionTime: "Mar 31 2024 12:00:00 UTC",
+		CancelLink:   "https://example.org/cancel_deletion?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}
+
+	suite.sender.SendDeletionEmail("user@example.org", deletionData)
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Deletion Scheduled\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because deletion of your account on https://example.org has been requested.\r\n\r\nYour account has been suspended, and will be permanently deleted at Mar 31 2024 12:00:00 UTC.\r\n\r\nIf you did not mean to delete your account, you can cancel the deletion before then by pasting the following in your browser's address bar:\r\n\r\nhttps://example.org/cancel_deletion?token=ee24f71d-e615-43f9-afae-385c0799b7fa\r\n\r\nIf you believe you've been sent this email in error, contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateArchive() {
+	archiveData := email.ArchiveData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ExpiryTime:   "Mar 31 2024 12:00:00 UTC",
+		DownloadLink: "https://example.org/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/archive/original/01HT0GQ2RSB0DGT2TGSAH5QSQ6.zip?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}
+
+	suite.sender.SendArchiveEmail("user@example.org", archiveData)
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Archive Ready\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because you requested an archive of your account data on https://example.org.\r\n\r\nYour archive is ready, and can be downloaded by pasting the following in your browser's address bar:\r\n\r\nhttps://example.org/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/archive/original/01HT0GQ2RSB0DGT2TGSAH5QSQ6.zip?token=ee24f71d-e615-43f9-afae-385c0799b7fa\r\n\r\nThis link will expire at Mar 31 2024 12:00:00 UTC. After that, you will need to request a new archive.\r\n\r\nIf you did not request an archive of your account data, contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateLockout() {
+	lockoutData := email.LockoutData{
+		Username:       "test",
+		InstanceURL:    "https://example.org",
+		InstanceName:   "Test Instance",
+		FailedAttempts: 5,
+		LockedUntil:    "Mar 31 2024 12:00:00 UTC",
+	}
+
+	suite.sender.SendLockoutEmail("user@example.org", lockoutData)
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign In Locked\r\n\r\nHello test!\r\n\r\nYou are receiving this mail because there have been 5 failed attempts in a row to sign in to your account on https://example.org with an incorrect password.\r\n\r\nTo protect your account, signing in has been temporarily locked until Mar 31 2024 12:00:00 UTC. Further failed attempts will extend the lock.\r\n\r\nIf this was you, you can simply try again after that time. If you've forgotten your password, contact the administrator of https://example.org.\r\n\r\nIf this wasn't you, someone may be trying to guess your password. Make sure you're using a strong password that you don't use anywhere else.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestNoopSenderNotConfigured() {
+	// A noop sender without a callback
+	// shouldn't pretend to have sent.
+	sender, err := email.NewNoopSender(nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = sender.SendTestEmail("user@example.org", email.TestData{
+		SendingUsername: "admin",
+		InstanceURL:     "https://example.org",
+		InstanceName:    "Test Instance",
+	})
+	suite.ErrorIs(err, email.ErrNotConfigured)
+	suite.EqualError(err, "email sending not configured")
+}
+
 func (suite *EmailTestSuite) TestTemplateReportRemoteToLocal() {
 	// Someone from a remote instance has reported one of our users.
 	reportData := email.NewReportData{
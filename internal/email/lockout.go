@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+var lockoutTemplate = registerTemplate(
+	"email_lockout.tmpl",
+	"GoToSocial Sign In Locked",
+)
+
+// LockoutData represents data passed into the sign in lockout email template.
+type LockoutData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Number of failed sign in attempts that led to the lockout.
+	FailedAttempts int
+	// Formatted time until which sign in is locked.
+	LockedUntil string
+}
+
+func (s *sender) SendLockoutEmail(toAddress string, data LockoutData) error {
+	return s.sendTemplate(lockoutTemplate, data, toAddress)
+}
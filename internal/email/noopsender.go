@@ -19,16 +19,22 @@ package email
 
 import (
 	"bytes"
+	"errors"
 	"text/template"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+// ErrNotConfigured is returned by a noop sender without
+// a sendCallback, to indicate that the email was not sent.
+var ErrNotConfigured = errors.New("email sending not configured")
+
 // NewNoopSender returns a no-op email sender that will just execute the given sendCallback
 // every time it would otherwise send an email to the given toAddress with the given message value.
 //
-// Passing a nil function is also acceptable, in which case the send functions will just return nil.
+// Passing a nil function is also acceptable, in which case the send functions will
+// return ErrNotConfigured, as used when no SMTP server is configured for the instance.
 func NewNoopSender(sendCallback func(toAddress string, message string)) (Sender, error) {
 	templateBaseDir := config.GetWebTemplateBaseDir()
 
@@ -49,41 +55,56 @@ type noopSender struct {
 }
 
 func (s *noopSender) SendConfirmEmail(toAddress string, data ConfirmData) error {
-	return s.sendTemplate(confirmTemplate, confirmSubject, data, toAddress)
+	return s.sendTemplate(confirmTemplate, data, toAddress)
 }
 
 func (s *noopSender) SendResetEmail(toAddress string, data ResetData) error {
-	return s.sendTemplate(resetTemplate, resetSubject, data, toAddress)
+	return s.sendTemplate(resetTemplate, data, toAddress)
+}
+
+func (s *noopSender) SendDeletionEmail(toAddress string, data DeletionData) error {
+	return s.sendTemplate(deletionTemplate, data, toAddress)
+}
+
+func (s *noopSender) SendArchiveEmail(toAddress string, data ArchiveData) error {
+	return s.sendTemplate(archiveTemplate, data, toAddress)
+}
+
+func (s *noopSender) SendLockoutEmail(toAddress string, data LockoutData) error {
+	return s.sendTemplate(lockoutTemplate, data, toAddress)
 }
 
 func (s *noopSender) SendTestEmail(toAddress string, data TestData) error {
-	return s.sendTemplate(testTemplate, testSubject, data, toAddress)
+	return s.sendTemplate(testTemplate, data, toAddress)
 }
 
 func (s *noopSender) SendNewReportEmail(toAddresses []string, data NewReportData) error {
-	return s.sendTemplate(newReportTemplate, newReportSubject, data, toAddresses...)
+	return s.sendTemplate(newReportTemplate, data, toAddresses...)
 }
 
 func (s *noopSender) SendReportClosedEmail(toAddress string, data ReportClosedData) error {
-	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
+	return s.sendTemplate(reportClosedTemplate, data, toAddress)
 }
 
-func (s *noopSender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+func (s *noopSender) sendTemplate(tmpl emailTemplate, data any, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
-	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
+	if err := s.template.ExecuteTemplate(buf, tmpl.name, data); err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), "test@example.org", toAddresses...)
+	msg, err := assembleMessage(tmpl.subject, buf.String(), "test@example.org", toAddresses...)
 	if err != nil {
 		return err
 	}
 
 	log.Tracef(nil, "NOT SENDING email to %s with contents: %s", toAddresses, msg)
 
-	if s.sendCallback != nil {
-		s.sendCallback(toAddresses[0], string(msg))
+	if s.sendCallback == nil {
+		// Nothing was actually sent, so
+		// don't pretend that it was.
+		return ErrNotConfigured
 	}
 
+	s.sendCallback(toAddresses[0], string(msg))
 	return nil
 }
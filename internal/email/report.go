@@ -17,11 +17,15 @@
 
 package email
 
-const (
-	newReportTemplate    = "email_new_report.tmpl"
-	newReportSubject     = "GoToSocial New Report"
-	reportClosedTemplate = "email_report_closed.tmpl"
-	reportClosedSubject  = "GoToSocial Report Closed"
+var (
+	newReportTemplate = registerTemplate(
+		"email_new_report.tmpl",
+		"GoToSocial New Report",
+	)
+	reportClosedTemplate = registerTemplate(
+		"email_report_closed.tmpl",
+		"GoToSocial Report Closed",
+	)
 )
 
 type NewReportData struct {
@@ -40,7 +44,7 @@ type NewReportData struct {
 }
 
 func (s *sender) SendNewReportEmail(toAddresses []string, data NewReportData) error {
-	return s.sendTemplate(newReportTemplate, newReportSubject, data, toAddresses...)
+	return s.sendTemplate(newReportTemplate, data, toAddresses...)
 }
 
 type ReportClosedData struct {
@@ -60,5 +64,5 @@ type ReportClosedData struct {
 }
 
 func (s *sender) SendReportClosedEmail(toAddress string, data ReportClosedData) error {
-	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
+	return s.sendTemplate(reportClosedTemplate, data, toAddress)
 }
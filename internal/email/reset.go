@@ -17,9 +17,9 @@
 
 package email
 
-const (
-	resetTemplate = "email_reset.tmpl"
-	resetSubject  = "GoToSocial Password Reset"
+var resetTemplate = registerTemplate(
+	"email_reset.tmpl",
+	"GoToSocial Password Reset",
 )
 
 // ResetData represents data passed into the reset email address template.
@@ -36,5 +36,5 @@ type ResetData struct {
 }
 
 func (s *sender) SendResetEmail(toAddress string, data ResetData) error {
-	return s.sendTemplate(resetTemplate, resetSubject, data, toAddress)
+	return s.sendTemplate(resetTemplate, data, toAddress)
 }
@@ -33,6 +33,15 @@ type Sender interface {
 	// SendResetEmail sends a 'reset your password' style email to the given toAddress, with the given data.
 	SendResetEmail(toAddress string, data ResetData) error
 
+	// SendDeletionEmail sends a 'your account will be deleted' style email to the given toAddress, with the given data.
+	SendDeletionEmail(toAddress string, data DeletionData) error
+
+	// SendArchiveEmail sends a 'your account archive is ready' style email to the given toAddress, with the given data.
+	SendArchiveEmail(toAddress string, data ArchiveData) error
+
+	// SendLockoutEmail sends a 'sign in to your account has been locked' style email to the given toAddress, with the given data.
+	SendLockoutEmail(toAddress string, data LockoutData) error
+
 	// SendTestEmail sends a 'testing email sending' style email to the given toAddress, with the given data.
 	SendTestEmail(toAddress string, data TestData) error
 
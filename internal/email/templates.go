@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// emailTemplate describes one kind of
+// email that can be sent by a Sender.
+type emailTemplate struct {
+	name    string // file name of the template, eg., "email_confirm.tmpl"
+	subject string // subject line of the email
+}
+
+// registry contains all email templates registered
+// with registerTemplate. Every email sent goes through
+// one of these, and is assembled in the same way, so
+// new emails should be added by registering a template
+// alongside the existing ones in web/template.
+var registry []emailTemplate
+
+// registerTemplate adds a template with the given
+// file name and subject to the registry, returning it.
+func registerTemplate(name string, subject string) emailTemplate {
+	t := emailTemplate{name: name, subject: subject}
+	registry = append(registry, t)
+	return t
+}
+
+// checkTemplates returns an error if any registered
+// template is missing from the given loaded templates,
+// so that this is caught on startup rather than when
+// an email is first sent.
+func checkTemplates(t *template.Template) error {
+	for _, et := range registry {
+		if t.Lookup(et.name) == nil {
+			return fmt.Errorf("email template %s not found", et.name)
+		}
+	}
+	return nil
+}
@@ -17,9 +17,9 @@
 
 package email
 
-const (
-	testTemplate = "email_test.tmpl"
-	testSubject  = "GoToSocial Test Email"
+var testTemplate = registerTemplate(
+	"email_test.tmpl",
+	"GoToSocial Test Email",
 )
 
 type TestData struct {
@@ -32,5 +32,5 @@ type TestData struct {
 }
 
 func (s *sender) SendTestEmail(toAddress string, data TestData) error {
-	return s.sendTemplate(testTemplate, testSubject, data, toAddress)
+	return s.sendTemplate(testTemplate, data, toAddress)
 }
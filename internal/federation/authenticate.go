@@ -135,7 +135,7 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 
 	var (
 		pubKeyIDStr = pubKeyID.String()
-		isLocal     = (pubKeyID.Host == config.GetHost())
+		isLocal     = config.IsLocalHost(pubKeyID.Host)
 		pubKeyAuth  *PubKeyAuth
 		errWithCode gtserror.WithCode
 	)
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/http"
 	"net/url"
 	"time"
 
@@ -34,10 +35,16 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// accountNotFoundMax is the number of consecutive
+// 404 Not Found responses to account dereferences,
+// after which the account is considered to be gone.
+const accountNotFoundMax = 3
+
 // accountFresh returns true if the given account is
 // still considered "fresh" according to the desired
 // freshness window (falls back to default if nil).
@@ -45,8 +52,8 @@ import (
 // Local accounts will always be considered fresh because
 // there's no remote state that could have changed.
 //
-// True is also returned for suspended accounts, since
-// we'll never want to try to refresh one of these.
+// True is also returned for suspended and tombstoned
+// accounts, since we'll never want to refresh these.
 //
 // Return value of false indicates that the account
 // is not fresh and should be refreshed from remote.
@@ -55,7 +62,7 @@ func accountFresh(
 	window *FreshnessWindow,
 ) bool {
 	if window == nil {
-		window = DefaultAccountFreshness
+		window = DefaultAccountFreshness()
 	}
 
 	if account.IsLocal() {
@@ -70,6 +77,12 @@ func accountFresh(
 		return true
 	}
 
+	if account.IsTombstoned() {
+		// Account is gone,
+		// no point refreshing.
+		return true
+	}
+
 	if account.IsInstance() &&
 		!account.IsNew() {
 		// Existing instance account.
@@ -146,10 +159,18 @@ func (d *Dereferencer) getAccountByURI(ctx context.Context, requestUser string,
 
 	if account == nil {
 		// Ensure that this is isn't a search for a local account.
-		if uri.Host == config.GetHost() || uri.Host == config.GetAccountDomain() {
+		if config.IsLocalHost(uri.Host) {
 			return nil, nil, gtserror.SetUnretrievable(err) // this will be db.ErrNoEntries
 		}
 
+		metrics.DereferenceCache("account", metrics.CacheMiss)
+
+		// Don't retry a dereference that
+		// failed recently, it'll fail again.
+		if err := d.recentDerefFailure(uriStr); err != nil {
+			return nil, nil, err
+		}
+
 		// Create and pass-through a new bare-bones model for dereferencing.
 		return d.enrichAccountSafely(ctx, requestUser, uri, &gtsmodel.Account{
 			ID:     id.NewULID(),
@@ -159,6 +180,8 @@ func (d *Dereferencer) getAccountByURI(ctx context.Context, requestUser string,
 	}
 
 	if accountFresh(account, nil) {
+		metrics.DereferenceCache("account", metrics.CacheHit)
+
 		// This is an existing account that is up-to-date,
 		// before returning ensure it is fully populated.
 		if err := d.state.DB.PopulateAccount(ctx, account); err != nil {
@@ -168,6 +191,18 @@ func (d *Dereferencer) getAccountByURI(ctx context.Context, requestUser string,
 		return account, nil, nil
 	}
 
+	metrics.DereferenceCache("account", metrics.CacheStale)
+
+	if d.recentDerefFailure(uriStr) != nil {
+		// Refetch failed recently, serve the
+		// existing account for now, populated.
+		if err := d.state.DB.PopulateAccount(ctx, account); err != nil {
+			log.Errorf(ctx, "error populating existing account: %v", err)
+		}
+
+		return account, nil, nil
+	}
+
 	// Try to update existing account model.
 	latest, accountable, err := d.enrichAccountSafely(ctx,
 		requestUser,
@@ -219,7 +254,7 @@ func (d *Dereferencer) getAccountByUsernameDomain(
 	username string,
 	domain string,
 ) (*gtsmodel.Account, ap.Accountable, error) {
-	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+	if config.IsLocalHost(domain) {
 		// We do local lookups using an empty domain,
 		// else it will fail the db search below.
 		domain = ""
@@ -418,7 +453,7 @@ func (d *Dereferencer) enrichAccountSafely(
 		accountable,
 	)
 
-	if gtserror.StatusCode(err) >= 400 {
+	if code := gtserror.StatusCode(err); code >= 400 {
 		if account.IsNew() {
 			// This was a new account enrich
 			// attempt which failed before we
@@ -434,8 +469,28 @@ func (d *Dereferencer) enrichAccountSafely(
 		// but don't return early. We can still
 		// return the model we had stored already.
 		account.FetchedAt = time.Now()
-		if err := d.state.DB.UpdateAccount(ctx, account, "fetched_at"); err != nil {
-			log.Error(ctx, "error updating %s fetched_at: %v", uriStr, err)
+		columns := []string{"fetched_at"}
+
+		switch {
+		// 410 Gone means the account
+		// has definitely been deleted.
+		case code == http.StatusGone:
+			d.tombstoneAccount(ctx, account)
+
+		// 404 may be a temporary blip,
+		// so only tombstone the account
+		// once it persists over attempts.
+		case code == http.StatusNotFound:
+			account.NotFoundCount++
+			if account.NotFoundCount >= accountNotFoundMax {
+				d.tombstoneAccount(ctx, account)
+			} else {
+				columns = append(columns, "not_found_count")
+			}
+		}
+
+		if err := d.state.DB.UpdateAccount(ctx, account, columns...); err != nil {
+			log.Errorf(ctx, "error updating %s fetched_at: %v", uriStr, err)
 		}
 	}
 
@@ -443,21 +498,45 @@ func (d *Dereferencer) enrichAccountSafely(
 	// we're done.
 	unlock()
 
-	if errors.Is(err, db.ErrAlreadyExists) {
-		// Ensure AP model isn't set,
-		// otherwise this indicates WE
-		// enriched the account.
-		apubAcc = nil
+	return latest, apubAcc, err
+}
 
-		// DATA RACE! We likely lost out to another goroutine
-		// in a call to db.Put(Account). Look again in DB by URI.
-		latest, err = d.state.DB.GetAccountByURI(ctx, account.URI)
-		if err != nil {
-			err = gtserror.Newf("error getting account %s from database after race: %w", uriStr, err)
-		}
+// tombstoneAccount marks the given (stored) remote
+// account as gone from its instance. The account is
+// suspended locally, and all follows + follow requests
+// to / from it are removed. Tombstoned accounts are not
+// refreshed again, unless an admin clears the tombstone.
+//
+// Note that the tombstone and suspension columns are
+// updated in the database here, fetched_at is left to
+// the caller.
+func (d *Dereferencer) tombstoneAccount(ctx context.Context, account *gtsmodel.Account) {
+	log.Infof(ctx, "account %s is gone, tombstoning it", account.URI)
+
+	now := time.Now()
+	account.TombstonedAt = now
+	account.SuspendedAt = now
+	account.SuspensionOrigin = account.ID
+	account.NotFoundCount = 0
+
+	if err := d.state.DB.UpdateAccount(ctx,
+		account,
+		"tombstoned_at",
+		"suspended_at",
+		"suspension_origin",
+		"not_found_count",
+	); err != nil {
+		log.Errorf(ctx, "error tombstoning account %s: %v", account.URI, err)
+		return
 	}
 
-	return latest, apubAcc, err
+	if err := d.state.DB.DeleteAccountFollows(ctx, account.ID); err != nil {
+		log.Errorf(ctx, "error deleting follows of account %s: %v", account.URI, err)
+	}
+
+	if err := d.state.DB.DeleteAccountFollowRequests(ctx, account.ID); err != nil {
+		log.Errorf(ctx, "error deleting follow requests of account %s: %v", account.URI, err)
+	}
 }
 
 // enrichAccount will enrich the given account, whether a
@@ -476,8 +555,13 @@ func (d *Dereferencer) enrichAccount(
 		return nil, nil, gtserror.Newf("couldn't create transport: %w", err)
 	}
 
+	// Cache key of any webfinger
+	// result used to discover URI.
+	var fingerKey string
+
 	if account.Username != "" {
 		// A username was provided so we can attempt a webfinger, this ensures up-to-date accountdomain info.
+		fingerKey = fingerCacheKey(account.Username, account.Domain)
 		accDomain, accURI, err := d.fingerRemoteAccount(ctx, tsport, account.Username, account.Domain)
 		switch {
 
@@ -569,6 +653,15 @@ func (d *Dereferencer) enrichAccount(
 		// Dereference latest version of the account.
 		rsp, err := tsport.Dereference(ctx, uri)
 		if err != nil {
+			d.recordDerefFailure(ctx, uri.String(), err)
+
+			if gtserror.StatusCode(err) == http.StatusGone &&
+				fingerKey != "" {
+				// Account is gone, make sure we don't
+				// keep serving a cached webfinger result.
+				d.state.Caches.GTS.Finger.Invalidate(fingerKey)
+			}
+
 			err := gtserror.Newf("error dereferencing %s: %w", uri, err)
 			return nil, nil, gtserror.SetUnretrievable(err)
 		}
@@ -727,11 +820,20 @@ func (d *Dereferencer) enrichAccount(
 		// Set time of update from the last-fetched date.
 		latestAcc.UpdatedAt = latestAcc.FetchedAt
 
-		// This is new, put it in the database.
-		err := d.state.DB.PutAccount(ctx, latestAcc)
+		// This is new, put it in the database. If we raced
+		// another goroutine to store an account with this
+		// URI, the winning stored account is returned.
+		stored, err := d.state.DB.PutAccountUpsert(ctx, latestAcc)
 		if err != nil {
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
+
+		if stored.ID != latestAcc.ID {
+			// We lost out, don't return the AP
+			// model, otherwise this indicates
+			// WE enriched the stored account.
+			return stored, nil, nil
+		}
 	} else {
 		// Prefer published time from apubAcc,
 		// fall back to previous stored value.
@@ -1057,7 +1159,10 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 		return gtserror.Newf("error getting account pinned statuses: %w", err)
 	}
 
-	var statusURIs []*url.URL
+	var (
+		statusURIs []*url.URL
+		pinned     []*gtsmodel.Status
+	)
 
 	for {
 		// Get next collect item.
@@ -1097,12 +1202,6 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 			}
 		}
 
-		// If the status was already pinned,
-		// we don't need to do anything.
-		if !status.PinnedAt.IsZero() {
-			continue
-		}
-
 		if status.AccountURI != account.URI {
 			// Someone's pinned a status that doesn't
 			// belong to them, this doesn't work for us.
@@ -1117,12 +1216,23 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 			continue
 		}
 
-		// All conditions are met for this status to
-		// be pinned, so we can finally update it.
-		status.PinnedAt = time.Now()
-		if err := d.state.DB.UpdateStatus(ctx, status, "pinned_at"); err != nil {
-			log.Errorf(ctx, "error updating status in featured collection %s: %v", status.URI, err)
-			continue
+		// All conditions are met
+		// for this status to be pinned.
+		pinned = append(pinned, status)
+	}
+
+	if !sameStatusIDs(wasPinned, pinned) {
+		// Pins are ordered by pinned_at, newest first, so
+		// stamp them working back from now to keep the order
+		// of the collection. Only done if something changed,
+		// to avoid rewriting every pin on each refresh.
+		now := time.Now()
+		for i, status := range pinned {
+			status.PinnedAt = now.Add(-time.Duration(i) * time.Millisecond)
+			if err := d.state.DB.UpdateStatus(ctx, status, "pinned_at"); err != nil {
+				log.Errorf(ctx, "error updating status in featured collection %s: %v", status.URI, err)
+				continue
+			}
 		}
 	}
 
@@ -20,6 +20,7 @@ package dereferencing_test
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,7 +28,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -59,6 +62,54 @@ func (suite *AccountTestSuite) TestDereferenceGroup() {
 	suite.Equal(ap.ActorGroup, dbGroup.ActorType)
 }
 
+func (suite *AccountTestSuite) TestDereferenceAccountConcurrent() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	groupURL := testrig.URLMustParse("https://unknown-instance.com/groups/some_group")
+
+	var (
+		wg       sync.WaitGroup
+		accounts [10]*gtsmodel.Account
+		errs     [10]error
+	)
+
+	// Hammer the dereferencer with
+	// lookups for one unseen account.
+	for i := range accounts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			accounts[i], _, errs[i] = suite.dereferencer.GetAccountByURI(
+				context.Background(),
+				fetchingAccount.Username,
+				groupURL,
+			)
+		}(i)
+	}
+	wg.Wait()
+
+	// All callers get the same account.
+	for i := range accounts {
+		if err := errs[i]; err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(accounts[0].ID, accounts[i].ID)
+	}
+
+	// Exactly one row should be in the database.
+	var all []*gtsmodel.Account
+	if err := suite.db.GetAll(context.Background(), &all); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var rows int
+	for _, account := range all {
+		if account.URI == groupURL.String() {
+			rows++
+		}
+	}
+	suite.Equal(1, rows)
+}
+
 func (suite *AccountTestSuite) TestDereferenceService() {
 	fetchingAccount := suite.testAccounts["local_account_1"]
 
@@ -84,6 +135,24 @@ func (suite *AccountTestSuite) TestDereferenceService() {
 	suite.Equal("example.org", dbService.Domain)
 }
 
+func (suite *AccountTestSuite) TestDereferenceCachesFinger() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	serviceURL := testrig.URLMustParse("https://owncast.example.org/federation/user/rgh")
+	_, _, err := suite.dereferencer.GetAccountByURI(
+		context.Background(),
+		fetchingAccount.Username,
+		serviceURL,
+	)
+	suite.NoError(err)
+
+	// webfinger result should now be cached
+	cached, ok := suite.state.Caches.GTS.Finger.Get("rgh@owncast.example.org")
+	suite.True(ok)
+	suite.Equal("example.org", cached.Domain)
+	suite.Equal("https://owncast.example.org/federation/user/rgh", cached.URI)
+}
+
 /*
 	We shouldn't try webfingering or making http calls to dereference local accounts
 	that might be passed into GetRemoteAccount for whatever reason, so these tests are
@@ -230,6 +299,47 @@ func (suite *AccountTestSuite) TestDereferenceRemoteAccountWithNonMatchingURI()
 	suite.Nil(fetchedAccount)
 }
 
+func (suite *AccountTestSuite) TestRefreshGoneAccountTombstones() {
+	ctx := context.Background()
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	// Follow the account from a local account.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01HRZ8C9ZT3Q8K5V7Y6N2X4W1M",
+		URI:             "http://localhost:8080/users/the_mighty_zork/follows/01HRZ8C9ZT3Q8K5V7Y6N2X4W1M",
+		AccountID:       fetchingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Mark the account as gone on its instance.
+	delete(suite.client.TestRemotePeople, targetAccount.URI)
+	suite.client.TestTombstones[targetAccount.URI] = &gtsmodel.Tombstone{}
+
+	// Refresh the account with a zero
+	// freshness window to force a deref.
+	_, _, err := suite.dereferencer.RefreshAccount(ctx,
+		fetchingAccount.Username,
+		targetAccount,
+		nil,
+		dereferencing.Now,
+	)
+	suite.Error(err)
+
+	// Account should now be tombstoned + suspended.
+	dbAccount, err := suite.db.GetAccountByID(ctx, targetAccount.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.IsTombstoned())
+	suite.False(dbAccount.SuspendedAt.IsZero())
+
+	// Follows to the account should be gone.
+	following, err := suite.db.IsFollowing(ctx, fetchingAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.False(following)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
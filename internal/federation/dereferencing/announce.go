@@ -59,7 +59,7 @@ func (d *Dereferencer) EnrichAnnounce(
 	// Fetch/deref status being boosted.
 	var target *gtsmodel.Status
 
-	if targetURIObj.Host == config.GetHost() {
+	if config.IsLocalHost(targetURIObj.Host) {
 		// This is a local status, fetch from the database
 		target, err = d.state.DB.GetStatusByURI(ctx, targetURI)
 	} else {
@@ -99,12 +99,24 @@ func (d *Dereferencer) EnrichAnnounce(
 	boost.BoostOf = target
 	boost.BoostOfAccountID = target.AccountID
 	boost.BoostOfAccount = target.Account
-	boost.Visibility = target.Visibility
 	boost.Federated = target.Federated
 	boost.Boostable = target.Boostable
 	boost.Replyable = target.Replyable
 	boost.Likeable = target.Likeable
 
+	// Keep the visibility the booster chose for
+	// public / unlisted targets, but never allow
+	// a boost to widen the audience of a status.
+	switch target.Visibility {
+	case gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked:
+		if boost.Visibility == "" ||
+			boost.Visibility == gtsmodel.VisibilityDirect {
+			boost.Visibility = target.Visibility
+		}
+	default:
+		boost.Visibility = target.Visibility
+	}
+
 	// Store the boost wrapper status in database.
 	switch err = d.state.DB.PutStatus(ctx, boost); {
 	case err == nil:
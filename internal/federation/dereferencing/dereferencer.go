@@ -22,6 +22,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
@@ -41,19 +42,23 @@ import (
 // refreshing from remote.
 type FreshnessWindow time.Duration
 
-var (
-	// 6 hours.
-	//
-	// Default window for doing a
-	// fresh dereference of an Account.
-	DefaultAccountFreshness = util.Ptr(FreshnessWindow(6 * time.Hour))
+// DefaultAccountFreshness returns the default
+// window for doing a fresh dereference of an
+// Account, as configured by the instance admin.
+func DefaultAccountFreshness() *FreshnessWindow {
+	window := config.GetInstanceFederationAccountFreshness()
+	return util.Ptr(FreshnessWindow(window))
+}
 
-	// 2 hours.
-	//
-	// Default window for doing a
-	// fresh dereference of a Status.
-	DefaultStatusFreshness = util.Ptr(FreshnessWindow(2 * time.Hour))
+// DefaultStatusFreshness returns the default
+// window for doing a fresh dereference of a
+// Status, as configured by the instance admin.
+func DefaultStatusFreshness() *FreshnessWindow {
+	window := config.GetInstanceFederationStatusFreshness()
+	return util.Ptr(FreshnessWindow(window))
+}
 
+var (
 	// 5 minutes.
 	//
 	// Fresh is useful when you're wanting
@@ -63,6 +68,13 @@ var (
 	// This is tuned to be quite fresh without
 	// causing loads of dereferencing calls.
 	Fresh = util.Ptr(FreshnessWindow(5 * time.Minute))
+
+	// Zero window.
+	//
+	// Now indicates that a model should
+	// always be refreshed from remote,
+	// regardless of when it was fetched.
+	Now = util.Ptr(FreshnessWindow(0))
 )
 
 // Dereferencer wraps logic and functionality for doing dereferencing
@@ -78,6 +90,11 @@ type Dereferencer struct {
 	derefHeaders map[string]*media.ProcessingMedia
 	derefEmojis  map[string]*media.ProcessingEmoji
 
+	// in-flight status dereferences by URI,
+	// shared between concurrent callers.
+	derefStatuses   map[string]*statusDeref
+	derefStatusesMu sync.Mutex
+
 	handshakes   map[string][]*url.URL
 	handshakesMu sync.Mutex
 }
@@ -97,6 +114,7 @@ func NewDereferencer(
 		derefAvatars:        make(map[string]*media.ProcessingMedia),
 		derefHeaders:        make(map[string]*media.ProcessingMedia),
 		derefEmojis:         make(map[string]*media.ProcessingEmoji),
+		derefStatuses:       make(map[string]*statusDeref),
 		handshakes:          make(map[string][]*url.URL),
 	}
 }
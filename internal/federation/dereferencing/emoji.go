@@ -91,6 +91,23 @@ func (d *Dereferencer) GetRemoteEmoji(ctx context.Context, requestingUsername st
 	return processingEmoji, nil
 }
 
+// GetEmoji returns the stored version of the given minimal remote emoji
+// (eg., as extracted from an AS tag), dereferencing it if not yet stored,
+// or refreshing it if it has since been updated.
+func (d *Dereferencer) GetEmoji(ctx context.Context, emoji *gtsmodel.Emoji, requestingUsername string) (*gtsmodel.Emoji, error) {
+	emojis, err := d.populateEmojis(ctx, []*gtsmodel.Emoji{emoji}, requestingUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(emojis) == 0 {
+		// Failure reasons are logged by populateEmojis.
+		return nil, fmt.Errorf("could not get emoji %s", emoji.URI)
+	}
+
+	return emojis[0], nil
+}
+
 func (d *Dereferencer) populateEmojis(ctx context.Context, rawEmojis []*gtsmodel.Emoji, requestingUsername string) ([]*gtsmodel.Emoji, error) {
 	// At this point we should know:
 	// * the AP uri of the emoji
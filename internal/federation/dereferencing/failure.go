@@ -0,0 +1,130 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const (
+	// derefFailureUnavailableTTL is how long a dereference that failed
+	// due to timeout, connection or server error is cached for. Short,
+	// as the remote will likely be back soon, but long enough to avoid
+	// hammering an instance that's down from every single interaction.
+	derefFailureUnavailableTTL = 2 * time.Minute
+
+	// derefFailureForbiddenTTL is how long a
+	// 401 / 403 dereference failure is cached for.
+	derefFailureForbiddenTTL = 10 * time.Minute
+
+	// derefFailureNotFoundTTL is how long a
+	// 404 / 410 dereference failure is cached for.
+	derefFailureNotFoundTTL = time.Hour
+)
+
+// recentDerefFailure returns an error if dereferencing the given
+// URI recently failed, in which case it shouldn't be tried again
+// yet. The manual locking here allows us to call Cache.Get, which
+// doesn't renew the item expiry.
+func (d *Dereferencer) recentDerefFailure(uriStr string) error {
+	fc := d.state.Caches.GTS.DerefFailure
+	fc.Lock()
+	item, ok := fc.Cache.Get(uriStr)
+	fc.Unlock()
+
+	if !ok || time.Now().After(item.Value.Expires) {
+		return nil
+	}
+
+	err := gtserror.Newf("skipping dereference of %s after recent failure: %s", uriStr, item.Value.Class)
+	if item.Value.StatusCode != 0 {
+		err = gtserror.WithStatusCode(err, item.Value.StatusCode)
+	}
+
+	return gtserror.SetUnretrievable(err)
+}
+
+// recordDerefFailure caches the given error, returned from
+// a transport dereference of the given URI, if it is of a
+// class that is worth remembering for a while.
+func (d *Dereferencer) recordDerefFailure(ctx context.Context, uriStr string, err error) {
+	if ctx.Err() != nil {
+		// Our own context was cancelled,
+		// this says nothing about remote.
+		return
+	}
+
+	class, ttl, ok := classifyDerefFailure(err)
+	if !ok {
+		return
+	}
+
+	d.state.Caches.GTS.DerefFailure.Set(uriStr, cache.CachedDerefFailure{
+		Class:      class,
+		StatusCode: gtserror.StatusCode(err),
+		Expires:    time.Now().Add(ttl),
+	})
+}
+
+// classifyDerefFailure returns the failure class of the given
+// dereference error, and how long it should be cached for. If
+// the error isn't worth caching, false is returned.
+func classifyDerefFailure(err error) (cache.DerefFailureClass, time.Duration, bool) {
+	if gtserror.IsNotFound(err) {
+		// Remote host does not exist.
+		return cache.DerefFailureNotFound, derefFailureNotFoundTTL, true
+	}
+
+	if gtserror.IsMalformed(err) {
+		// Remote is there, but gave us
+		// something we can't use. This
+		// isn't a failure to dereference.
+		return "", 0, false
+	}
+
+	code := gtserror.StatusCode(err)
+	switch {
+	case code == http.StatusNotFound,
+		code == http.StatusGone:
+		return cache.DerefFailureNotFound, derefFailureNotFoundTTL, true
+
+	case code == http.StatusUnauthorized,
+		code == http.StatusForbidden:
+		return cache.DerefFailureForbidden, derefFailureForbiddenTTL, true
+
+	case code == http.StatusRequestTimeout,
+		code == http.StatusTooManyRequests,
+		code >= 500:
+		return cache.DerefFailureUnavailable, derefFailureUnavailableTTL, true
+
+	case code >= 400:
+		// Other client errors,
+		// nothing to learn here.
+		return "", 0, false
+
+	default:
+		// Timeouts and
+		// connection failures.
+		return cache.DerefFailureUnavailable, derefFailureUnavailableTTL, true
+	}
+}
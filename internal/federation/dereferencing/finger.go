@@ -24,8 +24,10 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -39,6 +41,10 @@ import (
 // In case the response cannot be parsed, or the response
 // does not contain a valid subject string or AP URI, an
 // error will be returned instead.
+//
+// Successful results are cached for a short time, keyed
+// by username@host, so that bursts of lookups for the same
+// account don't all result in a request to the remote.
 func (d *Dereferencer) fingerRemoteAccount(
 	ctx context.Context,
 	transport transport.Transport,
@@ -52,8 +58,26 @@ func (d *Dereferencer) fingerRemoteAccount(
 	// Assemble target namestring for logging.
 	var target = "@" + username + "@" + host
 
+	// Check for a recent result in the cache.
+	key := fingerCacheKey(username, host)
+	if cached, ok := d.cachedFinger(key); ok {
+		uri, err := url.Parse(cached.URI)
+		if err == nil {
+			metrics.DereferenceCache("finger", metrics.CacheHit)
+			return cached.Domain, uri, nil
+		}
+
+		// Should never happen as we only
+		// store parsed URIs, but be safe.
+		d.state.Caches.GTS.Finger.Invalidate(key)
+	}
+
+	metrics.DereferenceCache("finger", metrics.CacheMiss)
+
 	b, err := transport.Finger(ctx, username, host)
 	if err != nil {
+		// Drop any stale cached result.
+		d.state.Caches.GTS.Finger.Invalidate(key)
 		err = gtserror.Newf("error webfingering %s: %w", target, err)
 		return "", nil, err
 	}
@@ -121,9 +145,37 @@ func (d *Dereferencer) fingerRemoteAccount(
 			continue
 		}
 
-		// All looks good, return happily!
+		// All looks good, cache
+		// result and return happily!
+		d.state.Caches.GTS.Finger.Set(key, cache.CachedFinger{
+			Domain: accDomain,
+			URI:    uri.String(),
+		})
 		return accDomain, uri, nil
 	}
 
 	return "", nil, gtserror.Newf("no suitable self, AP-type link found in webfinger response for %s", target)
 }
+
+// cachedFinger fetches a cached webfinger result for key.
+// The manual locking here allows us to call Cache.Get, which
+// doesn't renew the item expiry, so results will always be
+// refreshed from the remote once the short TTL is reached.
+func (d *Dereferencer) cachedFinger(key string) (cache.CachedFinger, bool) {
+	fc := d.state.Caches.GTS.Finger
+	fc.Lock()
+	item, ok := fc.Cache.Get(key)
+	fc.Unlock()
+
+	if !ok {
+		return cache.CachedFinger{}, false
+	}
+
+	return item.Value, true
+}
+
+// fingerCacheKey returns the webfinger
+// result cache key for username and host.
+func fingerCacheKey(username string, host string) string {
+	return username + "@" + host
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// RefreshPoll updates the vote counts / closed time of the poll
+// attached to the given existing status, from the given latest
+// Pollable representation, as received in an Update(Question).
+//
+// Returns false if the Pollable indicates more than just the poll
+// results have changed, i.e. the status content or poll options were
+// edited, in which case the caller should instead fully refresh the
+// status. On success, existing.Poll will be set to the updated poll,
+// with Closing set if the poll has just closed.
+func (d *Dereferencer) RefreshPoll(
+	ctx context.Context,
+	existing *gtsmodel.Status,
+	pollable ap.Pollable,
+) (bool, error) {
+	// Acquire per-URI deref lock, as
+	// other status enrichment does.
+	unlock := d.state.FedLocks.Lock(existing.URI)
+	defer unlock()
+
+	if existing.PollID == "" {
+		// Status previously had no poll,
+		// this must be a status edit.
+		return false, nil
+	}
+
+	if existing.Poll == nil {
+		var err error
+
+		// Fetch the existing poll model from the database.
+		existing.Poll, err = d.state.DB.GetPollByID(ctx, existing.PollID)
+		if err != nil {
+			return false, gtserror.Newf("error getting poll %s: %w", existing.PollID, err)
+		}
+	}
+
+	// Extract the latest poll model from the Pollable.
+	latest, err := ap.ExtractPoll(pollable)
+	if err != nil {
+		return false, gtserror.Newf("error extracting poll: %w", err)
+	}
+
+	if pollChanged(existing.Poll, latest) {
+		// Poll was replaced,
+		// this is a status edit.
+		return false, nil
+	}
+
+	// Check that status content hasn't also changed.
+	content, _ := typeutils.ContentToContentLanguage(ctx,
+		ap.ExtractContent(pollable),
+	)
+	if content != existing.Content {
+		return false, nil
+	}
+
+	if !pollUpdated(existing.Poll, latest) {
+		// Nothing to do.
+		return true, nil
+	}
+
+	// Update the existing poll with latest results.
+	poll := existing.Poll
+	poll.Closing = pollJustClosed(existing.Poll, latest)
+	poll.ClosedAt = latest.ClosedAt
+	poll.Voters = latest.Voters
+	poll.Votes = latest.Votes
+
+	// Update poll model in the database (specifically only the possible changed columns).
+	if err := d.state.DB.UpdatePoll(ctx, poll, "closed_at", "voters", "votes"); err != nil {
+		return false, gtserror.Newf("error updating poll: %w", err)
+	}
+
+	return true, nil
+}
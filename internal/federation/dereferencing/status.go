@@ -34,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -54,7 +55,7 @@ func statusFresh(
 	// Take default if no
 	// freshness window preferred.
 	if window == nil {
-		window = DefaultStatusFreshness
+		window = DefaultStatusFreshness()
 	}
 
 	if status.IsLocal() {
@@ -144,19 +145,28 @@ func (d *Dereferencer) getStatusByURI(ctx context.Context, requestUser string, u
 	if status == nil {
 		// Ensure not a failed search for a local
 		// status, if so we know it doesn't exist.
-		if uri.Host == config.GetHost() ||
-			uri.Host == config.GetAccountDomain() {
+		if config.IsLocalHost(uri.Host) {
 			return nil, nil, false, gtserror.SetUnretrievable(err)
 		}
 
+		metrics.DereferenceCache("status", metrics.CacheMiss)
+
+		// Don't retry a dereference that
+		// failed recently, it'll fail again.
+		if err := d.recentDerefFailure(uriStr); err != nil {
+			return nil, nil, false, err
+		}
+
 		// Create and pass-through a new bare-bones model for deref.
-		return d.enrichStatusSafely(ctx, requestUser, uri, &gtsmodel.Status{
+		return d.enrichStatusShared(ctx, requestUser, uri, &gtsmodel.Status{
 			Local: util.Ptr(false),
 			URI:   uriStr,
-		}, nil)
+		})
 	}
 
-	if statusFresh(status, DefaultStatusFreshness) {
+	if statusFresh(status, DefaultStatusFreshness()) {
+		metrics.DereferenceCache("status", metrics.CacheHit)
+
 		// This is an existing status that is up-to-date,
 		// before returning ensure it is fully populated.
 		if err := d.state.DB.PopulateStatus(ctx, status); err != nil {
@@ -166,12 +176,23 @@ func (d *Dereferencer) getStatusByURI(ctx context.Context, requestUser string, u
 		return status, nil, false, nil
 	}
 
+	metrics.DereferenceCache("status", metrics.CacheStale)
+
+	if d.recentDerefFailure(uriStr) != nil {
+		// Refetch failed recently, serve the
+		// existing status for now, populated.
+		if err := d.state.DB.PopulateStatus(ctx, status); err != nil {
+			log.Errorf(ctx, "error populating existing status: %v", err)
+		}
+
+		return status, nil, false, nil
+	}
+
 	// Try to deref and update existing status model.
-	latest, statusable, isNew, err := d.enrichStatusSafely(ctx,
+	latest, statusable, isNew, err := d.enrichStatusShared(ctx,
 		requestUser,
 		uri,
 		status,
-		nil,
 	)
 
 	if err != nil {
@@ -277,6 +298,137 @@ func (d *Dereferencer) RefreshStatusAsync(
 	})
 }
 
+// sharedStatusDerefTimeout is the maximum time a
+// dereference shared between callers may take, as
+// it's not bound to the context of any one caller.
+const sharedStatusDerefTimeout = 2 * time.Minute
+
+// statusDeref is an in-flight status
+// dereference, the result of which is
+// shared with any concurrent callers.
+type statusDeref struct {
+	done chan struct{}
+	err  error
+
+	// Full result of the dereference,
+	// only returned to the first caller.
+	latest     *gtsmodel.Status
+	apubStatus ap.Statusable
+	isNew      bool
+}
+
+// enrichStatusShared wraps enrichStatusSafely() to share a
+// single dereference of the status at the given URI between
+// concurrent callers, so that (for example) a status boosted
+// by several accounts at once is only fetched from remote once.
+//
+// The first caller starts the dereference, on a context detached
+// from its own so that it completes for any other waiting callers
+// even if the first caller gives up. Other callers wait for it to
+// finish and then either get the stored status from the database,
+// or the error that the dereference returned.
+func (d *Dereferencer) enrichStatusShared(
+	ctx context.Context,
+	requestUser string,
+	uri *url.URL,
+	status *gtsmodel.Status,
+) (*gtsmodel.Status, ap.Statusable, bool, error) {
+	uriStr := status.URI
+
+	d.derefStatusesMu.Lock()
+	deref, ok := d.derefStatuses[uriStr]
+	if ok {
+		d.derefStatusesMu.Unlock()
+		return d.waitStatusDeref(ctx, uriStr, deref)
+	}
+
+	// No dereference in progress, mark
+	// this one as in-flight in the map.
+	deref = &statusDeref{done: make(chan struct{})}
+	d.derefStatuses[uriStr] = deref
+	d.derefStatusesMu.Unlock()
+
+	// Set an error ahead of time, which is
+	// only replaced on return. So if enrich
+	// panics, all callers still get an error.
+	deref.err = gtserror.Newf("dereference of status %s did not complete", uriStr)
+
+	// Detach from the caller's context (keeping
+	// its values), so that cancellation of this
+	// caller doesn't fail the deref for others.
+	derefCtx, cncl := context.WithTimeout(
+		context.WithoutCancel(ctx),
+		sharedStatusDerefTimeout,
+	)
+
+	go func() {
+		defer func() {
+			// Nothing up this goroutine's
+			// stack to recover panics for us.
+			if r := recover(); r != nil {
+				log.Errorf(derefCtx, "panic dereferencing status %s: %v", uriStr, r)
+			}
+
+			// On exit safely remove
+			// dereference from map,
+			// and release waiters.
+			cncl()
+			d.derefStatusesMu.Lock()
+			delete(d.derefStatuses, uriStr)
+			d.derefStatusesMu.Unlock()
+			close(deref.done)
+		}()
+
+		latest, apubStatus, isNew, err := d.enrichStatusSafely(derefCtx,
+			requestUser,
+			uri,
+			status,
+			nil,
+		)
+		deref.latest = latest
+		deref.apubStatus = apubStatus
+		deref.isNew = isNew
+		deref.err = err
+	}()
+
+	select {
+	case <-deref.done:
+	case <-ctx.Done():
+		return nil, nil, false, ctx.Err()
+	}
+
+	return deref.latest, deref.apubStatus, deref.isNew, deref.err
+}
+
+// waitStatusDeref waits on the given in-flight status
+// dereference to finish, returning its outcome. As the
+// status was enriched by another caller, the returned
+// AP model is always nil, and the status is not new.
+func (d *Dereferencer) waitStatusDeref(
+	ctx context.Context,
+	uriStr string,
+	deref *statusDeref,
+) (*gtsmodel.Status, ap.Statusable, bool, error) {
+	select {
+	case <-deref.done:
+	case <-ctx.Done():
+		return nil, nil, false, ctx.Err()
+	}
+
+	if deref.err != nil {
+		return nil, nil, false, deref.err
+	}
+
+	// Dereference succeeded, get the
+	// latest version from the database.
+	latest, err := d.state.DB.GetStatusByURI(ctx, uriStr)
+	if err != nil {
+		return nil, nil, false, gtserror.Newf("error getting status %s from database after dereference: %w", uriStr, err)
+	}
+
+	return latest, nil, false, nil
+}
+
 // enrichStatusSafely wraps enrichStatus() to perform
 // it within the State{}.FedLocks mutexmap, which protects
 // dereferencing actions with per-URI mutex locks.
@@ -341,29 +493,6 @@ func (d *Dereferencer) enrichStatusSafely(
 	// we're done.
 	unlock()
 
-	if errors.Is(err, db.ErrAlreadyExists) {
-		// Ensure AP model isn't set,
-		// otherwise this indicates WE
-		// enriched the status.
-		apubStatus = nil
-
-		// We leave 'isNew' set so that caller
-		// still dereferences parents, otherwise
-		// the version we pass back may not have
-		// these attached as inReplyTos yet (since
-		// those happen OUTSIDE federator lock).
-		//
-		// TODO: performance-wise, this won't be
-		// great. should improve this if we can!
-
-		// DATA RACE! We likely lost out to another goroutine
-		// in a call to db.Put(Status). Look again in DB by URI.
-		latest, err = d.state.DB.GetStatusByURI(ctx, status.URI)
-		if err != nil {
-			err = gtserror.Newf("error getting status %s from database after race: %w", uriStr, err)
-		}
-	}
-
 	return latest, apubStatus, isNew, err
 }
 
@@ -395,6 +524,7 @@ func (d *Dereferencer) enrichStatus(
 		// Dereference latest version of the status.
 		rsp, err := tsport.Dereference(ctx, uri)
 		if err != nil {
+			d.recordDerefFailure(ctx, uri.String(), err)
 			err := gtserror.Newf("error dereferencing %s: %w", uri, err)
 			return nil, nil, gtserror.SetUnretrievable(err)
 		}
@@ -519,6 +649,9 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.Newf("error checking / creating threadID for status %s: %w", uri, err)
 	}
 
+	// Ensure the status' quoted status is populated, if possible.
+	d.fetchStatusQuote(ctx, requestUser, latestStatus)
+
 	// Ensure the status' tags are populated, (changes are expected / okay).
 	if err := d.fetchStatusTags(ctx, status, latestStatus); err != nil {
 		return nil, nil, gtserror.Newf("error populating tags for status %s: %w", uri, err)
@@ -535,11 +668,26 @@ func (d *Dereferencer) enrichStatus(
 	}
 
 	if isNew {
-		// This is new, put the status in the database.
-		err := d.state.DB.PutStatus(ctx, latestStatus)
+		// This is new, put the status in the database. If we
+		// raced another goroutine to store a status with this
+		// URI, the winning stored status is returned.
+		stored, err := d.state.DB.PutStatusUpsert(ctx, latestStatus)
 		if err != nil {
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
+
+		if stored.ID != latestStatus.ID {
+			// We lost out, don't return the AP
+			// model, otherwise this indicates
+			// WE enriched the stored status.
+			//
+			// Caller still sees this as new so
+			// that parents get dereferenced, as
+			// the stored version may not have
+			// these attached as inReplyTos yet
+			// (they're handled OUTSIDE fed lock).
+			return stored, nil, nil
+		}
 	} else {
 		// This is an existing status, update the model in the database.
 		if err := d.state.DB.UpdateStatus(ctx, latestStatus); err != nil {
@@ -752,6 +900,62 @@ func (d *Dereferencer) threadStatus(ctx context.Context, status *gtsmodel.Status
 	return nil
 }
 
+// quoteDerefKey is the context key used to
+// mark that we are currently dereferencing a
+// quoted status, to prevent quote loops.
+type quoteDerefKey struct{}
+
+// fetchStatusQuote populates the quoted status of the given
+// status (if any), fetching it from the database or remote
+// in the same way as a boost target. Only one level of quote
+// is dereferenced; quotes of quotes are taken from the db.
+//
+// Failure to fetch the quoted status is not fatal; the
+// quote URI is kept so that it may be fetched later on.
+func (d *Dereferencer) fetchStatusQuote(ctx context.Context, requestUser string, status *gtsmodel.Status) {
+	if status.QuoteOfURI == "" || status.QuoteOf != nil {
+		// Nothing to do.
+		return
+	}
+
+	uri, err := url.Parse(status.QuoteOfURI)
+	if err != nil {
+		log.Debugf(ctx, "invalid quote uri %s: %v", status.QuoteOfURI, err)
+		return
+	}
+
+	var quoteOf *gtsmodel.Status
+
+	if config.IsLocalHost(uri.Host) ||
+		ctx.Value(quoteDerefKey{}) != nil {
+		// This is a local status, or we're already
+		// dereferencing a quote; fetch from database.
+		quoteOf, err = d.state.DB.GetStatusByURI(ctx, status.QuoteOfURI)
+	} else {
+		// This is a remote status, we need to dereference it.
+		//
+		// d.GetStatusByURI will handle domain block checking for us,
+		// so we don't try to deref a quote target on a blocked host.
+		ctx := context.WithValue(ctx, quoteDerefKey{}, struct{}{})
+		quoteOf, _, _, err = d.getStatusByURI(ctx, requestUser, uri)
+	}
+
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Debugf(ctx, "error getting quoted status %s: %v", status.QuoteOfURI, err)
+	}
+
+	if quoteOf == nil {
+		// Try again later.
+		return
+	}
+
+	// Set quote_of_uri again in case the
+	// original URI was an indirect link.
+	status.QuoteOfURI = quoteOf.URI
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+}
+
 func (d *Dereferencer) fetchStatusTags(ctx context.Context, existing, status *gtsmodel.Status) error {
 	// Allocate new slice to take the yet-to-be determined tag IDs.
 	status.TagIDs = make([]string, len(status.Tags))
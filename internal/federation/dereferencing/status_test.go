@@ -20,12 +20,19 @@ package dereferencing_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -241,6 +248,186 @@ func (suite *StatusTestSuite) TestDereferenceStatusWithNonMatchingURI() {
 	suite.Nil(fetchedStatus)
 }
 
+func (suite *StatusTestSuite) TestDereferenceStatusConcurrent() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839")
+
+	var (
+		derefs  atomic.Int32
+		release = make(chan struct{})
+	)
+
+	// Wrap the mock client to count dereferences of the
+	// status, holding them until released, to ensure the
+	// second caller comes in while the first is in-flight.
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() == statusURL.String() {
+			derefs.Add(1)
+			<-release
+		}
+		return suite.client.Do(req)
+	}, "")
+
+	dereferencer := dereferencing.NewDereferencer(
+		&suite.state,
+		typeutils.NewConverter(&suite.state),
+		testrig.NewTestTransportController(&suite.state, client),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+
+	var (
+		wg       sync.WaitGroup
+		statuses [2]*gtsmodel.Status
+		errs     [2]error
+	)
+
+	for i := range statuses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i], _, errs[i] = dereferencer.GetStatusByURI(
+				context.Background(),
+				fetchingAccount.Username,
+				statusURL,
+			)
+		}(i)
+	}
+
+	// Give both callers time to
+	// get going, then release.
+	time.Sleep(500 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Only one transport dereference.
+	suite.Equal(int32(1), derefs.Load())
+
+	// Both callers get the same status.
+	for i := range statuses {
+		if !suite.NoError(errs[i]) {
+			continue
+		}
+		suite.Equal(statusURL.String(), statuses[i].URI)
+	}
+	if statuses[0] != nil && statuses[1] != nil {
+		suite.Equal(statuses[0].ID, statuses[1].ID)
+	}
+}
+
+func (suite *StatusTestSuite) TestDereferenceStatusConcurrentFirstCanceled() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839")
+
+	release := make(chan struct{})
+
+	// Wrap the mock client to hold dereferences
+	// of the status until released, so the first
+	// caller can be canceled while it's in-flight.
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() == statusURL.String() {
+			<-release
+		}
+		return suite.client.Do(req)
+	}, "")
+
+	dereferencer := dereferencing.NewDereferencer(
+		&suite.state,
+		typeutils.NewConverter(&suite.state),
+		testrig.NewTestTransportController(&suite.state, client),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+
+	var (
+		wg       sync.WaitGroup
+		statuses [2]*gtsmodel.Status
+		errs     [2]error
+	)
+
+	ctx, cncl := context.WithCancel(context.Background())
+	ctxs := [2]context.Context{ctx, context.Background()}
+
+	for i := range statuses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i], _, errs[i] = dereferencer.GetStatusByURI(
+				ctxs[i],
+				fetchingAccount.Username,
+				statusURL,
+			)
+		}(i)
+
+		// Ensure the cancelable
+		// caller gets in first.
+		time.Sleep(250 * time.Millisecond)
+	}
+
+	// Cancel the first caller
+	// then release the deref.
+	cncl()
+	time.Sleep(250 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// First caller gave up.
+	suite.ErrorIs(errs[0], context.Canceled)
+
+	// The second should still get the status.
+	if suite.NoError(errs[1]) {
+		suite.Equal(statusURL.String(), statuses[1].URI)
+	}
+}
+
+func (suite *StatusTestSuite) TestDereferenceStatusRecentFailure() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/doesnotexist")
+
+	var derefs atomic.Int32
+
+	// Wrap the mock client to count dereferences
+	// of the status, which will 404 as unknown.
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() == statusURL.String() {
+			derefs.Add(1)
+		}
+		return suite.client.Do(req)
+	}, "")
+
+	dereferencer := dereferencing.NewDereferencer(
+		&suite.state,
+		typeutils.NewConverter(&suite.state),
+		testrig.NewTestTransportController(&suite.state, client),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+
+	for i := 0; i < 2; i++ {
+		status, _, err := dereferencer.GetStatusByURI(
+			context.Background(),
+			fetchingAccount.Username,
+			statusURL,
+		)
+		suite.Nil(status)
+		suite.True(gtserror.IsUnretrievable(err))
+		suite.Equal(http.StatusNotFound, gtserror.StatusCode(err))
+	}
+
+	// Second attempt should have
+	// been served from the cache.
+	suite.Equal(int32(1), derefs.Load())
+
+	// Once the failure is forgotten,
+	// dereference should be tried again.
+	suite.state.Caches.GTS.DerefFailure.Invalidate(statusURL.String())
+
+	_, _, err := dereferencer.GetStatusByURI(
+		context.Background(),
+		fetchingAccount.Username,
+		statusURL,
+	)
+	suite.Error(err)
+	suite.Equal(int32(2), derefs.Load())
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
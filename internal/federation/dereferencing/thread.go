@@ -35,6 +35,11 @@ import (
 // ancesters we are willing to follow before returning error.
 const maxIter = 512
 
+// maxDescendantDepth defines how many levels of nested
+// replies collections we are willing to descend into
+// when dereferencing the descendants of a status.
+const maxDescendantDepth = 16
+
 // dereferenceThread handles dereferencing status thread after
 // fetch. Passing off appropriate parts to be enqueued for async
 // processing, or handling some parts synchronously when required.
@@ -232,13 +237,15 @@ func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, usernam
 	// Log function start
 	l.Trace("beginning")
 
-	// OUR instance hostname.
-	localhost := config.GetHost()
-
 	// Keep track of already dereferenced collection
 	// pages for this thread to prevent recursion.
 	derefdPages := make(map[string]struct{}, 10)
 
+	// Maximum number of reply items we will look at in this
+	// thread, to prevent large threads hammering small servers.
+	maxItems := config.GetInstanceFederationThreadBackfillMaxItems()
+	var items int
+
 	// frame represents a single stack frame when
 	// iteratively derefencing status descendants.
 	type frame struct {
@@ -251,6 +258,11 @@ func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, usernam
 		// the frame's collection page
 		// (is useful for logging).
 		pageURI string
+
+		// statusURI is the URI string of the
+		// status this frame's collection page
+		// contains the replies of.
+		statusURI string
 	}
 
 	var (
@@ -269,7 +281,11 @@ func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, usernam
 				if page == nil {
 					return nil
 				}
-				return &frame{page: page, pageURI: pageURI}
+				return &frame{
+					page:      page,
+					pageURI:   pageURI,
+					statusURI: statusIRIStr,
+				}
 			}(),
 		}
 
@@ -316,17 +332,28 @@ stackLoop:
 					continue itemLoop
 				}
 
-				if itemIRI.Host == localhost {
+				if config.IsLocalHost(itemIRI.Host) {
 					// This child is one of ours,
 					continue itemLoop
 				}
 
+				if maxItems > 0 && items >= maxItems {
+					l.Debugf("reached %d descendant items", maxItems)
+					return nil
+				}
+				items++
+
+				// Note we only ever use the item IRI here, and
+				// never trust any embedded object in the replies
+				// collection, as replies may originate from any
+				// host. The status is dereferenced from its origin.
+				//
 				// Dereference the remote status and store in the database.
 				// getStatusByURI guards against the following conditions:
 				//   - refetching recently fetched statuses (recursion!)
 				//   - remote domain is blocked (will return unretrievable)
 				//   - any http type error for a new status returns unretrievable
-				_, statusable, _, err := d.getStatusByURI(ctx, username, itemIRI)
+				status, statusable, _, err := d.getStatusByURI(ctx, username, itemIRI)
 				if err != nil {
 					l.Errorf("error dereferencing remote status %s: %v", itemIRI, err)
 					continue itemLoop
@@ -341,6 +368,19 @@ stackLoop:
 					continue itemLoop
 				}
 
+				if status.InReplyToURI != current.statusURI {
+					// This status doesn't reply to the status whose
+					// replies collection it was found in, so we
+					// shouldn't trust its replies. Just move on.
+					l.Debugf("%s is not a reply to %s", itemIRI, current.statusURI)
+					continue itemLoop
+				}
+
+				if len(stack) >= maxDescendantDepth {
+					l.Debugf("reached %d descendant depth", maxDescendantDepth)
+					continue itemLoop
+				}
+
 				// Extract any attached collection + ID URI from status.
 				page, pageURI := getAttachedStatusCollectionPage(statusable)
 				if page == nil {
@@ -349,8 +389,9 @@ stackLoop:
 
 				// Put current and next frame at top of stack
 				stack = append(stack, current, &frame{
-					pageURI: pageURI,
-					page:    page,
+					pageURI:   pageURI,
+					page:      page,
+					statusURI: status.URI,
 				})
 
 				// Now start at top of loop
@@ -418,3 +459,45 @@ func (d *Dereferencer) updateStatusParent(
 	}
 	return nil
 }
+
+// BackfillStatusReplies enqueues an asynchronous refresh of
+// the given remote status, walking its replies collection (up
+// to configured max items) in order to fetch any replies from
+// instances which we may not have received ourselves. This is
+// used when a thread is viewed by a local user.
+func (d *Dereferencer) BackfillStatusReplies(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) {
+	if config.GetInstanceFederationThreadBackfillMaxItems() <= 0 {
+		// Backfilling disabled.
+		return
+	}
+
+	if status.IsLocal() {
+		// Nothing to backfill.
+		return
+	}
+
+	d.state.Workers.Federator.MustEnqueueCtx(ctx, func(ctx context.Context) {
+		// Fetch latest copy of status from the database, as
+		// it may have already been refreshed in the meantime.
+		latest, err := d.state.DB.GetStatusByID(ctx, status.ID)
+		if err != nil {
+			log.Errorf(ctx, "error getting status %s: %v", status.ID, err)
+			return
+		}
+
+		// Refresh the status if it wasn't just fetched,
+		// which in turn dereferences the whole thread.
+		if _, _, err := d.RefreshStatus(ctx,
+			requestUser,
+			latest,
+			nil,
+			Fresh,
+		); err != nil {
+			log.Errorf(ctx, "error backfilling status replies: %v", err)
+		}
+	})
+}
@@ -43,3 +43,11 @@ func pollUpdated(existing, latest *gtsmodel.Poll) bool {
 func pollJustClosed(existing, latest *gtsmodel.Poll) bool {
 	return existing.ClosedAt.IsZero() && latest.Closed()
 }
+
+// sameStatusIDs returns whether both slices
+// contain the same statuses, in the same order.
+func sameStatusIDs(a, b []*gtsmodel.Status) bool {
+	return slices.EqualFunc(a, b, func(sa, sb *gtsmodel.Status) bool {
+		return sa.ID == sb.ID
+	})
+}
@@ -30,7 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
-func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) error {
+func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) (err error) {
 	if log.Level() >= level.DEBUG {
 		i, err := marshalItem(accept)
 		if err != nil {
@@ -46,6 +46,21 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 		return nil // Already processed.
 	}
 
+	// Drop activities we've
+	// already seen recently.
+	iri := ap.GetJSONLDId(accept)
+	if f.isDuplicate(ctx, ap.ActivityAccept, iri) {
+		return nil
+	}
+
+	// Forget the activity again if it couldn't
+	// be processed, so that a retry isn't dropped.
+	defer func() {
+		if err != nil {
+			f.forgetInboxActivity(ctx, ap.ActivityAccept, iri)
+		}
+	}()
+
 	requestingAcct := activityContext.requestingAcct
 	receivingAcct := activityContext.receivingAcct
 
@@ -60,6 +75,19 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 				// Cast the vocab.Type object to known AS type.
 				asFollow := objType.(vocab.ActivityStreamsFollow)
 
+				// Check whether this accepts
+				// the instance's relay follow.
+				if followURI := ap.GetJSONLDId(asFollow); followURI != nil {
+					isRelay, err := f.acceptRelayFollow(ctx, followURI.String(), requestingAcct, receivingAcct)
+					if err != nil {
+						return fmt.Errorf("ACCEPT: error accepting relay follow: %w", err)
+					}
+
+					if isRelay {
+						continue
+					}
+				}
+
 				// convert the follow to something we can understand
 				gtsFollow, err := f.converter.ASFollowToFollow(ctx, asFollow)
 				if err != nil {
@@ -107,6 +135,16 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 			// Serialize IRI.
 			iriStr := iri.String()
 
+			// ACCEPT RELAY FOLLOW
+			isRelay, err := f.acceptRelayFollow(ctx, iriStr, requestingAcct, receivingAcct)
+			if err != nil {
+				return fmt.Errorf("ACCEPT: error accepting relay follow: %w", err)
+			}
+
+			if isRelay {
+				continue
+			}
+
 			// ACCEPT FOLLOW
 			followReq, err := f.state.DB.GetFollowRequestByURI(ctx, iriStr)
 			if err != nil {
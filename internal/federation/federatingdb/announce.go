@@ -19,18 +19,22 @@ package federatingdb
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"slices"
 
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
-func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
+func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) (err error) {
 	if log.Level() >= level.DEBUG {
 		i, err := marshalItem(announce)
 		if err != nil {
@@ -46,6 +50,21 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		return nil // Already processed.
 	}
 
+	// Drop activities we've
+	// already seen recently.
+	iri := ap.GetJSONLDId(announce)
+	if f.isDuplicate(ctx, ap.ActivityAnnounce, iri) {
+		return nil
+	}
+
+	// Forget the activity again if it couldn't
+	// be processed, so that a retry isn't dropped.
+	defer func() {
+		if err != nil {
+			f.forgetInboxActivity(ctx, ap.ActivityAnnounce, iri)
+		}
+	}()
+
 	requestingAcct := activityContext.requestingAcct
 	receivingAcct := activityContext.receivingAcct
 
@@ -63,6 +82,17 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		)
 	}
 
+	// Announces from relays wrap statuses
+	// from elsewhere, they aren't boosts.
+	relay, err := f.getRelay(ctx, requestingAcct)
+	if err != nil {
+		return err
+	}
+
+	if relay != nil {
+		return f.relayAnnounce(ctx, relay, announce, receivingAcct)
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
@@ -74,6 +104,21 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		return nil
 	}
 
+	// Check the boost is permitted by the boost
+	// policy of the target, if it's our status.
+	permitted, err := f.boostPermitted(ctx, requestingAcct, boost)
+	if err != nil {
+		return gtserror.Newf("error checking boost policy: %w", err)
+	}
+
+	if !permitted {
+		log.Infof(ctx,
+			"announce %s not permitted by boost policy of status %s; dropping it",
+			boost.URI, boost.BoostOfURI,
+		)
+		return nil
+	}
+
 	// This is a new boost. Process side effects asynchronously.
 	f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
 		APObjectType:     ap.ActivityAnnounce,
@@ -84,3 +129,32 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 
 	return nil
 }
+
+// boostPermitted checks whether the given boost, if it targets
+// one of our local statuses, is permitted by that status' flags
+// and boost policy for the given (boosting) requester.
+func (f *federatingDB) boostPermitted(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	boost *gtsmodel.Status,
+) (bool, error) {
+	target, err := f.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		boost.BoostOfURI,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting status %s: %w", boost.BoostOfURI, err)
+	}
+
+	if target == nil || !*target.Local {
+		// Not ours to police.
+		return true, nil
+	}
+
+	boostable, err := f.visFilter.StatusBoostable(ctx, requester, target)
+	if err != nil || !boostable {
+		return false, err
+	}
+
+	return f.visFilter.StatusBoostPermitted(ctx, requester, target)
+}
@@ -97,6 +97,33 @@ func (suite *AnnounceTestSuite) TestAnnounceTwice() {
 	suite.Empty(suite.fromFederator)
 }
 
+func (suite *AnnounceTestSuite) TestAnnounceDuplicate() {
+	receivingAccount1 := suite.testAccounts["local_account_1"]
+	announcingAccount := suite.testAccounts["remote_account_1"]
+
+	ctx := createTestContext(receivingAccount1, announcingAccount)
+	announce1 := suite.testActivities["announce_forwarded_1_zork"]
+
+	err := suite.federatingDB.Announce(ctx, announce1.Activity.(vocab.ActivityStreamsAnnounce))
+	suite.NoError(err)
+
+	// should be a message heading to the processor now, which we can intercept here
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ActivityAnnounce, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	// Deliver the exact same announce to the same inbox again,
+	// as a retry or relayed copy would, and from the database
+	// only, as it would be after the cache entry has expired.
+	suite.state.Caches.GTS.InboxActivities.Clear()
+	err = suite.federatingDB.Announce(ctx, announce1.Activity.(vocab.ActivityStreamsAnnounce))
+	suite.NoError(err)
+
+	// the redundant delivery should have been
+	// dropped, so nothing in the messages channel...
+	suite.Empty(suite.fromFederator)
+}
+
 func TestAnnounceTestSuite(t *testing.T) {
 	suite.Run(t, &AnnounceTestSuite{})
 }
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/miekg/dns"
@@ -47,7 +48,7 @@ import (
 //
 // Under certain conditions and network activities, Create may be called
 // multiple times for the same ActivityStreams object.
-func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
+func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) (err error) {
 	if log.Level() >= level.TRACE {
 		i, err := marshalItem(asType)
 		if err != nil {
@@ -68,6 +69,28 @@ func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
 	requestingAcct := activityContext.requestingAcct
 	receivingAcct := activityContext.receivingAcct
 
+	switch name := asType.GetTypeName(); name {
+	case ap.ActivityBlock,
+		ap.ActivityCreate,
+		ap.ActivityFollow,
+		ap.ActivityLike,
+		ap.ActivityFlag:
+		// Drop activities we've
+		// already seen recently.
+		iri := ap.GetJSONLDId(asType)
+		if f.isDuplicate(ctx, name, iri) {
+			return nil
+		}
+
+		// Forget the activity again if it couldn't
+		// be processed, so that a retry isn't dropped.
+		defer func() {
+			if err != nil {
+				f.forgetInboxActivity(ctx, name, iri)
+			}
+		}()
+	}
+
 	switch asType.GetTypeName() {
 	case ap.ActivityBlock:
 		// BLOCK SOMETHING
@@ -285,6 +308,13 @@ func (f *federatingDB) createPollOptionables(
 			return gtserror.Newf("poll vote in status %s invalid: %s", statusURI, name)
 		}
 
+		if slices.Contains(choices, choice) {
+			// Same option sent more than once,
+			// only count it the once (see: retries).
+			log.Debugf(ctx, "%s sent duplicate vote for %q in poll %s", requester.URI, name, statusURI)
+			continue
+		}
+
 		// Append the option index to choices.
 		choices = append(choices, choice)
 	}
@@ -349,9 +379,19 @@ func (f *federatingDB) createStatusable(
 		//
 		// TODO: add Prometheus metrics for this.
 		log.Infof(ctx,
-			"status %s looked like spam (%v); dropping it",
+			"status %s looked like spam (%v); quarantining it",
 			ap.GetJSONLDId(statusable), err,
 		)
+
+		// Hold the status back for review by an admin.
+		if err := f.spamFilter.Quarantine(ctx,
+			receiver,
+			requester,
+			statusable,
+			err,
+		); err != nil {
+			log.Errorf(ctx, "error quarantining status: %v", err)
+		}
 		return nil
 
 	default:
@@ -380,6 +420,21 @@ func (f *federatingDB) createStatusable(
 		return nil
 	}
 
+	// Check whether this is a reply to one of our
+	// statuses, with a policy that doesn't permit it.
+	permitted, err := f.replyPermitted(ctx, requester, statusable)
+	if err != nil {
+		return gtserror.Newf("error checking reply policy: %w", err)
+	}
+
+	if !permitted {
+		log.Infof(ctx,
+			"status %s is a reply not permitted by reply policy; dropping it",
+			ap.GetJSONLDId(statusable),
+		)
+		return nil
+	}
+
 	// Do the rest of the processing asynchronously. The processor
 	// will handle inserting/updating + further dereferencing the status.
 	f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
@@ -394,6 +449,41 @@ func (f *federatingDB) createStatusable(
 	return nil
 }
 
+// replyPermitted checks whether the given statusable, if it's
+// a reply to one of our local statuses, is permitted by that
+// status' reply policy for the given (authoring) requester.
+func (f *federatingDB) replyPermitted(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusable ap.Statusable,
+) (bool, error) {
+	for _, inReplyToURI := range ap.GetInReplyTo(statusable) {
+		inReplyTo, err := f.state.DB.GetStatusByURI(
+			gtscontext.SetBarebones(ctx),
+			inReplyToURI.String(),
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("db error getting status %s: %w", inReplyToURI, err)
+		}
+
+		if inReplyTo == nil || !*inReplyTo.Local {
+			// Not ours to police.
+			continue
+		}
+
+		replyable, err := f.visFilter.StatusReplyable(ctx, requester, inReplyTo)
+		if err != nil {
+			return false, err
+		}
+
+		if !replyable {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 /*
 	FOLLOW HANDLERS
 */
@@ -449,6 +539,12 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		return errors.New("activityLike: could not convert type to like")
 	}
 
+	if ap.IsEmojiReact(like) {
+		// Not a Like as such,
+		// but an emoji reaction.
+		return f.activityEmojiReact(ctx, like, receivingAccount, requestingAccount)
+	}
+
 	fave, err := f.converter.ASLikeToFave(ctx, like)
 	if err != nil {
 		return fmt.Errorf("activityLike: could not convert Like to fave: %w", err)
@@ -461,6 +557,23 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		)
 	}
 
+	if *fave.Status.Local {
+		// Check the like is permitted
+		// by our status' like policy.
+		likeable, err := f.visFilter.StatusLikeable(ctx, requestingAccount, fave.Status)
+		if err != nil {
+			return fmt.Errorf("activityLike: error checking like policy: %w", err)
+		}
+
+		if !likeable {
+			log.Infof(ctx,
+				"like %s not permitted by like policy of status %s; dropping it",
+				fave.URI, fave.Status.URI,
+			)
+			return nil
+		}
+	}
+
 	fave.ID = id.NewULID()
 
 	if err := f.state.DB.PutStatusFave(ctx, fave); err != nil {
@@ -483,6 +596,54 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 	return nil
 }
 
+/*
+	EMOJIREACT HANDLERS
+*/
+
+func (f *federatingDB) activityEmojiReact(ctx context.Context, like vocab.ActivityStreamsLike, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
+	reaction, err := f.converter.ASEmojiReactToStatusReaction(ctx, like)
+	if err != nil {
+		return fmt.Errorf("activityEmojiReact: could not convert EmojiReact to reaction: %w", err)
+	}
+
+	if reaction.AccountID != requestingAccount.ID {
+		return fmt.Errorf(
+			"activityEmojiReact: requestingAccount %s is not EmojiReact actor account %s",
+			requestingAccount.URI, reaction.Account.URI,
+		)
+	}
+
+	if *reaction.Status.Local {
+		// Reactions are a kind of like,
+		// so check our status' like policy.
+		likeable, err := f.visFilter.StatusLikeable(ctx, requestingAccount, reaction.Status)
+		if err != nil {
+			return fmt.Errorf("activityEmojiReact: error checking like policy: %w", err)
+		}
+
+		if !likeable {
+			log.Infof(ctx,
+				"reaction %s not permitted by like policy of status %s; dropping it",
+				reaction.URI, reaction.Status.URI,
+			)
+			return nil
+		}
+	}
+
+	reaction.ID = id.NewULID()
+
+	// Any custom emoji still needs dereferencing,
+	// so leave storing the reaction to the worker.
+	f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ActivityEmojiReact,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         reaction,
+		ReceivingAccount: receivingAccount,
+	})
+
+	return nil
+}
+
 /*
 	FLAG HANDLERS
 */
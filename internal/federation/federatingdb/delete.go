@@ -45,6 +45,12 @@ func (f *federatingDB) Delete(ctx context.Context, id *url.URL) error {
 		return nil // Already processed.
 	}
 
+	// We're only given the ID of the deleted
+	// object, so drop redundant deletes of it.
+	if f.isDuplicate(ctx, ap.ActivityDelete, id) {
+		return nil
+	}
+
 	requestingAcct := activityContext.requestingAcct
 	receivingAcct := activityContext.receivingAcct
 
@@ -19,6 +19,7 @@ package federatingdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 
@@ -26,6 +27,10 @@ import (
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -78,7 +83,7 @@ func (f *federatingDB) SetInbox(c context.Context, inbox vocab.ActivityStreamsOr
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) InboxesForIRI(c context.Context, iri *url.URL) (inboxIRIs []*url.URL, err error) {
 	// check if this is a followers collection iri for a local account...
-	if iri.Host == config.GetHost() && uris.IsFollowersPath(iri) {
+	if config.IsLocalHost(iri.Host) && uris.IsFollowersPath(iri) {
 		localAccountUsername, err := uris.ParseFollowersPath(iri)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't extract local account username from uri %s: %s", iri, err)
@@ -143,3 +148,92 @@ func (f *federatingDB) InboxesForIRI(c context.Context, iri *url.URL) (inboxIRIs
 	// no error, we just didn't find anything so let the library handle the rest
 	return nil, nil
 }
+
+// inboxActivityKey returns the key under which delivery of
+// the given activity to the given receiving account is recorded.
+//
+// Activity type and actor are included so that an activity
+// can't shadow a different activity (or actor) reusing its ID.
+func inboxActivityKey(
+	activityType string,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+	iri *url.URL,
+) string {
+	return activityType + " " +
+		receivingAcct.ID + " " +
+		requestingAcct.URI + " " +
+		iri.String()
+}
+
+// isDuplicate records delivery of the activity of given type
+// and IRI in the current activity context, returning true if
+// it has already been delivered recently and should be dropped.
+//
+// Database errors are logged and the activity treated as new,
+// since dropping a legitimate activity is worse than processing
+// a redundant one.
+func (f *federatingDB) isDuplicate(ctx context.Context, activityType string, iri *url.URL) bool {
+	activityContext := getActivityContext(ctx)
+	if activityContext.internal || iri == nil {
+		return false
+	}
+
+	key := inboxActivityKey(
+		activityType,
+		activityContext.receivingAcct,
+		activityContext.requestingAcct,
+		iri,
+	)
+
+	// Check for a recent
+	// delivery in the cache.
+	if f.state.Caches.GTS.InboxActivities.Has(key) {
+		metrics.InboxDuplicate(activityType)
+		return true
+	}
+
+	// Record delivery in the database, which
+	// covers a longer horizon than the cache.
+	err := f.state.DB.PutInboxActivity(ctx, &gtsmodel.InboxActivity{
+		ID:  id.NewULID(),
+		Key: key,
+	})
+
+	switch {
+	case err == nil:
+		f.state.Caches.GTS.InboxActivities.Set(key, struct{}{})
+		return false
+
+	case errors.Is(err, db.ErrAlreadyExists):
+		f.state.Caches.GTS.InboxActivities.Set(key, struct{}{})
+		metrics.InboxDuplicate(activityType)
+		return true
+
+	default:
+		log.Errorf(ctx, "error recording inbox activity %s: %v", key, err)
+		return false
+	}
+}
+
+// forgetInboxActivity removes any record of delivery of the activity
+// of given type and IRI in the current activity context, so that it
+// may be processed again if re-sent, e.g. a Follow after an Undo.
+func (f *federatingDB) forgetInboxActivity(ctx context.Context, activityType string, iri *url.URL) {
+	activityContext := getActivityContext(ctx)
+	if activityContext.internal || iri == nil {
+		return
+	}
+
+	key := inboxActivityKey(
+		activityType,
+		activityContext.receivingAcct,
+		activityContext.requestingAcct,
+		iri,
+	)
+
+	f.state.Caches.GTS.InboxActivities.Invalidate(key)
+	if err := f.state.DB.DeleteInboxActivityByKey(ctx, key); err != nil {
+		log.Errorf(ctx, "error deleting inbox activity %s: %v", key, err)
+	}
+}
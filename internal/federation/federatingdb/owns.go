@@ -43,8 +43,8 @@ func (f *federatingDB) Owns(ctx context.Context, id *url.URL) (bool, error) {
 	l.Debug("entering Owns")
 
 	// if the id host isn't this instance host, we don't own this IRI
-	if host := config.GetHost(); id.Host != host {
-		l.Tracef("we DO NOT own activity because the host is %s not %s", id.Host, host)
+	if !config.IsLocalHost(id.Host) {
+		l.Tracef("we DO NOT own activity because the host %s is not ours", id.Host)
 		return false, nil
 	}
 
@@ -29,7 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
-func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsReject) error {
+func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsReject) (err error) {
 	if log.Level() >= level.DEBUG {
 		i, err := marshalItem(reject)
 		if err != nil {
@@ -45,6 +45,21 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 		return nil // Already processed.
 	}
 
+	// Drop activities we've
+	// already seen recently.
+	iri := ap.GetJSONLDId(reject)
+	if f.isDuplicate(ctx, ap.ActivityReject, iri) {
+		return nil
+	}
+
+	// Forget the activity again if it couldn't
+	// be processed, so that a retry isn't dropped.
+	defer func() {
+		if err != nil {
+			f.forgetInboxActivity(ctx, ap.ActivityReject, iri)
+		}
+	}()
+
 	requestingAcct := activityContext.requestingAcct
 	receivingAcct := activityContext.receivingAcct
 
@@ -0,0 +1,174 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// acceptRelayFollow handles an Accept of the instance actor's
+// Follow of a relay, with given follow URI. Returns false if
+// the follow URI did not belong to any relay we're following.
+func (f *federatingDB) acceptRelayFollow(
+	ctx context.Context,
+	followURI string,
+	requestingAcct *gtsmodel.Account,
+	receivingAcct *gtsmodel.Account,
+) (bool, error) {
+	relay, err := f.state.DB.GetRelayByFollowURI(ctx, followURI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting relay: %w", err)
+	}
+
+	if relay == nil {
+		// Not a relay follow.
+		return false, nil
+	}
+
+	if relay.State == gtsmodel.RelayStateRemoved {
+		// Relay was removed before it
+		// accepted, don't resurrect it.
+		log.Infof(ctx, "ignoring accept from removed relay %s", relay.InboxURI)
+		return true, nil
+	}
+
+	// Relays are only ever
+	// followed by the instance.
+	if !receivingAcct.IsInstance() {
+		return true, gtserror.New("relay follow accepted in non-instance inbox")
+	}
+
+	// Make sure the relay actor lives
+	// on the same host as the inbox we
+	// delivered the follow to.
+	inbox, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		return true, gtserror.Newf("error parsing relay inbox: %w", err)
+	}
+
+	if requestingAcct.Domain != inbox.Host {
+		return true, gtserror.Newf(
+			"relay follow accepted by %s, not on relay host %s",
+			requestingAcct.URI, inbox.Host,
+		)
+	}
+
+	relay.State = gtsmodel.RelayStateAccepted
+	relay.AccountID = requestingAcct.ID
+	if err := f.state.DB.UpdateRelay(ctx, relay, "state", "account_id"); err != nil {
+		return true, gtserror.Newf("db error updating relay: %w", err)
+	}
+
+	return true, nil
+}
+
+// getRelay returns the relay with given actor account, if any.
+//
+// Relays which haven't accepted our follow (or were removed
+// before they did) have no known actor account, so for these
+// the relay is matched by domain of the account instead.
+func (f *federatingDB) getRelay(ctx context.Context, account *gtsmodel.Account) (*gtsmodel.Relay, error) {
+	relay, err := f.state.DB.GetRelayByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting relay: %w", err)
+	}
+
+	if relay != nil {
+		return relay, nil
+	}
+
+	relays, err := f.state.DB.GetRelaysByDomain(ctx, account.Domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting relays: %w", err)
+	}
+
+	for _, relay := range relays {
+		if relay.AccountID == "" {
+			return relay, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// relayAnnounce handles an Announce from a relay. Unlike regular
+// Announces these are not boosts; instead, the wrapped statuses
+// are dereferenced and stored, if the relay has been accepted.
+// Announces from pending or removed relays are dropped.
+func (f *federatingDB) relayAnnounce(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	announce vocab.ActivityStreamsAnnounce,
+	receivingAcct *gtsmodel.Account,
+) error {
+	if relay.State != gtsmodel.RelayStateAccepted {
+		log.Infof(ctx, "dropping announce from relay %s in state %s", relay.InboxURI, relay.State)
+		return nil
+	}
+
+	for _, object := range ap.ExtractObjects(announce) {
+		var statusIRIs []*url.URL
+
+		switch t := object.GetType(); {
+		case t == nil:
+			// Plain IRI of the status.
+			statusIRIs = []*url.URL{object.GetIRI()}
+
+		case t.GetTypeName() == ap.ActivityCreate:
+			// Some relays wrap the whole
+			// Create, take object(s) of it.
+			create, ok := t.(vocab.ActivityStreamsCreate)
+			if !ok {
+				continue
+			}
+			statusIRIs = ap.GetObjectIRIs(create)
+
+		default:
+			// Embedded object, we'll still dereference
+			// it rather than trusting the relay's copy.
+			statusIRIs = []*url.URL{ap.GetJSONLDId(t)}
+		}
+
+		for _, statusIRI := range statusIRIs {
+			if statusIRI == nil {
+				continue
+			}
+
+			// Dereference and store the status
+			// asynchronously, as with forwards.
+			f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
+				APObjectType:     ap.ObjectNote,
+				APActivityType:   ap.ActivityCreate,
+				APIri:            statusIRI,
+				ReceivingAccount: receivingAcct,
+			})
+		}
+	}
+
+	return nil
+}
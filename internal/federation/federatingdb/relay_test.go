@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+type RelayTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *RelayTestSuite) putRelay(state gtsmodel.RelayState, accountID string) *gtsmodel.Relay {
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:        relayID,
+		InboxURI:  "http://fossbros-anonymous.io/inbox",
+		FollowURI: uris.GenerateURIForFollow(suite.testAccounts["instance_account"].Username, relayID),
+		Domain:    "fossbros-anonymous.io",
+		AccountID: accountID,
+		State:     state,
+	}
+
+	if err := suite.state.DB.PutRelay(context.Background(), relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return relay
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollow() {
+	var (
+		instanceAccount = suite.testAccounts["instance_account"]
+		relayAccount    = suite.testAccounts["remote_account_1"]
+		relay           = suite.putRelay(gtsmodel.RelayStatePending, "")
+		ctx             = createTestContext(instanceAccount, relayAccount)
+	)
+
+	followURI, err := url.Parse(relay.FollowURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accept := streams.NewActivityStreamsAccept()
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(followURI)
+	accept.SetActivityStreamsObject(objectProp)
+
+	if err := suite.federatingDB.Accept(ctx, accept); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relay, err = suite.state.DB.GetRelayByID(context.Background(), relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(gtsmodel.RelayStateAccepted, relay.State)
+	suite.Equal(relayAccount.ID, relay.AccountID)
+}
+
+func (suite *RelayTestSuite) TestRelayAnnounce() {
+	var (
+		instanceAccount = suite.testAccounts["instance_account"]
+		relayAccount    = suite.testAccounts["remote_account_1"]
+		ctx             = createTestContext(instanceAccount, relayAccount)
+		announce        = suite.testActivities["announce_forwarded_1_zork"]
+	)
+
+	suite.putRelay(gtsmodel.RelayStateAccepted, relayAccount.ID)
+
+	err := suite.federatingDB.Announce(ctx, announce.Activity.(vocab.ActivityStreamsAnnounce))
+	suite.NoError(err)
+
+	// Announced status should be dereferenced
+	// by IRI, rather than stored as a boost.
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Nil(msg.GTSModel)
+	suite.Equal("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1", msg.APIri.String())
+	suite.Equal(instanceAccount.ID, msg.ReceivingAccount.ID)
+}
+
+func (suite *RelayTestSuite) TestPendingRelayAnnounce() {
+	var (
+		instanceAccount = suite.testAccounts["instance_account"]
+		relayAccount    = suite.testAccounts["remote_account_1"]
+		ctx             = createTestContext(instanceAccount, relayAccount)
+		announce        = suite.testActivities["announce_forwarded_1_zork"]
+	)
+
+	// Relay actor isn't known until the relay accepts,
+	// so it should be matched by domain until then.
+	suite.putRelay(gtsmodel.RelayStatePending, "")
+
+	err := suite.federatingDB.Announce(ctx, announce.Activity.(vocab.ActivityStreamsAnnounce))
+	suite.NoError(err)
+
+	// Traffic from relays that haven't
+	// accepted our follow is dropped.
+	suite.Empty(suite.fromFederator)
+}
+
+func (suite *RelayTestSuite) TestRemovedRelayAnnounce() {
+	var (
+		instanceAccount = suite.testAccounts["instance_account"]
+		relayAccount    = suite.testAccounts["remote_account_1"]
+		ctx             = createTestContext(instanceAccount, relayAccount)
+		announce        = suite.testActivities["announce_forwarded_1_zork"]
+	)
+
+	suite.putRelay(gtsmodel.RelayStateRemoved, relayAccount.ID)
+
+	err := suite.federatingDB.Announce(ctx, announce.Activity.(vocab.ActivityStreamsAnnounce))
+	suite.NoError(err)
+
+	// Traffic from removed relays is dropped,
+	// rather than being stored as boosts.
+	suite.Empty(suite.fromFederator)
+}
+
+func (suite *RelayTestSuite) TestAcceptRemovedRelayFollow() {
+	var (
+		instanceAccount = suite.testAccounts["instance_account"]
+		relayAccount    = suite.testAccounts["remote_account_1"]
+		relay           = suite.putRelay(gtsmodel.RelayStateRemoved, "")
+		ctx             = createTestContext(instanceAccount, relayAccount)
+	)
+
+	followURI, err := url.Parse(relay.FollowURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accept := streams.NewActivityStreamsAccept()
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(followURI)
+	accept.SetActivityStreamsObject(objectProp)
+
+	if err := suite.federatingDB.Accept(ctx, accept); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// A late Accept shouldn't
+	// resurrect the relay.
+	relay, err = suite.state.DB.GetRelayByID(context.Background(), relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(gtsmodel.RelayStateRemoved, relay.State)
+	suite.Empty(relay.AccountID)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, &RelayTestSuite{})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
 func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo) error {
@@ -58,9 +59,27 @@ func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo)
 		// else skip handling (likely) IRI.
 		objType := object.GetType()
 		if objType == nil {
+			// Still forget any record of the
+			// undone activity, so it's not
+			// wrongly dropped if re-sent.
+			for _, typ := range []string{
+				ap.ActivityFollow,
+				ap.ActivityLike,
+				ap.ActivityAnnounce,
+				ap.ActivityBlock,
+			} {
+				f.forgetInboxActivity(ctx, typ, object.GetIRI())
+			}
 			continue
 		}
 
+		// Forget any record of the undone activity,
+		// so it's not wrongly dropped if re-sent.
+		f.forgetInboxActivity(ctx,
+			objType.GetTypeName(),
+			ap.GetJSONLDId(objType),
+		)
+
 		switch objType.GetTypeName() {
 		case ap.ActivityFollow:
 			if err := f.undoFollow(ctx, receivingAcct, requestingAcct, undo, objType); err != nil {
@@ -118,9 +137,26 @@ func (f *federatingDB) undoFollow(
 		return nil
 	}
 
-	// Delete any existing follow with this URI.
-	if err := f.state.DB.DeleteFollowByURI(ctx, follow.URI); err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return fmt.Errorf("undoFollow: db error removing follow: %w", err)
+	// Look for an existing follow with this URI.
+	existing, err := f.state.DB.GetFollowByURI(gtscontext.SetBarebones(ctx), follow.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("undoFollow: db error getting follow: %w", err)
+	}
+
+	if existing != nil {
+		// Delete the existing follow.
+		if err := f.state.DB.DeleteFollowByID(ctx, existing.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("undoFollow: db error removing follow: %w", err)
+		}
+
+		// Process side effects
+		// of the removed follow.
+		f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
+			APObjectType:     ap.ActivityFollow,
+			APActivityType:   ap.ActivityUndo,
+			GTSModel:         existing,
+			ReceivingAccount: receivingAccount,
+		})
 	}
 
 	// Delete any existing follow request with this URI.
@@ -144,6 +180,12 @@ func (f *federatingDB) undoLike(
 		return errors.New("undoLike: couldn't parse vocab.Type into vocab.ActivityStreamsLike")
 	}
 
+	if ap.IsEmojiReact(Like) {
+		// Not a Like as such,
+		// but an emoji reaction.
+		return f.undoEmojiReact(ctx, requestingAccount, undo, Like)
+	}
+
 	// Make sure the undo actor owns the target.
 	if !sameActor(undo.GetActivityStreamsActor(), Like.GetActivityStreamsActor()) {
 		// Ignore this Activity.
@@ -192,6 +234,52 @@ func (f *federatingDB) undoLike(
 	return nil
 }
 
+func (f *federatingDB) undoEmojiReact(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	undo vocab.ActivityStreamsUndo,
+	Like vocab.ActivityStreamsLike,
+) error {
+	// Make sure the undo actor owns the target.
+	if !sameActor(undo.GetActivityStreamsActor(), Like.GetActivityStreamsActor()) {
+		// Ignore this Activity.
+		return nil
+	}
+
+	uri := ap.GetJSONLDId(Like)
+	if uri == nil {
+		// Ignore this Activity.
+		return nil
+	}
+
+	// Unlike Likes, reactions are looked up by URI,
+	// as an account may react with multiple emojis.
+	reaction, err := f.state.DB.GetStatusReactionByURI(gtscontext.SetBarebones(ctx), uri.String())
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// We didn't have this
+			// reaction anyway, ignore.
+			return nil
+		}
+		// Real error.
+		return fmt.Errorf("undoEmojiReact: db error getting reaction %s: %w", uri, err)
+	}
+
+	// Ensure requester is reaction origin.
+	if reaction.AccountID != requestingAccount.ID {
+		// Ignore this Activity.
+		return nil
+	}
+
+	// Delete the status reaction.
+	if err := f.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		return fmt.Errorf("undoEmojiReact: db error deleting reaction %s: %w", reaction.ID, err)
+	}
+
+	log.Debug(ctx, "EmojiReact undone")
+	return nil
+}
+
 func (f *federatingDB) undoBlock(
 	ctx context.Context,
 	receivingAccount *gtsmodel.Account,
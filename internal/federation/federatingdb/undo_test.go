@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type UndoTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *UndoTestSuite) TestUndoFollow() {
+	// remote_account_1 follows local_account_1,
+	// then undoes the follow
+	followingAccount := suite.testAccounts["remote_account_1"]
+	followedAccount := suite.testAccounts["local_account_1"]
+	ctx := createTestContext(followedAccount, followingAccount)
+
+	// put the follow in the database
+	follow := &gtsmodel.Follow{
+		ID:              "01HV0Q2K4W4G5TB6Q9N1XJ3C8D",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		URI:             fmt.Sprintf("%s/follows/01HV0Q2K4W4G5TB6Q9N1XJ3C8D", followingAccount.URI),
+		AccountID:       followingAccount.ID,
+		TargetAccountID: followedAccount.ID,
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+	}
+	if err := suite.db.PutFollow(ctx, follow); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	asFollow, err := suite.tc.FollowToAS(ctx, follow)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// create an Undo of the follow
+	undo := streams.NewActivityStreamsUndo()
+
+	undoActor := streams.NewActivityStreamsActorProperty()
+	undoActor.AppendIRI(testrig.URLMustParse(followingAccount.URI))
+	undo.SetActivityStreamsActor(undoActor)
+
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsFollow(asFollow)
+	undo.SetActivityStreamsObject(undoObject)
+
+	// process the undo in the federating database
+	err = suite.federatingDB.Undo(ctx, undo)
+	suite.NoError(err)
+
+	// the follow should be gone
+	following, err := suite.db.IsFollowing(ctx, followingAccount.ID, followedAccount.ID)
+	suite.NoError(err)
+	suite.False(following)
+
+	// side effects of the removed follow
+	// should be passed to the fedi worker
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ActivityFollow, msg.APObjectType)
+	suite.Equal(ap.ActivityUndo, msg.APActivityType)
+	suite.Equal(followedAccount.ID, msg.ReceivingAccount.ID)
+	if undone, ok := msg.GTSModel.(*gtsmodel.Follow); suite.True(ok) {
+		suite.Equal(follow.ID, undone.ID)
+	}
+}
+
+func TestUndoTestSuite(t *testing.T) {
+	suite.Run(t, &UndoTestSuite{})
+}
@@ -84,7 +84,7 @@ func (f *federatingDB) updateAccountable(ctx context.Context, receivingAcct *gts
 	accountURIStr := accountURI.String()
 
 	// Don't try to update local accounts.
-	if accountURI.Host == config.GetHost() {
+	if config.IsLocalHost(accountURI.Host) {
 		return nil
 	}
 
@@ -121,7 +121,7 @@ func (f *federatingDB) updateStatusable(ctx context.Context, receivingAcct *gtsm
 	statusURIStr := statusURI.String()
 
 	// Don't try to update local statuses.
-	if statusURI.Host == config.GetHost() {
+	if config.IsLocalHost(statusURI.Host) {
 		return nil
 	}
 
@@ -150,6 +150,20 @@ func (f *federatingDB) updateStatusable(ctx context.Context, receivingAcct *gtsm
 		// For forwarded updates, set a nil AS
 		// status to force refresh from remote.
 		statusable = nil
+	} else if pollable, ok := ap.ToPollable(statusable); ok {
+		// Updates to a Question are typically just
+		// results changing as votes come in; queue
+		// an UPDATE QUESTION activity, which will
+		// refresh the poll (falling back to handling
+		// as a status edit if anything else changed).
+		f.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
+			APObjectType:     ap.ActivityQuestion,
+			APActivityType:   ap.ActivityUpdate,
+			GTSModel:         status, // original status
+			APObjectModel:    pollable,
+			ReceivingAccount: receivingAcct,
+		})
+		return nil
 	}
 
 	// Queue an UPDATE NOTE activity to our fedi API worker,
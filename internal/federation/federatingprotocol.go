@@ -378,9 +378,6 @@ func (f *Federator) Blocked(ctx context.Context, actorIRIs []*url.URL) (bool, er
 		}
 	}
 
-	// Get our own host value just once outside the loop.
-	ourHost := config.GetHost()
-
 	for accountID, iriHost := range accountIDs {
 		// Receiver shouldn't block other IRI owner.
 		//
@@ -413,7 +410,7 @@ func (f *Federator) Blocked(ctx context.Context, actorIRIs []*url.URL) (bool, er
 		// to a different account on our instance. In other words, two
 		// accounts are gossiping about + trying to tag a third account
 		// who has one or the other of them blocked.
-		if iriHost == ourHost {
+		if config.IsLocalHost(iriHost) {
 			blocked, err = f.db.IsBlocked(ctx, accountID, requestingAccount.ID)
 			if err != nil {
 				err = gtserror.Newf("db error checking block between other account and requester: %w", err)
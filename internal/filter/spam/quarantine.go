@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Quarantine stores the given statusable, which was identified
+// as spam for the given reason, so that it can be reviewed by an
+// admin, instead of silently dropping it. Quarantined statuses
+// are removed after the configured number of quarantine days.
+func (f *Filter) Quarantine(
+	ctx context.Context,
+	receiver *gtsmodel.Account,
+	requester *gtsmodel.Account,
+	statusable ap.Statusable,
+	reason error,
+) error {
+	uri := ap.GetJSONLDId(statusable)
+	if uri == nil {
+		return gtserror.New("statusable has no id")
+	}
+
+	// Serialize the statusable so
+	// it can be processed as normal
+	// if approved by an admin later.
+	m, err := ap.Serialize(statusable)
+	if err != nil {
+		return gtserror.Newf("error serializing statusable: %w", err)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return gtserror.Newf("error marshaling statusable: %w", err)
+	}
+
+	if err := f.state.DB.PutQuarantinedStatus(ctx, &gtsmodel.QuarantinedStatus{
+		ID:                 id.NewULID(),
+		URI:                uri.String(),
+		AccountID:          requester.ID,
+		Account:            requester,
+		ReceivingAccountID: receiver.ID,
+		ReceivingAccount:   receiver,
+		Reason:             reason.Error(),
+		Data:               data,
+	}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		return gtserror.Newf("db error putting quarantined status: %w", err)
+	}
+
+	return nil
+}
+
+// ScheduleQuarantineExpiry schedules hourly removal
+// of quarantined statuses older than the configured
+// number of quarantine days.
+func (f *Filter) ScheduleQuarantineExpiry() {
+	fn := func(ctx context.Context, now time.Time) {
+		days := config.GetInstanceFederationSpamFilterQuarantineDays()
+		olderThan := now.Add(-time.Duration(days) * 24 * time.Hour)
+
+		count, err := f.state.DB.DeleteQuarantinedStatusesOlderThan(ctx, olderThan)
+		if err != nil {
+			log.Errorf(ctx, "error removing expired quarantined statuses: %v", err)
+			return
+		}
+
+		if count > 0 {
+			log.Infof(ctx, "removed %d expired quarantined status(es)", count)
+		}
+	}
+
+	if !f.state.Workers.Scheduler.AddRecurring(
+		"@spamquarantine",
+		time.Now(),
+		time.Hour,
+		fn,
+	) {
+		panic("failed to schedule @spamquarantine")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -67,10 +68,12 @@ type preppedMention struct {
 // Otherwise check:
 //
 //  3. Receiver is locked and is followed by requester. Return nil.
-//  4. Five or more people are mentioned. Return Spam.
-//  5. Receiver follow (requests) a mentioned account. Return nil.
-//  6. Statusable has a media attachment. Return Spam.
-//  7. Statusable contains non-mention, non-hashtag links. Return Spam.
+//  4. Requester is a new account not followed by any local account,
+//     and mentions too many local accounts. Return Spam.
+//  5. Five or more people are mentioned. Return Spam.
+//  6. Receiver follow (requests) a mentioned account. Return nil.
+//  7. Statusable has a media attachment. Return Spam.
+//  8. Statusable contains non-mention, non-hashtag links. Return Spam.
 func (f *Filter) StatusableOK(
 	ctx context.Context,
 	receiver *gtsmodel.Account,
@@ -125,7 +128,19 @@ func (f *Filter) StatusableOK(
 		return nil
 	}
 
-	// HEURISTIC 4: How many people are mentioned?
+	// HEURISTIC 4: Is requester a new account, followed
+	// by nobody here, mentioning lots of local accounts?
+	mentionSpam, err := f.newAccountMentionSpam(ctx, requester, mentions)
+	if err != nil {
+		return gtserror.Newf("db error checking local followers: %w", err)
+	}
+
+	if mentionSpam {
+		err := errors.New("new account mentions too many local accounts that don't follow it")
+		return gtserror.SetSpam(err)
+	}
+
+	// HEURISTIC 5: How many people are mentioned?
 	// If it's 5 or more we can assume this is spam.
 	mentionsLen := len(mentions)
 	if mentionsLen >= 5 {
@@ -133,7 +148,7 @@ func (f *Filter) StatusableOK(
 		return gtserror.SetSpam(err)
 	}
 
-	// HEURISTIC 5: Four or fewer people are mentioned,
+	// HEURISTIC 6: Four or fewer people are mentioned,
 	// do we follow (request) at least one of them?
 	// If so, we're probably interested in the message.
 	knowsOne := f.knowsOneMentioned(ctx, receiver, mentions)
@@ -141,7 +156,7 @@ func (f *Filter) StatusableOK(
 		return nil
 	}
 
-	// HEURISTIC 6: Are there any media attachments?
+	// HEURISTIC 7: Are there any media attachments?
 	attachments, _ := ap.ExtractAttachments(statusable)
 	hasAttachments := len(attachments) != 0
 	if hasAttachments {
@@ -149,7 +164,7 @@ func (f *Filter) StatusableOK(
 		return gtserror.SetSpam(err)
 	}
 
-	// HEURISTIC 7: Are there any links in the post
+	// HEURISTIC 8: Are there any links in the post
 	// aside from mentions and hashtags? Include the
 	// summary/content warning when checking.
 	hashtags, _ := ap.ExtractHashtags(statusable)
@@ -170,11 +185,6 @@ func prepMentions(
 	ctx context.Context,
 	mentions []*gtsmodel.Mention,
 ) []preppedMention {
-	var (
-		host          = config.GetHost()
-		accountDomain = config.GetAccountDomain()
-	)
-
 	parsedMentions := make([]preppedMention, 0, len(mentions))
 	for _, mention := range mentions {
 		// Start by just embedding
@@ -223,7 +233,7 @@ func prepMentions(
 		}
 
 		// It's a mention of a local account if the target host is us.
-		parsedMention.local = parsedMention.domain == host || parsedMention.domain == accountDomain
+		parsedMention.local = config.IsLocalHost(parsedMention.domain)
 
 		// Done with this one.
 		parsedMentions = append(parsedMentions, parsedMention)
@@ -278,6 +288,59 @@ func (f *Filter) lockedFollowedBy(
 	return f.state.DB.IsFollowing(ctx, requester.ID, receiver.ID)
 }
 
+// newAccountMentionSpam returns true if requester
+// account is newer than the configured number of
+// days, is not followed by any local account, and
+// mentions more than the configured max number of
+// local accounts (which thus don't follow it).
+func (f *Filter) newAccountMentionSpam(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	mentions []preppedMention,
+) (bool, error) {
+	newDays := config.GetInstanceFederationSpamFilterNewAccountDays()
+	if newDays <= 0 {
+		// Heuristic disabled.
+		return false, nil
+	}
+
+	// If requester isn't new,
+	// return early to avoid a db call.
+	newFor := time.Duration(newDays) * 24 * time.Hour
+	if time.Since(requester.CreatedAt) >= newFor {
+		return false, nil
+	}
+
+	// Count the distinct local accounts mentioned.
+	local := make(map[string]struct{}, len(mentions))
+	for _, mention := range mentions {
+		if !mention.local {
+			continue
+		}
+
+		key := mention.TargetAccountURI
+		if key == "" {
+			key = strings.ToLower(mention.user)
+		}
+		local[key] = struct{}{}
+	}
+
+	if len(local) <= config.GetInstanceFederationSpamFilterMaxLocalMentions() {
+		// Within allowed mentions.
+		return false, nil
+	}
+
+	// Requesters followed by any local account are
+	// allowed; otherwise, none of the mentioned local
+	// accounts follow requester, so this is spam.
+	localFollowers, err := f.state.DB.CountAccountLocalFollowers(ctx, requester.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return localFollowers == 0, nil
+}
+
 // knowsOneMentioned returns true if the
 // receiver follows or has follow requested
 // at least one of the mentioned accounts.
@@ -22,6 +22,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -775,6 +776,88 @@ func (suite *StatusableTestSuite) TestStatusableOK() {
 	}
 }
 
+func (suite *StatusableTestSuite) TestStatusableNewAccountMentions() {
+	var (
+		ctx      = context.Background()
+		receiver = suite.testAccounts["local_account_1"]
+		message  = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://example.org/users/Some_User/statuses/01HQTD5QN1EA14F6HS8J4B9V8X",
+  "type": "Note",
+  "published": "2024-02-24T07:06:14Z",
+  "attributedTo": "http://example.org/users/Some_User",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ],
+  "cc": [
+    "http://localhost:8080/users/the_mighty_zork",
+    "http://localhost:8080/users/admin",
+    "http://localhost:8080/users/1happyturtle"
+  ],
+  "content": "<p>hey everyone!</p>",
+  "tag": [
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/the_mighty_zork",
+      "name": "@the_mighty_zork@localhost:8080"
+    },
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/admin",
+      "name": "@admin@localhost:8080"
+    },
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/1happyturtle",
+      "name": "@1happyturtle@localhost:8080"
+    }
+  ]
+}`
+	)
+
+	// Copy requester and
+	// make it brand new.
+	requester := new(gtsmodel.Account)
+	*requester = *suite.testAccounts["remote_account_2"]
+	requester.CreatedAt = time.Now()
+
+	check := func(acct *gtsmodel.Account) error {
+		rc := io.NopCloser(bytes.NewReader([]byte(message)))
+		statusable, err := ap.ResolveStatusable(ctx, rc)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return suite.filter.StatusableOK(ctx, receiver, acct, statusable)
+	}
+
+	// New account mentioning 3 local
+	// accounts that don't follow it: spam.
+	err := check(requester)
+	suite.True(gtserror.IsSpam(err), "expected Spam, got %+v", err)
+
+	// Established account sending the
+	// same message should be fine.
+	err = check(suite.testAccounts["remote_account_2"])
+	suite.NoError(err, "expected not spam, got %+v", err)
+
+	// Put a follow in place from a
+	// local account to the new requester.
+	fID := id.NewULID()
+	if err := suite.state.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              fID,
+		URI:             "http://localhost:8080/users/admin/follows/" + fID,
+		AccountID:       suite.testAccounts["admin_account"].ID,
+		TargetAccountID: requester.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now someone local knows them,
+	// so the message should be OK.
+	err = check(requester)
+	suite.NoError(err, "expected not spam, got %+v", err)
+}
+
 func TestStatusableTestSuite(t *testing.T) {
 	suite.Run(t, &StatusableTestSuite{})
 }
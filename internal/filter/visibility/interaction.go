@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// StatusReplyable checks if given status can be replied to by requester, checking the replyable flag and reply policy of the status.
+//
+// Note this does not check status visibility, which should be checked separately by the caller.
+func (f *Filter) StatusReplyable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if !*status.Replyable {
+		log.Trace(ctx, "status marked not replyable")
+		return false, nil
+	}
+
+	return f.interactionPermitted(ctx, requester, status, status.ReplyPolicy)
+}
+
+// StatusLikeable checks if given status can be liked by requester, checking the likeable flag and like policy of the status.
+//
+// Note this does not check status visibility, which should be checked separately by the caller.
+func (f *Filter) StatusLikeable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if !*status.Likeable {
+		log.Trace(ctx, "status marked not likeable")
+		return false, nil
+	}
+
+	return f.interactionPermitted(ctx, requester, status, status.LikePolicy)
+}
+
+// StatusBoostPermitted checks if given status' boost policy permits a boost by requester.
+//
+// Note this does not check whether the status is boostable at all, see StatusBoostable().
+func (f *Filter) StatusBoostPermitted(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	return f.interactionPermitted(ctx, requester, status, status.BoostPolicy)
+}
+
+// interactionPermitted checks whether requester is permitted to interact with status according to given policy.
+func (f *Filter) interactionPermitted(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, policy gtsmodel.InteractionPolicy) (bool, error) {
+	if policy == gtsmodel.InteractionPolicyAnyone {
+		// Nothing to check.
+		return true, nil
+	}
+
+	if requester.ID == status.AccountID {
+		// Author can always interact.
+		return true, nil
+	}
+
+	if !status.MentionsPopulated() {
+		// Status needs its mentions populating, fetch these from database.
+		var err error
+		status.Mentions, err = f.state.DB.GetMentions(ctx, status.MentionIDs)
+		if err != nil {
+			return false, gtserror.Newf("error populating status %s mentions: %w", status.ID, err)
+		}
+	}
+
+	if status.MentionsAccount(requester.ID) {
+		// Mentioned accounts are permitted by all policies.
+		return true, nil
+	}
+
+	switch policy {
+	case gtsmodel.InteractionPolicyFollowers:
+		// Check requester follows status author.
+		follows, err := f.state.DB.IsFollowing(ctx,
+			requester.ID,
+			status.AccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error checking follow %s->%s: %w", requester.ID, status.AccountID, err)
+		}
+
+		if !follows {
+			log.Trace(ctx, "interaction policy requires follow")
+			return false, nil
+		}
+
+		return true, nil
+
+	case gtsmodel.InteractionPolicyMentioned:
+		log.Trace(ctx, "interaction policy requires mention")
+		return false, nil
+
+	default:
+		log.Warnf(ctx, "unrecognized interaction policy %q on status %s", policy, status.ID)
+		return false, nil
+	}
+}
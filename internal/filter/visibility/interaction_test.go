@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusInteractionTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusInteractionTestSuite) TestReplyPolicyAnyone() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	ctx := context.Background()
+
+	replyable, err := suite.filter.StatusReplyable(ctx, suite.testAccounts["local_account_2"], testStatus)
+	suite.NoError(err)
+	suite.True(replyable)
+}
+
+func (suite *StatusInteractionTestSuite) TestReplyPolicyFollowers() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.ReplyPolicy = gtsmodel.InteractionPolicyFollowers
+	ctx := context.Background()
+
+	// local_account_1 follows admin.
+	replyable, err := suite.filter.StatusReplyable(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.True(replyable)
+
+	// local_account_2 does not.
+	replyable, err = suite.filter.StatusReplyable(ctx, suite.testAccounts["local_account_2"], testStatus)
+	suite.NoError(err)
+	suite.False(replyable)
+
+	// Author can always reply.
+	replyable, err = suite.filter.StatusReplyable(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.True(replyable)
+}
+
+func (suite *StatusInteractionTestSuite) TestLikePolicyMentioned() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.LikePolicy = gtsmodel.InteractionPolicyMentioned
+	ctx := context.Background()
+
+	// local_account_1 follows admin, but isn't mentioned.
+	likeable, err := suite.filter.StatusLikeable(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.False(likeable)
+}
+
+func (suite *StatusInteractionTestSuite) TestBoostPolicyFollowers() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.BoostPolicy = gtsmodel.InteractionPolicyFollowers
+	ctx := context.Background()
+
+	permitted, err := suite.filter.StatusBoostPermitted(ctx, suite.testAccounts["local_account_2"], testStatus)
+	suite.NoError(err)
+	suite.False(permitted)
+
+	permitted, err = suite.filter.StatusBoostPermitted(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.True(permitted)
+}
+
+func TestStatusInteractionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusInteractionTestSuite))
+}
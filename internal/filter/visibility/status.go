@@ -90,6 +90,14 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	if status.IsLocalOnly() &&
+		requester != nil && !requester.IsLocal() {
+		// Local-only statuses are
+		// never shown to remote accounts.
+		log.Trace(ctx, "remote request to local-only status")
+		return false, nil
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
@@ -116,7 +124,12 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return true, nil
 	}
 
-	if status.BoostOf != nil {
+	if status.BoostOf != nil &&
+		status.AccountID == status.BoostOfAccountID {
+		// Self-boosts inherit the audience of the
+		// boosted status, which includes mentions.
+		// Boosts by anyone else are only shown to
+		// the booster's followers (checked below).
 		if !status.BoostOf.MentionsPopulated() {
 			// Boosted status needs its mentions populating, fetch these from database.
 			status.BoostOf.Mentions, err = f.state.DB.GetMentions(ctx, status.BoostOf.MentionIDs)
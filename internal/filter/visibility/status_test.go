@@ -156,6 +156,23 @@ func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotFollowingCached()
 	suite.False(visible)
 }
 
+func (suite *StatusVisibleTestSuite) TestLocalOnlyStatusNotVisibleToRemote() {
+	ctx := context.Background()
+
+	// This status is unlocked but local-only.
+	testStatus := suite.testStatuses["local_account_1_status_2"]
+
+	// Should be visible to other local accounts.
+	visible, err := suite.filter.StatusVisible(ctx, suite.testAccounts["local_account_2"], testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// Should never be visible to remote accounts.
+	visible, err = suite.filter.StatusVisible(ctx, suite.testAccounts["remote_account_1"], testStatus)
+	suite.NoError(err)
+	suite.False(visible)
+}
+
 func TestStatusVisibleTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusVisibleTestSuite))
 }
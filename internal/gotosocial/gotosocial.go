@@ -21,29 +21,35 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 // Server represents a long-running
 // GoToSocial server instance.
 type Server struct {
-	db        db.DB
+	state     *state.State
 	apiRouter *router.Router
 	cleaner   *cleaner.Cleaner
+	processor *processing.Processor
 }
 
 // NewServer returns a new
 // GoToSocial server instance.
 func NewServer(
-	db db.DB,
+	state *state.State,
 	apiRouter *router.Router,
 	cleaner *cleaner.Cleaner,
+	processor *processing.Processor,
 ) *Server {
 	return &Server{
-		db:        db,
+		state:     state,
 		apiRouter: apiRouter,
 		cleaner:   cleaner,
+		processor: processor,
 	}
 }
 
@@ -55,12 +61,45 @@ func (s *Server) Start(ctx context.Context) error {
 	return s.cleaner.ScheduleJobs()
 }
 
-// Stop closes down the GoToSocial server, first closing the cleaner,
-// then the router, then the database. If something goes wrong while
-// stopping, an error will be returned.
+// Stop closes down the GoToSocial server, first closing open
+// streams, then the router, so no new work comes in. It then
+// waits (up to the configured drain timeout) for the worker
+// queues to be processed, and finally closes the database.
+// If something goes wrong while stopping, an error will be
+// returned.
 func (s *Server) Stop(ctx context.Context) error {
+	// Close open streams first, so they don't keep
+	// the router shutdown waiting, and clients are
+	// told we're going away rather than dropped.
+	s.processor.Stream().CloseAll()
+
 	if err := s.apiRouter.Stop(ctx); err != nil {
 		return err
 	}
-	return s.db.Close()
+
+	// Let queued side effects finish
+	// while the database is still open.
+	s.drainWorkers(ctx)
+
+	return s.state.DB.Close()
+}
+
+// drainWorkers waits for the worker pools to finish
+// processing queued tasks, up to the drain timeout.
+func (s *Server) drainWorkers(ctx context.Context) {
+	timeout := config.GetAdvancedShutdownDrainTimeout()
+	if timeout <= 0 {
+		return
+	}
+
+	log.Infof(ctx, "waiting up to %s for worker queues to drain", timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if remaining := s.state.Workers.Drain(ctx); remaining > 0 {
+		log.Warnf(ctx, "%d queued tasks not processed before drain timeout, dropping", remaining)
+		return
+	}
+
+	log.Info(ctx, "worker queues drained")
 }
@@ -42,6 +42,8 @@ const (
 	httpSigKey
 	httpSigPubKeyIDKey
 	dryRunKey
+	replicaReadKey
+	animationDisabledKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -193,3 +195,31 @@ func Barebones(ctx context.Context) bool {
 func SetBarebones(ctx context.Context) context.Context {
 	return context.WithValue(ctx, barebonesKey, struct{}{})
 }
+
+// ReplicaRead returns whether the "replicaread" context key has been set. This
+// can be used to indicate to the database that the caller tolerates slightly
+// stale results, allowing supported queries to be served from a read replica.
+func ReplicaRead(ctx context.Context) bool {
+	_, ok := ctx.Value(replicaReadKey).(struct{})
+	return ok
+}
+
+// SetReplicaRead sets the "replicaread" context flag and returns this wrapped context.
+// See ReplicaRead() for further information on the "replicaread" context flag.
+func SetReplicaRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadKey, struct{}{})
+}
+
+// AnimationDisabled returns whether the "animationdisabled" context key has been set.
+// This indicates that the requester has asked for static versions of animated avatars,
+// headers and emojis to be served in place of animated ones.
+func AnimationDisabled(ctx context.Context) bool {
+	_, ok := ctx.Value(animationDisabledKey).(struct{})
+	return ok
+}
+
+// SetAnimationDisabled sets the "animationdisabled" context flag and returns this wrapped
+// context. See AnimationDisabled() for further information on the "animationdisabled" flag.
+func SetAnimationDisabled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, animationDisabledKey, struct{}{})
+}
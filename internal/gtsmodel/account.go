@@ -32,63 +32,100 @@ import (
 
 // Account represents either a local or a remote fediverse account, gotosocial or otherwise (mastodon, pleroma, etc).
 type Account struct {
-	ID                      string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt               time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
-	UpdatedAt               time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
-	FetchedAt               time.Time        `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
-	Username                string           `bun:",nullzero,notnull,unique:usernamedomain"`                     // Username of the account, should just be a string of [a-zA-Z0-9_]. Can be added to domain to create the full username in the form ``[username]@[domain]`` eg., ``user_96@example.org``. Username and domain should be unique *with* each other
-	Domain                  string           `bun:",nullzero,unique:usernamedomain"`                             // Domain of the account, will be null if this is a local account, otherwise something like ``example.org``. Should be unique with username.
-	AvatarMediaAttachmentID string           `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
-	AvatarMediaAttachment   *MediaAttachment `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to avatarMediaAttachmentID
-	AvatarRemoteURL         string           `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
-	HeaderMediaAttachmentID string           `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
-	HeaderMediaAttachment   *MediaAttachment `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to headerMediaAttachmentID
-	HeaderRemoteURL         string           `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
-	DisplayName             string           `bun:""`                                                            // DisplayName for this account. Can be empty, then just the Username will be used for display purposes.
-	EmojiIDs                []string         `bun:"emojis,array"`                                                // Database IDs of any emojis used in this account's bio, display name, etc
-	Emojis                  []*Emoji         `bun:"attached_emojis,m2m:account_to_emojis"`                       // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
-	Fields                  []*Field         // A slice of of fields that this account has added to their profile.
-	FieldsRaw               []*Field         // The raw (unparsed) content of fields that this account has added to their profile, without conversion to HTML, only available when requester = target
-	Note                    string           `bun:""`                               // A note that this account has on their profile (ie., the account's bio/description of themselves)
-	NoteRaw                 string           `bun:""`                               // The raw contents of .Note without conversion to HTML, only available when requester = target
-	Memorial                *bool            `bun:",default:false"`                 // Is this a memorial account, ie., has the user passed away?
-	AlsoKnownAsURIs         []string         `bun:"also_known_as_uris,array"`       // This account is associated with these account URIs.
-	AlsoKnownAs             []*Account       `bun:"-"`                              // This account is associated with these accounts (field not stored in the db).
-	MovedToURI              string           `bun:",nullzero"`                      // This account has moved to this account URI.
-	MovedTo                 *Account         `bun:"-"`                              // This account has moved to this account (field not stored in the db).
-	Bot                     *bool            `bun:",default:false"`                 // Does this account identify itself as a bot?
-	Reason                  string           `bun:""`                               // What reason was given for signing up when this account was created?
-	Locked                  *bool            `bun:",default:true"`                  // Does this account need an approval for new followers?
-	Discoverable            *bool            `bun:",default:false"`                 // Should this account be shown in the instance's profile directory?
-	Privacy                 Visibility       `bun:",nullzero"`                      // Default post privacy for this account
-	Sensitive               *bool            `bun:",default:false"`                 // Set posts from this account to sensitive by default?
-	Language                string           `bun:",nullzero,notnull,default:'en'"` // What language does this account post in?
-	StatusContentType       string           `bun:",nullzero"`                      // What is the default format for statuses posted by this account (only for local accounts).
-	CustomCSS               string           `bun:",nullzero"`                      // Custom CSS that should be displayed for this Account's profile and statuses.
-	URI                     string           `bun:",nullzero,notnull,unique"`       // ActivityPub URI for this account.
-	URL                     string           `bun:",nullzero,unique"`               // Web URL for this account's profile
-	InboxURI                string           `bun:",nullzero,unique"`               // Address of this account's ActivityPub inbox, for sending activity to
-	SharedInboxURI          *string          `bun:""`                               // Address of this account's ActivityPub sharedInbox. Gotcha warning: this is a string pointer because it has three possible states: 1. We don't know yet if the account has a shared inbox -- null. 2. We know it doesn't have a shared inbox -- empty string. 3. We know it does have a shared inbox -- url string.
-	OutboxURI               string           `bun:",nullzero,unique"`               // Address of this account's activitypub outbox
-	FollowingURI            string           `bun:",nullzero,unique"`               // URI for getting the following list of this account
-	FollowersURI            string           `bun:",nullzero,unique"`               // URI for getting the followers list of this account
-	FeaturedCollectionURI   string           `bun:",nullzero,unique"`               // URL for getting the featured collection list of this account
-	ActorType               string           `bun:",nullzero,notnull"`              // What type of activitypub actor is this account?
-	PrivateKey              *rsa.PrivateKey  `bun:""`                               // Privatekey for signing activitypub requests, will only be defined for local accounts
-	PublicKey               *rsa.PublicKey   `bun:",notnull"`                       // Publickey for authorizing signed activitypub requests, will be defined for both local and remote accounts
-	PublicKeyURI            string           `bun:",nullzero,notnull,unique"`       // Web-reachable location of this account's public key
-	PublicKeyExpiresAt      time.Time        `bun:"type:timestamptz,nullzero"`      // PublicKey will expire/has expired at given time, and should be fetched again as appropriate. Only ever set for remote accounts.
-	SensitizedAt            time.Time        `bun:"type:timestamptz,nullzero"`      // When was this account set to have all its media shown as sensitive?
-	SilencedAt              time.Time        `bun:"type:timestamptz,nullzero"`      // When was this account silenced (eg., statuses only visible to followers, not public)?
-	SuspendedAt             time.Time        `bun:"type:timestamptz,nullzero"`      // When was this account suspended (eg., don't allow it to log in/post, don't accept media/posts from this account)
-	HideCollections         *bool            `bun:",default:false"`                 // Hide this account's collections
-	SuspensionOrigin        string           `bun:"type:CHAR(26),nullzero"`         // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
-	EnableRSS               *bool            `bun:",default:false"`                 // enable RSS feed subscription for this account's public posts at [URL]/feed
+	ID                                 string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt                          time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
+	UpdatedAt                          time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
+	FetchedAt                          time.Time        `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
+	Username                           string           `bun:",nullzero,notnull,unique:usernamedomain"`                     // Username of the account, should just be a string of [a-zA-Z0-9_]. Can be added to domain to create the full username in the form ``[username]@[domain]`` eg., ``user_96@example.org``. Username and domain should be unique *with* each other
+	Domain                             string           `bun:",nullzero,unique:usernamedomain"`                             // Domain of the account, will be null if this is a local account, otherwise something like ``example.org``. Should be unique with username.
+	AvatarMediaAttachmentID            string           `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
+	AvatarMediaAttachment              *MediaAttachment `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to avatarMediaAttachmentID
+	AvatarRemoteURL                    string           `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
+	HeaderMediaAttachmentID            string           `bun:"type:CHAR(26),nullzero"`                                      // Database ID of the media attachment, if present
+	HeaderMediaAttachment              *MediaAttachment `bun:"rel:belongs-to"`                                              // MediaAttachment corresponding to headerMediaAttachmentID
+	HeaderRemoteURL                    string           `bun:",nullzero"`                                                   // For a non-local account, where can the header be fetched?
+	DisplayName                        string           `bun:""`                                                            // DisplayName for this account. Can be empty, then just the Username will be used for display purposes.
+	EmojiIDs                           []string         `bun:"emojis,array"`                                                // Database IDs of any emojis used in this account's bio, display name, etc
+	Emojis                             []*Emoji         `bun:"attached_emojis,m2m:account_to_emojis"`                       // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
+	Fields                             []*Field         // A slice of of fields that this account has added to their profile.
+	FieldsRaw                          []*Field         // The raw (unparsed) content of fields that this account has added to their profile, without conversion to HTML, only available when requester = target
+	Note                               string           `bun:""`                               // A note that this account has on their profile (ie., the account's bio/description of themselves)
+	NoteRaw                            string           `bun:""`                               // The raw contents of .Note without conversion to HTML, only available when requester = target
+	Memorial                           *bool            `bun:",default:false"`                 // Is this a memorial account, ie., has the user passed away?
+	AlsoKnownAsURIs                    []string         `bun:"also_known_as_uris,array"`       // This account is associated with these account URIs.
+	AlsoKnownAs                        []*Account       `bun:"-"`                              // This account is associated with these accounts (field not stored in the db).
+	MovedToURI                         string           `bun:",nullzero"`                      // This account has moved to this account URI.
+	MovedTo                            *Account         `bun:"-"`                              // This account has moved to this account (field not stored in the db).
+	Bot                                *bool            `bun:",default:false"`                 // Does this account identify itself as a bot?
+	Reason                             string           `bun:""`                               // What reason was given for signing up when this account was created?
+	Locked                             *bool            `bun:",default:true"`                  // Does this account need an approval for new followers?
+	Discoverable                       *bool            `bun:",default:false"`                 // Should this account be shown in the instance's profile directory?
+	Privacy                            Visibility       `bun:",nullzero"`                      // Default post privacy for this account
+	Sensitive                          *bool            `bun:",default:false"`                 // Set posts from this account to sensitive by default?
+	Language                           string           `bun:",nullzero,notnull,default:'en'"` // What language does this account post in?
+	StatusContentType                  string           `bun:",nullzero"`                      // What is the default format for statuses posted by this account (only for local accounts).
+	ExpandSpoilers                     *bool            `bun:",default:false"`                 // Expand content warnings by default when reading (only for local accounts).
+	ExpandMedia                        string           `bun:",nullzero"`                      // How to display media when reading: default, show_all or hide_all (only for local accounts).
+	DisableAnimation                   *bool            `bun:",default:false"`                 // Serve static versions of animated avatars, headers and emojis to this account (only for local accounts).
+	BoostVisibility                    Visibility       `bun:",nullzero"`                      // Default visibility of boosts made by this account; empty to use the boosted status' visibility (only for local accounts).
+	CustomCSS                          string           `bun:",nullzero"`                      // Custom CSS that should be displayed for this Account's profile and statuses.
+	URI                                string           `bun:",nullzero,notnull,unique"`       // ActivityPub URI for this account.
+	URL                                string           `bun:",nullzero,unique"`               // Web URL for this account's profile
+	InboxURI                           string           `bun:",nullzero,unique"`               // Address of this account's ActivityPub inbox, for sending activity to
+	SharedInboxURI                     *string          `bun:""`                               // Address of this account's ActivityPub sharedInbox. Gotcha warning: this is a string pointer because it has three possible states: 1. We don't know yet if the account has a shared inbox -- null. 2. We know it doesn't have a shared inbox -- empty string. 3. We know it does have a shared inbox -- url string.
+	OutboxURI                          string           `bun:",nullzero,unique"`               // Address of this account's activitypub outbox
+	FollowingURI                       string           `bun:",nullzero,unique"`               // URI for getting the following list of this account
+	FollowersURI                       string           `bun:",nullzero,unique"`               // URI for getting the followers list of this account
+	FeaturedCollectionURI              string           `bun:",nullzero,unique"`               // URL for getting the featured collection list of this account
+	ActorType                          string           `bun:",nullzero,notnull"`              // What type of activitypub actor is this account?
+	PrivateKey                         *rsa.PrivateKey  `bun:""`                               // Privatekey for signing activitypub requests, will only be defined for local accounts
+	PublicKey                          *rsa.PublicKey   `bun:",notnull"`                       // Publickey for authorizing signed activitypub requests, will be defined for both local and remote accounts
+	PublicKeyURI                       string           `bun:",nullzero,notnull,unique"`       // Web-reachable location of this account's public key
+	PublicKeyExpiresAt                 time.Time        `bun:"type:timestamptz,nullzero"`      // PublicKey will expire/has expired at given time, and should be fetched again as appropriate. Only ever set for remote accounts.
+	SensitizedAt                       time.Time        `bun:"type:timestamptz,nullzero"`      // When was this account set to have all its media shown as sensitive?
+	SilencedAt                         time.Time        `bun:"type:timestamptz,nullzero"`      // When was this account silenced (eg., statuses only visible to followers, not public)?
+	SuspendedAt                        time.Time        `bun:"type:timestamptz,nullzero"`      // When was this account suspended (eg., don't allow it to log in/post, don't accept media/posts from this account)
+	HideCollections                    *bool            `bun:",default:false"`                 // Hide this account's collections
+	SuppressFollowRequestNotifications *bool            `bun:",default:false"`                 // Don't create notifications for new follow requests targeting this account (only for local accounts).
+	SuppressStatusUpdateNotifications  *bool            `bun:",default:false"`                 // Don't create notifications for edits of statuses this account boosted or faved (only for local accounts).
+	AutoAcceptFollowedBack             *bool            `bun:",default:false"`                 // Automatically accept follow requests from accounts this (locked) account already follows (only for local accounts).
+	AutoAcceptLocal                    *bool            `bun:",default:false"`                 // Automatically accept follow requests from accounts on this instance (only for local accounts).
+	AutoAcceptOlderThanDays            int              `bun:",notnull,default:0"`             // Automatically accept follow requests from accounts created more than this many days ago; 0 to disable (only for local accounts).
+	StatusesAutoDeleteAfterDays        int              `bun:",notnull,default:0"`             // Automatically delete statuses of this account older than this many days; 0 to disable (only for local accounts).
+	StatusesAutoDeleteKeepPinned       *bool            `bun:",default:true"`                  // Don't automatically delete statuses pinned by this account (only for local accounts).
+	StatusesAutoDeleteKeepBookmarked   *bool            `bun:",default:true"`                  // Don't automatically delete statuses bookmarked by this account (only for local accounts).
+	StatusesAutoDeleteKeepDirect       *bool            `bun:",default:true"`                  // Don't automatically delete direct messages of this account (only for local accounts).
+	NotificationsFilterNotFollowing    *bool            `bun:",default:false"`                 // Filter notifications from accounts this account doesn't follow into notification requests (only for local accounts).
+	NotificationsFilterNewAccounts     *bool            `bun:",default:false"`                 // Filter notifications from recently created accounts into notification requests (only for local accounts).
+	StatusesMaxChars                   int              `bun:",notnull,default:0"`             // Override of the instance max permitted characters for statuses posted by this account, set by an admin; 0 to use the instance setting (only for local accounts).
+	SuspensionOrigin                   string           `bun:"type:CHAR(26),nullzero"`         // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
+	EnableRSS                          *bool            `bun:",default:false"`                 // enable RSS feed subscription for this account's public posts at [URL]/feed
+	RSSIncludeSensitive                *bool            `bun:",default:false"`                 // include statuses marked as sensitive in this account's RSS feed
+	Theme                              string           `bun:",nullzero"`                      // filename of the web theme chosen by this account, if any
+	TombstonedAt                       time.Time        `bun:"type:timestamptz,nullzero"`      // When was this remote account found to be gone (deleted) on its instance? Tombstoned accounts are suspended, and no longer refreshed.
+	NotFoundCount                      int              `bun:",notnull,default:0"`             // How many consecutive times has dereferencing this remote account returned 404 Not Found?
 }
 
 // IsLocal returns whether account is a local user account.
 func (a *Account) IsLocal() bool {
-	return a.Domain == "" || a.Domain == config.GetHost() || a.Domain == config.GetAccountDomain()
+	return a.Domain == "" || config.IsLocalHost(a.Domain)
+}
+
+// MaxStatusChars returns the max permitted characters
+// (including content warning) for statuses posted by this
+// account, ie., the admin set override if any, else the
+// instance setting.
+func (a *Account) MaxStatusChars() int {
+	if a.StatusesMaxChars > 0 {
+		return a.StatusesMaxChars
+	}
+	return config.GetStatusesMaxChars()
+}
+
+// IsTombstoned returns whether account is a remote
+// account that has been marked as gone from its instance.
+func (a *Account) IsTombstoned() bool {
+	return !a.TombstonedAt.IsZero()
 }
 
 // IsRemote returns whether account is a remote user account.
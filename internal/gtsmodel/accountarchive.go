@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountArchiveStage describes how far along
+// the generation of an account archive is.
+type AccountArchiveStage uint8
+
+// Only ever add new stages to the *END* of the list
+// below, DO NOT insert them before/between other entries!
+
+const (
+	AccountArchiveStageOutbox   AccountArchiveStage = iota // Statuses are being written to outbox parts.
+	AccountArchiveStageBundle                              // Outbox is complete, archive zip is being bundled.
+	AccountArchiveStageComplete                            // Archive zip is ready for download.
+	AccountArchiveStageFailed                              // Archive generation failed.
+)
+
+func (s AccountArchiveStage) String() string {
+	switch s {
+	case AccountArchiveStageOutbox:
+		return "outbox"
+	case AccountArchiveStageBundle:
+		return "bundle"
+	case AccountArchiveStageComplete:
+		return "complete"
+	case AccountArchiveStageFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// AccountArchive models a (possibly still in-progress)
+// export of all of a local account's data, in the form
+// of a zip archive held in storage. Progress is stored
+// as the archive is generated, so that it can be resumed
+// from where it left off if the instance is restarted.
+type AccountArchive struct {
+	ID            string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID     string              `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account this archive belongs to
+	Account       *Account            `bun:"-"`                                                           // account corresponding to accountID
+	Stage         AccountArchiveStage `bun:",notnull,default:0"`                                          // how far along generation of this archive is
+	OutboxMaxID   string              `bun:"type:CHAR(26),nullzero"`                                      // id of the oldest status written to the outbox so far, to page down from
+	OutboxPartIDs []string            `bun:"outbox_parts,array"`                                          // ids of outbox parts written to storage so far, in order
+	StatusesCount int                 `bun:",notnull,default:0"`                                          // number of statuses written to the outbox so far
+	Token         string              `bun:",nullzero,notnull"`                                           // secret token required to download the archive
+	FileSize      int64               `bun:",notnull,default:0"`                                          // size of the archive zip in bytes, once complete
+	CompletedAt   time.Time           `bun:"type:timestamptz,nullzero"`                                   // when was the archive ready for download
+	ExpiresAt     time.Time           `bun:"type:timestamptz,nullzero"`                                   // when does the download link for the archive expire
+}
+
+// InFlight returns true if generation
+// of this archive is not yet finished.
+func (a *AccountArchive) InFlight() bool {
+	return a.Stage == AccountArchiveStageOutbox ||
+		a.Stage == AccountArchiveStageBundle
+}
@@ -0,0 +1,33 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountStats models statistics for an account, which are
+// updated incrementally as follows and statuses are created
+// and deleted, and periodically regenerated from scratch in
+// order to correct any drift from the real counts.
+type AccountStats struct {
+	AccountID      string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of the account these stats are for
+	RegeneratedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when were these stats last regenerated from scratch
+	FollowersCount int       `bun:",notnull,default:0"`                                          // number of accounts following this account
+	FollowingCount int       `bun:",notnull,default:0"`                                          // number of accounts followed by this account
+	StatusesCount  int       `bun:",notnull,default:0"`                                          // number of statuses (including boosts) created by this account
+	LastStatusAt   time.Time `bun:"type:timestamptz,nullzero"`                                   // when did this account last create a status
+}
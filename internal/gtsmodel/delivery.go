@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Delivery models an outgoing delivery of an ActivityPub
+// activity to a remote inbox which failed, and has been
+// queued to be retried later.
+type Delivery struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created, ie., when was delivery first attempted
+	PubKeyID      string    `bun:",nullzero,notnull"`                                           // public key ID of the local account to sign the delivery with
+	InboxURI      string    `bun:",nullzero,notnull"`                                           // inbox to deliver to
+	Domain        string    `bun:",nullzero,notnull"`                                           // domain of the inbox
+	Data          []byte    `bun:",nullzero,notnull"`                                           // serialized activity to deliver
+	Attempts      int       `bun:",notnull,default:1"`                                          // number of delivery attempts made so far
+	NextAttemptAt time.Time `bun:"type:timestamptz,nullzero,notnull"`                           // when should delivery next be attempted
+	LastError     string    `bun:",nullzero"`                                                   // error of the last delivery attempt
+}
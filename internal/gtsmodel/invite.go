@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Invite models an invite to sign up on this instance,
+// created by an admin. Signing up with the code of a
+// valid invite skips the approval queue, and may grant
+// the new user a role.
+type Invite struct {
+	ID                 string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Code               string     `bun:",nullzero,notnull,unique"`                                    // code to give when signing up with this invite
+	CreatedByAccountID string     `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account that created this invite
+	MaxUses            int        `bun:",nullzero"`                                                   // how many times this invite can be used, 0 for no limit
+	Uses               int        `bun:",notnull,default:0"`                                          // how many times this invite has been used
+	ExpiresAt          time.Time  `bun:"type:timestamptz,nullzero"`                                   // when does this invite expire, zero for never
+	Role               InviteRole `bun:",nullzero"`                                                   // role granted to users signing up with this invite, if any
+}
+
+// Expired returns true if the invite has expired (or been revoked).
+func (i *Invite) Expired() bool {
+	return !i.ExpiresAt.IsZero() && !time.Now().Before(i.ExpiresAt)
+}
+
+// UsedUp returns true if the invite has been
+// used the maximum number of times allowed.
+func (i *Invite) UsedUp() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// InviteRole is a role granted
+// to users who sign up with an
+// invite.
+type InviteRole string
+
+const (
+	InviteRoleNone      InviteRole = ""
+	InviteRoleModerator InviteRole = "moderator"
+	InviteRoleAdmin     InviteRole = "admin"
+)
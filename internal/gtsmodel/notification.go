@@ -32,6 +32,7 @@ type Notification struct {
 	StatusID         string           `bun:"type:CHAR(26),nullzero"`                                      // If the notification pertains to a status, what is the database ID of that status?
 	Status           *Status          `bun:"-"`                                                           // Status corresponding to StatusID. Can be nil, always check first + select using ID if necessary.
 	Read             *bool            `bun:",nullzero,notnull,default:false"`                             // Notification has been seen/read
+	Filtered         *bool            `bun:",nullzero,notnull,default:false"`                             // Notification was filtered out by the target's notification policy, and is held in a notification request
 }
 
 // NotificationType describes the reason/type of this notification.
@@ -46,4 +47,6 @@ const (
 	NotificationFave          NotificationType = "favourite"      // NotificationFave -- someone faved/liked one of your statuses
 	NotificationPoll          NotificationType = "poll"           // NotificationPoll -- a poll you voted in or created has ended
 	NotificationStatus        NotificationType = "status"         // NotificationStatus -- someone you enabled notifications for has posted a status.
+	NotificationReaction      NotificationType = "reaction"       // NotificationReaction -- someone reacted to one of your statuses with an emoji
+	NotificationUpdate        NotificationType = "update"         // NotificationUpdate -- a status you boosted or faved has been edited
 )
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// NotificationRequest groups notifications that were filtered
+// out by an account's notification policy, by the account
+// they originated from. The account can then choose to accept
+// or dismiss all notifications from that origin at once.
+type NotificationRequest struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID          string    `bun:"type:CHAR(26),unique:accountfromaccount,nullzero,notnull"`    // id of the account whose notifications were filtered
+	Account            *Account  `bun:"-"`                                                           // Account corresponding to AccountID
+	FromAccountID      string    `bun:"type:CHAR(26),unique:accountfromaccount,nullzero,notnull"`    // id of the account the filtered notifications originate from
+	FromAccount        *Account  `bun:"-"`                                                           // Account corresponding to FromAccountID
+	NotificationsCount int       `bun:",notnull,default:0"`                                          // number of notifications filtered into this request
+}
+
+// NotificationPermission allows notifications from the
+// account with FromAccountID to reach the account with
+// AccountID, regardless of the latter's notification policy.
+type NotificationPermission struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID     string    `bun:"type:CHAR(26),unique:accountfromaccount,nullzero,notnull"`    // id of the account that granted this permission
+	FromAccountID string    `bun:"type:CHAR(26),unique:accountfromaccount,nullzero,notnull"`    // id of the account permitted to notify
+}
@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// QuarantinedStatus models an incoming status that was identified
+// as spam by the inbox spam filter, and held back for review by an
+// admin rather than being dropped. The AS representation of the status
+// is kept so that it can be processed as normal if an admin approves it.
+type QuarantinedStatus struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                 // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                              // when was item created
+	URI                string    `bun:",nullzero,notnull,unique:quarantined_statuses_uri_receiving_account_id_uniq"`              // ActivityPub URI of the quarantined status
+	AccountID          string    `bun:"type:CHAR(26),nullzero,notnull"`                                                           // id of the account that sent the status
+	Account            *Account  `bun:"-"`                                                                                        // account corresponding to accountID
+	ReceivingAccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique:quarantined_statuses_uri_receiving_account_id_uniq"` // id of the local account the status was delivered to
+	ReceivingAccount   *Account  `bun:"-"`                                                                                        // account corresponding to receivingAccountID
+	Reason             string    `bun:",nullzero"`                                                                                // reason the status was identified as spam
+	Data               []byte    `bun:",nullzero,notnull"`                                                                        // serialized ActivityStreams JSON of the status
+}
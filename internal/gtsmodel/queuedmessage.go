@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// QueuedMessage models a client API or federator worker
+// message that has been journaled before being queued for
+// processing, so that it can be replayed at startup should
+// processing not have finished, eg., due to a crash. Rather
+// than storing the message's models themselves, only their
+// types and IDs are stored, to be fetched again on replay,
+// except for models already deleted from the database (eg.,
+// of Undo messages), a snapshot of which is stored instead.
+type QueuedMessage struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created, ie., when was message queued
+	Queue              string    `bun:",nullzero,notnull"`                                           // queue the message was sent to, see QueuedMessageQueue___ consts
+	APObjectType       string    `bun:",nullzero"`                                                   // ActivityPub object type of the message
+	APActivityType     string    `bun:",nullzero"`                                                   // ActivityPub activity type of the message
+	APIRI              string    `bun:"apiri,nullzero"`                                              // ActivityPub IRI of the message, if any
+	ModelType          string    `bun:",nullzero"`                                                   // type of the message's GTS model, if any, see QueuedMessageModel___ consts
+	ModelID            string    `bun:"type:CHAR(26),nullzero"`                                      // database ID of the message's GTS model, if any
+	ModelData          string    `bun:",nullzero"`                                                   // JSON snapshot of the message's GTS model, if not stored by ID
+	OriginAccountID    string    `bun:"type:CHAR(26),nullzero"`                                      // id of the message's origin account (client API only)
+	TargetAccountID    string    `bun:"type:CHAR(26),nullzero"`                                      // id of the message's target account (client API only)
+	ReceivingAccountID string    `bun:"type:CHAR(26),nullzero"`                                      // id of the message's receiving account (federator only)
+}
+
+// Queues that a QueuedMessage can belong to.
+const (
+	QueuedMessageQueueClientAPI = "client_api"
+	QueuedMessageQueueFediAPI   = "fedi_api"
+)
+
+// GTS model types that a QueuedMessage can refer to.
+const (
+	QueuedMessageModelAccount       = "account"
+	QueuedMessageModelBlock         = "block"
+	QueuedMessageModelDomainBlock   = "domain_block"
+	QueuedMessageModelFollow        = "follow"
+	QueuedMessageModelFollowRequest = "follow_request"
+	QueuedMessageModelPollVote      = "poll_vote"
+	QueuedMessageModelReport        = "report"
+	QueuedMessageModelStatus        = "status"
+	QueuedMessageModelStatusFave    = "status_fave"
+)
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Relay models a relay followed by this
+// instance for content discovery. Relays
+// are followed by the instance actor.
+type Relay struct {
+	ID        string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	InboxURI  string     `bun:",nullzero,notnull,unique"`                                    // inbox of the relay, to which the follow is delivered
+	FollowURI string     `bun:",nullzero,notnull,unique"`                                    // URI of the follow activity sent to the relay by the instance actor
+	Domain    string     `bun:",nullzero"`                                                   // domain of the relay, taken from its inbox URI
+	AccountID string     `bun:"type:CHAR(26),nullzero"`                                      // id of the relay actor account, set once the relay has accepted the follow
+	State     RelayState `bun:",nullzero,notnull,default:'pending'"`                         // state of the follow of this relay
+}
+
+// RelayState is the state of
+// the follow of a relay.
+type RelayState string
+
+const (
+	RelayStatePending  RelayState = "pending"  // follow sent, awaiting accept
+	RelayStateAccepted RelayState = "accepted" // follow accepted by the relay
+	RelayStateRemoved  RelayState = "removed"  // follow undone, relay kept to drop its traffic
+)
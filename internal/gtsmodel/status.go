@@ -54,6 +54,9 @@ type Status struct {
 	BoostOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the boosted status
 	BoostOf                  *Status            `bun:"-"`                                                           // status that corresponds to boostOfID
 	BoostOfAccount           *Account           `bun:"rel:belongs-to"`                                              // account that corresponds to boostOfAccountID
+	QuoteOfID                string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status quotes, if known
+	QuoteOfURI               string             `bun:",nullzero"`                                                   // activitypub uri of the status this status quotes
+	QuoteOf                  *Status            `bun:"-"`                                                           // status that corresponds to quoteOfID
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero"`                                      // id of the thread to which this status belongs; only set for remote statuses if a local account is involved at some point in the thread, otherwise null
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
@@ -65,10 +68,14 @@ type Status struct {
 	CreatedWithApplication   *Application       `bun:"rel:belongs-to"`                                              // application corresponding to createdWithApplicationID
 	ActivityStreamsType      string             `bun:",nullzero,notnull"`                                           // What is the activitystreams type of this status? See: https://www.w3.org/TR/activitystreams-vocabulary/#object-types. Will probably almost always be Note but who knows!.
 	Text                     string             `bun:""`                                                            // Original text of the status without formatting
+	ContentType              string             `bun:",nullzero"`                                                   // Content type (eg., text/plain, text/markdown) of the original text; empty for remote statuses
 	Federated                *bool              `bun:",notnull"`                                                    // This status will be federated beyond the local timeline(s)
 	Boostable                *bool              `bun:",notnull"`                                                    // This status can be boosted/reblogged
 	Replyable                *bool              `bun:",notnull"`                                                    // This status can be replied to
 	Likeable                 *bool              `bun:",notnull"`                                                    // This status can be liked/faved
+	ReplyPolicy              InteractionPolicy  `bun:",nullzero"`                                                   // Which accounts may reply to this status (if Replyable); empty means anyone
+	BoostPolicy              InteractionPolicy  `bun:",nullzero"`                                                   // Which accounts may boost this status (if Boostable); empty means anyone
+	LikePolicy               InteractionPolicy  `bun:",nullzero"`                                                   // Which accounts may like this status (if Likeable); empty means anyone
 }
 
 // GetID implements timeline.Timelineable{}.
@@ -163,6 +170,22 @@ func (s *Status) EmojisUpToDate(other *Status) bool {
 	return true
 }
 
+// InteractionPolicy describes which accounts are
+// permitted to interact with a status in a given way.
+type InteractionPolicy string
+
+const (
+	InteractionPolicyAnyone    InteractionPolicy = ""          // Any account that can see the status may interact.
+	InteractionPolicyFollowers InteractionPolicy = "followers" // Only followers of the author, or mentioned accounts, may interact.
+	InteractionPolicyMentioned InteractionPolicy = "mentioned" // Only accounts mentioned in the status may interact.
+)
+
+// IsLocalOnly returns true if this status
+// is set to not be federated beyond this instance.
+func (s *Status) IsLocalOnly() bool {
+	return s.Federated != nil && !*s.Federated
+}
+
 // GetAttachmentByRemoteURL searches status for MediaAttachment{} with remote URL.
 func (s *Status) GetAttachmentByRemoteURL(url string) (*MediaAttachment, bool) {
 	for _, media := range s.Attachments {
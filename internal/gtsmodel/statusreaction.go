@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StatusReaction refers to an emoji reaction (Misskey / Pleroma style)
+// in the database, from one account, targeting the status of another.
+type StatusReaction struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                               // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`            // when was item created
+	UpdatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`            // when was item last updated
+	AccountID       string    `bun:"type:CHAR(26),unique:statusreactionaccountstatusemoji,nullzero,notnull"` // id of the account that created ('did') the reaction
+	Account         *Account  `bun:"-"`                                                                      // account that created the reaction
+	TargetAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                                         // id the account owning the reacted-to status
+	TargetAccount   *Account  `bun:"-"`                                                                      // account owning the reacted-to status
+	StatusID        string    `bun:"type:CHAR(26),unique:statusreactionaccountstatusemoji,nullzero,notnull"` // database id of the status that has been reacted to
+	Status          *Status   `bun:"-"`                                                                      // the reacted-to status
+	Content         string    `bun:",unique:statusreactionaccountstatusemoji,nullzero,notnull"`              // unicode emoji, or ':shortcode:' of custom emoji, used to react
+	EmojiID         string    `bun:"type:CHAR(26),nullzero"`                                                 // id of the custom emoji used to react, if any
+	Emoji           *Emoji    `bun:"-"`                                                                      // custom emoji used to react, if any
+	URI             string    `bun:",nullzero,notnull,unique"`                                               // ActivityPub URI of this reaction
+}
+
+// IsCustomEmoji returns whether this reaction
+// was made using a custom emoji, or unicode emoji.
+func (r *StatusReaction) IsCustomEmoji() bool {
+	return r.EmojiID != ""
+}
@@ -27,5 +27,17 @@ type Tag struct {
 	Name      string    `bun:",unique,nullzero,notnull"`                                    // (lowercase) name of the tag without the hash prefix
 	Useable   *bool     `bun:",nullzero,notnull,default:true"`                              // Tag is useable on this instance.
 	Listable  *bool     `bun:",nullzero,notnull,default:true"`                              // Tagged statuses can be listed on this instance.
+	Trendable *bool     `bun:",nullzero,notnull,default:false"`                             // Tag has been approved by an admin to be shown in trends on this instance.
 	Href      string    `bun:"-"`                                                           // Href of the hashtag. Will only be set on freshly-extracted hashtags from remote AP messages. Not stored in the database.
 }
+
+// TagUse represents one use of a tag by a
+// public status, used to calculate per-day
+// tag usage history and trending tags.
+type TagUse struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	TagID     string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the used tag
+	StatusID  string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the status using the tag
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account that created the status
+}
@@ -38,7 +38,9 @@ type User struct {
 	LastSignInAt           time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did this user last sign in?
 	LastSignInIP           net.IP       `bun:",nullzero"`                                                   // What's the previous IP of this user?
 	SignInCount            int          `bun:",notnull,default:0"`                                          // How many times has this user signed in?
-	InviteID               string       `bun:"type:CHAR(26),nullzero"`                                      // id of the user who invited this user (who let this joker in?)
+	FailedSignInCount      int          `bun:",notnull,default:0"`                                          // How many times in a row has sign in failed for this user, due to an incorrect password?
+	SignInLockedUntil      time.Time    `bun:"type:timestamptz,nullzero"`                                   // Until when is sign in for this user locked out, following too many failed sign ins?
+	InviteID               string       `bun:"type:CHAR(26),nullzero"`                                      // id of the invite this user signed up with (who let this joker in?)
 	ChosenLanguages        []string     `bun:",nullzero"`                                                   // What languages does this user want to see?
 	FilteredLanguages      []string     `bun:",nullzero"`                                                   // What languages does this user not want to see?
 	Locale                 string       `bun:",nullzero"`                                                   // In what timezone/locale is this user located?
@@ -56,6 +58,8 @@ type User struct {
 	ResetPasswordToken     string       `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
 	ExternalID             string       `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	DeletionScheduledAt    time.Time    `bun:"type:timestamptz,nullzero"`                                   // When is this user's account due to be deleted, following a self-requested deletion?
+	DeletionCancelToken    string       `bun:",nullzero"`                                                   // The generated token that the user can use to cancel a scheduled deletion of their account
 }
 
 // NewSignup models parameters for the creation
@@ -76,4 +80,6 @@ type NewSignup struct {
 	EmailVerified bool   // Mark submitted email address as already verified (optional).
 	ExternalID    string // ID of this user in external OIDC system (optional).
 	Admin         bool   // Mark new user as an admin user (optional).
+	Moderator     bool   // Mark new user as a moderator user (optional).
+	InviteID      string // ID of the invite used to sign up (optional).
 }
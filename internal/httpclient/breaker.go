@@ -0,0 +1,234 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+)
+
+// maxBreakerBackoffShift is the max number of times
+// an open circuit's backoff will be doubled after
+// a failed probe, ie. max backoff is base * 2^5.
+const maxBreakerBackoffShift = 5
+
+// breakerState is the state of
+// a remote host's circuit breaker.
+type breakerState uint8
+
+const (
+	// breakerClosed is the default, healthy
+	// state: all requests are allowed through.
+	breakerClosed breakerState = iota
+
+	// breakerOpen means the host has been failing,
+	// all requests fail immediately until backoff.
+	breakerOpen
+
+	// breakerHalfOpen means backoff is over, and
+	// a single probe request is being allowed
+	// through to check if host has recovered.
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// breakerResult is the result of a
+// request, as far as the breaker cares.
+type breakerResult uint8
+
+const (
+	// breakerSuccess indicates a host responded.
+	breakerSuccess breakerResult = iota
+
+	// breakerFailure indicates a host failed
+	// to respond, or responded with temporary
+	// server error after all retries.
+	breakerFailure
+
+	// breakerIgnore indicates an error on our
+	// side, e.g. a cancelled context, which
+	// says nothing about the host's health.
+	breakerIgnore
+)
+
+// breaker tracks the circuit
+// state of a single remote host.
+type breaker struct {
+	state    breakerState
+	failures int       // consecutive failures
+	shift    int       // backoff multiplier shift
+	until    time.Time // when open circuit may be probed
+}
+
+// breakers provides per-host circuit breaking, to stop
+// requests to hosts that are down from tying up workers
+// waiting on timeouts. Only hosts that are failing are
+// tracked, a successful request removes the host entry.
+type breakers struct {
+	mu       sync.Mutex
+	hosts    map[string]*breaker
+	failures int           // no. failures to open circuit
+	backoff  time.Duration // base open circuit duration
+}
+
+// newBreakers returns a new breakers{} that opens a host's
+// circuit after given no. consecutive failures, for backoff
+// duration. If failures <= 0, circuit breaking is disabled.
+func newBreakers(failures int, backoff time.Duration) *breakers {
+	if backoff <= 0 {
+		backoff = time.Minute
+	}
+	return &breakers{
+		hosts:    make(map[string]*breaker),
+		failures: failures,
+		backoff:  backoff,
+	}
+}
+
+// allow returns whether a request to the given host may be
+// performed now. If the host's open circuit is due a probe,
+// this will allow just the single caller through to probe.
+func (b *breakers) allow(host string) bool {
+	if b.failures <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.hosts[host]
+	if !ok {
+		return true
+	}
+
+	switch br.state {
+	case breakerOpen:
+		if time.Now().Before(br.until) {
+			return false
+		}
+
+		// Backoff is over, let
+		// this one probe through.
+		b.transition(host, br, breakerHalfOpen)
+		return true
+
+	case breakerHalfOpen:
+		// Probe already in-flight.
+		return false
+
+	default:
+		return true
+	}
+}
+
+// record updates the circuit state of
+// host with the result of a request.
+func (b *breakers) record(host string, result breakerResult) {
+	if b.failures <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.hosts[host]
+
+	switch result {
+	case breakerSuccess:
+		if !ok {
+			return
+		}
+
+		if br.state != breakerClosed {
+			b.transition(host, br, breakerClosed)
+		}
+
+		// Host is healthy,
+		// stop tracking it.
+		delete(b.hosts, host)
+
+	case breakerFailure:
+		if !ok {
+			br = new(breaker)
+			b.hosts[host] = br
+		}
+
+		switch br.state {
+		case breakerClosed:
+			br.failures++
+			if br.failures < b.failures {
+				return
+			}
+
+			// Reached max
+			// failures, open.
+			br.shift = 0
+
+		case breakerHalfOpen:
+			// Probe failed, back
+			// off for longer.
+			if br.shift < maxBreakerBackoffShift {
+				br.shift++
+			}
+
+		default:
+			// Already open, this
+			// was started before.
+			return
+		}
+
+		br.until = time.Now().Add(b.backoff << br.shift)
+		b.transition(host, br, breakerOpen)
+
+	case breakerIgnore:
+		if ok && br.state == breakerHalfOpen {
+			// Probe told us nothing, return
+			// to open so another may probe.
+			br.state = breakerOpen
+		}
+	}
+}
+
+// transition moves host's breaker to given state, logging
+// and recording metrics. Must be called with mutex held.
+func (b *breakers) transition(host string, br *breaker, state breakerState) {
+	switch state {
+	case breakerOpen:
+		log.Warnf(nil, "opening circuit to %s after %d failures, backing off until %s",
+			host, br.failures, br.until.Format(time.RFC3339))
+	case breakerClosed:
+		log.Infof(nil, "closing circuit to %s, host has recovered", host)
+	}
+	metrics.CircuitBreaker(host, br.state.String(), state.String())
+	br.state = state
+}
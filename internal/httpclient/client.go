@@ -54,6 +54,9 @@ var (
 
 	// ErrBodyTooLarge is returned when a received response body is above predefined limit (default 40MB).
 	ErrBodyTooLarge = errors.New("body size too large")
+
+	// ErrCircuitOpen is returned when a request is not performed as the remote host has been failing too often.
+	ErrCircuitOpen = errors.New("circuit open for host")
 )
 
 // Config provides configuration details for setting up a new
@@ -79,6 +82,15 @@ type Config struct {
 	// Timeout: see http.Client{}.Timeout.
 	Timeout time.Duration
 
+	// BreakerFailures is the number of consecutive failed
+	// requests to a host after which its circuit is opened,
+	// failing further requests immediately. <= 0 disables.
+	BreakerFailures int
+
+	// BreakerBackoff is the initial duration a host's
+	// circuit is opened for, before it may be probed.
+	BreakerBackoff time.Duration
+
 	// DisableCompression: see http.Transport{}.DisableCompression.
 	DisableCompression bool
 
@@ -104,11 +116,13 @@ type Config struct {
 //   - protection from server side request forgery (SSRF) by only dialing
 //     out to known public IP prefixes, configurable with allows/blocks
 //   - retry-backoff logic for error temporary HTTP error responses
+//   - per-host circuit breaking for hosts that keep failing
 //   - optional request signing
 //   - request logging
 type Client struct {
 	client   http.Client
 	badHosts cache.TTLCache[string, struct{}]
+	breakers *breakers
 	bodyMax  int64
 }
 
@@ -147,6 +161,7 @@ func New(cfg Config) *Client {
 	// Prepare client fields.
 	c.client.Timeout = cfg.Timeout
 	c.bodyMax = cfg.MaxBodySize
+	c.breakers = newBreakers(cfg.BreakerFailures, cfg.BreakerBackoff)
 
 	// Prepare TLS config for transport.
 	tlsClientConfig := &tls.Config{
@@ -212,6 +227,26 @@ func (c *Client) DoSigned(r *http.Request, sign SignFunc) (rsp *http.Response, e
 	// Get request hostname.
 	host := r.URL.Hostname()
 
+	// Check host's circuit isn't open, i.e.
+	// it hasn't been failing consistently.
+	if !c.breakers.allow(host) {
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+	}
+
+	// Whether error was our own doing.
+	var localErr bool
+
+	defer func() {
+		switch {
+		case err == nil:
+			c.breakers.record(host, breakerSuccess)
+		case localErr || r.Context().Err() != nil:
+			c.breakers.record(host, breakerIgnore)
+		default:
+			c.breakers.record(host, breakerFailure)
+		}
+	}()
+
 	// Check whether request should fast fail.
 	fastFail := gtscontext.IsFastfail(r.Context())
 	if !fastFail {
@@ -253,6 +288,7 @@ func (c *Client) DoSigned(r *http.Request, sign SignFunc) (rsp *http.Response, e
 
 		// Sign the outgoing request.
 		if err := sign(r); err != nil {
+			localErr = true
 			return nil, err
 		}
 
@@ -300,7 +336,9 @@ func (c *Client) DoSigned(r *http.Request, sign SignFunc) (rsp *http.Response, e
 			ErrBodyTooLarge,
 			ErrReservedAddr,
 		) {
-			// Non-retryable errors.
+			// Non-retryable errors. Of these,
+			// only timeouts are the host's fault.
+			localErr = !errorsv2.IsV2(err, context.DeadlineExceeded)
 			return nil, err
 		} else if errstr := err.Error(); // nocollapse
 		strings.Contains(errstr, "stopped after 10 redirects") ||
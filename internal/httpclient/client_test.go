@@ -19,13 +19,17 @@ package httpclient_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/netip"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 )
 
@@ -143,3 +147,69 @@ func TestHTTPClientPrivateIP(t *testing.T) {
 		}
 	}
 }
+
+func TestHTTPClientCircuitBreaker(t *testing.T) {
+	var (
+		hits    atomic.Int32
+		healthy atomic.Bool
+	)
+
+	// Start a test server that fails until marked healthy.
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if !healthy.Load() {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	client := httpclient.New(httpclient.Config{
+		BreakerFailures: 2,
+		BreakerBackoff:  100 * time.Millisecond,
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+	})
+
+	do := func() error {
+		// Use fast-fail so failures aren't retried with backoff.
+		ctx := gtscontext.SetFastFail(context.Background())
+		req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+		rsp, err := client.Do(req)
+		if err == nil {
+			_ = rsp.Body.Close()
+		}
+		return err
+	}
+
+	// Fail enough times to open the circuit.
+	for i := 0; i < 2; i++ {
+		if err := do(); err == nil {
+			t.Fatal("expected request to failing server to error")
+		}
+	}
+
+	// Circuit is now open, request
+	// shouldn't even reach the server.
+	if err := do(); !errors.Is(err, httpclient.ErrCircuitOpen) {
+		t.Fatalf("expected circuit open error, got: %v", err)
+	}
+	if n := hits.Load(); n != 2 {
+		t.Fatalf("expected 2 requests to reach server, got %d", n)
+	}
+
+	// Recover server, wait for backoff to pass,
+	// and ensure the probe closes the circuit.
+	healthy.Store(true)
+	time.Sleep(150 * time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		if err := do(); err != nil {
+			t.Fatalf("expected request to recovered server to succeed, got: %v", err)
+		}
+	}
+	if n := hits.Load(); n != 4 {
+		t.Fatalf("expected 4 requests to reach server, got %d", n)
+	}
+}
@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/h2non/filetype"
+	"github.com/h2non/filetype/matchers/isobmff"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+func init() {
+	// filetype doesn't know AVIF, which is an
+	// ISOBMFF container like mp4, so teach it.
+	filetype.AddMatcher(
+		filetype.NewType(mimeAvif, mimeImageAvif),
+		isAVIF,
+	)
+}
+
+// isAVIF returns whether buf is the
+// start of an AVIF image or sequence.
+func isAVIF(buf []byte) bool {
+	if !isobmff.IsISOBMFF(buf) {
+		return false
+	}
+
+	major, _, compatible := isobmff.GetFtyp(buf)
+	if major == "avif" || major == "avis" {
+		return true
+	}
+
+	for _, brand := range compatible {
+		if brand == "avif" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeImageFFmpeg decodes the first frame of the image
+// in r using ffmpeg, for formats we can't decode in Go.
+//
+// The image is spooled to a temp file first, as ffmpeg
+// needs to seek within ISOBMFF containers such as AVIF.
+func decodeImageFFmpeg(ctx context.Context, r io.Reader) (*gtsImage, error) {
+	tmp, err := os.CreateTemp("", "gotosocial-decode-*")
+	if err != nil {
+		return nil, gtserror.Newf("error creating temp file: %w", err)
+	}
+	defer removeTemp(ctx, tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return nil, gtserror.Newf("error writing temp file: %w", err)
+	}
+
+	out, err := runFFmpeg(ctx, nil,
+		"-i", tmp.Name(),
+		"-frames:v", "1",
+		"-c:v", "png",
+		"-f", "image2pipe", "-",
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding image: %w", err)
+	}
+
+	return decodeImage(bytes.NewReader(out))
+}
+
+// ToWebP encodes the receiving image as WebP using ffmpeg,
+// which must be built with libwebp, as there is no WebP
+// encoder in Go. The image is handed to ffmpeg as PNG.
+func (m *gtsImage) ToWebP(ctx context.Context, quality int) ([]byte, error) {
+	var png bytes.Buffer
+	if err := pngEncoder.Encode(&png, m.image); err != nil {
+		return nil, gtserror.Newf("error encoding png: %w", err)
+	}
+
+	out, err := runFFmpeg(ctx, &png,
+		"-f", "png_pipe", "-i", "-",
+		"-c:v", "libwebp",
+		"-quality", strconv.Itoa(quality),
+		"-f", "webp", "-",
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error encoding webp: %w", err)
+	}
+
+	return out, nil
+}
+
+// runFFmpeg runs ffmpeg with the given args, feeding it
+// stdin if set, and returns whatever it wrote to stdout.
+// It is bounded by the configured transcode timeout.
+func runFFmpeg(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	timeout := config.GetMediaTranscodeTimeout()
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Note -nostdin only disables interaction,
+	// so input can still be piped with "-i -".
+	args = append([]string{
+		"-hide_banner", "-loglevel", "error", "-nostdin",
+	}, args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(tctx, config.GetMediaTranscodeFfmpegPath(), args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			// Caller gave up.
+			return nil, ctx.Err()
+		}
+
+		if tctx.Err() != nil {
+			// Our own timeout hit.
+			return nil, gtserror.Newf("ffmpeg took longer than %s", timeout)
+		}
+
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > 512 {
+			msg = "..." + msg[len(msg)-512:]
+		}
+		return nil, gtserror.Newf("%w: %s", err, msg)
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -25,6 +25,7 @@ import (
 
 	"codeberg.org/gruf/go-iotools"
 	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -42,6 +43,37 @@ var SupportedMIMETypes = []string{
 	mimeVideoMp4,
 }
 
+// TranscodeMIMETypes are video types that can be
+// uploaded if transcoding is enabled, and will be
+// transcoded to mp4 before being stored.
+var TranscodeMIMETypes = []string{
+	mimeVideoWebm,
+	mimeVideoQuicktime,
+	mimeVideoMatroska,
+}
+
+// FFmpegImageMIMETypes are image types that can be
+// uploaded if transcoding is enabled, as they're
+// decoded with ffmpeg. They're stored as uploaded.
+var FFmpegImageMIMETypes = []string{
+	mimeImageAvif,
+}
+
+// UploadMIMETypes returns the types of media that
+// can currently be uploaded, which depends on
+// whether transcoding is enabled in config.
+func UploadMIMETypes() []string {
+	if !config.GetMediaTranscodeEnabled() {
+		return SupportedMIMETypes
+	}
+
+	types := make([]string, 0, len(SupportedMIMETypes)+
+		len(TranscodeMIMETypes)+len(FFmpegImageMIMETypes))
+	types = append(types, SupportedMIMETypes...)
+	types = append(types, TranscodeMIMETypes...)
+	return append(types, FFmpegImageMIMETypes...)
+}
+
 var SupportedEmojiMIMETypes = []string{
 	mimeImageGif,
 	mimeImagePng,
@@ -169,6 +201,38 @@ func (m *Manager) PreProcessMedia(
 	return processingMedia
 }
 
+// ResumeProcessing queues processing again for local
+// media left marked as processing by LoadAttachmentAsync,
+// eg., because the server was stopped part way through.
+func (m *Manager) ResumeProcessing(ctx context.Context) error {
+	media, err := m.state.DB.GetProcessingAttachments(ctx)
+	if err != nil {
+		return gtserror.Newf("error getting processing media: %w", err)
+	}
+
+	for _, attachment := range media {
+		if !*attachment.Cached {
+			// Never got as far as storing the file,
+			// eg., it was awaiting transcode from a
+			// temp file. Nothing left to resume.
+			attachment.Processing = gtsmodel.ProcessingStatusError
+			if err := m.state.DB.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+				log.Errorf(ctx, "error updating media %s: %v", attachment.ID, err)
+			}
+			continue
+		}
+
+		log.Infof(ctx, "resuming processing of media %s", attachment.ID)
+		processingMedia := &ProcessingMedia{
+			media: attachment,
+			mgr:   m,
+		}
+		go m.state.Workers.Media.Enqueue(processingMedia.processAsync)
+	}
+
+	return nil
+}
+
 // PreProcessMediaRecache refetches, reprocesses,
 // and recaches an existing attachment that has
 // been uncached via cleaner pruning.
@@ -23,16 +23,21 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
 	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/h2non/filetype"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type ManagerTestSuite struct {
@@ -1390,6 +1395,176 @@ func (suite *ManagerTestSuite) TestNoReportedSizeSmallMedia() {
 	suite.Equal(actualSize, attachment.File.FileSize)
 }
 
+func (suite *ManagerTestSuite) TestUploadMIMETypes() {
+	// Without transcoding, only types we
+	// can process directly are allowed.
+	config.SetMediaTranscodeEnabled(false)
+	suite.Equal(media.SupportedMIMETypes, media.UploadMIMETypes())
+	suite.NotContains(media.UploadMIMETypes(), "video/webm")
+	suite.NotContains(media.UploadMIMETypes(), "image/avif")
+
+	// With transcoding, phone video formats are too.
+	config.SetMediaTranscodeEnabled(true)
+	defer config.SetMediaTranscodeEnabled(false)
+	suite.Subset(media.UploadMIMETypes(), media.SupportedMIMETypes)
+	suite.Contains(media.UploadMIMETypes(), "video/webm")
+	suite.Contains(media.UploadMIMETypes(), "video/quicktime")
+	suite.Contains(media.UploadMIMETypes(), "video/x-matroska")
+	suite.Contains(media.UploadMIMETypes(), "image/avif")
+}
+
+func (suite *ManagerTestSuite) TestAVIFFileType() {
+	// Minimal ftyp boxes, as found at the
+	// start of AVIF images and sequences.
+	for _, brands := range []string{
+		"avif\x00\x00\x00\x00mif1miaf",
+		"avis\x00\x00\x00\x00avifmsf1",
+		"mif1\x00\x00\x00\x00avifmiaf",
+	} {
+		hdr := append([]byte{0x00, 0x00, 0x00, byte(8 + len(brands))}, "ftyp"+brands...)
+		info, err := filetype.Match(hdr)
+		suite.NoError(err)
+		suite.Equal("avif", info.Extension)
+		suite.Equal("image/avif", info.MIME.Value)
+	}
+
+	// HEIF shouldn't be mistaken for AVIF.
+	brands := "heic\x00\x00\x00\x00mif1heic"
+	hdr := append([]byte{0x00, 0x00, 0x00, byte(8 + len(brands))}, "ftyp"+brands...)
+	info, err := filetype.Match(hdr)
+	suite.NoError(err)
+	suite.Equal("heif", info.Extension)
+}
+
+func (suite *ManagerTestSuite) TestThumbnailFallbackPath() {
+	suite.Equal(
+		"01F8MH17FWEB39HZJ76B6VXSKF/attachment/small/01F8MH6NEM8D7527KZAECTCR76.jpg",
+		media.ThumbnailFallbackPath("01F8MH17FWEB39HZJ76B6VXSKF/attachment/small/01F8MH6NEM8D7527KZAECTCR76.webp"),
+	)
+
+	// Only webp thumbnails have a fallback.
+	suite.Empty(media.ThumbnailFallbackPath("01F8MH17FWEB39HZJ76B6VXSKF/attachment/small/01F8MH6NEM8D7527KZAECTCR76.jpg"))
+	suite.Empty(media.ThumbnailFallbackPath(""))
+}
+
+// gifData returns a DataFunc reading the gif test file with given name.
+func gifData(name string) media.DataFunc {
+	return func(_ context.Context) (io.ReadCloser, int64, error) {
+		b, err := os.ReadFile("./test/" + name)
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+}
+
+func (suite *ManagerTestSuite) TestAnimatedGifTranscodeProcessBlocking() {
+	ctx := context.Background()
+
+	ffmpeg := config.GetMediaTranscodeFfmpegPath()
+	if _, err := exec.LookPath(ffmpeg); err != nil {
+		suite.T().Skip("ffmpeg not available")
+	}
+
+	config.SetMediaTranscodeEnabled(true)
+	defer config.SetMediaTranscodeEnabled(false)
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(gifData("test-gif-animated.gif"), accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// animated gif should be converted to a gifv mp4
+	suite.Equal(gtsmodel.FileTypeGifv, attachment.Type)
+	suite.Equal("video/mp4", attachment.File.ContentType)
+	suite.True(strings.HasSuffix(attachment.File.Path, ".mp4"))
+	suite.True(strings.HasSuffix(attachment.URL, ".mp4"))
+	suite.Equal(64, attachment.FileMeta.Original.Width)
+	suite.Equal(48, attachment.FileMeta.Original.Height)
+
+	// with a png thumbnail, as for a gif
+	suite.Equal("image/png", attachment.Thumbnail.ContentType)
+	suite.True(strings.HasSuffix(attachment.Thumbnail.Path, ".png"))
+
+	// make sure the transcoded file is in storage
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+	suite.Equal(attachment.File.FileSize, len(processedFullBytes))
+
+	info, err := filetype.Match(processedFullBytes)
+	suite.NoError(err)
+	suite.Equal("video/mp4", info.MIME.Value)
+
+	// and the thumbnail is actually a png
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+
+	info, err = filetype.Match(processedThumbnailBytes)
+	suite.NoError(err)
+	suite.Equal("image/png", info.MIME.Value)
+}
+
+func (suite *ManagerTestSuite) TestStaticGifTranscodeProcessBlocking() {
+	suite.testGifStoredAsImage("test-gif-static.gif", nil)
+}
+
+func (suite *ManagerTestSuite) TestMalformedGifTranscodeProcessBlocking() {
+	// This gif has a bogus block after its
+	// first frame, so we can't tell whether
+	// it's animated, but can still decode it.
+	suite.testGifStoredAsImage("test-gif-malformed.gif", nil)
+}
+
+func (suite *ManagerTestSuite) TestAnimatedGifAvatarTranscodeProcessBlocking() {
+	// Avatars should never be converted.
+	suite.testGifStoredAsImage("test-gif-animated.gif", &media.AdditionalMediaInfo{
+		Avatar: util.Ptr(true),
+	})
+}
+
+// testGifStoredAsImage processes the gif test file with given name with
+// transcoding enabled, and checks that it's stored unchanged as a gif.
+func (suite *ManagerTestSuite) testGifStoredAsImage(name string, ai *media.AdditionalMediaInfo) {
+	ctx := context.Background()
+
+	config.SetMediaTranscodeEnabled(true)
+	defer config.SetMediaTranscodeEnabled(false)
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	processingMedia := suite.manager.PreProcessMedia(gifData(name), accountID, ai)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// gif should be left as an image
+	suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	suite.Equal("image/gif", attachment.File.ContentType)
+	suite.True(strings.HasSuffix(attachment.File.Path, ".gif"))
+	suite.True(strings.HasSuffix(attachment.URL, ".gif"))
+	suite.EqualValues(gtsmodel.Original{
+		Width: 64, Height: 48, Size: 3072, Aspect: 1.3333333333333333,
+	}, attachment.FileMeta.Original)
+	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
+
+	// the bytes in storage should be the original gif
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	originalBytes, err := os.ReadFile("./test/" + name)
+	suite.NoError(err)
+	suite.Equal(originalBytes, processedFullBytes)
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, &ManagerTestSuite{})
 }
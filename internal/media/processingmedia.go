@@ -30,6 +30,8 @@ import (
 	"codeberg.org/superseriousbusiness/exif-terminator"
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -42,13 +44,14 @@ import (
 // currently being processed. It exposes functions
 // for retrieving data from the process.
 type ProcessingMedia struct {
-	media   *gtsmodel.MediaAttachment // processing media attachment details
-	dataFn  DataFunc                  // load-data function, returns media stream
-	recache bool                      // recaching existing (uncached) media
-	done    bool                      // done is set when process finishes with non ctx canceled type error
-	proc    runners.Processor         // proc helps synchronize only a singular running processing instance
-	err     error                     // error stores permanent error value when done
-	mgr     *Manager                  // mgr instance (access to db / storage)
+	media     *gtsmodel.MediaAttachment // processing media attachment details
+	dataFn    DataFunc                  // load-data function, returns media stream
+	recache   bool                      // recaching existing (uncached) media
+	transcode string                    // temp file awaiting transcode to mp4, if any
+	done      bool                      // done is set when process finishes with non ctx canceled type error
+	proc      runners.Processor         // proc helps synchronize only a singular running processing instance
+	err       error                     // error stores permanent error value when done
+	mgr       *Manager                  // mgr instance (access to db / storage)
 }
 
 // AttachmentID returns the ID of the underlying
@@ -90,6 +93,146 @@ func (p *ProcessingMedia) LoadAttachment(ctx context.Context) (*gtsmodel.MediaAt
 	return media, err
 }
 
+// LoadAttachmentAsync stores the original media and
+// inserts the attachment into the database marked as
+// processing, then queues the rest of processing
+// (decoding, thumbnailing) on the media worker pool.
+// When that's done, the attachment is updated again
+// as either processed, or on failure, as errored.
+//
+// The returned attachment is a copy of the partial
+// attachment, safe to use while processing continues.
+// Media that can't be stored, eg., an unsupported
+// type, is returned as an error and not inserted.
+func (p *ProcessingMedia) LoadAttachmentAsync(ctx context.Context) (*gtsmodel.MediaAttachment, error) {
+	// Stream media into storage, this
+	// needs to happen before returning
+	// as the data may not outlive ctx.
+	if err := p.store(ctx); err != nil {
+		p.removeOriginal(ctx)
+		return nil, err
+	}
+
+	// Any transcode is left to
+	// the media worker pool.
+
+	if !*p.media.Cached && p.transcode == "" {
+		// Nothing was stored, so nothing
+		// that we're able to process.
+		return nil, gtserror.Newf("unsupported media type %s", p.media.File.ContentType)
+	}
+
+	// Insert the partial attachment, marked
+	// as processing so it can be picked up
+	// again if processing gets interrupted.
+	p.media.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := p.mgr.state.DB.PutAttachment(ctx, p.media); err != nil {
+		p.removeOriginal(ctx)
+		if p.transcode != "" {
+			removeTemp(ctx, p.transcode)
+		}
+		return nil, gtserror.Newf("error inserting media: %w", err)
+	}
+
+	// Take a copy before handing
+	// p over to the worker pool.
+	media := *p.media
+
+	go p.mgr.state.Workers.Media.Enqueue(p.processAsync)
+	return &media, nil
+}
+
+// processAsync finishes processing of media stored
+// by LoadAttachmentAsync, and updates it in the db.
+func (p *ProcessingMedia) processAsync(ctx context.Context) {
+	if err := p.finishAsync(ctx); err != nil {
+		if errorsv2.IsV2(err,
+			context.Canceled,
+			context.DeadlineExceeded,
+		) {
+			// Server is shutting down, leave as
+			// processing to be resumed on restart.
+			return
+		}
+
+		log.Errorf(ctx, "error processing media %s: %v", p.media.ID, err)
+		p.media.Processing = gtsmodel.ProcessingStatusError
+	}
+
+	// Fetch the attachment as it is now, since
+	// the owner may have updated eg., description
+	// or focus while it was processing, and only
+	// set on it the fields owned by processing.
+	media, err := p.mgr.state.DB.GetAttachmentByID(
+		gtscontext.SetBarebones(ctx),
+		p.media.ID,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error getting media %s: %v", p.media.ID, err)
+		return
+	}
+
+	media.URL = p.media.URL
+	media.Type = p.media.Type
+	media.FileMeta.Original = p.media.FileMeta.Original
+	media.FileMeta.Small = p.media.FileMeta.Small
+	media.Blurhash = p.media.Blurhash
+	media.Processing = p.media.Processing
+	media.File = p.media.File
+	media.Thumbnail = p.media.Thumbnail
+	media.Cached = p.media.Cached
+
+	if err := p.mgr.state.DB.UpdateAttachment(ctx, media,
+		"url",
+		"type",
+		"original_width",
+		"original_height",
+		"original_size",
+		"original_aspect",
+		"original_duration",
+		"original_framerate",
+		"original_bitrate",
+		"small_width",
+		"small_height",
+		"small_size",
+		"small_aspect",
+		"blurhash",
+		"processing",
+		"file_path",
+		"file_content_type",
+		"file_file_size",
+		"file_updated_at",
+		"thumbnail_path",
+		"thumbnail_content_type",
+		"thumbnail_file_size",
+		"thumbnail_updated_at",
+		"thumbnail_url",
+		"cached",
+	); err != nil {
+		log.Errorf(ctx, "error updating media %s: %v", p.media.ID, err)
+	}
+}
+
+// finishAsync transcodes and stores any spooled
+// video, then finishes processing as normal.
+func (p *ProcessingMedia) finishAsync(ctx context.Context) error {
+	if p.transcode != "" {
+		if err := p.storeTranscoded(ctx); err != nil {
+			return err
+		}
+	}
+	return p.finish(ctx)
+}
+
+// removeOriginal removes the original media file
+// from storage, if any was (partially) written.
+func (p *ProcessingMedia) removeOriginal(ctx context.Context) {
+	err := p.mgr.state.Storage.Delete(ctx, p.media.File.Path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Errorf(ctx, "error removing media from storage: %v", err)
+	}
+}
+
 // Process allows the receiving object to fit the
 // runners.WorkerFunc signature. It performs a
 // (blocking) load and logs on error.
@@ -141,6 +284,13 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			errs.Append(storeErr)
 		}
 
+		// Transcode and store any spooled video.
+		if p.transcode != "" {
+			if err := p.storeTranscoded(ctx); err != nil {
+				errs.Append(err)
+			}
+		}
+
 		// Finish processing by reloading media into
 		// memory to get dimension and generate a thumb.
 		//
@@ -252,6 +402,19 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// Recombine header bytes with remaining stream
 	r := io.MultiReader(bytes.NewReader(hdrBuf), rc)
 
+	// Local video uploads in formats we can't process
+	// directly are spooled to disk to be transcoded
+	// and stored as mp4 instead of the original.
+	//
+	// Avatars and headers are left as images, as
+	// they're always served and displayed as such.
+	if p.media.RemoteURL == "" &&
+		!util.PtrValueOr(p.media.Avatar, false) &&
+		!util.PtrValueOr(p.media.Header, false) &&
+		transcodable(info.Extension) {
+		return p.spoolForTranscode(ctx, r, info.Extension)
+	}
+
 	// Assume we'll put
 	// this file in storage.
 	store := true
@@ -263,6 +426,23 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	case "gif":
 		// No problem
 
+	case "avif":
+		// AVIF is decoded with ffmpeg, so it's
+		// only supported when transcoding is on.
+		//
+		// Note there's no exif stripping for
+		// AVIF, it's stored exactly as it came.
+		if !config.GetMediaTranscodeEnabled() {
+			log.Warnf(ctx,
+				"media extension '%s' requires transcoding to be enabled, will be "+
+					"processed as type '%s' with minimal metadata, and will not be cached locally",
+				info.Extension, gtsmodel.FileTypeUnknown,
+			)
+
+			// Don't bother storing this.
+			store = false
+		}
+
 	case "jpg", "jpeg", "png", "webp":
 		if fileSize > 0 {
 			// A file size was provided so we can clean
@@ -349,30 +529,18 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 }
 
 func (p *ProcessingMedia) finish(ctx context.Context) error {
-	// Make a jolly assumption about thumbnail type.
-	p.media.Thumbnail.ContentType = mimeImageJpeg
-
-	// Calculate attachment thumbnail file path
-	p.media.Thumbnail.Path = uris.StoragePathForAttachment(
-		p.media.AccountID,
-		string(TypeAttachment),
-		string(SizeSmall),
-		p.media.ID,
-		// Always encode attachment
-		// thumbnails as jpg.
-		"jpg",
-	)
-
-	// Calculate attachment thumbnail serve path.
-	p.media.Thumbnail.URL = uris.URIForAttachment(
-		p.media.AccountID,
-		string(TypeAttachment),
-		string(SizeSmall),
-		p.media.ID,
-		// Always encode attachment
-		// thumbnails as jpg.
-		"jpg",
-	)
+	// Encode attachment thumbnails as jpg,
+	// except for gifv where we keep a png,
+	// as would be expected of a gif, or if
+	// configured to use webp where we can.
+	switch {
+	case p.media.Type == gtsmodel.FileTypeGifv:
+		p.setThumbnail(mimePng, mimeImagePng)
+	case config.GetMediaThumbnailWebP():
+		p.setThumbnail(mimeWebp, mimeImageWebp)
+	default:
+		p.setThumbnail("jpg", mimeImageJpeg)
+	}
 
 	// If original file hasn't been stored, there's
 	// likely something wrong with the data, or we
@@ -411,6 +579,17 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeImage
 
+	// .avif image (decoded with ffmpeg)
+	case mimeImageAvif:
+		fullImg, err = decodeImageFFmpeg(ctx, rc)
+		if err != nil {
+			return gtserror.Newf("error decoding image: %w", err)
+		}
+
+		// Mark as no longer unknown type now
+		// we know for sure we can decode it.
+		p.media.Type = gtsmodel.FileTypeImage
+
 	// .png image (requires ancillary chunk stripping)
 	case mimeImagePng:
 		fullImg, err = decodeImage(
@@ -441,8 +620,12 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		p.media.FileMeta.Original.Bitrate = &video.bitrate
 
 		// Mark as no longer unknown type now
-		// we know for sure we can decode it.
-		p.media.Type = gtsmodel.FileTypeVideo
+		// we know for sure we can decode it,
+		// unless transcoded from a gif, which
+		// will already be marked as gifv.
+		if p.media.Type != gtsmodel.FileTypeGifv {
+			p.media.Type = gtsmodel.FileTypeVideo
+		}
 	}
 
 	// fullImg should be in-memory by
@@ -487,15 +670,8 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		}
 	}
 
-	// Create a thumbnail JPEG encoder stream.
-	enc := thumbImg.ToJPEG(&jpeg.Options{
-		// Good enough for
-		// a thumbnail.
-		Quality: 70,
-	})
-
-	// Stream-encode the JPEG thumbnail image into storage.
-	sz, err := p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, enc)
+	// Encode the thumbnail image into storage.
+	sz, err := p.storeThumbnail(ctx, thumbImg)
 	if err != nil {
 		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
 	}
@@ -517,3 +693,87 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 
 	return nil
 }
+
+// setThumbnail sets the thumbnail storage path, serve
+// URL and content type of the attachment, for a thumbnail
+// encoded with the given file extension and content type.
+func (p *ProcessingMedia) setThumbnail(ext string, contentType string) {
+	p.media.Thumbnail.ContentType = contentType
+
+	// Calculate attachment thumbnail file path
+	p.media.Thumbnail.Path = uris.StoragePathForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeSmall),
+		p.media.ID,
+		ext,
+	)
+
+	// Calculate attachment thumbnail serve path.
+	p.media.Thumbnail.URL = uris.URIForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeSmall),
+		p.media.ID,
+		ext,
+	)
+}
+
+// storeThumbnail encodes thumbImg into storage at the
+// attachment thumbnail path, returning the size written.
+//
+// WebP thumbnails get a JPEG copy stored alongside them,
+// see ThumbnailFallbackPath(). If WebP encoding fails,
+// eg., as ffmpeg lacks libwebp, a JPEG is stored instead.
+func (p *ProcessingMedia) storeThumbnail(ctx context.Context, thumbImg *gtsImage) (int64, error) {
+	jpegOpts := &jpeg.Options{
+		// Good enough for
+		// a thumbnail.
+		Quality: 70,
+	}
+
+	switch p.media.Thumbnail.ContentType {
+	case mimeImagePng:
+		return p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, thumbImg.ToPNG())
+
+	case mimeImageWebp:
+		webp, err := thumbImg.ToWebP(ctx, jpegOpts.Quality)
+		if err != nil {
+			log.Warnf(ctx, "falling back to jpeg thumbnail: %v", err)
+
+			// Switch over to jpeg, clearing
+			// out anything left at its path.
+			p.setThumbnail("jpg", mimeImageJpeg)
+			if err := p.removeStored(ctx, p.media.Thumbnail.Path); err != nil {
+				return 0, err
+			}
+			break
+		}
+
+		// Store the jpeg copy for
+		// requesters without webp.
+		fallback := ThumbnailFallbackPath(p.media.Thumbnail.Path)
+		if err := p.removeStored(ctx, fallback); err != nil {
+			return 0, err
+		}
+
+		if _, err := p.mgr.state.Storage.PutStream(ctx, fallback, thumbImg.ToJPEG(jpegOpts)); err != nil {
+			return 0, gtserror.Newf("error writing thumbnail fallback to storage: %w", err)
+		}
+
+		sz, err := p.mgr.state.Storage.Put(ctx, p.media.Thumbnail.Path, webp)
+		return int64(sz), err
+	}
+
+	return p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, thumbImg.ToJPEG(jpegOpts))
+}
+
+// removeStored removes any file at path in storage, so
+// that it can be written afresh. Missing files are fine.
+func (p *ProcessingMedia) removeStored(ctx context.Context, path string) error {
+	err := p.mgr.state.Storage.Delete(ctx, path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return gtserror.Newf("error removing %s from storage: %w", path, err)
+	}
+	return nil
+}
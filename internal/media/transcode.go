@@ -0,0 +1,376 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// transcodable returns whether media with given
+// file extension should be transcoded to mp4.
+func transcodable(ext string) bool {
+	switch ext {
+	case "webm", "mov", "mkv", "gif":
+		return config.GetMediaTranscodeEnabled()
+	default:
+		return false
+	}
+}
+
+// spoolForTranscode writes the media in r to a temporary
+// file, to be transcoded and stored by storeTranscoded.
+// Attachment details are filled in for the mp4 to come.
+//
+// Animated gifs are marked as gifv, to be converted to a
+// silent looping mp4. Gifs that turn out not to be
+// animated are stored as they are, as an image.
+func (p *ProcessingMedia) spoolForTranscode(ctx context.Context, r io.Reader, ext string) error {
+	tmp, err := os.CreateTemp("", "gotosocial-upload-*")
+	if err != nil {
+		return gtserror.Newf("error creating temp file: %w", err)
+	}
+
+	_, err = io.Copy(tmp, r)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		removeTemp(ctx, tmp.Name())
+		return gtserror.Newf("error writing temp file: %w", err)
+	}
+
+	if ext == mimeGif {
+		animated, err := gifAnimated(tmp.Name())
+		if err != nil {
+			log.Warnf(ctx, "error checking whether gif is animated: %v", err)
+		}
+
+		if !animated {
+			// Nothing to convert.
+			defer removeTemp(ctx, tmp.Name())
+			return p.storeStaticGif(ctx, tmp.Name())
+		}
+
+		// Converted gifs behave as gifv.
+		p.media.Type = gtsmodel.FileTypeGifv
+	}
+
+	p.transcode = tmp.Name()
+
+	p.media.URL = uris.URIForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		p.media.ID,
+		mimeMp4,
+	)
+
+	p.media.File.ContentType = mimeVideoMp4
+
+	p.media.File.Path = uris.StoragePathForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		p.media.ID,
+		mimeMp4,
+	)
+
+	return nil
+}
+
+// storeTranscoded transcodes the file spooled by
+// spoolForTranscode to mp4, and stores the result
+// as the original file of the attachment. The
+// spooled and transcoded temp files are removed.
+func (p *ProcessingMedia) storeTranscoded(ctx context.Context) error {
+	src := p.transcode
+	p.transcode = ""
+	defer removeTemp(ctx, src)
+
+	gifv := (p.media.Type == gtsmodel.FileTypeGifv)
+	dst, err := transcodeVideo(ctx, src, gifv)
+	if err != nil {
+		return err
+	}
+	defer removeTemp(ctx, dst)
+
+	f, err := os.Open(dst)
+	if err != nil {
+		return gtserror.Newf("error opening transcoded file: %w", err)
+	}
+	defer f.Close()
+
+	wroteSize, err := p.mgr.state.Storage.PutStream(ctx, p.media.File.Path, f)
+	if err != nil {
+		return gtserror.Newf("error writing transcoded media to storage: %w", err)
+	}
+
+	p.media.File.FileSize = int(wroteSize)
+	p.media.Cached = util.Ptr(true)
+
+	return nil
+}
+
+// storeStaticGif stores the gif at path
+// as the original file of the attachment.
+func (p *ProcessingMedia) storeStaticGif(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return gtserror.Newf("error opening temp file: %w", err)
+	}
+	defer f.Close()
+
+	p.media.URL = uris.URIForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		p.media.ID,
+		mimeGif,
+	)
+
+	p.media.File.ContentType = mimeImageGif
+
+	p.media.File.Path = uris.StoragePathForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		p.media.ID,
+		mimeGif,
+	)
+
+	wroteSize, err := p.mgr.state.Storage.PutStream(ctx, p.media.File.Path, f)
+	if err != nil {
+		return gtserror.Newf("error writing media to storage: %w", err)
+	}
+
+	p.media.File.FileSize = int(wroteSize)
+	p.media.Cached = util.Ptr(true)
+
+	return nil
+}
+
+// transcodeVideo transcodes the video at src to
+// an H.264/AAC mp4 within the configured video
+// limits, returning the path of a new temp file.
+//
+// If gifv is set, src is an animated gif, and
+// is converted to a silent mp4 to be looped.
+func transcodeVideo(ctx context.Context, src string, gifv bool) (string, error) {
+	out, err := os.CreateTemp("", "gotosocial-transcode-*.mp4")
+	if err != nil {
+		return "", gtserror.Newf("error creating temp file: %w", err)
+	}
+	dst := out.Name()
+	_ = out.Close()
+
+	timeout := config.GetMediaTranscodeTimeout()
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Fit within the matrix limit by bounding
+	// both sides to its square root, keeping
+	// aspect ratio, with even dimensions as
+	// required by H.264 4:2:0 encoding.
+	side := strconv.Itoa(int(math.Sqrt(VideoMatrixLimit)))
+	scale := "scale=w='min(iw," + side + ")':h='min(ih," + side + ")'" +
+		":force_original_aspect_ratio=decrease:force_divisible_by=2"
+
+	args := []string{
+		"-hide_banner", "-loglevel", "error", "-nostdin", "-y",
+		"-i", src,
+		"-map", "0:v:0",
+		"-vf", scale,
+		"-fpsmax", strconv.Itoa(VideoFrameRateLimit),
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-pix_fmt", "yuv420p",
+	}
+
+	if gifv {
+		// Gifs have no audio.
+		args = append(args, "-an")
+	} else {
+		args = append(args,
+			"-map", "0:a:0?",
+			"-c:a", "aac", "-b:a", "128k",
+		)
+	}
+
+	args = append(args,
+		"-map_metadata", "-1",
+		"-movflags", "+faststart",
+		"-f", "mp4", dst,
+	)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(tctx, config.GetMediaTranscodeFfmpegPath(), args...)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		removeTemp(ctx, dst)
+
+		if ctx.Err() != nil {
+			// Caller gave up.
+			return "", ctx.Err()
+		}
+
+		if tctx.Err() != nil {
+			// Our own timeout hit.
+			return "", gtserror.Newf("transcoding took longer than %s", timeout)
+		}
+
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > 512 {
+			msg = "..." + msg[len(msg)-512:]
+		}
+		return "", gtserror.Newf("error transcoding video: %w: %s", err, msg)
+	}
+
+	// Check result is still within max video size.
+	info, err := os.Stat(dst)
+	if err != nil {
+		removeTemp(ctx, dst)
+		return "", gtserror.Newf("error checking transcoded file: %w", err)
+	}
+
+	if maxSize := config.GetMediaVideoMaxSize(); info.Size() > int64(maxSize) {
+		removeTemp(ctx, dst)
+		return "", gtserror.Newf(
+			"transcoded video is %s, over the limit of %s",
+			bytesize.Size(info.Size()), maxSize,
+		)
+	}
+
+	return dst, nil
+}
+
+// removeTemp removes the temp file at path, logging on error.
+func removeTemp(ctx context.Context, path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Errorf(ctx, "error removing temp file %s: %v", path, err)
+	}
+}
+
+// gifAnimated reports whether the gif at path contains
+// more than one frame, walking the gif block structure
+// without decoding any of the image data.
+func gifAnimated(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+
+	// Header and logical screen descriptor.
+	hdr := make([]byte, 13)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return false, err
+	}
+
+	if string(hdr[:3]) != "GIF" {
+		return false, errors.New("not a gif")
+	}
+
+	// Skip global color table if present.
+	if hdr[10]&0x80 != 0 {
+		n := 3 * (1 << ((hdr[10] & 0x07) + 1))
+		if _, err := r.Discard(n); err != nil {
+			return false, err
+		}
+	}
+
+	var frames int
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return false, err
+		}
+
+		switch b {
+		case 0x21: // extension
+			// Skip label, then sub-blocks.
+			if _, err := r.Discard(1); err != nil {
+				return false, err
+			}
+
+		case 0x2C: // image descriptor
+			if frames++; frames > 1 {
+				return true, nil
+			}
+
+			desc := make([]byte, 9)
+			if _, err := io.ReadFull(r, desc); err != nil {
+				return false, err
+			}
+
+			// Skip local color table if present.
+			if desc[8]&0x80 != 0 {
+				n := 3 * (1 << ((desc[8] & 0x07) + 1))
+				if _, err := r.Discard(n); err != nil {
+					return false, err
+				}
+			}
+
+			// Skip LZW minimum code size.
+			if _, err := r.Discard(1); err != nil {
+				return false, err
+			}
+
+		case 0x3B: // trailer
+			return false, nil
+
+		default:
+			return false, fmt.Errorf("unexpected gif block %#x", b)
+		}
+
+		// Skip data sub-blocks.
+		for {
+			size, err := r.ReadByte()
+			if err != nil {
+				return false, err
+			}
+
+			if size == 0 {
+				break
+			}
+
+			if _, err := r.Discard(int(size)); err != nil {
+				return false, err
+			}
+		}
+	}
+}
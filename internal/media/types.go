@@ -40,8 +40,27 @@ const (
 	mimeWebp      = "webp"
 	mimeImageWebp = mimeImage + "/" + mimeWebp
 
+	mimeAvif      = "avif"
+	mimeImageAvif = mimeImage + "/" + mimeAvif
+
 	mimeMp4      = "mp4"
 	mimeVideoMp4 = mimeVideo + "/" + mimeMp4
+
+	mimeWebm      = "webm"
+	mimeVideoWebm = mimeVideo + "/" + mimeWebm
+
+	mimeQuicktime      = "quicktime"
+	mimeVideoQuicktime = mimeVideo + "/" + mimeQuicktime
+
+	mimeMatroska      = "x-matroska"
+	mimeVideoMatroska = mimeVideo + "/" + mimeMatroska
+)
+
+// Limits on videos, as advertised in the instance
+// configuration, and enforced when transcoding.
+const (
+	VideoMatrixLimit    = 16777216 // width * height
+	VideoFrameRateLimit = 60
 )
 
 type Size string
@@ -59,6 +78,7 @@ const (
 	TypeHeader     Type = "header"     // TypeHeader is the key for profile header requests
 	TypeAvatar     Type = "avatar"     // TypeAvatar is the key for profile avatar requests
 	TypeEmoji      Type = "emoji"      // TypeEmoji is the key for emoji type requests
+	TypeArchive    Type = "archive"    // TypeArchive is the key for account data archives
 )
 
 // AdditionalMediaInfo represents additional information that should be added to an attachment
@@ -17,6 +17,8 @@
 
 package media
 
+import "strings"
+
 // newHdrBuf returns a buffer of suitable size to
 // read bytes from a file header or magic number.
 //
@@ -40,3 +42,15 @@ func newHdrBuf(fileSize int) []byte {
 
 	return make([]byte, bufSize)
 }
+
+// ThumbnailFallbackPath returns the storage path of the
+// JPEG copy kept alongside a WebP thumbnail at path, to be
+// served to requesters that don't accept WebP. Returns an
+// empty string if path isn't that of a WebP thumbnail.
+func ThumbnailFallbackPath(path string) string {
+	base, ok := strings.CutSuffix(path, "."+mimeWebp)
+	if !ok {
+		return ""
+	}
+	return base + ".jpg"
+}
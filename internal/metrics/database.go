@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !nometrics
+
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// databaseMetrics wraps the instruments
+// used to record database contention.
+type databaseMetrics struct {
+	busyRetries  metric.Int64Counter
+	busyTimeouts metric.Int64Counter
+}
+
+// dbMetrics is set by Initialize when metrics
+// are enabled, until then recording is a no-op.
+var dbMetrics atomic.Pointer[databaseMetrics]
+
+// initDatabase creates the database
+// contention instruments on the given meter.
+func initDatabase(meter metric.Meter) error {
+	var (
+		m   databaseMetrics
+		err error
+	)
+
+	m.busyRetries, err = meter.Int64Counter(
+		"gotosocial.db.busy_retries",
+		metric.WithDescription("Number of times a database query was retried because the database was busy"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.busyTimeouts, err = meter.Int64Counter(
+		"gotosocial.db.busy_timeouts",
+		metric.WithDescription("Number of database queries abandoned after retrying on a busy database for too long"),
+	)
+	if err != nil {
+		return err
+	}
+
+	dbMetrics.Store(&m)
+	return nil
+}
+
+// DatabaseBusy records a database query that was
+// retried the given number of times on a busy
+// database, and whether it was eventually given up on.
+func DatabaseBusy(retries int, timedOut bool) {
+	m := dbMetrics.Load()
+	if m == nil {
+		return
+	}
+
+	ctx := context.Background()
+
+	m.busyRetries.Add(ctx, int64(retries))
+	if timedOut {
+		m.busyTimeouts.Add(ctx, 1)
+	}
+}
@@ -0,0 +1,269 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !nometrics
+
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// federationMetrics wraps the instruments
+// used to record federation health metrics.
+type federationMetrics struct {
+	deliveryAttempts metric.Int64Counter
+	deliveryFailures metric.Int64Counter
+	deliveryLatency  metric.Float64Histogram
+	inboundActivity  metric.Int64Counter
+	inboxDuplicates  metric.Int64Counter
+	derefCache       metric.Int64Counter
+	breakerChanges   metric.Int64Counter
+	breakersOpen     metric.Int64UpDownCounter
+
+	domains *labelSet
+	types   *labelSet
+}
+
+// fedMetrics is set by Initialize when metrics
+// are enabled, until then recording is a no-op.
+var fedMetrics atomic.Pointer[federationMetrics]
+
+// initFederation creates the federation health
+// instruments on the given meter, and registers
+// the worker queue depth gauges for given state.
+func initFederation(meter metric.Meter, state *state.State) error {
+	var (
+		m   = federationMetrics{domains: newLabelSet(maxDomainLabels), types: newLabelSet(maxTypeLabels)}
+		err error
+	)
+
+	m.deliveryAttempts, err = meter.Int64Counter(
+		"gotosocial.federation.delivery_attempts",
+		metric.WithDescription("Number of attempted outgoing activity deliveries, by remote domain"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.deliveryFailures, err = meter.Int64Counter(
+		"gotosocial.federation.delivery_failures",
+		metric.WithDescription("Number of failed outgoing activity deliveries, by remote domain"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.deliveryLatency, err = meter.Float64Histogram(
+		"gotosocial.federation.delivery_latency",
+		metric.WithDescription("Latency of outgoing activity deliveries, by remote domain"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.inboundActivity, err = meter.Int64Counter(
+		"gotosocial.federation.inbound_activities",
+		metric.WithDescription("Number of activities received in inboxes, by activity type"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.inboxDuplicates, err = meter.Int64Counter(
+		"gotosocial.federation.inbox_duplicates",
+		metric.WithDescription("Number of redundant activities received in inboxes and dropped as duplicates, by activity type"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.derefCache, err = meter.Int64Counter(
+		"gotosocial.federation.dereference_cache",
+		metric.WithDescription("Number of dereference lookups served from (hit), refreshed in (stale), or missing from (miss) local cache"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.breakerChanges, err = meter.Int64Counter(
+		"gotosocial.httpclient.circuit_breaker_transitions",
+		metric.WithDescription("Number of outgoing circuit breaker state transitions, by remote domain and new state"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.breakersOpen, err = meter.Int64UpDownCounter(
+		"gotosocial.httpclient.circuit_breakers_open",
+		metric.WithDescription("Number of remote hosts with an open (or half-open) outgoing circuit breaker"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Worker queue depths and throughput, useful
+	// for spotting a federation / media backlog.
+	for name, pool := range map[string]*workers.WorkerPool{
+		"client_api": &state.Workers.ClientAPI,
+		"federator":  &state.Workers.Federator,
+		"media":      &state.Workers.Media,
+	} {
+		pool := pool
+		if _, err := meter.Int64ObservableGauge(
+			"gotosocial.workers."+name+".queue_depth",
+			metric.WithDescription("Number of tasks currently queued in the "+name+" worker pool"),
+			metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+				o.Observe(int64(pool.Queue()))
+				return nil
+			}),
+		); err != nil {
+			return err
+		}
+
+		if _, err := meter.Int64ObservableGauge(
+			"gotosocial.workers."+name+".in_flight",
+			metric.WithDescription("Number of tasks currently being processed in the "+name+" worker pool"),
+			metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+				o.Observe(int64(pool.InFlight()))
+				return nil
+			}),
+		); err != nil {
+			return err
+		}
+
+		if _, err := meter.Int64ObservableCounter(
+			"gotosocial.workers."+name+".processed",
+			metric.WithDescription("Number of tasks processed by the "+name+" worker pool"),
+			metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+				o.Observe(int64(pool.Processed()))
+				return nil
+			}),
+		); err != nil {
+			return err
+		}
+	}
+
+	fedMetrics.Store(&m)
+	return nil
+}
+
+// DeliveryAttempt records a single attempted delivery of
+// an activity to the given remote domain, how long it took,
+// and whether it failed (ie., err is non-nil).
+func DeliveryAttempt(domain string, took time.Duration, err error) {
+	m := fedMetrics.Load()
+	if m == nil {
+		return
+	}
+
+	ctx := context.Background()
+	attrs := metric.WithAttributes(attribute.String(
+		"domain", m.domains.get(sanitizeDomain(domain)),
+	))
+
+	m.deliveryAttempts.Add(ctx, 1, attrs)
+	m.deliveryLatency.Record(ctx, took.Seconds(), attrs)
+	if err != nil {
+		m.deliveryFailures.Add(ctx, 1, attrs)
+	}
+}
+
+// InboundActivity records receipt of an
+// activity of the given type in an inbox.
+func InboundActivity(typ string) {
+	m := fedMetrics.Load()
+	if m == nil {
+		return
+	}
+
+	m.inboundActivity.Add(
+		context.Background(), 1,
+		metric.WithAttributes(attribute.String(
+			"type", m.types.get(sanitizeType(typ)),
+		)),
+	)
+}
+
+// InboxDuplicate records receipt of a redundant activity of the
+// given type in an inbox, which was dropped as a duplicate.
+func InboxDuplicate(typ string) {
+	m := fedMetrics.Load()
+	if m == nil {
+		return
+	}
+
+	m.inboxDuplicates.Add(
+		context.Background(), 1,
+		metric.WithAttributes(attribute.String(
+			"type", m.types.get(sanitizeType(typ)),
+		)),
+	)
+}
+
+// DereferenceCache records the result of checking the local
+// cache (ie., database) for the given kind of dereferenced
+// model, one of CacheHit, CacheMiss or CacheStale.
+func DereferenceCache(kind string, result string) {
+	m := fedMetrics.Load()
+	if m == nil {
+		return
+	}
+
+	m.derefCache.Add(
+		context.Background(), 1,
+		metric.WithAttributes(
+			attribute.String("kind", kind),
+			attribute.String("result", result),
+		),
+	)
+}
+
+// CircuitBreaker records a transition of the outgoing circuit breaker
+// for the given remote host, from and to one of "closed", "open" or
+// "half_open". A host that keeps transitioning is flapping.
+func CircuitBreaker(host string, from string, to string) {
+	m := fedMetrics.Load()
+	if m == nil {
+		return
+	}
+
+	ctx := context.Background()
+
+	m.breakerChanges.Add(ctx, 1,
+		metric.WithAttributes(
+			attribute.String("domain", m.domains.get(sanitizeDomain(host))),
+			attribute.String("state", to),
+		),
+	)
+
+	switch {
+	case from == "closed":
+		m.breakersOpen.Add(ctx, 1)
+	case to == "closed":
+		m.breakersOpen.Add(ctx, -1)
+	}
+}
@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	// maxDomainLabels is the maximum number of distinct
+	// remote domains we will track as metric labels,
+	// any domains seen after this are bucketed as "other".
+	maxDomainLabels = 500
+
+	// maxTypeLabels is the maximum number of distinct
+	// activity types we will track as metric labels.
+	maxTypeLabels = 50
+
+	// otherLabel is used in place of a label value
+	// once the cardinality cap of a labelSet is reached,
+	// or when a label value is empty after sanitizing.
+	otherLabel = "other"
+)
+
+// Results of a dereference cache
+// lookup, see DereferenceCache().
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheStale = "stale"
+)
+
+// labelSet keeps track of the distinct values seen for a
+// metric label, in order to cap the cardinality of the
+// resulting timeseries. Remote domains and activity types
+// are entirely under the control of remote instances, so
+// without a cap they could be used to blow up memory usage
+// of both this instance and any scraping Prometheus server.
+type labelSet struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+	max  int
+}
+
+// newLabelSet returns a new labelSet
+// allowing up to max distinct values.
+func newLabelSet(max int) *labelSet {
+	return &labelSet{
+		seen: make(map[string]struct{}, max),
+		max:  max,
+	}
+}
+
+// get returns the given (sanitized) label value if it has
+// been seen before, or if there's still room for it in the
+// set. Otherwise it returns otherLabel.
+func (s *labelSet) get(value string) string {
+	if value == "" {
+		return otherLabel
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.seen[value]; ok {
+		return value
+	}
+
+	if len(s.seen) >= s.max {
+		return otherLabel
+	}
+
+	s.seen[value] = struct{}{}
+	return value
+}
+
+// sanitizeDomain returns a version of the given domain (host)
+// that's safe to use as a metric label value: lowercased, with
+// any port removed, and any characters that can't appear in a
+// (punycoded) hostname replaced with an underscore.
+func sanitizeDomain(domain string) string {
+	domain = strings.ToLower(domain)
+
+	// Drop the port, if any,
+	// taking care of IPv6.
+	if i := strings.LastIndexByte(domain, ':'); i != -1 &&
+		!strings.HasSuffix(domain, "]") {
+		domain = domain[:i]
+	}
+
+	// Longest valid hostname.
+	if len(domain) > 253 {
+		domain = domain[:253]
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z',
+			r >= '0' && r <= '9',
+			r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, domain)
+}
+
+// sanitizeType returns a version of the given ActivityStreams
+// type name that's safe to use as a metric label value, ie.,
+// containing only ASCII letters, and at most 32 of them.
+func sanitizeType(typ string) string {
+	if len(typ) > 32 {
+		typ = typ[:32]
+	}
+
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') ||
+			(r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return -1
+	}, typ)
+}
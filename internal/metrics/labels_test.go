@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestSanitizeDomain(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{in: "example.org", out: "example.org"},
+		{in: "Example.ORG", out: "example.org"},
+		{in: "example.org:8080", out: "example.org"},
+		{in: "xn--fiqs8s.example", out: "xn--fiqs8s.example"},
+		{in: "[::1]", out: "___1_"},
+		{in: "evil\"} 1\nfoo{", out: "evil___1_foo_"},
+	} {
+		if got := sanitizeDomain(test.in); got != test.out {
+			t.Errorf("sanitizeDomain(%q): expected %q, got %q", test.in, test.out, got)
+		}
+	}
+}
+
+func TestSanitizeType(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{in: "Create", out: "Create"},
+		{in: "Emoji\nReact", out: "EmojiReact"},
+		{in: "", out: ""},
+	} {
+		if got := sanitizeType(test.in); got != test.out {
+			t.Errorf("sanitizeType(%q): expected %q, got %q", test.in, test.out, got)
+		}
+	}
+}
+
+func TestLabelSetCap(t *testing.T) {
+	s := newLabelSet(3)
+
+	for i := 0; i < 3; i++ {
+		v := strconv.Itoa(i)
+		if got := s.get(v); got != v {
+			t.Fatalf("expected %q, got %q", v, got)
+		}
+	}
+
+	// Set is full, new values are bucketed.
+	if got := s.get("3"); got != otherLabel {
+		t.Fatalf("expected %q, got %q", otherLabel, got)
+	}
+
+	// Values seen already still pass through.
+	if got := s.get("1"); got != "1" {
+		t.Fatalf("expected %q, got %q", "1", got)
+	}
+
+	if got := s.get(""); got != otherLabel {
+		t.Fatalf("expected %q, got %q", otherLabel, got)
+	}
+}
@@ -25,7 +25,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
@@ -41,7 +41,7 @@ const (
 	serviceName = "GoToSocial"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(state *state.State) error {
 
 	if !config.GetMetricsEnabled() {
 		return nil
@@ -82,7 +82,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_users",
 		metric.WithDescription("Total number of users on this instance"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			userCount, err := db.CountInstanceUsers(c, thisInstance)
+			userCount, err := state.DB.CountInstanceUsers(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -99,7 +99,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_statuses",
 		metric.WithDescription("Total number of statuses on this instance"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			statusCount, err := db.CountInstanceStatuses(c, thisInstance)
+			statusCount, err := state.DB.CountInstanceStatuses(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -116,7 +116,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_federating_instances",
 		metric.WithDescription("Total number of other instances this instance is federating with"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			federatingCount, err := db.CountInstanceDomains(c, thisInstance)
+			federatingCount, err := state.DB.CountInstanceDomains(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -129,7 +129,19 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
-	return nil
+	if err := initDatabase(meter); err != nil {
+		return err
+	}
+
+	if err := initTimelines(meter, state); err != nil {
+		return err
+	}
+
+	if err := initStorage(meter, state); err != nil {
+		return err
+	}
+
+	return initFederation(meter, state)
 }
 
 func InstrumentGin() gin.HandlerFunc {
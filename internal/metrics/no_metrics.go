@@ -21,14 +21,15 @@ package metrics
 
 import (
 	"errors"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(state *state.State) error {
 	if config.GetMetricsEnabled() {
 		return errors.New("metrics was disabled at build time")
 	}
@@ -42,3 +43,15 @@ func InstrumentGin() gin.HandlerFunc {
 func InstrumentBun() bun.QueryHook {
 	return nil
 }
+
+func DeliveryAttempt(domain string, took time.Duration, err error) {}
+
+func InboundActivity(typ string) {}
+
+func InboxDuplicate(typ string) {}
+
+func DereferenceCache(kind string, result string) {}
+
+func CircuitBreaker(host string, from string, to string) {}
+
+func DatabaseBusy(retries int, timedOut bool) {}
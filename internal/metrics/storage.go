@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !nometrics
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// initStorage registers instruments reporting
+// the last storage health probe of given state.
+func initStorage(meter metric.Meter, state *state.State) error {
+	// last returns the most recent health
+	// probe result, if storage was probed.
+	last := func() (*storage.Health, bool) {
+		if state.Storage == nil {
+			return nil, false
+		}
+		h := state.Storage.LastHealth()
+		return h, h != nil
+	}
+
+	if _, err := meter.Int64ObservableGauge(
+		"gotosocial.storage.healthy",
+		metric.WithDescription("Whether the last storage health probe succeeded (1) or failed (0)"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			if h, ok := last(); ok {
+				var healthy int64
+				if h.Healthy() {
+					healthy = 1
+				}
+				o.Observe(healthy)
+			}
+			return nil
+		}),
+	); err != nil {
+		return err
+	}
+
+	if _, err := meter.Float64ObservableGauge(
+		"gotosocial.storage.probe_latency",
+		metric.WithDescription("Time taken by the last storage health probe"),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			if h, ok := last(); ok {
+				o.Observe(h.Latency.Seconds())
+			}
+			return nil
+		}),
+	); err != nil {
+		return err
+	}
+
+	if _, err := meter.Int64ObservableGauge(
+		"gotosocial.storage.free_bytes",
+		metric.WithDescription("Free space on the local storage filesystem, as of the last storage health probe"),
+		metric.WithUnit("By"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			if h, ok := last(); ok && h.FreeBytes >= 0 {
+				o.Observe(h.FreeBytes)
+			}
+			return nil
+		}),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
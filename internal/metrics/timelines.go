@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !nometrics
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// initTimelines registers the prepared item cache
+// instruments for the timelines of given state.
+func initTimelines(meter metric.Meter, state *state.State) error {
+	for name, get := range map[string]func() timeline.Manager{
+		"home": func() timeline.Manager { return state.Timelines.Home },
+		"list": func() timeline.Manager { return state.Timelines.List },
+	} {
+		get := get
+
+		// stats returns the current prepared item
+		// stats, if the timeline manager is set up.
+		stats := func() (timeline.PreparedStats, bool) {
+			m := get()
+			if m == nil {
+				return timeline.PreparedStats{}, false
+			}
+			return m.PreparedStats(), true
+		}
+
+		for _, counter := range []struct {
+			name  string
+			desc  string
+			value func(timeline.PreparedStats) int64
+		}{
+			{
+				name:  "prepared_hits",
+				desc:  "Number of statuses served from already prepared entries in " + name + " timelines",
+				value: func(s timeline.PreparedStats) int64 { return int64(s.Hits) },
+			},
+			{
+				name:  "prepared_misses",
+				desc:  "Number of statuses that had to be prepared to be served in " + name + " timelines",
+				value: func(s timeline.PreparedStats) int64 { return int64(s.Misses) },
+			},
+			{
+				name:  "prepared_evictions",
+				desc:  "Number of prepared statuses evicted from " + name + " timelines to stay within limits",
+				value: func(s timeline.PreparedStats) int64 { return int64(s.Evictions) },
+			},
+		} {
+			counter := counter
+			if _, err := meter.Int64ObservableCounter(
+				"gotosocial.timelines."+name+"."+counter.name,
+				metric.WithDescription(counter.desc),
+				metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+					if s, ok := stats(); ok {
+						o.Observe(counter.value(s))
+					}
+					return nil
+				}),
+			); err != nil {
+				return err
+			}
+		}
+
+		if _, err := meter.Int64ObservableGauge(
+			"gotosocial.timelines."+name+".prepared_items",
+			metric.WithDescription("Number of prepared statuses held in "+name+" timelines, as of the last sweep"),
+			metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+				if s, ok := stats(); ok {
+					o.Observe(int64(s.Items))
+				}
+				return nil
+			}),
+		); err != nil {
+			return err
+		}
+
+		if _, err := meter.Int64ObservableGauge(
+			"gotosocial.timelines."+name+".prepared_bytes",
+			metric.WithDescription("Estimated size of prepared statuses held in "+name+" timelines, as of the last sweep"),
+			metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+				if s, ok := stats(); ok {
+					o.Observe(int64(s.Bytes))
+				}
+				return nil
+			}),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -22,8 +22,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
@@ -117,6 +119,12 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 			}
 
 			c.Set(oauth.SessionAuthorizedAccount, user.Account)
+
+			// Flag the request context if this account
+			// prefers static avatars, headers and emojis.
+			if util.PtrValueOr(user.Account.DisableAnimation, false) {
+				c.Request = c.Request.WithContext(gtscontext.SetAnimationDisabled(ctx))
+			}
 		}
 
 		// check for application token
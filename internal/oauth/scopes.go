@@ -0,0 +1,261 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// Scope represents an OAuth scope that can be
+// requested by an application, and granted to
+// a token. Scopes are hierarchical: a broad scope
+// like "read" grants all scopes beneath it, like
+// "read:statuses" and "read:accounts".
+//
+// See: https://docs.joinmastodon.org/api/oauth-scopes/
+type Scope string
+
+const (
+	ScopeRead              Scope = "read"
+	ScopeReadAccounts      Scope = "read:accounts"
+	ScopeReadBlocks        Scope = "read:blocks"
+	ScopeReadBookmarks     Scope = "read:bookmarks"
+	ScopeReadCustomEmojis  Scope = "read:custom_emojis"
+	ScopeReadFavourites    Scope = "read:favourites"
+	ScopeReadFilters       Scope = "read:filters"
+	ScopeReadFollows       Scope = "read:follows"
+	ScopeReadLists         Scope = "read:lists"
+	ScopeReadMedia         Scope = "read:media"
+	ScopeReadMutes         Scope = "read:mutes"
+	ScopeReadNotifications Scope = "read:notifications"
+	ScopeReadReports       Scope = "read:reports"
+	ScopeReadSearch        Scope = "read:search"
+	ScopeReadStatuses      Scope = "read:statuses"
+	ScopeReadStreaming     Scope = "read:streaming"
+	ScopeReadUser          Scope = "read:user"
+
+	ScopeWrite              Scope = "write"
+	ScopeWriteAccounts      Scope = "write:accounts"
+	ScopeWriteBlocks        Scope = "write:blocks"
+	ScopeWriteBookmarks     Scope = "write:bookmarks"
+	ScopeWriteConversations Scope = "write:conversations"
+	ScopeWriteFavourites    Scope = "write:favourites"
+	ScopeWriteFilters       Scope = "write:filters"
+	ScopeWriteFollows       Scope = "write:follows"
+	ScopeWriteLists         Scope = "write:lists"
+	ScopeWriteMedia         Scope = "write:media"
+	ScopeWriteMutes         Scope = "write:mutes"
+	ScopeWriteNotifications Scope = "write:notifications"
+	ScopeWriteReports       Scope = "write:reports"
+	ScopeWriteStatuses      Scope = "write:statuses"
+	ScopeWriteUser          Scope = "write:user"
+
+	// ScopeFollow is a legacy scope
+	// granting read + write access
+	// to follows, blocks, and mutes.
+	ScopeFollow Scope = "follow"
+	ScopePush   Scope = "push"
+
+	// ScopeProfile is requested by clients
+	// that only need to verify credentials
+	// of the authorized account. As that
+	// requires read:accounts here, it's
+	// treated as granting read:accounts.
+	ScopeProfile Scope = "profile"
+
+	// ScopeAdmin is a GoToSocial-specific
+	// scope granting all admin scopes,
+	// ie., both admin:read and admin:write.
+	ScopeAdmin Scope = "admin"
+
+	ScopeAdminRead                     Scope = "admin:read"
+	ScopeAdminReadAccounts             Scope = "admin:read:accounts"
+	ScopeAdminReadReports              Scope = "admin:read:reports"
+	ScopeAdminReadDomainAllows         Scope = "admin:read:domain_allows"
+	ScopeAdminReadDomainBlocks         Scope = "admin:read:domain_blocks"
+	ScopeAdminReadIPBlocks             Scope = "admin:read:ip_blocks"
+	ScopeAdminReadEmailDomainBlocks    Scope = "admin:read:email_domain_blocks"
+	ScopeAdminReadCanonicalEmailBlocks Scope = "admin:read:canonical_email_blocks"
+
+	ScopeAdminWrite                     Scope = "admin:write"
+	ScopeAdminWriteAccounts             Scope = "admin:write:accounts"
+	ScopeAdminWriteReports              Scope = "admin:write:reports"
+	ScopeAdminWriteDomainAllows         Scope = "admin:write:domain_allows"
+	ScopeAdminWriteDomainBlocks         Scope = "admin:write:domain_blocks"
+	ScopeAdminWriteIPBlocks             Scope = "admin:write:ip_blocks"
+	ScopeAdminWriteEmailDomainBlocks    Scope = "admin:write:email_domain_blocks"
+	ScopeAdminWriteCanonicalEmailBlocks Scope = "admin:write:canonical_email_blocks"
+)
+
+// knownScopes contains all scopes
+// that may be requested of this server.
+var knownScopes = func() map[Scope]struct{} {
+	m := make(map[Scope]struct{})
+	for _, scope := range []Scope{
+		ScopeRead,
+		ScopeReadAccounts,
+		ScopeReadBlocks,
+		ScopeReadBookmarks,
+		ScopeReadCustomEmojis,
+		ScopeReadFavourites,
+		ScopeReadFilters,
+		ScopeReadFollows,
+		ScopeReadLists,
+		ScopeReadMedia,
+		ScopeReadMutes,
+		ScopeReadNotifications,
+		ScopeReadReports,
+		ScopeReadSearch,
+		ScopeReadStatuses,
+		ScopeReadStreaming,
+		ScopeReadUser,
+		ScopeWrite,
+		ScopeWriteAccounts,
+		ScopeWriteBlocks,
+		ScopeWriteBookmarks,
+		ScopeWriteConversations,
+		ScopeWriteFavourites,
+		ScopeWriteFilters,
+		ScopeWriteFollows,
+		ScopeWriteLists,
+		ScopeWriteMedia,
+		ScopeWriteMutes,
+		ScopeWriteNotifications,
+		ScopeWriteReports,
+		ScopeWriteStatuses,
+		ScopeWriteUser,
+		ScopeFollow,
+		ScopePush,
+		ScopeProfile,
+		ScopeAdmin,
+		ScopeAdminRead,
+		ScopeAdminReadAccounts,
+		ScopeAdminReadReports,
+		ScopeAdminReadDomainAllows,
+		ScopeAdminReadDomainBlocks,
+		ScopeAdminReadIPBlocks,
+		ScopeAdminReadEmailDomainBlocks,
+		ScopeAdminReadCanonicalEmailBlocks,
+		ScopeAdminWrite,
+		ScopeAdminWriteAccounts,
+		ScopeAdminWriteReports,
+		ScopeAdminWriteDomainAllows,
+		ScopeAdminWriteDomainBlocks,
+		ScopeAdminWriteIPBlocks,
+		ScopeAdminWriteEmailDomainBlocks,
+		ScopeAdminWriteCanonicalEmailBlocks,
+	} {
+		m[scope] = struct{}{}
+	}
+	return m
+}()
+
+// followScopes contains the scopes
+// granted by the legacy follow scope.
+var followScopes = map[Scope]struct{}{
+	ScopeReadBlocks:   {},
+	ScopeWriteBlocks:  {},
+	ScopeReadFollows:  {},
+	ScopeWriteFollows: {},
+	ScopeReadMutes:    {},
+	ScopeWriteMutes:   {},
+}
+
+// Permits returns true if this scope
+// grants the given (narrower) scope.
+func (s Scope) Permits(wanted Scope) bool {
+	if s == wanted {
+		return true
+	}
+
+	if s == ScopeFollow {
+		_, ok := followScopes[wanted]
+		return ok
+	}
+
+	if s == ScopeProfile {
+		return wanted == ScopeReadAccounts
+	}
+
+	// Broader scopes are prefixes
+	// of narrower ones, eg., "read"
+	// permits "read:statuses", and
+	// "admin" permits "admin:read".
+	return strings.HasPrefix(string(wanted), string(s)+":")
+}
+
+// ScopesPermit returns true if any of the
+// given space-separated scopes, as stored on
+// a token or application, grants wanted scope.
+func ScopesPermit(scopes string, wanted Scope) bool {
+	for _, s := range strings.Fields(scopes) {
+		if Scope(s).Permits(wanted) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateScopes returns an error if the given
+// space-separated scopes contain any scope not
+// known to this server.
+func ValidateScopes(scopes string) error {
+	for _, s := range strings.Fields(scopes) {
+		if _, ok := knownScopes[Scope(s)]; !ok {
+			return fmt.Errorf("scope %s not recognized", s)
+		}
+	}
+	return nil
+}
+
+// ValidateRequestedScopes returns an error if the given
+// requested scopes are not known, or not all permitted
+// by the scopes registered for the application.
+func ValidateRequestedScopes(requested string, registered string) error {
+	if err := ValidateScopes(requested); err != nil {
+		return err
+	}
+
+	for _, s := range strings.Fields(requested) {
+		if !ScopesPermit(registered, Scope(s)) {
+			return fmt.Errorf("scope %s not registered for application", s)
+		}
+	}
+
+	return nil
+}
+
+// RequireScope returns a 403 error if the token of the
+// authed request, if present, doesn't grant the given scope.
+func (a *Auth) RequireScope(scope Scope) gtserror.WithCode {
+	if a.Token == nil {
+		// Nothing to check,
+		// eg. public endpoint.
+		return nil
+	}
+
+	if !ScopesPermit(a.Token.GetScope(), scope) {
+		err := fmt.Errorf("token has insufficient scope permission: requires %s", scope)
+		return gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	return nil
+}
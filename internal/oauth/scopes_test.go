@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ScopesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopesTestSuite) TestScopesPermit() {
+	for _, test := range []struct {
+		scopes string
+		wanted oauth.Scope
+		expect bool
+	}{
+		{"read write follow push", oauth.ScopeReadStatuses, true},
+		{"read write follow push", oauth.ScopeWriteMedia, true},
+		{"read write follow push", oauth.ScopeAdminRead, false},
+		{"read write follow push", oauth.ScopeAdminWriteReports, false},
+		{"read", oauth.ScopeWriteStatuses, false},
+		{"read:statuses", oauth.ScopeReadStatuses, true},
+		{"read:statuses", oauth.ScopeReadAccounts, false},
+		{"read:statuses", oauth.ScopeRead, false},
+		{"follow", oauth.ScopeWriteBlocks, true},
+		{"follow", oauth.ScopeReadMutes, true},
+		{"follow", oauth.ScopeWriteStatuses, false},
+		{"profile", oauth.ScopeReadAccounts, true},
+		{"profile", oauth.ScopeReadStatuses, false},
+		{"profile", oauth.ScopeRead, false},
+		{"admin", oauth.ScopeAdminRead, true},
+		{"admin", oauth.ScopeAdminWriteDomainBlocks, true},
+		{"admin", oauth.ScopeReadStatuses, false},
+		{"admin:read", oauth.ScopeAdminReadReports, true},
+		{"admin:read", oauth.ScopeAdminWriteReports, false},
+		{"", oauth.ScopeRead, false},
+	} {
+		suite.Equal(
+			test.expect,
+			oauth.ScopesPermit(test.scopes, test.wanted),
+			"%q permits %s", test.scopes, test.wanted,
+		)
+	}
+}
+
+func (suite *ScopesTestSuite) TestValidateRequestedScopes() {
+	// Known + within registered scopes.
+	suite.NoError(oauth.ValidateRequestedScopes("read:statuses write:media", "read write"))
+	suite.NoError(oauth.ValidateRequestedScopes("read write", "read write follow"))
+	suite.NoError(oauth.ValidateRequestedScopes("profile", "profile read"))
+
+	// Not registered.
+	suite.EqualError(
+		oauth.ValidateRequestedScopes("read write", "read"),
+		"scope write not registered for application",
+	)
+
+	// Not known at all.
+	suite.EqualError(
+		oauth.ValidateRequestedScopes("read:everything", "read"),
+		"scope read:everything not recognized",
+	)
+}
+
+func TestScopesTestSuite(t *testing.T) {
+	suite.Run(t, new(ScopesTestSuite))
+}
@@ -75,7 +75,7 @@ type s struct {
 }
 
 // New returns a new oauth server that implements the Server interface
-func New(ctx context.Context, database db.Basic) Server {
+func New(ctx context.Context, database db.DB) Server {
 	ts := newTokenStore(ctx, database)
 	cs := NewClientStore(database)
 
@@ -122,6 +122,27 @@ func New(ctx context.Context, database db.Basic) Server {
 		}
 		return userID, nil
 	})
+	srv.SetClientScopeHandler(func(tgr *oauth2.TokenGenerateRequest) (bool, error) {
+		if tgr.Scope == "" {
+			// Default to read, as
+			// per the Mastodon API.
+			tgr.Scope = string(ScopeRead)
+		}
+
+		app, err := database.GetApplicationByClientID(ctx, tgr.ClientID)
+		if err != nil {
+			return false, fmt.Errorf("error getting application for client %s: %w", tgr.ClientID, err)
+		}
+
+		// Requested scopes must be known to us, and be
+		// within the scopes the application registered.
+		if err := ValidateRequestedScopes(tgr.Scope, app.Scopes); err != nil {
+			log.Debugf(ctx, "client %s requested invalid scope: %v", tgr.ClientID, err)
+			return false, nil
+		}
+
+		return true, nil
+	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
 	return &s{
 		server: srv,
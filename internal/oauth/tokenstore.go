@@ -31,6 +31,10 @@ import (
 	"github.com/superseriousbusiness/oauth2/v4/models"
 )
 
+// lastUsedInterval is the minimum time between
+// updates of an access token's last used time.
+const lastUsedInterval = time.Hour
+
 // tokenStore is an implementation of oauth2.TokenStore, which uses our db interface as a storage backend.
 type tokenStore struct {
 	oauth2.TokenStore
@@ -153,6 +157,17 @@ func (ts *tokenStore) GetByAccess(ctx context.Context, access string) (oauth2.To
 	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "access", Value: access}}, dbt); err != nil {
 		return nil, err
 	}
+
+	// Record that the token was used, but only
+	// if it wasn't already recorded recently, so
+	// we're not adding a db write to every request.
+	if now := time.Now(); now.Sub(dbt.LastUsedAt) > lastUsedInterval {
+		dbt.LastUsedAt = now
+		if err := ts.db.UpdateByID(ctx, dbt, dbt.ID, "last_used_at"); err != nil {
+			log.Errorf(ctx, "error updating token last used time: %v", err)
+		}
+	}
+
 	return DBTokenToToken(dbt), nil
 }
 
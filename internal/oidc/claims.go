@@ -17,7 +17,10 @@
 
 package oidc
 
-import "encoding/gob"
+import (
+	"encoding/gob"
+	"strings"
+)
 
 // Claims represents claims as found in an id_token returned from an OIDC flow.
 type Claims struct {
@@ -32,3 +35,37 @@ type Claims struct {
 func init() {
 	gob.Register(&Claims{})
 }
+
+// groupsFromClaim returns the groups contained in the given
+// claim of the given raw claims. Nested claims can be given
+// by separating keys with dots, eg., "realm_access.roles".
+//
+// The claim value can be either an array of
+// strings, or a single string for one group.
+func groupsFromClaim(raw map[string]any, claim string) []string {
+	var v any = raw
+	for _, key := range strings.Split(claim, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+
+	case []any:
+		groups := make([]string, 0, len(v))
+		for _, group := range v {
+			if group, ok := group.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+		return groups
+
+	default:
+		return nil
+	}
+}
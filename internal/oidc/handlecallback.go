@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -63,6 +64,18 @@ func (i *idp) HandleCallback(ctx context.Context, code string) (*Claims, gtserro
 		return nil, gtserror.NewErrorInternalError(err, err.Error())
 	}
 
+	if groupsClaim := config.GetOIDCGroupsClaim(); groupsClaim != "" && groupsClaim != "groups" {
+		// Groups are configured to be taken
+		// from some other claim, so parse
+		// claims generically to fetch them.
+		var raw map[string]any
+		if err := idToken.Claims(&raw); err != nil {
+			err := fmt.Errorf("could not parse raw claims from idToken: %s", err)
+			return nil, gtserror.NewErrorInternalError(err, err.Error())
+		}
+		claims.Groups = groupsFromClaim(raw, groupsClaim)
+	}
+
 	return claims, nil
 }
 
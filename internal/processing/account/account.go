@@ -18,6 +18,7 @@
 package account
 
 import (
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -44,6 +45,8 @@ type Processor struct {
 	formatter    *text.Formatter
 	federator    *federation.Federator
 	parseMention gtsmodel.ParseMentionFunc
+	emailSender  email.Sender
+	themes       *Themes
 }
 
 // New returns a new account processor.
@@ -56,6 +59,7 @@ func New(
 	federator *federation.Federator,
 	filter *visibility.Filter,
 	parseMention gtsmodel.ParseMentionFunc,
+	emailSender email.Sender,
 ) Processor {
 	return Processor{
 		c:            common,
@@ -67,5 +71,7 @@ func New(
 		formatter:    text.NewFormatter(state.DB),
 		federator:    federator,
 		parseMention: parseMention,
+		emailSender:  emailSender,
+		themes:       PopulateThemes(),
 	}
 }
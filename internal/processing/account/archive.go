@@ -0,0 +1,902 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+const (
+	// archiveExpiry is how long a complete
+	// archive remains available for download.
+	archiveExpiry = 7 * 24 * time.Hour
+
+	// archivePartSize is the number of
+	// statuses written to each outbox part.
+	archivePartSize = 100
+
+	// Media sizes used in storage paths of
+	// outbox parts, and of the final archive.
+	archiveSizePart     = "part"
+	archiveSizeOriginal = "original"
+)
+
+// archivePart models one part of an archive outbox, as
+// written to storage while the archive is generated.
+type archivePart struct {
+	// Serialized Create / Announce activities.
+	OrderedItems []json.RawMessage `json:"orderedItems"`
+
+	// IDs of media attached to the statuses.
+	Attachments []string `json:"attachments"`
+}
+
+// ArchiveCreate starts generation of a new archive of all
+// of the given account's data, returning its initial state.
+//
+// Only one archive may be in progress at a time per account.
+// Any previous (complete or failed) archives of the account
+// are removed when a new one is started.
+func (p *Processor) ArchiveCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	archives, err := p.state.DB.GetAccountArchives(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, archive := range archives {
+		if archive.InFlight() {
+			const text = "an archive of this account is already being generated"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+	}
+
+	for _, archive := range archives {
+		if err := p.deleteArchive(ctx, archive); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	archive := &gtsmodel.AccountArchive{
+		ID:        id.NewULID(),
+		AccountID: account.ID,
+		Account:   account,
+		Stage:     gtsmodel.AccountArchiveStageOutbox,
+		Token:     uuid.NewString(),
+	}
+
+	if err := p.state.DB.PutAccountArchive(ctx, archive); err != nil {
+		err := gtserror.Newf("db error putting account archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.enqueueArchive(archive.ID)
+
+	return p.converter.AccountArchiveToAPIAccountArchive(ctx, archive), nil
+}
+
+// ArchiveGet returns the state of the most
+// recently requested archive of the given account.
+func (p *Processor) ArchiveGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	archives, err := p.state.DB.GetAccountArchives(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(archives) == 0 {
+		const text = "no archive of this account has been requested"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return p.converter.AccountArchiveToAPIAccountArchive(ctx, archives[0]), nil
+}
+
+// ArchiveFileGet returns the content of the given
+// account archive for download via the fileserver,
+// provided the given token matches, and the archive
+// is complete and not yet expired.
+//
+// Any failure results in a 404, so as not to give
+// away whether an archive exists to the requester.
+func (p *Processor) ArchiveFileGet(
+	ctx context.Context,
+	accountID string,
+	fileName string,
+	token string,
+) (*apimodel.Content, gtserror.WithCode) {
+	archiveID, ok := cutArchiveFileName(fileName)
+	if !ok {
+		err := gtserror.Newf("file name %s not parseable", fileName)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	archive, err := p.state.DB.GetAccountArchiveByID(
+		gtscontext.SetBarebones(ctx),
+		archiveID,
+	)
+	if err != nil {
+		err := gtserror.Newf("db error getting account archive %s: %w", archiveID, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if archive.AccountID != accountID {
+		err := gtserror.Newf("archive %s not owned by %s", archiveID, accountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(archive.Token)) != 1 {
+		err := gtserror.Newf("invalid token for archive %s", archiveID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if archive.Stage != gtsmodel.AccountArchiveStageComplete {
+		err := gtserror.Newf("archive %s not complete", archiveID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if time.Now().After(archive.ExpiresAt) {
+		err := gtserror.Newf("archive %s expired", archiveID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, archiveKey(archive))
+	if err != nil {
+		err := gtserror.Newf("error getting archive %s from storage: %w", archiveID, err)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return &apimodel.Content{
+		ContentType:    "application/zip",
+		ContentLength:  archive.FileSize,
+		ContentUpdated: archive.CompletedAt,
+		Content:        rc,
+	}, nil
+}
+
+// ResumeArchives picks up generation of all archives that
+// were still in progress when the instance last stopped,
+// and schedules expiry of all complete archives.
+func (p *Processor) ResumeArchives(ctx context.Context) error {
+	archives, err := p.state.DB.GetAccountArchivesByStage(
+		gtscontext.SetBarebones(ctx),
+		gtsmodel.AccountArchiveStageOutbox,
+		gtsmodel.AccountArchiveStageBundle,
+		gtsmodel.AccountArchiveStageComplete,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting account archives: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, archive := range archives {
+		if archive.InFlight() {
+			log.Infof(ctx, "resuming generation of account archive %s", archive.ID)
+			p.enqueueArchive(archive.ID)
+			continue
+		}
+
+		if err := p.scheduleArchiveExpiry(ctx, archive); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// enqueueArchive enqueues generation of
+// the archive with the given ID on the
+// media worker pool.
+func (p *Processor) enqueueArchive(archiveID string) {
+	go p.state.Workers.Media.Enqueue(func(ctx context.Context) {
+		p.buildArchive(ctx, archiveID)
+	})
+}
+
+// buildArchive generates the archive with the given ID,
+// carrying on from whichever stage it was last left at.
+func (p *Processor) buildArchive(ctx context.Context, archiveID string) {
+	archive, err := p.state.DB.GetAccountArchiveByID(ctx, archiveID)
+	if err != nil {
+		log.Errorf(ctx, "db error getting account archive %s: %v", archiveID, err)
+		return
+	}
+
+	if err := p.buildArchiveStages(ctx, archive); err != nil {
+		if ctx.Err() != nil {
+			// We were interrupted (eg., by shutdown),
+			// leave the archive where it is so that
+			// it can be resumed on next startup.
+			log.Infof(ctx, "generation of account archive %s interrupted: %v", archiveID, err)
+			return
+		}
+
+		log.Errorf(ctx, "error generating account archive %s: %v", archiveID, err)
+
+		archive.Stage = gtsmodel.AccountArchiveStageFailed
+		if err := p.state.DB.UpdateAccountArchive(ctx, archive, "stage"); err != nil {
+			log.Errorf(ctx, "db error updating account archive %s: %v", archiveID, err)
+		}
+
+		// Don't leave any half-written data lying around.
+		p.deleteArchiveFiles(ctx, archive)
+	}
+}
+
+func (p *Processor) buildArchiveStages(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	if archive.Stage == gtsmodel.AccountArchiveStageOutbox {
+		if err := p.writeArchiveOutbox(ctx, archive); err != nil {
+			return err
+		}
+
+		archive.Stage = gtsmodel.AccountArchiveStageBundle
+		if err := p.state.DB.UpdateAccountArchive(ctx, archive, "stage"); err != nil {
+			return gtserror.Newf("db error updating account archive: %w", err)
+		}
+	}
+
+	if archive.Stage == gtsmodel.AccountArchiveStageBundle {
+		if err := p.writeArchiveBundle(ctx, archive); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		archive.Stage = gtsmodel.AccountArchiveStageComplete
+		archive.CompletedAt = now
+		archive.ExpiresAt = now.Add(archiveExpiry)
+		if err := p.state.DB.UpdateAccountArchive(
+			ctx,
+			archive,
+			"stage",
+			"file_size",
+			"completed_at",
+			"expires_at",
+		); err != nil {
+			return gtserror.Newf("db error updating account archive: %w", err)
+		}
+
+		// Outbox parts are now
+		// bundled, so not needed.
+		p.deleteArchiveParts(ctx, archive)
+
+		if err := p.scheduleArchiveExpiry(ctx, archive); err != nil {
+			log.Errorf(ctx, "error scheduling expiry of account archive %s: %v", archive.ID, err)
+		}
+
+		if err := p.emailArchiveComplete(ctx, archive); err != nil {
+			log.Errorf(ctx, "error emailing account archive %s: %v", archive.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// writeArchiveOutbox pages down through the statuses of
+// the archive's account, writing them in parts to storage,
+// and storing progress after each part so that this can
+// be resumed from where it left off if interrupted.
+func (p *Processor) writeArchiveOutbox(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	for {
+		statuses, err := p.state.DB.GetAccountStatuses(
+			ctx,
+			archive.AccountID,
+			archivePartSize,
+			false, // excludeReplies
+			false, // excludeReblogs
+			archive.OutboxMaxID,
+			"",    // minID
+			false, // mediaOnly
+			false, // publicOnly
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			// Reached
+			// the end.
+			return nil
+		}
+
+		var part archivePart
+		for _, status := range statuses {
+			item, err := p.archiveItem(ctx, status)
+			if err != nil {
+				log.Errorf(ctx, "skipping status %s: %v", status.ID, err)
+				continue
+			}
+
+			part.OrderedItems = append(part.OrderedItems, item)
+			part.Attachments = append(part.Attachments, status.AttachmentIDs...)
+		}
+
+		b, err := json.Marshal(part)
+		if err != nil {
+			return gtserror.Newf("error marshaling outbox part: %w", err)
+		}
+
+		// Name the part after its first status, so that
+		// rewriting an interrupted part reuses the key.
+		partID := statuses[0].ID
+		if err := p.putArchiveFile(ctx, archivePartKey(archive, partID), b); err != nil {
+			return err
+		}
+
+		archive.OutboxPartIDs = append(archive.OutboxPartIDs, partID)
+		archive.OutboxMaxID = statuses[len(statuses)-1].ID
+		archive.StatusesCount += len(part.OrderedItems)
+		if err := p.state.DB.UpdateAccountArchive(
+			ctx,
+			archive,
+			"outbox_max_id",
+			"outbox_parts",
+			"statuses_count",
+		); err != nil {
+			return gtserror.Newf("db error updating account archive: %w", err)
+		}
+	}
+}
+
+// archiveItem serializes the given status as the
+// activity that would appear for it in an outbox.
+func (p *Processor) archiveItem(ctx context.Context, status *gtsmodel.Status) (json.RawMessage, error) {
+	var (
+		m   map[string]interface{}
+		err error
+	)
+
+	if status.BoostOfID != "" {
+		if status.BoostOf == nil {
+			return nil, gtserror.New("boosted status not found")
+		}
+
+		announce, err := p.converter.BoostToAS(ctx, status, status.Account, status.BoostOfAccount)
+		if err != nil {
+			return nil, gtserror.Newf("error converting boost: %w", err)
+		}
+
+		m, err = ap.Serialize(announce)
+		if err != nil {
+			return nil, gtserror.Newf("error serializing boost: %w", err)
+		}
+	} else {
+		statusable, err := p.converter.StatusToAS(ctx, status)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status: %w", err)
+		}
+
+		create := typeutils.WrapStatusableInCreate(statusable, false)
+		m, err = ap.Serialize(create)
+		if err != nil {
+			return nil, gtserror.Newf("error serializing status: %w", err)
+		}
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, gtserror.Newf("error marshaling status: %w", err)
+	}
+
+	return b, nil
+}
+
+// writeArchiveBundle bundles everything up into the
+// final archive zip file, and writes it to storage.
+func (p *Processor) writeArchiveBundle(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	if archive.Account == nil {
+		account, err := p.state.DB.GetAccountByID(ctx, archive.AccountID)
+		if err != nil {
+			return gtserror.Newf("db error getting account: %w", err)
+		}
+		archive.Account = account
+	}
+
+	// Stream the zip straight
+	// into storage as it's built.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(p.writeArchiveZip(ctx, archive, pw))
+	}()
+
+	key := archiveKey(archive)
+
+	// Clear any archive left
+	// over from an interrupted run.
+	if err := p.state.Storage.Delete(ctx, key); err != nil &&
+		!errors.Is(err, storage.ErrNotFound) {
+		pr.CloseWithError(err)
+		return gtserror.Newf("error removing old archive from storage: %w", err)
+	}
+
+	n, err := p.state.Storage.PutStream(ctx, key, pr)
+	if err != nil {
+		pr.CloseWithError(err)
+		return gtserror.Newf("error writing archive to storage: %w", err)
+	}
+
+	archive.FileSize = n
+	return nil
+}
+
+func (p *Processor) writeArchiveZip(ctx context.Context, archive *gtsmodel.AccountArchive, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	if err := p.writeArchiveActor(ctx, zw, archive.Account); err != nil {
+		return err
+	}
+
+	attachmentIDs, err := p.writeArchiveOutboxJSON(ctx, zw, archive)
+	if err != nil {
+		return err
+	}
+
+	if archive.Account.AvatarMediaAttachmentID != "" {
+		attachmentIDs = append(attachmentIDs, archive.Account.AvatarMediaAttachmentID)
+	}
+
+	if archive.Account.HeaderMediaAttachmentID != "" {
+		attachmentIDs = append(attachmentIDs, archive.Account.HeaderMediaAttachmentID)
+	}
+
+	if err := p.writeArchiveMedia(ctx, zw, attachmentIDs); err != nil {
+		return err
+	}
+
+	if err := p.writeArchiveCSVs(ctx, zw, archive.Account); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return gtserror.Newf("error closing zip: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Processor) writeArchiveActor(ctx context.Context, zw *zip.Writer, account *gtsmodel.Account) error {
+	person, err := p.converter.AccountToAS(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error converting account: %w", err)
+	}
+
+	m, err := ap.Serialize(person)
+	if err != nil {
+		return gtserror.Newf("error serializing account: %w", err)
+	}
+
+	f, err := zw.Create("actor.json")
+	if err != nil {
+		return gtserror.Newf("error creating zip entry: %w", err)
+	}
+
+	if err := json.NewEncoder(f).Encode(m); err != nil {
+		return gtserror.Newf("error writing actor: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchiveOutboxJSON merges the outbox parts written to
+// storage into one outbox.json ordered collection, returning
+// the IDs of all media attached to the statuses therein.
+func (p *Processor) writeArchiveOutboxJSON(
+	ctx context.Context,
+	zw *zip.Writer,
+	archive *gtsmodel.AccountArchive,
+) ([]string, error) {
+	f, err := zw.Create("outbox.json")
+	if err != nil {
+		return nil, gtserror.Newf("error creating zip entry: %w", err)
+	}
+
+	header, err := json.Marshal(map[string]interface{}{
+		"@context":   "https://www.w3.org/ns/activitystreams",
+		"id":         archive.Account.OutboxURI,
+		"type":       ap.ObjectOrderedCollection,
+		"totalItems": archive.StatusesCount,
+	})
+	if err != nil {
+		return nil, gtserror.Newf("error marshaling outbox: %w", err)
+	}
+
+	// Write the collection header minus its
+	// closing brace, then stream the items
+	// from each of the parts into it.
+	if _, err := f.Write(header[:len(header)-1]); err != nil {
+		return nil, gtserror.Newf("error writing outbox: %w", err)
+	}
+
+	if _, err := io.WriteString(f, `,"orderedItems":[`); err != nil {
+		return nil, gtserror.Newf("error writing outbox: %w", err)
+	}
+
+	var (
+		attachmentIDs []string
+		first         = true
+	)
+
+	for _, partID := range archive.OutboxPartIDs {
+		b, err := p.state.Storage.Get(ctx, archivePartKey(archive, partID))
+		if err != nil {
+			return nil, gtserror.Newf("error getting outbox part %s from storage: %w", partID, err)
+		}
+
+		var part archivePart
+		if err := json.Unmarshal(b, &part); err != nil {
+			return nil, gtserror.Newf("error unmarshaling outbox part %s: %w", partID, err)
+		}
+
+		for _, item := range part.OrderedItems {
+			if !first {
+				if _, err := io.WriteString(f, ","); err != nil {
+					return nil, gtserror.Newf("error writing outbox: %w", err)
+				}
+			}
+			first = false
+
+			if _, err := f.Write(item); err != nil {
+				return nil, gtserror.Newf("error writing outbox: %w", err)
+			}
+		}
+
+		attachmentIDs = append(attachmentIDs, part.Attachments...)
+	}
+
+	if _, err := io.WriteString(f, "]}"); err != nil {
+		return nil, gtserror.Newf("error writing outbox: %w", err)
+	}
+
+	return attachmentIDs, nil
+}
+
+// writeArchiveMedia copies the original files of
+// the given media attachments from storage into
+// the zip, at the same paths as in storage.
+func (p *Processor) writeArchiveMedia(ctx context.Context, zw *zip.Writer, attachmentIDs []string) error {
+	seen := make(map[string]struct{}, len(attachmentIDs))
+
+	for _, attachmentID := range attachmentIDs {
+		if _, ok := seen[attachmentID]; ok {
+			continue
+		}
+		seen[attachmentID] = struct{}{}
+
+		attachment, err := p.state.DB.GetAttachmentByID(
+			gtscontext.SetBarebones(ctx),
+			attachmentID,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Attachment gone
+				// in the meantime.
+				continue
+			}
+			return gtserror.Newf("db error getting attachment %s: %w", attachmentID, err)
+		}
+
+		if attachment.File.Path == "" || !*attachment.Cached {
+			// Nothing in
+			// storage to copy.
+			continue
+		}
+
+		if err := p.copyArchiveFile(ctx, zw, attachment.File.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Processor) copyArchiveFile(ctx context.Context, zw *zip.Writer, key string) error {
+	rc, err := p.state.Storage.GetStream(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warnf(ctx, "media file %s missing from storage", key)
+			return nil
+		}
+		return gtserror.Newf("error getting %s from storage: %w", key, err)
+	}
+	defer rc.Close()
+
+	f, err := zw.Create("media/" + key)
+	if err != nil {
+		return gtserror.Newf("error creating zip entry: %w", err)
+	}
+
+	if _, err := io.Copy(f, rc); err != nil {
+		return gtserror.Newf("error copying %s from storage: %w", key, err)
+	}
+
+	return nil
+}
+
+// writeArchiveCSVs writes the account's follows, blocks
+// and bookmarks into the zip as csv files, in the same
+// formats as used by the csv exports of the client API.
+func (p *Processor) writeArchiveCSVs(ctx context.Context, zw *zip.Writer, account *gtsmodel.Account) error {
+	follows, err := p.state.DB.GetAccountFollows(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting follows: %w", err)
+	}
+
+	following := [][]string{{"Account address", "Show boosts", "Notify on new posts"}}
+	for _, follow := range follows {
+		if follow.TargetAccount == nil {
+			continue
+		}
+
+		following = append(following, []string{
+			archiveAcct(follow.TargetAccount),
+			strconv.FormatBool(*follow.ShowReblogs),
+			strconv.FormatBool(*follow.Notify),
+		})
+	}
+
+	if err := writeArchiveCSV(zw, "following_accounts.csv", following); err != nil {
+		return err
+	}
+
+	blocks, err := p.state.DB.GetAccountBlocks(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting blocks: %w", err)
+	}
+
+	blocked := make([][]string, 0, len(blocks))
+	for _, block := range blocks {
+		if block.TargetAccount == nil {
+			continue
+		}
+
+		blocked = append(blocked, []string{archiveAcct(block.TargetAccount)})
+	}
+
+	if err := writeArchiveCSV(zw, "blocked_accounts.csv", blocked); err != nil {
+		return err
+	}
+
+	bookmarks, errWithCode := p.BookmarksExport(ctx, account)
+	if errWithCode != nil {
+		return errWithCode.Unwrap()
+	}
+
+	return writeArchiveCSV(zw, "bookmarks.csv", bookmarks)
+}
+
+func writeArchiveCSV(zw *zip.Writer, name string, records [][]string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating zip entry: %w", err)
+	}
+
+	if err := csv.NewWriter(f).WriteAll(records); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// scheduleArchiveExpiry adds removal of the given
+// complete archive to the scheduler, at its expiry.
+func (p *Processor) scheduleArchiveExpiry(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	archiveID := archive.ID
+	taskID := archiveTaskID(archiveID)
+	_ = p.state.Workers.Scheduler.Cancel(taskID)
+	ok := p.state.Workers.Scheduler.AddOnce(
+		taskID,
+		archive.ExpiresAt,
+		func(ctx context.Context, _ time.Time) {
+			// Get the latest version of archive from database.
+			archive, err := p.state.DB.GetAccountArchiveByID(ctx, archiveID)
+			if err != nil {
+				if !errors.Is(err, db.ErrNoEntries) {
+					log.Errorf(ctx, "db error getting account archive %s: %v", archiveID, err)
+				}
+				return
+			}
+
+			if err := p.deleteArchive(ctx, archive); err != nil {
+				log.Errorf(ctx, "error removing expired account archive %s: %v", archiveID, err)
+			}
+		},
+	)
+
+	if !ok {
+		// Failed to add the expiry to the
+		// scheduler, it was starting / stopping.
+		return gtserror.Newf("failed adding expiry of account archive %s to scheduler", archiveID)
+	}
+
+	return nil
+}
+
+// deleteAccountArchives removes all
+// archives of the given account.
+func (p *Processor) deleteAccountArchives(ctx context.Context, account *gtsmodel.Account) error {
+	archives, err := p.state.DB.GetAccountArchives(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting account archives: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, archive := range archives {
+		if err := p.deleteArchive(ctx, archive); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// deleteArchive removes the given archive
+// from both the database and storage.
+func (p *Processor) deleteArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	_ = p.state.Workers.Scheduler.Cancel(archiveTaskID(archive.ID))
+
+	p.deleteArchiveFiles(ctx, archive)
+
+	if err := p.state.DB.DeleteAccountArchiveByID(ctx, archive.ID); err != nil {
+		return gtserror.Newf("db error deleting account archive %s: %w", archive.ID, err)
+	}
+
+	return nil
+}
+
+// deleteArchiveFiles removes the outbox parts
+// and zip of the given archive from storage.
+func (p *Processor) deleteArchiveFiles(ctx context.Context, archive *gtsmodel.AccountArchive) {
+	p.deleteArchiveParts(ctx, archive)
+	p.deleteArchiveFile(ctx, archiveKey(archive))
+}
+
+func (p *Processor) deleteArchiveParts(ctx context.Context, archive *gtsmodel.AccountArchive) {
+	for _, partID := range archive.OutboxPartIDs {
+		p.deleteArchiveFile(ctx, archivePartKey(archive, partID))
+	}
+}
+
+func (p *Processor) deleteArchiveFile(ctx context.Context, key string) {
+	if err := p.state.Storage.Delete(ctx, key); err != nil &&
+		!errors.Is(err, storage.ErrNotFound) {
+		log.Errorf(ctx, "error removing %s from storage: %v", key, err)
+	}
+}
+
+// putArchiveFile writes the given data to storage at
+// key, replacing anything already stored there.
+func (p *Processor) putArchiveFile(ctx context.Context, key string, b []byte) error {
+	if err := p.state.Storage.Delete(ctx, key); err != nil &&
+		!errors.Is(err, storage.ErrNotFound) {
+		return gtserror.Newf("error removing %s from storage: %w", key, err)
+	}
+
+	if _, err := p.state.Storage.Put(ctx, key, b); err != nil {
+		return gtserror.Newf("error writing %s to storage: %w", key, err)
+	}
+
+	return nil
+}
+
+func (p *Processor) emailArchiveComplete(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	user, err := p.state.DB.GetUserByAccountID(ctx, archive.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.Email == "" {
+		// Nowhere
+		// to send it.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	return p.emailSender.SendArchiveEmail(
+		user.Email,
+		email.ArchiveData{
+			Username:     archive.Account.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			ExpiryTime:   archive.ExpiresAt.UTC().Format("Jan _2 2006 15:04:05 MST"),
+			DownloadLink: uris.URIForAccountArchive(archive.AccountID, archive.ID, archive.Token),
+		},
+	)
+}
+
+// archiveKey returns the storage key
+// of the given archive's zip file.
+func archiveKey(archive *gtsmodel.AccountArchive) string {
+	return uris.StoragePathForAttachment(
+		archive.AccountID,
+		string(media.TypeArchive),
+		archiveSizeOriginal,
+		archive.ID,
+		"zip",
+	)
+}
+
+// archivePartKey returns the storage key
+// of the given archive's outbox part.
+func archivePartKey(archive *gtsmodel.AccountArchive, partID string) string {
+	return uris.StoragePathForAttachment(
+		archive.AccountID,
+		string(media.TypeArchive),
+		archiveSizePart,
+		partID,
+		"json",
+	)
+}
+
+// cutArchiveFileName returns the archive ID
+// from a fileserver file name like "{$id}.zip".
+func cutArchiveFileName(fileName string) (string, bool) {
+	archiveID, ok := strings.CutSuffix(fileName, ".zip")
+	return archiveID, ok && archiveID != ""
+}
+
+// archiveAcct returns the username@domain
+// form of the given account's address.
+func archiveAcct(account *gtsmodel.Account) string {
+	domain := account.Domain
+	if domain == "" {
+		domain = config.GetAccountDomain()
+	}
+	return account.Username + "@" + domain
+}
+
+// archiveTaskID returns the scheduler task
+// ID for expiry of the given archive.
+func archiveTaskID(archiveID string) string {
+	return "account-archive-" + archiveID
+}
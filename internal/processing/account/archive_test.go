@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreate() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		user    = suite.testUsers["local_account_1"]
+	)
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, account)
+	suite.NoError(errWithCode)
+	suite.Nil(apiArchive.URL)
+
+	// Wait for the archive to be generated.
+	var archive *gtsmodel.AccountArchive
+	if !testrig.WaitFor(func() bool {
+		archive, _ = suite.db.GetAccountArchiveByID(ctx, apiArchive.ID)
+		return archive != nil && archive.Stage == gtsmodel.AccountArchiveStageComplete
+	}) {
+		suite.FailNow("timed out waiting for archive")
+	}
+
+	// Archive state should now be complete, with a link.
+	apiArchive, errWithCode = suite.accountProcessor.ArchiveGet(ctx, account)
+	suite.NoError(errWithCode)
+	suite.Equal("complete", apiArchive.State)
+	suite.NotNil(apiArchive.URL)
+
+	// Link should have been emailed to the user.
+	suite.Contains(suite.sentEmails[user.Email], *apiArchive.URL)
+
+	// Archive can't be fetched without the right token.
+	_, errWithCode = suite.accountProcessor.ArchiveFileGet(ctx, account.ID, archive.ID+".zip", "nope")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	content, errWithCode := suite.accountProcessor.ArchiveFileGet(ctx, account.ID, archive.ID+".zip", archive.Token)
+	suite.NoError(errWithCode)
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.Close())
+	suite.EqualValues(content.ContentLength, len(b))
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	for _, name := range []string{
+		"actor.json",
+		"outbox.json",
+		"following_accounts.csv",
+		"blocked_accounts.csv",
+		"bookmarks.csv",
+		"media/" + suite.testAttachments["local_account_1_status_4_attachment_1"].File.Path,
+	} {
+		suite.Contains(files, name)
+	}
+
+	rc, err := files["outbox.json"].Open()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer rc.Close()
+
+	var outbox struct {
+		TotalItems   int               `json:"totalItems"`
+		OrderedItems []json.RawMessage `json:"orderedItems"`
+	}
+	suite.NoError(json.NewDecoder(rc).Decode(&outbox))
+	suite.NotZero(outbox.TotalItems)
+	suite.Equal(archive.StatusesCount, outbox.TotalItems)
+	suite.Len(outbox.OrderedItems, outbox.TotalItems)
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreateInFlight() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	// Put an archive that's still being generated.
+	if err := suite.db.PutAccountArchive(ctx, &gtsmodel.AccountArchive{
+		ID:        "01HT0GQ2RSB0DGT2TGSAH5QSQ6",
+		AccountID: account.ID,
+		Stage:     gtsmodel.AccountArchiveStageBundle,
+		Token:     "ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, account)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
@@ -19,37 +19,74 @@ package account
 
 import (
 	"context"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
-	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+const (
+	// maxBookmarksImportSize is the maximum size
+	// of CSV file accepted by BookmarksImport.
+	maxBookmarksImportSize = 1 << 20 // 1MiB
+
+	// maxBookmarksImportRows is the maximum number
+	// of rows in CSV file accepted by BookmarksImport.
+	maxBookmarksImportRows = 1000
+
+	// maxBookmarksImportRemote is the maximum number of
+	// unknown remote statuses that BookmarksImport will
+	// dereference while handling one import request.
+	maxBookmarksImportRemote = 40
+
+	// bookmarksImportRemoteTimeout is the total time that
+	// BookmarksImport will spend dereferencing unknown
+	// remote statuses while handling one import request.
+	bookmarksImportRemoteTimeout = 30 * time.Second
 )
 
 // BookmarksGet returns a pageable response of statuses that are bookmarked by requestingAccount.
 // Paging for this response is done based on bookmark ID rather than status ID.
-func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmodel.Account, limit int, maxID string, minID string) (*apimodel.PageableResponse, gtserror.WithCode) {
-	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, limit, maxID, minID)
+func (p *Processor) BookmarksGet(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, page)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
 	count := len(bookmarks)
 	if count == 0 {
-		return util.EmptyPageableResponse(), nil
+		return paging.EmptyResponse(), nil
 	}
 
 	var (
 		items = make([]interface{}, 0, count)
 
-		// Set next + prev values before filtering and API
-		// converting, so caller can still page properly.
-		// Page based on bookmark ID, not status ID.
-		nextMaxIDValue = bookmarks[count-1].ID
-		prevMinIDValue = bookmarks[0].ID
+		// Get the lowest and highest ID values before
+		// filtering and API converting, so caller can
+		// still page properly. Page based on bookmark
+		// ID, not status ID.
+		lo = bookmarks[count-1].ID
+		hi = bookmarks[0].ID
 	)
 
 	for _, bookmark := range bookmarks {
@@ -82,11 +119,332 @@ func (p *Processor) BookmarksGet(ctx context.Context, requestingAccount *gtsmode
 		items = append(items, item)
 	}
 
-	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "/api/v1/bookmarks",
-		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
-	})
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/bookmarks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// BookmarksExport returns the URIs of all statuses bookmarked
+// by requestingAccount, newest bookmark first, as CSV records
+// suitable for later import via BookmarksImport.
+func (p *Processor) BookmarksExport(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requestingAccount.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting bookmarks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(bookmarks))
+
+	for _, bookmark := range bookmarks {
+		status, err := p.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			bookmark.StatusID,
+		)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// We just don't have the status for some reason.
+				// Skip this one.
+				continue
+			}
+			err = gtserror.Newf("db error getting bookmarked status: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		records = append(records, []string{status.URI})
+	}
+
+	return records, nil
+}
+
+// BookmarksImport parses the given CSV file of status URIs
+// (or URLs), as produced by BookmarksExport, and bookmarks
+// each status that is known to this instance and visible to
+// the requestingAccount. Remote statuses not yet known are
+// dereferenced, up to maxBookmarksImportRemote of them within
+// bookmarksImportRemoteTimeout, so as not to hold the request
+// up indefinitely on remote fetches. Remaining unknown remote
+// statuses are reported with status 429, to be imported again.
+//
+// The result of each row is reported back in the returned
+// MultiStatus, so that the caller can see which failed.
+func (p *Processor) BookmarksImport(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	dataF *multipart.FileHeader,
+) (*apimodel.MultiStatus, gtserror.WithCode) {
+	if dataF.Size > maxBookmarksImportSize {
+		err := fmt.Errorf("file size %d exceeds max bookmarks import size %d", dataF.Size, maxBookmarksImportSize)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Open the provided file.
+	file, err := dataF.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse file as CSV records,
+	// up to the max number of rows.
+	reader := csv.NewReader(io.LimitReader(file, maxBookmarksImportSize))
+	reader.FieldsPerRecord = -1
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			err = gtserror.Newf("error parsing attachment as csv: %w", err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if len(records) == maxBookmarksImportRows {
+			err := fmt.Errorf("file exceeds max bookmarks import rows %d", maxBookmarksImportRows)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		records = append(records, record)
+	}
+
+	count := len(records)
+	if count == 0 {
+		err = gtserror.New("error importing bookmarks: 0 entries provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	var (
+		entries = make([]apimodel.MultiStatusEntry, 0, count)
+
+		// Indices of entries for unknown
+		// remote statuses, by their URI.
+		toDeref = make(map[int]*url.URL)
+	)
+
+	for _, record := range records {
+		if len(record) == 0 {
+			continue
+		}
+
+		uriStr := strings.TrimSpace(record[0])
+		if uriStr == "" {
+			continue
+		}
+
+		var entry apimodel.MultiStatusEntry
+
+		uri, errWithCode := p.bookmarkImport(ctx, requestingAccount, uriStr)
+		switch {
+		case errWithCode != nil:
+			entry = apimodel.MultiStatusEntry{
+				Resource: uriStr,
+				Message:  errWithCode.Safe(),
+				Status:   errWithCode.Code(),
+			}
+
+		case uri != nil:
+			// Unknown remote status,
+			// filled in further down.
+			toDeref[len(entries)] = uri
+			entry = apimodel.MultiStatusEntry{
+				Resource: uriStr,
+			}
+
+		default:
+			entry = apimodel.MultiStatusEntry{
+				Resource: uriStr,
+				Message:  http.StatusText(http.StatusOK),
+				Status:   http.StatusOK,
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(toDeref) > 0 {
+		p.bookmarkImportRemote(ctx, requestingAccount, entries, toDeref)
+	}
+
+	return apimodel.NewMultiStatus(entries), nil
+}
+
+// bookmarkImport resolves the status at the given URI
+// from the database and bookmarks it for requestingAccount,
+// if visible. It is a no-op if the status is already bookmarked.
+//
+// If the status is remote and not yet known, its parsed
+// URI is returned to be dereferenced later by the caller.
+func (p *Processor) bookmarkImport(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	uriStr string,
+) (*url.URL, gtserror.WithCode) {
+	uri, err := url.Parse(uriStr)
+	if err != nil || uri.Scheme == "" || uri.Host == "" {
+		err := fmt.Errorf("%s is not a valid status uri", uriStr)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Look for the status by URI, falling
+	// back to URL, as either may be given.
+	status, err := p.state.DB.GetStatusByURI(ctx, uriStr)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		status, err = p.state.DB.GetStatusByURL(ctx, uriStr)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting status: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if status == nil {
+		if config.IsLocalHost(uri.Host) {
+			// Local statuses can't
+			// be dereferenced.
+			err := fmt.Errorf("status %s could not be resolved", uriStr)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		// Remote status we
+		// don't know yet.
+		return uri, nil
+	}
+
+	return nil, p.bookmarkStatus(ctx, requestingAccount, status, uriStr)
+}
+
+// bookmarkImportRemote dereferences the remote status URIs
+// in toDeref in turn, (keyed by index into entries), and
+// bookmarks them for requestingAccount if visible, setting
+// the result of each in entries. Dereferencing is limited to
+// maxBookmarksImportRemote statuses and to the total time of
+// bookmarksImportRemoteTimeout; statuses beyond either limit
+// are reported with status 429, so they can be tried again.
+func (p *Processor) bookmarkImportRemote(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	entries []apimodel.MultiStatusEntry,
+	toDeref map[int]*url.URL,
+) {
+	ctx, cancel := context.WithTimeout(ctx, bookmarksImportRemoteTimeout)
+	defer cancel()
+
+	// Dereference in
+	// order of rows.
+	idxs := make([]int, 0, len(toDeref))
+	for i := range toDeref {
+		idxs = append(idxs, i)
+	}
+	slices.Sort(idxs)
+
+	for n, i := range idxs {
+		uri := toDeref[i]
+
+		if n >= maxBookmarksImportRemote || ctx.Err() != nil {
+			err := fmt.Errorf("too many unknown remote statuses to resolve in one import, try importing %s again later", uri)
+			errWithCode := gtserror.NewErrorTooManyRequests(err, err.Error())
+			entries[i].Message = errWithCode.Safe()
+			entries[i].Status = errWithCode.Code()
+			continue
+		}
+
+		errWithCode := p.bookmarkImportDeref(ctx, requestingAccount, uri)
+		if errWithCode != nil {
+			entries[i].Message = errWithCode.Safe()
+			entries[i].Status = errWithCode.Code()
+			continue
+		}
+
+		entries[i].Message = http.StatusText(http.StatusOK)
+		entries[i].Status = http.StatusOK
+	}
+}
+
+// bookmarkImportDeref dereferences the remote status
+// at the given URI, and bookmarks it for requestingAccount
+// if visible.
+func (p *Processor) bookmarkImportDeref(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	uri *url.URL,
+) gtserror.WithCode {
+	status, _, err := p.federator.GetStatusByURI(
+		gtscontext.SetFastFail(ctx),
+		requestingAccount.Username,
+		uri,
+	)
+	if err != nil || status == nil {
+		log.Debugf(ctx, "status %s could not be resolved: %v", uri, err)
+		err := fmt.Errorf("status %s could not be resolved", uri)
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return p.bookmarkStatus(ctx, requestingAccount, status, uri.String())
+}
+
+// bookmarkStatus bookmarks the given status, (given as uriStr
+// by the user), for requestingAccount if visible. It is a no-op
+// if the status is already bookmarked.
+func (p *Processor) bookmarkStatus(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	status *gtsmodel.Status,
+	uriStr string,
+) gtserror.WithCode {
+	visible, err := p.filter.StatusVisible(ctx, requestingAccount, status)
+	if err != nil {
+		err := gtserror.Newf("error checking status visibility: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !visible {
+		err := fmt.Errorf("status %s is not visible to you", uriStr)
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	bookmarkID, err := p.state.DB.GetStatusBookmarkID(ctx, requestingAccount.ID, status.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error checking existing bookmark: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if bookmarkID != "" {
+		// Status is already bookmarked.
+		return nil
+	}
+
+	// Create and store a new bookmark.
+	if err := p.state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+		ID:              id.NewULID(),
+		AccountID:       requestingAccount.ID,
+		Account:         requestingAccount,
+		TargetAccountID: status.AccountID,
+		TargetAccount:   status.Account,
+		StatusID:        status.ID,
+		Status:          status,
+	}); err != nil {
+		err := gtserror.Newf("error putting bookmark in database: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.c.InvalidateTimelinedStatus(ctx, requestingAccount.ID, status.ID); err != nil {
+		err := gtserror.Newf("error invalidating status from timelines: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
 }
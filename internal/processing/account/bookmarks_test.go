@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BookmarksTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *BookmarksTestSuite) csvFileHeader(data string) *multipart.FileHeader {
+	b := new(bytes.Buffer)
+	w := multipart.NewWriter(b)
+
+	fw, err := w.CreateFormFile("data", "bookmarks.csv")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := fw.Write([]byte(data)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(b, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return form.File["data"][0]
+}
+
+func (suite *BookmarksTestSuite) TestBookmarksExport() {
+	records, errWithCode := suite.accountProcessor.BookmarksExport(
+		context.Background(),
+		suite.testAccounts["local_account_1"],
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal([][]string{
+		{suite.testStatuses["admin_account_status_1"].URI},
+	}, records)
+}
+
+func (suite *BookmarksTestSuite) TestBookmarksImport() {
+	var (
+		ctx           = context.Background()
+		requester     = suite.testAccounts["local_account_2"]
+		toBookmark    = suite.testStatuses["admin_account_status_1"]
+		alreadyStatus = suite.testStatuses["local_account_1_status_1"]
+		unresolvable  = "http://localhost:8080/users/admin/statuses/01HQBHCD3PZ6QJJTG6Z7YDVMKS"
+		invalid       = "not a uri"
+		csvData       = toBookmark.URI + "\n" + unresolvable + "\n" + invalid + "\n" + alreadyStatus.URL + "\n"
+	)
+
+	multiStatus, errWithCode := suite.accountProcessor.BookmarksImport(ctx, requester, suite.csvFileHeader(csvData))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(4, multiStatus.Metadata.Total)
+	suite.Equal(2, multiStatus.Metadata.Success)
+	suite.Equal(2, multiStatus.Metadata.Failure)
+
+	suite.Equal(toBookmark.URI, multiStatus.Data[0].Resource)
+	suite.Equal(http.StatusOK, multiStatus.Data[0].Status)
+	suite.Equal(unresolvable, multiStatus.Data[1].Resource)
+	suite.Equal(http.StatusNotFound, multiStatus.Data[1].Status)
+	suite.Equal(invalid, multiStatus.Data[2].Resource)
+	suite.Equal(http.StatusBadRequest, multiStatus.Data[2].Status)
+	suite.Equal(http.StatusOK, multiStatus.Data[3].Status)
+
+	// Both resolvable statuses should now be bookmarked.
+	for _, status := range []string{toBookmark.ID, alreadyStatus.ID} {
+		bookmarkID, err := suite.db.GetStatusBookmarkID(ctx, requester.ID, status)
+		suite.NoError(err)
+		suite.NotEmpty(bookmarkID)
+	}
+}
+
+func (suite *BookmarksTestSuite) TestBookmarksImportRemoteUnknown() {
+	var (
+		ctx          = context.Background()
+		requester    = suite.testAccounts["local_account_2"]
+		resolvable   = "https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839"
+		unresolvable = "https://unknown-instance.com/users/brand_new_person/statuses/01HQBHCD3PZ6QJJTG6Z7YDVMKS"
+	)
+
+	multiStatus, errWithCode := suite.accountProcessor.BookmarksImport(ctx, requester, suite.csvFileHeader(resolvable+"\n"+unresolvable+"\n"))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Unknown remote statuses should be dereferenced
+	// while handling the request, with the result of
+	// each reported back.
+	suite.Equal(1, multiStatus.Metadata.Success)
+	suite.Equal(1, multiStatus.Metadata.Failure)
+	suite.Equal(resolvable, multiStatus.Data[0].Resource)
+	suite.Equal(http.StatusOK, multiStatus.Data[0].Status)
+	suite.Equal(unresolvable, multiStatus.Data[1].Resource)
+	suite.Equal(http.StatusNotFound, multiStatus.Data[1].Status)
+
+	// Resolvable status should now be bookmarked.
+	status, err := suite.db.GetStatusByURI(ctx, resolvable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	bookmarkID, err := suite.db.GetStatusBookmarkID(ctx, requester.ID, status.ID)
+	suite.NoError(err)
+	suite.NotEmpty(bookmarkID)
+}
+
+func (suite *BookmarksTestSuite) TestBookmarksImportTooManyRows() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_2"]
+		csvData   = strings.Repeat(suite.testStatuses["admin_account_status_1"].URI+"\n", 1001)
+	)
+
+	_, errWithCode := suite.accountProcessor.BookmarksImport(ctx, requester, suite.csvFileHeader(csvData))
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestBookmarksTestSuite(t *testing.T) {
+	suite.Run(t, new(BookmarksTestSuite))
+}
@@ -19,13 +19,16 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/oauth2/v4"
@@ -41,6 +44,15 @@ func (p *Processor) Create(
 	app *gtsmodel.Application,
 	form *apimodel.AccountCreateRequest,
 ) (*apimodel.Token, gtserror.WithCode) {
+	var invite *gtsmodel.Invite
+	if form.Invite != "" {
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.getUsableInvite(ctx, form.Invite)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
 	emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, form.Email)
 	if err != nil {
 		err := fmt.Errorf("db error checking email availability: %w", err)
@@ -61,23 +73,52 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorConflict(err, err.Error())
 	}
 
-	// Only store reason if one is required.
-	var reason string
-	if config.GetAccountsReasonRequired() {
-		reason = form.Reason
-	}
-
-	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
+	newSignup := gtsmodel.NewSignup{
 		Username:    form.Username,
 		Email:       form.Email,
 		Password:    form.Password,
-		Reason:      text.SanitizeToPlaintext(reason),
+		Reason:      text.SanitizeToPlaintext(form.Reason), // Stored even if not required, for admins to see.
 		PreApproved: !config.GetAccountsApprovalRequired(), // Mark as approved if no approval required.
 		SignUpIP:    form.IP,
 		Locale:      form.Locale,
 		AppID:       app.ID,
-	})
+	}
+
+	if invite != nil {
+		// Use up the invite now that we know
+		// the signup is otherwise good to go.
+		used, err := p.state.DB.UseInvite(ctx, invite.ID)
+		if err != nil {
+			err := gtserror.Newf("db error using invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !used {
+			// Someone else used it up (or an
+			// admin revoked it) in the meantime.
+			err := errors.New("invite is no longer valid")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		// Invited users skip the approval
+		// queue, but still need to confirm
+		// their email address as usual.
+		newSignup.InviteID = invite.ID
+		newSignup.PreApproved = true
+		newSignup.Admin = invite.Role == gtsmodel.InviteRoleAdmin
+		newSignup.Moderator = invite.Role == gtsmodel.InviteRoleModerator
+	}
+
+	user, err := p.state.DB.NewSignup(ctx, newSignup)
 	if err != nil {
+		if invite != nil {
+			// Signup didn't go through, so give
+			// back the invite use taken above.
+			if err := p.state.DB.ReleaseInvite(ctx, invite.ID); err != nil {
+				log.Errorf(ctx, "db error releasing invite %s: %v", invite.ID, err)
+			}
+		}
+
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -106,3 +147,31 @@ func (p *Processor) Create(
 		CreatedAt:   accessToken.GetAccessCreateAt().Unix(),
 	}, nil
 }
+
+// getUsableInvite gets the invite with the given code,
+// returning a user-facing error if the invite does not
+// exist, has expired, or has been used up.
+func (p *Processor) getUsableInvite(ctx context.Context, code string) (*gtsmodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByCode(ctx, code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		err := errors.New("invite code is not valid")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if invite.Expired() {
+		err := errors.New("invite has expired")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if invite.UsedUp() {
+		err := errors.New("invite has already been used the maximum number of times")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return invite, nil
+}
@@ -57,6 +57,12 @@ func (p *Processor) Delete(
 		l.Errorf("continuing after error during account delete: %v", err)
 	}
 
+	// Purge timelines *before* follows, as we
+	// use follows to find the timelines to purge.
+	if err := p.deleteAccountFromTimelines(ctx, account); err != nil {
+		l.Errorf("continuing after error during account delete: %v", err)
+	}
+
 	if err := p.deleteAccountFollows(ctx, account); err != nil {
 		l.Errorf("continuing after error during account delete: %v", err)
 	}
@@ -74,6 +80,10 @@ func (p *Processor) Delete(
 	}
 
 	if account.IsLocal() {
+		if err := p.deleteAccountArchives(ctx, account); err != nil {
+			l.Errorf("continuing after error during account delete: %v", err)
+		}
+
 		// We delete tokens, applications and clients for
 		// account as one of the last stages during deletion,
 		// as other database models rely on these.
@@ -161,6 +171,50 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 	return nil
 }
 
+// deleteAccountFromTimelines removes any remaining statuses
+// by (or boosting) the given account from the cached home and
+// list timelines of the account's local followers.
+func (p *Processor) deleteAccountFromTimelines(ctx context.Context, account *gtsmodel.Account) error {
+	followers, err := p.state.DB.GetAccountLocalFollowers(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting local followers of account %s: %w", account.ID, err)
+	}
+
+	for _, follow := range followers {
+		// Wipe account from follower's home timeline.
+		if err := p.state.Timelines.Home.WipeItemsFromAccountID(
+			ctx,
+			follow.AccountID,
+			account.ID,
+		); err != nil {
+			log.Errorf(ctx, "error wiping items from home timeline of %s: %v", follow.AccountID, err)
+		}
+
+		// Wipe account from any of follower's
+		// list timelines that include the follow.
+		listEntries, err := p.state.DB.GetListEntriesForFollowID(
+			gtscontext.SetBarebones(ctx),
+			follow.ID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting list entries for follow %s: %v", follow.ID, err)
+			continue
+		}
+
+		for _, listEntry := range listEntries {
+			if err := p.state.Timelines.List.WipeItemsFromAccountID(
+				ctx,
+				listEntry.ListID,
+				account.ID,
+			); err != nil {
+				log.Errorf(ctx, "error wiping items from list timeline %s: %v", listEntry.ListID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // deleteAccountFollows deletes:
 //   - Follows targeting account.
 //   - Follow requests targeting account.
@@ -449,6 +503,11 @@ func (p *Processor) deleteAccountNotifications(ctx context.Context, account *gts
 		return gtserror.Newf("error deleting notifications by account: %w", err)
 	}
 
+	// Delete notification requests and permissions of, or originating from, given account.
+	if err := p.state.DB.DeleteAccountNotificationRequests(ctx, account.ID); err != nil {
+		return gtserror.Newf("error deleting notification requests: %w", err)
+	}
+
 	return nil
 }
 
@@ -477,6 +536,14 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting faves targeting account: %w", err)
 	}
 
+	// Delete all reactions owned by given account.
+	// Reactions targeting the account are deleted
+	// along with its statuses.
+	if err := p.state.DB.DeleteStatusReactionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions by account: %w", err)
+	}
+
 	// TODO: add status mutes here when they're implemented.
 
 	// Delete all poll votes owned by given account.
@@ -526,6 +593,14 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 	account.SuspensionOrigin = origin
 	account.HideCollections = util.Ptr(true)
 	account.EnableRSS = util.Ptr(false)
+	account.RSSIncludeSensitive = util.Ptr(false)
+	account.SuppressFollowRequestNotifications = util.Ptr(false)
+	account.SuppressStatusUpdateNotifications = util.Ptr(false)
+	account.AutoAcceptFollowedBack = util.Ptr(false)
+	account.AutoAcceptLocal = util.Ptr(false)
+	account.AutoAcceptOlderThanDays = 0
+	account.StatusesAutoDeleteAfterDays = 0
+	account.Theme = ""
 
 	return []string{
 		"fetched_at",
@@ -549,6 +624,14 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 		"suspension_origin",
 		"hide_collections",
 		"enable_rss",
+		"rss_include_sensitive",
+		"suppress_follow_request_notifications",
+		"suppress_status_update_notifications",
+		"auto_accept_followed_back",
+		"auto_accept_local",
+		"auto_accept_older_than_days",
+		"statuses_auto_delete_after_days",
+		"theme",
 	}
 }
 
@@ -591,6 +674,10 @@ func stubbifyUser(user *gtsmodel.User) ([]string, error) {
 	user.ConfirmationSentAt = never
 	user.ResetPasswordToken = ""
 	user.ResetPasswordSentAt = never
+	user.DeletionScheduledAt = never
+	user.DeletionCancelToken = ""
+	user.FailedSignInCount = 0
+	user.SignInLockedUntil = never
 
 	return []string{
 		"encrypted_password",
@@ -607,5 +694,9 @@ func stubbifyUser(user *gtsmodel.User) ([]string, error) {
 		"confirmation_sent_at",
 		"reset_password_token",
 		"reset_password_sent_at",
+		"deletion_scheduled_at",
+		"deletion_cancel_token",
+		"failed_sign_in_count",
+		"sign_in_locked_until",
 	}, nil
 }
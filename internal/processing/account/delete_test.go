@@ -25,6 +25,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type AccountDeleteTestSuite struct {
@@ -95,6 +96,44 @@ func (suite *AccountDeleteTestSuite) TestAccountDeleteLocal() {
 	suite.Zero(updatedUser.ConfirmationSentAt)
 	suite.Zero(updatedUser.ResetPasswordToken)
 	suite.Zero(updatedUser.ResetPasswordSentAt)
+	suite.Zero(updatedUser.DeletionScheduledAt)
+	suite.Zero(updatedUser.DeletionCancelToken)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteWipesTimelines() {
+	ctx := context.Background()
+
+	// Zork follows turtle, and has
+	// turtle in one of their lists.
+	var (
+		zork   = suite.testAccounts["local_account_1"]
+		list   = testrig.NewTestLists()["local_account_1_list_1"]
+		status = suite.testStatuses["local_account_2_status_1"]
+	)
+
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+
+	// Get one of turtle's statuses into
+	// zork's home + list timelines.
+	if _, err := suite.state.Timelines.Home.IngestOne(ctx, zork.ID, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := suite.state.Timelines.List.IngestOne(ctx, list.ID, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(1, suite.state.Timelines.Home.GetIndexedLength(ctx, zork.ID))
+	suite.Equal(1, suite.state.Timelines.List.GetIndexedLength(ctx, list.ID))
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, zork.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Turtle's status should be gone from both.
+	suite.Zero(suite.state.Timelines.Home.GetIndexedLength(ctx, zork.ID))
+	suite.Zero(suite.state.Timelines.List.GetIndexedLength(ctx, list.ID))
 }
 
 func TestAccountDeleteTestSuite(t *testing.T) {
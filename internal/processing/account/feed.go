@@ -0,0 +1,295 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/feeds"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// FeedLength is the number of items
+	// included in a feed if no limit is given.
+	FeedLength = 20
+)
+
+// FeedFormat is a syndication format
+// that feeds can be rendered in.
+type FeedFormat interface {
+	// ContentType returns the
+	// MIME type of this format.
+	ContentType() string
+
+	// Render renders the given
+	// feed as a string in this format.
+	Render(feed *feeds.Feed) (string, error)
+}
+
+var (
+	// FeedFormatRSS renders feeds as RSS 2.0.
+	FeedFormatRSS FeedFormat = rssFormat{}
+
+	// FeedFormatAtom renders feeds as Atom 1.0.
+	FeedFormatAtom FeedFormat = atomFormat{}
+
+	// FeedFormatJSON renders feeds as JSON Feed 1.1.
+	FeedFormatJSON FeedFormat = jsonFormat{}
+)
+
+type rssFormat struct{}
+
+func (rssFormat) ContentType() string { return "application/rss+xml" }
+
+func (rssFormat) Render(feed *feeds.Feed) (string, error) { return feed.ToRss() }
+
+type atomFormat struct{}
+
+func (atomFormat) ContentType() string { return "application/atom+xml" }
+
+func (atomFormat) Render(feed *feeds.Feed) (string, error) { return feed.ToAtom() }
+
+type jsonFormat struct{}
+
+func (jsonFormat) ContentType() string { return "application/feed+json" }
+
+func (jsonFormat) Render(feed *feeds.Feed) (string, error) { return feed.ToJSON() }
+
+type GetFeed func() (string, gtserror.WithCode)
+
+// GetFeedForUsername returns a function to return the feed of a local account
+// with the given username in the given format, and the last-modified time (time
+// that the account last posted a status eligible to be included in the feed).
+//
+// To save db calls, callers to this function should only call the returned GetFeed
+// func if the last-modified time is newer than the last-modified time they have cached.
+//
+// If the account has not yet posted a feed-eligible status, the returned last-modified
+// time will be zero, and the GetFeed func will return a valid feed with no items.
+//
+// The feed will contain at most limit items, which should be
+// between 1 and the configured instance-rss-max-items.
+func (p *Processor) GetFeedForUsername(ctx context.Context, username string, format FeedFormat, limit int) (GetFeed, time.Time, gtserror.WithCode) {
+	var (
+		never = time.Time{}
+	)
+
+	account, err := p.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Simply no account with this username.
+			err = gtserror.New("account not found")
+			return nil, never, gtserror.NewErrorNotFound(err)
+		}
+
+		// Real db error.
+		err = gtserror.Newf("db error getting account %s: %w", username, err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	// Ensure account has feeds enabled.
+	if !*account.EnableRSS {
+		err = gtserror.New("account RSS feed not enabled")
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	// LastModified time is needed by callers to check freshness for cacheing.
+	// This might be a zero time.Time if account has never posted a status that's
+	// eligible to appear in the feed; that's fine.
+	lastPostAt, err := p.state.DB.GetAccountLastPosted(ctx, account.ID, true)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting account %s last posted: %w", username, err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	return func() (string, gtserror.WithCode) {
+		// Assemble author namestring once only.
+		author := "@" + account.Username + "@" + config.GetAccountDomain()
+
+		// Derive image/thumbnail for this account (may be nil).
+		image, errWithCode := p.feedImageForAccount(ctx, account, author)
+		if errWithCode != nil {
+			return "", errWithCode
+		}
+
+		feed := &feeds.Feed{
+			Title:       "Posts from " + author,
+			Description: "Posts from " + author,
+			Link:        &feeds.Link{Href: account.URL},
+			Image:       image,
+		}
+
+		// If the account has never posted anything, just use
+		// account creation time as Updated value for the feed;
+		// we could use time.Now() here but this would likely
+		// mess up cacheing; we want something determinate.
+		//
+		// We can also return early rather than wasting a db call,
+		// since we already know there's no eligible statuses.
+		if lastPostAt.IsZero() {
+			feed.Updated = account.CreatedAt
+			return renderFeed(feed, format)
+		}
+
+		// Account has posted at least one status that's
+		// eligible to appear in the feed.
+		//
+		// Reuse the lastPostAt value for feed.Updated.
+		feed.Updated = lastPostAt
+
+		// Retrieve latest statuses as they'd be shown on the web view of the
+		// account profile, leaving out sensitive ones unless account opted in.
+		includeSensitive := util.PtrValueOr(account.RSSIncludeSensitive, false)
+		statuses, err := p.state.DB.GetAccountRSSStatuses(ctx, account.ID, includeSensitive, limit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("db error getting account rss statuses: %w", err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		if errWithCode := p.addFeedItems(ctx, feed, statuses); errWithCode != nil {
+			return "", errWithCode
+		}
+
+		return renderFeed(feed, format)
+	}, lastPostAt, nil
+}
+
+// GetInstanceFeed returns a function to return the instance-wide feed
+// of public posts by local accounts that have enabled RSS, in the given
+// format, and the last-modified time of the feed, as with GetFeedForUsername.
+//
+// The instance feed must be enabled with instance-expose-public-rss.
+func (p *Processor) GetInstanceFeed(ctx context.Context, format FeedFormat, limit int) (GetFeed, time.Time, gtserror.WithCode) {
+	var never = time.Time{}
+
+	if !config.GetInstanceExposePublicRSS() {
+		err := gtserror.New("instance feed not enabled")
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		err = gtserror.Newf("db error getting instance: %w", err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	// Unlike for accounts there's no cheap way of getting
+	// the last-modified time, so fetch the statuses here
+	// and use the time of the latest one.
+	statuses, err := p.state.DB.GetLocalRSSStatuses(ctx, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting local rss statuses: %w", err)
+		return nil, never, gtserror.NewErrorInternalError(err)
+	}
+
+	var lastPostAt time.Time
+	if len(statuses) > 0 {
+		lastPostAt = statuses[0].CreatedAt
+	}
+
+	return func() (string, gtserror.WithCode) {
+		title := "Posts from " + instance.Title
+		if instance.Title == "" {
+			title = "Posts from " + config.GetHost()
+		}
+
+		feed := &feeds.Feed{
+			Title:       title,
+			Description: title,
+			Link:        &feeds.Link{Href: instance.URI},
+		}
+
+		// As with account feeds, fall back to a
+		// determinate time if there are no statuses.
+		feed.Updated = lastPostAt
+		if lastPostAt.IsZero() {
+			feed.Updated = instance.CreatedAt
+		}
+
+		if errWithCode := p.addFeedItems(ctx, feed, statuses); errWithCode != nil {
+			return "", errWithCode
+		}
+
+		return renderFeed(feed, format)
+	}, lastPostAt, nil
+}
+
+// addFeedItems converts each of the given statuses to a
+// feed item, and adds them to the feed in the given order.
+func (p *Processor) addFeedItems(ctx context.Context, feed *feeds.Feed, statuses []*gtsmodel.Status) gtserror.WithCode {
+	for _, status := range statuses {
+		item, err := p.converter.StatusToRSSItem(ctx, status)
+		if err != nil {
+			err = gtserror.Newf("error converting status to feed item: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		feed.Add(item)
+	}
+
+	return nil
+}
+
+func (p *Processor) feedImageForAccount(ctx context.Context, account *gtsmodel.Account, author string) (*feeds.Image, gtserror.WithCode) {
+	if account.AvatarMediaAttachmentID == "" {
+		// No image, no problem!
+		return nil, nil
+	}
+
+	// Ensure account avatar attachment populated.
+	if account.AvatarMediaAttachment == nil {
+		var err error
+		account.AvatarMediaAttachment, err = p.state.DB.GetAttachmentByID(ctx, account.AvatarMediaAttachmentID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// No attachment found with this ID (race condition?).
+				return nil, nil
+			}
+
+			// Real db error.
+			err = gtserror.Newf("db error fetching avatar media attachment: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return &feeds.Image{
+		Url:   account.AvatarMediaAttachment.Thumbnail.URL,
+		Title: "Avatar for " + author,
+		Link:  account.URL,
+	}, nil
+}
+
+func renderFeed(feed *feeds.Feed, format FeedFormat) (string, gtserror.WithCode) {
+	// Render the feed. Even with no statuses,
+	// this will still produce a valid feed.
+	rendered, err := format.Render(feed)
+	if err != nil {
+		err := gtserror.Newf("error rendering feed as %s: %w", format.ContentType(), err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return rendered, nil
+}
@@ -20,9 +20,12 @@ package account_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type GetRSSTestSuite struct {
@@ -30,7 +33,7 @@ type GetRSSTestSuite struct {
 }
 
 func (suite *GetRSSTestSuite) TestGetAccountRSSAdmin() {
-	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(context.Background(), "admin")
+	getFeed, lastModified, err := suite.accountProcessor.GetFeedForUsername(context.Background(), "admin", account.FeedFormatRSS, account.FeedLength)
 	suite.NoError(err)
 	suite.EqualValues(1634733405, lastModified.Unix())
 
@@ -39,11 +42,21 @@ func (suite *GetRSSTestSuite) TestGetAccountRSSAdmin() {
 
 	fmt.Println(feed)
 
-	suite.Equal("<?xml version=\"1.0\" encoding=\"UTF-8\"?><rss version=\"2.0\" xmlns:content=\"http://purl.org/rss/1.0/modules/content/\">\n  <channel>\n    <title>Posts from @admin@localhost:8080</title>\n    <link>http://localhost:8080/@admin</link>\n    <description>Posts from @admin@localhost:8080</description>\n    <pubDate>Wed, 20 Oct 2021 12:36:45 +0000</pubDate>\n    <lastBuildDate>Wed, 20 Oct 2021 12:36:45 +0000</lastBuildDate>\n    <item>\n      <title>open to see some puppies</title>\n      <link>http://localhost:8080/@admin/statuses/01F8MHAAY43M6RJ473VQFCVH37</link>\n      <description>@admin@localhost:8080 made a new post: &#34;🐕🐕🐕🐕🐕&#34;</description>\n      <content:encoded><![CDATA[🐕🐕🐕🐕🐕]]></content:encoded>\n      <author>@admin@localhost:8080</author>\n      <guid>http://localhost:8080/@admin/statuses/01F8MHAAY43M6RJ473VQFCVH37</guid>\n      <pubDate>Wed, 20 Oct 2021 12:36:45 +0000</pubDate>\n      <source>http://localhost:8080/@admin/feed.rss</source>\n    </item>\n    <item>\n      <title>hello world! #welcome ! first post on the instance :rainbow: !</title>\n      <link>http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R</link>\n      <description>@admin@localhost:8080 posted 1 attachment: &#34;hello world! #welcome ! first post on the instance :rainbow: !&#34;</description>\n      <content:encoded><![CDATA[hello world! #welcome ! first post on the instance <img src=\"http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png\" title=\":rainbow:\" alt=\":rainbow:\" width=\"25\" height=\"25\"/> !]]></content:encoded>\n      <author>@admin@localhost:8080</author>\n      <enclosure url=\"http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg\" length=\"62529\" type=\"image/jpeg\"></enclosure>\n      <guid>http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R</guid>\n      <pubDate>Wed, 20 Oct 2021 11:36:45 +0000</pubDate>\n      <source>http://localhost:8080/@admin/feed.rss</source>\n    </item>\n  </channel>\n</rss>", feed)
+	suite.Equal("<?xml version=\"1.0\" encoding=\"UTF-8\"?><rss version=\"2.0\" xmlns:content=\"http://purl.org/rss/1.0/modules/content/\">\n  <channel>\n    <title>Posts from @admin@localhost:8080</title>\n    <link>http://localhost:8080/@admin</link>\n    <description>Posts from @admin@localhost:8080</description>\n    <pubDate>Wed, 20 Oct 2021 12:36:45 +0000</pubDate>\n    <lastBuildDate>Wed, 20 Oct 2021 12:36:45 +0000</lastBuildDate>\n    <item>\n      <title>hello world! #welcome ! first post on the instance :rainbow: !</title>\n      <link>http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R</link>\n      <description>@admin@localhost:8080 posted 1 attachment: &#34;hello world! #welcome ! first post on the instance :rainbow: !&#34;</description>\n      <content:encoded><![CDATA[hello world! #welcome ! first post on the instance <img src=\"http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png\" title=\":rainbow:\" alt=\":rainbow:\" width=\"25\" height=\"25\"/> !]]></content:encoded>\n      <author>@admin@localhost:8080</author>\n      <enclosure url=\"http://localhost:8080/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg\" length=\"62529\" type=\"image/jpeg\"></enclosure>\n      <guid>http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R</guid>\n      <pubDate>Wed, 20 Oct 2021 11:36:45 +0000</pubDate>\n      <source>http://localhost:8080/@admin/feed.rss</source>\n    </item>\n  </channel>\n</rss>", feed)
 }
 
 func (suite *GetRSSTestSuite) TestGetAccountRSSZork() {
-	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(context.Background(), "the_mighty_zork")
+	ctx := context.Background()
+
+	// Zork's posts are all marked sensitive,
+	// so opt in to including them in the feed.
+	zork := suite.testAccounts["local_account_1"]
+	zork.RSSIncludeSensitive = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, zork, "rss_include_sensitive"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	getFeed, lastModified, err := suite.accountProcessor.GetFeedForUsername(ctx, "the_mighty_zork", account.FeedFormatRSS, account.FeedLength)
 	suite.NoError(err)
 	suite.EqualValues(1702200240, lastModified.Unix())
 
@@ -71,7 +84,7 @@ func (suite *GetRSSTestSuite) TestGetAccountRSSZorkNoPosts() {
 		}
 	}
 
-	getFeed, lastModified, err := suite.accountProcessor.GetRSSFeedForUsername(ctx, "the_mighty_zork")
+	getFeed, lastModified, err := suite.accountProcessor.GetFeedForUsername(ctx, "the_mighty_zork", account.FeedFormatRSS, account.FeedLength)
 	suite.NoError(err)
 	suite.Empty(lastModified)
 
@@ -83,6 +96,74 @@ func (suite *GetRSSTestSuite) TestGetAccountRSSZorkNoPosts() {
 	suite.Equal("<?xml version=\"1.0\" encoding=\"UTF-8\"?><rss version=\"2.0\" xmlns:content=\"http://purl.org/rss/1.0/modules/content/\">\n  <channel>\n    <title>Posts from @the_mighty_zork@localhost:8080</title>\n    <link>http://localhost:8080/@the_mighty_zork</link>\n    <description>Posts from @the_mighty_zork@localhost:8080</description>\n    <pubDate>Fri, 20 May 2022 11:09:18 +0000</pubDate>\n    <lastBuildDate>Fri, 20 May 2022 11:09:18 +0000</lastBuildDate>\n    <image>\n      <url>http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg</url>\n      <title>Avatar for @the_mighty_zork@localhost:8080</title>\n      <link>http://localhost:8080/@the_mighty_zork</link>\n    </image>\n  </channel>\n</rss>", feed)
 }
 
+func (suite *GetRSSTestSuite) TestGetAccountRSSZorkSensitiveExcluded() {
+	getFeed, _, err := suite.accountProcessor.GetFeedForUsername(context.Background(), "the_mighty_zork", account.FeedFormatRSS, account.FeedLength)
+	suite.NoError(err)
+
+	feed, err := getFeed()
+	suite.NoError(err)
+
+	// Zork hasn't opted in to including
+	// sensitive posts, which all of theirs are.
+	suite.NotContains(feed, "<item>")
+}
+
+func (suite *GetRSSTestSuite) TestGetAccountRSSLimit() {
+	ctx := context.Background()
+
+	zork := suite.testAccounts["local_account_1"]
+	zork.RSSIncludeSensitive = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, zork, "rss_include_sensitive"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	getFeed, _, err := suite.accountProcessor.GetFeedForUsername(ctx, "the_mighty_zork", account.FeedFormatRSS, 1)
+	suite.NoError(err)
+
+	feed, err := getFeed()
+	suite.NoError(err)
+
+	suite.Equal(1, strings.Count(feed, "<item>"))
+	suite.Contains(feed, "<title>HTML in post</title>")
+}
+
+func (suite *GetRSSTestSuite) TestGetInstanceRSS() {
+	getFeed, lastModified, err := suite.accountProcessor.GetInstanceFeed(context.Background(), account.FeedFormatRSS, account.FeedLength)
+	suite.NoError(err)
+	suite.EqualValues(1634729805, lastModified.Unix())
+
+	feed, err := getFeed()
+	suite.NoError(err)
+
+	// Only admin's non-sensitive post should be included:
+	// sensitive posts are always left out of this feed,
+	// and local_account_2 hasn't enabled RSS at all.
+	suite.Equal(1, strings.Count(feed, "<item>"))
+	suite.Contains(feed, "<guid>http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R</guid>")
+}
+
+func (suite *GetRSSTestSuite) TestGetAtomFeed() {
+	getFeed, _, err := suite.accountProcessor.GetFeedForUsername(context.Background(), "admin", account.FeedFormatAtom, account.FeedLength)
+	suite.NoError(err)
+
+	feed, err := getFeed()
+	suite.NoError(err)
+	suite.Contains(feed, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	suite.Equal(1, strings.Count(feed, "<entry>"))
+	suite.Equal("application/atom+xml", account.FeedFormatAtom.ContentType())
+}
+
+func (suite *GetRSSTestSuite) TestGetJSONFeed() {
+	getFeed, _, err := suite.accountProcessor.GetFeedForUsername(context.Background(), "admin", account.FeedFormatJSON, account.FeedLength)
+	suite.NoError(err)
+
+	feed, err := getFeed()
+	suite.NoError(err)
+	suite.Contains(feed, `"version": "https://jsonfeed.org/version/1.1"`)
+	suite.Contains(feed, `"id": "http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R"`)
+	suite.Equal("application/feed+json", account.FeedFormatJSON.ContentType())
+}
+
 func TestGetRSSTestSuite(t *testing.T) {
 	suite.Run(t, new(GetRSSTestSuite))
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
@@ -103,17 +104,35 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if targetAccount.IsLocal() && !*targetAccount.Locked {
-		// If the target account is local and not locked,
-		// we can already accept the follow request and
-		// skip any further processing.
-		//
+	// If the target account is local and not locked, or is
+	// locked but one of its auto-accept rules matches, we can
+	// already accept the follow request and skip any further
+	// processing.
+	accept := targetAccount.IsLocal() && !*targetAccount.Locked
+	if targetAccount.IsLocal() && *targetAccount.Locked {
+		accept, err = p.FollowRequestAutoAccept(ctx, fr)
+		if err != nil {
+			log.Errorf(ctx, "error checking follow request auto-accept rules: %v", err)
+		}
+	}
+
+	if accept {
 		// Because we know the requestingAccount is also
 		// local, we don't need to federate the accept out.
-		if _, err := p.state.DB.AcceptFollowRequest(ctx, requestingAccount.ID, form.ID); err != nil {
-			err = gtserror.Newf("error accepting follow request for local unlocked account: %w", err)
+		follow, err := p.state.DB.AcceptFollowRequest(ctx, requestingAccount.ID, form.ID)
+		if err != nil {
+			err = gtserror.Newf("error accepting follow request for local account: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
+
+		// Update follow counts. This follow
+		// won't be seen by the client worker.
+		if err := p.state.DB.UpdateAccountStatsFollows(ctx, follow.AccountID, 0, 1); err != nil {
+			log.Errorf(ctx, "db error updating account stats: %v", err)
+		}
+		if err := p.state.DB.UpdateAccountStatsFollows(ctx, follow.TargetAccountID, 1, 0); err != nil {
+			log.Errorf(ctx, "db error updating account stats: %v", err)
+		}
 	} else if targetAccount.IsRemote() {
 		// Otherwise we leave the follow request as it is,
 		// and we handle the rest of the process async.
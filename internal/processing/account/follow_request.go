@@ -20,14 +20,17 @@ package account
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // FollowRequestAccept handles the accepting of a follow request from the sourceAccountID to the requestingAccount (the currently authorized account).
@@ -98,18 +101,34 @@ func (p *Processor) FollowRequestsGet(ctx context.Context, requestingAccount *gt
 	lo := followRequests[count-1].ID
 	hi := followRequests[0].ID
 
-	// Func to fetch follow source at index.
-	getIdx := func(i int) *gtsmodel.Account {
-		return followRequests[i].Account
+	items := make([]interface{}, 0, count)
+	for _, followRequest := range followRequests {
+		if followRequest.Account == nil {
+			// Requester may have
+			// been deleted meanwhile.
+			continue
+		}
+
+		// Check whether requester is visible to requesting account.
+		visible, err := p.filter.AccountVisible(ctx, requestingAccount, followRequest.Account)
+		if err != nil {
+			log.Errorf(ctx, "error checking account visibility: %v", err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		item, err := p.converter.FollowRequestToAPIAccount(ctx, followRequest)
+		if err != nil {
+			log.Errorf(ctx, "error converting follow request: %v", err)
+			continue
+		}
+
+		items = append(items, item)
 	}
 
-	// Get a filtered slice of public API account models.
-	items := p.c.GetVisibleAPIAccountsPaged(ctx,
-		requestingAccount,
-		getIdx,
-		count,
-	)
-
 	return paging.PackageResponse(paging.ResponseParams{
 		Items: items,
 		Path:  "/api/v1/follow_requests",
@@ -117,3 +136,49 @@ func (p *Processor) FollowRequestsGet(ctx context.Context, requestingAccount *gt
 		Prev:  page.Prev(lo, hi),
 	}), nil
 }
+
+// FollowRequestAutoAccept returns whether the given follow request
+// targeting a local account matches one of that account's follow
+// request auto-accept rules, ie., whether it can be accepted without
+// waiting for the target to review it. Requests to remote accounts
+// never match.
+func (p *Processor) FollowRequestAutoAccept(ctx context.Context, followReq *gtsmodel.FollowRequest) (bool, error) {
+	// Ensure requester and target are set.
+	if err := p.state.DB.PopulateFollowRequest(ctx, followReq); err != nil {
+		return false, gtserror.Newf("error populating follow request %s: %w", followReq.ID, err)
+	}
+
+	requester := followReq.Account
+	target := followReq.TargetAccount
+
+	if target.IsRemote() {
+		// Not our
+		// rules to apply.
+		return false, nil
+	}
+
+	if util.PtrValueOr(target.AutoAcceptLocal, false) &&
+		requester.IsLocal() {
+		return true, nil
+	}
+
+	if days := target.AutoAcceptOlderThanDays; days > 0 {
+		minAge := time.Duration(days) * 24 * time.Hour
+		if time.Since(requester.CreatedAt) > minAge {
+			return true, nil
+		}
+	}
+
+	if util.PtrValueOr(target.AutoAcceptFollowedBack, false) {
+		following, err := p.state.DB.IsFollowing(ctx, target.ID, requester.ID)
+		if err != nil {
+			return false, gtserror.Newf("error checking follow: %w", err)
+		}
+
+		if following {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
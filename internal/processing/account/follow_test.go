@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -130,6 +131,78 @@ func (suite *FollowTestSuite) TestUpdateExistingFollowSetNothing() {
 	suite.False(relationship.Notifying)
 }
 
+func (suite *FollowTestSuite) TestFollowingGetHidden() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_1"]
+
+	// Hide target account's collections.
+	targetAccount.HideCollections = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, targetAccount, "hide_collections"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Other accounts should see nothing.
+	resp, errWithCode := suite.accountProcessor.FollowingGet(ctx, requestingAccount, targetAccount.ID, nil)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Items)
+
+	resp, errWithCode = suite.accountProcessor.FollowersGet(ctx, requestingAccount, targetAccount.ID, nil)
+	suite.NoError(errWithCode)
+	suite.Empty(resp.Items)
+
+	// But the account itself should
+	// still see who it's following.
+	resp, errWithCode = suite.accountProcessor.FollowingGet(ctx, targetAccount, targetAccount.ID, nil)
+	suite.NoError(errWithCode)
+	suite.Len(resp.Items, 2)
+}
+
+func (suite *FollowTestSuite) TestFollowLockedAutoAcceptLocal() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_2"]
+
+	// Target is locked, but accepts
+	// requests from local accounts.
+	targetAccount.AutoAcceptLocal = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, targetAccount, "auto_accept_local"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{
+		ID: targetAccount.ID,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Request should have been
+	// accepted straight away.
+	suite.True(relationship.Following)
+	suite.False(relationship.Requested)
+}
+
+func (suite *FollowTestSuite) TestFollowLockedNoAutoAcceptRule() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{
+		ID: targetAccount.ID,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// No rules set, request
+	// should remain pending.
+	suite.False(relationship.Following)
+	suite.True(relationship.Requested)
+}
+
 func TestFollowTestS(t *testing.T) {
 	suite.Run(t, new(FollowTestSuite))
 }
@@ -24,11 +24,13 @@ import (
 	"net/url"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 // Get processes the given request for account information.
@@ -67,6 +69,12 @@ func (p *Processor) GetCustomCSSForUsername(ctx context.Context, username string
 		return "", gtserror.NewErrorInternalError(fmt.Errorf("db error: %w", err))
 	}
 
+	if !config.GetAccountsCustomCSSAllowRemoteImports() {
+		// CSS may have been stored before remote
+		// imports were forbidden, so strip them here too.
+		customCSS, _ = text.StripCSSRemoteImports(customCSS, config.GetHost(), config.GetAccountDomain())
+	}
+
 	return customCSS, nil
 }
 
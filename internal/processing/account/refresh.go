@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Refresh forces a refresh of the target account from its
+// remote instance, regardless of when it was last fetched,
+// and returns the updated public account model.
+//
+// Local accounts are returned as-is. Requests are rate
+// limited per requester, to avoid hammering remotes.
+func (p *Processor) Refresh(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	if errWithCode := p.c.RefreshAllowed(ctx, requester); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	target, errWithCode := p.c.GetVisibleTargetAccount(ctx, requester, targetID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !target.IsLocal() {
+		latest, _, err := p.federator.RefreshAccount(ctx,
+			requester.Username,
+			target,
+			nil,
+			dereferencing.Now,
+		)
+		if err != nil {
+			// Just log the error and fall back
+			// to returning what we have stored.
+			log.Errorf(ctx, "error refreshing account %s: %v", target.URI, err)
+		} else {
+			target = latest
+		}
+	}
+
+	apiAcct, err := p.converter.AccountToAPIAccountPublic(ctx, target)
+	if err != nil {
+		err := gtserror.Newf("error converting account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAcct, nil
+}
@@ -26,16 +26,23 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // FollowersGet fetches a list of the target account's followers.
 func (p *Processor) FollowersGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, page *paging.Page) (*apimodel.PageableResponse, gtserror.WithCode) {
 	// Fetch target account to check it exists, and visibility of requester->target.
-	_, errWithCode := p.c.GetVisibleTargetAccount(ctx, requestingAccount, targetAccountID)
+	targetAccount, errWithCode := p.c.GetVisibleTargetAccount(ctx, requestingAccount, targetAccountID)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
+	if collectionsHidden(requestingAccount, targetAccount) {
+		// Only the account owner
+		// may see hidden followers.
+		return paging.EmptyResponse(), nil
+	}
+
 	follows, err := p.state.DB.GetAccountFollowers(ctx, targetAccountID, page)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err = gtserror.Newf("db error getting followers: %w", err)
@@ -76,11 +83,17 @@ func (p *Processor) FollowersGet(ctx context.Context, requestingAccount *gtsmode
 // FollowingGet fetches a list of the accounts that target account is following.
 func (p *Processor) FollowingGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, page *paging.Page) (*apimodel.PageableResponse, gtserror.WithCode) {
 	// Fetch target account to check it exists, and visibility of requester->target.
-	_, errWithCode := p.c.GetVisibleTargetAccount(ctx, requestingAccount, targetAccountID)
+	targetAccount, errWithCode := p.c.GetVisibleTargetAccount(ctx, requestingAccount, targetAccountID)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
+	if collectionsHidden(requestingAccount, targetAccount) {
+		// Only the account owner
+		// may see hidden follows.
+		return paging.EmptyResponse(), nil
+	}
+
 	// Fetch known accounts that follow given target account ID.
 	follows, err := p.state.DB.GetAccountFollows(ctx, targetAccountID, page)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -119,6 +132,16 @@ func (p *Processor) FollowingGet(ctx context.Context, requestingAccount *gtsmode
 	}), nil
 }
 
+// collectionsHidden returns whether the followers / following
+// of target account should be hidden from requesting account,
+// i.e. target has hidden them and requester isn't target.
+func collectionsHidden(requestingAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) bool {
+	if !util.PtrValueOr(targetAccount.HideCollections, false) {
+		return false
+	}
+	return requestingAccount == nil || requestingAccount.ID != targetAccount.ID
+}
+
 // RelationshipGet returns a relationship model describing the relationship of the targetAccount to the Authed account.
 func (p *Processor) RelationshipGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	if requestingAccount == nil {
@@ -137,3 +160,28 @@ func (p *Processor) RelationshipGet(ctx context.Context, requestingAccount *gtsm
 
 	return r, nil
 }
+
+// RelationshipsGet returns relationship models describing the relationship of each of the
+// targetAccountIDs to the Authed account, in the same order as the given IDs. Unknown IDs
+// produce a relationship with everything set to false rather than an error.
+func (p *Processor) RelationshipsGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountIDs []string) ([]*apimodel.Relationship, gtserror.WithCode) {
+	if requestingAccount == nil {
+		return nil, gtserror.NewErrorForbidden(gtserror.New("not authed"))
+	}
+
+	gtsRs, err := p.state.DB.GetRelationships(ctx, requestingAccount.ID, targetAccountIDs)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(gtserror.Newf("error getting relationships: %s", err))
+	}
+
+	rs := make([]*apimodel.Relationship, 0, len(gtsRs))
+	for _, gtsR := range gtsRs {
+		r, err := p.converter.RelationshipToAPIRelationship(ctx, gtsR)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(gtserror.Newf("error converting relationship: %s", err))
+		}
+		rs = append(rs, r)
+	}
+
+	return rs, nil
+}
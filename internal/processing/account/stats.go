@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// regenerateStatsEvery is the frequency at
+	// which stale account stats are regenerated.
+	regenerateStatsEvery = time.Hour
+
+	// regenerateStatsAfter is the age after
+	// which account stats are considered stale,
+	// and may have drifted from the real counts.
+	regenerateStatsAfter = 24 * time.Hour
+
+	// regenerateStatsLimit is the maximum number
+	// of stale account stats regenerated per run.
+	regenerateStatsLimit = 500
+)
+
+// ScheduleStatsRegeneration schedules stale account stats
+// to be periodically regenerated from scratch, in order to
+// correct any drift from their incremental updates.
+func (p *Processor) ScheduleStatsRegeneration() error {
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@accountstats",
+		time.Now(),
+		regenerateStatsEvery,
+		func(ctx context.Context, now time.Time) {
+			if err := p.RegenerateStaleStats(ctx, now); err != nil {
+				log.Errorf(ctx, "error regenerating account stats: %v", err)
+			}
+		},
+	) {
+		return gtserror.New("failed to schedule @accountstats")
+	}
+
+	return nil
+}
+
+// RegenerateStaleStats regenerates the oldest account stats
+// which were last regenerated before the stale threshold.
+func (p *Processor) RegenerateStaleStats(ctx context.Context, now time.Time) error {
+	stale, err := p.state.DB.GetAccountStatsRegeneratedBefore(ctx,
+		now.Add(-regenerateStatsAfter),
+		regenerateStatsLimit,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting stale account stats: %w", err)
+	}
+
+	for _, stats := range stale {
+		if _, err := p.state.DB.RegenerateAccountStats(ctx, stats.AccountID); err != nil {
+			log.Errorf(ctx, "error regenerating stats for account %s: %v", stats.AccountID, err)
+		}
+	}
+
+	return nil
+}
@@ -132,11 +132,14 @@ func (p *Processor) StatusesGet(
 
 // WebStatusesGet fetches a number of statuses (in descending order)
 // from the given account. It selects only statuses which are suitable
-// for showing on the public web profile of an account.
+// for showing on the public web profile of an account. If mediaOnly
+// is true, only statuses with attachments will be returned, and
+// next links will point to the media tab of the web profile.
 func (p *Processor) WebStatusesGet(
 	ctx context.Context,
 	targetAccountID string,
 	maxID string,
+	mediaOnly bool,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	account, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
 	if err != nil {
@@ -152,7 +155,7 @@ func (p *Processor) WebStatusesGet(
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
-	statuses, err := p.state.DB.GetAccountWebStatuses(ctx, targetAccountID, 10, maxID)
+	statuses, err := p.state.DB.GetAccountWebStatuses(ctx, targetAccountID, 10, maxID, mediaOnly)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -180,9 +183,14 @@ func (p *Processor) WebStatusesGet(
 		items = append(items, item)
 	}
 
+	path := "/@" + account.Username
+	if mediaOnly {
+		path += "/media"
+	}
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
 		Items:          items,
-		Path:           "/@" + account.Username,
+		Path:           path,
 		NextMaxIDValue: nextMaxIDValue,
 	})
 }
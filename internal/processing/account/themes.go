@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	themesPathPrefix = "/assets/themes"
+	themeTitleKey    = "theme-title:"
+	themeDescKey     = "theme-description:"
+)
+
+// Themes contains the web themes that were
+// found in [web-assets-base-dir]/themes at startup.
+type Themes struct {
+	// Themes keyed by their file name.
+	ByFileName map[string]*apimodel.Theme
+
+	// Themes sorted alphabetically by title.
+	SortedByTitle []*apimodel.Theme
+}
+
+// PopulateThemes reads the themes directory of the web
+// assets, and returns the themes found there. Any
+// *.css file in that directory is taken to be a theme;
+// the title and description of each theme are read
+// from "theme-title:" and "theme-description:" lines
+// in the leading comment of the file, if present.
+func PopulateThemes() *Themes {
+	themes := &Themes{
+		ByFileName: make(map[string]*apimodel.Theme),
+	}
+
+	webAssetsAbsFilePath, err := filepath.Abs(config.GetWebAssetBaseDir())
+	if err != nil {
+		log.Warnf(nil, "error getting abs path for web assets: %v", err)
+		return themes
+	}
+
+	themesAbsFilePath := filepath.Join(webAssetsAbsFilePath, "themes")
+	themeFiles, err := os.ReadDir(themesAbsFilePath)
+	if err != nil {
+		log.Warnf(nil, "error reading themes at %s: %v", themesAbsFilePath, err)
+		return themes
+	}
+
+	for _, f := range themeFiles {
+		// Ignore nested directories.
+		if f.IsDir() {
+			continue
+		}
+
+		fileName := f.Name()
+		if !strings.EqualFold(filepath.Ext(fileName), ".css") {
+			continue
+		}
+
+		theme, err := parseTheme(filepath.Join(themesAbsFilePath, fileName))
+		if err != nil {
+			log.Warnf(nil, "error reading theme %s: %v", fileName, err)
+			continue
+		}
+
+		themes.ByFileName[fileName] = theme
+		themes.SortedByTitle = append(themes.SortedByTitle, theme)
+	}
+
+	slices.SortFunc(themes.SortedByTitle, func(a, b *apimodel.Theme) int {
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	})
+
+	return themes
+}
+
+// parseTheme reads the title and description of
+// the theme stylesheet at the given path. If the
+// stylesheet doesn't declare a title, the file
+// name without extension is used instead.
+func parseTheme(path string) (*apimodel.Theme, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	fileName := filepath.Base(path)
+	theme := &apimodel.Theme{
+		Title:    strings.TrimSuffix(fileName, filepath.Ext(fileName)),
+		FileName: fileName,
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, themeTitleKey):
+			theme.Title = strings.TrimSpace(strings.TrimPrefix(line, themeTitleKey))
+
+		case strings.HasPrefix(line, themeDescKey):
+			theme.Description = strings.TrimSpace(strings.TrimPrefix(line, themeDescKey))
+
+		case strings.Contains(line, "*/"):
+			// End of the leading
+			// comment, stop here.
+			return theme, nil
+		}
+	}
+
+	return theme, scanner.Err()
+}
+
+// ThemesGet returns the web themes available
+// on this instance, sorted by title.
+func (p *Processor) ThemesGet() []apimodel.Theme {
+	themes := make([]apimodel.Theme, 0, len(p.themes.SortedByTitle))
+	for _, theme := range p.themes.SortedByTitle {
+		themes = append(themes, *theme)
+	}
+	return themes
+}
+
+// ThemeStylesheet returns the stylesheet path of the given theme
+// file name, or an empty string if no such theme is available.
+// Callers should treat an empty return value as "use the default".
+func (p *Processor) ThemeStylesheet(fileName string) string {
+	if _, ok := p.themes.ByFileName[fileName]; !ok {
+		return ""
+	}
+	return themesPathPrefix + "/" + fileName
+}
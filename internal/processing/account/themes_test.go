@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ThemesTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ThemesTestSuite) TestThemesGet() {
+	themes := suite.accountProcessor.ThemesGet()
+
+	titles := make([]string, 0, len(themes))
+	for _, theme := range themes {
+		titles = append(titles, theme.Title)
+	}
+	suite.Equal([]string{"Forest", "High contrast", "Light", "Midnight"}, titles)
+
+	suite.Equal("light.css", themes[2].FileName)
+	suite.Equal("Light backgrounds with dark text and blue accents.", themes[2].Description)
+}
+
+func (suite *ThemesTestSuite) TestThemeStylesheet() {
+	suite.Equal("/assets/themes/light.css", suite.accountProcessor.ThemeStylesheet("light.css"))
+
+	// Unknown or empty themes should
+	// fall back to the default.
+	suite.Empty(suite.accountProcessor.ThemeStylesheet("removed.css"))
+	suite.Empty(suite.accountProcessor.ThemeStylesheet(""))
+}
+
+func TestThemesTestSuite(t *testing.T) {
+	suite.Run(t, new(ThemesTestSuite))
+}
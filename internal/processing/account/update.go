@@ -197,24 +197,62 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		}
 	}
 
+	if form.AvatarDescription != nil {
+		if err := validate.ProfileMediaDescription(*form.AvatarDescription); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		description := text.SanitizeToPlaintext(*form.AvatarDescription)
+		form.AvatarDescription = &description
+	}
+
+	if form.HeaderDescription != nil {
+		if err := validate.ProfileMediaDescription(*form.HeaderDescription); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		description := text.SanitizeToPlaintext(*form.HeaderDescription)
+		form.HeaderDescription = &description
+	}
+
 	if form.Avatar != nil && form.Avatar.Size != 0 {
-		avatarInfo, err := p.UpdateAvatar(ctx, form.Avatar, nil, account.ID)
+		avatarInfo, err := p.UpdateAvatar(ctx, form.Avatar, form.AvatarDescription, account.ID)
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err)
 		}
 		account.AvatarMediaAttachmentID = avatarInfo.ID
 		account.AvatarMediaAttachment = avatarInfo
 		log.Tracef(ctx, "new avatar info for account %s is %+v", account.ID, avatarInfo)
+	} else if form.AvatarDescription != nil && account.AvatarMediaAttachmentID != "" {
+		// Process just the description for the existing avatar.
+		attachment, errWithCode := p.updateProfileMediaDescription(ctx,
+			account.AvatarMediaAttachment,
+			account.AvatarMediaAttachmentID,
+			*form.AvatarDescription,
+		)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		account.AvatarMediaAttachment = attachment
 	}
 
 	if form.Header != nil && form.Header.Size != 0 {
-		headerInfo, err := p.UpdateHeader(ctx, form.Header, nil, account.ID)
+		headerInfo, err := p.UpdateHeader(ctx, form.Header, form.HeaderDescription, account.ID)
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err)
 		}
 		account.HeaderMediaAttachmentID = headerInfo.ID
 		account.HeaderMediaAttachment = headerInfo
 		log.Tracef(ctx, "new header info for account %s is %+v", account.ID, headerInfo)
+	} else if form.HeaderDescription != nil && account.HeaderMediaAttachmentID != "" {
+		// Process just the description for the existing header.
+		attachment, errWithCode := p.updateProfileMediaDescription(ctx,
+			account.HeaderMediaAttachment,
+			account.HeaderMediaAttachmentID,
+			*form.HeaderDescription,
+		)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		account.HeaderMediaAttachment = attachment
 	}
 
 	if form.Locked != nil {
@@ -249,20 +287,110 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 
 			account.StatusContentType = *form.Source.StatusContentType
 		}
+
+		if form.Source.ExpandSpoilers != nil {
+			account.ExpandSpoilers = form.Source.ExpandSpoilers
+		}
+
+		if form.Source.ExpandMedia != nil {
+			if err := validate.ExpandMedia(*form.Source.ExpandMedia); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.ExpandMedia = *form.Source.ExpandMedia
+		}
+
+		if form.Source.DisableAnimation != nil {
+			account.DisableAnimation = form.Source.DisableAnimation
+		}
+
+		if form.Source.BoostVisibility != nil {
+			if err := validate.BoostVisibility(*form.Source.BoostVisibility); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			// Empty string unsets the preference.
+			account.BoostVisibility = typeutils.APIVisToVis(apimodel.Visibility(*form.Source.BoostVisibility))
+		}
 	}
 
 	if form.CustomCSS != nil {
-		customCSS := *form.CustomCSS
+		// Sanitize before validating, so that escaped
+		// @import rules can't sneak past validation.
+		customCSS := text.SanitizeToPlaintext(*form.CustomCSS)
 		if err := validate.CustomCSS(customCSS); err != nil {
 			return nil, gtserror.NewErrorBadRequest(err, err.Error())
 		}
-		account.CustomCSS = text.SanitizeToPlaintext(customCSS)
+		account.CustomCSS = customCSS
 	}
 
 	if form.EnableRSS != nil {
 		account.EnableRSS = form.EnableRSS
 	}
 
+	if form.RSSIncludeSensitive != nil {
+		account.RSSIncludeSensitive = form.RSSIncludeSensitive
+	}
+
+	if form.HideCollections != nil {
+		account.HideCollections = form.HideCollections
+	}
+
+	if form.SuppressFollowRequestNotifications != nil {
+		account.SuppressFollowRequestNotifications = form.SuppressFollowRequestNotifications
+	}
+
+	if form.SuppressStatusUpdateNotifications != nil {
+		account.SuppressStatusUpdateNotifications = form.SuppressStatusUpdateNotifications
+	}
+
+	if form.AutoAcceptFollowedBack != nil {
+		account.AutoAcceptFollowedBack = form.AutoAcceptFollowedBack
+	}
+
+	if form.AutoAcceptLocal != nil {
+		account.AutoAcceptLocal = form.AutoAcceptLocal
+	}
+
+	if form.AutoAcceptOlderThanDays != nil {
+		days := *form.AutoAcceptOlderThanDays
+		if err := validate.AutoAcceptOlderThanDays(days); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		account.AutoAcceptOlderThanDays = days
+	}
+
+	if form.StatusesAutoDeleteAfterDays != nil {
+		days := *form.StatusesAutoDeleteAfterDays
+		if err := validate.StatusesAutoDeleteAfterDays(days); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		account.StatusesAutoDeleteAfterDays = days
+	}
+
+	if form.StatusesAutoDeleteKeepPinned != nil {
+		account.StatusesAutoDeleteKeepPinned = form.StatusesAutoDeleteKeepPinned
+	}
+
+	if form.StatusesAutoDeleteKeepBookmarked != nil {
+		account.StatusesAutoDeleteKeepBookmarked = form.StatusesAutoDeleteKeepBookmarked
+	}
+
+	if form.StatusesAutoDeleteKeepDirect != nil {
+		account.StatusesAutoDeleteKeepDirect = form.StatusesAutoDeleteKeepDirect
+	}
+
+	if form.Theme != nil {
+		theme := *form.Theme
+		if theme != "" {
+			if _, ok := p.themes.ByFileName[theme]; !ok {
+				err := fmt.Errorf("theme %s not recognized", theme)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+		}
+		account.Theme = theme
+	}
+
 	err := p.state.DB.UpdateAccount(ctx, account)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account %s: %s", account.ID, err))
@@ -282,6 +410,33 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 	return acctSensitive, nil
 }
 
+// updateProfileMediaDescription updates the description of
+// an existing avatar or header attachment, fetching it from
+// the db first if it's not yet populated on the account.
+func (p *Processor) updateProfileMediaDescription(
+	ctx context.Context,
+	attachment *gtsmodel.MediaAttachment,
+	attachmentID string,
+	description string,
+) (*gtsmodel.MediaAttachment, gtserror.WithCode) {
+	if attachment == nil {
+		var err error
+		attachment, err = p.state.DB.GetAttachmentByID(ctx, attachmentID)
+		if err != nil {
+			err := gtserror.Newf("db error getting attachment %s: %w", attachmentID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	attachment.Description = description
+	if err := p.state.DB.UpdateAttachment(ctx, attachment, "description"); err != nil {
+		err := gtserror.Newf("db error updating attachment description: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return attachment, nil
+}
+
 // UpdateAvatar does the dirty work of checking the avatar
 // part of an account update form, parsing and checking the
 // media, and doing the necessary updates in the database
@@ -19,10 +19,13 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -44,11 +47,19 @@ func (p *Processor) AccountAction(
 	case gtsmodel.AdminActionSuspend:
 		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
 
+	case gtsmodel.AdminActionUnsuspend:
+		return p.accountActionUnsuspend(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionUntombstone:
+		return p.accountActionUntombstone(ctx, adminAcct, targetAcct, request.Text)
+
 	default:
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionUnsuspend.String(),
+			gtsmodel.AdminActionUntombstone.String(),
 		}
 
 		err := fmt.Errorf(
@@ -100,3 +111,181 @@ func (p *Processor) accountActionSuspend(
 
 	return actionID, errWithCode
 }
+
+// accountActionUnsuspend cancels the pending
+// self-requested deletion of a local account,
+// lifting the accompanying suspension. Other
+// suspensions result in the account being
+// deleted straight away, so can't be undone.
+func (p *Processor) accountActionUnsuspend(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsLocal() ||
+		targetAcct.SuspensionOrigin != targetAcct.ID {
+		const text = "account is not pending deletion"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting target user: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	if user.DeletionScheduledAt.IsZero() {
+		const text = "account is not pending deletion"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionUnsuspend,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			errs := gtserror.NewMultiError(2)
+
+			// Clear scheduled deletion; the
+			// scheduled task itself checks
+			// this and skips when triggered.
+			user.DeletionScheduledAt = time.Time{}
+			user.DeletionCancelToken = ""
+			if err := p.state.DB.UpdateUser(ctx,
+				user,
+				"deletion_scheduled_at",
+				"deletion_cancel_token",
+			); err != nil {
+				errs.Append(err)
+				return errs
+			}
+
+			// Lift the suspension.
+			targetAcct.SuspendedAt = time.Time{}
+			targetAcct.SuspensionOrigin = ""
+			if err := p.state.DB.UpdateAccount(ctx,
+				targetAcct,
+				"suspended_at",
+				"suspension_origin",
+			); err != nil {
+				errs.Append(err)
+				return errs
+			}
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+// accountActionUntombstone clears the tombstone from a
+// remote account which was previously found to be gone,
+// lifting the accompanying suspension. The account will
+// be refreshed from remote the next time it's encountered.
+func (p *Processor) accountActionUntombstone(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsTombstoned() {
+		const text = "account is not tombstoned"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           gtsmodel.AdminActionUntombstone,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			// Clear tombstone + suspension, and
+			// zero fetched_at so that the account
+			// is considered stale on next deref.
+			targetAcct.TombstonedAt = time.Time{}
+			targetAcct.SuspendedAt = time.Time{}
+			targetAcct.SuspensionOrigin = ""
+			targetAcct.NotFoundCount = 0
+			targetAcct.FetchedAt = time.Time{}
+
+			if err := p.state.DB.UpdateAccount(ctx,
+				targetAcct,
+				"tombstoned_at",
+				"suspended_at",
+				"suspension_origin",
+				"not_found_count",
+				"fetched_at",
+			); err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Append(err)
+				return errs
+			}
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+// AccountStatusesMaxCharsSet overrides the max permitted
+// characters for statuses posted by the given local account,
+// or clears the override if maxChars is 0. This only applies
+// to statuses created from now on, existing ones are untouched.
+func (p *Processor) AccountStatusesMaxCharsSet(
+	ctx context.Context,
+	accountID string,
+	maxChars int,
+) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := gtserror.Newf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if !account.IsLocal() {
+		const text = "statuses max chars can only be set for local accounts"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	account.StatusesMaxChars = maxChars
+	if err := p.state.DB.UpdateAccount(ctx,
+		account,
+		"statuses_max_chars",
+	); err != nil {
+		err := gtserror.Newf("db error updating account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccount, err := p.converter.AccountToAdminAPIAccount(ctx, account)
+	if err != nil {
+		err := gtserror.Newf("error converting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -98,6 +99,44 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 	suite.Empty(actionID)
 }
 
+func (suite *AccountTestSuite) TestAccountStatusesMaxCharsSet() {
+	var (
+		ctx        = context.Background()
+		targetAcct = suite.testAccounts["local_account_1"]
+	)
+
+	apiAccount, errWithCode := suite.adminProcessor.AccountStatusesMaxCharsSet(ctx, targetAcct.ID, 20000)
+	suite.NoError(errWithCode)
+	suite.Equal(20000, apiAccount.StatusesMaxChars)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(20000, dbAccount.MaxStatusChars())
+
+	// Clearing the override should
+	// fall back to instance setting.
+	apiAccount, errWithCode = suite.adminProcessor.AccountStatusesMaxCharsSet(ctx, targetAcct.ID, 0)
+	suite.NoError(errWithCode)
+	suite.Zero(apiAccount.StatusesMaxChars)
+
+	dbAccount, err = suite.db.GetAccountByID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(config.GetStatusesMaxChars(), dbAccount.MaxStatusChars())
+}
+
+func (suite *AccountTestSuite) TestAccountStatusesMaxCharsSetRemote() {
+	_, errWithCode := suite.adminProcessor.AccountStatusesMaxCharsSet(
+		context.Background(),
+		suite.testAccounts["remote_account_1"].ID,
+		20000,
+	)
+	suite.EqualError(errWithCode, "statuses max chars can only be set for local accounts")
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
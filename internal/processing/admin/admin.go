@@ -38,6 +38,10 @@ type Processor struct {
 	// admin Actions currently
 	// undergoing processing
 	actions *Actions
+
+	// recently computed
+	// measures + dimensions
+	metrics *metricsCache
 }
 
 func (p *Processor) Actions() *Actions {
@@ -65,5 +69,9 @@ func New(
 			r:     make(map[string]*gtsmodel.AdminAction),
 			state: state,
 		},
+
+		metrics: &metricsCache{
+			entries: make(map[string]metricsCacheEntry),
+		},
 	}
 }
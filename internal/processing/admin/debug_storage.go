@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DebugStorage re-runs the storage health probe and
+// returns the outcome. A failed probe is still a
+// successful request, with the reason in the response.
+func (p *Processor) DebugStorage(ctx context.Context) (*apimodel.DebugStorageResponse, gtserror.WithCode) {
+	h := p.state.Storage.Probe(ctx)
+
+	resp := &apimodel.DebugStorageResponse{
+		Backend:   h.Backend,
+		Healthy:   h.Healthy(),
+		CheckedAt: util.FormatISO8601(h.CheckedAt),
+		LatencyMS: h.Latency.Milliseconds(),
+	}
+
+	if h.FreeBytes >= 0 {
+		resp.FreeBytes = util.Ptr(h.FreeBytes)
+	}
+
+	if h.Err != nil {
+		resp.Error = h.Err.Error()
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// DeliveriesBacklogGet returns the number of failed deliveries
+// queued for retry per domain, largest backlog first.
+func (p *Processor) DeliveriesBacklogGet(ctx context.Context) ([]*apimodel.AdminDeliveryBacklog, gtserror.WithCode) {
+	counts, err := p.state.DB.CountDeliveriesByDomain(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error counting deliveries: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	backlog := make([]*apimodel.AdminDeliveryBacklog, 0, len(counts))
+	for domain, count := range counts {
+		backlog = append(backlog, &apimodel.AdminDeliveryBacklog{
+			Domain: domain,
+			Count:  count,
+		})
+	}
+
+	slices.SortFunc(backlog, func(a, b *apimodel.AdminDeliveryBacklog) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Domain, b.Domain)
+	})
+
+	return backlog, nil
+}
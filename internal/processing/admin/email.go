@@ -19,6 +19,7 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -30,7 +31,8 @@ import (
 // EmailTest sends a generic test email to the given toAddress (which
 // should be a valid email address). To help callers differentiate between
 // proper errors and the smtp errors they're likely fishing for, will return
-// 422 + help text on an SMTP error, or error 500 otherwise.
+// 422 + help text on an SMTP error (or if email sending is not configured
+// at all), or error 500 otherwise.
 func (p *Processor) EmailTest(ctx context.Context, account *gtsmodel.Account, toAddress string) gtserror.WithCode {
 	// Pull our instance entry from the database,
 	// so we can greet the email recipient nicely.
@@ -47,8 +49,10 @@ func (p *Processor) EmailTest(ctx context.Context, account *gtsmodel.Account, to
 	}
 
 	if err := p.emailSender.SendTestEmail(toAddress, testData); err != nil {
-		if gtserror.IsSMTP(err) {
-			// An error occurred during the SMTP part.
+		if gtserror.IsSMTP(err) ||
+			errors.Is(err, email.ErrNotConfigured) {
+			// An error occurred during the SMTP part,
+			// or there's no SMTP server to speak to.
 			// We should indicate this to the caller, as
 			// it will likely help them debug the issue.
 			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
@@ -0,0 +1,192 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// inviteCodeEnc is a base 32 encoding based on a
+// human-readable character set (no padding), so that
+// invite codes can be read out or typed in easily.
+var inviteCodeEnc = base32.NewEncoding("0123456789abcdefghjkmnpqrstvwxyz").WithPadding(-1)
+
+// InvitesGet returns all invites created on this instance.
+func (p *Processor) InvitesGet(ctx context.Context) ([]*apimodel.AdminInvite, gtserror.WithCode) {
+	if errWithCode := requireInvitesEnabled(); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	invites, err := p.state.DB.GetInvites(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvites := make([]*apimodel.AdminInvite, len(invites))
+	for i, invite := range invites {
+		apiInvites[i] = p.converter.InviteToAdminAPIInvite(invite)
+	}
+
+	return apiInvites, nil
+}
+
+// InviteGet returns one invite, with the given ID.
+func (p *Processor) InviteGet(ctx context.Context, id string) (*apimodel.AdminInvite, gtserror.WithCode) {
+	if errWithCode := requireInvitesEnabled(); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	invite, errWithCode := p.getInvite(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.InviteToAdminAPIInvite(invite), nil
+}
+
+// InviteCreate creates a new invite on behalf of the given
+// admin account, with a randomly generated invite code.
+func (p *Processor) InviteCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminInviteCreateRequest,
+) (*apimodel.AdminInvite, gtserror.WithCode) {
+	if errWithCode := requireInvitesEnabled(); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.MaxUses < 0 {
+		const text = "max_uses must be 0 or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.ExpiresIn < 0 {
+		const text = "expires_in must be 0 or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	role := gtsmodel.InviteRole(form.Role)
+	switch role {
+	case gtsmodel.InviteRoleNone,
+		gtsmodel.InviteRoleModerator,
+		gtsmodel.InviteRoleAdmin:
+		// No problem.
+	default:
+		err := fmt.Errorf("role %s not recognized, must be one of moderator, admin", form.Role)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		err := gtserror.Newf("error generating invite code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:                 id.NewULID(),
+		Code:               code,
+		CreatedByAccountID: adminAcct.ID,
+		MaxUses:            form.MaxUses,
+		Role:               role,
+	}
+
+	if form.ExpiresIn > 0 {
+		invite.ExpiresAt = time.Now().Add(time.Duration(form.ExpiresIn) * time.Second)
+	}
+
+	if err := p.state.DB.PutInvite(ctx, invite); err != nil {
+		err := gtserror.Newf("db error putting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.InviteToAdminAPIInvite(invite), nil
+}
+
+// InviteRevoke revokes the invite with the given ID by
+// expiring it immediately, so it can no longer be used
+// to sign up. Accounts already created with the invite
+// are not affected.
+func (p *Processor) InviteRevoke(ctx context.Context, id string) (*apimodel.AdminInvite, gtserror.WithCode) {
+	if errWithCode := requireInvitesEnabled(); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	invite, errWithCode := p.getInvite(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if invite.Expired() {
+		// Nothing to do.
+		return p.converter.InviteToAdminAPIInvite(invite), nil
+	}
+
+	invite.ExpiresAt = time.Now()
+	if err := p.state.DB.UpdateInvite(ctx, invite, "expires_at"); err != nil {
+		err := gtserror.Newf("db error updating invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.InviteToAdminAPIInvite(invite), nil
+}
+
+func (p *Processor) getInvite(ctx context.Context, id string) (*gtsmodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		err := fmt.Errorf("invite %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return invite, nil
+}
+
+func requireInvitesEnabled() gtserror.WithCode {
+	if !config.GetAccountsInvitesEnabled() {
+		const text = "invites are not enabled on this instance"
+		return gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+	return nil
+}
+
+// newInviteCode returns a new random
+// invite code with 80 bits of entropy.
+func newInviteCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return inviteCodeEnc.EncodeToString(b), nil
+}
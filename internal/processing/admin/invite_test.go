@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type InviteTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *InviteTestSuite) TestInviteCreateRevoke() {
+	ctx := context.Background()
+	adminAcct := suite.testAccounts["admin_account"]
+
+	invite, errWithCode := suite.adminProcessor.InviteCreate(ctx, adminAcct, &apimodel.AdminInviteCreateRequest{
+		MaxUses:   5,
+		ExpiresIn: 3600,
+		Role:      "moderator",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(invite.Code, 16)
+	suite.Equal(adminAcct.ID, invite.CreatedByAccountID)
+	suite.Equal(5, invite.MaxUses)
+	suite.NotNil(invite.ExpiresAt)
+	suite.Equal("moderator", invite.Role)
+	suite.False(invite.Expired)
+
+	invites, errWithCode := suite.adminProcessor.InvitesGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(invites, 1)
+
+	revoked, errWithCode := suite.adminProcessor.InviteRevoke(ctx, invite.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(revoked.Expired)
+
+	// Revoked invite should
+	// no longer be usable.
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbInvite.Expired())
+}
+
+func (suite *InviteTestSuite) TestInviteCreateBadRole() {
+	_, errWithCode := suite.adminProcessor.InviteCreate(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.AdminInviteCreateRequest{Role: "overlord"},
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *InviteTestSuite) TestInvitesDisabled() {
+	config.SetAccountsInvitesEnabled(false)
+
+	_, errWithCode := suite.adminProcessor.InvitesGet(context.Background())
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
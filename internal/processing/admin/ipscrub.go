@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"net"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// IPScrub goes through all users on the instance, and
+// scrubs any stored sign up / sign in IP addresses that
+// are more complete than accounts-ip-retention currently
+// allows, ie., removing or truncating them as configured.
+//
+// This is useful after changing accounts-ip-retention to a
+// stricter setting, as that only applies to new sign ins.
+//
+// The number of users that were scrubbed is returned.
+func (p *Processor) IPScrub(ctx context.Context) (int, gtserror.WithCode) {
+	users, err := p.state.DB.GetAllUsers(gtscontext.SetBarebones(ctx))
+	if err != nil {
+		err := gtserror.Newf("db error getting users: %w", err)
+		return 0, gtserror.NewErrorInternalError(err)
+	}
+
+	var scrubbed int
+
+	for _, user := range users {
+		var columns []string
+
+		for column, ip := range map[string]*net.IP{
+			"sign_up_ip":         &user.SignUpIP,
+			"current_sign_in_ip": &user.CurrentSignInIP,
+			"last_sign_in_ip":    &user.LastSignInIP,
+		} {
+			retained := util.RetainIP(*ip)
+			if retained.Equal(*ip) {
+				// Nothing
+				// to scrub.
+				continue
+			}
+
+			*ip = retained
+			columns = append(columns, column)
+		}
+
+		if len(columns) == 0 {
+			continue
+		}
+
+		if err := p.state.DB.UpdateUser(ctx, user, columns...); err != nil {
+			err := gtserror.Newf("db error updating user %s: %w", user.ID, err)
+			return scrubbed, gtserror.NewErrorInternalError(err)
+		}
+
+		scrubbed++
+	}
+
+	return scrubbed, nil
+}
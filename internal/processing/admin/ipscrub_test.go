@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type IPScrubTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *IPScrubTestSuite) TestIPScrubFull() {
+	// Nothing to scrub when
+	// retaining full IPs.
+	scrubbed, errWithCode := suite.adminProcessor.IPScrub(context.Background())
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Zero(scrubbed)
+}
+
+func (suite *IPScrubTestSuite) TestIPScrubTruncated() {
+	ctx := context.Background()
+	config.SetAccountsIPRetention(config.AccountsIPRetentionTruncated)
+
+	scrubbed, errWithCode := suite.adminProcessor.IPScrub(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(len(suite.testUsers), scrubbed)
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("59.99.19.0", user.SignUpIP.String())
+	suite.Equal("88.234.118.0", user.CurrentSignInIP.String())
+	suite.Equal("147.111.231.0", user.LastSignInIP.String())
+
+	// Already scrubbed, so
+	// nothing more to do.
+	scrubbed, errWithCode = suite.adminProcessor.IPScrub(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Zero(scrubbed)
+}
+
+func (suite *IPScrubTestSuite) TestIPScrubNone() {
+	ctx := context.Background()
+	config.SetAccountsIPRetention(config.AccountsIPRetentionNone)
+
+	scrubbed, errWithCode := suite.adminProcessor.IPScrub(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(len(suite.testUsers), scrubbed)
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(user.SignUpIP)
+	suite.Empty(user.CurrentSignInIP)
+	suite.Empty(user.LastSignInIP)
+}
+
+func TestIPScrubTestSuite(t *testing.T) {
+	suite.Run(t, &IPScrubTestSuite{})
+}
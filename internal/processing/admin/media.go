@@ -19,11 +19,16 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/cleaner"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // MediaRefetch forces a refetch of remote emojis.
@@ -47,19 +52,58 @@ func (p *Processor) MediaRefetch(ctx context.Context, requestingAccount *gtsmode
 	return nil
 }
 
-// MediaPrune triggers a non-blocking prune of unused media, orphaned, uncaching remote and fixing cache states.
-func (p *Processor) MediaPrune(ctx context.Context, mediaRemoteCacheDays int) gtserror.WithCode {
+// MediaPrune performs a prune of unused media, orphaned, uncaching remote and fixing
+// cache states, returning a report of what was cleaned up. If dryRun is true, nothing
+// is actually removed, and the report shows what would have been cleaned up.
+func (p *Processor) MediaPrune(ctx context.Context, mediaRemoteCacheDays int, dryRun bool) (*apimodel.AdminMediaCleanupReport, gtserror.WithCode) {
 	if mediaRemoteCacheDays < 0 {
 		err := fmt.Errorf("MediaPrune: invalid value for mediaRemoteCacheDays prune: value was %d, cannot be less than 0", mediaRemoteCacheDays)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
-	// Start background task performing all media cleanup tasks.
-	go func() {
-		ctx := context.Background()
-		p.cleaner.Media().All(ctx, mediaRemoteCacheDays)
-		p.cleaner.Emoji().All(ctx, mediaRemoteCacheDays)
-	}()
+	// Don't abandon the run
+	// halfway through if the
+	// client goes away.
+	ctx = context.WithoutCancel(ctx)
+	if dryRun {
+		ctx = gtscontext.SetDryRun(ctx)
+	}
 
-	return nil
+	report := p.cleaner.Run(ctx, mediaRemoteCacheDays)
+	return mediaCleanupReportToAPI(report), nil
+}
+
+// MediaCleanupReportGet returns the report of the
+// last media cleanup run since the server started.
+func (p *Processor) MediaCleanupReportGet() (*apimodel.AdminMediaCleanupReport, gtserror.WithCode) {
+	report := p.cleaner.LastReport()
+	if report == nil {
+		err := errors.New("no media cleanup has run yet")
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return mediaCleanupReportToAPI(report), nil
+}
+
+func mediaCleanupReportToAPI(report *cleaner.Report) *apimodel.AdminMediaCleanupReport {
+	entry := func(e cleaner.ReportEntry) apimodel.AdminMediaCleanupReportEntry {
+		return apimodel.AdminMediaCleanupReportEntry{
+			Count: e.Count,
+			Bytes: e.Bytes,
+		}
+	}
+
+	return &apimodel.AdminMediaCleanupReport{
+		DryRun:                 report.DryRun,
+		RemoteCacheDays:        report.RemoteCacheDays,
+		StartedAt:              util.FormatISO8601(report.StartedAt),
+		FinishedAt:             util.FormatISO8601(report.FinishedAt),
+		OrphanedLocal:          entry(report.OrphanedLocal),
+		StaleRemoteAttachments: entry(report.StaleRemoteAttachments),
+		StaleAvatarsHeaders:    entry(report.StaleAvatarsHeaders),
+		UnusedMedia:            entry(report.UnusedMedia),
+		StaleRemoteEmoji:       entry(report.StaleRemoteEmoji),
+		UnusedEmoji:            entry(report.UnusedEmoji),
+		BytesReclaimed:         report.BytesReclaimed(),
+	}
 }
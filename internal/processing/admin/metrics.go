@@ -0,0 +1,370 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/language"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// metricsCacheTTL is how long computed measures
+	// and dimensions are cached for, since dashboards
+	// tend to poll for the same ones repeatedly.
+	metricsCacheTTL = 5 * time.Minute
+
+	// metricsMaxDays is the longest period (in days)
+	// that measures and dimensions can be requested for.
+	metricsMaxDays = 366
+
+	// Default and maximum number
+	// of entries per dimension.
+	dimensionsDefaultLimit = 10
+	dimensionsMaxLimit     = 100
+)
+
+// Keys of supported measures.
+const (
+	MeasureActiveUsers     = "active_users"
+	MeasureNewUsers        = "new_users"
+	MeasureInteractions    = "interactions"
+	MeasureOpenedReports   = "opened_reports"
+	MeasureResolvedReports = "resolved_reports"
+)
+
+// Keys of supported dimensions.
+const (
+	DimensionLanguages = "languages"
+	DimensionServers   = "servers"
+)
+
+// metricsCache holds recently computed
+// measures and dimensions, keyed by their
+// key and the period they were computed for.
+type metricsCache struct {
+	mu      sync.Mutex
+	entries map[string]metricsCacheEntry
+}
+
+type metricsCacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// get returns the cached value for key, if not expired.
+func (m *metricsCache) get(key string) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+// set caches value for key, clearing out
+// any other entries that have expired.
+func (m *metricsCache) set(key string, value any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range m.entries {
+		if now.After(entry.expires) {
+			delete(m.entries, k)
+		}
+	}
+
+	m.entries[key] = metricsCacheEntry{
+		value:   value,
+		expires: now.Add(metricsCacheTTL),
+	}
+}
+
+// MeasuresGet returns the requested measures of
+// activity on the instance from start day to end
+// day (both inclusive, UTC), with a value for each
+// day. Keys of unsupported measures are ignored.
+func (p *Processor) MeasuresGet(
+	ctx context.Context,
+	keys []string,
+	start time.Time,
+	end time.Time,
+) ([]*apimodel.AdminMeasure, gtserror.WithCode) {
+	start, end, errWithCode := metricsPeriod(start, end)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	measures := make([]*apimodel.AdminMeasure, 0, len(keys))
+	for _, key := range keys {
+		switch key {
+		case MeasureActiveUsers,
+			MeasureNewUsers,
+			MeasureInteractions,
+			MeasureOpenedReports,
+			MeasureResolvedReports:
+		default:
+			// Not (yet)
+			// supported.
+			continue
+		}
+
+		measure, err := p.measure(ctx, key, start, end)
+		if err != nil {
+			err := gtserror.Newf("error calculating measure %s: %w", key, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		measures = append(measures, measure)
+	}
+
+	return measures, nil
+}
+
+// DimensionsGet returns the requested dimensions of
+// activity on the instance from start day to end day
+// (both inclusive, UTC), with at most limit entries each.
+// Keys of unsupported dimensions are ignored.
+func (p *Processor) DimensionsGet(
+	ctx context.Context,
+	keys []string,
+	start time.Time,
+	end time.Time,
+	limit int,
+) ([]*apimodel.AdminDimension, gtserror.WithCode) {
+	start, end, errWithCode := metricsPeriod(start, end)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	switch {
+	case limit <= 0:
+		limit = dimensionsDefaultLimit
+	case limit > dimensionsMaxLimit:
+		limit = dimensionsMaxLimit
+	}
+
+	dimensions := make([]*apimodel.AdminDimension, 0, len(keys))
+	for _, key := range keys {
+		switch key {
+		case DimensionLanguages,
+			DimensionServers:
+		default:
+			// Not (yet)
+			// supported.
+			continue
+		}
+
+		dimension, err := p.dimension(ctx, key, start, end, limit)
+		if err != nil {
+			err := gtserror.Newf("error calculating dimension %s: %w", key, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		dimensions = append(dimensions, dimension)
+	}
+
+	return dimensions, nil
+}
+
+// metricsPeriod truncates start and end to whole
+// days (UTC), and returns the period from the start
+// of the start day, to the end of the end day.
+func metricsPeriod(start time.Time, end time.Time) (time.Time, time.Time, gtserror.WithCode) {
+	start = start.UTC().Truncate(24 * time.Hour)
+	end = end.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+
+	if !start.Before(end) {
+		const text = "start_at must not be after end_at"
+		return start, end, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if end.Sub(start) > metricsMaxDays*24*time.Hour {
+		text := fmt.Sprintf("period between start_at and end_at must not be longer than %d days", metricsMaxDays)
+		return start, end, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return start, end, nil
+}
+
+// measure calculates (or fetches from cache)
+// the measure with key between start and end.
+func (p *Processor) measure(
+	ctx context.Context,
+	key string,
+	start time.Time,
+	end time.Time,
+) (*apimodel.AdminMeasure, error) {
+	cacheKey := "measure:" + key + ":" + start.String() + ":" + end.String()
+	if cached, ok := p.metrics.get(cacheKey); ok {
+		return cached.(*apimodel.AdminMeasure), nil
+	}
+
+	total, err := p.countMeasure(ctx, key, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Previous period is the same
+	// length, ending at our start.
+	prevTotal, err := p.countMeasure(ctx, key, start.Add(-end.Sub(start)), start)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []apimodel.AdminMeasureData
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		count, err := p.countMeasure(ctx, key, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, apimodel.AdminMeasureData{
+			Date:  util.FormatISO8601(day),
+			Value: strconv.Itoa(count),
+		})
+	}
+
+	measure := &apimodel.AdminMeasure{
+		Key:           key,
+		Total:         strconv.Itoa(total),
+		PreviousTotal: strconv.Itoa(prevTotal),
+		Data:          data,
+	}
+
+	p.metrics.set(cacheKey, measure)
+	return measure, nil
+}
+
+// countMeasure returns the value of
+// measure with key between start and end.
+func (p *Processor) countMeasure(
+	ctx context.Context,
+	key string,
+	start time.Time,
+	end time.Time,
+) (int, error) {
+	switch key {
+	case MeasureActiveUsers:
+		return p.state.DB.CountActiveUsers(ctx, start, end)
+	case MeasureNewUsers:
+		return p.state.DB.CountNewUsers(ctx, start, end)
+	case MeasureInteractions:
+		return p.state.DB.CountInteractions(ctx, start, end)
+	case MeasureOpenedReports:
+		return p.state.DB.CountOpenedReports(ctx, start, end)
+	case MeasureResolvedReports:
+		return p.state.DB.CountResolvedReports(ctx, start, end)
+	default:
+		return 0, gtserror.Newf("unknown measure %s", key)
+	}
+}
+
+// dimension calculates (or fetches from cache) the
+// dimension with key between start and end.
+func (p *Processor) dimension(
+	ctx context.Context,
+	key string,
+	start time.Time,
+	end time.Time,
+	limit int,
+) (*apimodel.AdminDimension, error) {
+	cacheKey := "dimension:" + key + ":" + start.String() + ":" + end.String() + ":" + strconv.Itoa(limit)
+	if cached, ok := p.metrics.get(cacheKey); ok {
+		return cached.(*apimodel.AdminDimension), nil
+	}
+
+	var (
+		counts   map[string]int
+		humanKey func(string) string
+		err      error
+	)
+
+	switch key {
+	case DimensionLanguages:
+		counts, err = p.state.DB.CountStatusesByLanguage(ctx, start, end, limit)
+		humanKey = func(key string) string {
+			lang, err := language.Parse(key)
+			if err != nil {
+				return key
+			}
+			return lang.DisplayStr
+		}
+
+	case DimensionServers:
+		counts, err = p.state.DB.CountStatusesByDomain(ctx, start, end, limit)
+		humanKey = func(key string) string { return key }
+
+	default:
+		err = gtserror.Newf("unknown dimension %s", key)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		key   string
+		count int
+	}
+
+	entries := make([]entry, 0, len(counts))
+	for key, count := range counts {
+		entries = append(entries, entry{key, count})
+	}
+
+	// Largest first, falling back
+	// to key for stable ordering.
+	slices.SortFunc(entries, func(a, b entry) int {
+		if c := cmp.Compare(b.count, a.count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.key, b.key)
+	})
+
+	data := make([]apimodel.AdminDimensionData, 0, len(entries))
+	for _, e := range entries {
+		data = append(data, apimodel.AdminDimensionData{
+			Key:      e.key,
+			HumanKey: humanKey(e.key),
+			Value:    strconv.Itoa(e.count),
+		})
+	}
+
+	dimension := &apimodel.AdminDimension{
+		Key:  key,
+		Data: data,
+	}
+
+	p.metrics.set(cacheKey, dimension)
+	return dimension, nil
+}
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/admin"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MetricsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *MetricsTestSuite) TestMeasuresGet() {
+	measures, errWithCode := suite.adminProcessor.MeasuresGet(
+		context.Background(),
+		[]string{
+			admin.MeasureOpenedReports,
+			admin.MeasureResolvedReports,
+			"tag_accounts", // unsupported
+		},
+		testrig.TimeMustParse("2022-05-14T00:00:00Z"),
+		testrig.TimeMustParse("2022-05-15T00:00:00Z"),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal([]*apimodel.AdminMeasure{
+		{
+			Key:           admin.MeasureOpenedReports,
+			Total:         "2",
+			PreviousTotal: "0",
+			Data: []apimodel.AdminMeasureData{
+				{Date: "2022-05-14T00:00:00.000Z", Value: "1"},
+				{Date: "2022-05-15T00:00:00.000Z", Value: "1"},
+			},
+		},
+		{
+			Key:           admin.MeasureResolvedReports,
+			Total:         "1",
+			PreviousTotal: "0",
+			Data: []apimodel.AdminMeasureData{
+				{Date: "2022-05-14T00:00:00.000Z", Value: "0"},
+				{Date: "2022-05-15T00:00:00.000Z", Value: "1"},
+			},
+		},
+	}, measures)
+}
+
+func (suite *MetricsTestSuite) TestMeasuresGetBadPeriod() {
+	_, errWithCode := suite.adminProcessor.MeasuresGet(
+		context.Background(),
+		[]string{admin.MeasureNewUsers},
+		testrig.TimeMustParse("2022-05-15T00:00:00Z"),
+		testrig.TimeMustParse("2022-05-14T00:00:00Z"),
+	)
+	suite.EqualError(errWithCode, "start_at must not be after end_at")
+
+	_, errWithCode = suite.adminProcessor.MeasuresGet(
+		context.Background(),
+		[]string{admin.MeasureNewUsers},
+		testrig.TimeMustParse("2020-01-01T00:00:00Z"),
+		testrig.TimeMustParse("2022-01-01T00:00:00Z"),
+	)
+	suite.EqualError(errWithCode, "period between start_at and end_at must not be longer than 366 days")
+}
+
+func (suite *MetricsTestSuite) TestDimensionsGet() {
+	dimensions, errWithCode := suite.adminProcessor.DimensionsGet(
+		context.Background(),
+		[]string{admin.DimensionLanguages},
+		testrig.TimeMustParse("2021-01-01T00:00:00Z"),
+		testrig.TimeMustParse("2021-12-31T00:00:00Z"),
+		1,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(dimensions, 1)
+	suite.Equal(admin.DimensionLanguages, dimensions[0].Key)
+	suite.Len(dimensions[0].Data, 1)
+	suite.Equal("en", dimensions[0].Data[0].Key)
+	suite.Equal("English", dimensions[0].Data[0].HumanKey)
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// QuarantinedStatusesGet returns a page of statuses
+// quarantined by the spam filter, newest first.
+func (p *Processor) QuarantinedStatusesGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	statuses, err := p.state.DB.GetQuarantinedStatuses(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting quarantined statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(statuses)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := statuses[count-1].ID
+	hi := statuses[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, status := range statuses {
+		item, err := p.converter.QuarantinedStatusToAdminAPIQuarantinedStatus(ctx, status)
+		if err != nil {
+			log.Errorf(ctx, "error converting quarantined status: %v", err)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/quarantine/statuses",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// QuarantinedStatusGet returns one quarantined status, with the given ID.
+func (p *Processor) QuarantinedStatusGet(ctx context.Context, id string) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	status, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiQuarantinedStatus(ctx, status)
+}
+
+// QuarantinedStatusApprove releases the quarantined status with
+// the given ID, processing it as if it had passed the spam filter.
+func (p *Processor) QuarantinedStatusApprove(ctx context.Context, id string) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	status, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiStatus, errWithCode := p.apiQuarantinedStatus(ctx, status)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	statusable, err := ap.ResolveStatusable(ctx, io.NopCloser(bytes.NewReader(status.Data)))
+	if err != nil {
+		err := gtserror.Newf("error resolving quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteQuarantinedStatusByID(ctx, status.ID); err != nil {
+		err := gtserror.Newf("db error deleting quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process the status as it would have
+	// been if the spam filter had let it in.
+	p.state.Workers.EnqueueFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityCreate,
+		APObjectModel:    statusable,
+		ReceivingAccount: status.ReceivingAccount,
+	})
+
+	return apiStatus, nil
+}
+
+// QuarantinedStatusReject drops the quarantined status with the given ID.
+func (p *Processor) QuarantinedStatusReject(ctx context.Context, id string) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	status, errWithCode := p.getQuarantinedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiStatus, errWithCode := p.apiQuarantinedStatus(ctx, status)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteQuarantinedStatusByID(ctx, status.ID); err != nil {
+		err := gtserror.Newf("db error deleting quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiStatus, nil
+}
+
+func (p *Processor) getQuarantinedStatus(ctx context.Context, id string) (*gtsmodel.QuarantinedStatus, gtserror.WithCode) {
+	status, err := p.state.DB.GetQuarantinedStatusByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		err := fmt.Errorf("quarantined status %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return status, nil
+}
+
+func (p *Processor) apiQuarantinedStatus(ctx context.Context, status *gtsmodel.QuarantinedStatus) (*apimodel.AdminQuarantinedStatus, gtserror.WithCode) {
+	apiStatus, err := p.converter.QuarantinedStatusToAdminAPIQuarantinedStatus(ctx, status)
+	if err != nil {
+		err := gtserror.Newf("error converting quarantined status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiStatus, nil
+}
@@ -0,0 +1,256 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// RelaysGet returns all relays followed by this instance.
+func (p *Processor) RelaysGet(ctx context.Context) ([]*apimodel.AdminRelay, gtserror.WithCode) {
+	relays, err := p.state.DB.GetRelays(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting relays: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRelays := make([]*apimodel.AdminRelay, 0, len(relays))
+	for _, relay := range relays {
+		if relay.State == gtsmodel.RelayStateRemoved {
+			// Only kept to drop its traffic.
+			continue
+		}
+		apiRelays = append(apiRelays, p.converter.RelayToAdminAPIRelay(relay))
+	}
+
+	return apiRelays, nil
+}
+
+// RelayGet returns one relay, with the given ID.
+func (p *Processor) RelayGet(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayCreate follows the relay with the given inbox URL
+// as the instance actor. The relay will stay pending until
+// it has accepted the follow.
+func (p *Processor) RelayCreate(ctx context.Context, inboxURL string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	inbox, err := url.Parse(inboxURL)
+	if err != nil || inbox.Host == "" || (inbox.Scheme != "http" && inbox.Scheme != "https") {
+		err := fmt.Errorf("invalid inbox url %s, must be an absolute http or https url", inboxURL)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, inbox.Host)
+	if err != nil {
+		err := gtserror.Newf("db error checking for domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		err := fmt.Errorf("relay domain %s is blocked", inbox.Host)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	existing, err := p.state.DB.GetRelayByInboxURI(ctx, inbox.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking for existing relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil && existing.State != gtsmodel.RelayStateRemoved {
+		err := fmt.Errorf("relay with inbox url %s already exists", inbox)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var relay *gtsmodel.Relay
+	if existing != nil {
+		// Follow a previously removed relay
+		// again, with a fresh follow URI
+		// so the old Undo doesn't apply.
+		relay = existing
+		relay.FollowURI = uris.GenerateURIForFollow(instanceAcct.Username, id.NewULID())
+		relay.AccountID = ""
+		relay.State = gtsmodel.RelayStatePending
+
+		// Update the relay before sending the
+		// follow, as the relay may well Accept
+		// before delivery has returned here.
+		if err := p.state.DB.UpdateRelay(ctx, relay,
+			"follow_uri",
+			"account_id",
+			"state",
+		); err != nil {
+			err := gtserror.Newf("db error updating relay: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	} else {
+		relayID := id.NewULID()
+		relay = &gtsmodel.Relay{
+			ID:        relayID,
+			InboxURI:  inbox.String(),
+			FollowURI: uris.GenerateURIForFollow(instanceAcct.Username, relayID),
+			Domain:    inbox.Host,
+			State:     gtsmodel.RelayStatePending,
+		}
+
+		// Store the relay before sending the
+		// follow, as the relay may well Accept
+		// before delivery has returned here.
+		if err := p.state.DB.PutRelay(ctx, relay); err != nil {
+			err := gtserror.Newf("db error putting relay: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	follow, err := p.converter.RelayToASFollow(ctx, relay)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deliverToRelay(ctx, instanceAcct, relay, follow); err != nil {
+		// We never managed to follow the
+		// relay, mark it as removed so any
+		// traffic from it is still dropped.
+		relay.State = gtsmodel.RelayStateRemoved
+		if err := p.state.DB.UpdateRelay(ctx, relay, "state"); err != nil {
+			log.Errorf(ctx, "db error updating relay: %v", err)
+		}
+
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return p.converter.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayDelete stops following the relay with the given ID,
+// sending an Undo of the Follow to the relay. The relay is
+// kept, marked as removed, so that any further traffic from
+// it is dropped rather than treated as regular activities.
+func (p *Processor) RelayDelete(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Mark the relay removed first,
+	// so its traffic stops being
+	// accepted straight away.
+	relay.State = gtsmodel.RelayStateRemoved
+	if err := p.state.DB.UpdateRelay(ctx, relay, "state"); err != nil {
+		err := gtserror.Newf("db error updating relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	undo, err := p.converter.RelayToASUndoFollow(ctx, relay)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to undo follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Failing to deliver the Undo is not
+	// fatal, as we will simply drop any
+	// further traffic from the relay.
+	if err := p.deliverToRelay(ctx, instanceAcct, relay, undo); err != nil {
+		log.Warnf(ctx, "error undoing follow of relay %s: %v", relay.InboxURI, err)
+	}
+
+	return p.converter.RelayToAdminAPIRelay(relay), nil
+}
+
+func (p *Processor) getRelay(ctx context.Context, id string) (*gtsmodel.Relay, gtserror.WithCode) {
+	relay, err := p.state.DB.GetRelayByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay == nil || relay.State == gtsmodel.RelayStateRemoved {
+		err := fmt.Errorf("relay %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return relay, nil
+}
+
+// deliverToRelay signs and delivers the given
+// activity from the instance actor to the relay.
+func (p *Processor) deliverToRelay(
+	ctx context.Context,
+	instanceAcct *gtsmodel.Account,
+	relay *gtsmodel.Relay,
+	activity vocab.Type,
+) error {
+	inbox, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing relay inbox: %w", err)
+	}
+
+	data, err := ap.Serialize(activity)
+	if err != nil {
+		return gtserror.Newf("error serializing %T: %w", activity, err)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return gtserror.Newf("error marshaling %T: %w", activity, err)
+	}
+
+	tsport, err := p.transportController.NewTransportForUsername(ctx, instanceAcct.Username)
+	if err != nil {
+		return gtserror.Newf("error creating transport: %w", err)
+	}
+
+	if err := tsport.Deliver(ctx, b, inbox); err != nil {
+		return gtserror.Newf("error delivering %T to relay %s: %w", activity, inbox, err)
+	}
+
+	return nil
+}
@@ -37,6 +37,11 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 		scopes = form.Scopes
 	}
 
+	// make sure we know what the app's asking for
+	if err := oauth.ValidateScopes(scopes); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
 	// generate new IDs for this application and its associated client
 	clientID, err := id.NewRandomULID()
 	if err != nil {
@@ -22,6 +22,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
 )
 
 // Processor provides a processor with logic
@@ -32,6 +34,10 @@ type Processor struct {
 	converter *typeutils.Converter
 	federator *federation.Federator
 	filter    *visibility.Filter
+
+	// per-account limiter for
+	// forced remote refreshes.
+	refreshLimiter *limiter.Limiter
 }
 
 // New returns a new Processor instance.
@@ -46,5 +52,13 @@ func New(
 		converter: converter,
 		federator: federator,
 		filter:    filter,
+
+		refreshLimiter: limiter.New(
+			memory.NewStore(),
+			limiter.Rate{
+				Period: refreshLimitPeriod,
+				Limit:  refreshLimit,
+			},
+		),
 	}
 }
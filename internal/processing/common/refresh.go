@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	// Number of forced remote refreshes
+	// allowed per account per period.
+	refreshLimit       = 10
+	refreshLimitPeriod = 5 * time.Minute
+)
+
+// RefreshAllowed checks whether requester is allowed to
+// force a refresh of a remote account / status right now,
+// counting this attempt towards their rate limit. This
+// prevents users from hammering remote instances through
+// us with refresh requests.
+func (p *Processor) RefreshAllowed(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) gtserror.WithCode {
+	limit, err := p.refreshLimiter.Get(ctx, requester.ID)
+	if err != nil {
+		// Should never happen with
+		// the in-memory store, but...
+		err := gtserror.Newf("error checking refresh limit: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if limit.Reached {
+		const text = "refresh limit reached, try again later"
+		return gtserror.NewErrorTooManyRequests(errors.New(text), text)
+	}
+
+	return nil
+}
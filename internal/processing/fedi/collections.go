@@ -22,13 +22,17 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // InboxPost handles POST requests to a user's inbox for new activitypub messages.
@@ -47,63 +51,99 @@ func (p *Processor) InboxPost(ctx context.Context, w http.ResponseWriter, r *htt
 
 // OutboxGet returns the activitypub representation of a local user's outbox.
 // This contains links to PUBLIC posts made by this user.
-func (p *Processor) OutboxGet(
-	ctx context.Context,
-	requestedUser string,
-	page bool,
-	maxID string,
-	minID string,
-) (interface{}, gtserror.WithCode) {
+func (p *Processor) OutboxGet(ctx context.Context, requestedUser string, page *paging.Page) (interface{}, gtserror.WithCode) {
 	// Authenticate the incoming request, getting related user accounts.
 	_, receiver, errWithCode := p.authenticate(ctx, requestedUser)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	var data map[string]interface{}
-	// There are two scenarios:
-	// 1. we're asked for the whole collection and not a page -- we can just return the collection, with no items, but a link to 'first' page.
-	// 2. we're asked for a specific page; this can be either the first page or any other page
-
-	if !page {
-		/*
-			scenario 1: return the collection with no items
-			we want something that looks like this:
-			{
-				"@context": "https://www.w3.org/ns/activitystreams",
-				"id": "https://example.org/users/whatever/outbox",
-				"type": "OrderedCollection",
-				"first": "https://example.org/users/whatever/outbox?page=true",
-				"last": "https://example.org/users/whatever/outbox?min_id=0&page=true"
-			}
-		*/
-		collection, err := p.converter.OutboxToASCollection(ctx, receiver.OutboxURI)
-		if err != nil {
+	// Parse the collection ID object from account's outbox URI.
+	collectionID, err := url.Parse(receiver.OutboxURI)
+	if err != nil {
+		err := gtserror.Newf("error parsing account outbox uri %s: %w", receiver.OutboxURI, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Calculate total number of statuses available in account outbox.
+	total, err := p.state.DB.CountAccountOutboxStatuses(ctx, receiver.ID)
+	if err != nil {
+		err := gtserror.Newf("error counting outbox statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var obj vocab.Type
+
+	// Start the AS collection params.
+	var params ap.CollectionParams
+	params.ID = collectionID
+	params.Total = total
+
+	if page == nil {
+		// i.e. paging disabled, return collection
+		// that links to first page (i.e. path below).
+		params.Query = make(url.Values, 1)
+		params.Query.Set("limit", "40") // enables paging
+		obj = ap.NewASOrderedCollection(params)
+	} else {
+		// i.e. paging enabled
+
+		// Get the requested page of public, federated outbox statuses.
+		statuses, err := p.state.DB.GetAccountOutboxStatuses(ctx, receiver.ID, page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting outbox statuses: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
 
-		data, err = ap.Serialize(collection)
-		if err != nil {
-			return nil, gtserror.NewErrorInternalError(err)
+		// page ID values.
+		var lo, hi string
+
+		if len(statuses) > 0 {
+			// Get the lowest and highest
+			// ID values, used for paging.
+			lo = statuses[len(statuses)-1].ID
+			hi = statuses[0].ID
 		}
 
-		return data, nil
-	}
+		// Start building AS collection page params.
+		var pageParams ap.CollectionPageParams
+		pageParams.CollectionParams = params
 
-	// scenario 2 -- get the requested page
-	// limit pages to 30 entries per page
-	publicStatuses, err := p.state.DB.GetAccountStatuses(ctx, receiver.ID, 30, true, true, maxID, minID, false, true)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return nil, gtserror.NewErrorInternalError(err)
-	}
+		// Current page details.
+		pageParams.Current = page
+		pageParams.Count = len(statuses)
 
-	outboxPage, err := p.converter.StatusesToASOutboxPage(ctx, receiver.OutboxURI, maxID, minID, publicStatuses)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(err)
+		// Set linked next/prev parameters.
+		setPageLinks(&pageParams, page, lo, hi)
+
+		// Set the collection item property builder function.
+		pageParams.Append = func(i int, itemsProp ap.ItemsPropertyBuilder) {
+			// Get status at index.
+			status := statuses[i]
+
+			// Convert status to AS Statusable.
+			statusable, err := p.converter.StatusToAS(ctx, status)
+			if err != nil {
+				log.Errorf(ctx, "error converting status %s to AS: %v", status.URI, err)
+				return
+			}
+
+			// Wrap Statusable in a Create activity,
+			// linking to the object only by its IRI.
+			create := typeutils.WrapStatusableInCreate(statusable, true)
+
+			// Add to item property.
+			itemsProp.AppendActivityStreamsCreate(create)
+		}
+
+		// Build AS collection page object from params.
+		obj = ap.NewASOrderedCollectionPage(pageParams)
 	}
 
-	data, err = ap.Serialize(outboxPage)
+	// Serialized the prepared object.
+	data, err := ap.Serialize(obj)
 	if err != nil {
+		err := gtserror.Newf("error serializing: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -140,7 +180,11 @@ func (p *Processor) FollowersGet(ctx context.Context, requestedUser string, page
 	params.ID = collectionID
 	params.Total = total
 
-	if page == nil {
+	if util.PtrValueOr(receiver.HideCollections, false) {
+		// i.e. collection hidden, return
+		// only the total number of items.
+		obj = hiddenCollection(params)
+	} else if page == nil {
 		// i.e. paging disabled, return collection
 		// that links to first page (i.e. path below).
 		params.Query = make(url.Values, 1)
@@ -175,8 +219,7 @@ func (p *Processor) FollowersGet(ctx context.Context, requestedUser string, page
 		pageParams.Count = len(followers)
 
 		// Set linked next/prev parameters.
-		pageParams.Next = page.Next(lo, hi)
-		pageParams.Prev = page.Prev(lo, hi)
+		setPageLinks(&pageParams, page, lo, hi)
 
 		// Set the collection item property builder function.
 		pageParams.Append = func(i int, itemsProp ap.ItemsPropertyBuilder) {
@@ -239,7 +282,11 @@ func (p *Processor) FollowingGet(ctx context.Context, requestedUser string, page
 	params.ID = collectionID
 	params.Total = total
 
-	if page == nil {
+	if util.PtrValueOr(receiver.HideCollections, false) {
+		// i.e. collection hidden, return
+		// only the total number of items.
+		obj = hiddenCollection(params)
+	} else if page == nil {
 		// i.e. paging disabled, return collection
 		// that links to first page (i.e. path below).
 		params.Query = make(url.Values, 1)
@@ -274,14 +321,13 @@ func (p *Processor) FollowingGet(ctx context.Context, requestedUser string, page
 		pageParams.Count = len(follows)
 
 		// Set linked next/prev parameters.
-		pageParams.Next = page.Next(lo, hi)
-		pageParams.Prev = page.Prev(lo, hi)
+		setPageLinks(&pageParams, page, lo, hi)
 
 		// Set the collection item property builder function.
 		pageParams.Append = func(i int, itemsProp ap.ItemsPropertyBuilder) {
-			// Get follower URI at index.
+			// Get followed URI at index.
 			follow := follows[i]
-			accURI := follow.Account.URI
+			accURI := follow.TargetAccount.URI
 
 			// Parse URL object from URI.
 			iri, err := url.Parse(accURI)
@@ -308,6 +354,28 @@ func (p *Processor) FollowingGet(ctx context.Context, requestedUser string, page
 	return data, nil
 }
 
+// setPageLinks sets the next and prev page links of the given
+// collection page params, from the current page and the lowest
+// and highest item IDs contained in it. A next page is only linked
+// when the current page was filled to its limit, as otherwise
+// there are no further items to be found in that direction.
+func setPageLinks(params *ap.CollectionPageParams, page *paging.Page, lo, hi string) {
+	if limit := page.GetLimit(); limit > 0 && params.Count >= limit {
+		params.Next = page.Next(lo, hi)
+	}
+	params.Prev = page.Prev(lo, hi)
+}
+
+// hiddenCollection returns an ordered collection with
+// the given ID and total items, but without any link to
+// a first page, for use when an account has hidden
+// its followers / following collections.
+func hiddenCollection(params ap.CollectionParams) vocab.Type {
+	collection := ap.NewASOrderedCollection(params)
+	collection.SetActivityStreamsFirst(nil)
+	return collection
+}
+
 // FeaturedCollectionGet returns an ordered collection of the requested username's Pinned posts.
 // The returned collection have an `items` property which contains an ordered list of status URIs.
 func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser string) (interface{}, gtserror.WithCode) {
@@ -324,6 +392,10 @@ func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser str
 		}
 	}
 
+	// Drop any local-only statuses, these
+	// should never be exposed over federation.
+	statuses = slices.DeleteFunc(statuses, (*gtsmodel.Status).IsLocalOnly)
+
 	collection, err := p.converter.StatusesToASFeaturedCollection(ctx, receiver.FeaturedCollectionURI, statuses)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fedi
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// pruneInboxActivitiesEvery is the frequency at
+	// which old inbox activity records are pruned.
+	pruneInboxActivitiesEvery = time.Hour
+
+	// pruneInboxActivitiesAfter is the age after which
+	// inbox activity records are pruned, i.e. the horizon
+	// over which redundant deliveries are detected.
+	pruneInboxActivitiesAfter = 7 * 24 * time.Hour
+)
+
+// ScheduleInboxActivityPruning schedules records of
+// delivered inbox activities, used to drop redundant
+// deliveries, to be periodically pruned once old.
+func (p *Processor) ScheduleInboxActivityPruning() error {
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@inboxactivities",
+		time.Now(),
+		pruneInboxActivitiesEvery,
+		func(ctx context.Context, now time.Time) {
+			if err := p.PruneInboxActivities(ctx, now); err != nil {
+				log.Errorf(ctx, "error pruning inbox activities: %v", err)
+			}
+		},
+	) {
+		return gtserror.New("failed to schedule @inboxactivities")
+	}
+
+	return nil
+}
+
+// PruneInboxActivities deletes records of delivered
+// inbox activities older than the pruning horizon.
+func (p *Processor) PruneInboxActivities(ctx context.Context, now time.Time) error {
+	pruned, err := p.state.DB.DeleteInboxActivitiesOlderThan(ctx,
+		now.Add(-pruneInboxActivitiesAfter),
+	)
+	if err != nil {
+		return gtserror.Newf("error deleting inbox activities: %w", err)
+	}
+
+	log.Debugf(ctx, "pruned %d inbox activities", pruned)
+	return nil
+}
@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package markers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Export returns CSV records of the timeline markers
+// set by the given account, with a header row first.
+// Each row contains the timeline name, the last read
+// ID, the marker version, and when it was last updated.
+func (p *Processor) Export(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	records := [][]string{
+		{"timeline", "last_read_id", "version", "updated_at"},
+	}
+
+	for _, name := range []gtsmodel.MarkerName{
+		gtsmodel.MarkerNameHome,
+		gtsmodel.MarkerNameNotifications,
+	} {
+		marker, err := p.state.DB.GetMarker(ctx, account.ID, name)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Marker not set.
+				continue
+			}
+			err = gtserror.Newf("db error getting %s marker: %w", name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		records = append(records, []string{
+			string(marker.Name),
+			marker.LastReadID,
+			strconv.Itoa(marker.Version),
+			marker.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return records, nil
+}
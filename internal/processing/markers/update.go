@@ -28,15 +28,82 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-// Update updates the given markers and returns an API model for them.
-func (p *Processor) Update(ctx context.Context, markers []*gtsmodel.Marker) (*apimodel.Marker, gtserror.WithCode) {
-	for _, marker := range markers {
-		if err := p.state.DB.UpdateMarker(ctx, marker); err != nil {
+// Update updates the markers given in the form for the given account,
+// and returns an API model for them. Home and notifications markers
+// can be updated in a single request.
+//
+// If the client includes the version of a marker it last saw, and that
+// version is older than the version currently stored, the marker was
+// updated by another client in the meantime, and 409 is returned.
+func (p *Processor) Update(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.MarkerPostRequest,
+) (*apimodel.Marker, gtserror.WithCode) {
+	type markerUpdate struct {
+		marker  *gtsmodel.Marker
+		version *int
+	}
+
+	updates := make([]markerUpdate, 0, apimodel.MarkerNameNumValues)
+
+	if lastReadID := form.HomeLastReadID(); lastReadID != "" {
+		updates = append(updates, markerUpdate{
+			marker: &gtsmodel.Marker{
+				AccountID:  account.ID,
+				Name:       gtsmodel.MarkerNameHome,
+				LastReadID: lastReadID,
+			},
+			version: form.HomeVersion(),
+		})
+	}
+
+	if lastReadID := form.NotificationsLastReadID(); lastReadID != "" {
+		updates = append(updates, markerUpdate{
+			marker: &gtsmodel.Marker{
+				AccountID:  account.ID,
+				Name:       gtsmodel.MarkerNameNotifications,
+				LastReadID: lastReadID,
+			},
+			version: form.NotificationsVersion(),
+		})
+	}
+
+	// Check all submitted versions before
+	// updating anything, so that a conflict
+	// on one marker doesn't leave the other
+	// marker updated and this one not.
+	for _, update := range updates {
+		if update.version == nil {
+			// Client didn't
+			// say, just update.
+			continue
+		}
+
+		prev, err := p.state.DB.GetMarker(ctx, account.ID, update.marker.Name)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting %s marker: %w", update.marker.Name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if prev != nil && *update.version < prev.Version {
+			text := fmt.Sprintf(
+				"%s marker version %d is older than stored version %d",
+				update.marker.Name, *update.version, prev.Version,
+			)
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+	}
+
+	markers := make([]*gtsmodel.Marker, 0, len(updates))
+	for _, update := range updates {
+		if err := p.state.DB.UpdateMarker(ctx, update.marker); err != nil {
 			if errors.Is(err, db.ErrAlreadyExists) {
 				return nil, gtserror.NewErrorConflict(err, "marker updated by another client")
 			}
 			return nil, gtserror.NewErrorInternalError(err)
 		}
+		markers = append(markers, update.marker)
 	}
 
 	apiMarker, err := p.converter.MarkersToAPIMarker(ctx, markers)
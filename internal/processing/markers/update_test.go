@@ -0,0 +1,128 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package markers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/markers"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MarkersTestSuite struct {
+	suite.Suite
+	state   state.State
+	markers markers.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+}
+
+func (suite *MarkersTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+	suite.markers = markers.New(&suite.state, typeutils.NewConverter(&suite.state))
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *MarkersTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *MarkersTestSuite) TestUpdateBoth() {
+	account := suite.testAccounts["local_account_1"]
+
+	apiMarker, errWithCode := suite.markers.Update(context.Background(), account, &apimodel.MarkerPostRequest{
+		Home: &apimodel.MarkerPostRequestMarker{
+			LastReadID: "01F8MHAMCHF6Y650WCRSCP4WMY",
+			Version:    util.Ptr(0),
+		},
+		Notifications: &apimodel.MarkerPostRequestMarker{
+			LastReadID: "01F8Q0ANPTWW10DAKTX7BRPBJQ",
+			Version:    util.Ptr(4),
+		},
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Both markers should be updated,
+	// and their versions incremented.
+	suite.Equal("01F8MHAMCHF6Y650WCRSCP4WMY", apiMarker.Home.LastReadID)
+	suite.Equal(1, apiMarker.Home.Version)
+	suite.Equal("01F8Q0ANPTWW10DAKTX7BRPBJQ", apiMarker.Notifications.LastReadID)
+	suite.Equal(5, apiMarker.Notifications.Version)
+}
+
+func (suite *MarkersTestSuite) TestUpdateOutdatedVersion() {
+	account := suite.testAccounts["local_account_1"]
+
+	// Stored notifications marker is version 4.
+	_, errWithCode := suite.markers.Update(context.Background(), account, &apimodel.MarkerPostRequest{
+		Home: &apimodel.MarkerPostRequestMarker{
+			LastReadID: "01F8MHAMCHF6Y650WCRSCP4WMY",
+		},
+		Notifications: &apimodel.MarkerPostRequestMarker{
+			LastReadID: "01F8Q0ANPTWW10DAKTX7BRPBJQ",
+			Version:    util.Ptr(3),
+		},
+	})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Home marker should not
+	// have been updated either.
+	apiMarker, errWithCode := suite.markers.Get(context.Background(), account, []apimodel.MarkerName{
+		apimodel.MarkerNameHome,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("01F8MH82FYRXD2RC6108DAJ5HB", apiMarker.Home.LastReadID)
+	suite.Equal(0, apiMarker.Home.Version)
+}
+
+func (suite *MarkersTestSuite) TestExport() {
+	account := suite.testAccounts["local_account_1"]
+
+	records, errWithCode := suite.markers.Export(context.Background(), account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal([][]string{
+		{"timeline", "last_read_id", "version", "updated_at"},
+		{"home", "01F8MH82FYRXD2RC6108DAJ5HB", "0", "2022-05-14T11:21:09Z"},
+		{"notifications", "01F8Q0ANPTWW10DAKTX7BRPBJP", "4", "2022-05-14T11:21:09Z"},
+	}, records)
+}
+
+func TestMarkersTestSuite(t *testing.T) {
+	suite.Run(t, new(MarkersTestSuite))
+}
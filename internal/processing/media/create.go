@@ -30,6 +30,42 @@ import (
 
 // Create creates a new media attachment belonging to the given account, using the request form.
 func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	media, errWithCode := p.preProcess(account, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// process the media attachment and load it immediately
+	attachment, err := media.LoadAttachment(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	} else if attachment.Type == gtsmodel.FileTypeUnknown {
+		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return p.toAPIAttachment(ctx, attachment)
+}
+
+// CreateAsync is like Create, but only stores the uploaded file before
+// returning a partial attachment. Further processing continues in the
+// media worker pool, with progress visible via Get.
+func (p *Processor) CreateAsync(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	media, errWithCode := p.preProcess(account, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	attachment, err := media.LoadAttachmentAsync(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return p.toAPIAttachment(ctx, attachment)
+}
+
+// preProcess prepares processing of the media in form.
+func (p *Processor) preProcess(account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*media.ProcessingMedia, gtserror.WithCode) {
 	data := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
 		f, err := form.File.Open()
 		return f, form.File.Size, err
@@ -41,21 +77,14 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
-	// process the media attachment and load it immediately
-	media := p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
+	return p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
 		Description: &form.Description,
 		FocusX:      &focusX,
 		FocusY:      &focusY,
-	})
-
-	attachment, err := media.LoadAttachment(ctx)
-	if err != nil {
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
-	} else if attachment.Type == gtsmodel.FileTypeUnknown {
-		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
-	}
+	}), nil
+}
 
+func (p *Processor) toAPIAttachment(ctx context.Context, attachment *gtsmodel.MediaAttachment) (*apimodel.Attachment, gtserror.WithCode) {
 	apiAttachment, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
 		err := fmt.Errorf("error parsing media attachment to frontend type: %s", err)
@@ -26,6 +26,7 @@ import (
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
 // Delete deletes the media attachment with the given ID, including all files pertaining to that attachment.
@@ -49,6 +50,13 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 		}
 	}
 
+	// delete the thumbnail's jpeg fallback, if any
+	if fallback := media.ThumbnailFallbackPath(attachment.Thumbnail.Path); fallback != "" {
+		if err := p.state.Storage.Delete(ctx, fallback); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			errs = append(errs, fmt.Sprintf("remove thumbnail fallback at path %s: %s", fallback, err))
+		}
+	}
+
 	// delete the file from storage
 	if attachment.File.Path != "" {
 		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
@@ -18,17 +18,24 @@
 package media
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -85,7 +92,7 @@ func (p *Processor) GetFile(
 	case media.TypeEmoji:
 		return p.getEmojiContent(ctx, wantedMediaID, owningAccountID, mediaSize)
 	case media.TypeAttachment, media.TypeHeader, media.TypeAvatar:
-		return p.getAttachmentContent(ctx, requestingAccount, wantedMediaID, owningAccountID, mediaSize)
+		return p.getAttachmentContent(ctx, requestingAccount, mediaType, wantedMediaID, owningAccountID, mediaSize, form.PreferJPEG)
 	default:
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media type %s not recognized", mediaType))
 	}
@@ -121,7 +128,7 @@ func parseSize(s string) (media.Size, error) {
 	return "", fmt.Errorf("%s not a recognized media.Size", s)
 }
 
-func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount *gtsmodel.Account, wantedMediaID string, owningAccountID string, mediaSize media.Size) (*apimodel.Content, gtserror.WithCode) {
+func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount *gtsmodel.Account, mediaType media.Type, wantedMediaID string, owningAccountID string, mediaSize media.Size, preferJPEG bool) (*apimodel.Content, gtserror.WithCode) {
 	// retrieve attachment from the database and do basic checks on it
 	a, err := p.state.DB.GetAttachmentByID(ctx, wantedMediaID)
 	if err != nil {
@@ -217,6 +224,16 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		attachmentContent.ContentLength = int64(a.File.FileSize)
 		storagePath = a.File.Path
 	case media.SizeSmall:
+		if fallback := media.ThumbnailFallbackPath(a.Thumbnail.Path); preferJPEG && fallback != "" {
+			err := p.getThumbnailFallback(ctx, fallback, attachmentContent)
+			if err == nil {
+				return attachmentContent, nil
+			}
+
+			// Serve the webp thumbnail rather than nothing.
+			log.Warnf(ctx, "error loading thumbnail fallback %s: %v", fallback, err)
+		}
+
 		attachmentContent.ContentType = a.Thumbnail.ContentType
 		attachmentContent.ContentLength = int64(a.Thumbnail.FileSize)
 		storagePath = a.Thumbnail.Path
@@ -225,11 +242,26 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 	}
 
 	// ... so now we can safely return it
-	return p.retrieveFromStorage(ctx, storagePath, attachmentContent)
+	return p.retrieveFromStorage(ctx, mediaType, storagePath, attachmentContent)
+}
+
+// getThumbnailFallback loads the JPEG copy of a WebP thumbnail
+// kept at path into content. Its size isn't in the database,
+// so it's read into memory in full; thumbnails are small.
+func (p *Processor) getThumbnailFallback(ctx context.Context, path string, content *apimodel.Content) error {
+	b, err := p.state.Storage.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	content.ContentType = "image/jpeg"
+	content.ContentLength = int64(len(b))
+	content.ContentETag = contentETag(path, content.ContentLength, content.ContentUpdated)
+	content.Content = io.NopCloser(bytes.NewReader(b))
+	return nil
 }
 
 func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owningAccountID string, emojiSize media.Size) (*apimodel.Content, gtserror.WithCode) {
-	emojiContent := &apimodel.Content{}
 	var storagePath string
 
 	// reconstruct the static emoji image url -- reason
@@ -283,6 +315,10 @@ func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owning
 		}
 	}
 
+	emojiContent := &apimodel.Content{
+		ContentUpdated: e.UpdatedAt,
+	}
+
 	switch emojiSize {
 	case media.SizeOriginal:
 		emojiContent.ContentType = e.ImageContentType
@@ -296,15 +332,28 @@ func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owning
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media size %s not recognized for emoji", emojiSize))
 	}
 
-	return p.retrieveFromStorage(ctx, storagePath, emojiContent)
+	return p.retrieveFromStorage(ctx, media.TypeEmoji, storagePath, emojiContent)
 }
 
-func (p *Processor) retrieveFromStorage(ctx context.Context, storagePath string, content *apimodel.Content) (*apimodel.Content, gtserror.WithCode) {
-	// If running on S3 storage with proxying disabled then
-	// just fetch a pre-signed URL instead of serving the content.
-	if url := p.state.Storage.URL(ctx, storagePath); url != nil {
-		content.URL = url
-		return content, nil
+func (p *Processor) retrieveFromStorage(ctx context.Context, mediaType media.Type, storagePath string, content *apimodel.Content) (*apimodel.Content, gtserror.WithCode) {
+	// Stored files are never modified in place, only
+	// ever replaced at a new path, so the path, size and
+	// update time together identify this exact content.
+	content.ContentETag = contentETag(
+		storagePath,
+		content.ContentLength,
+		content.ContentUpdated,
+	)
+
+	// If running on S3 storage with proxying disabled (for
+	// this type of media) then just fetch a pre-signed URL
+	// instead of serving the content. By this point all the
+	// permission checks on the requested media have passed.
+	if !slices.Contains(config.GetStorageS3ProxyMediaTypes(), string(mediaType)) {
+		if url := p.state.Storage.URL(ctx, storagePath); url != nil {
+			content.URL = url
+			return content, nil
+		}
 	}
 
 	reader, err := p.state.Storage.GetStream(ctx, storagePath)
@@ -315,3 +364,14 @@ func (p *Processor) retrieveFromStorage(ctx context.Context, storagePath string,
 	content.Content = reader
 	return content, nil
 }
+
+// contentETag derives a strong, quoted entity
+// tag for the content stored at the given path.
+func contentETag(storagePath string, size int64, updated time.Time) string {
+	sum := sha256.Sum256([]byte(
+		storagePath + ":" +
+			strconv.FormatInt(size, 10) + ":" +
+			strconv.FormatInt(updated.UnixNano(), 10),
+	))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
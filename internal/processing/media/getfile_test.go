@@ -22,6 +22,7 @@ import (
 	"context"
 	"io"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -208,6 +209,68 @@ func (suite *GetFileTestSuite) TestGetRemoteFileThumbnailUncached() {
 	suite.EqualValues(testAttachment.Thumbnail.FileSize, content.ContentLength)
 }
 
+func (suite *GetFileTestSuite) TestGetThumbnailWebPFallback() {
+	ctx := context.Background()
+
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	jpegPath := testAttachment.Thumbnail.Path
+	jpegBytes, err := suite.storage.Get(ctx, jpegPath)
+	suite.NoError(err)
+
+	// Swap in a webp thumbnail, keeping the
+	// existing jpeg as its fallback copy.
+	webpPath := strings.TrimSuffix(jpegPath, ".jpg") + ".webp"
+	webpBytes := []byte("not really a webp")
+	_, err = suite.storage.Put(ctx, webpPath, webpBytes)
+	suite.NoError(err)
+
+	testAttachment.Thumbnail.Path = webpPath
+	testAttachment.Thumbnail.ContentType = "image/webp"
+	testAttachment.Thumbnail.FileSize = len(webpBytes)
+	err = suite.db.UpdateAttachment(ctx, testAttachment,
+		"thumbnail_path",
+		"thumbnail_content_type",
+		"thumbnail_file_size",
+	)
+	suite.NoError(err)
+	suite.Equal(jpegPath, media.ThumbnailFallbackPath(webpPath))
+
+	getThumbnail := func(preferJPEG bool) ([]byte, *apimodel.Content) {
+		content, errWithCode := suite.mediaProcessor.GetFile(ctx, nil, &apimodel.GetContentRequestForm{
+			AccountID:  testAttachment.AccountID,
+			MediaType:  string(media.TypeAttachment),
+			MediaSize:  string(media.SizeSmall),
+			FileName:   path.Base(webpPath),
+			PreferJPEG: preferJPEG,
+		})
+		suite.NoError(errWithCode)
+		suite.NotNil(content)
+
+		b, err := io.ReadAll(content.Content)
+		suite.NoError(err)
+		suite.NoError(content.Content.Close())
+		return b, content
+	}
+
+	// By default the webp is served.
+	b, content := getThumbnail(false)
+	suite.Equal(webpBytes, b)
+	suite.Equal("image/webp", content.ContentType)
+	suite.EqualValues(len(webpBytes), content.ContentLength)
+
+	// Requesters preferring jpeg get the fallback.
+	b, content = getThumbnail(true)
+	suite.Equal(jpegBytes, b)
+	suite.Equal("image/jpeg", content.ContentType)
+	suite.EqualValues(len(jpegBytes), content.ContentLength)
+
+	// Without a fallback, the webp is served anyway.
+	suite.NoError(suite.storage.Delete(ctx, jpegPath))
+	b, content = getThumbnail(true)
+	suite.Equal(webpBytes, b)
+	suite.Equal("image/webp", content.ContentType)
+}
+
 func TestGetFileTestSuite(t *testing.T) {
 	suite.Run(t, &GetFileTestSuite{})
 }
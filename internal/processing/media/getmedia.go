@@ -28,24 +28,41 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, mediaAttachmentID string) (*apimodel.Attachment, gtserror.WithCode) {
+// Get returns the attachment with given ID belonging to account, and
+// whether it's still being processed, as uploaded with CreateAsync.
+// While processing, the attachment has no URL. If processing failed,
+// an error is returned so the client knows to upload again.
+func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, mediaAttachmentID string) (*apimodel.Attachment, bool, gtserror.WithCode) {
 	attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaAttachmentID)
 	if err != nil {
 		if err == db.ErrNoEntries {
 			// attachment doesn't exist
-			return nil, gtserror.NewErrorNotFound(errors.New("attachment doesn't exist in the db"))
+			return nil, false, gtserror.NewErrorNotFound(errors.New("attachment doesn't exist in the db"))
 		}
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("db error getting attachment: %s", err))
+		return nil, false, gtserror.NewErrorNotFound(fmt.Errorf("db error getting attachment: %s", err))
 	}
 
 	if attachment.AccountID != account.ID {
-		return nil, gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
+		return nil, false, gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
+	}
+
+	if attachment.Processing == gtsmodel.ProcessingStatusError {
+		const text = "processing of uploaded media failed, please upload it again"
+		return nil, false, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 	}
 
 	a, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
+		return nil, false, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
+	}
+
+	processing := attachment.Processing == gtsmodel.ProcessingStatusProcessing
+	if processing {
+		// Not ready to be
+		// served just yet.
+		a.URL = nil
+		a.TextURL = nil
 	}
 
-	return &a, nil
+	return &a, processing, nil
 }
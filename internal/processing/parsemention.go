@@ -60,9 +60,9 @@ func GetParseMentionFunc(state *state.State, federator *federation.Federator) gt
 		//   - "@someone" with no host component.
 		//   - "@someone@gts.example.org" and we're host "gts.example.org".
 		//   - "@someone@example.org" and we're account-domain "example.org".
+		//   - "@someone@www.example.org" and that's one of our host-aliases.
 		local := targetHost == "" ||
-			targetHost == config.GetHost() ||
-			targetHost == config.GetAccountDomain()
+			config.IsLocalHost(targetHost)
 
 		// Either a local or remote
 		// target for the mention.
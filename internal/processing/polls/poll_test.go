@@ -22,17 +22,22 @@ import (
 	"math/rand"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -52,8 +57,10 @@ func (suite *PollTestSuite) SetupTest() {
 	suite.state.Caches.Init()
 	testrig.StartNoopWorkers(&suite.state)
 	testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
 	converter := typeutils.NewConverter(&suite.state)
-	controller := testrig.NewTestTransportController(&suite.state, nil)
+	httpClient := testrig.NewMockHTTPClient(nil, "../../../testrig/media")
+	controller := testrig.NewTestTransportController(&suite.state, httpClient)
 	mediaMgr := media.NewManager(&suite.state)
 	federator := testrig.NewTestFederator(&suite.state, controller, mediaMgr)
 	suite.filter = visibility.NewFilter(&suite.state)
@@ -208,8 +215,158 @@ func (suite *PollTestSuite) testPollVote(ctx context.Context, requester *gtsmode
 	}
 }
 
+func (suite *PollTestSuite) TestPollVoteOwnPoll() {
+	ctx := context.Background()
+	requester := testrig.NewTestAccounts()["local_account_2"]
+	poll := suite.openPoll(ctx, "local_account_2_status_8_poll", false)
+
+	_, errWithCode := suite.polls.PollVote(ctx, requester, poll.ID, []int{0})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *PollTestSuite) TestPollVoteExpired() {
+	ctx := context.Background()
+	requester := testrig.NewTestAccounts()["admin_account"]
+
+	// Poll expired, but hasn't been closed yet.
+	poll := testrig.NewTestPolls()["local_account_1_status_6_poll"]
+
+	_, errWithCode := suite.polls.PollVote(ctx, requester, poll.ID, []int{0})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *PollTestSuite) TestPollVoteInvalidChoices() {
+	ctx := context.Background()
+	requester := testrig.NewTestAccounts()["admin_account"]
+	poll := suite.openPoll(ctx, "local_account_2_status_8_poll", false)
+
+	for _, choices := range [][]int{
+		nil,
+		{-1},
+		{len(poll.Options)},
+		{0, 1}, // single-choice poll
+	} {
+		_, errWithCode := suite.polls.PollVote(ctx, requester, poll.ID, choices)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code(), "choices %v", choices)
+	}
+
+	// None of the above should have been counted.
+	suite.assertVotes(ctx, poll.ID, []int{0, 1, 1}, 2)
+}
+
+func (suite *PollTestSuite) TestPollVoteSingleChoiceTwice() {
+	ctx := context.Background()
+	requester := testrig.NewTestAccounts()["admin_account"]
+	poll := suite.openPoll(ctx, "local_account_2_status_8_poll", false)
+
+	// Duplicate choices count as one
+	// vote, even in a single-choice poll.
+	apiPoll, errWithCode := suite.polls.PollVote(ctx, requester, poll.ID, []int{0, 0})
+	suite.NoError(errWithCode)
+	suite.Equal([]int{0}, *apiPoll.OwnVotes)
+
+	// Voting again isn't allowed,
+	// even with revotes enabled.
+	config.SetStatusesPollAllowRevote(true)
+	_, errWithCode = suite.polls.PollVote(ctx, requester, poll.ID, []int{1})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Only the first vote was counted.
+	suite.assertVotes(ctx, poll.ID, []int{1, 1, 1}, 3)
+}
+
+func (suite *PollTestSuite) TestPollVoteMultipleRevote() {
+	ctx := context.Background()
+	requester := testrig.NewTestAccounts()["admin_account"]
+	poll := suite.openPoll(ctx, "local_account_2_status_8_poll", true)
+
+	apiPoll, errWithCode := suite.polls.PollVote(ctx, requester, poll.ID, []int{0, 2, 0})
+	suite.NoError(errWithCode)
+	suite.Equal([]int{0, 2}, *apiPoll.OwnVotes)
+	suite.assertVotes(ctx, poll.ID, []int{1, 1, 2}, 3)
+
+	// Revotes are disabled by default.
+	_, errWithCode = suite.polls.PollVote(ctx, requester, poll.ID, []int{1})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.assertVotes(ctx, poll.ID, []int{1, 1, 2}, 3)
+
+	// With revotes enabled, the new
+	// choices replace the old ones.
+	config.SetStatusesPollAllowRevote(true)
+	apiPoll, errWithCode = suite.polls.PollVote(ctx, requester, poll.ID, []int{1})
+	suite.NoError(errWithCode)
+	suite.Equal([]int{1}, *apiPoll.OwnVotes)
+	suite.Equal(3, apiPoll.VotesCount)
+	suite.assertVotes(ctx, poll.ID, []int{0, 2, 1}, 3)
+}
+
+func (suite *PollTestSuite) TestPollVoteRemote() {
+	ctx := context.Background()
+	requester := testrig.NewTestAccounts()["admin_account"]
+	poll := suite.openPoll(ctx, "remote_account_1_status_2_poll", true)
+
+	// Capture messages enqueued for the
+	// worker, which federates the vote.
+	var msgs []messages.FromClientAPI
+	suite.state.Workers.EnqueueClientAPI = func(_ context.Context, m ...messages.FromClientAPI) {
+		msgs = append(msgs, m...)
+	}
+
+	// Revotes are never allowed in remote polls.
+	config.SetStatusesPollAllowRevote(true)
+
+	_, errWithCode := suite.polls.PollVote(ctx, requester, poll.ID, []int{2, 2, 1})
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.polls.PollVote(ctx, requester, poll.ID, []int{0})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Vote should be federated exactly once.
+	if suite.Len(msgs, 1) {
+		vote, ok := msgs[0].GTSModel.(*gtsmodel.PollVote)
+		suite.True(ok)
+		suite.Equal(ap.ActivityCreate, msgs[0].APActivityType)
+		suite.Equal(ap.ActivityQuestion, msgs[0].APObjectType)
+		suite.Equal([]int{2, 1}, vote.Choices)
+	}
+
+	suite.assertVotes(ctx, poll.ID, []int{3, 3, 19}, 7)
+}
+
+// openPoll reopens the test poll with key until an hour
+// from now, optionally making it multiple-choice.
+func (suite *PollTestSuite) openPoll(ctx context.Context, key string, multiple bool) *gtsmodel.Poll {
+	poll := testrig.NewTestPolls()[key]
+	poll.Multiple = &multiple
+	poll.ExpiresAt = time.Now().Add(time.Hour)
+	poll.ClosedAt = time.Time{}
+
+	if err := suite.state.DB.UpdatePoll(ctx, poll,
+		"multiple",
+		"expires_at",
+		"closed_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return poll
+}
+
+// assertVotes checks the stored vote
+// counts and voters of poll with ID.
+func (suite *PollTestSuite) assertVotes(ctx context.Context, pollID string, votes []int, voters int) {
+	poll, err := suite.state.DB.GetPollByID(ctx, pollID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(votes, poll.Votes)
+	suite.Equal(voters, *poll.Voters)
+}
+
 // voteChoicesAreValid is a utility function to check whether choices are valid for poll.
 func voteChoicesAreValid(poll *gtsmodel.Poll, choices []int) bool {
+	choices = util.Deduplicate(choices)
 	if len(choices) == 0 || !*poll.Multiple && len(choices) > 1 {
 		// Invalid number of vote choices.
 		return false
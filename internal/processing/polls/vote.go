@@ -23,11 +23,13 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, pollID string, choices []int) (*apimodel.Poll, gtserror.WithCode) {
@@ -44,10 +46,16 @@ func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, p
 		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 
 	// Poll has already closed, no more voting!
-	case poll.Closed():
+	case poll.Closed() || poll.Expired():
 		const text = "poll already closed"
 		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Voting for the same choice more
+	// than once counts as a single vote.
+	choices = util.Deduplicate(choices)
 
+	switch {
 	// No choices given, or multiple given for single-choice poll.
 	case len(choices) == 0 || (!*poll.Multiple && len(choices) > 1):
 		const text = "invalid number of choices for poll"
@@ -62,6 +70,31 @@ func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, p
 		}
 	}
 
+	// Check for an existing vote by requester.
+	prev, err := p.state.DB.GetPollVoteBy(ctx, pollID, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error getting existing poll vote: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if prev != nil {
+		if !canRevote(poll) {
+			// Users cannot vote multiple *times* (not choices).
+			const text = "you have already voted in poll"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		// Drop the previous vote, to be
+		// replaced by the new choices.
+		if err := p.state.DB.DeletePollVoteBy(ctx, pollID, requester.ID); err != nil {
+			err := gtserror.Newf("error deleting previous poll vote: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// And reflect this on our copy.
+		poll.DecrementVotes(prev.Choices)
+	}
+
 	// Wrap the choices in a PollVote model.
 	vote := &gtsmodel.PollVote{
 		ID:        id.NewULID(),
@@ -73,7 +106,7 @@ func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, p
 	}
 
 	// Insert the new poll votes into the database.
-	err := p.state.DB.PutPollVote(ctx, vote)
+	err = p.state.DB.PutPollVote(ctx, vote)
 	switch {
 
 	case err == nil:
@@ -106,3 +139,13 @@ func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, p
 	// Return converted API model poll.
 	return p.toAPIPoll(ctx, requester, poll)
 }
+
+// canRevote returns whether votes in poll may be replaced by
+// voting again. Only local multiple-choice polls allow this,
+// as there's no way to federate a changed vote to a remote
+// poll, nor to tell whether its origin would accept one.
+func canRevote(poll *gtsmodel.Poll) bool {
+	return config.GetStatusesPollAllowRevote() &&
+		*poll.Multiple &&
+		*poll.Status.Local
+}
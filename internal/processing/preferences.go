@@ -23,6 +23,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *Processor) PreferencesGet(ctx context.Context, accountID string) (*apimodel.Preferences, gtserror.WithCode) {
@@ -31,15 +32,20 @@ func (p *Processor) PreferencesGet(ctx context.Context, accountID string) (*apim
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	expandMedia := apimodel.ExpandMediaDefault
+	if act.ExpandMedia != "" {
+		expandMedia = act.ExpandMedia
+	}
+
 	return &apimodel.Preferences{
 		PostingDefaultVisibility: mastoPrefVisibility(act.Privacy),
 		PostingDefaultSensitive:  *act.Sensitive,
 		PostingDefaultLanguage:   act.Language,
-		// The Reading* preferences don't appear to actually be settable by the
-		// client, so forcing some sensible defaults here
-		ReadingExpandMedia:    "default",
-		ReadingExpandSpoilers: false,
-		ReadingAutoPlayGifs:   false,
+		ReadingExpandMedia:       expandMedia,
+		ReadingExpandSpoilers:    util.PtrValueOr(act.ExpandSpoilers, false),
+		// Autoplaying gifs isn't settable
+		// yet, so force a sensible default.
+		ReadingAutoPlayGifs: false,
 	}, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type PreferencesTestSuite struct {
@@ -69,6 +70,24 @@ func (suite *PreferencesTestSuite) TestPreferencesGet() {
 	}
 }
 
+func (suite *PreferencesTestSuite) TestPreferencesGetReading() {
+	ctx := context.Background()
+
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.ExpandSpoilers = util.Ptr(true)
+	testAccount.ExpandMedia = model.ExpandMediaShowAll
+
+	if err := suite.db.UpdateAccount(ctx, testAccount, "expand_spoilers", "expand_media"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	prefs, err := suite.processor.PreferencesGet(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal("show_all", prefs.ReadingExpandMedia)
+	suite.True(prefs.ReadingExpandSpoilers)
+}
+
 func TestPreferencesTestSuite(t *testing.T) {
 	suite.Run(t, &PreferencesTestSuite{})
 }
@@ -388,9 +388,47 @@ func (p *Processor) accountsByUsernameDomain(
 		}
 	} else {
 		appendAccount(foundAccount)
+
+		if limit == 1 {
+			// Page is full.
+			return nil
+		}
+
+		// Leave room for exact match.
+		limit--
 	}
 
-	return nil
+	// Follow up the exact match (if any) with other
+	// known accounts with usernames starting with the
+	// given username. Unlike the exact match, which
+	// the caller may be looking for to unblock it,
+	// these should not include blocked accounts.
+	return p.accountsByText(
+		ctx,
+		requestingAccount.ID,
+		maxID,
+		minID,
+		limit,
+		offset,
+		"@"+username,
+		following,
+		func(account *gtsmodel.Account) {
+			if foundAccount != nil && account.ID == foundAccount.ID {
+				// Already appended.
+				return
+			}
+
+			blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, account.ID)
+			if err != nil {
+				log.Errorf(ctx, "error checking block with %s: %v", account.ID, err)
+				return
+			}
+
+			if !blocked {
+				appendAccount(account)
+			}
+		},
+	)
 }
 
 // accountByUsernameDomain looks for one account with the given
@@ -407,7 +445,7 @@ func (p *Processor) accountByUsernameDomain(
 	resolve bool,
 ) (*gtsmodel.Account, error) {
 	var usernameDomain string
-	if domain == "" || domain == config.GetHost() || domain == config.GetAccountDomain() {
+	if domain == "" || config.IsLocalHost(domain) {
 		// Local lookup, normalize domain.
 		domain = ""
 		usernameDomain = username
@@ -731,6 +769,10 @@ func (p *Processor) byText(
 	appendAccount func(*gtsmodel.Account),
 	appendStatus func(*gtsmodel.Status),
 ) error {
+	// Text searches tolerate being slightly
+	// stale, so may be served from a replica.
+	ctx = gtscontext.SetReplicaRead(ctx)
+
 	if queryType == queryTypeAny {
 		// If search type is any, ignore maxID and minID
 		// parameters, since we can't use them to page
@@ -775,6 +817,10 @@ func (p *Processor) byText(
 
 // accountsByText searches in the database for limit
 // number of accounts using the given query text.
+//
+// When fetching the first page of results, accounts
+// followed by the requester are ranked first, and the
+// rest of the page is filled up with other accounts.
 func (p *Processor) accountsByText(
 	ctx context.Context,
 	requestingAccountID string,
@@ -786,6 +832,31 @@ func (p *Processor) accountsByText(
 	following bool,
 	appendAccount func(*gtsmodel.Account),
 ) error {
+	// IDs of accounts already appended.
+	appended := make(map[string]struct{})
+
+	if !following && maxID == "" && minID == "" {
+		// First page of a search not restricted
+		// to followed accounts; rank those first.
+		followed, err := p.state.DB.SearchForAccounts(
+			ctx,
+			requestingAccountID,
+			query, maxID, minID, limit, true, offset)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error checking database for followed accounts using text %s: %w", query, err)
+		}
+
+		for _, account := range followed {
+			appended[account.ID] = struct{}{}
+			appendAccount(account)
+		}
+
+		if limit > 0 && len(followed) >= limit {
+			// Page is full.
+			return nil
+		}
+	}
+
 	accounts, err := p.state.DB.SearchForAccounts(
 		ctx,
 		requestingAccountID,
@@ -795,6 +866,17 @@ func (p *Processor) accountsByText(
 	}
 
 	for _, account := range accounts {
+		if limit > 0 && len(appended) >= limit {
+			// Page is full.
+			break
+		}
+
+		if _, ok := appended[account.ID]; ok {
+			// Already ranked first.
+			continue
+		}
+
+		appended[account.ID] = struct{}{}
 		appendAccount(account)
 	}
 
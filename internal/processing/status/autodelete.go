@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+const (
+	// autoDeleteEvery is the frequency at which
+	// old statuses are automatically deleted.
+	autoDeleteEvery = time.Hour
+
+	// autoDeleteAccountLimit is the maximum number of
+	// statuses of any one account deleted per run, so
+	// that enabling auto-delete on an old account doesn't
+	// flood the federator with Delete activities at once.
+	autoDeleteAccountLimit = 50
+
+	// autoDeleteLimit is the maximum number of
+	// statuses deleted per run, across all accounts.
+	autoDeleteLimit = 500
+)
+
+// ScheduleAutoDelete schedules old statuses of accounts
+// that have enabled automatic deletion of statuses to
+// be periodically deleted, in batches.
+func (p *Processor) ScheduleAutoDelete() error {
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@statusesautodelete",
+		time.Now(),
+		autoDeleteEvery,
+		func(ctx context.Context, now time.Time) {
+			if err := p.AutoDelete(ctx, now); err != nil {
+				log.Errorf(ctx, "error auto-deleting statuses: %v", err)
+			}
+		},
+	) {
+		return gtserror.New("failed to schedule @statusesautodelete")
+	}
+
+	return nil
+}
+
+// AutoDelete enqueues deletion of statuses that are older
+// than their author's auto-delete age as of the given time.
+// Deletions go through the same client API pathway as statuses
+// deleted by their author, so side effects are the same.
+func (p *Processor) AutoDelete(ctx context.Context, now time.Time) error {
+	accounts, err := p.state.DB.GetAccountsWithStatusesAutoDelete(ctx)
+	if err != nil {
+		return gtserror.Newf("error getting accounts with auto-delete enabled: %w", err)
+	}
+
+	remaining := autoDeleteLimit
+	for _, account := range accounts {
+		if remaining <= 0 {
+			// Leave the rest
+			// for the next run.
+			break
+		}
+
+		limit := min(remaining, autoDeleteAccountLimit)
+		olderThan := now.AddDate(0, 0, -account.StatusesAutoDeleteAfterDays)
+
+		statuses, err := p.state.DB.GetAccountAutoDeleteStatuses(ctx,
+			account,
+			olderThan,
+			limit,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting statuses to auto-delete for account %s: %v", account.ID, err)
+			continue
+		}
+
+		for _, status := range statuses {
+			p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityDelete,
+				GTSModel:       status,
+				OriginAccount:  account,
+				TargetAccount:  account,
+			})
+		}
+
+		remaining -= len(statuses)
+	}
+
+	return nil
+}
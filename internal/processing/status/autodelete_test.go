@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+type StatusAutoDeleteTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusAutoDeleteTestSuite) TestAutoDelete() {
+	ctx := context.Background()
+
+	// Capture deletions enqueued by auto-delete.
+	var msgs []messages.FromClientAPI
+	suite.state.Workers.EnqueueClientAPI = func(_ context.Context, m ...messages.FromClientAPI) {
+		msgs = append(msgs, m...)
+	}
+
+	// Nobody has auto-delete enabled yet.
+	if err := suite.status.AutoDelete(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(msgs)
+
+	testAccount := suite.testAccounts["admin_account"]
+	testAccount.StatusesAutoDeleteAfterDays = 1
+	if err := suite.db.UpdateAccount(ctx, testAccount, "statuses_auto_delete_after_days"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.status.AutoDelete(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(msgs)
+
+	for _, msg := range msgs {
+		suite.Equal(ap.ObjectNote, msg.APObjectType)
+		suite.Equal(ap.ActivityDelete, msg.APActivityType)
+		suite.Equal(testAccount.ID, msg.OriginAccount.ID)
+
+		status, ok := msg.GTSModel.(*gtsmodel.Status)
+		if !ok {
+			suite.FailNow("expected *gtsmodel.Status")
+		}
+		suite.Equal(testAccount.ID, status.AccountID)
+
+		// Pinned statuses are kept by default.
+		suite.True(status.PinnedAt.IsZero())
+	}
+}
+
+func TestStatusAutoDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusAutoDeleteTestSuite))
+}
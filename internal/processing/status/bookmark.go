@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -76,6 +77,14 @@ func (p *Processor) BookmarkCreate(ctx context.Context, requestingAccount *gtsmo
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if config.GetDbFullTextSearch() {
+		// Make bookmarked status searchable by bookmarker.
+		if err := p.state.DB.PutStatusSearchEntry(ctx, requestingAccount.ID, targetStatus); err != nil {
+			err = gtserror.Newf("error indexing bookmarked status for search: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	if err := p.c.InvalidateTimelinedStatus(ctx, requestingAccount.ID, targetStatusID); err != nil {
 		err = gtserror.Newf("error invalidating status from timelines: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -102,6 +111,10 @@ func (p *Processor) BookmarkRemove(ctx context.Context, requestingAccount *gtsmo
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if errWithCode := p.unindexBookmark(ctx, requestingAccount, targetStatus); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if err := p.c.InvalidateTimelinedStatus(ctx, requestingAccount.ID, targetStatusID); err != nil {
 		err = gtserror.Newf("error invalidating status from timelines: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -109,3 +122,31 @@ func (p *Processor) BookmarkRemove(ctx context.Context, requestingAccount *gtsmo
 
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
+
+// unindexBookmark removes the given unbookmarked status from
+// the full text search index of the requesting account, unless
+// the status was authored or faved by it, in which case it
+// should remain searchable.
+func (p *Processor) unindexBookmark(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatus *gtsmodel.Status) gtserror.WithCode {
+	if !config.GetDbFullTextSearch() || targetStatus.AccountID == requestingAccount.ID {
+		return nil
+	}
+
+	faved, err := p.state.DB.IsStatusFavedBy(ctx, targetStatus.ID, requestingAccount.ID)
+	if err != nil {
+		err = gtserror.Newf("error checking status fave: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if faved {
+		// Still faved.
+		return nil
+	}
+
+	if err := p.state.DB.DeleteStatusSearchEntry(ctx, requestingAccount.ID, targetStatus.ID); err != nil {
+		err = gtserror.Newf("error unindexing unbookmarked status for search: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
@@ -20,24 +20,36 @@ package status
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // BoostCreate processes the boost/reblog of target
 // status, returning the newly-created boost.
+//
+// If visibility is empty, the requester's default boost
+// visibility is used, falling back to the visibility of
+// the target status if the requester hasn't set one.
 func (p *Processor) BoostCreate(
 	ctx context.Context,
 	requester *gtsmodel.Account,
 	application *gtsmodel.Application,
 	targetID string,
+	visibility apimodel.Visibility,
 ) (*apimodel.Status, gtserror.WithCode) {
+	if err := validate.BoostVisibility(string(visibility)); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
 	// Get target status and ensure it's not a boost.
 	target, errWithCode := p.c.GetVisibleTargetStatus(
 		ctx,
@@ -73,11 +85,38 @@ func (p *Processor) BoostCreate(
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
+	// Check the requester is permitted
+	// by the status' boost policy.
+	permitted, err := p.filter.StatusBoostPermitted(ctx,
+		requester,
+		target,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s boost is permitted: %w", target.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !permitted {
+		const text = "status boost policy does not permit boosts from you"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Work out the visibility of the boost.
+	boostVis, errWithCode := boostVisibility(
+		target,
+		typeutils.APIVisToVis(visibility),
+		requester.BoostVisibility,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// Status is visible and boostable.
 	boost, err := p.converter.StatusToBoost(ctx,
 		target,
 		requester,
 		application.ID,
+		boostVis,
 	)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -153,80 +192,127 @@ func (p *Processor) BoostRemove(
 	return p.c.GetAPIStatus(ctx, requester, target)
 }
 
-// StatusBoostedBy returns a slice of accounts that have boosted the given status, filtered according to privacy settings.
-func (p *Processor) StatusBoostedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.Account, gtserror.WithCode) {
-	targetStatus, err := p.state.DB.GetStatusByID(ctx, targetStatusID)
-	if err != nil {
-		wrapped := fmt.Errorf("BoostedBy: error fetching status %s: %s", targetStatusID, err)
-		if !errors.Is(err, db.ErrNoEntries) {
-			return nil, gtserror.NewErrorInternalError(wrapped)
-		}
-		return nil, gtserror.NewErrorNotFound(wrapped)
+// StatusBoostedBy returns a pageable response of accounts that have boosted
+// the given status, filtered according to privacy settings. Paging for this
+// response is done based on boost wrapper status ID.
+func (p *Processor) StatusBoostedBy(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	target, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
-	if boostOfID := targetStatus.BoostOfID; boostOfID != "" {
-		// the target status is a boost wrapper, redirect this request to the status it boosts
-		boostedStatus, err := p.state.DB.GetStatusByID(ctx, boostOfID)
-		if err != nil {
-			wrapped := fmt.Errorf("BoostedBy: error fetching status %s: %s", boostOfID, err)
-			if !errors.Is(err, db.ErrNoEntries) {
-				return nil, gtserror.NewErrorInternalError(wrapped)
-			}
-			return nil, gtserror.NewErrorNotFound(wrapped)
-		}
-		targetStatus = boostedStatus
+	// If target is a boost wrapper,
+	// redirect to the status it boosts.
+	target, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		target,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
-	visible, err := p.filter.StatusVisible(ctx, requestingAccount, targetStatus)
-	if err != nil {
-		err = fmt.Errorf("BoostedBy: error seeing if status %s is visible: %s", targetStatus.ID, err)
-		return nil, gtserror.NewErrorNotFound(err)
-	}
-	if !visible {
-		err = errors.New("BoostedBy: status is not visible")
-		return nil, gtserror.NewErrorNotFound(err)
+	boosts, err := p.state.DB.GetStatusBoostsPage(ctx, target.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting boosts of %s: %w", target.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	statusBoosts, err := p.state.DB.GetStatusBoosts(ctx, targetStatus.ID)
-	if err != nil {
-		err = fmt.Errorf("BoostedBy: error seeing who boosted status: %s", err)
-		return nil, gtserror.NewErrorNotFound(err)
+	count := len(boosts)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
 	}
 
-	// filter account IDs so the user doesn't see accounts they blocked or which blocked them
-	accountIDs := make([]string, 0, len(statusBoosts))
-	for _, s := range statusBoosts {
-		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, s.AccountID)
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Get the lowest and highest ID values before
+		// filtering and API converting, so caller can
+		// still page properly. Page based on boost ID.
+		lo = boosts[count-1].ID
+		hi = boosts[0].ID
+	)
+
+	for _, boost := range boosts {
+		// Only show the requester accounts that they
+		// don't block, and which don't block them.
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, boost.AccountID)
 		if err != nil {
-			err = fmt.Errorf("BoostedBy: error checking blocks: %s", err)
-			return nil, gtserror.NewErrorNotFound(err)
-		}
-		if !blocked {
-			accountIDs = append(accountIDs, s.AccountID)
+			err = gtserror.Newf("error checking blocks: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
 		}
-	}
 
-	// TODO: filter other things here? suspended? muted? silenced?
+		if blocked {
+			continue
+		}
 
-	// fetch accounts + create their API representations
-	apiAccounts := make([]*apimodel.Account, 0, len(accountIDs))
-	for _, accountID := range accountIDs {
-		account, err := p.state.DB.GetAccountByID(ctx, accountID)
-		if err != nil {
-			wrapped := fmt.Errorf("BoostedBy: error fetching account %s: %s", accountID, err)
-			if !errors.Is(err, db.ErrNoEntries) {
-				return nil, gtserror.NewErrorInternalError(wrapped)
-			}
-			return nil, gtserror.NewErrorNotFound(wrapped)
+		if boost.Account == nil {
+			// Account isn't set for some reason, just skip.
+			log.WithContext(ctx).WithField("boost", boost).Warn("boost had no associated account")
+			continue
 		}
 
-		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, boost.Account)
 		if err != nil {
-			err = fmt.Errorf("BoostedBy: error converting account to api model: %s", err)
+			err = gtserror.Newf("error converting account %s to frontend representation: %w", boost.AccountID, err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
-		apiAccounts = append(apiAccounts, apiAccount)
+
+		items = append(items, apiAccount)
 	}
 
-	return apiAccounts, nil
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/statuses/" + target.ID + "/reblogged_by",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// boostVisibility returns the visibility to use for a boost
+// of the given target status, based on the visibility that
+// was requested (if any), and the booster's default (if any).
+//
+// Public and unlisted statuses can be boosted with any of
+// public, unlisted or followers-only visibility. Boosts of
+// statuses with a more restrictive visibility than that
+// always inherit the visibility of the boosted status.
+func boostVisibility(
+	target *gtsmodel.Status,
+	requested gtsmodel.Visibility,
+	preferred gtsmodel.Visibility,
+) (gtsmodel.Visibility, gtserror.WithCode) {
+	switch target.Visibility {
+	case gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked:
+		if requested != "" {
+			return requested, nil
+		}
+
+		if preferred != "" {
+			return preferred, nil
+		}
+
+		return target.Visibility, nil
+
+	default:
+		if requested == gtsmodel.VisibilityPublic ||
+			requested == gtsmodel.VisibilityUnlocked {
+			// Don't allow boosting a status to a wider
+			// audience than the status itself was sent to.
+			const text = "status with restricted visibility cannot be boosted publicly"
+			return "", gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		// Don't error on the booster's default
+		// visibility, just inherit from target.
+		return target.Visibility, nil
+	}
 }
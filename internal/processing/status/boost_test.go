@@ -19,9 +19,12 @@ package status_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type StatusBoostTestSuite struct {
@@ -36,7 +39,7 @@ func (suite *StatusBoostTestSuite) TestBoostOfBoost() {
 	application1 := suite.testApplications["application_1"]
 	targetStatus1 := suite.testStatuses["admin_account_status_1"]
 
-	boost1, err := suite.status.BoostCreate(ctx, boostingAccount1, application1, targetStatus1.ID)
+	boost1, err := suite.status.BoostCreate(ctx, boostingAccount1, application1, targetStatus1.ID, "")
 	suite.NoError(err)
 	suite.NotNil(boost1)
 	suite.Equal(targetStatus1.ID, boost1.Reblog.ID)
@@ -46,7 +49,7 @@ func (suite *StatusBoostTestSuite) TestBoostOfBoost() {
 	application2 := suite.testApplications["application_2"]
 	targetStatus2ID := boost1.ID
 
-	boost2, err := suite.status.BoostCreate(ctx, boostingAccount2, application2, targetStatus2ID)
+	boost2, err := suite.status.BoostCreate(ctx, boostingAccount2, application2, targetStatus2ID, "")
 	suite.NoError(err)
 	suite.NotNil(boost2)
 	// the boosted status should not be the boost,
@@ -54,6 +57,85 @@ func (suite *StatusBoostTestSuite) TestBoostOfBoost() {
 	suite.Equal(targetStatus1.ID, boost2.Reblog.ID)
 }
 
+func (suite *StatusBoostTestSuite) TestBoostWithVisibility() {
+	ctx := context.Background()
+
+	boostingAccount := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	boost, errWithCode := suite.status.BoostCreate(ctx, boostingAccount, application, targetStatus.ID, apimodel.VisibilityPrivate)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(apimodel.VisibilityPrivate, boost.Visibility)
+
+	dbBoost, err := suite.db.GetStatusByID(ctx, boost.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.VisibilityFollowersOnly, dbBoost.Visibility)
+}
+
+func (suite *StatusBoostTestSuite) TestBoostWithDefaultVisibility() {
+	ctx := context.Background()
+
+	boostingAccount := new(gtsmodel.Account)
+	*boostingAccount = *suite.testAccounts["local_account_1"]
+	boostingAccount.BoostVisibility = gtsmodel.VisibilityUnlocked
+	application := suite.testApplications["application_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	boost, errWithCode := suite.status.BoostCreate(ctx, boostingAccount, application, targetStatus.ID, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(apimodel.VisibilityUnlisted, boost.Visibility)
+}
+
+func (suite *StatusBoostTestSuite) TestBoostFollowersOnlyPublicly() {
+	ctx := context.Background()
+
+	boostingAccount := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_5"]
+
+	boost, errWithCode := suite.status.BoostCreate(ctx, boostingAccount, application, targetStatus.ID, apimodel.VisibilityPublic)
+	suite.Nil(boost)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("status with restricted visibility cannot be boosted publicly", errWithCode.Safe())
+}
+
+func (suite *StatusBoostTestSuite) TestBoostFollowersOnlyInherit() {
+	ctx := context.Background()
+
+	// Default boost visibility should be
+	// ignored for followers-only statuses.
+	boostingAccount := new(gtsmodel.Account)
+	*boostingAccount = *suite.testAccounts["local_account_1"]
+	boostingAccount.BoostVisibility = gtsmodel.VisibilityPublic
+	application := suite.testApplications["application_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_5"]
+
+	boost, errWithCode := suite.status.BoostCreate(ctx, boostingAccount, application, targetStatus.ID, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(apimodel.VisibilityPrivate, boost.Visibility)
+}
+
+func (suite *StatusBoostTestSuite) TestBoostDirectVisibility() {
+	ctx := context.Background()
+
+	boostingAccount := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	boost, errWithCode := suite.status.BoostCreate(ctx, boostingAccount, application, targetStatus.ID, apimodel.VisibilityDirect)
+	suite.Nil(boost)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
 func TestStatusBoostTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusBoostTestSuite))
 }
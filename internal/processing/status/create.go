@@ -40,6 +40,9 @@ import (
 
 // Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
 //
+// If the form has an idempotency key, and a status was already created by the requester with that
+// key within the last hour, the existing status is returned instead of creating another one.
+//
 // Precondition: the form's fields should have already been validated and normalized by the caller.
 func (p *Processor) Create(
 	ctx context.Context,
@@ -49,6 +52,58 @@ func (p *Processor) Create(
 ) (
 	*apimodel.Status,
 	gtserror.WithCode,
+) {
+	if form.IdempotencyKey == "" {
+		// Nothing to dedupe on.
+		return p.create(ctx, requester, application, form)
+	}
+
+	// Keys are scoped per requesting account.
+	key := requester.ID + "/" + form.IdempotencyKey
+
+	// Serialize requests with the same key, so a retry
+	// arriving while the original is still in progress
+	// waits for it, rather than creating a duplicate.
+	unlock := p.createLocks.Lock(key)
+	defer unlock()
+
+	// Check for replays BEFORE doing anything
+	// with side effects, eg., claiming media.
+	if statusID, ok := p.state.Caches.GTS.IdempotencyKeys.Get(key); ok {
+		status, err := p.state.DB.GetStatusByID(ctx, statusID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting status %s: %w", statusID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if status != nil {
+			return p.c.GetAPIStatus(ctx, requester, status)
+		}
+
+		// Original status has since
+		// been deleted, create anew.
+	}
+
+	apiStatus, errWithCode := p.create(ctx, requester, application, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Store key -> status ID for replays.
+	p.state.Caches.GTS.IdempotencyKeys.Set(key, apiStatus.ID)
+
+	return apiStatus, nil
+}
+
+// create is the actual implementation of Create.
+func (p *Processor) create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
 ) {
 	// Generate new ID for status.
 	statusID := id.NewULID()
@@ -132,8 +187,22 @@ func (p *Processor) Create(
 		}
 	}
 
-	// Insert this new status in the database.
+	// Insert this new status in the database. Media
+	// checks above are repeated within the insert
+	// transaction, to catch racing status creates.
 	if err := p.state.DB.PutStatus(ctx, status); err != nil {
+		if status.Poll != nil {
+			// Don't leave the already inserted poll dangling.
+			if err := p.state.DB.DeletePollByID(ctx, status.Poll.ID); err != nil {
+				log.Errorf(ctx, "error deleting poll: %v", err)
+			}
+		}
+
+		var claimErr *db.MediaClaimError
+		if errors.As(err, &claimErr) {
+			text := fmt.Sprintf("media %s already attached to status", claimErr.MediaID)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+		}
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -185,6 +254,22 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 		return gtserror.NewErrorForbidden(errors.New(text), text)
 	}
 
+	// Check the requester is permitted
+	// by the status' reply policy.
+	replyable, err := p.filter.StatusReplyable(ctx,
+		requester,
+		inReplyTo,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is replyable: %w", inReplyTo.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !replyable {
+		const text = "in-reply-to status reply policy does not permit replies from you"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
 	// Set status fields from inReplyTo.
 	status.InReplyToID = inReplyTo.ID
 	status.InReplyTo = inReplyTo
@@ -259,12 +344,22 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 
 		if attachment.AccountID != thisAccountID {
 			text := fmt.Sprintf("media %s does not belong to account", mediaID)
-			return gtserror.NewErrorBadRequest(errors.New(text), text)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 		}
 
 		if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
 			text := fmt.Sprintf("media %s already attached to status", mediaID)
-			return gtserror.NewErrorBadRequest(errors.New(text), text)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		switch attachment.Processing {
+		case gtsmodel.ProcessingStatusProcessing:
+			text := fmt.Sprintf("media %s has not finished processing, try again in a moment", mediaID)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+
+		case gtsmodel.ProcessingStatusError:
+			text := fmt.Sprintf("processing of media %s failed, please upload it again", mediaID)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 		}
 
 		if length := len([]rune(attachment.Description)); length < minChars {
@@ -302,8 +397,11 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 
 	switch vis {
 	case gtsmodel.VisibilityPublic:
-		// for public, there's no need to change any of the advanced flags from true regardless of what the user filled out
-		break
+		// for public, there's no need to change any of the advanced flags from true regardless of what the user filled out,
+		// except for federated, since a public status can still be kept local-only
+		if form.Federated != nil {
+			federated = *form.Federated
+		}
 	case gtsmodel.VisibilityUnlocked:
 		// for unlocked the user can set any combination of flags they like so look at them all to see if they're set and then apply them
 		if form.Federated != nil {
@@ -346,14 +444,55 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 		likeable = true
 	}
 
+	var replyPolicy, boostPolicy, likePolicy gtsmodel.InteractionPolicy
+	if policy := form.InteractionPolicy; policy != nil {
+		// Apply any interaction policy
+		// on top of the flags set above.
+		replyable, replyPolicy = processInteractionPolicy(policy.Replies, replyable)
+		boostable, boostPolicy = processInteractionPolicy(policy.Boosts, boostable)
+		likeable, likePolicy = processInteractionPolicy(policy.Likes, likeable)
+	}
+
+	if form.LocalOnly != nil && *form.LocalOnly {
+		// local-only overrides any
+		// other federated setting.
+		federated = false
+	}
+
+	if status.InReplyTo != nil && status.InReplyTo.IsLocalOnly() {
+		// Replies to a local-only status
+		// must themselves be local-only,
+		// or they'd leak the thread.
+		federated = false
+	}
+
 	status.Visibility = vis
 	status.Federated = &federated
 	status.Boostable = &boostable
 	status.Replyable = &replyable
 	status.Likeable = &likeable
+	status.ReplyPolicy = replyPolicy
+	status.BoostPolicy = boostPolicy
+	status.LikePolicy = likePolicy
 	return nil
 }
 
+// processInteractionPolicy applies the given API interaction policy value
+// to the given interaction flag, returning the resulting flag value and
+// the database interaction policy to store alongside it.
+func processInteractionPolicy(value apimodel.InteractionPolicyValue, permitted bool) (bool, gtsmodel.InteractionPolicy) {
+	switch value {
+	case apimodel.InteractionPolicyNobody:
+		return false, gtsmodel.InteractionPolicyAnyone
+	case apimodel.InteractionPolicyFollowers:
+		return permitted, gtsmodel.InteractionPolicyFollowers
+	case apimodel.InteractionPolicyMentioned:
+		return permitted, gtsmodel.InteractionPolicyMentioned
+	default:
+		return permitted, gtsmodel.InteractionPolicyAnyone
+	}
+}
+
 func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
 	if form.Language != "" {
 		status.Language = form.Language
@@ -367,10 +506,10 @@ func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLang
 }
 
 func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.ParseMentionFunc, form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) error {
-	if form.ContentType == "" {
+	contentType := form.ContentType
+	if contentType == "" {
 		// If content type wasn't specified, use the author's preferred content-type.
-		contentType := apimodel.StatusContentType(status.Account.StatusContentType)
-		form.ContentType = contentType
+		contentType = apimodel.StatusContentType(status.Account.StatusContentType)
 	}
 
 	// format is the currently set text formatting
@@ -383,10 +522,11 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 		return formatFunc(ctx, parseMention, status.AccountID, status.ID, input)
 	}
 
-	switch form.ContentType {
+	switch contentType {
 	// None given / set,
 	// use default (plain).
 	case "":
+		contentType = apimodel.StatusContentTypeDefault
 		fallthrough
 
 	// Format status according to text/plain.
@@ -399,9 +539,13 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 
 	// Unknown.
 	default:
-		return fmt.Errorf("invalid status format: %q", form.ContentType)
+		return fmt.Errorf("invalid status format: %q", contentType)
 	}
 
+	// Store the content type used, so we
+	// know how to interpret the raw text later.
+	status.ContentType = string(contentType)
+
 	// Sanitize status text and format.
 	contentRes := formatInput(format, form.Status)
 
@@ -435,6 +579,10 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 		}
 	}
 
+	// The same emoji may have been used in more than one
+	// of content, content warning, and poll options.
+	status.Emojis = util.DeduplicateFunc(status.Emojis, func(emoji *gtsmodel.Emoji) string { return emoji.ID })
+
 	// Gather all the database IDs from each of the gathered status mentions, tags, and emojis.
 	status.MentionIDs = gatherIDs(status.Mentions, func(mention *gtsmodel.Mention) string { return mention.ID })
 	status.TagIDs = gatherIDs(status.Tags, func(tag *gtsmodel.Tag) string { return tag.ID })
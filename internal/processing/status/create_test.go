@@ -19,6 +19,7 @@ package status_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -26,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type StatusCreateTestSuite struct {
@@ -174,6 +176,41 @@ func (suite *StatusCreateTestSuite) TestProcessStatusMarkdownWithSpoilerTextEmoj
 	suite.NotEmpty(apiStatus.Emojis)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessStatusMarkdownWithCode() {
+	ctx := context.Background()
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "#welcome :rainbow:\n\n`#notatag :rainbow:`\n\n```\n@notamention #alsonotatag\n```",
+			SpoilerText: "code :rainbow:",
+			MediaIDs:    []string{},
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypeMarkdown,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	// Only tags and emojis outside code
+	// should end up on the status, with
+	// emoji used twice only counted once.
+	suite.Len(apiStatus.Tags, 1)
+	suite.Equal("welcome", apiStatus.Tags[0].Name)
+	suite.Empty(apiStatus.Mentions)
+	suite.Len(apiStatus.Emojis, 1)
+
+	dbStatus, dbErr := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	suite.NoError(dbErr)
+	suite.Len(dbStatus.TagIDs, 1)
+	suite.Empty(dbStatus.MentionIDs)
+	suite.Len(dbStatus.EmojiIDs, 1)
+}
+
 func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 	ctx := context.Background()
 
@@ -208,6 +245,43 @@ func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 	suite.Nil(apiStatus)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessMediaNotClaimable() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	for _, test := range []struct {
+		mediaID string
+		expect  string
+	}{
+		{
+			// Belongs to another account.
+			mediaID: suite.testAttachments["admin_account_status_1_attachment_1"].ID,
+			expect:  "media 01F8MH6NEM8D7527KZAECTCR76 does not belong to account",
+		},
+		{
+			// Already attached to another status.
+			mediaID: suite.testAttachments["local_account_1_status_4_attachment_1"].ID,
+			expect:  "media 01F8MH7TDVANYKWVE8VVKFPJTJ already attached to status",
+		},
+	} {
+		statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      "this one's mine now",
+				MediaIDs:    []string{test.mediaID},
+				Visibility:  apimodel.VisibilityPublic,
+				ContentType: apimodel.StatusContentTypePlain,
+			},
+		}
+
+		apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+		suite.EqualError(errWithCode, test.expect)
+		suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+		suite.Nil(apiStatus)
+	}
+}
+
 func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 	ctx := context.Background()
 
@@ -286,6 +360,134 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessLocalOnlyStatus() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "this stays between us",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			LocalOnly: util.Ptr(true),
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+	suite.True(apiStatus.LocalOnly)
+
+	dbStatus, dbErr := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if dbErr != nil {
+		suite.FailNow(dbErr.Error())
+	}
+	suite.False(*dbStatus.Federated)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessReplyToLocalOnlyStatus() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_2"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// This status is local-only.
+	inReplyTo := suite.testStatuses["local_account_1_status_2"]
+
+	// Reply without asking for local-only,
+	// it should be forced local-only anyway.
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "agreed",
+			InReplyToID: inReplyTo.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: util.Ptr(true),
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+	suite.True(apiStatus.LocalOnly)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessReplyNotPermittedByPolicy() {
+	ctx := context.Background()
+
+	// Restrict replies to admin's status
+	// to mentioned accounts only.
+	inReplyTo := new(gtsmodel.Status)
+	*inReplyTo = *suite.testStatuses["admin_account_status_1"]
+	inReplyTo.ReplyPolicy = gtsmodel.InteractionPolicyMentioned
+	if err := suite.state.DB.UpdateStatus(ctx, inReplyTo, "reply_policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "can i reply?",
+			InReplyToID: inReplyTo.ID,
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "in-reply-to status reply policy does not permit replies from you")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *StatusCreateTestSuite) TestProcessStatusWithInteractionPolicy() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "followers may reply, nobody may boost",
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			InteractionPolicy: &apimodel.StatusInteractionPolicy{
+				Replies: apimodel.InteractionPolicyFollowers,
+				Boosts:  apimodel.InteractionPolicyNobody,
+			},
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.Equal(&apimodel.StatusInteractionPolicy{
+		Replies: apimodel.InteractionPolicyFollowers,
+		Boosts:  apimodel.InteractionPolicyNobody,
+		Likes:   apimodel.InteractionPolicyAnyone,
+	}, apiStatus.InteractionPolicy)
+
+	dbStatus, err := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.InteractionPolicyFollowers, dbStatus.ReplyPolicy)
+	suite.False(*dbStatus.Boostable)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -30,10 +30,15 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
-func (p *Processor) getFaveableStatus(
+// getFaveTarget returns the visible (unwrapped) target
+// status with given ID, and any existing fave of it by
+// the requester. Like policy is checked by the caller,
+// as it's only relevant when creating a fave.
+func (p *Processor) getFaveTarget(
 	ctx context.Context,
 	requester *gtsmodel.Account,
 	targetID string,
@@ -62,11 +67,6 @@ func (p *Processor) getFaveableStatus(
 		return nil, nil, errWithCode
 	}
 
-	if !*target.Likeable {
-		err := errors.New("status is not faveable")
-		return nil, nil, gtserror.NewErrorForbidden(err, err.Error())
-	}
-
 	fave, err := p.state.DB.GetStatusFave(ctx, requester.ID, target.ID)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err = fmt.Errorf("getFaveTarget: error checking existing fave: %w", err)
@@ -78,7 +78,7 @@ func (p *Processor) getFaveableStatus(
 
 // FaveCreate adds a fave for the requestingAccount, targeting the given status (no-op if fave already exists).
 func (p *Processor) FaveCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
-	targetStatus, existingFave, errWithCode := p.getFaveableStatus(ctx, requestingAccount, targetStatusID)
+	targetStatus, existingFave, errWithCode := p.getFaveTarget(ctx, requestingAccount, targetStatusID)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -88,6 +88,27 @@ func (p *Processor) FaveCreate(ctx context.Context, requestingAccount *gtsmodel.
 		return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 	}
 
+	if !*targetStatus.Likeable {
+		err := errors.New("status is not faveable")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	// Check the requester is permitted
+	// by the status' like policy.
+	likeable, err := p.filter.StatusLikeable(ctx,
+		requestingAccount,
+		targetStatus,
+	)
+	if err != nil {
+		err = gtserror.Newf("error seeing if status %s is likeable: %w", targetStatus.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !likeable {
+		err := errors.New("status like policy does not permit faves from you")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
 	// Create and store a new fave
 	faveID := id.NewULID()
 	gtsFave := &gtsmodel.StatusFave{
@@ -120,7 +141,7 @@ func (p *Processor) FaveCreate(ctx context.Context, requestingAccount *gtsmodel.
 
 // FaveRemove removes a fave for the requesting account, targeting the given status (no-op if fave doesn't exist).
 func (p *Processor) FaveRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
-	targetStatus, existingFave, errWithCode := p.getFaveableStatus(ctx, requestingAccount, targetStatusID)
+	targetStatus, existingFave, errWithCode := p.getFaveTarget(ctx, requestingAccount, targetStatusID)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
@@ -148,31 +169,55 @@ func (p *Processor) FaveRemove(ctx context.Context, requestingAccount *gtsmodel.
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
 
-// FavedBy returns a slice of accounts that have liked the given status, filtered according to privacy settings.
-func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.Account, gtserror.WithCode) {
-	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
-		requestingAccount,
-		targetStatusID,
+// FavedBy returns a pageable response of accounts that have liked the given
+// status, filtered according to privacy settings. Paging for this response
+// is done based on fave ID.
+func (p *Processor) FavedBy(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	target, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		targetID,
 		nil, // default freshness
 	)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	statusFaves, err := p.state.DB.GetStatusFaves(ctx, targetStatus.ID)
-	if err != nil {
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("FavedBy: error seeing who faved status: %s", err))
+	faves, err := p.state.DB.GetStatusFavesPage(ctx, target.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting faves of %s: %w", target.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(faves)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
 	}
 
-	// For each fave, ensure that we're only showing
-	// the requester accounts that they don't block,
-	// and which don't block them.
-	apiAccounts := make([]*apimodel.Account, 0, len(statusFaves))
-	for _, fave := range statusFaves {
-		if blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, fave.AccountID); err != nil {
-			err = fmt.Errorf("FavedBy: error checking blocks: %w", err)
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Get the lowest and highest ID values before
+		// filtering and API converting, so caller can
+		// still page properly. Page based on fave ID.
+		lo = faves[count-1].ID
+		hi = faves[0].ID
+	)
+
+	for _, fave := range faves {
+		// Only show the requester accounts that they
+		// don't block, and which don't block them.
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, fave.AccountID)
+		if err != nil {
+			err = gtserror.Newf("error checking blocks: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
-		} else if blocked {
+		}
+
+		if blocked {
 			continue
 		}
 
@@ -184,11 +229,17 @@ func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Acc
 
 		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, fave.Account)
 		if err != nil {
-			err = fmt.Errorf("FavedBy: error converting account %s to frontend representation: %w", fave.AccountID, err)
+			err = gtserror.Newf("error converting account %s to frontend representation: %w", fave.AccountID, err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
-		apiAccounts = append(apiAccounts, apiAccount)
+
+		items = append(items, apiAccount)
 	}
 
-	return apiAccounts, nil
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/statuses/" + target.ID + "/favourited_by",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
 }
@@ -19,12 +19,25 @@ package status
 
 import (
 	"context"
+	"runtime"
 	"slices"
 	"strings"
+	"sync"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// Limits on the number of ancestors and
+	// descendants returned in a status context,
+	// matching those used by Mastodon.
+	contextAncestorsLimit      = 4096
+	contextDescendantsLimit    = 4096
+	webContextAncestorsLimit   = 40
+	webContextDescendantsLimit = 60
 )
 
 // Get gets the given status, taking account of privacy settings and blocks etc.
@@ -41,6 +54,38 @@ func (p *Processor) Get(ctx context.Context, requestingAccount *gtsmodel.Account
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
 
+// SourceGet returns the source text of the given status,
+// which must be owned by the requesting account.
+func (p *Processor) SourceGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requestingAccount,
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if targetStatus.AccountID != requestingAccount.ID {
+		err := gtserror.Newf("status %s does not belong to account %s", targetStatusID, requestingAccount.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	contentType := apimodel.StatusContentType(targetStatus.ContentType)
+	if contentType == "" {
+		// Statuses created before content
+		// type was stored were always plain.
+		contentType = apimodel.StatusContentTypeDefault
+	}
+
+	return &apimodel.StatusSource{
+		ID:          targetStatus.ID,
+		Text:        targetStatus.Text,
+		SpoilerText: targetStatus.ContentWarning,
+		ContentType: contentType,
+	}, nil
+}
+
 // WebGet gets the given status for web use, taking account of privacy settings.
 func (p *Processor) WebGet(ctx context.Context, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
 	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
@@ -64,7 +109,7 @@ func (p *Processor) contextGet(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
 	targetStatusID string,
-	convert func(context.Context, *gtsmodel.Status, *gtsmodel.Account) (*apimodel.Status, error),
+	convert func(context.Context, []*gtsmodel.Status, *gtsmodel.Account) []*apimodel.Status,
 ) (*apimodel.Context, gtserror.WithCode) {
 	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
 		requestingAccount,
@@ -75,41 +120,58 @@ func (p *Processor) contextGet(
 		return nil, errWithCode
 	}
 
+	if requestingAccount != nil {
+		// Thread is being viewed by a local user,
+		// so try to backfill any replies that we
+		// may be missing from remote instances.
+		p.federator.BackfillStatusReplies(ctx,
+			requestingAccount.Username,
+			targetStatus,
+		)
+	}
+
 	parents, err := p.state.DB.GetStatusParents(ctx, targetStatus)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	var ancestors []*apimodel.Status
-	for _, status := range parents {
-		if v, err := p.filter.StatusVisible(ctx, requestingAccount, status); err == nil && v {
-			apiStatus, err := convert(ctx, status, requestingAccount)
-			if err == nil {
-				ancestors = append(ancestors, apiStatus)
-			}
-		}
+	// Mastodon uses much smaller limits for
+	// context requests that aren't authorized.
+	ancestorsLimit, descendantsLimit := contextAncestorsLimit, contextDescendantsLimit
+	if requestingAccount == nil {
+		ancestorsLimit, descendantsLimit = webContextAncestorsLimit, webContextDescendantsLimit
 	}
 
-	slices.SortFunc(ancestors, func(lhs, rhs *apimodel.Status) int {
+	// Sort and limit statuses before converting them,
+	// so that we only do the (relatively expensive)
+	// conversion for those that we're actually returning.
+	parents = p.visibleStatuses(ctx, requestingAccount, parents)
+
+	slices.SortFunc(parents, func(lhs, rhs *gtsmodel.Status) int {
 		return strings.Compare(lhs.ID, rhs.ID)
 	})
 
+	if len(parents) > ancestorsLimit {
+		// Keep the ancestors closest to the target.
+		parents = parents[len(parents)-ancestorsLimit:]
+	}
+
+	ancestors := convert(ctx, parents, requestingAccount)
+
 	children, err := p.state.DB.GetStatusChildren(ctx, targetStatus.ID)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	var descendants []*apimodel.Status
-	for _, status := range children {
-		if v, err := p.filter.StatusVisible(ctx, requestingAccount, status); err == nil && v {
-			apiStatus, err := convert(ctx, status, requestingAccount)
-			if err == nil {
-				descendants = append(descendants, apiStatus)
-			}
-		}
+	children = p.visibleStatuses(ctx, requestingAccount, children)
+
+	TopoSort(children, targetStatus.AccountID)
+
+	if len(children) > descendantsLimit {
+		children = children[:descendantsLimit]
 	}
 
-	TopoSort(descendants, targetStatus.AccountID)
+	descendants := convert(ctx, children, requestingAccount)
 
 	//goland:noinspection GoImportUsedAsName
 	context := &apimodel.Context{
@@ -126,39 +188,86 @@ func (p *Processor) contextGet(
 	return context, nil
 }
 
-// TopoSort sorts statuses topologically, by self-reply, and by ID.
+// visibleStatuses filters the given statuses for visibility
+// to requester. This is done concurrently, as checking the
+// visibility of statuses in large threads can be slow. The
+// returned slice maintains the order of the input statuses.
+func (p *Processor) visibleStatuses(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statuses []*gtsmodel.Status,
+) []*gtsmodel.Status {
+	var (
+		visibles = make([]*gtsmodel.Status, len(statuses))
+		wg       sync.WaitGroup
+
+		// sem bounds the number of
+		// concurrent visibility checks.
+		sem = make(chan struct{}, runtime.GOMAXPROCS(0))
+	)
+
+	for i, status := range statuses {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, status *gtsmodel.Status) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			visible, err := p.filter.StatusVisible(ctx, requester, status)
+			if err != nil {
+				log.Errorf(ctx, "error checking status %s visibility: %v", status.ID, err)
+				return
+			}
+
+			if visible {
+				visibles[i] = status
+			}
+		}(i, status)
+	}
+
+	wg.Wait()
+
+	// Drop statuses that were not visible.
+	return slices.DeleteFunc(visibles, func(status *gtsmodel.Status) bool {
+		return status == nil
+	})
+}
+
+// TopoSort sorts statuses topologically, by self-reply, by creation time, and by ID.
 // Can handle cycles but the output order will be arbitrary.
 // (But if there are cycles, something went wrong upstream.)
-func TopoSort(apiStatuses []*apimodel.Status, targetAccountID string) {
-	if len(apiStatuses) == 0 {
+func TopoSort(statuses []*gtsmodel.Status, targetAccountID string) {
+	if len(statuses) == 0 {
 		return
 	}
 
 	// Map of status IDs to statuses.
-	lookup := make(map[string]*apimodel.Status, len(apiStatuses))
-	for _, apiStatus := range apiStatuses {
-		lookup[apiStatus.ID] = apiStatus
+	lookup := make(map[string]*gtsmodel.Status, len(statuses))
+	for _, status := range statuses {
+		lookup[status.ID] = status
 	}
 
 	// Tree of statuses to their children.
 	// The nil status may have children: any who don't have a parent, or whose parent isn't in the input.
-	tree := make(map[*apimodel.Status][]*apimodel.Status, len(apiStatuses))
-	for _, apiStatus := range apiStatuses {
-		var parent *apimodel.Status
-		if apiStatus.InReplyToID != nil {
-			parent = lookup[*apiStatus.InReplyToID]
+	tree := make(map[*gtsmodel.Status][]*gtsmodel.Status, len(statuses))
+	for _, status := range statuses {
+		var parent *gtsmodel.Status
+		if status.InReplyToID != "" {
+			parent = lookup[status.InReplyToID]
 		}
-		tree[parent] = append(tree[parent], apiStatus)
+		tree[parent] = append(tree[parent], status)
 	}
 
-	// Sort children of each status by self-reply status and then ID, *in reverse*.
-	isSelfReply := func(apiStatus *apimodel.Status) bool {
-		return apiStatus.GetAccountID() == targetAccountID &&
-			apiStatus.InReplyToAccountID != nil &&
-			*apiStatus.InReplyToAccountID == targetAccountID
+	// Sort children of each status by self-reply status, then creation time, and then ID, *in reverse*.
+	isSelfReply := func(status *gtsmodel.Status) bool {
+		return status.AccountID == targetAccountID &&
+			status.InReplyToAccountID == targetAccountID
 	}
 	for id, children := range tree {
-		slices.SortFunc(children, func(lhs, rhs *apimodel.Status) int {
+		slices.SortFunc(children, func(lhs, rhs *gtsmodel.Status) int {
 			lhsIsContextSelfReply := isSelfReply(lhs)
 			rhsIsContextSelfReply := isSelfReply(rhs)
 
@@ -168,14 +277,18 @@ func TopoSort(apiStatuses []*apimodel.Status, targetAccountID string) {
 				return -1
 			}
 
+			if c := lhs.CreatedAt.Compare(rhs.CreatedAt); c != 0 {
+				return -c
+			}
+
 			return -strings.Compare(lhs.ID, rhs.ID)
 		})
 		tree[id] = children
 	}
 
 	// Traverse the tree using preorder depth-first search, topologically sorting the statuses.
-	stack := make([]*apimodel.Status, 1, len(tree))
-	apiStatusIndex := 0
+	stack := make([]*gtsmodel.Status, 1, len(tree))
+	statusIndex := 0
 	for len(stack) > 0 {
 		parent := stack[len(stack)-1]
 		children := tree[parent]
@@ -196,22 +309,22 @@ func TopoSort(apiStatuses []*apimodel.Status, targetAccountID string) {
 		stack = append(stack, child)
 
 		// Overwrite the next entry of the input slice.
-		apiStatuses[apiStatusIndex] = child
-		apiStatusIndex++
+		statuses[statusIndex] = child
+		statusIndex++
 	}
 
 	// There should only be nodes left in the tree in the event of a cycle.
 	// Append them to the end in arbitrary order.
 	// This ensures that the slice of statuses has no duplicates.
 	for node := range tree {
-		apiStatuses[apiStatusIndex] = node
-		apiStatusIndex++
+		statuses[statusIndex] = node
+		statusIndex++
 	}
 }
 
 // ContextGet returns the context (previous and following posts) from the given status ID.
 func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
-	return p.contextGet(ctx, requestingAccount, targetStatusID, p.converter.StatusToAPIStatus)
+	return p.contextGet(ctx, requestingAccount, targetStatusID, p.converter.StatusesToAPIStatuses)
 }
 
 // WebContextGet is like ContextGet, but is explicitly
@@ -219,5 +332,5 @@ func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.
 //
 // TODO: a more advanced threading model could be implemented here.
 func (p *Processor) WebContextGet(ctx context.Context, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
-	return p.contextGet(ctx, nil, targetStatusID, p.converter.StatusToWebStatus)
+	return p.contextGet(ctx, nil, targetStatusID, p.converter.StatusesToWebStatuses)
 }
@@ -18,38 +18,48 @@
 package status_test
 
 import (
+	"testing"
+	"time"
+
 	"github.com/stretchr/testify/suite"
-	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
-	"testing"
 )
 
 type topoSortTestSuite struct {
 	suite.Suite
 }
 
-func statusIDs(apiStatuses []*apimodel.Status) []string {
-	ids := make([]string, 0, len(apiStatuses))
-	for _, apiStatus := range apiStatuses {
-		ids = append(ids, apiStatus.ID)
+func statusIDs(statuses []*gtsmodel.Status) []string {
+	ids := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		ids = append(ids, status.ID)
 	}
 	return ids
 }
 
+func mustParseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
 func (suite *topoSortTestSuite) TestBranched() {
 	// https://commons.wikimedia.org/wiki/File:Sorted_binary_tree_ALL_RGB.svg
-	f := &apimodel.Status{ID: "F"}
-	b := &apimodel.Status{ID: "B", InReplyToID: &f.ID}
-	a := &apimodel.Status{ID: "A", InReplyToID: &b.ID}
-	d := &apimodel.Status{ID: "D", InReplyToID: &b.ID}
-	c := &apimodel.Status{ID: "C", InReplyToID: &d.ID}
-	e := &apimodel.Status{ID: "E", InReplyToID: &d.ID}
-	g := &apimodel.Status{ID: "G", InReplyToID: &f.ID}
-	i := &apimodel.Status{ID: "I", InReplyToID: &g.ID}
-	h := &apimodel.Status{ID: "H", InReplyToID: &i.ID}
-
-	expected := statusIDs([]*apimodel.Status{f, b, a, d, c, e, g, i, h})
-	list := []*apimodel.Status{a, b, c, d, e, f, g, h, i}
+	f := &gtsmodel.Status{ID: "F"}
+	b := &gtsmodel.Status{ID: "B", InReplyToID: f.ID}
+	a := &gtsmodel.Status{ID: "A", InReplyToID: b.ID}
+	d := &gtsmodel.Status{ID: "D", InReplyToID: b.ID}
+	c := &gtsmodel.Status{ID: "C", InReplyToID: d.ID}
+	e := &gtsmodel.Status{ID: "E", InReplyToID: d.ID}
+	g := &gtsmodel.Status{ID: "G", InReplyToID: f.ID}
+	i := &gtsmodel.Status{ID: "I", InReplyToID: g.ID}
+	h := &gtsmodel.Status{ID: "H", InReplyToID: i.ID}
+
+	expected := statusIDs([]*gtsmodel.Status{f, b, a, d, c, e, g, i, h})
+	list := []*gtsmodel.Status{a, b, c, d, e, f, g, h, i}
 	status.TopoSort(list, "")
 	actual := statusIDs(list)
 
@@ -57,78 +67,96 @@ func (suite *topoSortTestSuite) TestBranched() {
 }
 
 func (suite *topoSortTestSuite) TestBranchedWithSelfReplyChain() {
-	targetAccount := &apimodel.Account{ID: "1"}
-	otherAccount := &apimodel.Account{ID: "2"}
+	targetAccountID := "1"
+	otherAccountID := "2"
 
-	f := &apimodel.Status{
-		ID:      "F",
-		Account: targetAccount,
+	f := &gtsmodel.Status{
+		ID:        "F",
+		AccountID: targetAccountID,
 	}
-	b := &apimodel.Status{
+	b := &gtsmodel.Status{
 		ID:                 "B",
-		Account:            targetAccount,
-		InReplyToID:        &f.ID,
-		InReplyToAccountID: &f.Account.ID,
+		AccountID:          targetAccountID,
+		InReplyToID:        f.ID,
+		InReplyToAccountID: f.AccountID,
 	}
-	a := &apimodel.Status{
+	a := &gtsmodel.Status{
 		ID:                 "A",
-		Account:            otherAccount,
-		InReplyToID:        &b.ID,
-		InReplyToAccountID: &b.Account.ID,
+		AccountID:          otherAccountID,
+		InReplyToID:        b.ID,
+		InReplyToAccountID: b.AccountID,
 	}
-	d := &apimodel.Status{
+	d := &gtsmodel.Status{
 		ID:                 "D",
-		Account:            targetAccount,
-		InReplyToID:        &b.ID,
-		InReplyToAccountID: &b.Account.ID,
+		AccountID:          targetAccountID,
+		InReplyToID:        b.ID,
+		InReplyToAccountID: b.AccountID,
 	}
-	c := &apimodel.Status{
+	c := &gtsmodel.Status{
 		ID:                 "C",
-		Account:            otherAccount,
-		InReplyToID:        &d.ID,
-		InReplyToAccountID: &d.Account.ID,
+		AccountID:          otherAccountID,
+		InReplyToID:        d.ID,
+		InReplyToAccountID: d.AccountID,
 	}
-	e := &apimodel.Status{
+	e := &gtsmodel.Status{
 		ID:                 "E",
-		Account:            targetAccount,
-		InReplyToID:        &d.ID,
-		InReplyToAccountID: &d.Account.ID,
+		AccountID:          targetAccountID,
+		InReplyToID:        d.ID,
+		InReplyToAccountID: d.AccountID,
 	}
-	g := &apimodel.Status{
+	g := &gtsmodel.Status{
 		ID:                 "G",
-		Account:            otherAccount,
-		InReplyToID:        &f.ID,
-		InReplyToAccountID: &f.Account.ID,
+		AccountID:          otherAccountID,
+		InReplyToID:        f.ID,
+		InReplyToAccountID: f.AccountID,
 	}
-	i := &apimodel.Status{
+	i := &gtsmodel.Status{
 		ID:                 "I",
-		Account:            targetAccount,
-		InReplyToID:        &g.ID,
-		InReplyToAccountID: &g.Account.ID,
+		AccountID:          targetAccountID,
+		InReplyToID:        g.ID,
+		InReplyToAccountID: g.AccountID,
 	}
-	h := &apimodel.Status{
+	h := &gtsmodel.Status{
 		ID:                 "H",
-		Account:            otherAccount,
-		InReplyToID:        &i.ID,
-		InReplyToAccountID: &i.Account.ID,
+		AccountID:          otherAccountID,
+		InReplyToID:        i.ID,
+		InReplyToAccountID: i.AccountID,
 	}
 
-	expected := statusIDs([]*apimodel.Status{f, b, d, e, c, a, g, i, h})
-	list := []*apimodel.Status{a, b, c, d, e, f, g, h, i}
-	status.TopoSort(list, targetAccount.ID)
+	expected := statusIDs([]*gtsmodel.Status{f, b, d, e, c, a, g, i, h})
+	list := []*gtsmodel.Status{a, b, c, d, e, f, g, h, i}
+	status.TopoSort(list, targetAccountID)
+	actual := statusIDs(list)
+
+	suite.Equal(expected, actual)
+}
+
+func (suite *topoSortTestSuite) TestBranchedByCreatedAt() {
+	// IDs are out of order relative to creation time,
+	// which can happen for remote dereferenced statuses.
+	f := &gtsmodel.Status{ID: "F", CreatedAt: mustParseTime("2024-01-01T00:00:00.000Z")}
+	b := &gtsmodel.Status{ID: "B", InReplyToID: f.ID, CreatedAt: mustParseTime("2024-01-01T00:03:00.000Z")}
+	a := &gtsmodel.Status{ID: "A", InReplyToID: f.ID, CreatedAt: mustParseTime("2024-01-01T00:02:00.000Z")}
+	c := &gtsmodel.Status{ID: "C", InReplyToID: f.ID, CreatedAt: mustParseTime("2024-01-01T00:01:00.000Z")}
+	e := &gtsmodel.Status{ID: "E", InReplyToID: a.ID, CreatedAt: mustParseTime("2024-01-01T00:04:00.000Z")}
+	d := &gtsmodel.Status{ID: "D", InReplyToID: a.ID, CreatedAt: mustParseTime("2024-01-01T00:04:00.000Z")}
+
+	expected := statusIDs([]*gtsmodel.Status{f, c, a, d, e, b})
+	list := []*gtsmodel.Status{a, b, c, d, e, f}
+	status.TopoSort(list, "")
 	actual := statusIDs(list)
 
 	suite.Equal(expected, actual)
 }
 
 func (suite *topoSortTestSuite) TestDisconnected() {
-	f := &apimodel.Status{ID: "F"}
-	b := &apimodel.Status{ID: "B", InReplyToID: &f.ID}
+	f := &gtsmodel.Status{ID: "F"}
+	b := &gtsmodel.Status{ID: "B", InReplyToID: f.ID}
 	dID := "D"
-	e := &apimodel.Status{ID: "E", InReplyToID: &dID}
+	e := &gtsmodel.Status{ID: "E", InReplyToID: dID}
 
-	expected := statusIDs([]*apimodel.Status{e, f, b})
-	list := []*apimodel.Status{b, e, f}
+	expected := statusIDs([]*gtsmodel.Status{e, f, b})
+	list := []*gtsmodel.Status{b, e, f}
 	status.TopoSort(list, "")
 	actual := statusIDs(list)
 
@@ -137,10 +165,10 @@ func (suite *topoSortTestSuite) TestDisconnected() {
 
 func (suite *topoSortTestSuite) TestTrivialCycle() {
 	xID := "X"
-	x := &apimodel.Status{ID: xID, InReplyToID: &xID}
+	x := &gtsmodel.Status{ID: xID, InReplyToID: xID}
 
-	expected := statusIDs([]*apimodel.Status{x})
-	list := []*apimodel.Status{x}
+	expected := statusIDs([]*gtsmodel.Status{x})
+	list := []*gtsmodel.Status{x}
 	status.TopoSort(list, "")
 	actual := statusIDs(list)
 
@@ -149,11 +177,11 @@ func (suite *topoSortTestSuite) TestTrivialCycle() {
 
 func (suite *topoSortTestSuite) TestCycle() {
 	yID := "Y"
-	x := &apimodel.Status{ID: "X", InReplyToID: &yID}
-	y := &apimodel.Status{ID: yID, InReplyToID: &x.ID}
+	x := &gtsmodel.Status{ID: "X", InReplyToID: yID}
+	y := &gtsmodel.Status{ID: yID, InReplyToID: x.ID}
 
-	expected := statusIDs([]*apimodel.Status{x, y})
-	list := []*apimodel.Status{x, y}
+	expected := statusIDs([]*gtsmodel.Status{x, y})
+	list := []*gtsmodel.Status{x, y}
 	status.TopoSort(list, "")
 	actual := statusIDs(list)
 
@@ -162,12 +190,12 @@ func (suite *topoSortTestSuite) TestCycle() {
 
 func (suite *topoSortTestSuite) TestMixedCycle() {
 	yID := "Y"
-	x := &apimodel.Status{ID: "X", InReplyToID: &yID}
-	y := &apimodel.Status{ID: yID, InReplyToID: &x.ID}
-	z := &apimodel.Status{ID: "Z"}
+	x := &gtsmodel.Status{ID: "X", InReplyToID: yID}
+	y := &gtsmodel.Status{ID: yID, InReplyToID: x.ID}
+	z := &gtsmodel.Status{ID: "Z"}
 
-	expected := statusIDs([]*apimodel.Status{x, y, z})
-	list := []*apimodel.Status{x, y, z}
+	expected := statusIDs([]*gtsmodel.Status{x, y, z})
+	list := []*gtsmodel.Status{x, y, z}
 	status.TopoSort(list, "")
 	actual := statusIDs(list)
 
@@ -175,8 +203,8 @@ func (suite *topoSortTestSuite) TestMixedCycle() {
 }
 
 func (suite *topoSortTestSuite) TestEmpty() {
-	expected := statusIDs([]*apimodel.Status{})
-	list := []*apimodel.Status{}
+	expected := statusIDs([]*gtsmodel.Status{})
+	list := []*gtsmodel.Status{}
 	status.TopoSort(list, "")
 	actual := statusIDs(list)
 
@@ -185,7 +213,7 @@ func (suite *topoSortTestSuite) TestEmpty() {
 
 func (suite *topoSortTestSuite) TestNil() {
 	expected := statusIDs(nil)
-	var list []*apimodel.Status
+	var list []*gtsmodel.Status
 	status.TopoSort(list, "")
 	actual := statusIDs(list)
 
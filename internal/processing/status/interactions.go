@@ -0,0 +1,162 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// interaction is a like or
+// boost of a status, used to
+// merge the two when paging.
+type interaction struct {
+	id        string
+	typ       string
+	createdAt time.Time
+	account   *gtsmodel.Account
+}
+
+// StatusInteractionsGet returns a page of likes and boosts of
+// the target status, newest first. Only the author of the status
+// may see these; everyone else (including admins) gets forbidden.
+//
+// No block filtering is done, so that the totals always match
+// the favourites and reblogs counts shown on the status itself.
+func (p *Processor) StatusInteractionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	target, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if target.AccountID != requester.ID {
+		const text = "only the author of a status can see its interactions"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	faves, err := p.state.DB.GetStatusFavesPage(ctx, target.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting faves of %s: %w", target.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	boosts, err := p.state.DB.GetStatusBoostsPage(ctx, target.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting boosts of %s: %w", target.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	interactions := make([]interaction, 0, len(faves)+len(boosts))
+	for _, fave := range faves {
+		interactions = append(interactions, interaction{
+			id:        fave.ID,
+			typ:       "favourite",
+			createdAt: fave.CreatedAt,
+			account:   fave.Account,
+		})
+	}
+	for _, boost := range boosts {
+		interactions = append(interactions, interaction{
+			id:        boost.ID,
+			typ:       "reblog",
+			createdAt: boost.CreatedAt,
+			account:   boost.Account,
+		})
+	}
+
+	// Faves and boosts share the same ID space,
+	// so sort them together by ID, newest first.
+	slices.SortFunc(interactions, func(a, b interaction) int {
+		return strings.Compare(b.id, a.id)
+	})
+
+	// Each of the queries above will have
+	// returned up to limit entries, so trim
+	// the merged slice back down to limit,
+	// keeping those closest to the boundary.
+	if limit := page.GetLimit(); limit > 0 && len(interactions) > limit {
+		if page.GetOrder().Ascending() {
+			interactions = interactions[len(interactions)-limit:]
+		} else {
+			interactions = interactions[:limit]
+		}
+	}
+
+	count := len(interactions)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Get the lowest and highest ID values before
+		// API converting, so caller can still page
+		// properly. Page based on fave / boost ID.
+		lo = interactions[count-1].id
+		hi = interactions[0].id
+	)
+
+	for _, i := range interactions {
+		if i.account == nil {
+			// Account isn't set for some reason, just skip.
+			log.WithContext(ctx).WithField("interaction", i.id).Warn("interaction had no associated account")
+			continue
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, i.account)
+		if err != nil {
+			err = gtserror.Newf("error converting account %s to frontend representation: %w", i.account.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		items = append(items, &apimodel.StatusInteraction{
+			ID:        i.id,
+			Type:      i.typ,
+			CreatedAt: util.FormatISO8601(i.createdAt),
+			Account:   apiAccount,
+		})
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/statuses/" + target.ID + "/interactions",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
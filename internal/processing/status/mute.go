@@ -35,7 +35,9 @@ import (
 //   - Status exists and is visible to requester.
 //   - Status belongs to or mentions requesting account.
 //   - Status is not a boost.
-//   - Status has a thread ID.
+//
+// If the status has no thread ID (eg., it was created
+// before thread IDs were introduced), one is assigned.
 func (p *Processor) getMuteableStatus(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
@@ -62,13 +64,24 @@ func (p *Processor) getMuteableStatus(
 	}
 
 	if targetStatus.ThreadID == "" {
-		err := gtserror.New("cannot mute or unmute status with no threadID")
-		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		// Legacy status without a thread,
+		// assign it one now so that it can
+		// still be muted and unmuted.
+		if errWithCode := p.processThreadID(ctx, targetStatus); errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if err := p.state.DB.UpdateStatus(ctx, targetStatus, "thread_id"); err != nil {
+			err := gtserror.Newf("db error updating thread ID of status %s: %w", targetStatusID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
 	}
 
 	return targetStatus, nil
 }
 
+// MuteCreate mutes the thread of the given status for the
+// requesting account, returning the status with muted set.
 func (p *Processor) MuteCreate(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
@@ -88,7 +101,7 @@ func (p *Processor) MuteCreate(
 	threadMute, err := p.state.DB.GetThreadMutedByAccount(ctx, threadID, accountID)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		// Real db error.
-		err := gtserror.Newf("db error fetching mute of thread %s for account %s", threadID, accountID)
+		err := gtserror.Newf("db error fetching mute of thread %s for account %s: %w", threadID, accountID, err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -104,13 +117,20 @@ func (p *Processor) MuteCreate(
 		ThreadID:  threadID,
 		AccountID: accountID,
 	}); err != nil {
-		err := gtserror.Newf("db error putting mute of thread %s for account %s", threadID, accountID)
+		err := gtserror.Newf("db error putting mute of thread %s for account %s: %w", threadID, accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.c.InvalidateTimelinedStatus(ctx, accountID, targetStatusID); err != nil {
+		err = gtserror.Newf("error invalidating status from timelines: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
 
+// MuteRemove unmutes the thread of the given status for the
+// requesting account, returning the status with muted unset.
 func (p *Processor) MuteRemove(
 	ctx context.Context,
 	requestingAccount *gtsmodel.Account,
@@ -130,7 +150,7 @@ func (p *Processor) MuteRemove(
 	threadMute, err := p.state.DB.GetThreadMutedByAccount(ctx, threadID, accountID)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		// Real db error.
-		err := gtserror.Newf("db error fetching mute of thread %s for account %s", threadID, accountID)
+		err := gtserror.Newf("db error fetching mute of thread %s for account %s: %w", threadID, accountID, err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -142,7 +162,12 @@ func (p *Processor) MuteRemove(
 
 	// Gotta remove the mute.
 	if err := p.state.DB.DeleteThreadMute(ctx, threadMute.ID); err != nil {
-		err := gtserror.Newf("db error deleting mute of thread %s for account %s", threadID, accountID)
+		err := gtserror.Newf("db error deleting mute of thread %s for account %s: %w", threadID, accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.c.InvalidateTimelinedStatus(ctx, accountID, targetStatusID); err != nil {
+		err = gtserror.Newf("error invalidating status from timelines: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
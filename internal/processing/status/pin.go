@@ -24,12 +24,12 @@ import (
 	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-const allowedPinnedCount = 10
-
 // getPinnableStatus fetches targetStatusID status and ensures that requestingAccountID
 // can pin or unpin it.
 //
@@ -87,16 +87,24 @@ func (p *Processor) PinCreate(ctx context.Context, requestingAccount *gtsmodel.A
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
+	maxPinned := config.GetStatusesMaxPinned()
+	if maxPinned <= 0 {
+		err := errors.New("pinning statuses is disabled on this instance")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
 	pinnedCount, err := p.state.DB.CountAccountPinned(ctx, requestingAccount.ID)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking number of pinned statuses: %w", err))
 	}
 
-	if pinnedCount >= allowedPinnedCount {
-		err = fmt.Errorf("status pin limit exceeded, you've already pinned %d status(es) out of %d", pinnedCount, allowedPinnedCount)
+	if pinnedCount >= maxPinned {
+		err = fmt.Errorf("status pin limit exceeded, you've already pinned %d status(es) out of %d", pinnedCount, maxPinned)
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
+	// Pins are ordered by pinned_at, newest
+	// first, so this puts the status on top.
 	targetStatus.PinnedAt = time.Now()
 	if err := p.state.DB.UpdateStatus(ctx, targetStatus, "pinned_at"); err != nil {
 		err = gtserror.Newf("db error pinning status: %w", err)
@@ -145,3 +153,63 @@ func (p *Processor) PinRemove(ctx context.Context, requestingAccount *gtsmodel.A
 
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
+
+// PinsReorder changes the order of requestingAccount's pinned statuses to
+// the order of the given status IDs, first ID on top. The given IDs must be
+// exactly those of the currently pinned statuses, else 422 is returned.
+//
+// Pin order is stored by restamping pinned_at of each status, so that
+// sorting by pinned_at (newest first) yields the requested order.
+func (p *Processor) PinsReorder(ctx context.Context, requestingAccount *gtsmodel.Account, statusIDs []string) ([]*apimodel.Status, gtserror.WithCode) {
+	pinned, err := p.state.DB.GetAccountPinnedStatuses(ctx, requestingAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting pinned statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	pinnedByID := make(map[string]*gtsmodel.Status, len(pinned))
+	for _, status := range pinned {
+		pinnedByID[status.ID] = status
+	}
+
+	// Ensure given IDs are a permutation
+	// of the currently pinned status IDs.
+	ordered := make([]*gtsmodel.Status, 0, len(statusIDs))
+	for _, id := range statusIDs {
+		status, ok := pinnedByID[id]
+		if !ok {
+			err := fmt.Errorf("status %s is not pinned, or given more than once", id)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		delete(pinnedByID, id)
+		ordered = append(ordered, status)
+	}
+
+	if len(pinnedByID) != 0 {
+		err := errors.New("status IDs must include all pinned statuses")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Space the pins out by a millisecond
+	// each, working back from now, which
+	// every db backend can store exactly.
+	now := time.Now()
+	for i, status := range ordered {
+		status.PinnedAt = now.Add(-time.Duration(i) * time.Millisecond)
+		if err := p.state.DB.UpdateStatus(ctx, status, "pinned_at"); err != nil {
+			err = gtserror.Newf("db error reordering pinned status: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiStatuses := make([]*apimodel.Status, 0, len(ordered))
+	for _, status := range ordered {
+		apiStatus, errWithCode := p.c.GetAPIStatus(ctx, requestingAccount, status)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
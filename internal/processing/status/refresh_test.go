@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusRefreshTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusRefreshTestSuite) TestRefreshLocal() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	// Local status should just be returned as-is.
+	apiStatus, errWithCode := suite.status.Refresh(ctx, requester, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(targetStatus.ID, apiStatus.ID)
+}
+
+func (suite *StatusRefreshTestSuite) TestRefreshRateLimited() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	// Hammer the endpoint until limit is reached.
+	var code int
+	for i := 0; i < 20 && code == 0; i++ {
+		_, errWithCode := suite.status.Refresh(ctx, requester, targetStatus.ID)
+		if errWithCode != nil {
+			code = errWithCode.Code()
+		}
+	}
+
+	suite.Equal(http.StatusTooManyRequests, code)
+}
+
+func TestStatusRefreshTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusRefreshTestSuite))
+}
@@ -18,6 +18,7 @@
 package status
 
 import (
+	"codeberg.org/gruf/go-mutexes"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -41,6 +42,10 @@ type Processor struct {
 
 	// other processors
 	polls *polls.Processor
+
+	// per account + idempotency
+	// key status creation locks.
+	createLocks *mutexes.MutexMap
 }
 
 // New returns a new status processor.
@@ -62,5 +67,6 @@ func New(
 		formatter:    text.NewFormatter(state.DB),
 		parseMention: parseMention,
 		polls:        polls,
+		createLocks:  new(mutexes.MutexMap),
 	}
 }
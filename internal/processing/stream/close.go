@@ -0,0 +1,31 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+// CloseToken closes all open streams of the given
+// account ID that were authorized with the given access
+// token, so that revoking a token takes effect immediately.
+func (p *Processor) CloseToken(accountID string, token string) {
+	p.streams.CloseToken(accountID, token)
+}
+
+// CloseAll closes all open streams, and prevents any
+// more from being opened, for use on server shutdown.
+func (p *Processor) CloseAll() {
+	p.streams.CloseAll()
+}
@@ -20,14 +20,21 @@ package stream
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"codeberg.org/gruf/go-byteutil"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
+// maxUnreadCount is the most unread notifications
+// that will be counted when streaming a notification.
+const maxUnreadCount = 100
+
 // Notify streams the given notification to any open, appropriate streams belonging to the given account.
 func (p *Processor) Notify(ctx context.Context, account *gtsmodel.Account, notif *apimodel.Notification) {
 	b, err := json.Marshal(notif)
@@ -38,9 +45,53 @@ func (p *Processor) Notify(ctx context.Context, account *gtsmodel.Account, notif
 	p.streams.Post(ctx, account.ID, stream.Message{
 		Payload: byteutil.B2S(b),
 		Event:   stream.EventTypeNotification,
+		// Set notification type so
+		// streams can filter on it.
+		NotificationType: notif.Type,
+		UnreadCount:      p.unreadCount(ctx, account.ID),
+		Stream: []string{
+			stream.TimelineNotifications,
+			stream.TimelineHome,
+		},
+	})
+}
+
+// NotificationsMerged tells any open notification streams belonging
+// to the given account that its notifications have changed in bulk,
+// so that clients refetch them instead of showing stale entries.
+func (p *Processor) NotificationsMerged(ctx context.Context, account *gtsmodel.Account) {
+	p.streams.Post(ctx, account.ID, stream.Message{
+		Payload:     "1",
+		Event:       stream.EventTypeNotificationsMerged,
+		UnreadCount: p.unreadCount(ctx, account.ID),
 		Stream: []string{
 			stream.TimelineNotifications,
 			stream.TimelineHome,
 		},
 	})
 }
+
+// unreadCount returns the amount of notifications newer than
+// the account's notifications marker, capped at maxUnreadCount,
+// or nil if this couldn't be determined.
+func (p *Processor) unreadCount(ctx context.Context, accountID string) *int {
+	lastReadID := id.Lowest
+
+	marker, err := p.state.DB.GetMarker(ctx, accountID, gtsmodel.MarkerNameNotifications)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting notifications marker: %v", err)
+		return nil
+	}
+
+	if marker != nil {
+		lastReadID = marker.LastReadID
+	}
+
+	count, err := p.state.DB.CountAccountNotificationsSince(ctx, accountID, lastReadID, maxUnreadCount)
+	if err != nil {
+		log.Errorf(ctx, "db error counting unread notifications: %v", err)
+		return nil
+	}
+
+	return &count
+}
@@ -22,9 +22,11 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
@@ -86,6 +88,46 @@ func (suite *NotificationTestSuite) TestStreamNotification() {
 }`, dst.String())
 }
 
+func (suite *NotificationTestSuite) TestStreamNotificationFiltered() {
+	account := suite.testAccounts["local_account_1"]
+
+	openStream, errWithCode := suite.streamProcessor.Open(context.Background(), account, stream.TimelineNotifications)
+	suite.NoError(errWithCode)
+	defer openStream.Close()
+
+	// Don't stream follow notifications.
+	openStream.FilterNotifications(nil, []string{"follow"})
+
+	suite.streamProcessor.Notify(context.Background(), account, &apimodel.Notification{
+		ID:   "01FH57SJCMDWQGEAJ0X08CE3WV",
+		Type: "follow",
+	})
+	suite.streamProcessor.Notify(context.Background(), account, &apimodel.Notification{
+		ID:   "01FH57SJCMDWQGEAJ0X08CE3WW",
+		Type: "mention",
+	})
+
+	// Only the mention should have come through.
+	msg, ok := openStream.Recv(context.Background())
+	suite.True(ok)
+	suite.Equal(stream.EventTypeNotification, msg.Event)
+	suite.Contains(msg.Payload, "01FH57SJCMDWQGEAJ0X08CE3WW")
+
+	// Now only stream favourites.
+	openStream.FilterNotifications([]string{"favourite"}, nil)
+
+	suite.streamProcessor.Notify(context.Background(), account, &apimodel.Notification{
+		ID:   "01FH57SJCMDWQGEAJ0X08CE3WX",
+		Type: "mention",
+	})
+
+	ctx, cncl := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cncl()
+
+	_, ok = openStream.Recv(ctx)
+	suite.False(ok)
+}
+
 func TestNotificationTestSuite(t *testing.T) {
 	suite.Run(t, &NotificationTestSuite{})
 }
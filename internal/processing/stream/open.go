@@ -28,11 +28,19 @@ import (
 )
 
 // Open returns a new Stream for the given account, which will contain a channel for passing messages back to the caller.
-func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamType string) (*stream.Stream, gtserror.WithCode) {
+// Stream types may be empty, in which case the caller can subscribe to stream types later using Subscribe().
+func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamTypes ...string) (*stream.Stream, gtserror.WithCode) {
 	l := log.WithContext(ctx).WithFields(kv.Fields{
 		{"account", account.ID},
-		{"streamType", streamType},
+		{"streamTypes", streamTypes},
 	}...)
 	l.Debug("received open stream request")
-	return p.streams.Open(account.ID, streamType), nil
+
+	for _, streamType := range streamTypes {
+		if errWithCode := p.checkStreamType(ctx, account, streamType); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	return p.streams.Open(account.ID, streamTypes...), nil
 }
@@ -35,6 +35,33 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestCloseAll() {
+	account := suite.testAccounts["local_account_1"]
+
+	stream, errWithCode := suite.streamProcessor.Open(context.Background(), account, "user")
+	suite.NoError(errWithCode)
+
+	suite.streamProcessor.CloseAll()
+
+	// Open stream should now be closed.
+	select {
+	case <-stream.Done():
+	default:
+		suite.FailNow("expected stream to be closed")
+	}
+
+	// And any new stream should
+	// be closed straight away.
+	stream, errWithCode = suite.streamProcessor.Open(context.Background(), account, "user")
+	suite.NoError(errWithCode)
+
+	select {
+	case <-stream.Done():
+	default:
+		suite.FailNow("expected new stream to be closed")
+	}
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+// Subscribe adds the given stream type to the
+// given open stream belonging to account, after
+// checking the stream type is valid and that the
+// account is permitted to subscribe to it.
+func (p *Processor) Subscribe(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	str *stream.Stream,
+	streamType string,
+) gtserror.WithCode {
+	if errWithCode := p.checkStreamType(ctx, account, streamType); errWithCode != nil {
+		return errWithCode
+	}
+	str.Subscribe(streamType)
+	return nil
+}
+
+// Unsubscribe removes the given stream type from the given
+// open stream. Unsubscribing from a stream type that the
+// stream was never subscribed to is a no-op.
+func (p *Processor) Unsubscribe(
+	str *stream.Stream,
+	streamType string,
+) {
+	str.Unsubscribe(streamType)
+}
+
+// checkStreamType checks whether the given stream type is one
+// we know about and, in the case of lists, whether the list
+// exists and is owned by the given account.
+func (p *Processor) checkStreamType(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	streamType string,
+) gtserror.WithCode {
+	if streamType == stream.TimelineList {
+		const text = "list ID required for list stream"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	listID, ok := strings.CutPrefix(streamType, stream.TimelineList+":")
+	if !ok {
+		if !slices.Contains(stream.AllTimelines, streamType) {
+			const text = "unknown stream type"
+			return gtserror.NewErrorBadRequest(errors.New(text), text+": "+streamType)
+		}
+		return nil
+	}
+
+	list, err := p.state.DB.GetListByID(
+		gtscontext.SetBarebones(ctx),
+		listID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting list %s: %w", listID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if list == nil || list.AccountID != account.ID {
+		const text = "list not found"
+		return gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type SubscribeTestSuite struct {
+	StreamTestSuite
+}
+
+func (suite *SubscribeTestSuite) TestSubscribeList() {
+	var (
+		ctx      = context.Background()
+		account  = suite.testAccounts["local_account_1"]
+		listType = stream.TimelineList + ":01H0G8E4Q2J3FE3JDWJVWEDCD1"
+	)
+
+	// Open stream without any initial stream types.
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer openStream.Close()
+
+	// Subscribe to one of the account's lists.
+	errWithCode = suite.streamProcessor.Subscribe(ctx, account, openStream, listType)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	apiStatus := &apimodel.Status{ID: "01FVW7JHQFSFK166WWKR8CBA6M"}
+	suite.streamProcessor.Update(ctx, account, apiStatus, listType)
+
+	msg, ok := openStream.Recv(ctx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+
+	// List ID should be included
+	// separately so clients can demux.
+	suite.Equal([]string{stream.TimelineList, "01H0G8E4Q2J3FE3JDWJVWEDCD1"}, msg.Stream)
+
+	// Unsubscribe, nothing more
+	// should come through.
+	suite.streamProcessor.Unsubscribe(openStream, listType)
+	suite.streamProcessor.Update(ctx, account, apiStatus, listType)
+
+	recvCtx, cncl := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cncl()
+
+	_, ok = openStream.Recv(recvCtx)
+	suite.False(ok)
+}
+
+func (suite *SubscribeTestSuite) TestSubscribeListNotOwned() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_2"]
+	)
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, account, stream.TimelineHome)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer openStream.Close()
+
+	// List belongs to local_account_1.
+	errWithCode = suite.streamProcessor.Subscribe(ctx,
+		account,
+		openStream,
+		stream.TimelineList+":01H0G8E4Q2J3FE3JDWJVWEDCD1",
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *SubscribeTestSuite) TestSubscribeUnknown() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer openStream.Close()
+
+	errWithCode = suite.streamProcessor.Subscribe(ctx, account, openStream, "pokemon")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	errWithCode = suite.streamProcessor.Subscribe(ctx, account, openStream, stream.TimelineList)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *SubscribeTestSuite) TestSubscribeNotifications() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer openStream.Close()
+
+	// Notifications-only stream should be
+	// subscribable over an open stream too.
+	errWithCode = suite.streamProcessor.Subscribe(ctx, account, openStream, stream.TimelineNotifications)
+	suite.Nil(errWithCode)
+}
+
+func (suite *SubscribeTestSuite) TestSendError() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer openStream.Close()
+
+	// Errors are sent regardless of
+	// the stream's subscribed types.
+	openStream.SendError(ctx, "pokemon", `{"error":"Bad Request: unknown stream type: pokemon","status":400}`)
+
+	msg, ok := openStream.Recv(ctx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeError, msg.Event)
+	suite.Equal([]string{"pokemon"}, msg.Stream)
+	suite.Equal(`{"error":"Bad Request: unknown stream type: pokemon","status":400}`, msg.Payload)
+}
+
+func (suite *SubscribeTestSuite) TestUnsubscribeNeverSubscribed() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	openStream, errWithCode := suite.streamProcessor.Open(ctx, account, stream.TimelineHome)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer openStream.Close()
+
+	// Should be a no-op.
+	suite.streamProcessor.Unsubscribe(openStream, stream.TimelineList+":01H0G8E4Q2J3FE3JDWJVWEDCD1")
+
+	// Still subscribed to home timeline.
+	suite.streamProcessor.Update(ctx, account, &apimodel.Status{ID: "01FVW7JHQFSFK166WWKR8CBA6M"}, stream.TimelineHome)
+
+	msg, ok := openStream.Recv(ctx)
+	suite.True(ok)
+	suite.Equal([]string{stream.TimelineHome}, msg.Stream)
+}
+
+func TestSubscribeTestSuite(t *testing.T) {
+	suite.Run(t, &SubscribeTestSuite{})
+}
@@ -20,31 +20,49 @@ package timeline
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
-	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
-func (p *Processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.PageableResponse, gtserror.WithCode) {
-	statuses, nextMaxID, prevMinID, err := p.state.DB.GetFavedTimeline(ctx, authed.Account.ID, maxID, minID, limit)
+// FavedTimelineGet returns a pageable response of statuses faved by the
+// requesting account, most recently faved first. Paging for this response
+// is done based on fave ID rather than status ID. Statuses which are no
+// longer visible to the requesting account are skipped.
+func (p *Processor) FavedTimelineGet(
+	ctx context.Context,
+	authed *oauth.Auth,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	faves, err := p.state.DB.GetStatusFavesByAccount(ctx, authed.Account.ID, page)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		err = fmt.Errorf("FavedTimelineGet: db error getting statuses: %w", err)
+		err = gtserror.Newf("db error getting faves: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	count := len(statuses)
+	count := len(faves)
 	if count == 0 {
-		return util.EmptyPageableResponse(), nil
+		return paging.EmptyResponse(), nil
 	}
 
-	items := make([]interface{}, 0, count)
-	for _, s := range statuses {
-		visible, err := p.filter.StatusVisible(ctx, authed.Account, s)
+	var (
+		visibles = make([]*gtsmodel.Status, 0, count)
+
+		// Get the lowest and highest ID values before
+		// filtering and API converting, so caller can
+		// still page properly. Page based on fave ID,
+		// not status ID.
+		lo = faves[count-1].ID
+		hi = faves[0].ID
+	)
+
+	for _, fave := range faves {
+		visible, err := p.filter.StatusVisible(ctx, authed.Account, fave.Status)
 		if err != nil {
 			log.Errorf(ctx, "error checking status visibility: %v", err)
 			continue
@@ -54,20 +72,21 @@ func (p *Processor) FavedTimelineGet(ctx context.Context, authed *oauth.Auth, ma
 			continue
 		}
 
-		apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, authed.Account)
-		if err != nil {
-			log.Errorf(ctx, "error convering to api status: %v", err)
-			continue
-		}
+		visibles = append(visibles, fave.Status)
+	}
+
+	// Convert all visible statuses at once.
+	apiStatuses := p.converter.StatusesToAPIStatuses(ctx, visibles, authed.Account)
 
-		items = append(items, apiStatus)
+	items := make([]interface{}, len(apiStatuses))
+	for i, apiStatus := range apiStatuses {
+		items[i] = apiStatus
 	}
 
-	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "/api/v1/favourites",
-		NextMaxIDValue: nextMaxID,
-		PrevMinIDValue: prevMinID,
-		Limit:          limit,
-	})
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/favourites",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
 }
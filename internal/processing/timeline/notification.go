@@ -21,18 +21,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
-	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
-func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, excludeTypes []string) (*apimodel.PageableResponse, gtserror.WithCode) {
-	notifs, err := p.state.DB.GetAccountNotifications(ctx, authed.Account.ID, maxID, sinceID, minID, limit, excludeTypes)
+func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, page *paging.Page, excludeTypes []string) (*apimodel.PageableResponse, gtserror.WithCode) {
+	var (
+		maxID   = page.GetMax()
+		sinceID string
+		minID   string
+	)
+
+	// The page minimum is either a
+	// min_id (paging up), or since_id.
+	if page.GetOrder().Ascending() {
+		minID = page.GetMin()
+	} else {
+		sinceID = page.GetMin()
+	}
+
+	notifs, err := p.state.DB.GetAccountNotifications(ctx, authed.Account.ID, maxID, sinceID, minID, page.GetLimit(), excludeTypes)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err = fmt.Errorf("NotificationsGet: db error getting notifications: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -40,26 +56,20 @@ func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, ma
 
 	count := len(notifs)
 	if count == 0 {
-		return util.EmptyPageableResponse(), nil
+		return paging.EmptyResponse(), nil
 	}
 
 	var (
-		items          = make([]interface{}, 0, count)
-		nextMaxIDValue string
-		prevMinIDValue string
-	)
+		items = make([]interface{}, 0, count)
 
-	for i, n := range notifs {
-		// Set next + prev values before filtering and API
-		// converting, so caller can still page properly.
-		if i == count-1 {
-			nextMaxIDValue = n.ID
-		}
-
-		if i == 0 {
-			prevMinIDValue = n.ID
-		}
+		// Get the lowest and highest ID values before
+		// filtering and API converting, so caller can
+		// still page properly.
+		lo = notifs[count-1].ID
+		hi = notifs[0].ID
+	)
 
+	for _, n := range notifs {
 		// Ensure this notification should be shown to requester.
 		if n.OriginAccount != nil {
 			// Account is set, ensure it's visible to notif target.
@@ -96,13 +106,20 @@ func (p *Processor) NotificationsGet(ctx context.Context, authed *oauth.Auth, ma
 		items = append(items, item)
 	}
 
-	return util.PackagePageableResponse(util.PageableResponseParams{
-		Items:          items,
-		Path:           "api/v1/notifications",
-		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
-	})
+	// Preserve excluded types
+	// in next / prev links.
+	var query url.Values
+	if len(excludeTypes) > 0 {
+		query = url.Values{"exclude_types[]": excludeTypes}
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/notifications",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
 }
 
 func (p *Processor) NotificationGet(ctx context.Context, account *gtsmodel.Account, targetNotifID string) (*apimodel.Notification, gtserror.WithCode) {
@@ -134,6 +151,36 @@ func (p *Processor) NotificationGet(ctx context.Context, account *gtsmodel.Accou
 	return apiNotif, nil
 }
 
+// NotificationsUnreadCount returns the amount of notifications targeting the
+// given account that are newer than the account's notifications marker, ie.,
+// that haven't been read yet, counting at most limit. If the account hasn't
+// set a notifications marker, all notifications are considered unread.
+func (p *Processor) NotificationsUnreadCount(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	limit int,
+) (*apimodel.NotificationsUnreadCount, gtserror.WithCode) {
+	lastReadID := id.Lowest
+
+	marker, err := p.state.DB.GetMarker(ctx, account.ID, gtsmodel.MarkerNameNotifications)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting notifications marker: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if marker != nil {
+		lastReadID = marker.LastReadID
+	}
+
+	count, err := p.state.DB.CountAccountNotificationsSince(ctx, account.ID, lastReadID, limit)
+	if err != nil {
+		err = gtserror.Newf("db error counting unread notifications: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.NotificationsUnreadCount{Count: count}, nil
+}
+
 func (p *Processor) NotificationsClear(ctx context.Context, authed *oauth.Auth) gtserror.WithCode {
 	// Delete all notifications of all types that target the authorized account.
 	if err := p.state.DB.DeleteNotifications(ctx, nil, authed.Account.ID, ""); err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -0,0 +1,249 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// NotificationPolicyGet returns the notification
+// policy of the given account, with a summary of
+// its currently pending notification requests.
+func (p *Processor) NotificationPolicyGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	requests, notifications, err := p.state.DB.CountAccountNotificationRequests(ctx, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error counting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.NotificationPolicy{
+		FilterNotFollowing: util.PtrValueOr(account.NotificationsFilterNotFollowing, false),
+		FilterNewAccounts:  util.PtrValueOr(account.NotificationsFilterNewAccounts, false),
+		Summary: apimodel.NotificationPolicySummary{
+			PendingRequestsCount:      requests,
+			PendingNotificationsCount: notifications,
+		},
+	}, nil
+}
+
+// NotificationPolicyUpdate updates the notification
+// policy of the given account with the given form.
+func (p *Processor) NotificationPolicyUpdate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	form *apimodel.UpdateNotificationPolicyRequest,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	columns := make([]string, 0, 2)
+
+	if form.FilterNotFollowing != nil {
+		account.NotificationsFilterNotFollowing = form.FilterNotFollowing
+		columns = append(columns, "notifications_filter_not_following")
+	}
+
+	if form.FilterNewAccounts != nil {
+		account.NotificationsFilterNewAccounts = form.FilterNewAccounts
+		columns = append(columns, "notifications_filter_new_accounts")
+	}
+
+	if len(columns) != 0 {
+		if err := p.state.DB.UpdateAccount(ctx, account, columns...); err != nil {
+			err := gtserror.Newf("db error updating account: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.NotificationPolicyGet(ctx, account)
+}
+
+// NotificationRequestsGet returns a page of the notification
+// requests of the given account, newest first.
+func (p *Processor) NotificationRequestsGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	requests, err := p.state.DB.GetAccountNotificationRequests(ctx, account.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(requests)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := requests[count-1].ID
+	hi := requests[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, request := range requests {
+		// Ensure origin account is visible to requester.
+		visible, err := p.filter.AccountVisible(ctx, account, request.FromAccount)
+		if err != nil {
+			log.Errorf(ctx, "error checking account visibility: %v", err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		item, err := p.converter.NotificationRequestToAPINotificationRequest(ctx, request)
+		if err != nil {
+			log.Errorf(ctx, "error converting notification request: %v", err)
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/notifications/requests",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// NotificationRequestGet returns the notification
+// request of the given account with the given ID.
+func (p *Processor) NotificationRequestGet(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) (*apimodel.NotificationRequest, gtserror.WithCode) {
+	request, errWithCode := p.getNotificationRequest(ctx, account, requestID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiRequest, err := p.converter.NotificationRequestToAPINotificationRequest(ctx, request)
+	if err != nil {
+		err := gtserror.Newf("error converting notification request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRequest, nil
+}
+
+// NotificationRequestAccept accepts the notification request of the
+// given account with the given ID: all notifications held in it are
+// released, and further notifications from the same origin account
+// will bypass the account's notification policy.
+func (p *Processor) NotificationRequestAccept(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) gtserror.WithCode {
+	request, errWithCode := p.getNotificationRequest(ctx, account, requestID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Allow origin account to notify
+	// this account from now on.
+	if err := p.state.DB.PutNotificationPermission(ctx, &gtsmodel.NotificationPermission{
+		ID:            id.NewULID(),
+		AccountID:     account.ID,
+		FromAccountID: request.FromAccountID,
+	}); err != nil {
+		err := gtserror.Newf("db error putting notification permission: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Release held notifications.
+	if err := p.state.DB.UnfilterNotifications(ctx, account.ID, request.FromAccountID); err != nil {
+		err := gtserror.Newf("db error unfiltering notifications: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteNotificationRequestByID(ctx, request.ID); err != nil {
+		err := gtserror.Newf("db error deleting notification request: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// NotificationRequestDismiss dismisses the notification request
+// of the given account with the given ID, deleting all notifications
+// held in it. Further notifications from the same origin account
+// will still be filtered according to the account's policy.
+func (p *Processor) NotificationRequestDismiss(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) gtserror.WithCode {
+	request, errWithCode := p.getNotificationRequest(ctx, account, requestID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteFilteredNotifications(ctx, account.ID, request.FromAccountID); err != nil {
+		err := gtserror.Newf("db error deleting filtered notifications: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteNotificationRequestByID(ctx, request.ID); err != nil {
+		err := gtserror.Newf("db error deleting notification request: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// getNotificationRequest gets the notification request with the
+// given ID, checking that it belongs to the given account.
+func (p *Processor) getNotificationRequest(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	requestID string,
+) (*gtsmodel.NotificationRequest, gtserror.WithCode) {
+	request, err := p.state.DB.GetNotificationRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		err := gtserror.Newf("db error getting notification request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if request.AccountID != account.ID {
+		err := gtserror.Newf("notification request %s does not belong to account %s", requestID, account.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return request, nil
+}
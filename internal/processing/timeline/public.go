@@ -24,13 +24,19 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *Processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.PageableResponse, gtserror.WithCode) {
+	// Timeline reads tolerate being slightly
+	// stale, so may be served from a replica.
+	ctx = gtscontext.SetReplicaRead(ctx)
+
 	statuses, err := p.state.DB.GetPublicTimeline(ctx, maxID, sinceID, minID, limit, local)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err = gtserror.Newf("db error getting statuses: %w", err)
@@ -43,7 +49,7 @@ func (p *Processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, m
 	}
 
 	var (
-		items = make([]interface{}, 0, count)
+		timelineables = make([]*gtsmodel.Status, 0, count)
 
 		// Set next + prev values before filtering and API
 		// converting, so caller can still page properly.
@@ -62,13 +68,15 @@ func (p *Processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, m
 			continue
 		}
 
-		apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, authed.Account)
-		if err != nil {
-			log.Errorf(ctx, "error convert to api status: %v", err)
-			continue
-		}
+		timelineables = append(timelineables, s)
+	}
+
+	// Convert all timelineable statuses at once.
+	apiStatuses := p.converter.StatusesToAPIStatuses(ctx, timelineables, authed.Account)
 
-		items = append(items, apiStatus)
+	items := make([]interface{}, len(apiStatuses))
+	for i, apiStatus := range apiStatuses {
+		items[i] = apiStatus
 	}
 
 	return util.PackagePageableResponse(util.PageableResponseParams{
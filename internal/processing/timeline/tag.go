@@ -24,6 +24,7 @@ import (
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -44,6 +45,10 @@ func (p *Processor) TagTimelineGet(
 	minID string,
 	limit int,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
+	// Timeline reads tolerate being slightly
+	// stale, so may be served from a replica.
+	ctx = gtscontext.SetReplicaRead(ctx)
+
 	tag, errWithCode := p.getTag(ctx, tagName)
 	if errWithCode != nil {
 		return nil, errWithCode
@@ -103,7 +108,7 @@ func (p *Processor) packageTagResponse(
 	}
 
 	var (
-		items = make([]interface{}, 0, count)
+		timelineables = make([]*gtsmodel.Status, 0, count)
 
 		// Set next + prev values before filtering and API
 		// converting, so caller can still page properly.
@@ -122,13 +127,15 @@ func (p *Processor) packageTagResponse(
 			continue
 		}
 
-		apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, requestingAcct)
-		if err != nil {
-			log.Errorf(ctx, "error converting to api status: %v", err)
-			continue
-		}
+		timelineables = append(timelineables, s)
+	}
+
+	// Convert all timelineable statuses at once.
+	apiStatuses := p.converter.StatusesToAPIStatuses(ctx, timelineables, requestingAcct)
 
-		items = append(items, apiStatus)
+	items := make([]interface{}, len(apiStatuses))
+	for i, apiStatus := range apiStatuses {
+		items[i] = apiStatus
 	}
 
 	return util.PackagePageableResponse(util.PageableResponseParams{
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// TokensGet returns the active oauth access
+// tokens that the given user has authorized.
+func (p *Processor) TokensGet(ctx context.Context, user *gtsmodel.User) ([]*apimodel.TokenInfo, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokens(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens for user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTokens := make([]*apimodel.TokenInfo, 0, len(tokens))
+	for _, token := range tokens {
+		apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+		if err != nil {
+			err := gtserror.Newf("error converting token %s: %w", token.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiTokens = append(apiTokens, apiToken)
+	}
+
+	return apiTokens, nil
+}
+
+// TokenDelete revokes the given user's oauth access token with the
+// given ID, closing any open streams that were authorized with it.
+func (p *Processor) TokenDelete(ctx context.Context, user *gtsmodel.User, id string) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil || token.UserID != user.ID || token.Access == "" {
+		// Don't reveal whether tokens
+		// of other users exist or not.
+		err := gtserror.Newf("token %s not found for user %s", id, user.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err := gtserror.Newf("error converting token %s: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting token %s: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Token no longer works for new requests,
+	// make sure it doesn't keep working for
+	// any streams that are already open too.
+	p.stream.CloseToken(user.AccountID, token.Access)
+
+	return apiToken, nil
+}
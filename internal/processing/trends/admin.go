@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// AdminTagsGet returns all currently trending tags at given
+// limit and offset, including those not yet approved.
+func (p *Processor) AdminTagsGet(ctx context.Context, limit int, offset int) ([]*apimodel.AdminTag, gtserror.WithCode) {
+	tags, err := p.trendingTags(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	tags = paginate(tags, limit, offset)
+
+	apiTags := make([]*apimodel.AdminTag, 0, len(tags))
+	for _, tag := range tags {
+		apiTag, err := p.converter.TagToAPIAdminTag(ctx, tag)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s to admin api tag: %v", tag.ID, err)
+			continue
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// AdminTagSetTrendable approves (or rejects) the
+// tag with given ID for being shown in trends.
+func (p *Processor) AdminTagSetTrendable(ctx context.Context, id string, trendable bool) (*apimodel.AdminTag, gtserror.WithCode) {
+	tag, err := p.state.DB.GetTag(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if tag == nil {
+		err := fmt.Errorf("tag %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	tag.Trendable = &trendable
+	if err := p.state.DB.UpdateTag(ctx, tag, "trendable"); err != nil {
+		err := gtserror.Newf("db error updating tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTag, err := p.converter.TagToAPIAdminTag(ctx, tag)
+	if err != nil {
+		err := gtserror.Newf("error converting tag to admin api tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiTag, nil
+}
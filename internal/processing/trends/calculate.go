@@ -0,0 +1,320 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"cmp"
+	"context"
+	"html"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+const (
+	// calculateEvery is the frequency
+	// at which trends are recalculated.
+	calculateEvery = 15 * time.Minute
+
+	// trendingWindow is how far back tag
+	// uses and status interactions are
+	// considered when scoring trends.
+	trendingWindow = 48 * time.Hour
+
+	// maxCandidates is the maximum number
+	// of tags, statuses and links kept
+	// after each trends calculation.
+	maxCandidates = 100
+)
+
+// linkRegex matches anchors in status HTML content,
+// capturing all attributes preceding the closing '>'.
+var linkRegex = regexp.MustCompile(`<a\s+([^>]*)>`)
+
+// hrefRegex and classRegex capture
+// the href and class of an anchor.
+var (
+	hrefRegex  = regexp.MustCompile(`href="([^"]+)"`)
+	classRegex = regexp.MustCompile(`class="([^"]*)"`)
+)
+
+// Schedule schedules trends to be recalculated
+// periodically, starting immediately, if enabled.
+func (p *Processor) Schedule() error {
+	if !config.GetInstanceTrendsEnabled() {
+		// Nothing to do.
+		return nil
+	}
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@trends",
+		time.Time{},
+		calculateEvery,
+		func(ctx context.Context, now time.Time) {
+			if err := p.Calculate(ctx, now); err != nil {
+				log.Errorf(ctx, "error calculating trends: %v", err)
+			}
+		},
+	) {
+		return gtserror.New("failed to schedule @trends")
+	}
+
+	return nil
+}
+
+// Calculate recalculates trending tags, statuses
+// and links as of the given time, pruning tag
+// usage older than the history window.
+func (p *Processor) Calculate(ctx context.Context, now time.Time) error {
+	if !config.GetInstanceTrendsEnabled() {
+		// Nothing to do.
+		return nil
+	}
+
+	// Tag uses older than the history
+	// window are no longer needed.
+	if err := p.state.DB.DeleteTagUsesOlderThan(ctx,
+		typeutils.HistoryStart(now),
+	); err != nil {
+		return gtserror.Newf("error pruning tag uses: %w", err)
+	}
+
+	since := now.Add(-trendingWindow)
+
+	tagIDs, err := p.calculateTags(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := p.calculateStatuses(ctx, now, since)
+	if err != nil {
+		return err
+	}
+
+	statusIDs := make([]string, len(statuses))
+	for i, status := range statuses {
+		statusIDs[i] = status.ID
+	}
+
+	links := calculateLinks(now, statuses)
+
+	p.trends.mu.Lock()
+	p.trends.tagIDs = tagIDs
+	p.trends.statusIDs = statusIDs
+	p.trends.links = links
+	p.trends.mu.Unlock()
+
+	return nil
+}
+
+// calculateTags returns the IDs of the tags used
+// by the most distinct accounts since given time,
+// falling back to total uses to break ties.
+func (p *Processor) calculateTags(ctx context.Context, since time.Time) ([]string, error) {
+	uses, err := p.state.DB.GetTagUsesSince(ctx, since)
+	if err != nil {
+		return nil, gtserror.Newf("error getting tag uses: %w", err)
+	}
+
+	type score struct {
+		tagID    string
+		uses     int
+		accounts map[string]struct{}
+	}
+
+	scores := make(map[string]*score)
+	for _, use := range uses {
+		s, ok := scores[use.TagID]
+		if !ok {
+			s = &score{tagID: use.TagID, accounts: make(map[string]struct{})}
+			scores[use.TagID] = s
+		}
+
+		s.uses++
+		s.accounts[use.AccountID] = struct{}{}
+	}
+
+	sorted := make([]*score, 0, len(scores))
+	for _, s := range scores {
+		sorted = append(sorted, s)
+	}
+
+	slices.SortFunc(sorted, func(a, b *score) int {
+		if c := cmp.Compare(len(b.accounts), len(a.accounts)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.uses, a.uses); c != 0 {
+			return c
+		}
+		return strings.Compare(a.tagID, b.tagID)
+	})
+
+	sorted = paginate(sorted, maxCandidates, 0)
+
+	tagIDs := make([]string, len(sorted))
+	for i, s := range sorted {
+		tagIDs[i] = s.tagID
+	}
+
+	return tagIDs, nil
+}
+
+// calculateStatuses returns the public statuses that were
+// faved and boosted most since given time, excluding any
+// statuses older than the history window.
+func (p *Processor) calculateStatuses(ctx context.Context, now time.Time, since time.Time) ([]*gtsmodel.Status, error) {
+	counts, err := p.state.DB.GetStatusInteractionCountsSince(ctx, since, maxCandidates)
+	if err != nil {
+		return nil, gtserror.Newf("error getting status interaction counts: %w", err)
+	}
+
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+
+	statuses, err := p.state.DB.GetStatusesByIDs(ctx, ids)
+	if err != nil {
+		return nil, gtserror.Newf("error getting statuses: %w", err)
+	}
+
+	historyStart := typeutils.HistoryStart(now)
+
+	statuses = slices.DeleteFunc(statuses, func(s *gtsmodel.Status) bool {
+		return s.Visibility != gtsmodel.VisibilityPublic ||
+			s.BoostOfID != "" ||
+			s.CreatedAt.Before(historyStart)
+	})
+
+	slices.SortFunc(statuses, func(a, b *gtsmodel.Status) int {
+		if c := cmp.Compare(counts[b.ID], counts[a.ID]); c != 0 {
+			return c
+		}
+
+		// Newest first.
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	return paginate(statuses, maxCandidates, 0), nil
+}
+
+// calculateLinks returns the links shared by the most distinct
+// accounts among the given statuses, along with usage history.
+func calculateLinks(now time.Time, statuses []*gtsmodel.Status) []*apimodel.TrendsLink {
+	type link struct {
+		url      string
+		statuses []*gtsmodel.Status
+		accounts map[string]struct{}
+	}
+
+	links := make(map[string]*link)
+	for _, status := range statuses {
+		for _, u := range statusLinks(status.Content) {
+			l, ok := links[u]
+			if !ok {
+				l = &link{url: u, accounts: make(map[string]struct{})}
+				links[u] = l
+			}
+
+			l.statuses = append(l.statuses, status)
+			l.accounts[status.AccountID] = struct{}{}
+		}
+	}
+
+	sorted := make([]*link, 0, len(links))
+	for _, l := range links {
+		sorted = append(sorted, l)
+	}
+
+	slices.SortFunc(sorted, func(a, b *link) int {
+		if c := cmp.Compare(len(b.accounts), len(a.accounts)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(b.statuses), len(a.statuses)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.url, b.url)
+	})
+
+	sorted = paginate(sorted, maxCandidates, 0)
+
+	apiLinks := make([]*apimodel.TrendsLink, len(sorted))
+	for i, l := range sorted {
+		var providerName string
+		if u, err := url.Parse(l.url); err == nil {
+			providerName = u.Hostname()
+		}
+
+		apiLinks[i] = &apimodel.TrendsLink{
+			Card: apimodel.Card{
+				URL:          l.url,
+				Title:        l.url,
+				Type:         "link",
+				ProviderName: providerName,
+			},
+			History: typeutils.UsesToAPIHistory(now, l.statuses, func(s *gtsmodel.Status) (time.Time, string) {
+				return s.CreatedAt, s.AccountID
+			}),
+		}
+	}
+
+	return apiLinks
+}
+
+// statusLinks returns the unique http(s) links in the given
+// status HTML content, ignoring mentions and hashtags.
+func statusLinks(content string) []string {
+	var links []string
+	for _, match := range linkRegex.FindAllStringSubmatch(content, -1) {
+		attrs := match[1]
+
+		if class := classRegex.FindStringSubmatch(attrs); class != nil &&
+			strings.Contains(class[1], "mention") {
+			// Mentions and hashtags
+			// are both marked up as
+			// mentions, skip these.
+			continue
+		}
+
+		href := hrefRegex.FindStringSubmatch(attrs)
+		if href == nil {
+			continue
+		}
+
+		u := html.UnescapeString(href[1])
+		if !strings.HasPrefix(u, "https://") &&
+			!strings.HasPrefix(u, "http://") {
+			continue
+		}
+
+		if !slices.Contains(links, u) {
+			links = append(links, u)
+		}
+	}
+
+	return links
+}
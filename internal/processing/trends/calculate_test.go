@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStatusLinks(t *testing.T) {
+	for _, test := range []struct {
+		content string
+		expect  []string
+	}{
+		{
+			content: `<p>hello world</p>`,
+			expect:  nil,
+		},
+		{
+			content: `<p>check out <a href="https://example.org/article?a=1&amp;b=2" rel="nofollow noreferrer noopener" target="_blank">https://example.org/article?a=1&amp;b=2</a> and <a href="https://example.org/article?a=1&amp;b=2" rel="nofollow noreferrer noopener" target="_blank">this again</a></p>`,
+			expect:  []string{"https://example.org/article?a=1&b=2"},
+		},
+		{
+			content: `<p><span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> <a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag">#<span>welcome</span></a> <a href="http://example.org/page">page</a></p>`,
+			expect:  []string{"http://example.org/page"},
+		},
+		{
+			content: `<p><a href="mailto:someone@example.org">mail me</a></p>`,
+			expect:  nil,
+		},
+	} {
+		if links := statusLinks(test.content); !slices.Equal(links, test.expect) {
+			t.Errorf("expected %v, got %v for content %q", test.expect, links, test.content)
+		}
+	}
+}
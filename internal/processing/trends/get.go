@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// TagsGet returns trending tags that have been approved
+// as trendable by an admin, at given limit and offset.
+func (p *Processor) TagsGet(ctx context.Context, limit int, offset int) ([]apimodel.Tag, gtserror.WithCode) {
+	if !config.GetInstanceTrendsEnabled() {
+		return []apimodel.Tag{}, nil
+	}
+
+	tags, err := p.trendingTags(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Only show approved, useable tags.
+	approved := make([]*gtsmodel.Tag, 0, len(tags))
+	for _, tag := range tags {
+		if *tag.Trendable && *tag.Useable {
+			approved = append(approved, tag)
+		}
+	}
+
+	approved = paginate(approved, limit, offset)
+
+	apiTags := make([]apimodel.Tag, 0, len(approved))
+	for _, tag := range approved {
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, true)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s to api tag: %v", tag.ID, err)
+			continue
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// StatusesGet returns trending public statuses
+// visible to requester, at given limit and offset.
+// Requester may be nil for unauthenticated requests.
+func (p *Processor) StatusesGet(ctx context.Context, requester *gtsmodel.Account, limit int, offset int) ([]*apimodel.Status, gtserror.WithCode) {
+	if !config.GetInstanceTrendsEnabled() {
+		return []*apimodel.Status{}, nil
+	}
+
+	p.trends.mu.RLock()
+	statusIDs := p.trends.statusIDs
+	p.trends.mu.RUnlock()
+
+	statuses, err := p.state.DB.GetStatusesByIDs(ctx, statusIDs)
+	if err != nil {
+		err := gtserror.Newf("db error getting statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Filter out any statuses not visible
+	// to requester before paging through.
+	visible := make([]*gtsmodel.Status, 0, len(statuses))
+	for _, status := range statuses {
+		ok, err := p.filter.StatusVisible(ctx, requester, status)
+		if err != nil {
+			log.Errorf(ctx, "error checking status visibility: %v", err)
+			continue
+		}
+
+		if ok {
+			visible = append(visible, status)
+		}
+	}
+
+	visible = paginate(visible, limit, offset)
+
+	apiStatuses := make([]*apimodel.Status, 0, len(visible))
+	for _, status := range visible {
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester)
+		if err != nil {
+			log.Errorf(ctx, "error converting to api status: %v", err)
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
+
+// LinksGet returns trending links at given limit and offset.
+func (p *Processor) LinksGet(ctx context.Context, limit int, offset int) ([]*apimodel.TrendsLink, gtserror.WithCode) {
+	if !config.GetInstanceTrendsEnabled() {
+		return []*apimodel.TrendsLink{}, nil
+	}
+
+	p.trends.mu.RLock()
+	links := paginate(p.trends.links, limit, offset)
+	p.trends.mu.RUnlock()
+
+	if links == nil {
+		links = []*apimodel.TrendsLink{}
+	}
+
+	return links, nil
+}
+
+// trendingTags returns the most recently calculated
+// trending tags, whether or not they are approved.
+func (p *Processor) trendingTags(ctx context.Context) ([]*gtsmodel.Tag, error) {
+	p.trends.mu.RLock()
+	tagIDs := p.trends.tagIDs
+	p.trends.mu.RUnlock()
+
+	tags, err := p.state.DB.GetTags(ctx, tagIDs)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting tags: %w", err)
+	}
+
+	return tags, nil
+}
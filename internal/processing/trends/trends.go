@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"sync"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+	filter    *visibility.Filter
+
+	// most recently
+	// calculated trends.
+	trends *trends
+}
+
+// trends holds the results of the most
+// recent trends calculation, in order of
+// descending score.
+type trends struct {
+	mu        sync.RWMutex
+	tagIDs    []string
+	statusIDs []string
+	links     []*apimodel.TrendsLink
+}
+
+// New returns a new trends processor.
+func New(state *state.State, converter *typeutils.Converter, filter *visibility.Filter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+		filter:    filter,
+		trends:    new(trends),
+	}
+}
+
+// paginate returns the page of s
+// at given offset, of at most limit.
+func paginate[T any](s []T, limit int, offset int) []T {
+	if offset >= len(s) {
+		return nil
+	}
+
+	s = s[offset:]
+	if limit < len(s) {
+		s = s[:limit]
+	}
+
+	return s
+}
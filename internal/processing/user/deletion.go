@@ -0,0 +1,317 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// DeletionRequest processes a request from the given user
+// to delete their own account.
+//
+// If accounts-deletion-grace-period is set, the account is
+// suspended straight away, and the actual deletion is scheduled
+// for when the grace period has passed. Until then, the deletion
+// can be cancelled by the user (via a link sent to them by email),
+// or by an admin (by unsuspending the account, see the admin processor).
+//
+// Otherwise, the account is deleted immediately.
+func (p *Processor) DeletionRequest(
+	ctx context.Context,
+	user *gtsmodel.User,
+	account *gtsmodel.Account,
+) gtserror.WithCode {
+	gracePeriod := config.GetAccountsDeletionGracePeriod()
+	if gracePeriod <= 0 {
+		// No grace period, just
+		// delete account now.
+		p.enqueueDelete(ctx, account)
+		return nil
+	}
+
+	if !user.DeletionScheduledAt.IsZero() {
+		// Deletion already
+		// scheduled, nothing
+		// more to do.
+		return nil
+	}
+
+	// Mark user for deletion.
+	now := time.Now()
+	user.DeletionScheduledAt = now.Add(gracePeriod)
+	user.DeletionCancelToken = uuid.NewString()
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"deletion_scheduled_at",
+		"deletion_cancel_token",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Suspend the account in the meantime, using
+	// the account's own ID as suspension origin
+	// to mark this as a self-inflicted suspension.
+	account.SuspendedAt = now
+	account.SuspensionOrigin = account.ID
+	if err := p.state.DB.UpdateAccount(
+		ctx,
+		account,
+		"suspended_at",
+		"suspension_origin",
+	); err != nil {
+		err := gtserror.Newf("db error updating account: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.ScheduleDeletion(ctx, user); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Let the user know, and give them a
+	// way out in case they change their mind.
+	if err := p.emailDeletionScheduled(ctx, user, account); err != nil {
+		log.Errorf(ctx, "error emailing user %s: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+// DeletionCancel processes a request to cancel a scheduled account
+// deletion, usually initiated as a result of clicking on the link
+// in a 'your account will be deleted' type email.
+func (p *Processor) DeletionCancel(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
+	if token == "" {
+		return nil, gtserror.NewErrorNotFound(errors.New("no token provided"))
+	}
+
+	user, err := p.state.DB.GetUserByDeletionCancelToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user.DeletionScheduledAt.IsZero() {
+		// Nothing to cancel.
+		return nil, gtserror.NewErrorNotFound(errors.New("no deletion scheduled"))
+	}
+
+	if user.Account == nil {
+		account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		user.Account = account
+	}
+
+	if user.Account.SuspensionOrigin != user.AccountID {
+		// Account was suspended by someone else
+		// in the meantime, don't unsuspend it.
+		const text = "account is suspended"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if err := p.cancelDeletion(ctx, user, user.Account); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
+
+// cancelDeletion clears the scheduled deletion
+// of the given user, and lifts the suspension
+// of the user's account.
+func (p *Processor) cancelDeletion(
+	ctx context.Context,
+	user *gtsmodel.User,
+	account *gtsmodel.Account,
+) error {
+	user.DeletionScheduledAt = time.Time{}
+	user.DeletionCancelToken = ""
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"deletion_scheduled_at",
+		"deletion_cancel_token",
+	); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	account.SuspendedAt = time.Time{}
+	account.SuspensionOrigin = ""
+	if err := p.state.DB.UpdateAccount(
+		ctx,
+		account,
+		"suspended_at",
+		"suspension_origin",
+	); err != nil {
+		return gtserror.Newf("db error updating account: %w", err)
+	}
+
+	// Task would no-op anyway,
+	// but tidy up after ourselves.
+	_ = p.state.Workers.Scheduler.Cancel(deletionTaskID(account.ID))
+
+	return nil
+}
+
+// ScheduleDeletions schedules deletion of all
+// accounts currently marked for deletion.
+func (p *Processor) ScheduleDeletions(ctx context.Context) error {
+	users, err := p.state.DB.GetUsersScheduledForDeletion(gtscontext.SetBarebones(ctx))
+	if err != nil {
+		return gtserror.Newf("error getting users scheduled for deletion from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, user := range users {
+		// Schedule each of the deletions and catch any errors.
+		if err := p.ScheduleDeletion(ctx, user); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// ScheduleDeletion adds the deletion of the
+// given user's account to the scheduler.
+func (p *Processor) ScheduleDeletion(ctx context.Context, user *gtsmodel.User) error {
+	// Ensure has a valid deletion time.
+	if user.DeletionScheduledAt.IsZero() {
+		return gtserror.Newf("user %s not scheduled for deletion", user.ID)
+	}
+
+	// Clear any old task for this user
+	// (eg., deletion requested, cancelled,
+	// then requested again), then add.
+	taskID := deletionTaskID(user.AccountID)
+	_ = p.state.Workers.Scheduler.Cancel(taskID)
+	ok := p.state.Workers.Scheduler.AddOnce(
+		taskID,
+		user.DeletionScheduledAt,
+		p.onDeletion(user.ID),
+	)
+
+	if !ok {
+		// Failed to add the deletion to the
+		// scheduler, it was starting / stopping.
+		return gtserror.Newf("failed adding deletion of user %s to scheduler", user.ID)
+	}
+
+	atStr := user.DeletionScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled account deletion for user %s at '%s'", user.ID, atStr)
+	return nil
+}
+
+// onDeletion returns a callback function to be used by
+// the scheduler when the given user's account is due
+// to be deleted.
+func (p *Processor) onDeletion(userID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of user from database.
+		user, err := p.state.DB.GetUserByID(ctx, userID)
+		if err != nil {
+			log.Errorf(ctx, "error getting user %s from db: %v", userID, err)
+			return
+		}
+
+		if user.DeletionScheduledAt.IsZero() ||
+			user.DeletionScheduledAt.After(now) {
+			// Deletion was cancelled
+			// (or rescheduled) since
+			// this task was added.
+			return
+		}
+
+		p.enqueueDelete(ctx, user.Account)
+	}
+}
+
+// enqueueDelete enqueues deletion of the given
+// account, with the account itself as origin.
+// See also: (*account.Processor).DeleteSelf().
+func (p *Processor) enqueueDelete(ctx context.Context, account *gtsmodel.Account) {
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityDelete,
+		OriginAccount:  account,
+		TargetAccount:  account,
+	})
+}
+
+func (p *Processor) emailDeletionScheduled(
+	ctx context.Context,
+	user *gtsmodel.User,
+	account *gtsmodel.Account,
+) error {
+	if user.Email == "" {
+		// Nowhere
+		// to send it.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	if err := p.emailSender.SendDeletionEmail(
+		user.Email,
+		email.DeletionData{
+			Username:     account.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			DeletionTime: user.DeletionScheduledAt.UTC().Format("Jan _2 2006 15:04:05 MST"),
+			CancelLink:   uris.GenerateURIForDeletionCancel(user.DeletionCancelToken),
+		},
+	); err != nil {
+		return err
+	}
+
+	user.LastEmailedAt = time.Now()
+	if err := p.state.DB.UpdateUser(ctx, user, "last_emailed_at"); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return nil
+}
+
+// deletionTaskID returns the scheduler task
+// ID for deletion of the given account.
+func deletionTaskID(accountID string) string {
+	return "account-deletion-" + accountID
+}
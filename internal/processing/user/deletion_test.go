@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DeletionTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *DeletionTestSuite) TestDeletionRequestThenCancel() {
+	ctx := context.Background()
+
+	testrig.StartNoopWorkers(&suite.state)
+	defer testrig.StopWorkers(&suite.state)
+
+	config.SetAccountsDeletionGracePeriod(168 * time.Hour)
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if errWithCode := suite.user.DeletionRequest(ctx, user, user.Account); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// User should be scheduled for deletion
+	// in a week, with a token set to cancel.
+	user, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(time.Now().Add(168*time.Hour), user.DeletionScheduledAt, 1*time.Minute)
+	suite.NotEmpty(user.DeletionCancelToken)
+
+	// Account should be suspended by itself.
+	suite.WithinDuration(time.Now(), user.Account.SuspendedAt, 1*time.Minute)
+	suite.Equal(user.AccountID, user.Account.SuspensionOrigin)
+
+	// User should have been told how to cancel.
+	suite.Len(suite.sentEmails, 1)
+	suite.Contains(suite.sentEmails[user.Email], "/cancel_deletion?token="+user.DeletionCancelToken)
+
+	// Cancel using the token.
+	user, errWithCode := suite.user.DeletionCancel(ctx, user.DeletionCancelToken)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Deletion + suspension should be lifted.
+	user, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(user.DeletionScheduledAt)
+	suite.Empty(user.DeletionCancelToken)
+	suite.Zero(user.Account.SuspendedAt)
+	suite.Empty(user.Account.SuspensionOrigin)
+}
+
+func (suite *DeletionTestSuite) TestDeletionCancelBadToken() {
+	user, errWithCode := suite.user.DeletionCancel(context.Background(), "8a1ef4bd-ed4e-4a5b-8b4f-9e4d0a5ac7f6")
+	suite.Nil(user)
+	suite.EqualError(errWithCode, "sql: no rows in result set")
+}
+
+func TestDeletionTestSuite(t *testing.T) {
+	suite.Run(t, &DeletionTestSuite{})
+}
@@ -23,13 +23,23 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 var oneWeek = 168 * time.Hour
 
+// confirmResendInterval is the minimum time
+// between confirmation emails sent to a user,
+// to prevent the resend endpoint being abused
+// to flood someone's inbox.
+const confirmResendInterval = 5 * time.Minute
+
 // EmailConfirm processes an email confirmation request, usually initiated as a result of clicking on a link
 // in a 'confirm your email address' type email.
 func (p *Processor) EmailConfirm(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
@@ -80,3 +90,114 @@ func (p *Processor) EmailConfirm(ctx context.Context, token string) (*gtsmodel.U
 
 	return user, nil
 }
+
+// EmailConfirmResend sends the 'please confirm your email address'
+// email again to the given user, who has not yet confirmed their email
+// address, eg., because the first one didn't arrive.
+//
+// If newEmail is set, the unconfirmed email address of the user is
+// changed to this first, in case they made a typo when signing up.
+// It's assumed the caller has already validated newEmail.
+//
+// Confirmation emails can only be sent once every confirmResendInterval.
+func (p *Processor) EmailConfirmResend(ctx context.Context, userID string, newEmail string) gtserror.WithCode {
+	user, err := p.state.DB.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !user.ConfirmedAt.IsZero() {
+		// Only for users who haven't confirmed their email yet;
+		// changing the email of a confirmed user is done with
+		// the password (ie., via the user settings).
+		const text = "email address already confirmed"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if sinceLast := time.Since(user.ConfirmationSentAt); sinceLast < confirmResendInterval {
+		text := fmt.Sprintf(
+			"confirmation email was sent recently, please wait %s before trying again",
+			(confirmResendInterval - sinceLast).Round(time.Second),
+		)
+		return gtserror.NewErrorTooManyRequests(errors.New(text), text)
+	}
+
+	columns := []string{
+		"confirmation_token",
+		"confirmation_sent_at",
+		"last_emailed_at",
+	}
+
+	if newEmail != "" && newEmail != user.UnconfirmedEmail {
+		available, err := p.state.DB.IsEmailAvailable(ctx, newEmail)
+		if err != nil {
+			err := gtserror.Newf("db error checking email availability: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if !available {
+			err := fmt.Errorf("email address %s is not available", newEmail)
+			return gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		user.UnconfirmedEmail = newEmail
+		columns = append(columns, "unconfirmed_email")
+	}
+
+	if user.UnconfirmedEmail == "" {
+		const text = "no email address to confirm"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if user.Account == nil {
+		account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+		user.Account = account
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		err := gtserror.Newf("db error getting instance: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Fresh token, so any link in
+	// earlier emails stops working.
+	confirmToken := uuid.NewString()
+
+	if err := p.emailSender.SendConfirmEmail(
+		user.UnconfirmedEmail,
+		email.ConfirmData{
+			Username:     user.Account.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			ConfirmLink:  uris.GenerateURIForEmailConfirm(confirmToken),
+		},
+	); err != nil {
+		if gtserror.IsSMTP(err) ||
+			errors.Is(err, email.ErrNotConfigured) {
+			// Let the caller know the
+			// email couldn't be sent.
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	user.ConfirmationToken = confirmToken
+	user.ConfirmationSentAt = now
+	user.LastEmailedAt = now
+	if err := p.state.DB.UpdateUser(ctx, user, columns...); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
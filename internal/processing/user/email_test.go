@@ -19,6 +19,7 @@ package user_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
@@ -79,6 +80,37 @@ func (suite *EmailConfirmTestSuite) TestConfirmEmailOldToken() {
 	suite.EqualError(errWithCode, "ConfirmEmail: confirmation token expired")
 }
 
+func (suite *EmailConfirmTestSuite) TestConfirmEmailResend() {
+	ctx := context.Background()
+
+	user := suite.testUsers["unconfirmed_account"]
+
+	// Change the address while we're at it.
+	errWithCode := suite.user.EmailConfirmResend(ctx, user.ID, "typo.fixed@example.org")
+	suite.NoError(errWithCode)
+
+	user, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("typo.fixed@example.org", user.UnconfirmedEmail)
+	suite.NotEmpty(user.ConfirmationToken)
+	suite.WithinDuration(time.Now(), user.ConfirmationSentAt, time.Minute)
+	suite.Contains(suite.sentEmails["typo.fixed@example.org"], user.ConfirmationToken)
+
+	// Sending again straight
+	// away should be refused.
+	errWithCode = suite.user.EmailConfirmResend(ctx, user.ID, "")
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+}
+
+func (suite *EmailConfirmTestSuite) TestConfirmEmailResendConfirmed() {
+	ctx := context.Background()
+
+	errWithCode := suite.user.EmailConfirmResend(ctx, suite.testUsers["local_account_1"].ID, "")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
 func TestEmailConfirmTestSuite(t *testing.T) {
 	suite.Run(t, &EmailConfirmTestSuite{})
 }
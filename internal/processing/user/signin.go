@@ -0,0 +1,191 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// signInLockoutThreshold is the number of failed
+	// sign ins in a row after which sign in is locked.
+	signInLockoutThreshold = 5
+
+	// signInLockoutBase is how long sign in is locked
+	// for on reaching the threshold. Each further failed
+	// sign in doubles this, up to signInLockoutMax.
+	signInLockoutBase = time.Minute
+	signInLockoutMax  = 24 * time.Hour
+
+	// signInLockoutReset is how long after a lock
+	// has expired that failed sign ins are forgotten,
+	// and the lockout duration starts from scratch.
+	signInLockoutReset = 24 * time.Hour
+)
+
+// SignInLocked returns whether sign in is currently locked
+// for the given user, following too many failed sign ins,
+// and if so, the time until which it's locked.
+func (p *Processor) SignInLocked(user *gtsmodel.User) (time.Time, bool) {
+	if user.SignInLockedUntil.IsZero() ||
+		time.Now().After(user.SignInLockedUntil) {
+		return time.Time{}, false
+	}
+	return user.SignInLockedUntil, true
+}
+
+// SignInFailed records a failed sign in for the given
+// user (ie., an incorrect password), locking sign in for
+// an exponentially increasing time once the threshold of
+// failed sign ins in a row is reached. The count is only
+// cleared by a successful sign in, or once a lock has been
+// expired for signInLockoutReset.
+//
+// The user is emailed the first time sign in is locked.
+func (p *Processor) SignInFailed(ctx context.Context, user *gtsmodel.User) error {
+	count, prevLockedUntil, err := p.state.DB.IncrementUserFailedSignIns(ctx,
+		user.ID,
+		time.Now().Add(-signInLockoutReset),
+	)
+	if err != nil {
+		return gtserror.Newf("db error incrementing failed sign ins: %w", err)
+	}
+
+	user.FailedSignInCount = count
+	user.SignInLockedUntil = prevLockedUntil
+
+	if user.FailedSignInCount < signInLockoutThreshold {
+		// Not locking yet.
+		return nil
+	}
+
+	// Only the lock column is updated, so as
+	// not to overwrite any concurrent increments.
+	user.SignInLockedUntil = time.Now().Add(signInLockoutDuration(user.FailedSignInCount))
+	if err := p.state.DB.UpdateUser(ctx, user, "sign_in_locked_until"); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	if prevLockedUntil.IsZero() {
+		// Only email on first lock, so as
+		// not to flood the user's inbox if
+		// someone keeps trying anyway.
+		if err := p.emailSignInLocked(ctx, user); err != nil {
+			log.Errorf(ctx, "error emailing user %s: %v", user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// SignInSucceeded records a successful sign in for the
+// given user from the given IP address, and clears any
+// failed sign ins recorded for them.
+//
+// The IP address is stored according to accounts-ip-retention.
+func (p *Processor) SignInSucceeded(ctx context.Context, user *gtsmodel.User, ip net.IP) error {
+	user.LastSignInAt = user.CurrentSignInAt
+	user.LastSignInIP = user.CurrentSignInIP
+	user.CurrentSignInAt = time.Now()
+	user.CurrentSignInIP = util.RetainIP(ip)
+	user.SignInCount++
+	user.FailedSignInCount = 0
+	user.SignInLockedUntil = time.Time{}
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"last_sign_in_at",
+		"last_sign_in_ip",
+		"current_sign_in_at",
+		"current_sign_in_ip",
+		"sign_in_count",
+		"failed_sign_in_count",
+		"sign_in_locked_until",
+	); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return nil
+}
+
+// signInLockoutDuration returns how long sign in should
+// be locked for after the given number of failed sign ins.
+func signInLockoutDuration(failed int) time.Duration {
+	exp := failed - signInLockoutThreshold
+	if exp >= 16 {
+		// Would be over
+		// the max anyway.
+		return signInLockoutMax
+	}
+
+	d := signInLockoutBase << exp
+	if d > signInLockoutMax {
+		return signInLockoutMax
+	}
+	return d
+}
+
+func (p *Processor) emailSignInLocked(ctx context.Context, user *gtsmodel.User) error {
+	if user.Email == "" {
+		// Nowhere
+		// to send it.
+		return nil
+	}
+
+	if user.Account == nil {
+		account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			return gtserror.Newf("db error getting account: %w", err)
+		}
+		user.Account = account
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	if err := p.emailSender.SendLockoutEmail(
+		user.Email,
+		email.LockoutData{
+			Username:       user.Account.Username,
+			InstanceURL:    instance.URI,
+			InstanceName:   instance.Title,
+			FailedAttempts: user.FailedSignInCount,
+			LockedUntil:    user.SignInLockedUntil.UTC().Format("Jan _2 2006 15:04:05 MST"),
+		},
+	); err != nil {
+		return err
+	}
+
+	user.LastEmailedAt = time.Now()
+	if err := p.state.DB.UpdateUser(ctx, user, "last_emailed_at"); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type SignInTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *SignInTestSuite) TestSignInLockout() {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Fail four times, should
+	// not be locked yet.
+	for i := 0; i < 4; i++ {
+		if err := suite.user.SignInFailed(ctx, user); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	_, locked := suite.user.SignInLocked(user)
+	suite.False(locked)
+	suite.Empty(suite.sentEmails)
+
+	// Fifth time should lock
+	// and email the user.
+	if err := suite.user.SignInFailed(ctx, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+	lockedUntil, locked := suite.user.SignInLocked(user)
+	suite.True(locked)
+	suite.WithinDuration(time.Now().Add(time.Minute), lockedUntil, 10*time.Second)
+	suite.Contains(suite.sentEmails[user.Email], "there have been 5 failed attempts")
+
+	// Lockout should be stored.
+	user, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(5, user.FailedSignInCount)
+	suite.WithinDuration(lockedUntil, user.SignInLockedUntil, time.Second)
+
+	// Another failure should
+	// double the lockout time.
+	if err := suite.user.SignInFailed(ctx, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+	lockedUntil, _ = suite.user.SignInLocked(user)
+	suite.WithinDuration(time.Now().Add(2*time.Minute), lockedUntil, 10*time.Second)
+
+	// Success should clear everything.
+	if err := suite.user.SignInSucceeded(ctx, user, net.ParseIP("192.0.2.123")); err != nil {
+		suite.FailNow(err.Error())
+	}
+	user, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	_, locked = suite.user.SignInLocked(user)
+	suite.False(locked)
+	suite.Zero(user.FailedSignInCount)
+	suite.Zero(user.SignInLockedUntil)
+	suite.Equal("192.0.2.123", user.CurrentSignInIP.String())
+}
+
+func (suite *SignInTestSuite) TestSignInLockoutGrowsAfterExpiry() {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Fail until locked.
+	for i := 0; i < 5; i++ {
+		if err := suite.user.SignInFailed(ctx, user); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	firstLockedUntil, locked := suite.user.SignInLocked(user)
+	suite.True(locked)
+	suite.Contains(suite.sentEmails[user.Email], "there have been 5 failed attempts")
+	delete(suite.sentEmails, user.Email)
+
+	// expire stores a lock on the user
+	// that expired a moment ago, as if
+	// the user had waited it out.
+	expire := func() {
+		user.SignInLockedUntil = time.Now().Add(-time.Second)
+		if err := suite.db.UpdateUser(ctx, user, "sign_in_locked_until"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Failing once after the lock
+	// expires should lock again,
+	// for longer than the first lock.
+	expire()
+	if err := suite.user.SignInFailed(ctx, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+	secondLockedUntil, locked := suite.user.SignInLocked(user)
+	suite.True(locked)
+	suite.WithinDuration(time.Now().Add(2*time.Minute), secondLockedUntil, 10*time.Second)
+	suite.True(secondLockedUntil.After(firstLockedUntil))
+
+	// And again.
+	expire()
+	if err := suite.user.SignInFailed(ctx, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+	thirdLockedUntil, locked := suite.user.SignInLocked(user)
+	suite.True(locked)
+	suite.WithinDuration(time.Now().Add(4*time.Minute), thirdLockedUntil, 10*time.Second)
+
+	// Only the first lock
+	// should have emailed.
+	suite.Empty(suite.sentEmails)
+
+	user, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(7, user.FailedSignInCount)
+}
+
+func (suite *SignInTestSuite) TestSignInLockoutReset() {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Store a lock that
+	// expired long ago.
+	user.FailedSignInCount = 7
+	user.SignInLockedUntil = time.Now().Add(-48 * time.Hour)
+	if err := suite.db.UpdateUser(ctx, user, "failed_sign_in_count", "sign_in_locked_until"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Next failure should start
+	// counting again from scratch.
+	if err := suite.user.SignInFailed(ctx, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+	_, locked := suite.user.SignInLocked(user)
+	suite.False(locked)
+
+	user, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, user.FailedSignInCount)
+	suite.Zero(user.SignInLockedUntil)
+}
+
+func (suite *SignInTestSuite) TestSignInIPRetention() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	config.SetAccountsIPRetention(config.AccountsIPRetentionTruncated)
+	if err := suite.user.SignInSucceeded(ctx, user, net.ParseIP("192.0.2.123")); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	config.SetAccountsIPRetention(config.AccountsIPRetentionNone)
+	if err := suite.user.SignInSucceeded(ctx, user, net.ParseIP("198.51.100.1")); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Previous sign in should have been
+	// truncated, latest not stored at all.
+	suite.Equal("192.0.2.0", user.LastSignInIP.String())
+	suite.Empty(user.CurrentSignInIP)
+}
+
+func TestSignInTestSuite(t *testing.T) {
+	suite.Run(t, new(SignInTestSuite))
+}
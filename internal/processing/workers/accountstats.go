@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// incrementStatusesCount updates the account stats
+// of the author of the given newly created status.
+func incrementStatusesCount(
+	ctx context.Context,
+	state *state.State,
+	status *gtsmodel.Status,
+) {
+	if err := state.DB.UpdateAccountStatsStatuses(ctx,
+		status.AccountID,
+		1,
+		status.CreatedAt,
+	); err != nil {
+		log.Errorf(ctx, "db error updating account stats: %v", err)
+	}
+}
+
+// decrementStatusesCount updates the account
+// stats of the author of the given deleted status.
+func decrementStatusesCount(
+	ctx context.Context,
+	state *state.State,
+	status *gtsmodel.Status,
+) {
+	if err := state.DB.UpdateAccountStatsStatuses(ctx,
+		status.AccountID,
+		-1,
+		time.Time{},
+	); err != nil {
+		log.Errorf(ctx, "db error updating account stats: %v", err)
+	}
+}
+
+// updateFollowCounts updates the account stats of both the
+// origin and the target of the given follow by delta, i.e.
+// 1 for a newly created follow, and -1 for a removed follow.
+func updateFollowCounts(
+	ctx context.Context,
+	state *state.State,
+	follow *gtsmodel.Follow,
+	delta int,
+) {
+	// Origin's following count changes.
+	if err := state.DB.UpdateAccountStatsFollows(ctx,
+		follow.AccountID,
+		0,
+		delta,
+	); err != nil {
+		log.Errorf(ctx, "db error updating account stats: %v", err)
+	}
+
+	// Target's followers count changes.
+	if err := state.DB.UpdateAccountStatsFollows(ctx,
+		follow.TargetAccountID,
+		delta,
+		0,
+	); err != nil {
+		log.Errorf(ctx, "db error updating account stats: %v", err)
+	}
+}
+
+// regenerateAccountStats regenerates the account stats of
+// the given accounts from scratch. This is used where any
+// number of follows between accounts may have been removed.
+func regenerateAccountStats(
+	ctx context.Context,
+	state *state.State,
+	accountIDs ...string,
+) {
+	for _, accountID := range accountIDs {
+		if _, err := state.DB.RegenerateAccountStats(ctx, accountID); err != nil {
+			log.Errorf(ctx, "db error regenerating account stats: %v", err)
+		}
+	}
+}
@@ -19,15 +19,24 @@ package workers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/url"
+	"sync"
+	"time"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // federate wraps functions for federating
@@ -39,6 +48,13 @@ type federate struct {
 	*federation.Federator
 	state     *state.State
 	converter *typeutils.Converter
+
+	// Accounts with an open account update
+	// debounce window, mapped to whether a
+	// further update is pending for the end
+	// of that window. See ScheduleUpdateAccount.
+	accountUpdates   map[string]bool
+	accountUpdatesMu sync.Mutex
 }
 
 // parseURI is a cheeky little
@@ -131,6 +147,93 @@ func (f *federate) DeleteAccount(ctx context.Context, account *gtsmodel.Account)
 		)
 	}
 
+	// Followers aside, other instances may still
+	// hold a copy of this account's profile, so
+	// deliver the (now ID'd) Delete to them too.
+	if err := f.deliverToKnownInboxes(ctx, account, delete); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// deliverToKnownInboxes delivers the given activity from account
+// to every known remote inbox, skipping the inboxes of account's
+// followers, which should already have been reached via outbox.
+func (f *federate) deliverToKnownInboxes(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	activity vocab.Type,
+) error {
+	inboxURIs, err := f.state.DB.GetInstanceInboxURIs(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting known inboxes: %w", err)
+	}
+
+	if len(inboxURIs) == 0 {
+		// Nobody to tell.
+		return nil
+	}
+
+	followers, err := f.state.DB.GetAccountFollowers(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting followers: %w", err)
+	}
+
+	// Gather inboxes that have
+	// already been delivered to.
+	delivered := make(map[string]struct{}, len(followers))
+	for _, follow := range followers {
+		if follow.Account == nil || follow.Account.IsLocal() {
+			continue
+		}
+
+		inboxURI := follow.Account.InboxURI
+		if sharedInboxURI := util.PtrValueOr(follow.Account.SharedInboxURI, ""); sharedInboxURI != "" {
+			inboxURI = sharedInboxURI
+		}
+
+		delivered[inboxURI] = struct{}{}
+	}
+
+	recipients := make([]*url.URL, 0, len(inboxURIs))
+	for _, inboxURI := range inboxURIs {
+		if _, ok := delivered[inboxURI]; ok {
+			continue
+		}
+
+		recipient, err := url.Parse(inboxURI)
+		if err != nil {
+			log.Warnf(ctx, "skipping invalid inbox uri %s: %v", inboxURI, err)
+			continue
+		}
+
+		recipients = append(recipients, recipient)
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	data, err := ap.Serialize(activity)
+	if err != nil {
+		return gtserror.Newf("error serializing %T: %w", activity, err)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return gtserror.Newf("error marshaling %T: %w", activity, err)
+	}
+
+	tsport, err := f.TransportController().NewTransportForUsername(ctx, account.Username)
+	if err != nil {
+		return gtserror.Newf("error creating transport: %w", err)
+	}
+
+	if err := tsport.BatchDeliver(ctx, b, recipients); err != nil {
+		return gtserror.Newf("error delivering %T to known inboxes: %w", activity, err)
+	}
+
 	return nil
 }
 
@@ -290,6 +393,133 @@ func (f *federate) UpdateStatus(ctx context.Context, status *gtsmodel.Status) er
 	return nil
 }
 
+// pollUpdateThrottle is the minimum period between
+// federating updated vote counts of one local poll.
+const pollUpdateThrottle = time.Minute
+
+// pollUpdateTaskID returns the scheduler task
+// ID used for throttled updates of poll with ID.
+func pollUpdateTaskID(pollID string) string {
+	return "poll-update-" + pollID
+}
+
+// ScheduleUpdatePoll schedules federating the latest vote counts
+// of the given local poll, throttled such that each poll is sent
+// out at most once per pollUpdateThrottle. Further calls while an
+// update is already scheduled are no-ops, as the scheduled update
+// will fetch the latest vote counts from the database when it runs.
+func (f *federate) ScheduleUpdatePoll(poll *gtsmodel.Poll) {
+	var (
+		pollID = poll.ID
+		taskID = pollUpdateTaskID(pollID)
+	)
+
+	_ = f.state.Workers.Scheduler.AddOnce(
+		taskID,
+		time.Now().Add(pollUpdateThrottle),
+		func(ctx context.Context, _ time.Time) {
+			// Remove this task from the scheduler,
+			// so that later votes may schedule again.
+			_ = f.state.Workers.Scheduler.Cancel(taskID)
+
+			// Get the latest version of poll from database.
+			poll, err := f.state.DB.GetPollByID(ctx, pollID)
+			if err != nil {
+				log.Errorf(ctx, "error getting poll %s from db: %v", pollID, err)
+				return
+			}
+
+			// Extract status and
+			// set its Poll field.
+			status := poll.Status
+			status.Poll = poll
+
+			if err := f.UpdatePoll(ctx, status); err != nil {
+				log.Errorf(ctx, "error federating poll %s update: %v", pollID, err)
+			}
+		},
+	)
+}
+
+// UpdatePoll federates an Update(Question) for the given local status
+// with poll, to the status audience as well as to any remote voters
+// and boosters (as hidden recipients), so that they see latest counts.
+func (f *federate) UpdatePoll(ctx context.Context, status *gtsmodel.Status) error {
+	// Do nothing if the status
+	// shouldn't be federated.
+	if !*status.Federated {
+		return nil
+	}
+
+	// Do nothing if this
+	// isn't our status.
+	if !*status.Local {
+		return nil
+	}
+
+	// Ensure the status model is fully populated.
+	if err := f.state.DB.PopulateStatus(ctx, status); err != nil {
+		return gtserror.Newf("error populating status: %w", err)
+	}
+
+	// Parse the outbox URI of the status author.
+	outboxIRI, err := parseURI(status.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	// Convert status to ActivityStreams Statusable implementing type.
+	statusable, err := f.converter.StatusToAS(ctx, status)
+	if err != nil {
+		return gtserror.Newf("error converting status to Statusable: %w", err)
+	}
+
+	// Wrap the Statusable in an Update.
+	update := typeutils.WrapStatusableInUpdate(statusable, false)
+
+	// Gather remote voters and boosters of the poll,
+	// who won't necessarily be part of its audience.
+	votes, err := f.state.DB.GetPollVotes(ctx, status.PollID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting poll votes: %w", err)
+	}
+
+	boosts, err := f.state.DB.GetStatusBoosts(ctx, status.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting status boosts: %w", err)
+	}
+
+	recipients := make(map[string]struct{}, len(votes)+len(boosts))
+	for _, vote := range votes {
+		if vote.Account != nil && vote.Account.IsRemote() {
+			recipients[vote.Account.URI] = struct{}{}
+		}
+	}
+	for _, boost := range boosts {
+		if boost.Account != nil && boost.Account.IsRemote() {
+			recipients[boost.Account.URI] = struct{}{}
+		}
+	}
+
+	// Add these as hidden recipients, which
+	// are stripped from the Update on delivery.
+	for uriStr := range recipients {
+		uri, err := parseURI(uriStr)
+		if err != nil {
+			log.Warnf(ctx, "skipping poll update recipient: %v", err)
+			continue
+		}
+		ap.AppendBcc(update, uri)
+	}
+
+	// Send the Update activity via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(ctx, outboxIRI, update); err != nil {
+		return gtserror.Newf("error sending Update activity via outbox %s: %w", outboxIRI, err)
+	}
+
+	return nil
+}
+
 func (f *federate) Follow(ctx context.Context, follow *gtsmodel.Follow) error {
 	// Populate model.
 	if err := f.state.DB.PopulateFollow(ctx, follow); err != nil {
@@ -403,6 +633,12 @@ func (f *federate) UndoLike(ctx context.Context, fave *gtsmodel.StatusFave) erro
 		return nil
 	}
 
+	// Do nothing if the faved
+	// status shouldn't be federated.
+	if fave.Status.IsLocalOnly() {
+		return nil
+	}
+
 	// Parse relevant URI(s).
 	outboxIRI, err := parseURI(fave.Account.OutboxURI)
 	if err != nil {
@@ -467,6 +703,12 @@ func (f *federate) UndoAnnounce(ctx context.Context, boost *gtsmodel.Status) err
 		return nil
 	}
 
+	// Do nothing if the boost
+	// shouldn't be federated.
+	if boost.IsLocalOnly() {
+		return nil
+	}
+
 	// Parse relevant URI(s).
 	outboxIRI, err := parseURI(boost.Account.OutboxURI)
 	if err != nil {
@@ -688,6 +930,12 @@ func (f *federate) Like(ctx context.Context, fave *gtsmodel.StatusFave) error {
 		return nil
 	}
 
+	// Do nothing if the faved
+	// status shouldn't be federated.
+	if fave.Status.IsLocalOnly() {
+		return nil
+	}
+
 	// Parse relevant URI(s).
 	outboxIRI, err := parseURI(fave.Account.OutboxURI)
 	if err != nil {
@@ -725,6 +973,12 @@ func (f *federate) Announce(ctx context.Context, boost *gtsmodel.Status) error {
 		return nil
 	}
 
+	// Do nothing if the boost
+	// shouldn't be federated.
+	if boost.IsLocalOnly() {
+		return nil
+	}
+
 	// Parse relevant URI(s).
 	outboxIRI, err := parseURI(boost.Account.OutboxURI)
 	if err != nil {
@@ -755,6 +1009,85 @@ func (f *federate) Announce(ctx context.Context, boost *gtsmodel.Status) error {
 	return nil
 }
 
+// accountUpdateDebounce is the minimum period
+// between federating profile updates of one local
+// account, so rapid successive edits collapse.
+const accountUpdateDebounce = time.Minute
+
+// accountUpdateTaskID returns the scheduler task ID used
+// for debounce windows of updates of account with ID.
+func accountUpdateTaskID(accountID string) string {
+	return "account-update-" + accountID
+}
+
+// ScheduleUpdateAccount federates an Update of the given local account,
+// debounced such that each account is sent out at most once per
+// accountUpdateDebounce. If no update was sent recently, the update is
+// sent immediately. Otherwise, it is collapsed with any other updates
+// in the current window into one update sent at the end of the window,
+// which will fetch the latest version of the account from the database.
+func (f *federate) ScheduleUpdateAccount(ctx context.Context, account *gtsmodel.Account) {
+	f.accountUpdatesMu.Lock()
+	if _, open := f.accountUpdates[account.ID]; open {
+		// Window already open,
+		// send at the end of it.
+		f.accountUpdates[account.ID] = true
+		f.accountUpdatesMu.Unlock()
+		return
+	}
+	f.accountUpdates[account.ID] = false
+	f.accountUpdatesMu.Unlock()
+
+	// Open a new window and send now.
+	f.openAccountUpdateWindow(account.ID)
+	if err := f.UpdateAccount(ctx, account); err != nil {
+		log.Errorf(ctx, "error federating account %s update: %v", account.ID, err)
+	}
+}
+
+// openAccountUpdateWindow schedules the end of an account update
+// debounce window for account with ID, at which point any pending
+// update is sent, opening another window in turn.
+func (f *federate) openAccountUpdateWindow(accountID string) {
+	taskID := accountUpdateTaskID(accountID)
+
+	_ = f.state.Workers.Scheduler.AddOnce(
+		taskID,
+		time.Now().Add(accountUpdateDebounce),
+		func(ctx context.Context, _ time.Time) {
+			// Remove this task from the scheduler,
+			// so that another window may be opened.
+			_ = f.state.Workers.Scheduler.Cancel(taskID)
+
+			f.accountUpdatesMu.Lock()
+			if !f.accountUpdates[accountID] {
+				// Nothing pending,
+				// close the window.
+				delete(f.accountUpdates, accountID)
+				f.accountUpdatesMu.Unlock()
+				return
+			}
+			f.accountUpdates[accountID] = false
+			f.accountUpdatesMu.Unlock()
+
+			// Sending now, so open
+			// a new window for this.
+			f.openAccountUpdateWindow(accountID)
+
+			// Get the latest version of account from database.
+			account, err := f.state.DB.GetAccountByID(ctx, accountID)
+			if err != nil {
+				log.Errorf(ctx, "error getting account %s from db: %v", accountID, err)
+				return
+			}
+
+			if err := f.UpdateAccount(ctx, account); err != nil {
+				log.Errorf(ctx, "error federating account %s update: %v", accountID, err)
+			}
+		},
+	)
+}
+
 func (f *federate) UpdateAccount(ctx context.Context, account *gtsmodel.Account) error {
 	// Populate model.
 	if err := f.state.DB.PopulateAccount(ctx, account); err != nil {
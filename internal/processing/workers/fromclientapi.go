@@ -48,15 +48,28 @@ type clientAPI struct {
 }
 
 func (p *Processor) EnqueueClientAPI(cctx context.Context, msgs ...messages.FromClientAPI) {
+	// Journal messages first if enabled,
+	// so they survive a crash / restart.
+	ids := p.journalClientAPI(cctx, msgs)
+	p.enqueueClientAPI(cctx, ids, msgs)
+}
+
+// enqueueClientAPI enqueues the given messages for processing, removing
+// them from the journal once processed if journal IDs are given.
+func (p *Processor) enqueueClientAPI(cctx context.Context, ids []string, msgs []messages.FromClientAPI) {
 	_ = p.workers.ClientAPI.MustEnqueueCtx(cctx, func(wctx context.Context) {
 		// Copy caller ctx values to worker's.
 		wctx = gtscontext.WithValues(wctx, cctx)
 
 		// Process worker messages.
-		for _, msg := range msgs {
+		for i, msg := range msgs {
 			if err := p.ProcessFromClientAPI(wctx, msg); err != nil {
 				log.Errorf(wctx, "error processing client API message: %v", err)
 			}
+
+			if ids != nil {
+				p.journalDone(wctx, ids[i])
+			}
 		}
 	})
 }
@@ -228,6 +241,15 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg messages.FromClientAP
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Make status searchable by its author.
+	indexStatus(ctx, p.state, status.Account, status)
+
+	// Count tag uses for trends.
+	trackTagUses(ctx, p.state, status)
+
+	// Update author's statuses count.
+	incrementStatusesCount(ctx, p.state, status)
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status;
 		// uncache the prepared version from all timelines.
@@ -268,10 +290,8 @@ func (p *clientAPI) CreatePollVote(ctx context.Context, cMsg messages.FromClient
 
 	if *status.Local {
 		// These are poll votes in a local status, we only need to
-		// federate the updated status model with latest vote counts.
-		if err := p.federate.UpdateStatus(ctx, status); err != nil {
-			log.Errorf(ctx, "error federating status update: %v", err)
-		}
+		// federate the updated poll with latest vote counts.
+		p.federate.ScheduleUpdatePoll(status.Poll)
 	} else {
 		// These are votes in a remote poll, federate to origin the new poll vote(s).
 		if err := p.federate.CreatePollVote(ctx, vote.Poll, vote); err != nil {
@@ -320,6 +340,9 @@ func (p *clientAPI) CreateLike(ctx context.Context, cMsg messages.FromClientAPI)
 		log.Errorf(ctx, "error notifying fave: %v", err)
 	}
 
+	// Make faved status searchable by faver.
+	indexStatus(ctx, p.state, fave.Account, fave.Status)
+
 	// Interaction counts changed on the faved status;
 	// uncache the prepared version from all timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, fave.StatusID)
@@ -347,6 +370,9 @@ func (p *clientAPI) CreateAnnounce(ctx context.Context, cMsg messages.FromClient
 		log.Errorf(ctx, "error notifying boost: %v", err)
 	}
 
+	// Update booster's statuses count.
+	incrementStatusesCount(ctx, p.state, boost)
+
 	// Interaction counts changed on the boosted status;
 	// uncache the prepared version from all timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, boost.BoostOfID)
@@ -364,27 +390,23 @@ func (p *clientAPI) CreateBlock(ctx context.Context, cMsg messages.FromClientAPI
 		return gtserror.Newf("%T not parseable as *gtsmodel.Block", cMsg.GTSModel)
 	}
 
-	// Remove blockee's statuses from blocker's timeline.
-	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
-		ctx,
-		block.AccountID,
-		block.TargetAccountID,
-	); err != nil {
-		return gtserror.Newf("error wiping timeline items for block: %w", err)
-	}
+	// Remove each account's statuses from the other's timelines.
+	p.surface.removeAccountFromTimelines(ctx, block.AccountID, block.TargetAccountID)
+	p.surface.removeAccountFromTimelines(ctx, block.TargetAccountID, block.AccountID)
 
-	// Remove blocker's statuses from blockee's timeline.
-	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
-		ctx,
-		block.TargetAccountID,
-		block.AccountID,
-	); err != nil {
-		return gtserror.Newf("error wiping timeline items for block: %w", err)
-	}
+	// Remove each account's notifications from the other.
+	p.surface.removeNotificationsFrom(ctx, block.AccountID, block.TargetAccountID)
+	p.surface.removeNotificationsFrom(ctx, block.TargetAccountID, block.AccountID)
 
-	// TODO: same with notifications?
 	// TODO: same with bookmarks?
 
+	// Follows between the accounts were
+	// removed, so regenerate their stats.
+	regenerateAccountStats(ctx, p.state,
+		block.AccountID,
+		block.TargetAccountID,
+	)
+
 	if err := p.federate.Block(ctx, block); err != nil {
 		log.Errorf(ctx, "error federating block: %v", err)
 	}
@@ -407,6 +429,9 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg messages.FromClientAP
 	// Status representation has changed, invalidate from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
+	// Status text may have changed, update search index.
+	reindexStatus(ctx, p.state, status)
+
 	if status.Poll != nil && status.Poll.Closing {
 
 		// If the latest status has a newly closed poll, at least compared
@@ -421,6 +446,11 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg messages.FromClientAP
 		log.Errorf(ctx, "error streaming status edit: %v", err)
 	}
 
+	// Let those who boosted or faved the status know.
+	if err := p.surface.notifyStatusUpdate(ctx, status); err != nil {
+		log.Errorf(ctx, "error notifying status edit: %v", err)
+	}
+
 	return nil
 }
 
@@ -430,9 +460,9 @@ func (p *clientAPI) UpdateAccount(ctx context.Context, cMsg messages.FromClientA
 		return gtserror.Newf("cannot cast %T -> *gtsmodel.Account", cMsg.GTSModel)
 	}
 
-	if err := p.federate.UpdateAccount(ctx, account); err != nil {
-		log.Errorf(ctx, "error federating account update: %v", err)
-	}
+	// Federate the profile changes out remotely,
+	// collapsing rapid successive edits into one.
+	p.federate.ScheduleUpdateAccount(ctx, account)
 
 	return nil
 }
@@ -462,6 +492,9 @@ func (p *clientAPI) AcceptFollow(ctx context.Context, cMsg messages.FromClientAP
 		return gtserror.Newf("%T not parseable as *gtsmodel.Follow", cMsg.GTSModel)
 	}
 
+	// Update follow counts.
+	updateFollowCounts(ctx, p.state, follow, 1)
+
 	if err := p.surface.notifyFollow(ctx, follow); err != nil {
 		log.Errorf(ctx, "error notifying follow: %v", err)
 	}
@@ -495,6 +528,13 @@ func (p *clientAPI) UndoFollow(ctx context.Context, cMsg messages.FromClientAPI)
 		return gtserror.Newf("%T not parseable as *gtsmodel.Follow", cMsg.GTSModel)
 	}
 
+	// Update follow counts.
+	updateFollowCounts(ctx, p.state, follow, -1)
+
+	// Target's statuses (in particular followers-only
+	// ones) may no longer be visible to the unfollower.
+	p.surface.removeAccountFromTimelines(ctx, follow.AccountID, follow.TargetAccountID)
+
 	if err := p.federate.UndoFollow(ctx, follow); err != nil {
 		log.Errorf(ctx, "error federating follow undo: %v", err)
 	}
@@ -525,6 +565,9 @@ func (p *clientAPI) UndoFave(ctx context.Context, cMsg messages.FromClientAPI) e
 	// uncache the prepared version from all timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, statusFave.StatusID)
 
+	// Unfaved status may no longer be searchable by faver.
+	unindexFave(ctx, p.state, statusFave)
+
 	if err := p.federate.UndoLike(ctx, statusFave); err != nil {
 		log.Errorf(ctx, "error federating like undo: %v", err)
 	}
@@ -542,6 +585,9 @@ func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg messages.FromClientAP
 		return gtserror.Newf("db error deleting status: %w", err)
 	}
 
+	// Update booster's statuses count.
+	decrementStatusesCount(ctx, p.state, status)
+
 	if err := p.surface.deleteStatusFromTimelines(ctx, status.ID); err != nil {
 		log.Errorf(ctx, "error removing timelined status: %v", err)
 	}
@@ -580,6 +626,9 @@ func (p *clientAPI) DeleteStatus(ctx context.Context, cMsg messages.FromClientAP
 		log.Errorf(ctx, "error wiping status: %v", err)
 	}
 
+	// Update author's statuses count.
+	decrementStatusesCount(ctx, p.state, status)
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status;
 		// uncache the prepared version from all timelines.
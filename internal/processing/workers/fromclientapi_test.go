@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -750,6 +751,62 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusBoostNoReblogs() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusDirectConversation() {
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		streams          = suite.openStreams(ctx, receivingAccount, nil)
+		directStream     = streams[stream.TimelineDirect]
+
+		// Admin account sends a direct
+		// reply to receiving account.
+		status = suite.newStatus(
+			ctx,
+			postingAccount,
+			gtsmodel.VisibilityDirect,
+			suite.testStatuses["local_account_1_status_1"],
+			nil,
+		)
+	)
+
+	// Process the new status.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			OriginAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Conversation should be streamed to receiver.
+	ctx, cncl := context.WithTimeout(ctx, time.Second*5)
+	defer cncl()
+
+	msg, ok := directStream.Recv(ctx)
+	if !ok {
+		suite.FailNow("expected a message but message was not received")
+	}
+	suite.Equal(stream.EventTypeConversation, msg.Event)
+	suite.Equal([]string{stream.TimelineDirect}, msg.Stream)
+
+	conversation := new(apimodel.Conversation)
+	if err := json.Unmarshal([]byte(msg.Payload), conversation); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(status.ThreadID, conversation.ID)
+	suite.True(conversation.Unread)
+	suite.Equal(status.ID, conversation.LastStatus.ID)
+	if suite.Len(conversation.Accounts, 1) {
+		suite.Equal(postingAccount.ID, conversation.Accounts[0].ID)
+	}
+}
+
 func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	var (
 		ctx                  = context.Background()
@@ -807,6 +864,273 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}
 }
 
+func (suite *FromClientAPITestSuite) TestProcessUndoFollowRemovesFromTimeline() {
+	var (
+		ctx             = context.Background()
+		postingAccount  = suite.testAccounts["local_account_2"]
+		unfollower      = suite.testAccounts["local_account_1"]
+		follow          = suite.testFollows["local_account_1_local_account_2"]
+		timelineHasPost = func(statusID string) bool {
+			items, err := suite.state.Timelines.Home.GetTimeline(ctx, unfollower.ID, "", "", "", 20, false)
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			for _, item := range items {
+				if item.GetID() == statusID {
+					return true
+				}
+			}
+
+			return false
+		}
+	)
+
+	// Post a followers-only status, and
+	// process it into followers' timelines.
+	status := suite.newStatus(ctx, postingAccount, gtsmodel.VisibilityFollowersOnly, nil, nil)
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			OriginAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Follower should see the status.
+	suite.True(timelineHasPost(status.ID))
+
+	// Remove the follow from the db first, to mimic
+	// what would have already happened earlier up the flow.
+	if err := suite.db.DeleteFollowByID(ctx, follow.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the unfollow.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityUndo,
+			GTSModel:       follow,
+			OriginAccount:  unfollower,
+			TargetAccount:  postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status should now be gone from
+	// the unfollower's home timeline.
+	suite.False(timelineHasPost(status.ID))
+}
+
+func (suite *FromClientAPITestSuite) TestProcessBlockRemovesNotifications() {
+	var (
+		ctx         = context.Background()
+		blocker     = suite.testAccounts["local_account_1"]
+		blocked     = suite.testAccounts["admin_account"]
+		notif       = testrig.NewTestNotifications()["local_account_1_like"]
+		streams     = suite.openStreams(ctx, blocker, nil)
+		notifStream = streams[stream.TimelineNotifications]
+		blockID     = id.NewULID()
+		block       = &gtsmodel.Block{
+			ID:              blockID,
+			URI:             blocker.URI + "/blocks/" + blockID,
+			AccountID:       blocker.ID,
+			Account:         blocker,
+			TargetAccountID: blocked.ID,
+			TargetAccount:   blocked,
+		}
+	)
+
+	// Notification from the soon-to-be
+	// blocked account should exist.
+	if _, err := suite.db.GetNotificationByID(ctx, notif.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the block.
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ActivityBlock,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       block,
+			OriginAccount:  blocker,
+			TargetAccount:  blocked,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Notification should now be gone.
+	_, err := suite.db.GetNotificationByID(ctx, notif.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Blocker's notifications stream
+	// should have been told to refresh.
+	suite.checkStreamed(
+		notifStream,
+		true,
+		"1",
+		stream.EventTypeNotificationsMerged,
+	)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessUpdateAccountDebounced() {
+	var (
+		ctx      = context.Background()
+		account  = suite.testAccounts["local_account_1"]
+		follower = suite.testAccounts["remote_account_1"]
+		followID = id.NewULID()
+		sent     = func() int {
+			var count int
+			for _, inbox := range []string{
+				follower.InboxURI,
+				*follower.SharedInboxURI,
+			} {
+				if msgs, ok := suite.httpClient.SentMessages.Load(inbox); ok {
+					count += len(msgs.([][]byte))
+				}
+			}
+			return count
+		}
+	)
+
+	// Have a remote account follow the
+	// local account so it gets updates.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              followID,
+		URI:             follower.URI + "/follow/" + followID,
+		AccountID:       follower.ID,
+		TargetAccountID: account.ID,
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Edit the profile a few times
+	// in quick succession.
+	for _, displayName := range []string{"one", "two", "three"} {
+		account.DisplayName = displayName
+		if err := suite.db.UpdateAccount(ctx, account, "display_name"); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if err := suite.processor.Workers().ProcessFromClientAPI(
+			ctx,
+			messages.FromClientAPI{
+				APObjectType:   ap.ObjectProfile,
+				APActivityType: ap.ActivityUpdate,
+				GTSModel:       account,
+				OriginAccount:  account,
+			},
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// The first edit should be delivered straight away.
+	if !testrig.WaitFor(func() bool { return sent() > 0 }) {
+		suite.FailNow("timed out waiting for account update delivery")
+	}
+
+	// Give any other deliveries a chance to go out;
+	// the later edits should be held until the end
+	// of the debounce window, so there's only one.
+	time.Sleep(time.Second)
+	suite.Equal(1, sent())
+}
+
+func (suite *FromClientAPITestSuite) TestProcessUpdateStatusNotifiesInteracted() {
+	var (
+		ctx    = context.Background()
+		author = suite.testAccounts["local_account_1"]
+		faver  = suite.testAccounts["admin_account"]
+		status = new(gtsmodel.Status)
+	)
+
+	// Edit a status that admin has
+	// both faved and boosted.
+	*status = *suite.testStatuses["local_account_1_status_1"]
+	status.Content = "hello everyone! (edited)"
+	status.Sensitive = util.Ptr(true)
+	if err := suite.db.UpdateStatus(ctx, status, "content", "sensitive"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       status,
+			OriginAccount:  author,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Admin should have been
+	// notified of the edit.
+	notif, err := suite.db.GetNotification(ctx,
+		gtsmodel.NotificationUpdate,
+		faver.ID,
+		author.ID,
+		status.ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*notif.Filtered)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessUpdateStatusNotificationsSuppressed() {
+	var (
+		ctx    = context.Background()
+		author = suite.testAccounts["local_account_1"]
+		faver  = new(gtsmodel.Account)
+		status = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	// Admin doesn't want to hear about edits.
+	*faver = *suite.testAccounts["admin_account"]
+	faver.SuppressStatusUpdateNotifications = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, faver, "suppress_status_update_notifications"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.Workers().ProcessFromClientAPI(
+		ctx,
+		messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       status,
+			OriginAccount:  author,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetNotification(ctx,
+		gtsmodel.NotificationUpdate,
+		faver.ID,
+		author.ID,
+		status.ID,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
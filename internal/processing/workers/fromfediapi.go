@@ -19,10 +19,14 @@ package workers
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -47,15 +51,28 @@ type fediAPI struct {
 }
 
 func (p *Processor) EnqueueFediAPI(cctx context.Context, msgs ...messages.FromFediAPI) {
+	// Journal messages first if enabled,
+	// so they survive a crash / restart.
+	ids := p.journalFediAPI(cctx, msgs)
+	p.enqueueFediAPI(cctx, ids, msgs)
+}
+
+// enqueueFediAPI enqueues the given messages for processing, removing
+// them from the journal once processed if journal IDs are given.
+func (p *Processor) enqueueFediAPI(cctx context.Context, ids []string, msgs []messages.FromFediAPI) {
 	_ = p.workers.Federator.MustEnqueueCtx(cctx, func(wctx context.Context) {
 		// Copy caller ctx values to worker's.
 		wctx = gtscontext.WithValues(wctx, cctx)
 
 		// Process worker messages.
-		for _, msg := range msgs {
+		for i, msg := range msgs {
 			if err := p.ProcessFromFediAPI(wctx, msg); err != nil {
 				log.Errorf(wctx, "error processing fedi API message: %v", err)
 			}
+
+			if ids != nil {
+				p.journalDone(wctx, ids[i])
+			}
 		}
 	})
 }
@@ -107,6 +124,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg messages.FromFe
 		case ap.ActivityAnnounce:
 			return p.fediAPI.CreateAnnounce(ctx, fMsg)
 
+		// CREATE EMOJIREACT/REACTION
+		case ap.ActivityEmojiReact:
+			return p.fediAPI.CreateEmojiReact(ctx, fMsg)
+
 		// CREATE BLOCK
 		case ap.ActivityBlock:
 			return p.fediAPI.CreateBlock(ctx, fMsg)
@@ -120,6 +141,15 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg messages.FromFe
 			return p.fediAPI.CreatePollVote(ctx, fMsg)
 		}
 
+	// ACCEPT SOMETHING
+	case ap.ActivityAccept:
+		switch fMsg.APObjectType { //nolint:gocritic
+
+		// ACCEPT FOLLOW
+		case ap.ActivityFollow:
+			return p.fediAPI.AcceptFollow(ctx, fMsg)
+		}
+
 	// UPDATE SOMETHING
 	case ap.ActivityUpdate:
 		switch fMsg.APObjectType { //nolint:gocritic
@@ -131,6 +161,19 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg messages.FromFe
 		// UPDATE PROFILE/ACCOUNT
 		case ap.ObjectProfile:
 			return p.fediAPI.UpdateAccount(ctx, fMsg)
+
+		// UPDATE QUESTION
+		case ap.ActivityQuestion:
+			return p.fediAPI.UpdatePoll(ctx, fMsg)
+		}
+
+	// UNDO SOMETHING
+	case ap.ActivityUndo:
+		switch fMsg.APObjectType { //nolint:gocritic
+
+		// UNDO FOLLOW
+		case ap.ActivityFollow:
+			return p.fediAPI.UndoFollow(ctx, fMsg)
 		}
 
 	// DELETE SOMETHING
@@ -222,6 +265,12 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg messages.FromFediAPI) e
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Count tag uses for trends.
+	trackTagUses(ctx, p.state, status)
+
+	// Update author's statuses count.
+	incrementStatusesCount(ctx, p.state, status)
+
 	return nil
 }
 
@@ -233,7 +282,18 @@ func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg messages.FromFediAPI)
 	}
 
 	// Insert the new poll vote in the database.
-	if err := p.state.DB.PutPollVote(ctx, vote); err != nil {
+	switch err := p.state.DB.PutPollVote(ctx, vote); {
+	case err == nil:
+		// no issue.
+
+	case errors.Is(err, db.ErrAlreadyExists):
+		// Account already voted in this poll, likely
+		// a retried delivery of the same vote that
+		// raced the check in the federating db.
+		log.Debugf(ctx, "%s already voted in poll %s", vote.AccountID, vote.PollID)
+		return nil
+
+	default:
 		return gtserror.Newf("error inserting poll vote in db: %w", err)
 	}
 
@@ -256,15 +316,9 @@ func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg messages.FromFediAPI)
 	p.surface.invalidateStatusFromTimelines(ctx, vote.Poll.StatusID)
 
 	if *status.Local {
-		// Before federating it, increment the
-		// poll vote counts on our local copy.
-		status.Poll.IncrementVotes(vote.Choices)
-
 		// These were poll votes in a local status, we need to
-		// federate the updated status model with latest vote counts.
-		if err := p.federate.UpdateStatus(ctx, status); err != nil {
-			log.Errorf(ctx, "error federating status update: %v", err)
-		}
+		// federate the updated poll with latest vote counts.
+		p.federate.ScheduleUpdatePoll(status.Poll)
 	}
 
 	return nil
@@ -281,14 +335,24 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg messages.FromFediAPI
 	}
 
 	if *followRequest.TargetAccount.Locked {
-		// Account on our instance is locked: just notify the follow request.
-		if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
-			log.Errorf(ctx, "error notifying follow request: %v", err)
+		// Account on our instance is locked: check whether
+		// the request matches any of its auto-accept rules.
+		autoAccept, err := p.account.FollowRequestAutoAccept(ctx, followRequest)
+		if err != nil {
+			log.Errorf(ctx, "error checking follow request auto-accept rules: %v", err)
+		}
+
+		if !autoAccept {
+			// No rule matched: just notify the follow request.
+			if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
+				log.Errorf(ctx, "error notifying follow request: %v", err)
+			}
+			return nil
 		}
-		return nil
 	}
 
-	// Account on our instance is not locked:
+	// Account on our instance is not locked, or
+	// the request matched an auto-accept rule:
 	// Automatically accept the follow request
 	// and notify about the new follower.
 	follow, err := p.state.DB.AcceptFollowRequest(
@@ -300,6 +364,9 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg messages.FromFediAPI
 		return gtserror.Newf("error accepting follow request: %w", err)
 	}
 
+	// Update follow counts.
+	updateFollowCounts(ctx, p.state, follow, 1)
+
 	if err := p.federate.AcceptFollow(ctx, follow); err != nil {
 		log.Errorf(ctx, "error federating follow request accept: %v", err)
 	}
@@ -333,6 +400,62 @@ func (p *fediAPI) CreateLike(ctx context.Context, fMsg messages.FromFediAPI) err
 	return nil
 }
 
+func (p *fediAPI) CreateEmojiReact(ctx context.Context, fMsg messages.FromFediAPI) error {
+	reaction, ok := fMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", fMsg.GTSModel)
+	}
+
+	if reaction.Emoji != nil && reaction.Emoji.ID == "" {
+		var (
+			emoji *gtsmodel.Emoji
+			err   error
+		)
+
+		if config.IsLocalHost(reaction.Emoji.Domain) {
+			// One of our own emojis,
+			// these are stored domainless.
+			emoji, err = p.state.DB.GetEmojiByShortcodeDomain(ctx,
+				reaction.Emoji.Shortcode,
+				"",
+			)
+		} else {
+			// Remote custom emoji, fetch
+			// it (or load if stored).
+			emoji, err = p.federate.GetEmoji(ctx,
+				reaction.Emoji,
+				fMsg.ReceivingAccount.Username,
+			)
+		}
+
+		if err != nil {
+			return gtserror.Newf("error getting reaction emoji: %w", err)
+		}
+
+		reaction.Emoji = emoji
+		reaction.EmojiID = emoji.ID
+	}
+
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Already handled this
+			// reaction, nothing to do.
+			return nil
+		}
+		return gtserror.Newf("error inserting reaction: %w", err)
+	}
+
+	if err := p.surface.notifyReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying reaction: %v", err)
+	}
+
+	// Reactions changed on the reacted-to status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, reaction.StatusID)
+
+	return nil
+}
+
 func (p *fediAPI) CreateAnnounce(ctx context.Context, fMsg messages.FromFediAPI) error {
 	boost, ok := fMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -369,6 +492,9 @@ func (p *fediAPI) CreateAnnounce(ctx context.Context, fMsg messages.FromFediAPI)
 		log.Errorf(ctx, "error notifying announce: %v", err)
 	}
 
+	// Update booster's statuses count.
+	incrementStatusesCount(ctx, p.state, boost)
+
 	// Interaction counts changed on the original status;
 	// uncache the prepared version from all timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, boost.BoostOfID)
@@ -383,40 +509,12 @@ func (p *fediAPI) CreateBlock(ctx context.Context, fMsg messages.FromFediAPI) er
 	}
 
 	// Remove each account's posts from the other's timelines.
-	//
-	// First home timelines.
-	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
-		ctx,
-		block.AccountID,
-		block.TargetAccountID,
-	); err != nil {
-		log.Errorf(ctx, "error wiping items from block -> target's home timeline: %v", err)
-	}
-
-	if err := p.state.Timelines.Home.WipeItemsFromAccountID(
-		ctx,
-		block.TargetAccountID,
-		block.AccountID,
-	); err != nil {
-		log.Errorf(ctx, "error wiping items from target -> block's home timeline: %v", err)
-	}
-
-	// Now list timelines.
-	if err := p.state.Timelines.List.WipeItemsFromAccountID(
-		ctx,
-		block.AccountID,
-		block.TargetAccountID,
-	); err != nil {
-		log.Errorf(ctx, "error wiping items from block -> target's list timeline(s): %v", err)
-	}
+	p.surface.removeAccountFromTimelines(ctx, block.AccountID, block.TargetAccountID)
+	p.surface.removeAccountFromTimelines(ctx, block.TargetAccountID, block.AccountID)
 
-	if err := p.state.Timelines.List.WipeItemsFromAccountID(
-		ctx,
-		block.TargetAccountID,
-		block.AccountID,
-	); err != nil {
-		log.Errorf(ctx, "error wiping items from target -> block's list timeline(s): %v", err)
-	}
+	// Remove each account's notifications from the other.
+	p.surface.removeNotificationsFrom(ctx, block.AccountID, block.TargetAccountID)
+	p.surface.removeNotificationsFrom(ctx, block.TargetAccountID, block.AccountID)
 
 	// Remove any follows that existed between blocker + blockee.
 	if err := p.state.DB.DeleteFollow(
@@ -452,6 +550,37 @@ func (p *fediAPI) CreateBlock(ctx context.Context, fMsg messages.FromFediAPI) er
 		log.Errorf(ctx, "error deleting follow request from target -> block: %v", err)
 	}
 
+	// Follows between the accounts were
+	// removed, so regenerate their stats.
+	regenerateAccountStats(ctx, p.state,
+		block.AccountID,
+		block.TargetAccountID,
+	)
+
+	return nil
+}
+
+func (p *fediAPI) AcceptFollow(ctx context.Context, fMsg messages.FromFediAPI) error {
+	follow, ok := fMsg.GTSModel.(*gtsmodel.Follow)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Follow", fMsg.GTSModel)
+	}
+
+	// Update follow counts.
+	updateFollowCounts(ctx, p.state, follow, 1)
+
+	return nil
+}
+
+func (p *fediAPI) UndoFollow(ctx context.Context, fMsg messages.FromFediAPI) error {
+	follow, ok := fMsg.GTSModel.(*gtsmodel.Follow)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Follow", fMsg.GTSModel)
+	}
+
+	// Update follow counts.
+	updateFollowCounts(ctx, p.state, follow, -1)
+
 	return nil
 }
 
@@ -526,6 +655,9 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg messages.FromFediAPI) e
 	// Status representation was refetched, uncache from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
+	// Status text may have changed, update search index.
+	reindexStatus(ctx, p.state, status)
+
 	if status.Poll != nil && status.Poll.Closing {
 
 		// If the latest status has a newly closed poll, at least compared
@@ -540,6 +672,53 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg messages.FromFediAPI) e
 		log.Errorf(ctx, "error streaming status edit: %v", err)
 	}
 
+	if statusEdited(existing, status) {
+		// Let those who boosted or faved the status know.
+		if err := p.surface.notifyStatusUpdate(ctx, status); err != nil {
+			log.Errorf(ctx, "error notifying status edit: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *fediAPI) UpdatePoll(ctx context.Context, fMsg messages.FromFediAPI) error {
+	// Cast the existing Status model attached to msg.
+	existing, ok := fMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("cannot cast %T -> *gtsmodel.Status", fMsg.GTSModel)
+	}
+
+	// Cast the updated ActivityPub pollable object.
+	pollable, ok := fMsg.APObjectModel.(ap.Pollable)
+	if !ok {
+		return gtserror.Newf("cannot cast %T -> ap.Pollable", fMsg.APObjectModel)
+	}
+
+	// Update poll results from the latest Question.
+	pollOnly, err := p.federate.RefreshPoll(ctx, existing, pollable)
+	if err != nil {
+		return gtserror.Newf("error refreshing poll: %w", err)
+	}
+
+	if !pollOnly {
+		// More than the poll results changed,
+		// handle this as a regular status edit.
+		return p.UpdateStatus(ctx, fMsg)
+	}
+
+	// Poll counts changed on the status, uncache from timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, existing.ID)
+
+	if existing.Poll.Closing {
+
+		// If the latest status has a newly closed poll, at least compared
+		// to the existing version, then notify poll close to all voters.
+		if err := p.surface.notifyPollClose(ctx, existing); err != nil {
+			log.Errorf(ctx, "error sending poll notification: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -558,6 +737,9 @@ func (p *fediAPI) DeleteStatus(ctx context.Context, fMsg messages.FromFediAPI) e
 		log.Errorf(ctx, "error wiping status: %v", err)
 	}
 
+	// Update author's statuses count.
+	decrementStatusesCount(ctx, p.state, status)
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status;
 		// uncache the prepared version from all timelines.
@@ -573,9 +755,56 @@ func (p *fediAPI) DeleteAccount(ctx context.Context, fMsg messages.FromFediAPI)
 		return gtserror.Newf("%T not parseable as *gtsmodel.Account", fMsg.GTSModel)
 	}
 
+	// Gather the accounts on the other side of
+	// follows to / from the account, as these
+	// follows are removed along with the account.
+	var followAccountIDs []string
+
+	follows, err := p.state.DB.GetAccountFollows(gtscontext.SetBarebones(ctx), account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting account follows: %v", err)
+	}
+
+	for _, follow := range follows {
+		followAccountIDs = append(followAccountIDs, follow.TargetAccountID)
+	}
+
+	followers, err := p.state.DB.GetAccountFollowers(gtscontext.SetBarebones(ctx), account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting account followers: %v", err)
+	}
+
+	for _, follow := range followers {
+		followAccountIDs = append(followAccountIDs, follow.AccountID)
+	}
+
 	if err := p.account.Delete(ctx, account, account.ID); err != nil {
 		log.Errorf(ctx, "error deleting account: %v", err)
 	}
 
+	// Follows with the account were
+	// removed, so regenerate the stats
+	// of the accounts on the other side.
+	regenerateAccountStats(ctx, p.state,
+		util.Deduplicate(followAccountIDs)...,
+	)
+
 	return nil
 }
+
+// statusEdited returns whether the refreshed status
+// differs from its previous version in a way that
+// changes its meaning: its content, content warning,
+// sensitivity or attachments. Only the sensitive or
+// CW flags changing still counts, as it changes what
+// a boost of the status shows to others.
+func statusEdited(before, after *gtsmodel.Status) bool {
+	if before == nil || after == nil {
+		return false
+	}
+
+	return before.Content != after.Content ||
+		before.ContentWarning != after.ContentWarning ||
+		util.PtrValueOr(before.Sensitive, false) != util.PtrValueOr(after.Sensitive, false) ||
+		!slices.Equal(before.AttachmentIDs, after.AttachmentIDs)
+}
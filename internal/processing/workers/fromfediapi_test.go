@@ -207,6 +207,97 @@ func (suite *FromFediAPITestSuite) TestProcessFave() {
 	suite.EqualValues([]string{stream.TimelineNotifications}, msg.Stream)
 }
 
+func (suite *FromFediAPITestSuite) TestProcessFaveFiltered() {
+	ctx := context.Background()
+
+	favedStatus := suite.testStatuses["local_account_1_status_1"]
+	favingAccount := suite.testAccounts["remote_account_1"]
+
+	// Faved account filters notifications
+	// from accounts it doesn't follow.
+	favedAccount := new(gtsmodel.Account)
+	*favedAccount = *suite.testAccounts["local_account_1"]
+	favedAccount.NotificationsFilterNotFollowing = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, favedAccount, "notifications_filter_not_following"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	wssStream, errWithCode := suite.processor.Stream().Open(ctx, favedAccount, stream.TimelineNotifications)
+	suite.NoError(errWithCode)
+
+	fave := &gtsmodel.StatusFave{
+		ID:              "01FGKJPXFTVQPG9YSSZ95ADS7Q",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       favingAccount.ID,
+		Account:         favingAccount,
+		TargetAccountID: favedAccount.ID,
+		TargetAccount:   favedAccount,
+		StatusID:        favedStatus.ID,
+		Status:          favedStatus,
+		URI:             favingAccount.URI + "/faves/aaaaaaaaaaaa",
+	}
+
+	if err := suite.db.Put(ctx, fave); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ActivityLike,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         fave,
+		ReceivingAccount: favedAccount,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// A filtered notification should exist for the fave.
+	notif, err := suite.db.GetNotification(ctx,
+		gtsmodel.NotificationFave,
+		favedAccount.ID,
+		favingAccount.ID,
+		favedStatus.ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*notif.Filtered)
+
+	// It should be held in a notification request.
+	request, err := suite.db.GetNotificationRequest(ctx, favedAccount.ID, favingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, request.NotificationsCount)
+
+	// It shouldn't be streamed.
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+	_, ok := wssStream.Recv(timeoutCtx)
+	suite.False(ok)
+
+	// Accept the request.
+	if errWithCode := suite.processor.Timeline().NotificationRequestAccept(ctx, favedAccount, request.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Notification should now be released...
+	notif, err = suite.db.GetNotificationByID(ctx, notif.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*notif.Filtered)
+
+	// ...the request gone...
+	_, err = suite.db.GetNotificationRequestByID(ctx, request.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// ...and faving account allowed from now on.
+	permitted, err := suite.db.IsNotificationPermitted(ctx, favedAccount.ID, favingAccount.ID)
+	suite.NoError(err)
+	suite.True(permitted)
+}
+
 // TestProcessFaveWithDifferentReceivingAccount ensures that when an account receives a fave that's for
 // another account in their AP inbox, a notification isn't streamed to the receiving account.
 //
@@ -308,6 +399,10 @@ func (suite *FromFediAPITestSuite) TestProcessAccountDelete() {
 	suite.NoError(err)
 
 	// now they are mufos!
+	statsBefore, err := suite.db.RegenerateAccountStats(ctx, receivingAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
 	err = suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
 		APObjectType:     ap.ObjectProfile,
 		APActivityType:   ap.ActivityDelete,
@@ -360,6 +455,58 @@ func (suite *FromFediAPITestSuite) TestProcessAccountDelete() {
 	suite.False(*dbAccount.Discoverable)
 	suite.WithinDuration(time.Now(), dbAccount.SuspendedAt, 30*time.Second)
 	suite.Equal(dbAccount.ID, dbAccount.SuspensionOrigin)
+
+	// zork's stats should no longer count the mufos
+	stats, err := suite.db.GetAccountStats(ctx, receivingAccount.ID)
+	suite.NoError(err)
+	suite.Equal(statsBefore.FollowersCount-1, stats.FollowersCount)
+	suite.Equal(statsBefore.FollowingCount-1, stats.FollowingCount)
+}
+
+func (suite *FromFediAPITestSuite) TestProcessUndoFollow() {
+	ctx := context.Background()
+
+	followingAccount := suite.testAccounts["remote_account_1"]
+	followedAccount := suite.testAccounts["local_account_1"]
+
+	follow := &gtsmodel.Follow{
+		ID:              "01HV0Q2K4W4G5TB6Q9N1XJ3C8D",
+		CreatedAt:       time.Now().Add(-1 * time.Hour),
+		UpdatedAt:       time.Now().Add(-1 * time.Hour),
+		AccountID:       followingAccount.ID,
+		TargetAccountID: followedAccount.ID,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01HV0Q2K4W4G5TB6Q9N1XJ3C8D", followingAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+	if err := suite.db.PutFollow(ctx, follow); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statsBefore, err := suite.db.RegenerateAccountStats(ctx, followedAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Follow is deleted by the federating
+	// db before the Undo reaches the worker.
+	if err := suite.db.DeleteFollowByID(ctx, follow.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err = suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ActivityFollow,
+		APActivityType:   ap.ActivityUndo,
+		GTSModel:         follow,
+		ReceivingAccount: followedAccount,
+	})
+	suite.NoError(err)
+
+	// Followed account should
+	// have one follower less.
+	stats, err := suite.db.GetAccountStats(ctx, followedAccount.ID)
+	suite.NoError(err)
+	suite.Equal(statsBefore.FollowersCount-1, stats.FollowersCount)
 }
 
 func (suite *FromFediAPITestSuite) TestProcessFollowRequestLocked() {
@@ -415,6 +562,111 @@ func (suite *FromFediAPITestSuite) TestProcessFollowRequestLocked() {
 	suite.Empty(&suite.httpClient.SentMessages)
 }
 
+func (suite *FromFediAPITestSuite) TestProcessFollowRequestLockedSuppressed() {
+	ctx := context.Background()
+
+	originAccount := suite.testAccounts["remote_account_1"]
+
+	// target is a locked account that
+	// doesn't want follow request notifs.
+	targetAccount := new(gtsmodel.Account)
+	*targetAccount = *suite.testAccounts["local_account_2"]
+	targetAccount.SuppressFollowRequestNotifications = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, targetAccount, "suppress_follow_request_notifications"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	followRequest := &gtsmodel.FollowRequest{
+		ID:              "01FGRYAVAWWPP926J175QGM0WV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+
+	if err := suite.db.Put(ctx, followRequest); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ActivityFollow,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         followRequest,
+		ReceivingAccount: targetAccount,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// No notification should have been created.
+	_, err := suite.db.GetNotification(
+		ctx,
+		gtsmodel.NotificationFollowRequest,
+		targetAccount.ID,
+		originAccount.ID,
+		"",
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// But the follow request should still be queued.
+	_, err = suite.db.GetFollowRequest(ctx, originAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+}
+
+func (suite *FromFediAPITestSuite) TestProcessFollowRequestLockedAutoAccept() {
+	ctx := context.Background()
+
+	originAccount := suite.testAccounts["remote_account_1"]
+
+	// target is a locked account that accepts
+	// requests from accounts older than a day.
+	targetAccount := new(gtsmodel.Account)
+	*targetAccount = *suite.testAccounts["local_account_2"]
+	targetAccount.AutoAcceptOlderThanDays = 1
+	if err := suite.db.UpdateAccount(ctx, targetAccount, "auto_accept_older_than_days"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	followRequest := &gtsmodel.FollowRequest{
+		ID:              "01FGRYAVAWWPP926J175QGM0WV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+
+	if err := suite.db.Put(ctx, followRequest); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ActivityFollow,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         followRequest,
+		ReceivingAccount: targetAccount,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Follow request should be gone...
+	_, err := suite.db.GetFollowRequest(ctx, originAccount.ID, targetAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// ...replaced by a follow.
+	follows, err := suite.db.IsFollowing(ctx, originAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.True(follows)
+}
+
 func (suite *FromFediAPITestSuite) TestProcessFollowRequestUnlocked() {
 	ctx := context.Background()
 
@@ -528,6 +780,87 @@ func (suite *FromFediAPITestSuite) TestCreateStatusFromIRI() {
 	suite.Equal(statusCreator.URI, s.AccountURI)
 }
 
+func (suite *FromFediAPITestSuite) TestUpdatePollResults() {
+	ctx := context.Background()
+
+	receivingAccount := suite.testAccounts["local_account_1"]
+	existing := suite.testStatuses["remote_account_1_status_2"]
+
+	// Copy existing status and poll,
+	// bumping the vote counts on poll.
+	status := new(gtsmodel.Status)
+	*status = *existing
+	status.Poll = new(gtsmodel.Poll)
+	if poll, err := suite.db.GetPollByID(ctx, existing.PollID); err != nil {
+		suite.FailNow(err.Error())
+	} else {
+		*status.Poll = *poll
+	}
+	status.Poll.Votes = []int{4, 2, 20}
+	status.Poll.Voters = util.Ptr(9)
+
+	// Convert to Question, as if
+	// received in an Update activity.
+	statusable, err := suite.typeconverter.StatusToAS(ctx, status)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	pollable, ok := ap.ToPollable(statusable)
+	if !ok {
+		suite.FailNow("status not pollable")
+	}
+
+	err = suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:     ap.ActivityQuestion,
+		APActivityType:   ap.ActivityUpdate,
+		GTSModel:         existing,
+		APObjectModel:    pollable,
+		ReceivingAccount: receivingAccount,
+	})
+	suite.NoError(err)
+
+	// Poll should have been updated in place.
+	poll, err := suite.db.GetPollByID(ctx, existing.PollID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]int{4, 2, 20}, poll.Votes)
+	suite.Equal(9, *poll.Voters)
+	suite.Equal(existing.PollID, poll.ID)
+}
+
+func (suite *FromFediAPITestSuite) TestCreatePollVoteDuplicate() {
+	ctx := context.Background()
+
+	receivingAccount := suite.testAccounts["local_account_2"]
+	voter := suite.testAccounts["remote_account_1"]
+	poll := testrig.NewTestPolls()["local_account_2_status_8_poll"]
+
+	// Voter has already voted in this poll,
+	// so this looks like a retried delivery.
+	err := suite.processor.Workers().ProcessFromFediAPI(ctx, messages.FromFediAPI{
+		APObjectType:   ap.ActivityQuestion,
+		APActivityType: ap.ActivityCreate,
+		GTSModel: &gtsmodel.PollVote{
+			ID:        "01HEN2VHW4HAHBM4YH3N5ZZZZZ",
+			Choices:   []int{1},
+			AccountID: voter.ID,
+			Account:   voter,
+			PollID:    poll.ID,
+		},
+		ReceivingAccount: receivingAccount,
+	})
+	suite.NoError(err)
+
+	// Vote counts should be unchanged.
+	dbPoll, err := suite.db.GetPollByID(ctx, poll.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(poll.Votes, dbPoll.Votes)
+	suite.Equal(*poll.Voters, *dbPoll.Voters)
+}
+
 func TestFromFederatorTestSuite(t *testing.T) {
 	suite.Run(t, &FromFediAPITestSuite{})
 }
@@ -0,0 +1,497 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// ReplayQueued re-enqueues any journaled client API / federator messages
+// left over from before the last shutdown, ie., that didn't finish being
+// processed. This should be called at startup, once the enqueue functions
+// have been set and worker pools started. Messages whose models can no
+// longer be found in the database (eg., they were deleted) are skipped.
+func (p *Processor) ReplayQueued(ctx context.Context) error {
+	qMsgs, err := p.state.DB.GetQueuedMessages(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting queued messages: %w", err)
+	}
+
+	if len(qMsgs) == 0 {
+		return nil
+	}
+
+	var replayed int
+
+	for _, qMsg := range qMsgs {
+		var err error
+
+		switch qMsg.Queue {
+		case gtsmodel.QueuedMessageQueueClientAPI:
+			var msg messages.FromClientAPI
+			if msg, err = p.rehydrateClientAPI(ctx, qMsg); err == nil {
+				p.enqueueClientAPI(ctx, []string{qMsg.ID}, []messages.FromClientAPI{msg})
+			}
+
+		case gtsmodel.QueuedMessageQueueFediAPI:
+			var msg messages.FromFediAPI
+			if msg, err = p.rehydrateFediAPI(ctx, qMsg); err == nil {
+				p.enqueueFediAPI(ctx, []string{qMsg.ID}, []messages.FromFediAPI{msg})
+			}
+
+		default:
+			err = gtserror.Newf("unknown queue %s", qMsg.Queue)
+		}
+
+		if err != nil {
+			log.Warnf(ctx, "skipping replay of queued message %s: %v", qMsg.ID, err)
+			p.journalDone(ctx, qMsg.ID)
+			continue
+		}
+
+		replayed++
+	}
+
+	log.Infof(ctx, "replayed %d of %d queued messages", replayed, len(qMsgs))
+	return nil
+}
+
+// journalClientAPI journals the given client API messages to the database
+// if durable queueing is enabled, returning their journal IDs, with an
+// empty ID for any message that couldn't be journaled. If not enabled,
+// the returned slice is nil.
+func (p *Processor) journalClientAPI(ctx context.Context, msgs []messages.FromClientAPI) []string {
+	if !config.GetAdvancedDurableQueue() {
+		return nil
+	}
+
+	ids := make([]string, len(msgs))
+	for i, msg := range msgs {
+		modelType, modelID, modelData, ok := journalModel(msg.APActivityType, msg.GTSModel)
+		if !ok {
+			// Can't be replayed.
+			continue
+		}
+
+		ids[i] = p.journal(ctx, &gtsmodel.QueuedMessage{
+			ID:              id.NewULID(),
+			Queue:           gtsmodel.QueuedMessageQueueClientAPI,
+			APObjectType:    msg.APObjectType,
+			APActivityType:  msg.APActivityType,
+			ModelType:       modelType,
+			ModelID:         modelID,
+			ModelData:       modelData,
+			OriginAccountID: accountID(msg.OriginAccount),
+			TargetAccountID: accountID(msg.TargetAccount),
+		})
+	}
+
+	return ids
+}
+
+// journalFediAPI is the federator equivalent of journalClientAPI().
+func (p *Processor) journalFediAPI(ctx context.Context, msgs []messages.FromFediAPI) []string {
+	if !config.GetAdvancedDurableQueue() {
+		return nil
+	}
+
+	ids := make([]string, len(msgs))
+	for i, msg := range msgs {
+		if msg.APObjectModel != nil {
+			// ActivityPub models parsed from incoming
+			// requests aren't stored anywhere we could
+			// get them back from, so can't be replayed.
+			continue
+		}
+
+		modelType, modelID, modelData, ok := journalModel(msg.APActivityType, msg.GTSModel)
+		if !ok {
+			// Can't be replayed.
+			continue
+		}
+
+		var iri string
+		if msg.APIri != nil {
+			iri = msg.APIri.String()
+		}
+
+		ids[i] = p.journal(ctx, &gtsmodel.QueuedMessage{
+			ID:                 id.NewULID(),
+			Queue:              gtsmodel.QueuedMessageQueueFediAPI,
+			APObjectType:       msg.APObjectType,
+			APActivityType:     msg.APActivityType,
+			APIRI:              iri,
+			ModelType:          modelType,
+			ModelID:            modelID,
+			ModelData:          modelData,
+			ReceivingAccountID: accountID(msg.ReceivingAccount),
+		})
+	}
+
+	return ids
+}
+
+// journal puts the given queued message in the
+// database, returning its ID, or empty on error.
+func (p *Processor) journal(ctx context.Context, qMsg *gtsmodel.QueuedMessage) string {
+	if err := p.state.DB.PutQueuedMessage(ctx, qMsg); err != nil {
+		log.Errorf(ctx, "error journaling queued message: %v", err)
+		return ""
+	}
+	return qMsg.ID
+}
+
+// journalDone removes the queued message with given
+// ID from the journal, now that it has been processed.
+func (p *Processor) journalDone(ctx context.Context, id string) {
+	if id == "" {
+		// Not journaled.
+		return
+	}
+
+	if ctx.Err() != nil {
+		// Processing was cut short, eg. on
+		// shutdown, leave in the journal to
+		// be replayed on next startup.
+		return
+	}
+
+	if err := p.state.DB.DeleteQueuedMessageByID(ctx, id); err != nil {
+		log.Errorf(ctx, "error removing queued message %s from journal: %v", id, err)
+	}
+}
+
+// rehydrateClientAPI rebuilds the client API message
+// journaled as given queued message, from the database.
+func (p *Processor) rehydrateClientAPI(ctx context.Context, qMsg *gtsmodel.QueuedMessage) (messages.FromClientAPI, error) {
+	var (
+		msg messages.FromClientAPI
+		err error
+	)
+
+	msg.APObjectType = qMsg.APObjectType
+	msg.APActivityType = qMsg.APActivityType
+
+	msg.GTSModel, err = p.loadModel(ctx, qMsg)
+	if err != nil {
+		return msg, err
+	}
+
+	msg.OriginAccount, err = p.loadAccount(ctx, qMsg.OriginAccountID)
+	if err != nil {
+		return msg, err
+	}
+
+	if msg.OriginAccount == nil {
+		return msg, gtserror.New("no origin account")
+	}
+
+	msg.TargetAccount, err = p.loadAccount(ctx, qMsg.TargetAccountID)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
+
+// rehydrateFediAPI rebuilds the federator message
+// journaled as given queued message, from the database.
+func (p *Processor) rehydrateFediAPI(ctx context.Context, qMsg *gtsmodel.QueuedMessage) (messages.FromFediAPI, error) {
+	var (
+		msg messages.FromFediAPI
+		err error
+	)
+
+	msg.APObjectType = qMsg.APObjectType
+	msg.APActivityType = qMsg.APActivityType
+
+	if qMsg.APIRI != "" {
+		msg.APIri, err = url.Parse(qMsg.APIRI)
+		if err != nil {
+			return msg, gtserror.Newf("error parsing iri: %w", err)
+		}
+	}
+
+	msg.GTSModel, err = p.loadModel(ctx, qMsg)
+	if err != nil {
+		return msg, err
+	}
+
+	msg.ReceivingAccount, err = p.loadAccount(ctx, qMsg.ReceivingAccountID)
+	if err != nil {
+		return msg, err
+	}
+
+	if msg.ReceivingAccount == nil {
+		return msg, gtserror.New("no receiving account")
+	}
+
+	return msg, nil
+}
+
+// loadAccount fetches account with given ID
+// from the database, or nil if ID is empty.
+func (p *Processor) loadAccount(ctx context.Context, id string) (*gtsmodel.Account, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, id)
+	if err != nil {
+		return nil, gtserror.Newf("error getting account %s: %w", id, err)
+	}
+
+	return account, nil
+}
+
+// loadModel fetches the GTS model of given queued message from
+// the database, or nil if model type empty. If the model was
+// journaled as a snapshot, it is rebuilt from that instead.
+func (p *Processor) loadModel(ctx context.Context, qMsg *gtsmodel.QueuedMessage) (any, error) {
+	if qMsg.ModelData != "" {
+		return p.rebuildModel(ctx, qMsg.ModelType, qMsg.ModelData)
+	}
+
+	var (
+		modelType = qMsg.ModelType
+		id        = qMsg.ModelID
+		model     any
+		err       error
+	)
+
+	switch modelType {
+	case "":
+		return nil, nil
+	case gtsmodel.QueuedMessageModelAccount:
+		model, err = p.state.DB.GetAccountByID(ctx, id)
+	case gtsmodel.QueuedMessageModelBlock:
+		model, err = p.state.DB.GetBlockByID(ctx, id)
+	case gtsmodel.QueuedMessageModelDomainBlock:
+		model, err = p.state.DB.GetDomainBlockByID(ctx, id)
+	case gtsmodel.QueuedMessageModelFollow:
+		model, err = p.state.DB.GetFollowByID(ctx, id)
+	case gtsmodel.QueuedMessageModelFollowRequest:
+		model, err = p.state.DB.GetFollowRequestByID(ctx, id)
+	case gtsmodel.QueuedMessageModelPollVote:
+		model, err = p.state.DB.GetPollVoteByID(ctx, id)
+	case gtsmodel.QueuedMessageModelReport:
+		model, err = p.state.DB.GetReportByID(ctx, id)
+	case gtsmodel.QueuedMessageModelStatus:
+		model, err = p.state.DB.GetStatusByID(ctx, id)
+	case gtsmodel.QueuedMessageModelStatusFave:
+		model, err = p.state.DB.GetStatusFaveByID(ctx, id)
+	default:
+		return nil, gtserror.Newf("unknown model type %s", modelType)
+	}
+
+	if err != nil {
+		return nil, gtserror.Newf("error getting %s %s: %w", modelType, id, err)
+	}
+
+	return model, nil
+}
+
+// rebuildModel rebuilds the GTS model of given queued message
+// model type from the given snapshot, as taken by snapshotModel,
+// populating it from the database.
+func (p *Processor) rebuildModel(ctx context.Context, modelType string, data string) (any, error) {
+	var (
+		model any
+		err   error
+	)
+
+	switch modelType {
+	case gtsmodel.QueuedMessageModelBlock:
+		block := new(gtsmodel.Block)
+		if err = json.Unmarshal([]byte(data), block); err == nil {
+			err = p.state.DB.PopulateBlock(ctx, block)
+		}
+		model = block
+	case gtsmodel.QueuedMessageModelFollow:
+		follow := new(gtsmodel.Follow)
+		if err = json.Unmarshal([]byte(data), follow); err == nil {
+			err = p.state.DB.PopulateFollow(ctx, follow)
+		}
+		model = follow
+	case gtsmodel.QueuedMessageModelFollowRequest:
+		followReq := new(gtsmodel.FollowRequest)
+		if err = json.Unmarshal([]byte(data), followReq); err == nil {
+			err = p.state.DB.PopulateFollowRequest(ctx, followReq)
+		}
+		model = followReq
+	case gtsmodel.QueuedMessageModelStatusFave:
+		fave := new(gtsmodel.StatusFave)
+		if err = json.Unmarshal([]byte(data), fave); err == nil {
+			err = p.state.DB.PopulateStatusFave(ctx, fave)
+		}
+		model = fave
+	default:
+		return nil, gtserror.Newf("unknown snapshot model type %s", modelType)
+	}
+
+	if err != nil {
+		return nil, gtserror.Newf("error rebuilding %s: %w", modelType, err)
+	}
+
+	return model, nil
+}
+
+// journalModel returns the queued message model type, ID and
+// snapshot of the given GTS model of a message with the given
+// activity type, or false if the model can't be had on replay.
+//
+// The models of Undo and Delete messages are usually already
+// removed from the database by the time they're queued, so a
+// snapshot of them is journaled instead where possible.
+func journalModel(activityType string, model any) (string, string, string, bool) {
+	if activityType == ap.ActivityUndo ||
+		activityType == ap.ActivityDelete {
+		modelType, data, ok := snapshotModel(model)
+		if ok {
+			return modelType, "", data, true
+		}
+	}
+
+	modelType, id, ok := modelRef(model)
+	return modelType, id, "", ok
+}
+
+// snapshotModel returns the queued message model type and
+// a JSON snapshot of the given GTS model, minus any struct
+// pointers (repopulated on replay), or false if the model
+// is not of a type that can be snapshotted.
+func snapshotModel(model any) (string, string, bool) {
+	var (
+		modelType string
+		snapshot  any
+	)
+
+	switch model := model.(type) {
+	case *gtsmodel.Block:
+		if model != nil {
+			block := *model
+			block.Account = nil
+			block.TargetAccount = nil
+			modelType, snapshot = gtsmodel.QueuedMessageModelBlock, &block
+		}
+	case *gtsmodel.Follow:
+		if model != nil {
+			follow := *model
+			follow.Account = nil
+			follow.TargetAccount = nil
+			modelType, snapshot = gtsmodel.QueuedMessageModelFollow, &follow
+		}
+	case *gtsmodel.FollowRequest:
+		if model != nil {
+			followReq := *model
+			followReq.Account = nil
+			followReq.TargetAccount = nil
+			modelType, snapshot = gtsmodel.QueuedMessageModelFollowRequest, &followReq
+		}
+	case *gtsmodel.StatusFave:
+		if model != nil {
+			fave := *model
+			fave.Account = nil
+			fave.TargetAccount = nil
+			fave.Status = nil
+			modelType, snapshot = gtsmodel.QueuedMessageModelStatusFave, &fave
+		}
+	}
+
+	if snapshot == nil {
+		return "", "", false
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", "", false
+	}
+
+	return modelType, string(data), true
+}
+
+// modelRef returns the queued message model type and
+// ID of the given GTS model, or false if the model is
+// not of a type that can be fetched again on replay.
+func modelRef(model any) (string, string, bool) {
+	var modelType, id string
+
+	switch model := model.(type) {
+	case nil:
+		return "", "", true
+	case *gtsmodel.Account:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelAccount, model.ID
+		}
+	case *gtsmodel.Block:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelBlock, model.ID
+		}
+	case *gtsmodel.DomainBlock:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelDomainBlock, model.ID
+		}
+	case *gtsmodel.Follow:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelFollow, model.ID
+		}
+	case *gtsmodel.FollowRequest:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelFollowRequest, model.ID
+		}
+	case *gtsmodel.PollVote:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelPollVote, model.ID
+		}
+	case *gtsmodel.Report:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelReport, model.ID
+		}
+	case *gtsmodel.Status:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelStatus, model.ID
+		}
+	case *gtsmodel.StatusFave:
+		if model != nil {
+			modelType, id = gtsmodel.QueuedMessageModelStatusFave, model.ID
+		}
+	}
+
+	return modelType, id, id != ""
+}
+
+// accountID returns the ID of given
+// account, or empty if account is nil.
+func accountID(account *gtsmodel.Account) string {
+	if account == nil {
+		return ""
+	}
+	return account.ID
+}
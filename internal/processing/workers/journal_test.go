@@ -0,0 +1,229 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+)
+
+type JournalTestSuite struct {
+	WorkersTestSuite
+}
+
+// journalEmpty returns whether no queued
+// messages are left in the journal.
+func (suite *JournalTestSuite) journalEmpty() bool {
+	qMsgs, err := suite.db.GetQueuedMessages(context.Background())
+	return err == nil && len(qMsgs) == 0
+}
+
+func (suite *JournalTestSuite) TestJournalProcessed() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		status  = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	config.SetAdvancedDurableQueue(true)
+
+	// Hold up the client API worker, so we
+	// can check the message got journaled.
+	release := make(chan struct{})
+	suite.state.Workers.ClientAPI.Enqueue(func(ctx context.Context) {
+		<-release
+	})
+
+	suite.processor.Workers().EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       status,
+		OriginAccount:  account,
+	})
+
+	qMsgs, err := suite.db.GetQueuedMessages(ctx)
+	suite.NoError(err)
+	if suite.Len(qMsgs, 1) {
+		suite.Equal(gtsmodel.QueuedMessageQueueClientAPI, qMsgs[0].Queue)
+		suite.Equal(gtsmodel.QueuedMessageModelStatus, qMsgs[0].ModelType)
+		suite.Equal(status.ID, qMsgs[0].ModelID)
+		suite.Equal(account.ID, qMsgs[0].OriginAccountID)
+	}
+
+	// Once processed, message
+	// should leave the journal.
+	close(release)
+	suite.Eventually(suite.journalEmpty, 5*time.Second, 10*time.Millisecond)
+}
+
+func (suite *JournalTestSuite) TestReplayQueued() {
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		status           = suite.testStatuses["admin_account_status_1"]
+		homeStream       = suite.openStreams(ctx, receivingAccount, nil)[stream.TimelineHome]
+	)
+
+	// Journal entries left over from a crash, the
+	// second of which refers to a deleted status.
+	for _, modelID := range []string{
+		status.ID,
+		"01HTB1Q5KSKB5TXEM2JYSJ0DWM",
+	} {
+		if err := suite.db.PutQueuedMessage(ctx, &gtsmodel.QueuedMessage{
+			ID:              id.NewULID(),
+			Queue:           gtsmodel.QueuedMessageQueueClientAPI,
+			APObjectType:    ap.ObjectNote,
+			APActivityType:  ap.ActivityCreate,
+			ModelType:       gtsmodel.QueuedMessageModelStatus,
+			ModelID:         modelID,
+			OriginAccountID: postingAccount.ID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	if err := suite.processor.Workers().ReplayQueued(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Replayed status should be
+	// streamed to the follower.
+	recvCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	msg, ok := homeStream.Recv(recvCtx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+
+	// Both should have left the journal, the
+	// first processed, and the second skipped.
+	suite.Eventually(suite.journalEmpty, 5*time.Second, 10*time.Millisecond)
+}
+
+func (suite *JournalTestSuite) TestReplayQueuedUndoFollow() {
+	var (
+		ctx    = context.Background()
+		follow = suite.testFollows["local_account_1_admin_account"]
+	)
+
+	// Load stats of the follower
+	// while the follow still exists.
+	stats, err := suite.db.GetAccountStats(ctx, follow.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	followingCount := stats.FollowingCount
+
+	// Unfollow deletes the follow
+	// before the Undo is queued.
+	if err := suite.db.DeleteFollowByID(ctx, follow.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Journal entry for the Undo left over
+	// from a crash, with a snapshot of the
+	// follow as it was queued by unfollow.
+	data, err := json.Marshal(&gtsmodel.Follow{
+		AccountID:       follow.AccountID,
+		TargetAccountID: follow.TargetAccountID,
+		URI:             follow.URI,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutQueuedMessage(ctx, &gtsmodel.QueuedMessage{
+		ID:              id.NewULID(),
+		Queue:           gtsmodel.QueuedMessageQueueClientAPI,
+		APObjectType:    ap.ActivityFollow,
+		APActivityType:  ap.ActivityUndo,
+		ModelType:       gtsmodel.QueuedMessageModelFollow,
+		ModelData:       string(data),
+		OriginAccountID: follow.AccountID,
+		TargetAccountID: follow.TargetAccountID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.processor.Workers().ReplayQueued(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Replayed Undo should be processed,
+	// updating the follower's stats.
+	suite.Eventually(func() bool {
+		stats, err := suite.db.GetAccountStats(ctx, follow.AccountID)
+		return err == nil && stats.FollowingCount == followingCount-1
+	}, 5*time.Second, 10*time.Millisecond)
+	suite.Eventually(suite.journalEmpty, 5*time.Second, 10*time.Millisecond)
+}
+
+func (suite *JournalTestSuite) TestJournalUndoFollowSnapshot() {
+	var (
+		ctx    = context.Background()
+		follow = suite.testFollows["local_account_1_admin_account"]
+	)
+
+	config.SetAdvancedDurableQueue(true)
+
+	// Hold up the client API worker, so we
+	// can check the message got journaled.
+	release := make(chan struct{})
+	suite.state.Workers.ClientAPI.Enqueue(func(ctx context.Context) {
+		<-release
+	})
+	defer close(release)
+
+	// Follow models of Undo messages have no
+	// ID, as the follow is already deleted.
+	suite.processor.Workers().EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityUndo,
+		GTSModel: &gtsmodel.Follow{
+			AccountID:       follow.AccountID,
+			TargetAccountID: follow.TargetAccountID,
+			URI:             follow.URI,
+		},
+		OriginAccount: suite.testAccounts["local_account_1"],
+		TargetAccount: suite.testAccounts["admin_account"],
+	})
+
+	qMsgs, err := suite.db.GetQueuedMessages(ctx)
+	suite.NoError(err)
+	if suite.Len(qMsgs, 1) {
+		suite.Equal(gtsmodel.QueuedMessageModelFollow, qMsgs[0].ModelType)
+		suite.Empty(qMsgs[0].ModelID)
+		suite.Contains(qMsgs[0].ModelData, follow.URI)
+	}
+}
+
+func TestJournalTestSuite(t *testing.T) {
+	suite.Run(t, new(JournalTestSuite))
+}
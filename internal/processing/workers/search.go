@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// indexStatus adds the given status to the full text
+// search index of the given account, if full text search
+// is enabled and the account is local (remote accounts
+// never search our index so it would be wasted storage).
+func indexStatus(
+	ctx context.Context,
+	state *state.State,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) {
+	if !config.GetDbFullTextSearch() || !account.IsLocal() {
+		return
+	}
+
+	if err := state.DB.PutStatusSearchEntry(ctx, account.ID, status); err != nil {
+		log.Errorf(ctx, "db error indexing status %s for search: %v", status.ID, err)
+	}
+}
+
+// reindexStatus updates the text of all full text
+// search index entries for the given (edited) status.
+func reindexStatus(
+	ctx context.Context,
+	state *state.State,
+	status *gtsmodel.Status,
+) {
+	if !config.GetDbFullTextSearch() {
+		return
+	}
+
+	if err := state.DB.UpdateStatusSearchEntries(ctx, status); err != nil {
+		log.Errorf(ctx, "db error reindexing status %s for search: %v", status.ID, err)
+	}
+}
+
+// unindexFave removes the status targeted by the given fave
+// from the full text search index of the faving account,
+// unless the status was authored or bookmarked by it, in
+// which case it should remain searchable.
+func unindexFave(
+	ctx context.Context,
+	state *state.State,
+	fave *gtsmodel.StatusFave,
+) {
+	if !config.GetDbFullTextSearch() {
+		return
+	}
+
+	if fave.AccountID == fave.TargetAccountID {
+		// Own status.
+		return
+	}
+
+	bookmarkID, err := state.DB.GetStatusBookmarkID(ctx, fave.AccountID, fave.StatusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error checking status bookmark: %v", err)
+		return
+	}
+
+	if bookmarkID != "" {
+		// Still bookmarked.
+		return
+	}
+
+	if err := state.DB.DeleteStatusSearchEntry(ctx, fave.AccountID, fave.StatusID); err != nil {
+		log.Errorf(ctx, "db error unindexing status %s for search: %v", fave.StatusID, err)
+	}
+}
@@ -20,14 +20,23 @@ package workers
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// notificationsNewAccountAge is the age under which
+// accounts are considered new, for the purposes of the
+// "filter new accounts" notification policy.
+const notificationsNewAccountAge = 30 * 24 * time.Hour
+
 // notifyMentions iterates through mentions on the
 // given status, and notifies each mentioned account
 // that they have a new mention.
@@ -106,6 +115,13 @@ func (s *surface) notifyFollowRequest(
 		return nil
 	}
 
+	if util.PtrValueOr(followReq.TargetAccount.SuppressFollowRequestNotifications, false) {
+		// Target doesn't want to be
+		// notified of follow requests;
+		// the request remains queued.
+		return nil
+	}
+
 	// Now notify the follow request itself.
 	if err := s.notify(ctx,
 		gtsmodel.NotificationFollowRequest,
@@ -224,6 +240,65 @@ func (s *surface) notifyFave(
 	return nil
 }
 
+// notifyReaction notifies the target of the given
+// reaction that their status has been reacted to.
+func (s *surface) notifyReaction(
+	ctx context.Context,
+	reaction *gtsmodel.StatusReaction,
+) error {
+	if !config.GetStatusesReactionsEnabled() {
+		// Reactions not shown,
+		// so don't notify either.
+		return nil
+	}
+
+	if reaction.TargetAccountID == reaction.AccountID {
+		// Self-reaction, nothing to do.
+		return nil
+	}
+
+	// Beforehand, ensure the passed status reaction is fully populated.
+	if err := s.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction %s: %w", reaction.ID, err)
+	}
+
+	if reaction.TargetAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return nil
+	}
+
+	// Ensure reactee hasn't
+	// muted the thread.
+	muted, err := s.state.DB.IsThreadMutedByAccount(
+		ctx,
+		reaction.Status.ThreadID,
+		reaction.TargetAccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status thread mute %s: %w", reaction.StatusID, err)
+	}
+
+	if muted {
+		// Reactee doesn't want
+		// notifs for this thread.
+		return nil
+	}
+
+	// notify status author
+	// of reaction by account.
+	if err := s.notify(ctx,
+		gtsmodel.NotificationReaction,
+		reaction.TargetAccount,
+		reaction.Account,
+		reaction.StatusID,
+	); err != nil {
+		return gtserror.Newf("error notifying status author %s: %w", reaction.TargetAccountID, err)
+	}
+
+	return nil
+}
+
 // notifyAnnounce notifies the status boost target
 // account that their status has been boosted.
 func (s *surface) notifyAnnounce(
@@ -333,6 +408,104 @@ func (s *surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status)
 	return errs.Combine()
 }
 
+// notifyStatusUpdate notifies local accounts that
+// boosted or faved the given status that it has
+// been edited, so they can decide whether they
+// still want to stand by their boost or fave.
+func (s *surface) notifyStatusUpdate(ctx context.Context, status *gtsmodel.Status) error {
+	// Beforehand, ensure the passed status is fully populated.
+	if err := s.state.DB.PopulateStatus(ctx, status); err != nil {
+		return gtserror.Newf("error populating status %s: %w", status.ID, err)
+	}
+
+	boosts, err := s.state.DB.GetStatusBoosts(ctx, status.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting boosts of status %s: %w", status.ID, err)
+	}
+
+	faves, err := s.state.DB.GetStatusFaves(ctx, status.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting faves of status %s: %w", status.ID, err)
+	}
+
+	// Gather accounts that interacted,
+	// notifying each at most the once.
+	targets := make([]*gtsmodel.Account, 0, len(boosts)+len(faves))
+	for _, boost := range boosts {
+		targets = append(targets, boost.Account)
+	}
+	for _, fave := range faves {
+		targets = append(targets, fave.Account)
+	}
+	targets = util.DeduplicateFunc(targets, func(a *gtsmodel.Account) string {
+		if a == nil {
+			return ""
+		}
+		return a.ID
+	})
+
+	var errs gtserror.MultiError
+
+	for _, target := range targets {
+		if target == nil ||
+			target.IsRemote() ||
+			target.ID == status.AccountID {
+			// No need to notify
+			// remote accounts, or
+			// the author themself.
+			continue
+		}
+
+		if util.PtrValueOr(target.SuppressStatusUpdateNotifications, false) {
+			// Target doesn't want
+			// to know about edits.
+			continue
+		}
+
+		// Ensure target hasn't
+		// muted the thread.
+		muted, err := s.state.DB.IsThreadMutedByAccount(
+			ctx,
+			status.ThreadID,
+			target.ID,
+		)
+		if err != nil {
+			errs.Appendf("error checking status thread mute %s: %w", status.ID, err)
+			continue
+		}
+
+		if muted {
+			// Target doesn't want
+			// notifs for this thread.
+			continue
+		}
+
+		// Ensure target can
+		// still see the status.
+		visible, err := s.filter.StatusVisible(ctx, target, status)
+		if err != nil {
+			errs.Appendf("error checking status visibility: %w", err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		if err := s.notify(ctx,
+			gtsmodel.NotificationUpdate,
+			target,
+			status.Account,
+			status.ID,
+		); err != nil {
+			errs.Appendf("error notifying account %s of status update: %w", target.ID, err)
+			continue
+		}
+	}
+
+	return errs.Combine()
+}
+
 // notify creates, inserts, and streams a new
 // notification to the target account if it
 // doesn't yet exist with the given parameters.
@@ -373,6 +546,17 @@ func (s *surface) notify(
 		return gtserror.Newf("error checking existence of notification: %w", err)
 	}
 
+	// Check whether the target's notification
+	// policy wants this notification filtered.
+	filtered, err := s.notificationFiltered(ctx,
+		notificationType,
+		targetAccount,
+		originAccount,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking notification policy: %w", err)
+	}
+
 	// Notification doesn't yet exist, so
 	// we need to create + store one.
 	notif := &gtsmodel.Notification{
@@ -383,12 +567,20 @@ func (s *surface) notify(
 		OriginAccountID:  originAccount.ID,
 		OriginAccount:    originAccount,
 		StatusID:         statusID,
+		Filtered:         &filtered,
 	}
 
 	if err := s.state.DB.PutNotification(ctx, notif); err != nil {
 		return gtserror.Newf("error putting notification in database: %w", err)
 	}
 
+	if filtered {
+		// Hold the notification in a notification
+		// request from the origin account instead
+		// of streaming it to the user.
+		return s.addToNotificationRequest(ctx, targetAccount, originAccount)
+	}
+
 	// Stream notification to the user.
 	apiNotif, err := s.converter.NotificationToAPINotification(ctx, notif)
 	if err != nil {
@@ -398,3 +590,193 @@ func (s *surface) notify(
 
 	return nil
 }
+
+// notificationFiltered returns whether a notification of the given
+// type, from originAccount, should be filtered into a notification
+// request according to the notification policy of targetAccount.
+func (s *surface) notificationFiltered(
+	ctx context.Context,
+	notificationType gtsmodel.NotificationType,
+	targetAccount *gtsmodel.Account,
+	originAccount *gtsmodel.Account,
+) (bool, error) {
+	switch notificationType {
+	case gtsmodel.NotificationPoll,
+		gtsmodel.NotificationStatus,
+		gtsmodel.NotificationUpdate:
+		// Polls ending, posts from accounts the
+		// target asked to be notified about, and
+		// edits of statuses the target boosted or
+		// faved, are never filtered.
+		return false, nil
+	}
+
+	var (
+		filterNotFollowing = util.PtrValueOr(targetAccount.NotificationsFilterNotFollowing, false)
+		filterNewAccounts  = util.PtrValueOr(targetAccount.NotificationsFilterNewAccounts, false)
+	)
+
+	if !filterNotFollowing && !filterNewAccounts {
+		// Accept all.
+		return false, nil
+	}
+
+	if originAccount.ID == targetAccount.ID {
+		// Never filter
+		// own actions.
+		return false, nil
+	}
+
+	// Check if target has already accepted
+	// notifications from origin account.
+	permitted, err := s.state.DB.IsNotificationPermitted(ctx,
+		targetAccount.ID,
+		originAccount.ID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking notification permission: %w", err)
+	}
+
+	if permitted {
+		return false, nil
+	}
+
+	if filterNotFollowing {
+		following, err := s.state.DB.IsFollowing(ctx,
+			targetAccount.ID,
+			originAccount.ID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error checking follow: %w", err)
+		}
+
+		if !following {
+			return true, nil
+		}
+	}
+
+	if filterNewAccounts &&
+		time.Since(originAccount.CreatedAt) < notificationsNewAccountAge {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// addToNotificationRequest adds one filtered notification to the
+// notification request of targetAccount holding notifications from
+// originAccount, creating that notification request if necessary.
+func (s *surface) addToNotificationRequest(
+	ctx context.Context,
+	targetAccount *gtsmodel.Account,
+	originAccount *gtsmodel.Account,
+) error {
+	getRequest := func() (*gtsmodel.NotificationRequest, error) {
+		request, err := s.state.DB.GetNotificationRequest(
+			gtscontext.SetBarebones(ctx),
+			targetAccount.ID,
+			originAccount.ID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error getting notification request: %w", err)
+		}
+		return request, nil
+	}
+
+	request, err := getRequest()
+	if err != nil {
+		return err
+	}
+
+	if request == nil {
+		// First filtered notification
+		// from origin, create request.
+		request = &gtsmodel.NotificationRequest{
+			ID:                 id.NewULID(),
+			AccountID:          targetAccount.ID,
+			Account:            targetAccount,
+			FromAccountID:      originAccount.ID,
+			FromAccount:        originAccount,
+			NotificationsCount: 1,
+		}
+
+		err := s.state.DB.PutNotificationRequest(ctx, request)
+		if err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, db.ErrAlreadyExists) {
+			return gtserror.Newf("error putting notification request: %w", err)
+		}
+
+		// Another notification from origin
+		// created the request in the meantime,
+		// so fetch that one and bump its count.
+		request, err = getRequest()
+		if err != nil {
+			return err
+		}
+
+		if request == nil {
+			return gtserror.New("notification request already exists but could not be fetched")
+		}
+	}
+
+	// Request already exists, bump count.
+	if err := s.state.DB.IncrementNotificationRequest(ctx, request.ID); err != nil {
+		return gtserror.Newf("error updating notification request: %w", err)
+	}
+
+	return nil
+}
+
+// removeNotificationsFrom deletes all notifications targeting the
+// given account that originate from the target account, along with
+// any notification request holding them. Use this when the target
+// account should no longer be able to reach the account, ie., after
+// a block. If the account is local, open notification streams are
+// told to refresh so that removed notifications disappear.
+func (s *surface) removeNotificationsFrom(ctx context.Context, accountID string, targetAccountID string) {
+	l := log.
+		WithContext(ctx).
+		WithField("accountID", accountID).
+		WithField("targetAccountID", targetAccountID)
+
+	if err := s.state.DB.DeleteNotifications(
+		ctx,
+		nil,
+		accountID,
+		targetAccountID,
+	); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		l.Errorf("db error deleting notifications: %v", err)
+	}
+
+	request, err := s.state.DB.GetNotificationRequest(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+		targetAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		l.Errorf("db error getting notification request: %v", err)
+	}
+
+	if request != nil {
+		if err := s.state.DB.DeleteNotificationRequestByID(ctx, request.ID); err != nil {
+			l.Errorf("db error deleting notification request: %v", err)
+		}
+	}
+
+	account, err := s.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), accountID)
+	if err != nil {
+		l.Errorf("db error getting account: %v", err)
+		return
+	}
+
+	if !account.IsLocal() {
+		// Only local accounts
+		// have open streams.
+		return
+	}
+
+	s.stream.NotificationsMerged(ctx, account)
+}
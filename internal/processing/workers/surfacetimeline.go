@@ -71,6 +71,14 @@ func (s *surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Stream direct messages as conversation
+	// updates to each local participant.
+	if status.Visibility == gtsmodel.VisibilityDirect {
+		if err := s.streamConversation(ctx, status); err != nil {
+			return gtserror.Newf("error streaming conversation for status %s: %w", status.ID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -353,6 +361,55 @@ func (s *surface) timelineStatus(
 	return true, nil
 }
 
+// streamConversation streams the given direct status as a
+// conversation update to the direct stream of each local
+// account participating in it, ie., the author + mentions.
+func (s *surface) streamConversation(ctx context.Context, status *gtsmodel.Status) error {
+	participants := make([]*gtsmodel.Account, 0, 1+len(status.Mentions))
+	participants = append(participants, status.Account)
+	for _, mention := range status.Mentions {
+		participants = append(participants, mention.TargetAccount)
+	}
+
+	var errs gtserror.MultiError
+	seen := make(map[string]struct{}, len(participants))
+
+	for _, account := range participants {
+		if account == nil || !account.IsLocal() {
+			// Only stream to local accounts.
+			continue
+		}
+
+		if _, ok := seen[account.ID]; ok {
+			// Already streamed.
+			continue
+		}
+		seen[account.ID] = struct{}{}
+
+		// Make sure the participant can actually
+		// see the status (e.g. no blocks involved).
+		visible, err := s.filter.StatusVisible(ctx, account, status)
+		if err != nil {
+			errs.Appendf("error checking status %s visibility: %w", status.ID, err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		apiConversation, err := s.converter.StatusToAPIConversation(ctx, status, account)
+		if err != nil {
+			errs.Appendf("error converting status %s to conversation: %w", status.ID, err)
+			continue
+		}
+
+		s.stream.Conversation(ctx, account, apiConversation)
+	}
+
+	return errs.Combine()
+}
+
 // deleteStatusFromTimelines completely removes the given status from all timelines.
 // It will also stream deletion of the status to all open streams.
 func (s *surface) deleteStatusFromTimelines(ctx context.Context, statusID string) error {
@@ -386,6 +443,57 @@ func (s *surface) invalidateStatusFromTimelines(ctx context.Context, statusID st
 	}
 }
 
+// removeAccountFromTimelines removes statuses by (or boosting)
+// the target account from the HOME and LIST timelines of the given
+// account. Use this when the relationship between the two changes
+// such that the target's statuses may no longer be visible to the
+// account, ie., after an unfollow or a block.
+func (s *surface) removeAccountFromTimelines(ctx context.Context, accountID string, targetAccountID string) {
+	l := log.
+		WithContext(ctx).
+		WithField("accountID", accountID).
+		WithField("targetAccountID", targetAccountID)
+
+	account, err := s.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), accountID)
+	if err != nil {
+		l.Errorf("db error getting account: %v", err)
+		return
+	}
+
+	if !account.IsLocal() {
+		// Only local accounts
+		// have timelines.
+		return
+	}
+
+	if err := s.state.Timelines.Home.WipeItemsFromAccountID(
+		ctx,
+		accountID,
+		targetAccountID,
+	); err != nil {
+		l.Errorf("error wiping items from home timeline: %v", err)
+	}
+
+	// Entries for a removed follow will already have
+	// been deleted from lists, so just wipe the target
+	// from all of the account's list timelines.
+	lists, err := s.state.DB.GetListsForAccountID(gtscontext.SetBarebones(ctx), accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		l.Errorf("db error getting lists: %v", err)
+		return
+	}
+
+	for _, list := range lists {
+		if err := s.state.Timelines.List.WipeItemsFromAccountID(
+			ctx,
+			list.ID,
+			targetAccountID,
+		); err != nil {
+			l.Errorf("error wiping items from list timeline %s: %v", list.ID, err)
+		}
+	}
+}
+
 // timelineStatusUpdate looks up HOME and LIST timelines of accounts
 // that follow the the status author and pushes edit messages into any
 // active streams.
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// trackTagUses stores one tag use for each tag
+// of the given newly created status, if trends are
+// enabled and the status is public, for use in
+// tag usage history and trending tags.
+func trackTagUses(
+	ctx context.Context,
+	state *state.State,
+	status *gtsmodel.Status,
+) {
+	if !config.GetInstanceTrendsEnabled() ||
+		status.Visibility != gtsmodel.VisibilityPublic {
+		return
+	}
+
+	for _, tagID := range status.TagIDs {
+		use := &gtsmodel.TagUse{
+			ID:        id.NewULID(),
+			TagID:     tagID,
+			StatusID:  status.ID,
+			AccountID: status.AccountID,
+		}
+
+		if err := state.DB.PutTagUse(ctx, use); err != nil {
+			log.Errorf(ctx, "db error storing use of tag %s: %v", tagID, err)
+		}
+	}
+}
@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -85,6 +86,18 @@ func wipeStatusF(state *state.State, media *media.Processor, surface *surface) w
 			errs.Appendf("error deleting status faves: %w", err)
 		}
 
+		// delete all reactions to this status
+		if err := state.DB.DeleteStatusReactionsForStatus(ctx, statusToDelete.ID); err != nil {
+			errs.Appendf("error deleting status reactions: %w", err)
+		}
+
+		// delete all search index entries for this status
+		if config.GetDbFullTextSearch() {
+			if err := state.DB.DeleteStatusSearchEntriesForStatus(ctx, statusToDelete.ID); err != nil {
+				errs.Appendf("error deleting status search entries: %w", err)
+			}
+		}
+
 		if pollID := statusToDelete.PollID; pollID != "" {
 			// Delete this poll by ID from the database.
 			if err := state.DB.DeletePollByID(ctx, pollID); err != nil {
@@ -98,6 +111,9 @@ func wipeStatusF(state *state.State, media *media.Processor, surface *surface) w
 
 			// Cancel any scheduled expiry task for poll.
 			_ = state.Workers.Scheduler.Cancel(pollID)
+
+			// Cancel any scheduled update task for poll.
+			_ = state.Workers.Scheduler.Cancel(pollUpdateTaskID(pollID))
 		}
 
 		// delete all boosts for this status + remove them from timelines
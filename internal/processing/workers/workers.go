@@ -30,6 +30,7 @@ import (
 )
 
 type Processor struct {
+	state     *state.State
 	workers   *workers.Workers
 	clientAPI *clientAPI
 	fediAPI   *fediAPI
@@ -58,9 +59,10 @@ func New(
 	// Init federate logic
 	// wrapper struct.
 	federate := &federate{
-		Federator: federator,
-		state:     state,
-		converter: converter,
+		Federator:      federator,
+		state:          state,
+		converter:      converter,
+		accountUpdates: make(map[string]bool),
 	}
 
 	// Init shared logic wipe
@@ -72,6 +74,7 @@ func New(
 	)
 
 	return Processor{
+		state:   state,
 		workers: &state.Workers,
 		clientAPI: &clientAPI{
 			state:      state,
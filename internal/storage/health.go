@@ -0,0 +1,211 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"syscall"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/minio/minio-go/v7"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// healthProbeKey is the key of the object
+// written and removed again by a health probe.
+const healthProbeKey = "gotosocial-health-probe"
+
+var (
+	// ErrAuth indicates storage rejected our credentials.
+	ErrAuth = errors.New("storage rejected credentials")
+
+	// ErrBucketMissing indicates the configured S3 bucket does not exist.
+	ErrBucketMissing = errors.New("storage bucket does not exist")
+
+	// ErrUnreachable indicates storage could not be reached over the network.
+	ErrUnreachable = errors.New("storage could not be reached")
+
+	// ErrNotWritable indicates the storage directory can't be written to.
+	ErrNotWritable = errors.New("storage is not writable")
+
+	// ErrLowSpace indicates storage is low on free space.
+	ErrLowSpace = errors.New("storage is low on free space")
+
+	// errDiskFreeUnsupported is returned by diskFree
+	// on platforms where free space can't be checked.
+	errDiskFreeUnsupported = errors.New("checking free space not supported")
+)
+
+// Health contains the outcome of one storage health probe.
+type Health struct {
+	// Storage backend probed, ie., "local", "s3" or "memory".
+	Backend string
+
+	// When the probe was started.
+	CheckedAt time.Time
+
+	// How long the probe took to complete.
+	Latency time.Duration
+
+	// Free space in bytes on the filesystem
+	// of local storage, or -1 if not known.
+	FreeBytes int64
+
+	// Err is set if the probe failed. This will
+	// wrap one of ErrAuth, ErrBucketMissing,
+	// ErrUnreachable, ErrNotWritable or
+	// ErrLowSpace where the cause is known.
+	Err error
+}
+
+// Healthy returns whether the probe succeeded.
+func (h *Health) Healthy() bool {
+	return h.Err == nil
+}
+
+// Probe checks the health of storage by writing and removing
+// a small probe object, and for local storage by checking free
+// space against the configured minimum. The result is stored
+// for later retrieval with LastHealth, and returned.
+func (d *Driver) Probe(ctx context.Context) *Health {
+	h := &Health{
+		Backend:   d.backend(),
+		CheckedAt: time.Now(),
+		FreeBytes: -1,
+	}
+
+	h.Err = d.probe(ctx, h)
+	h.Latency = time.Since(h.CheckedAt)
+
+	d.health.Store(h)
+	return h
+}
+
+// LastHealth returns the result of the most recent
+// Probe, or nil if storage hasn't been probed yet.
+func (d *Driver) LastHealth() *Health {
+	return d.health.Load()
+}
+
+func (d *Driver) probe(ctx context.Context, h *Health) error {
+	if _, ok := d.Storage.(*storage.DiskStorage); ok {
+		// Check free space on local disk.
+		free, err := diskFree(d.BasePath)
+		switch {
+		case errors.Is(err, errDiskFreeUnsupported):
+			// Can't check on this platform.
+
+		case err != nil:
+			return d.explain("checking free space", err)
+
+		default:
+			h.FreeBytes = free
+
+			minFree := config.GetStorageLocalMinFreeSpace()
+			if minFree > 0 && free < int64(minFree) {
+				return fmt.Errorf("%w: %s free in %s, below storage-local-min-free-space of %s",
+					ErrLowSpace, bytesize.Size(free), d.BasePath, minFree)
+			}
+		}
+	}
+
+	// Write the probe object.
+	_, err := d.Put(ctx, healthProbeKey, []byte("ok"))
+	if errors.Is(err, ErrAlreadyExists) {
+		// Left over from an interrupted
+		// earlier probe, remove and retry.
+		if err := d.Delete(ctx, healthProbeKey); err != nil {
+			return d.explain("removing stale probe object", err)
+		}
+		_, err = d.Put(ctx, healthProbeKey, []byte("ok"))
+	}
+	if err != nil {
+		return d.explain("writing probe object", err)
+	}
+
+	// And remove it again.
+	if err := d.Delete(ctx, healthProbeKey); err != nil {
+		return d.explain("removing probe object", err)
+	}
+
+	return nil
+}
+
+// explain wraps an error encountered during a probe with
+// one of the exported health errors where the cause is
+// known, along with a hint of which config to check.
+func (d *Driver) explain(action string, err error) error {
+	var (
+		netErr net.Error
+		cause  error
+		hint   string
+	)
+
+	switch code := minio.ToErrorResponse(err).Code; {
+	case code == "AccessDenied",
+		code == "InvalidAccessKeyId",
+		code == "SignatureDoesNotMatch",
+		code == "InvalidToken",
+		code == "ExpiredToken":
+		cause = ErrAuth
+		hint = "check storage-s3-access-key and storage-s3-secret-key, and that they may access storage-s3-bucket"
+
+	case code == "NoSuchBucket":
+		cause = ErrBucketMissing
+		hint = fmt.Sprintf("check storage-s3-bucket %q exists at storage-s3-endpoint", d.Bucket)
+
+	case errors.As(err, &netErr):
+		cause = ErrUnreachable
+		hint = "check storage-s3-endpoint and storage-s3-use-ssl, and that the endpoint is reachable from this host"
+
+	case errors.Is(err, syscall.ENOSPC):
+		cause = ErrLowSpace
+		hint = fmt.Sprintf("free up some space in %s", d.BasePath)
+
+	case errors.Is(err, fs.ErrPermission),
+		errors.Is(err, syscall.EROFS),
+		errors.Is(err, fs.ErrNotExist):
+		cause = ErrNotWritable
+		hint = fmt.Sprintf("check storage-local-base-path %s exists and is writable by the user running gotosocial", d.BasePath)
+
+	default:
+		return fmt.Errorf("error %s: %w", action, err)
+	}
+
+	return fmt.Errorf("%w (%s): error %s: %v", cause, hint, action, err)
+}
+
+// backend returns the name of the underlying storage backend.
+func (d *Driver) backend() string {
+	switch d.Storage.(type) {
+	case *storage.S3Storage:
+		return "s3"
+	case *storage.DiskStorage:
+		return "local"
+	case *storage.MemoryStorage:
+		return "memory"
+	default:
+		return fmt.Sprintf("%T", d.Storage)
+	}
+}
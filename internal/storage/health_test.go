@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+func TestProbeLocal(t *testing.T) {
+	config.SetStorageLocalBasePath(t.TempDir())
+	config.SetStorageLocalMinFreeSpace(0)
+
+	driver, err := storage.NewFileStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer driver.Close()
+
+	h := driver.Probe(context.Background())
+	if !h.Healthy() {
+		t.Fatalf("expected healthy storage, got %v", h.Err)
+	}
+	if h.Backend != "local" {
+		t.Fatalf("expected local backend, got %s", h.Backend)
+	}
+	if driver.LastHealth() != h {
+		t.Fatal("expected last health to be set")
+	}
+
+	// Probe object shouldn't be left behind.
+	if have, _ := driver.Has(context.Background(), "gotosocial-health-probe"); have {
+		t.Fatal("expected probe object to be removed")
+	}
+
+	// Ask for more free space than any disk has.
+	config.SetStorageLocalMinFreeSpace(1024 * 1024 * bytesize.TiB)
+	defer config.SetStorageLocalMinFreeSpace(0)
+
+	h = driver.Probe(context.Background())
+	if h.FreeBytes >= 0 && !errors.Is(h.Err, storage.ErrLowSpace) {
+		t.Fatalf("expected low space error, got %v", h.Err)
+	}
+}
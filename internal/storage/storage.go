@@ -24,6 +24,7 @@ import (
 	"mime"
 	"net/url"
 	"path"
+	"sync/atomic"
 	"time"
 
 	"codeberg.org/gruf/go-bytesize"
@@ -63,6 +64,12 @@ type Driver struct {
 	Proxy          bool
 	Bucket         string
 	PresignedCache *ttl.Cache[string, PresignedURL]
+
+	// Local-only parameters
+	BasePath string
+
+	// Result of last health probe
+	health atomic.Pointer[Health]
 }
 
 // Get returns the byte value for key in storage.
@@ -232,7 +239,8 @@ func NewFileStorage() (*Driver, error) {
 	}
 
 	return &Driver{
-		Storage: disk,
+		Storage:  disk,
+		BasePath: basePath,
 	}, nil
 }
 
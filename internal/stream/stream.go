@@ -21,8 +21,10 @@ import (
 	"context"
 	"maps"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -42,8 +44,28 @@ const (
 	// user's timeline has been edited (yes this
 	// is a confusing name, blame Mastodon ...).
 	EventTypeStatusUpdate = "status.update"
+
+	// EventTypeConversation -- a direct
+	// conversation has been updated.
+	EventTypeConversation = "conversation"
+
+	// EventTypeNotificationsMerged -- the user's
+	// notifications have changed in bulk (eg.,
+	// some were removed), and should be refetched.
+	EventTypeNotificationsMerged = "notifications_merged"
+
+	// EventTypeError -- a request made by the
+	// client over the stream (eg., to subscribe
+	// to a stream type) was rejected.
+	EventTypeError = "error"
 )
 
+// sendTimeout is the maximum time to wait
+// for a stream consumer to receive a message
+// when its buffer is full, before assuming
+// the consumer is dead and closing the stream.
+const sendTimeout = time.Minute
+
 const (
 	// TimelineLocal:
 	// All public posts originating from this
@@ -84,17 +106,27 @@ var AllStatusTimelines = []string{
 	TimelineList,
 }
 
+// AllTimelines contains all Timelines that
+// a stream can be subscribed to. Note that
+// the list Timeline requires a list ID.
+var AllTimelines = []string{
+	TimelineLocal,
+	TimelinePublic,
+	TimelineHome,
+	TimelineNotifications,
+	TimelineDirect,
+	TimelineList,
+}
+
 type Streams struct {
 	streams map[string][]*Stream
+	closed  bool
 	mutex   sync.Mutex
 }
 
-// Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
+// Open will open open a new Stream for given account ID and stream types. Stream
+// types may be empty, in which case the caller is expected to Subscribe() later.
 func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
-	if len(streamTypes) == 0 {
-		panic("no stream types given")
-	}
-
 	// Prep new Stream.
 	str := new(Stream)
 	str.done = make(chan struct{})
@@ -109,6 +141,15 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	// Acquire lock.
 	s.mutex.Lock()
 
+	if s.closed {
+		// No new streams after CloseAll(),
+		// return stream already closed.
+		s.mutex.Unlock()
+		str.close = func() {}
+		str.Close()
+		return str
+	}
+
 	if s.streams == nil {
 		// Main stream-map needs allocating.
 		s.streams = make(map[string][]*Stream)
@@ -149,7 +190,7 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 	for _, str := range s.streams[accountID] {
 
 		// Check whether stream supports any of our message targets.
-		if stype := str.getStreamType(msg.Stream...); stype != "" {
+		if stype := str.getStreamType(msg.Stream...); stype != "" && str.accepts(msg) {
 
 			// Rescope var
 			// to prevent
@@ -159,9 +200,10 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 			// Use a message copy to *only*
 			// include the supported stream.
 			msgCopy := Message{
-				Stream:  []string{stype},
-				Event:   msg.Event,
-				Payload: msg.Payload,
+				Stream:      messageStream(stype),
+				Event:       msg.Event,
+				Payload:     msg.Payload,
+				UnreadCount: msg.UnreadCount,
 			}
 
 			// Send message to supported stream
@@ -200,7 +242,7 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 		for _, str := range strs {
 
 			// Check whether stream supports any of our message targets.
-			if stype := str.getStreamType(msg.Stream...); stype != "" {
+			if stype := str.getStreamType(msg.Stream...); stype != "" && str.accepts(msg) {
 
 				// Rescope var
 				// to prevent
@@ -210,9 +252,10 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 				// Use a message copy to *only*
 				// include the supported stream.
 				msgCopy := Message{
-					Stream:  []string{stype},
-					Event:   msg.Event,
-					Payload: msg.Payload,
+					Stream:      messageStream(stype),
+					Event:       msg.Event,
+					Payload:     msg.Payload,
+					UnreadCount: msg.UnreadCount,
 				}
 
 				// Send message to supported stream
@@ -240,6 +283,70 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	return ok
 }
 
+// CloseToken will close all streams of given account ID that were
+// authorized with the given access token, eg., when it's revoked.
+func (s *Streams) CloseToken(accountID string, token string) {
+	var toClose []*Stream
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	// Gather the streams authorized with token.
+	for _, str := range s.streams[accountID] {
+		if str.hasToken(token) {
+			toClose = append(toClose, str)
+		}
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	// Close streams outside lock,
+	// as closing them will want to
+	// acquire lock to remove them.
+	for _, str := range toClose {
+		str.Close()
+	}
+}
+
+// CloseAll will close all open streams, and prevent
+// any new ones from being opened, eg., on shutdown.
+func (s *Streams) CloseAll() {
+	var toClose []*Stream
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	// Mark as closed and
+	// gather ALL streams.
+	s.closed = true
+	for _, strs := range s.streams {
+		toClose = append(toClose, strs...)
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	// Close streams outside lock,
+	// as closing them will want to
+	// acquire lock to remove them.
+	for _, str := range toClose {
+		str.Close()
+	}
+}
+
+// messageStream returns the value of the outgoing
+// Message{}.Stream field for given internal stream
+// type, so that clients can demultiplex messages.
+// List types are tracked internally as `list:<id>`,
+// but are sent as [`list`, `<id>`] like Mastodon.
+func messageStream(streamType string) []string {
+	if listID, ok := strings.CutPrefix(streamType, TimelineList+":"); ok {
+		return []string{TimelineList, listID}
+	}
+	return []string{streamType}
+}
+
 // Stream represents one
 // open stream for a client.
 type Stream struct {
@@ -249,8 +356,17 @@ type Stream struct {
 	// gets updated via CAS operations in .cas().
 	types atomic.Pointer[map[string]struct{}]
 
+	// atomically updated ptr to a read-only
+	// notification filter, set by the client.
+	notifs atomic.Pointer[notifFilter]
+
+	// atomically updated ptr to the access
+	// token this stream was authorized with.
+	token atomic.Pointer[string]
+
 	// protects stream close.
 	done chan struct{}
+	once sync.Once
 
 	// inbound msg ch.
 	msgCh chan Message
@@ -282,6 +398,72 @@ func (s *Stream) Unsubscribe(streamType string) {
 	})
 }
 
+// FilterNotifications sets notification types that this stream supports.
+// If types is not empty, only notifications of those types will be sent.
+// Notifications of any of the excludeTypes will never be sent.
+func (s *Stream) FilterNotifications(types []string, excludeTypes []string) {
+	f := notifFilter{
+		types:   make(map[string]struct{}, len(types)),
+		exclude: make(map[string]struct{}, len(excludeTypes)),
+	}
+	for _, t := range types {
+		f.types[t] = struct{}{}
+	}
+	for _, t := range excludeTypes {
+		f.exclude[t] = struct{}{}
+	}
+	s.notifs.Store(&f)
+}
+
+// SendError sends an error event to this stream only, with given
+// payload, to inform the client a request it made was rejected.
+// Returns false if the context is canceled, or stream closed.
+func (s *Stream) SendError(ctx context.Context, streamType string, payload string) bool {
+	return s.send(ctx, Message{
+		Stream:  messageStream(streamType),
+		Event:   EventTypeError,
+		Payload: payload,
+	})
+}
+
+// SetToken sets the access token that this stream was authorized
+// with, so that the stream can be closed if the token is revoked.
+func (s *Stream) SetToken(token string) {
+	s.token.Store(&token)
+}
+
+// hasToken returns whether stream was authorized with given token.
+func (s *Stream) hasToken(token string) bool {
+	ptr := s.token.Load()
+	return ptr != nil && *ptr == token
+}
+
+// accepts returns whether given message passes any filters set on stream.
+func (s *Stream) accepts(msg Message) bool {
+	if msg.Event != EventTypeNotification {
+		// Only notifications
+		// are filtered.
+		return true
+	}
+
+	f := s.notifs.Load()
+	if f == nil {
+		// No filter set.
+		return true
+	}
+
+	if _, ok := f.exclude[msg.NotificationType]; ok {
+		return false
+	}
+
+	if len(f.types) > 0 {
+		_, ok := f.types[msg.NotificationType]
+		return ok
+	}
+
+	return true
+}
+
 // getStreamType returns the first stream type in given list that stream supports.
 func (s *Stream) getStreamType(streamTypes ...string) string {
 	if ptr := s.types.Load(); ptr != nil {
@@ -296,6 +478,10 @@ func (s *Stream) getStreamType(streamTypes ...string) string {
 
 // send will block on posting a new Message{}, returning early with
 // a false value if provided context is canceled, or stream closed.
+//
+// If the consumer of the stream doesn't receive the message within
+// sendTimeout, it's assumed to be dead and the stream gets closed,
+// to prevent dead consumers from holding up the caller forever.
 func (s *Stream) send(ctx context.Context, msg Message) bool {
 	select {
 	case <-s.done:
@@ -304,6 +490,26 @@ func (s *Stream) send(ctx context.Context, msg Message) bool {
 		return false
 	case s.msgCh <- msg:
 		return true
+	default:
+		// Message channel is full,
+		// fall back to waiting below.
+	}
+
+	timer := time.NewTimer(sendTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-s.done:
+		return false
+	case <-ctx.Done():
+		return false
+	case s.msgCh <- msg:
+		return true
+	case <-timer.C:
+		// Consumer has stopped
+		// receiving, clean up.
+		s.Close()
+		return false
 	}
 }
 
@@ -320,15 +526,21 @@ func (s *Stream) Recv(ctx context.Context) (Message, bool) {
 	}
 }
 
+// Done returns a channel that is closed
+// once the stream has been closed, either by
+// the caller, or because its consumer died.
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
 // Close will close the underlying context, finally
 // removing it from the parent Streams per-account-map.
+// It is safe to call Close multiple times, concurrently.
 func (s *Stream) Close() {
-	select {
-	case <-s.done:
-	default:
+	s.once.Do(func() {
 		close(s.done)
 		s.close()
-	}
+	})
 }
 
 // cas will perform a Compare And Swap operation on s.types using modifier func.
@@ -363,6 +575,13 @@ func (s *Stream) cas(fn func(map[string]struct{}) bool) {
 	}
 }
 
+// notifFilter contains notification types
+// to include / exclude on a stream.
+type notifFilter struct {
+	types   map[string]struct{}
+	exclude map[string]struct{}
+}
+
 // Message represents
 // one streamed message.
 type Message struct {
@@ -378,4 +597,13 @@ type Message struct {
 	// The actual payload of the message. In case of an
 	// update or notification, this will be a JSON string.
 	Payload string `json:"payload"`
+
+	// The type of notification contained in
+	// the payload, if this is a notification.
+	// Used for filtering, never sent to client.
+	NotificationType string `json:"-"`
+
+	// Amount of unread notifications for the
+	// receiving account, if this is a notification.
+	UnreadCount *int `json:"unread_count,omitempty"`
 }
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// cssImportRegex matches CSS @import rules, capturing
+// the imported URL, which may be given either as a
+// (quoted) string or wrapped in url(...).
+var cssImportRegex = regexp.MustCompile(`(?i)@import\s+(?:url\(\s*)?["']?([^"'\)\s;]*)["']?\s*\)?[^;]*;?`)
+
+// StripCSSRemoteImports removes any @import rules from
+// the given CSS which import a stylesheet from a host
+// other than one of the given local hosts. Imports of
+// relative URLs are left alone. The returned bool will
+// be true if any @import rules were removed.
+func StripCSSRemoteImports(css string, localHosts ...string) (string, bool) {
+	var stripped bool
+	css = cssImportRegex.ReplaceAllStringFunc(css, func(rule string) string {
+		target := cssImportRegex.FindStringSubmatch(rule)[1]
+		if !isRemoteCSSImport(target, localHosts) {
+			return rule
+		}
+		stripped = true
+		return ""
+	})
+	return css, stripped
+}
+
+// isRemoteCSSImport returns true if the given
+// @import target points to a non-local host.
+func isRemoteCSSImport(target string, localHosts []string) bool {
+	u, err := url.Parse(target)
+	if err != nil {
+		// Can't tell where this
+		// points, so assume the worst.
+		return true
+	}
+
+	if u.Scheme == "" && u.Host == "" {
+		// Relative import.
+		return false
+	}
+
+	for _, host := range localHosts {
+		if strings.EqualFold(u.Host, host) {
+			return false
+		}
+	}
+
+	return true
+}
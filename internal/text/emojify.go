@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"html"
 	"html/template"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
@@ -95,23 +96,97 @@ func emojify(
 		emojisMap[shortcode] = emoji
 	}
 
-	return regexes.ReplaceAllStringFunc(
-		regexes.EmojiFinder,
-		input,
-		func(shortcode string, buf *bytes.Buffer) string {
-			// Look for emoji with this shortcode.
-			emoji, ok := emojisMap[shortcode]
-			if !ok {
-				return shortcode
-			}
-
-			// Escape raw emoji content.
-			url := html.EscapeString(emoji.URL)
-			code := html.EscapeString(emoji.Shortcode)
-
-			// Write emoji repr to buffer.
-			write(url, code, buf)
-			return buf.String()
-		},
-	)
+	replace := func(in string) string {
+		return regexes.ReplaceAllStringFunc(
+			regexes.EmojiFinder,
+			in,
+			func(shortcode string, buf *bytes.Buffer) string {
+				// Look for emoji with this shortcode.
+				emoji, ok := emojisMap[shortcode]
+				if !ok {
+					return shortcode
+				}
+
+				// Escape raw emoji content.
+				url := html.EscapeString(emoji.URL)
+				code := html.EscapeString(emoji.Shortcode)
+
+				// Write emoji repr to buffer.
+				write(url, code, buf)
+				return buf.String()
+			},
+		)
+	}
+
+	// Leave shortcode-like text inside
+	// code spans and blocks untouched,
+	// so as not to mangle code snippets.
+	var out strings.Builder
+	for input != "" {
+		start, end := codeRegion(input)
+		if start < 0 {
+			out.WriteString(replace(input))
+			break
+		}
+
+		out.WriteString(replace(input[:start]))
+		out.WriteString(input[start:end])
+		input = input[end:]
+	}
+
+	return out.String()
+}
+
+// codeRegion returns the start and end indices of the
+// first `<pre>` or `<code>` element in the given HTML,
+// or -1, -1 if there is none. An unclosed element is
+// taken to run until the end of the input.
+func codeRegion(in string) (int, int) {
+	start, tag := -1, ""
+	for _, t := range []string{"pre", "code"} {
+		i := openTagIndex(in, t)
+		if i >= 0 && (start < 0 || i < start) {
+			start, tag = i, t
+		}
+	}
+
+	if start < 0 {
+		return -1, -1
+	}
+
+	closeTag := "</" + tag + ">"
+	end := strings.Index(in[start:], closeTag)
+	if end < 0 {
+		return start, len(in)
+	}
+
+	return start, start + end + len(closeTag)
+}
+
+// openTagIndex returns the index of the
+// first opening tag with the given name
+// in the given HTML, or -1 if not found.
+func openTagIndex(in string, tag string) int {
+	open := "<" + tag
+	for offset := 0; ; {
+		i := strings.Index(in[offset:], open)
+		if i < 0 {
+			return -1
+		}
+
+		// Make sure this is the whole tag
+		// name, and not eg., "<precise>".
+		i += offset
+		after := i + len(open)
+		if after == len(in) {
+			return -1
+		}
+
+		switch in[after] {
+		case '>', ' ', '\t', '\n', '/':
+			return i
+		}
+
+		offset = after
+	}
 }
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text_test
+
+import (
+	"html/template"
+	"testing"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func TestEmojifyWebSkipsCode(t *testing.T) {
+	emojis := []apimodel.Emoji{{
+		Shortcode: "rainbow",
+		URL:       "https://example.org/rainbow.png",
+	}}
+
+	const img = `<img src="https://example.org/rainbow.png" title=":rainbow:" alt=":rainbow:" class="emoji" loading="lazy" width="25" height="25"/>`
+
+	for _, test := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `<p>:rainbow: <code>:rainbow:</code> :rainbow:</p>`,
+			expected: `<p>` + img + ` <code>:rainbow:</code> ` + img + `</p>`,
+		},
+		{
+			in:       `<pre><code class="language-go">x := &#34;:rainbow:&#34;</code></pre><p>:rainbow:</p>`,
+			expected: `<pre><code class="language-go">x := &#34;:rainbow:&#34;</code></pre><p>` + img + `</p>`,
+		},
+		{
+			// Not a code element.
+			in:       `<precise>:rainbow:</precise>`,
+			expected: `<precise>` + img + `</precise>`,
+		},
+		{
+			// Unclosed code runs to the end.
+			in:       `<p>:rainbow: <code>unclosed :rainbow:`,
+			expected: `<p>` + img + ` <code>unclosed :rainbow:`,
+		},
+	} {
+		out := text.EmojifyWeb(emojis, template.HTML(test.in))
+		if string(out) != test.expected {
+			t.Errorf("emojify %q:\nexpected %q\n     got %q", test.in, test.expected, out)
+		}
+	}
+}
@@ -75,6 +75,7 @@ const (
 	mdWithLink                      = "Check out this code, i heard it was written by a sloth https://github.com/superseriousbusiness/gotosocial"
 	mdWithLinkExpected              = "<p>Check out this code, i heard it was written by a sloth <a href=\"https://github.com/superseriousbusiness/gotosocial\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">https://github.com/superseriousbusiness/gotosocial</a></p>"
 	mdObjectInCodeBlock             = "@foss_satan@fossbros-anonymous.io this is how to mention a user\n```\n@the_mighty_zork hey bud! nice #ObjectOrientedProgramming software you've been writing lately! :rainbow:\n```\nhope that helps"
+	mdMixedCode                     = "#Hashtag for @the_mighty_zork :rainbow:\n\nhere's `#notatag :rainbow:` inline\n\n```\n@notamention #alsonotatag\n```"
 	mdObjectInCodeBlockExpected     = "<p><span class=\"h-card\"><a href=\"http://fossbros-anonymous.io/@foss_satan\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\">@<span>foss_satan</span></a></span> this is how to mention a user</p><pre><code>@the_mighty_zork hey bud! nice #ObjectOrientedProgramming software you&#39;ve been writing lately! :rainbow:\n</code></pre><p>hope that helps</p>"
 	// Hashtags can be italicized but only with *, not _.
 	mdItalicHashtag          = "*#hashtag*"
@@ -190,6 +191,20 @@ func (suite *MarkdownTestSuite) TestParseObjectInCodeBlock() {
 	suite.Empty(formatted.Emojis)
 }
 
+func (suite *MarkdownTestSuite) TestParseMixedCode() {
+	formatted := suite.FromMarkdown(mdMixedCode)
+	suite.Contains(formatted.HTML, "<code>#notatag :rainbow:</code>")
+	suite.Contains(formatted.HTML, "<pre><code>@notamention #alsonotatag\n</code></pre>")
+
+	// Only the matches outside code count.
+	suite.Len(formatted.Tags, 1)
+	suite.Equal("hashtag", formatted.Tags[0].Name)
+	suite.Len(formatted.Mentions, 1)
+	suite.Equal("@the_mighty_zork", formatted.Mentions[0].NameString)
+	suite.Len(formatted.Emojis, 1)
+	suite.Equal("rainbow", formatted.Emojis[0].Shortcode)
+}
+
 func (suite *MarkdownTestSuite) TestParseItalicHashtag() {
 	formatted := suite.FromMarkdown(mdItalicHashtag)
 	suite.Equal(mdItalicHashtagExpected, formatted.HTML)
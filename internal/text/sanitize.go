@@ -49,6 +49,11 @@ var regular *bluemonday.Policy = func() *bluemonday.Policy {
 	// See: https://developer.mozilla.org/en-US/docs/Web/HTML/Element/details
 	p.AllowAttrs("open").Matching(regexp.MustCompile(`(?i)^(|open)$`)).OnElements("details")
 
+	// "figure" is permitted and takes no attributes;
+	// "figcaption" is permitted below with phrasing elements.
+	// See: https://developer.mozilla.org/en-US/docs/Web/HTML/Element/figure
+	p.AllowElements("figure")
+
 	// "section" is permitted and takes no attributes.
 	// See: https://developer.mozilla.org/en-US/docs/Web/HTML/Element/section
 	p.AllowElements("section")
@@ -93,6 +98,15 @@ var regular *bluemonday.Policy = func() *bluemonday.Policy {
 	// Enable ordered, unordered, and definition lists.
 	p.AllowLists()
 
+	// Tables are permitted, but without any of the
+	// presentational attributes bluemonday's AllowTables()
+	// would let through; only cell spans and header scope.
+	// See: https://developer.mozilla.org/en-US/docs/Web/HTML/Element/table
+	p.AllowElements("table", "caption", "thead", "tbody", "tfoot", "tr")
+	p.AllowAttrs("colspan", "rowspan").Matching(bluemonday.Integer).OnElements("td", "th")
+	p.AllowAttrs("scope").Matching(regexp.MustCompile(`(?i)^(row|col|rowgroup|colgroup)$`)).OnElements("th")
+	p.AllowElements("td", "th")
+
 	// Class needed on span for mentions, which look like this when assembled:
 	// `<span class="h-card"><a href="https://example.org/users/targetAccount" class="u-url mention">@<span>someusername</span></a></span>`
 	p.AllowAttrs("class").OnElements("span")
@@ -128,6 +128,73 @@ func (suite *SanitizeTestSuite) TestSanitizeInlineImg() {
 	suite.Equal(`<p>Here&#39;s an inline image: </p>`, sanitized)
 }
 
+func (suite *SanitizeTestSuite) TestSanitizeRichElements() {
+	for _, test := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			// Ruby annotations, eg., from Akkoma.
+			in:       `<p><ruby>漢<rp>(</rp><rt>kan</rt><rp>)</rp>字<rp>(</rp><rt>ji</rt><rp>)</rp></ruby></p>`,
+			expected: `<p><ruby>漢<rp>(</rp><rt>kan</rt><rp>)</rp>字<rp>(</rp><rt>ji</rt><rp>)</rp></ruby></p>`,
+		},
+		{
+			// Figure from write.as; inline image is still dropped.
+			in:       `<figure><img src="https://example.org/a.png" alt="x"><figcaption>A <em>cat</em></figcaption></figure>`,
+			expected: `<figure><figcaption>A <em>cat</em></figcaption></figure>`,
+		},
+		{
+			in:       `<p>H<sub>2</sub>O and E=mc<sup>2</sup></p>`,
+			expected: `<p>H<sub>2</sub>O and E=mc<sup>2</sup></p>`,
+		},
+		{
+			in:       `<details open><summary>spoiler</summary><p>hi</p></details>`,
+			expected: `<details open=""><summary>spoiler</summary><p>hi</p></details>`,
+		},
+		{
+			// Presentational and script attributes on tables are dropped.
+			in:       `<table style="color:red" onclick="x()"><caption>t</caption><thead><tr><th scope="col" colspan="2" width="100">a</th></tr></thead><tbody><tr><td rowspan="2;x" align="left">b</td></tr></tbody></table>`,
+			expected: `<table><caption>t</caption><thead><tr><th scope="col" colspan="2">a</th></tr></thead><tbody><tr><td>b</td></tr></tbody></table>`,
+		},
+	} {
+		suite.Equal(test.expected, text.SanitizeToHTML(test.in))
+	}
+}
+
+func (suite *SanitizeTestSuite) TestSanitizeNastyNested() {
+	for _, test := range []struct {
+		in       string
+		expected string
+	}{
+		{
+			in:       `<figure onmouseover="alert(1)"><figcaption><ruby><script>alert(1)</script><rt><iframe src="https://evil.example"></iframe>x</rt></ruby></figcaption></figure>`,
+			expected: `<figure><figcaption><ruby><rt>x</rt></ruby></figcaption></figure>`,
+		},
+		{
+			in:       `<details><summary><svg><a href="javascript:alert(1)">x</a></svg></summary><style>body{}</style></details>`,
+			expected: `<details><summary>x</summary></details>`,
+		},
+		{
+			in:       `<sup><sub><sup><object data="x"><embed src="y"></object>deep</sup></sub></sup>`,
+			expected: `<sup><sub><sup>deep</sup></sub></sup>`,
+		},
+		{
+			in:       `<ruby><rt><math><mi xlink:href="javascript:alert(1)">x</mi></math></rt></ruby>`,
+			expected: `<ruby><rt>x</rt></ruby>`,
+		},
+		{
+			in:       `<table><tr><td><form action="https://evil"><input name=a></form><a href="https://ok.example" onclick="x">ok</a></td></tr></table>`,
+			expected: `<table><tr><td><a href="https://ok.example" rel="nofollow noreferrer noopener" target="_blank">ok</a></td></tr></table>`,
+		},
+		{
+			in:       `<figure><figcaption>unclosed <b>bold<figure><figcaption><img src=x onerror=alert(1)>`,
+			expected: `<figure><figcaption>unclosed <b>bold<figure><figcaption>`,
+		},
+	} {
+		suite.Equal(test.expected, text.SanitizeToHTML(test.in))
+	}
+}
+
 func TestSanitizeTestSuite(t *testing.T) {
 	suite.Run(t, new(SanitizeTestSuite))
 }
@@ -53,6 +53,14 @@ func (t *timeline) Get(ctx context.Context, amount int, maxID string, sinceID st
 	t.lastGot = time.Now()
 	t.Unlock()
 
+	if sinceID != "" && minID != "" {
+		// When both are given, minID determines
+		// the paging direction (up), but sinceID
+		// may still be the tighter lower bound.
+		minID = max(minID, sinceID)
+		sinceID = ""
+	}
+
 	var (
 		items []Preparable
 		err   error
@@ -241,9 +249,12 @@ func (t *timeline) getXBetweenIDs(ctx context.Context, amount int, behindID stri
 
 			l.Trace("entry is just right")
 
-			if entry.prepared == nil {
+			if entry.prepared != nil {
+				t.stats.hits.Add(1)
+			} else {
 				// Whoops, this entry isn't prepared yet; some
 				// race condition? That's OK, we can do it now.
+				t.stats.misses.Add(1)
 				prepared, err := t.prepareFunction(ctx, t.timelineID, entry.itemID)
 				if err != nil {
 					if errors.Is(err, db.ErrNoEntries) {
@@ -258,9 +269,10 @@ func (t *timeline) getXBetweenIDs(ctx context.Context, amount int, behindID stri
 					err = gtserror.Newf("db error while trying to prepare %s: %w", entry.itemID, err)
 					return false, err
 				}
-				entry.prepared = prepared
+				entry.setPrepared(prepared)
 			}
 
+			entry.touch()
 			items = append(items, entry.prepared)
 
 			served++
@@ -320,10 +332,11 @@ func (t *timeline) getXBetweenIDs(ctx context.Context, amount int, behindID stri
 	for e := beforeIDMark; e != nil; e = e.Prev() {
 		entry := e.Value.(*indexedItemsEntry)
 
-		if entry.itemID == beforeID {
-			// Don't include the beforeID
-			// entry itself, just continue.
-			l.Trace("entry item ID is equal to beforeID, skipping")
+		if entry.itemID <= beforeID {
+			// Don't include the beforeID entry
+			// itself, or the older entry that
+			// may have been marked in its place.
+			l.Trace("entry item ID is not newer than beforeID, skipping")
 			continue
 		}
 
@@ -335,9 +348,12 @@ func (t *timeline) getXBetweenIDs(ctx context.Context, amount int, behindID stri
 			break
 		}
 
-		if entry.prepared == nil {
+		if entry.prepared != nil {
+			t.stats.hits.Add(1)
+		} else {
 			// Whoops, this entry isn't prepared yet; some
 			// race condition? That's OK, we can do it now.
+			t.stats.misses.Add(1)
 			prepared, err := t.prepareFunction(ctx, t.timelineID, entry.itemID)
 			if err != nil {
 				if errors.Is(err, db.ErrNoEntries) {
@@ -352,9 +368,10 @@ func (t *timeline) getXBetweenIDs(ctx context.Context, amount int, behindID stri
 				err = gtserror.Newf("db error while trying to prepare %s: %w", entry.itemID, err)
 				return nil, err
 			}
-			entry.prepared = prepared
+			entry.setPrepared(prepared)
 		}
 
+		entry.touch()
 		items = append(items, entry.prepared)
 
 		served++
@@ -591,6 +591,121 @@ func (suite *GetTestSuite) TestGetMinIDFromLowestPossible() {
 	suite.Equal(suite.lowestStatusID, statuses[0].GetID())
 }
 
+func (suite *GetTestSuite) TestGetMinIDPartiallyIndexed() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		maxID       = ""
+		sinceID     = ""
+		minID       = "01F8MHBQCBTDKN6X5VHGMMN4MA"
+		limit       = 2
+		local       = false
+	)
+
+	// Only index the newest few statuses, so
+	// the timeline doesn't reach down to minID.
+	suite.fillTimelineNewest(testAccount.ID, 3)
+
+	// The statuses immediately newer than minID.
+	expect, err := suite.state.DB.GetHomeTimeline(ctx, testAccount.ID, "", "", minID, limit, local)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err := suite.state.Timelines.Home.GetTimeline(
+		ctx,
+		testAccount.ID,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+		local,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.checkStatuses(statuses, id.Highest, minID, limit)
+	for i := range expect {
+		suite.Equal(expect[i].ID, statuses[i].GetID())
+	}
+}
+
+func (suite *GetTestSuite) TestGetBetweenIDPageUp() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		maxID       = "01FCTA44PW9H1TB328S9AQXKDS"
+		sinceID     = ""
+		minID       = "01F8MHBQCBTDKN6X5VHGMMN4MA"
+		limit       = 2
+		local       = false
+	)
+
+	suite.fillTimeline(testAccount.ID)
+
+	// Ask for fewer than there are between these
+	// two IDs, the ones immediately newer than
+	// minID should be returned, not the ones
+	// immediately older than maxID.
+	statuses, err := suite.state.Timelines.Home.GetTimeline(
+		ctx,
+		testAccount.ID,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+		local,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.checkStatuses(statuses, maxID, minID, 2)
+	suite.Equal("01F8MHC8VWDRBQR0N1BATDDEM5", statuses[0].GetID())
+	suite.Equal("01F8MHC0H0A7XHTVH5F596ZKBM", statuses[1].GetID())
+}
+
+func (suite *GetTestSuite) TestGetBetweenIDPageUpPartiallyIndexed() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		maxID       = "01FCTA44PW9H1TB328S9AQXKDS"
+		sinceID     = ""
+		minID       = "01F8MHBQCBTDKN6X5VHGMMN4MA"
+		limit       = 2
+		local       = false
+	)
+
+	// Only index the newest few statuses, so
+	// the timeline doesn't reach down to minID.
+	suite.fillTimelineNewest(testAccount.ID, 3)
+
+	// The statuses immediately newer than minID.
+	expect, err := suite.state.DB.GetHomeTimeline(ctx, testAccount.ID, maxID, "", minID, limit, local)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err := suite.state.Timelines.Home.GetTimeline(
+		ctx,
+		testAccount.ID,
+		maxID,
+		sinceID,
+		minID,
+		limit,
+		local,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.checkStatuses(statuses, maxID, minID, len(expect))
+	for i := range expect {
+		suite.Equal(expect[i].ID, statuses[i].GetID())
+	}
+}
+
 func (suite *GetTestSuite) TestGetBetweenID() {
 	var (
 		ctx         = context.Background()
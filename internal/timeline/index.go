@@ -62,6 +62,7 @@ func (t *timeline) indexXBetweenIDs(ctx context.Context, amount int, behindID st
 		listLen          = t.items.data.Len()
 		behindIDPosition int
 		beforeIDPosition int
+		reachedBeforeID  bool
 	)
 
 	for e := t.items.data.Front(); e != nil; e = e.Next() {
@@ -93,6 +94,7 @@ func (t *timeline) indexXBetweenIDs(ctx context.Context, amount int, behindID st
 			// We've gone beyond the bounds of
 			// items we're interested in; stop.
 			l.Trace("reached older items, breaking")
+			reachedBeforeID = true
 			break
 		}
 	}
@@ -100,6 +102,12 @@ func (t *timeline) indexXBetweenIDs(ctx context.Context, amount int, behindID st
 	// We can now figure out if we need to make db calls.
 	var grabMore bool
 	switch {
+	case !frontToBack && !reachedBeforeID:
+		// We're paging up from beforeID, but the list
+		// doesn't reach down that far, so we can't know
+		// which items come immediately after beforeID.
+		// Grab the full amount upwards from beforeID.
+		grabMore = true
 	case listLen < amount:
 		// The whole list is shorter than the
 		// amount we're being asked to return,
@@ -247,7 +255,7 @@ func (t *timeline) IndexAndPrepareOne(ctx context.Context, statusID string, boos
 	if err != nil {
 		return true, gtserror.Newf("error preparing: %w", err)
 	}
-	postIndexEntry.prepared = preparable
+	postIndexEntry.setPrepared(preparable)
 
 	return true, nil
 }
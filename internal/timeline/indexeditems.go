@@ -35,6 +35,26 @@ type indexedItemsEntry struct {
 	accountID        string
 	boostOfAccountID string
 	prepared         Preparable
+
+	// estimated size of prepared in
+	// bytes, and lru clock time at
+	// which it was last prepared / served.
+	size int
+	used uint64
+}
+
+// setPrepared sets the prepared version of this
+// entry, estimating its size and marking it used.
+func (e *indexedItemsEntry) setPrepared(prepared Preparable) {
+	e.prepared = prepared
+	e.size = preparedSize(prepared)
+	e.touch()
+}
+
+// touch marks this entry as (just) used,
+// for least-recently-used eviction.
+func (e *indexedItemsEntry) touch() {
+	e.used = lruClock.Add(1)
 }
 
 // WARNING: ONLY CALL THIS FUNCTION IF YOU ALREADY HAVE
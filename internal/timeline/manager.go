@@ -86,6 +86,10 @@ type Manager interface {
 	// Prune manually triggers a prune operation for the given timelineID.
 	Prune(ctx context.Context, timelineID string, desiredPreparedItemsLength int, desiredIndexedItemsLength int) (int, error)
 
+	// PreparedStats returns statistics on the prepared items
+	// held by the timelines of this manager. See Timelines.Sweep.
+	PreparedStats() PreparedStats
+
 	// Start starts hourly cleanup jobs for this timeline manager.
 	Start() error
 
@@ -110,6 +114,7 @@ type manager struct {
 	filterFunction     FilterFunction
 	prepareFunction    PrepareFunction
 	skipInsertFunction SkipInsertFunction
+	stats              preparedStats
 }
 
 func (m *manager) Start() error {
@@ -241,6 +246,16 @@ func (m *manager) Prune(ctx context.Context, timelineID string, desiredPreparedI
 	return m.getOrCreateTimeline(ctx, timelineID).Prune(desiredPreparedItemsLength, desiredIndexedItemsLength), nil
 }
 
+func (m *manager) PreparedStats() PreparedStats {
+	return PreparedStats{
+		Hits:      m.stats.hits.Load(),
+		Misses:    m.stats.misses.Load(),
+		Evictions: m.stats.evictions.Load(),
+		Items:     int(m.stats.items.Load()),
+		Bytes:     int(m.stats.bytes.Load()),
+	}
+}
+
 // getOrCreateTimeline returns a timeline with the given id,
 // creating a new timeline with that id if necessary.
 func (m *manager) getOrCreateTimeline(ctx context.Context, timelineID string) Timeline {
@@ -252,7 +267,7 @@ func (m *manager) getOrCreateTimeline(ctx context.Context, timelineID string) Ti
 
 	// Timeline did not yet exist in sync.Map.
 	// Create + store it.
-	timeline := NewTimeline(ctx, timelineID, m.grabFunction, m.filterFunction, m.prepareFunction, m.skipInsertFunction)
+	timeline := newTimeline(timelineID, m.grabFunction, m.filterFunction, m.prepareFunction, m.skipInsertFunction, &m.stats)
 	m.timelines.Store(timelineID, timeline)
 
 	return timeline
@@ -132,7 +132,7 @@ func (t *timeline) prepareXBetweenIDs(ctx context.Context, amount int, behindID
 			// We've got a proper db error.
 			return gtserror.Newf("db error while trying to prepare %s: %w", entry.itemID, err)
 		}
-		entry.prepared = prepared
+		entry.setPrepared(prepared)
 	}
 
 	return nil
@@ -0,0 +1,244 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"encoding/json"
+	"slices"
+	"sync/atomic"
+)
+
+// lruClock is a logical clock used to order
+// prepared items across all timelines by
+// when they were last used, for eviction.
+var lruClock atomic.Uint64
+
+// preparedStats counts prepared item cache
+// activity for all timelines of a manager.
+type preparedStats struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+
+	// set on each sweep.
+	items atomic.Int64
+	bytes atomic.Int64
+}
+
+// PreparedStats is a snapshot of the prepared
+// item cache statistics of a timeline manager.
+type PreparedStats struct {
+	// Hits is the number of items served
+	// from already prepared entries.
+	Hits uint64
+
+	// Misses is the number of items that
+	// had to be prepared in order to serve.
+	Misses uint64
+
+	// Evictions is the number of prepared
+	// items dropped by sweeps to keep within
+	// the per-timeline and memory limits.
+	Evictions uint64
+
+	// Items and Bytes are the number and
+	// estimated size of prepared items held,
+	// as of the last sweep.
+	Items int
+	Bytes int
+}
+
+// preparedSize estimates the in-memory size of
+// the prepared item by its serialized size, which
+// is a reasonable proxy for API model structures.
+func preparedSize(prepared Preparable) int {
+	b, err := json.Marshal(prepared)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// lruEntry is a prepared entry
+// considered for sweep eviction.
+type lruEntry struct {
+	timeline *timeline
+	entry    *indexedItemsEntry
+	used     uint64
+	size     int
+}
+
+// Sweep unprepares items in all timelines held by the
+// Home and List managers, least recently used first, such
+// that no timeline holds more than maxPrepared prepared
+// items, and the estimated size of all prepared items is
+// within memoryTarget bytes. Zero or less disables a limit.
+//
+// Indexed items are left in place, and will be prepared
+// again when next served. This is intended to be called
+// regularly alongside the cache sweep scheduler task.
+func (t *Timelines) Sweep(maxPrepared int, memoryTarget int) {
+	sweep(maxPrepared, memoryTarget, t.Home, t.List)
+}
+
+func sweep(maxPrepared int, memoryTarget int, managers ...Manager) {
+	type usage struct{ items, bytes int }
+
+	var (
+		entries []lruEntry
+		usages  = make(map[*preparedStats]*usage, len(managers))
+		total   int
+	)
+
+	for _, m := range managers {
+		m, ok := m.(*manager)
+		if !ok {
+			continue
+		}
+
+		u := new(usage)
+		usages[&m.stats] = u
+
+		m.timelines.Range(func(_ any, v any) bool {
+			t, ok := v.(*timeline)
+			if !ok {
+				return true
+			}
+
+			// Enforce per-timeline limit, and gather
+			// remaining prepared entries for the
+			// global memory limit check below.
+			evicted, prepared := t.sweep(maxPrepared)
+			m.stats.evictions.Add(uint64(evicted))
+
+			for _, e := range prepared {
+				u.items++
+				u.bytes += e.size
+				total += e.size
+			}
+			entries = append(entries, prepared...)
+
+			return true
+		})
+	}
+
+	if memoryTarget > 0 && total > memoryTarget {
+		// Over budget, drop least recently used first.
+		slices.SortFunc(entries, func(a, b lruEntry) int {
+			switch {
+			case a.used < b.used:
+				return -1
+			case a.used > b.used:
+				return 1
+			default:
+				return 0
+			}
+		})
+
+		for _, e := range entries {
+			if total <= memoryTarget {
+				break
+			}
+
+			if !e.timeline.evict(e.entry, e.used) {
+				// Used since gathered.
+				continue
+			}
+
+			e.timeline.stats.evictions.Add(1)
+			total -= e.size
+
+			if u := usages[e.timeline.stats]; u != nil {
+				u.items--
+				u.bytes -= e.size
+			}
+		}
+	}
+
+	for stats, u := range usages {
+		stats.items.Store(int64(u.items))
+		stats.bytes.Store(int64(u.bytes))
+	}
+}
+
+// sweep unprepares least recently used entries in
+// this timeline beyond maxPrepared, returning the
+// number evicted, and the entries still prepared.
+func (t *timeline) sweep(maxPrepared int) (int, []lruEntry) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.items == nil || t.items.data == nil {
+		// Nothing indexed yet.
+		return 0, nil
+	}
+
+	var prepared []lruEntry
+	for e := t.items.data.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*indexedItemsEntry)
+		if entry.prepared == nil {
+			continue
+		}
+
+		prepared = append(prepared, lruEntry{
+			timeline: t,
+			entry:    entry,
+			used:     entry.used,
+			size:     entry.size,
+		})
+	}
+
+	if maxPrepared <= 0 || len(prepared) <= maxPrepared {
+		// Within limit.
+		return 0, prepared
+	}
+
+	// Sort most recently used first, and
+	// unprepare everything beyond the limit.
+	slices.SortFunc(prepared, func(a, b lruEntry) int {
+		switch {
+		case a.used > b.used:
+			return -1
+		case a.used < b.used:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	evicted := prepared[maxPrepared:]
+	for _, e := range evicted {
+		e.entry.prepared = nil
+	}
+
+	return len(evicted), prepared[:maxPrepared]
+}
+
+// evict unprepares the given entry, unless it has been
+// used (or unprepared) since the given lru clock time.
+func (t *timeline) evict(entry *indexedItemsEntry, used uint64) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if entry.prepared == nil || entry.used != used {
+		return false
+	}
+
+	entry.prepared = nil
+	return true
+}
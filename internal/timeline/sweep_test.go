@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SweepTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *SweepTestSuite) TestSweepPerTimelineLimit() {
+	var (
+		ctx           = context.Background()
+		testAccountID = suite.testAccounts["local_account_1"].ID
+	)
+
+	suite.fillTimeline(testAccountID)
+
+	suite.state.Timelines.Sweep(5, 0)
+
+	stats := suite.state.Timelines.Home.PreparedStats()
+	suite.Equal(uint64(18), stats.Evictions)
+	suite.Equal(5, stats.Items)
+	suite.Positive(stats.Bytes)
+
+	// Indexed items should be left alone.
+	suite.Equal(23, suite.state.Timelines.Home.GetIndexedLength(ctx, testAccountID))
+}
+
+func (suite *SweepTestSuite) TestSweepMemoryTarget() {
+	var (
+		ctx           = context.Background()
+		testAccountID = suite.testAccounts["local_account_1"].ID
+	)
+
+	suite.fillTimeline(testAccountID)
+
+	// Tiny memory target, everything should go.
+	suite.state.Timelines.Sweep(0, 1)
+
+	stats := suite.state.Timelines.Home.PreparedStats()
+	suite.Equal(uint64(23), stats.Evictions)
+	suite.Zero(stats.Items)
+	suite.Zero(stats.Bytes)
+	suite.Equal(23, suite.state.Timelines.Home.GetIndexedLength(ctx, testAccountID))
+
+	// Items should be prepared again when served.
+	items, err := suite.state.Timelines.Home.GetTimeline(ctx, testAccountID, "", "", "", 5, false)
+	suite.NoError(err)
+	suite.Len(items, 5)
+}
+
+func (suite *SweepTestSuite) TestSweepWithinLimits() {
+	testAccountID := suite.testAccounts["local_account_1"].ID
+
+	suite.fillTimeline(testAccountID)
+
+	suite.state.Timelines.Sweep(9999999, 1<<30)
+
+	stats := suite.state.Timelines.Home.PreparedStats()
+	suite.Zero(stats.Evictions)
+	suite.Equal(23, stats.Items)
+	suite.Positive(stats.Bytes)
+}
+
+func TestSweepTestSuite(t *testing.T) {
+	suite.Run(t, new(SweepTestSuite))
+}
@@ -142,6 +142,7 @@ type timeline struct {
 	prepareFunction PrepareFunction
 	timelineID      string
 	lastGot         time.Time
+	stats           *preparedStats
 	sync.Mutex
 }
 
@@ -159,6 +160,27 @@ func NewTimeline(
 	prepareFunction PrepareFunction,
 	skipInsertFunction SkipInsertFunction,
 ) Timeline {
+	return newTimeline(
+		timelineID,
+		grabFunction,
+		filterFunction,
+		prepareFunction,
+		skipInsertFunction,
+		new(preparedStats),
+	)
+}
+
+// newTimeline returns a new timeline with the given ID,
+// using the given functions, and counting prepared item
+// cache hits / misses / evictions in the given stats.
+func newTimeline(
+	timelineID string,
+	grabFunction GrabFunction,
+	filterFunction FilterFunction,
+	prepareFunction PrepareFunction,
+	skipInsertFunction SkipInsertFunction,
+	stats *preparedStats,
+) *timeline {
 	return &timeline{
 		items: &indexedItems{
 			skipInsert: skipInsertFunction,
@@ -168,5 +190,6 @@ func NewTimeline(
 		prepareFunction: prepareFunction,
 		timelineID:      timelineID,
 		lastGot:         time.Time{},
+		stats:           stats,
 	}
 }
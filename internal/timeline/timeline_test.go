@@ -70,6 +70,12 @@ func (suite *TimelineStandardTestSuite) TearDownTest() {
 }
 
 func (suite *TimelineStandardTestSuite) fillTimeline(timelineID string) {
+	suite.fillTimelineNewest(timelineID, len(suite.testStatuses))
+}
+
+// fillTimelineNewest puts only the newest
+// n testrig statuses into the timeline.
+func (suite *TimelineStandardTestSuite) fillTimelineNewest(timelineID string, n int) {
 	// Put testrig statuses in a determinate order
 	// since we can't trust a map to keep order.
 	statuses := []*gtsmodel.Status{}
@@ -88,9 +94,9 @@ func (suite *TimelineStandardTestSuite) fillTimeline(timelineID string) {
 		suite.FailNow("", "statuses weren't ordered properly by sort")
 	}
 
-	// Put all test statuses into the timeline; we don't
+	// Put test statuses into the timeline; we don't
 	// need to be fussy about who sees what for these tests.
-	for _, status := range statuses {
+	for _, status := range statuses[:n] {
 		if _, err := suite.state.Timelines.Home.IngestOne(context.Background(), timelineID, status); err != nil {
 			suite.FailNow(err.Error())
 		}
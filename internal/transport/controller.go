@@ -29,6 +29,8 @@ import (
 	"net/http"
 	"net/url"
 	"runtime"
+	"sync/atomic"
+	"time"
 
 	"codeberg.org/gruf/go-byteutil"
 	"codeberg.org/gruf/go-cache/v3"
@@ -48,6 +50,9 @@ type Controller interface {
 
 	// NewTransportForUsername searches for account with username, and returns result of .NewTransport().
 	NewTransportForUsername(ctx context.Context, username string) (Transport, error)
+
+	// RetryDeliveries retries all queued deliveries that failed earlier and are due by now.
+	RetryDeliveries(ctx context.Context, now time.Time)
 }
 
 type controller struct {
@@ -58,6 +63,7 @@ type controller struct {
 	trspCache cache.TTLCache[string, *transport]
 	userAgent string
 	senders   int // no. concurrent batch delivery routines.
+	retrying  atomic.Bool
 }
 
 // NewController returns an implementation of the Controller interface for creating new transports
@@ -22,11 +22,13 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"codeberg.org/gruf/go-byteutil"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 )
 
 func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*url.URL) error {
@@ -79,8 +81,9 @@ func (t *transport) BatchDeliver(ctx context.Context, b []byte, recipients []*ur
 					continue
 				}
 
-				// Attempt to deliver data to recipient.
-				if err := t.deliver(ctx, b, to); err != nil {
+				// Attempt to deliver data to recipient,
+				// queueing it for retry on temporary error.
+				if err := t.deliverOrQueue(ctx, b, to); err != nil {
 					mutex.Lock() // safely append err to accumulator.
 					errs.Appendf("error delivering to %s: %w", to, err)
 					mutex.Unlock()
@@ -102,11 +105,19 @@ func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
 		return nil
 	}
 
-	// Deliver data to recipient.
-	return t.deliver(ctx, b, to)
+	// Deliver data to recipient, queueing
+	// it for retry on temporary error.
+	return t.deliverOrQueue(ctx, b, to)
 }
 
-func (t *transport) deliver(ctx context.Context, b []byte, to *url.URL) error {
+func (t *transport) deliver(ctx context.Context, b []byte, to *url.URL) (err error) {
+	// Record federation health
+	// for the target domain.
+	start := time.Now()
+	defer func() {
+		metrics.DeliveryAttempt(to.Host, time.Since(start), err)
+	}()
+
 	url := to.String()
 
 	// Use rewindable bytes reader for body.
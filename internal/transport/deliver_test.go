@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DeliverTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *DeliverTestSuite) TestDeliverRetryQueue() {
+	var (
+		ctx    = context.Background()
+		status = http.StatusServiceUnavailable
+		posts  = 0
+	)
+
+	client := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		posts++
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	}, "../../testrig/media")
+
+	controller := testrig.NewTestTransportController(&suite.state, client)
+	tsport, err := controller.NewTransportForUsername(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	inbox, _ := url.Parse("https://example.org/users/someone/inbox")
+
+	// Temporary failure should
+	// queue delivery for retry.
+	if err := tsport.Deliver(ctx, []byte(`{}`), inbox); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, posts)
+	suite.assertBacklog(map[string]int{"example.org": 1})
+
+	// Retrying before backoff
+	// elapsed does nothing.
+	controller.RetryDeliveries(ctx, time.Now())
+	suite.Equal(1, posts)
+	suite.assertBacklog(map[string]int{"example.org": 1})
+
+	// Retrying after backoff elapsed
+	// delivers, and dequeues delivery.
+	status = http.StatusAccepted
+	controller.RetryDeliveries(ctx, time.Now().Add(2*time.Minute))
+	suite.Equal(2, posts)
+	suite.assertBacklog(map[string]int{})
+
+	// Permanent failure isn't queued.
+	status = http.StatusGone
+	suite.Error(tsport.Deliver(ctx, []byte(`{}`), inbox))
+	suite.Equal(3, posts)
+	suite.assertBacklog(map[string]int{})
+}
+
+func (suite *DeliverTestSuite) assertBacklog(expect map[string]int) {
+	counts, err := suite.state.DB.CountDeliveriesByDomain(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(expect, counts)
+}
+
+func TestDeliverTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliverTestSuite))
+}
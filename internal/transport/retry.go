@@ -0,0 +1,226 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// baseRetryBackoff is the backoff before the first retry
+	// of a queued delivery, doubled for each further attempt.
+	baseRetryBackoff = time.Minute
+
+	// maxRetryBackoff caps the backoff
+	// between retries of a queued delivery.
+	maxRetryBackoff = 12 * time.Hour
+
+	// maxRetryAge is how long after first
+	// failing a delivery is given up on.
+	maxRetryAge = 72 * time.Hour
+
+	// retryBatchSize is the max number of due
+	// deliveries fetched at once for retry.
+	retryBatchSize = 100
+)
+
+// errUndeliverable marks queued deliveries
+// that can no longer be delivered at all.
+var errUndeliverable = errors.New("undeliverable")
+
+// deliverOrQueue attempts to deliver data to recipient, queueing
+// the delivery to be retried later if it failed temporarily. Only
+// errors for failures that won't be retried are returned.
+func (t *transport) deliverOrQueue(ctx context.Context, b []byte, to *url.URL) error {
+	err := t.deliver(ctx, b, to)
+	if err == nil || !retryable(err) {
+		return err
+	}
+
+	now := time.Now()
+	delivery := &gtsmodel.Delivery{
+		ID:            id.NewULID(),
+		CreatedAt:     now,
+		PubKeyID:      t.pubKeyID,
+		InboxURI:      to.String(),
+		Domain:        to.Host,
+		Data:          b,
+		Attempts:      1,
+		NextAttemptAt: now.Add(retryBackoff(1)),
+		LastError:     err.Error(),
+	}
+
+	if err := t.controller.state.DB.PutDelivery(ctx, delivery); err != nil {
+		return gtserror.Newf("error queueing failed delivery: %w", err)
+	}
+
+	log.Warnf(ctx, "queued delivery to %s for retry at %s after error: %v", to, delivery.NextAttemptAt, err)
+	return nil
+}
+
+// RetryDeliveries attempts once more each queued delivery due
+// by now, dropping those that failed permanently or for too long.
+func (c *controller) RetryDeliveries(ctx context.Context, now time.Time) {
+	if !c.retrying.CompareAndSwap(false, true) {
+		// Previous run still going.
+		return
+	}
+	defer c.retrying.Store(false)
+
+	for {
+		deliveries, err := c.state.DB.GetDeliveriesDue(ctx, now, retryBatchSize)
+		if err != nil {
+			log.Errorf(ctx, "db error getting due deliveries: %v", err)
+			return
+		}
+
+		for _, delivery := range deliveries {
+			c.retryDelivery(ctx, now, delivery)
+		}
+
+		if len(deliveries) < retryBatchSize {
+			// Queue drained.
+			return
+		}
+	}
+}
+
+// retryDelivery attempts the given queued delivery once more,
+// removing it from the queue on success or permanent failure,
+// else scheduling the next attempt with exponential backoff.
+func (c *controller) retryDelivery(ctx context.Context, now time.Time, delivery *gtsmodel.Delivery) {
+	l := log.WithContext(ctx).WithField("inbox", delivery.InboxURI)
+
+	err := c.attemptDelivery(ctx, delivery)
+	switch {
+	case err == nil:
+		l.Infof("delivered after %d attempts", delivery.Attempts+1)
+
+	case !retryable(err):
+		l.Warnf("dropping delivery after permanent failure: %v", err)
+
+	case now.Sub(delivery.CreatedAt) >= maxRetryAge:
+		l.Warnf("dropping delivery after failing for %s: %v", maxRetryAge, err)
+
+	default:
+		// Try again later.
+		delivery.Attempts++
+		delivery.NextAttemptAt = now.Add(retryBackoff(delivery.Attempts))
+		delivery.LastError = err.Error()
+		if err := c.state.DB.UpdateDelivery(ctx, delivery,
+			"attempts",
+			"next_attempt_at",
+			"last_error",
+		); err != nil {
+			l.Errorf("db error updating delivery: %v", err)
+		}
+		return
+	}
+
+	if err := c.state.DB.DeleteDeliveryByID(ctx, delivery.ID); err != nil {
+		l.Errorf("db error deleting delivery: %v", err)
+	}
+}
+
+// attemptDelivery performs the given queued delivery, signed
+// by the account it was originally going to be signed by.
+func (c *controller) attemptDelivery(ctx context.Context, delivery *gtsmodel.Delivery) error {
+	blocked, err := c.state.DB.IsDomainBlocked(ctx, delivery.Domain)
+	if err != nil {
+		return gtserror.Newf("db error checking domain block: %w", err)
+	}
+
+	if blocked {
+		// Never deliver to blocked domains.
+		return fmt.Errorf("%w: domain %s blocked", errUndeliverable, delivery.Domain)
+	}
+
+	account, err := c.state.DB.GetAccountByPubkeyID(ctx, delivery.PubKeyID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	if account == nil || account.PrivateKey == nil || !account.SuspendedAt.IsZero() {
+		// Sending account is gone.
+		return fmt.Errorf("%w: sending account not found", errUndeliverable)
+	}
+
+	tsport, err := c.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	if err != nil {
+		return gtserror.Newf("error creating transport: %w", err)
+	}
+
+	to, err := url.Parse(delivery.InboxURI)
+	if err != nil {
+		return fmt.Errorf("%w: invalid inbox uri: %w", errUndeliverable, err)
+	}
+
+	return tsport.(*transport).deliver(ctx, delivery.Data, to)
+}
+
+// retryable returns whether a delivery failing with
+// err may succeed if it is attempted again later.
+func retryable(err error) bool {
+	if errors.Is(err, errUndeliverable) {
+		return false
+	}
+
+	if gtserror.IsNotFound(err) {
+		// Inbox host does not exist.
+		return false
+	}
+
+	code := gtserror.StatusCode(err)
+	switch {
+	case code == http.StatusRequestTimeout,
+		code == http.StatusTooManyRequests:
+		// Try again later.
+		return true
+
+	case code >= 400 && code < 500:
+		// Includes 410 Gone, the
+		// recipient won't be back.
+		return false
+
+	default:
+		// Server errors, timeouts
+		// and connection failures.
+		return true
+	}
+}
+
+// retryBackoff returns the backoff before the next attempt
+// of a delivery, given the number of attempts made so far.
+func retryBackoff(attempts int) time.Duration {
+	backoff := baseRetryBackoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
@@ -21,18 +21,25 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"strings"
 
 	"github.com/miekg/dns"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// followRequestNoteMaxLength is the maximum length, in
+// runes, of notes kept from incoming follow requests.
+const followRequestNoteMaxLength = 500
+
 // ASRepresentationToAccount converts a remote account/person/application representation into a gts model account.
 //
 // If accountDomain is provided then this value will be used as the account's Domain, else the AP ID host.
@@ -272,6 +279,18 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		ap.ExtractContent(statusable),
 	)
 
+	// Some types of statusable carry structured info
+	// outside of content, which we render into content
+	// as best we can so it isn't lost on clients.
+	switch statusable.GetTypeName() {
+	case ap.ObjectArticle:
+		status.Content = articleContent(statusable, status.Content)
+	case ap.ObjectEvent:
+		status.Content = eventContent(statusable, status.Content)
+	case ap.ObjectAudio:
+		status.Content = audioContent(statusable, status.Content)
+	}
+
 	// status.Attachments
 	//
 	// Media attachments for later dereferencing.
@@ -280,6 +299,18 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		log.Warnf(ctx, "error(s) extracting attachments for %s: %v", uri, err)
 	}
 
+	// An Audio object (eg., from Funkwhale)
+	// is itself the attachment, so add it.
+	if attachmentable, ok := statusable.(ap.Attachmentable); ok &&
+		statusable.GetTypeName() == ap.ObjectAudio {
+		audio, err := ap.ExtractAudioAttachment(attachmentable)
+		if err != nil {
+			log.Warnf(ctx, "error extracting audio for %s: %v", uri, err)
+		} else {
+			status.Attachments = append(status.Attachments, audio)
+		}
+	}
+
 	// status.Poll
 	//
 	// Attached poll information (the statusable will actually
@@ -321,10 +352,11 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// status.ContentWarning
 	//
 	// Topic or content warning for this status;
-	// prefer Summary, fall back to Name.
+	// prefer Summary, fall back to Name, unless
+	// Name is a title we've already used elsewhere.
 	if summary := ap.ExtractSummary(statusable); summary != "" {
 		status.ContentWarning = summary
-	} else {
+	} else if !nameIsTitle(statusable.GetTypeName()) {
 		status.ContentWarning = ap.ExtractName(statusable)
 	}
 
@@ -391,6 +423,32 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteOfURI
+	// status.QuoteOfID
+	// status.QuoteOf
+	//
+	// Status that this status quotes, if applicable.
+	// As with replies, if we don't have the quoted status
+	// in the database we just set the URI for later deref.
+	if quoteOf := ap.ExtractQuoteURI(statusable); quoteOf != nil {
+		quoteOfURI := quoteOf.String()
+		status.QuoteOfURI = quoteOfURI
+
+		// Check if we already have the quoted status.
+		quoteOf, err := c.state.DB.GetStatusByURI(ctx, quoteOfURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quote %s from db: %w", quoteOfURI, err)
+			return nil, err
+		}
+
+		if quoteOf != nil {
+			// We have it in the DB! Set
+			// appropriate fields here and now.
+			status.QuoteOfID = quoteOf.ID
+			status.QuoteOf = quoteOf
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -448,6 +506,14 @@ func (c *Converter) ASFollowToFollowRequest(ctx context.Context, followable ap.F
 		TargetAccountID: target.ID,
 	}
 
+	// Some servers send a note along
+	// with a follow; keep it as plaintext.
+	if withContent, ok := followable.(ap.WithContent); ok {
+		note := ap.ExtractContent(withContent).Content
+		note = text.SanitizeToPlaintext(note)
+		followRequest.Note = trimTo(note, followRequestNoteMaxLength)
+	}
+
 	return followRequest, nil
 }
 
@@ -513,6 +579,76 @@ func (c *Converter) ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gt
 	}, nil
 }
 
+// ASEmojiReactToStatusReaction converts a remote activity streams 'EmojiReact'
+// (normalized into a Like, see ap.NormalizeIncomingEmojiReact) into a gts model
+// status reaction. If the reaction uses a custom emoji, the returned reaction's
+// Emoji will be set to the minimal emoji extracted from the activity's tags, with
+// no ID; it's up to the caller to dereference it and set EmojiID where necessary.
+func (c *Converter) ASEmojiReactToStatusReaction(ctx context.Context, like vocab.ActivityStreamsLike) (*gtsmodel.StatusReaction, error) {
+	uriObj := ap.GetJSONLDId(like)
+	if uriObj == nil {
+		err := gtserror.New("unusable iri property")
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Stringify uri obj.
+	uri := uriObj.String()
+
+	// Reaction emoji is given as content,
+	// either unicode emoji or ':shortcode:'.
+	content := strings.TrimSpace(ap.ExtractContent(like).Content)
+	if content == "" {
+		err := gtserror.Newf("no content for reaction %s", uri)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	origin, err := c.getASActorAccount(ctx, uri, like)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := c.getASObjectStatus(ctx, uri, like)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &gtsmodel.StatusReaction{
+		AccountID:       origin.ID,
+		Account:         origin,
+		TargetAccountID: target.AccountID,
+		TargetAccount:   target.Account,
+		StatusID:        target.ID,
+		Status:          target,
+		Content:         content,
+		URI:             uri,
+	}
+
+	if shortcode, ok := strings.CutPrefix(content, ":"); ok {
+		shortcode = strings.TrimSuffix(shortcode, ":")
+
+		// Custom emoji, find it in the tags.
+		emojis, err := ap.ExtractEmojis(like)
+		if err != nil {
+			err := gtserror.Newf("error extracting emojis for reaction %s: %w", uri, err)
+			return nil, gtserror.SetMalformed(err)
+		}
+
+		for _, emoji := range emojis {
+			if emoji.Shortcode == shortcode {
+				reaction.Emoji = emoji
+				break
+			}
+		}
+
+		if reaction.Emoji == nil {
+			err := gtserror.Newf("no emoji tag for reaction %s with %s", uri, content)
+			return nil, gtserror.SetMalformed(err)
+		}
+	}
+
+	return reaction, nil
+}
+
 // ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 func (c *Converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error) {
 	uriObj := ap.GetJSONLDId(blockable)
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -281,10 +282,90 @@ func (suite *ASToInternalTestSuite) TestParseBookwyrmStatus() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Equal("Review of \"Dracula\" (5 stars): A great read, not just for codifying vampire lore, but the way it's built from letters and diaries.", status.ContentWarning)
+	suite.Empty(status.ContentWarning)
+	suite.True(strings.HasPrefix(status.Content, `<h1>Review of &#34;Dracula&#34; (5 stars): A great read, not just for codifying vampire lore, but the way it&#39;s built from letters and diaries.</h1><p>The original novel is a great read.`))
 	suite.Len(status.Attachments, 1)
 }
 
+func (suite *ASToInternalTestSuite) TestParseMobilizonEvent() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + authorAccount.URI + `/events/01HRXK6T5B2Y7Q1Z6Y3Z5G9J8D",
+  "type": "Event",
+  "attributedTo": "` + authorAccount.URI + `",
+  "published": "2024-03-10T12:00:00Z",
+  "name": "Repair Café",
+  "content": "<p>Bring your broken things!</p>",
+  "startTime": "2024-03-16T14:00:00+01:00",
+  "endTime": "2024-03-16T17:00:00+01:00",
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ]
+}`
+
+	t := suite.jsonToType(raw)
+	asEvent, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), asEvent)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(status.ContentWarning)
+	suite.Equal(`<h1>Repair Café</h1><p>Starts: <time datetime="2024-03-16T13:00:00Z">Saturday, 16 March 2024 13:00 UTC</time><br>Ends: <time datetime="2024-03-16T16:00:00Z">Saturday, 16 March 2024 16:00 UTC</time></p><p>Bring your broken things!</p>`, status.Content)
+}
+
+func (suite *ASToInternalTestSuite) TestParseFunkwhaleAudio() {
+	authorAccount := suite.testAccounts["remote_account_1"]
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "` + authorAccount.URI + `/uploads/5e5a2c7b",
+  "type": "Audio",
+  "attributedTo": "` + authorAccount.URI + `",
+  "published": "2024-03-10T12:00:00Z",
+  "name": "Some Artist - Some Track",
+  "url": [
+    {
+      "type": "Link",
+      "mediaType": "text/html",
+      "href": "https://fossbros-anonymous.io/library/tracks/42"
+    },
+    {
+      "type": "Link",
+      "mediaType": "audio/ogg",
+      "href": "https://fossbros-anonymous.io/media/tracks/42.ogg"
+    }
+  ],
+  "to": [
+    "https://www.w3.org/ns/activitystreams#Public"
+  ]
+}`
+
+	t := suite.jsonToType(raw)
+	asAudio, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(context.Background(), asAudio)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(status.ContentWarning)
+	suite.Equal(`<p>Some Artist - Some Track</p>`, status.Content)
+	if suite.Len(status.Attachments, 1) {
+		suite.Equal("https://fossbros-anonymous.io/media/tracks/42.ogg", status.Attachments[0].RemoteURL)
+		suite.Equal("Some Artist - Some Track", status.Attachments[0].Description)
+	}
+}
+
 func (suite *ASToInternalTestSuite) TestParseFlag1() {
 	reportedAccount := suite.testAccounts["local_account_1"]
 	reportingAccount := suite.testAccounts["remote_account_1"]
@@ -20,11 +20,13 @@ package typeutils
 import (
 	"sync"
 
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 type Converter struct {
 	state          *state.State
+	filter         *visibility.Filter
 	defaultAvatars []string
 	randAvatars    sync.Map
 }
@@ -32,6 +34,7 @@ type Converter struct {
 func NewConverter(state *state.State) *Converter {
 	return &Converter{
 		state:          state,
+		filter:         visibility.NewFilter(state),
 		defaultAvatars: populateDefaultAvatars(),
 	}
 }
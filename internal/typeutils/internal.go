@@ -45,13 +45,20 @@ func (c *Converter) FollowRequestToFollow(
 }
 
 // StatusToBoost wraps the target status into a
-// boost wrapper status owned by the requester.
+// boost wrapper status owned by the requester,
+// with the given visibility. If visibility is
+// empty, the target status' visibility is used.
 func (c *Converter) StatusToBoost(
 	ctx context.Context,
 	target *gtsmodel.Status,
 	booster *gtsmodel.Account,
 	applicationID string,
+	visibility gtsmodel.Visibility,
 ) (*gtsmodel.Status, error) {
+	if visibility == "" {
+		visibility = target.Visibility
+	}
+
 	// The boost won't use the same IDs as the
 	// target so we need to generate new ones.
 	boostID := id.NewULID()
@@ -81,8 +88,9 @@ func (c *Converter) StatusToBoost(
 		MentionIDs:    []string{},
 		EmojiIDs:      []string{},
 
-		// Remaining fields all
-		// taken from boosted status.
+		// Remaining fields (except
+		// visibility) all taken
+		// from boosted status.
 		Content:             target.Content,
 		ContentWarning:      target.ContentWarning,
 		ActivityStreamsType: target.ActivityStreamsType,
@@ -93,7 +101,7 @@ func (c *Converter) StatusToBoost(
 		BoostOf:             target,
 		BoostOfAccountID:    target.AccountID,
 		BoostOfAccount:      target.Account,
-		Visibility:          target.Visibility,
+		Visibility:          visibility,
 		Federated:           util.Ptr(*target.Federated),
 		Boostable:           util.Ptr(*target.Boostable),
 		Replyable:           util.Ptr(*target.Replyable),
@@ -318,6 +318,14 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 			avatarURLProperty.AppendIRI(avatarURL)
 			iconImage.SetActivityStreamsUrl(avatarURLProperty)
 
+			if desc := a.AvatarMediaAttachment.Description; desc != "" {
+				// Set description as
+				// name, for alt text.
+				nameProp := streams.NewActivityStreamsNameProperty()
+				nameProp.AppendXMLSchemaString(desc)
+				iconImage.SetActivityStreamsName(nameProp)
+			}
+
 			iconProperty.AppendActivityStreamsImage(iconImage)
 			person.SetActivityStreamsIcon(iconProperty)
 		}
@@ -352,6 +360,14 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 			headerURLProperty.AppendIRI(headerURL)
 			headerImage.SetActivityStreamsUrl(headerURLProperty)
 
+			if desc := a.HeaderMediaAttachment.Description; desc != "" {
+				// Set description as
+				// name, for alt text.
+				nameProp := streams.NewActivityStreamsNameProperty()
+				nameProp.AppendXMLSchemaString(desc)
+				headerImage.SetActivityStreamsName(nameProp)
+			}
+
 			headerProperty.AppendActivityStreamsImage(headerImage)
 			person.SetActivityStreamsImage(headerProperty)
 		}
@@ -932,6 +948,63 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// RelayToASFollow converts a gts model relay into the AS Follow
+// of that relay by the instance actor. As per the de-facto relay
+// protocol, the object of the Follow is the public collection.
+func (c *Converter) RelayToASFollow(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsFollow, error) {
+	instanceAcct, err := c.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	actorURI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing instance account uri: %w", err)
+	}
+
+	followURI, err := url.Parse(r.FollowURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing relay follow uri: %w", err)
+	}
+
+	publicURI, err := url.Parse(pub.PublicActivityPubIRI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", pub.PublicActivityPubIRI, err)
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+	ap.SetJSONLDId(follow, followURI)
+	ap.AppendActorIRIs(follow, actorURI)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(publicURI)
+	follow.SetActivityStreamsObject(objectProp)
+
+	return follow, nil
+}
+
+// RelayToASUndoFollow converts a gts model relay into
+// an AS Undo of the Follow of that relay by the instance actor.
+func (c *Converter) RelayToASUndoFollow(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsUndo, error) {
+	follow, err := c.RelayToASFollow(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	undo := streams.NewActivityStreamsUndo()
+
+	// Same actor as the Follow.
+	undo.SetActivityStreamsActor(follow.GetActivityStreamsActor())
+
+	// Relays expect the whole Follow
+	// object, not just its URI.
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsFollow(follow)
+	undo.SetActivityStreamsObject(objectProp)
+
+	return undo, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
@@ -1235,8 +1308,8 @@ func (c *Converter) BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.
 	}
 	ccProp.AppendIRI(boostedAccountURI)
 
-	// maybe CC it to public depending on the boosted status visibility
-	switch boostWrapperStatus.BoostOf.Visibility {
+	// maybe CC it to public depending on the boost visibility
+	switch boostWrapperStatus.Visibility {
 	case gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked:
 		publicURI, err := url.Parse(pub.PublicActivityPubIRI)
 		if err != nil {
@@ -1453,146 +1526,6 @@ func (c *Converter) StatusURIsToASRepliesPage(ctx context.Context, status *gtsmo
 	return page, nil
 }
 
-// StatusesToASOutboxPage returns an ordered collection page using the given statuses and parameters as contents.
-//
-// The maxID and minID should be the parameters that were passed to the database to obtain the given statuses.
-// These will be used to create the 'id' field of the collection.
-//
-// OutboxID is used to create the 'partOf' field in the collection.
-//
-// Appropriate 'next' and 'prev' fields will be created based on the highest and lowest IDs present in the statuses slice.
-// the goal is to end up with something like this:
-//
-//	{
-//		"id": "https://example.org/users/whatever/outbox?page=true",
-//		"type": "OrderedCollectionPage",
-//		"next": "https://example.org/users/whatever/outbox?max_id=01FJC1Q0E3SSQR59TD2M1KP4V8&page=true",
-//		"prev": "https://example.org/users/whatever/outbox?min_id=01FJC1Q0E3SSQR59TD2M1KP4V8&page=true",
-//		"partOf": "https://example.org/users/whatever/outbox",
-//		"orderedItems": [
-//			"id": "https://example.org/users/whatever/statuses/01FJC1MKPVX2VMWP2ST93Q90K7/activity",
-//			"type": "Create",
-//			"actor": "https://example.org/users/whatever",
-//			"published": "2021-10-18T20:06:18Z",
-//			"to": [
-//				"https://www.w3.org/ns/activitystreams#Public"
-//			],
-//			"cc": [
-//				"https://example.org/users/whatever/followers"
-//			],
-//			"object": "https://example.org/users/whatever/statuses/01FJC1MKPVX2VMWP2ST93Q90K7"
-//		]
-//	}
-func (c *Converter) StatusesToASOutboxPage(ctx context.Context, outboxID string, maxID string, minID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollectionPage, error) {
-	page := streams.NewActivityStreamsOrderedCollectionPage()
-
-	// .id
-	pageIDProp := streams.NewJSONLDIdProperty()
-	pageID := fmt.Sprintf("%s?page=true", outboxID)
-	if minID != "" {
-		pageID = fmt.Sprintf("%s&minID=%s", pageID, minID)
-	}
-	if maxID != "" {
-		pageID = fmt.Sprintf("%s&maxID=%s", pageID, maxID)
-	}
-	pageIDURI, err := url.Parse(pageID)
-	if err != nil {
-		return nil, err
-	}
-	pageIDProp.SetIRI(pageIDURI)
-	page.SetJSONLDId(pageIDProp)
-
-	// .partOf
-	collectionIDURI, err := url.Parse(outboxID)
-	if err != nil {
-		return nil, err
-	}
-	partOfProp := streams.NewActivityStreamsPartOfProperty()
-	partOfProp.SetIRI(collectionIDURI)
-	page.SetActivityStreamsPartOf(partOfProp)
-
-	// .orderedItems
-	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
-	var highest string
-	var lowest string
-	for _, s := range statuses {
-		note, err := c.StatusToAS(ctx, s)
-		if err != nil {
-			return nil, err
-		}
-
-		activity := WrapStatusableInCreate(note, true)
-		itemsProp.AppendActivityStreamsCreate(activity)
-
-		if highest == "" || s.ID > highest {
-			highest = s.ID
-		}
-		if lowest == "" || s.ID < lowest {
-			lowest = s.ID
-		}
-	}
-	page.SetActivityStreamsOrderedItems(itemsProp)
-
-	// .next
-	if lowest != "" {
-		nextProp := streams.NewActivityStreamsNextProperty()
-		nextPropIDString := fmt.Sprintf("%s?page=true&max_id=%s", outboxID, lowest)
-		nextPropIDURI, err := url.Parse(nextPropIDString)
-		if err != nil {
-			return nil, err
-		}
-		nextProp.SetIRI(nextPropIDURI)
-		page.SetActivityStreamsNext(nextProp)
-	}
-
-	// .prev
-	if highest != "" {
-		prevProp := streams.NewActivityStreamsPrevProperty()
-		prevPropIDString := fmt.Sprintf("%s?page=true&min_id=%s", outboxID, highest)
-		prevPropIDURI, err := url.Parse(prevPropIDString)
-		if err != nil {
-			return nil, err
-		}
-		prevProp.SetIRI(prevPropIDURI)
-		page.SetActivityStreamsPrev(prevProp)
-	}
-
-	return page, nil
-}
-
-// OutboxToASCollection returns an ordered collection with appropriate id, next, and last fields.
-// The returned collection won't have any actual entries; just links to where entries can be obtained.
-// we want something that looks like this:
-//
-//	{
-//		"@context": "https://www.w3.org/ns/activitystreams",
-//		"id": "https://example.org/users/whatever/outbox",
-//		"type": "OrderedCollection",
-//		"first": "https://example.org/users/whatever/outbox?page=true"
-//	}
-func (c *Converter) OutboxToASCollection(ctx context.Context, outboxID string) (vocab.ActivityStreamsOrderedCollection, error) {
-	collection := streams.NewActivityStreamsOrderedCollection()
-
-	collectionIDProp := streams.NewJSONLDIdProperty()
-	outboxIDURI, err := url.Parse(outboxID)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing url %s", outboxID)
-	}
-	collectionIDProp.SetIRI(outboxIDURI)
-	collection.SetJSONLDId(collectionIDProp)
-
-	collectionFirstProp := streams.NewActivityStreamsFirstProperty()
-	collectionFirstPropID := fmt.Sprintf("%s?page=true", outboxID)
-	collectionFirstPropIDURI, err := url.Parse(collectionFirstPropID)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing url %s", collectionFirstPropID)
-	}
-	collectionFirstProp.SetIRI(collectionFirstPropIDURI)
-	collection.SetActivityStreamsFirst(collectionFirstProp)
-
-	return collection, nil
-}
-
 // StatusesToASFeaturedCollection converts a slice of statuses into an ordered collection
 // of URIs, suitable for serializing and serving via the activitypub API.
 func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featuredCollectionID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error) {
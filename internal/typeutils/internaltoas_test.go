@@ -59,12 +59,14 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
     "mediaType": "image/jpeg",
+    "name": "a green goblin looking nasty",
     "type": "Image",
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg"
   },
   "id": "http://localhost:8080/users/the_mighty_zork",
   "image": {
     "mediaType": "image/jpeg",
+    "name": "A very old-school screenshot of the original team fortress mod for quake ",
     "type": "Image",
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
@@ -177,12 +179,14 @@ func (suite *InternalToASTestSuite) TestAccountToASAliasedAndMoved() {
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
     "mediaType": "image/jpeg",
+    "name": "a green goblin looking nasty",
     "type": "Image",
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg"
   },
   "id": "http://localhost:8080/users/the_mighty_zork",
   "image": {
     "mediaType": "image/jpeg",
+    "name": "A very old-school screenshot of the original team fortress mod for quake ",
     "type": "Image",
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
@@ -276,12 +280,14 @@ func (suite *InternalToASTestSuite) TestAccountToASWithEmoji() {
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
     "mediaType": "image/jpeg",
+    "name": "a green goblin looking nasty",
     "type": "Image",
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg"
   },
   "id": "http://localhost:8080/users/the_mighty_zork",
   "image": {
     "mediaType": "image/jpeg",
+    "name": "A very old-school screenshot of the original team fortress mod for quake ",
     "type": "Image",
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
@@ -340,12 +346,14 @@ func (suite *InternalToASTestSuite) TestAccountToASWithSharedInbox() {
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
     "mediaType": "image/jpeg",
+    "name": "a green goblin looking nasty",
     "type": "Image",
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg"
   },
   "id": "http://localhost:8080/users/the_mighty_zork",
   "image": {
     "mediaType": "image/jpeg",
+    "name": "A very old-school screenshot of the original team fortress mod for quake ",
     "type": "Image",
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
@@ -366,27 +374,6 @@ func (suite *InternalToASTestSuite) TestAccountToASWithSharedInbox() {
 }`, trimmed)
 }
 
-func (suite *InternalToASTestSuite) TestOutboxToASCollection() {
-	testAccount := suite.testAccounts["admin_account"]
-	ctx := context.Background()
-
-	collection, err := suite.typeconverter.OutboxToASCollection(ctx, testAccount.OutboxURI)
-	suite.NoError(err)
-
-	ser, err := ap.Serialize(collection)
-	suite.NoError(err)
-
-	bytes, err := json.MarshalIndent(ser, "", "  ")
-	suite.NoError(err)
-
-	suite.Equal(`{
-  "@context": "https://www.w3.org/ns/activitystreams",
-  "first": "http://localhost:8080/users/admin/outbox?page=true",
-  "id": "http://localhost:8080/users/admin/outbox",
-  "type": "OrderedCollection"
-}`, string(bytes))
-}
-
 func (suite *InternalToASTestSuite) TestStatusToAS() {
 	testStatus := suite.testStatuses["local_account_1_status_1"]
 	ctx := context.Background()
@@ -737,60 +724,13 @@ func (suite *InternalToASTestSuite) TestStatusToASDeleteDirectMessage() {
 }`, string(bytes))
 }
 
-func (suite *InternalToASTestSuite) TestStatusesToASOutboxPage() {
-	testAccount := suite.testAccounts["admin_account"]
-	ctx := context.Background()
-
-	// get public statuses from testaccount
-	statuses, err := suite.db.GetAccountStatuses(ctx, testAccount.ID, 30, true, true, "", "", false, true)
-	suite.NoError(err)
-
-	page, err := suite.typeconverter.StatusesToASOutboxPage(ctx, testAccount.OutboxURI, "", "", statuses)
-	suite.NoError(err)
-
-	ser, err := ap.Serialize(page)
-	suite.NoError(err)
-
-	bytes, err := json.MarshalIndent(ser, "", "  ")
-	suite.NoError(err)
-
-	suite.Equal(`{
-  "@context": "https://www.w3.org/ns/activitystreams",
-  "id": "http://localhost:8080/users/admin/outbox?page=true",
-  "next": "http://localhost:8080/users/admin/outbox?page=true\u0026max_id=01F8MH75CBF9JFX4ZAD54N0W0R",
-  "orderedItems": [
-    {
-      "actor": "http://localhost:8080/users/admin",
-      "cc": "http://localhost:8080/users/admin/followers",
-      "id": "http://localhost:8080/users/admin/statuses/01F8MHAAY43M6RJ473VQFCVH37/activity#Create",
-      "object": "http://localhost:8080/users/admin/statuses/01F8MHAAY43M6RJ473VQFCVH37",
-      "published": "2021-10-20T12:36:45Z",
-      "to": "https://www.w3.org/ns/activitystreams#Public",
-      "type": "Create"
-    },
-    {
-      "actor": "http://localhost:8080/users/admin",
-      "cc": "http://localhost:8080/users/admin/followers",
-      "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/activity#Create",
-      "object": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
-      "published": "2021-10-20T11:36:45Z",
-      "to": "https://www.w3.org/ns/activitystreams#Public",
-      "type": "Create"
-    }
-  ],
-  "partOf": "http://localhost:8080/users/admin/outbox",
-  "prev": "http://localhost:8080/users/admin/outbox?page=true\u0026min_id=01F8MHAAY43M6RJ473VQFCVH37",
-  "type": "OrderedCollectionPage"
-}`, string(bytes))
-}
-
 func (suite *InternalToASTestSuite) TestSelfBoostFollowersOnlyToAS() {
 	ctx := context.Background()
 
 	testStatus := suite.testStatuses["local_account_1_status_5"]
 	testAccount := suite.testAccounts["local_account_1"]
 
-	boostWrapperStatus, err := suite.typeconverter.StatusToBoost(ctx, testStatus, testAccount, "")
+	boostWrapperStatus, err := suite.typeconverter.StatusToBoost(ctx, testStatus, testAccount, "", "")
 	suite.NoError(err)
 	suite.NotNil(boostWrapperStatus)
 
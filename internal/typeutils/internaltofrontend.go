@@ -18,14 +18,20 @@
 package typeutils
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -33,15 +39,16 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 const (
 	instanceStatusesCharactersReservedPerURL    = 25
-	instanceMediaAttachmentsImageMatrixLimit    = 16777216 // width * height
-	instanceMediaAttachmentsVideoMatrixLimit    = 16777216 // width * height
-	instanceMediaAttachmentsVideoFrameRateLimit = 60
+	instanceMediaAttachmentsImageMatrixLimit    = 16777216               // width * height
+	instanceMediaAttachmentsVideoMatrixLimit    = media.VideoMatrixLimit // width * height
+	instanceMediaAttachmentsVideoFrameRateLimit = media.VideoFrameRateLimit
 	instancePollsMinExpiration                  = 300     // seconds
 	instancePollsMaxExpiration                  = 2629746 // seconds
 	instanceAccountsMaxFeaturedTags             = 10
@@ -50,10 +57,7 @@ const (
 	instanceMastodonVersion                     = "3.5.3"
 )
 
-var instanceStatusesSupportedMimeTypes = []string{
-	string(apimodel.StatusContentTypePlain),
-	string(apimodel.StatusContentTypeMarkdown),
-}
+var instanceStatusesSupportedMimeTypes = apimodel.StatusContentTypesSupported
 
 func toMastodonVersion(in string) string {
 	return instanceMastodonVersion + "+" + strings.ReplaceAll(in, " ", "-")
@@ -82,15 +86,36 @@ func (c *Converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		statusContentType = a.StatusContentType
 	}
 
+	expandMedia := apimodel.ExpandMediaDefault
+	if a.ExpandMedia != "" {
+		expandMedia = a.ExpandMedia
+	}
+
 	apiAccount.Source = &apimodel.Source{
-		Privacy:             c.VisToAPIVis(ctx, a.Privacy),
-		Sensitive:           *a.Sensitive,
-		Language:            a.Language,
-		StatusContentType:   statusContentType,
-		Note:                a.NoteRaw,
-		Fields:              c.fieldsToAPIFields(a.FieldsRaw),
-		FollowRequestsCount: frc,
-		AlsoKnownAsURIs:     a.AlsoKnownAsURIs,
+		Privacy:                            c.VisToAPIVis(ctx, a.Privacy),
+		Sensitive:                          *a.Sensitive,
+		Language:                           a.Language,
+		StatusContentType:                  statusContentType,
+		Note:                               a.NoteRaw,
+		Fields:                             c.fieldsToAPIFields(a.FieldsRaw),
+		FollowRequestsCount:                frc,
+		AlsoKnownAsURIs:                    a.AlsoKnownAsURIs,
+		ExpandSpoilers:                     util.PtrValueOr(a.ExpandSpoilers, false),
+		ExpandMedia:                        expandMedia,
+		DisableAnimation:                   util.PtrValueOr(a.DisableAnimation, false),
+		BoostVisibility:                    c.VisToAPIVis(ctx, a.BoostVisibility),
+		HideCollections:                    util.PtrValueOr(a.HideCollections, false),
+		SuppressFollowRequestNotifications: util.PtrValueOr(a.SuppressFollowRequestNotifications, false),
+		SuppressStatusUpdateNotifications:  util.PtrValueOr(a.SuppressStatusUpdateNotifications, false),
+		AutoAcceptFollowedBack:             util.PtrValueOr(a.AutoAcceptFollowedBack, false),
+		AutoAcceptLocal:                    util.PtrValueOr(a.AutoAcceptLocal, false),
+		AutoAcceptOlderThanDays:            a.AutoAcceptOlderThanDays,
+		StatusesAutoDeleteAfterDays:        a.StatusesAutoDeleteAfterDays,
+		StatusesAutoDeleteKeepPinned:       util.PtrValueOr(a.StatusesAutoDeleteKeepPinned, true),
+		StatusesAutoDeleteKeepBookmarked:   util.PtrValueOr(a.StatusesAutoDeleteKeepBookmarked, true),
+		StatusesAutoDeleteKeepDirect:       util.PtrValueOr(a.StatusesAutoDeleteKeepDirect, true),
+		RSSIncludeSensitive:                util.PtrValueOr(a.RSSIncludeSensitive, false),
+		StatusesMaxChars:                   a.StatusesMaxChars,
 	}
 
 	return apiAccount, nil
@@ -110,29 +135,20 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	//   - Statuses count
 	//   - Last status time
 
-	followersCount, err := c.state.DB.CountAccountFollowers(ctx, a.ID)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return nil, gtserror.Newf("error counting followers: %w", err)
-	}
-
-	followingCount, err := c.state.DB.CountAccountFollows(ctx, a.ID)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return nil, gtserror.Newf("error counting following: %w", err)
+	stats, err := c.state.DB.GetAccountStats(ctx, a.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting account stats: %w", err)
 	}
 
-	statusesCount, err := c.state.DB.CountAccountStatuses(ctx, a.ID)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return nil, gtserror.Newf("error counting statuses: %w", err)
-	}
+	// Stats are updated incrementally, so guard
+	// against any drift into negative counts.
+	followersCount := max(stats.FollowersCount, 0)
+	followingCount := max(stats.FollowingCount, 0)
+	statusesCount := max(stats.StatusesCount, 0)
 
 	var lastStatusAt *string
-	lastPosted, err := c.state.DB.GetAccountLastPosted(ctx, a.ID, false)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return nil, gtserror.Newf("error getting last posted: %w", err)
-	}
-
-	if !lastPosted.IsZero() {
-		lastStatusAt = util.Ptr(util.FormatISO8601(lastPosted))
+	if !stats.LastStatusAt.IsZero() {
+		lastStatusAt = util.Ptr(util.FormatISO8601(stats.LastStatusAt))
 	}
 
 	// Profile media + nice extras:
@@ -144,18 +160,22 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	var (
 		aviURL          string
 		aviURLStatic    string
+		aviDesc         string
 		headerURL       string
 		headerURLStatic string
+		headerDesc      string
 	)
 
 	if a.AvatarMediaAttachment != nil {
-		aviURL = a.AvatarMediaAttachment.URL
 		aviURLStatic = a.AvatarMediaAttachment.Thumbnail.URL
+		aviURL = staticOr(ctx, a.AvatarMediaAttachment.URL, aviURLStatic)
+		aviDesc = a.AvatarMediaAttachment.Description
 	}
 
 	if a.HeaderMediaAttachment != nil {
-		headerURL = a.HeaderMediaAttachment.URL
 		headerURLStatic = a.HeaderMediaAttachment.Thumbnail.URL
+		headerURL = staticOr(ctx, a.HeaderMediaAttachment.URL, headerURLStatic)
+		headerDesc = a.HeaderMediaAttachment.Description
 	}
 
 	// convert account gts model fields to front api model fields
@@ -246,31 +266,34 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	// can be populated directly below.
 
 	accountFrontend := &apimodel.Account{
-		ID:             a.ID,
-		Username:       a.Username,
-		Acct:           acct,
-		DisplayName:    a.DisplayName,
-		Locked:         locked,
-		Discoverable:   discoverable,
-		Bot:            bot,
-		CreatedAt:      util.FormatISO8601(a.CreatedAt),
-		Note:           a.Note,
-		URL:            a.URL,
-		Avatar:         aviURL,
-		AvatarStatic:   aviURLStatic,
-		Header:         headerURL,
-		HeaderStatic:   headerURLStatic,
-		FollowersCount: followersCount,
-		FollowingCount: followingCount,
-		StatusesCount:  statusesCount,
-		LastStatusAt:   lastStatusAt,
-		Emojis:         apiEmojis,
-		Fields:         fields,
-		Suspended:      !a.SuspendedAt.IsZero(),
-		CustomCSS:      a.CustomCSS,
-		EnableRSS:      enableRSS,
-		Role:           role,
-		Moved:          moved,
+		ID:                a.ID,
+		Username:          a.Username,
+		Acct:              acct,
+		DisplayName:       a.DisplayName,
+		Locked:            locked,
+		Discoverable:      discoverable,
+		Bot:               bot,
+		CreatedAt:         util.FormatISO8601(a.CreatedAt),
+		Note:              a.Note,
+		URL:               a.URL,
+		Avatar:            aviURL,
+		AvatarStatic:      aviURLStatic,
+		AvatarDescription: aviDesc,
+		Header:            headerURL,
+		HeaderStatic:      headerURLStatic,
+		HeaderDescription: headerDesc,
+		FollowersCount:    followersCount,
+		FollowingCount:    followingCount,
+		StatusesCount:     statusesCount,
+		LastStatusAt:      lastStatusAt,
+		Emojis:            apiEmojis,
+		Fields:            fields,
+		Suspended:         !a.SuspendedAt.IsZero(),
+		CustomCSS:         a.CustomCSS,
+		EnableRSS:         enableRSS,
+		Theme:             a.Theme,
+		Role:              role,
+		Moved:             moved,
 	}
 
 	// Bodge default avatar + header in,
@@ -300,6 +323,31 @@ func (c *Converter) fieldsToAPIFields(f []*gtsmodel.Field) []apimodel.Field {
 	return fields
 }
 
+// FollowRequestToAPIAccount takes a db model follow request, and returns the
+// public representation of the requesting account, wrapped with the time
+// the request was made and the note that came with it, if any.
+func (c *Converter) FollowRequestToAPIAccount(ctx context.Context, fr *gtsmodel.FollowRequest) (*apimodel.FollowRequestAccount, error) {
+	if fr.Account == nil {
+		// Ensure requesting account is set.
+		account, err := c.state.DB.GetAccountByID(ctx, fr.AccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting account %s: %w", fr.AccountID, err)
+		}
+		fr.Account = account
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, fr.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account %s: %w", fr.AccountID, err)
+	}
+
+	return &apimodel.FollowRequestAccount{
+		Account:           apiAccount,
+		FollowRequestedAt: util.FormatISO8601(fr.CreatedAt),
+		FollowRequestNote: fr.Note,
+	}, nil
+}
+
 // AccountToAPIAccountBlocked takes a db model account as a param, and returns a apitype account, or an error if
 // something goes wrong. The returned account will be a bare minimum representation of the account. This function should be used
 // when someone wants to view an account they've blocked.
@@ -374,6 +422,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = apimodel.AccountRole{Name: apimodel.AccountRoleUser} // assume user by default
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if a.IsRemote() {
@@ -399,7 +448,9 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 			email = user.UnconfirmedEmail
 		}
 
-		if i := user.CurrentSignInIP.String(); i != "<nil>" {
+		// Respect accounts-ip-retention for any IPs
+		// stored before it was changed to be stricter.
+		if i := util.RetainIP(user.CurrentSignInIP).String(); i != "<nil>" {
 			ip = &i
 		}
 
@@ -418,6 +469,17 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			invite, err := c.state.DB.GetInviteByID(ctx, user.InviteID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, gtserror.Newf("db error getting invite %s: %w", user.InviteID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.CreatedByAccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -443,7 +505,8 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
+		StatusesMaxChars:       a.StatusesMaxChars,
 	}, nil
 }
 
@@ -468,6 +531,39 @@ func (c *Converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 	}, nil
 }
 
+// TokenToAPITokenInfo converts a gts model oauth token into its api
+// representation, without including the secret token values themselves.
+func (c *Converter) TokenToAPITokenInfo(ctx context.Context, t *gtsmodel.Token) (*apimodel.TokenInfo, error) {
+	createdAt := t.AccessCreateAt
+	if createdAt.IsZero() {
+		createdAt = t.CreatedAt
+	}
+
+	info := &apimodel.TokenInfo{
+		ID:        t.ID,
+		CreatedAt: util.FormatISO8601(createdAt),
+		Scope:     t.Scope,
+	}
+
+	if !t.LastUsedAt.IsZero() {
+		info.LastUsed = util.FormatISO8601(t.LastUsedAt)
+	}
+
+	app, err := c.state.DB.GetApplicationByClientID(ctx, t.ClientID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting application for token %s: %w", t.ID, err)
+	}
+
+	if app != nil {
+		info.Application, err = c.AppToAPIAppPublic(ctx, app)
+		if err != nil {
+			return nil, gtserror.Newf("error converting application for token %s: %w", t.ID, err)
+		}
+	}
+
+	return info, nil
+}
+
 // AttachmentToAPIAttachment converts a gts model media attacahment into its api representation for serialization on the API.
 func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.MediaAttachment) (apimodel.Attachment, error) {
 	apiAttachment := apimodel.Attachment{
@@ -528,7 +624,7 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 			Y: a.FileMeta.Focus.Y,
 		}
 
-	case gtsmodel.FileTypeVideo:
+	case gtsmodel.FileTypeVideo, gtsmodel.FileTypeGifv:
 		if i := a.FileMeta.Original.Duration; i != nil {
 			apiAttachment.Meta.Original.Duration = *i
 		}
@@ -598,7 +694,7 @@ func (c *Converter) EmojiToAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (api
 
 	return apimodel.Emoji{
 		Shortcode:       e.Shortcode,
-		URL:             e.ImageURL,
+		URL:             staticOr(ctx, e.ImageURL, e.ImageStaticURL),
 		StaticURL:       e.ImageStaticURL,
 		VisibleInPicker: *e.VisibleInPicker,
 		Category:        category,
@@ -644,19 +740,54 @@ func (c *Converter) EmojiCategoryToAPIEmojiCategory(ctx context.Context, categor
 }
 
 // TagToAPITag converts a gts model tag into its api (frontend) representation for serialization on the API.
-// If stubHistory is set to 'true', then the 'history' field of the tag will be populated with a pointer to an empty slice, for API compatibility reasons.
-func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistory bool) (apimodel.Tag, error) {
-	return apimodel.Tag{
+// If withHistory is set to 'true', then the 'history' field of the tag will be populated with per-day usage
+// counts of the tag over the past week. If trends are disabled, this will always be zero usage.
+func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, withHistory bool) (apimodel.Tag, error) {
+	apiTag := apimodel.Tag{
 		Name: strings.ToLower(t.Name),
 		URL:  uris.URIForTag(t.Name),
-		History: func() *[]any {
-			if !stubHistory {
-				return nil
-			}
+	}
+
+	if !withHistory {
+		return apiTag, nil
+	}
+
+	var (
+		now   = time.Now()
+		uses  []*gtsmodel.TagUse
+		since = HistoryStart(now)
+		err   error
+	)
+
+	if config.GetInstanceTrendsEnabled() {
+		// Tag uses are only tracked with trends enabled.
+		uses, err = c.state.DB.GetTagUsesForTagSince(ctx, t.ID, since)
+		if err != nil {
+			return apimodel.Tag{}, gtserror.Newf("db error getting tag uses: %w", err)
+		}
+	}
+
+	history := UsesToAPIHistory(now, uses, func(use *gtsmodel.TagUse) (time.Time, string) {
+		return use.CreatedAt, use.AccountID
+	})
+	apiTag.History = &history
 
-			h := make([]any, 0)
-			return &h
-		}(),
+	return apiTag, nil
+}
+
+// TagToAPIAdminTag converts a gts model tag into its admin api
+// representation, including usage history of the past week.
+func (c *Converter) TagToAPIAdminTag(ctx context.Context, t *gtsmodel.Tag) (*apimodel.AdminTag, error) {
+	apiTag, err := c.TagToAPITag(ctx, t, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apimodel.AdminTag{
+		Tag:       apiTag,
+		ID:        t.ID,
+		Trendable: *t.Trendable,
+		Usable:    *t.Useable,
 	}, nil
 }
 
@@ -669,7 +800,52 @@ func (c *Converter) StatusToAPIStatus(
 	s *gtsmodel.Status,
 	requestingAccount *gtsmodel.Account,
 ) (*apimodel.Status, error) {
-	apiStatus, err := c.statusToFrontend(ctx, s, requestingAccount)
+	return c.statusToAPIStatus(ctx, s, requestingAccount, nil)
+}
+
+// StatusesToAPIStatuses is like StatusToAPIStatus, but
+// converts a slice of statuses at once, prefetching any
+// counts and interactions needed in a handful of queries.
+// Statuses which fail to convert are logged and omitted.
+//
+// Requesting account can be nil.
+func (c *Converter) StatusesToAPIStatuses(
+	ctx context.Context,
+	statuses []*gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) []*apimodel.Status {
+	return c.statusesToFrontend(ctx, statuses, requestingAccount, c.statusToAPIStatus)
+}
+
+// statusToAPIStatus is like StatusToAPIStatus, but
+// uses the given (optional) batch of prefetched data.
+func (c *Converter) statusToAPIStatus(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	batch *statusBatch,
+) (*apimodel.Status, error) {
+	apiStatus, err := c.statusToAPI(ctx, s, requestingAccount, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.setStatusQuote(ctx, s, requestingAccount, apiStatus, batch, c.statusToAPI); err != nil {
+		return nil, err
+	}
+
+	return apiStatus, nil
+}
+
+// statusToAPI converts a gts model status into its api
+// representation, without populating any quoted status.
+func (c *Converter) statusToAPI(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	batch *statusBatch,
+) (*apimodel.Status, error) {
+	apiStatus, err := c.statusToFrontend(ctx, s, requestingAccount, batch)
 	if err != nil {
 		return nil, err
 	}
@@ -693,7 +869,52 @@ func (c *Converter) StatusToWebStatus(
 	s *gtsmodel.Status,
 	requestingAccount *gtsmodel.Account,
 ) (*apimodel.Status, error) {
-	webStatus, err := c.statusToFrontend(ctx, s, requestingAccount)
+	return c.statusToWebStatus(ctx, s, requestingAccount, nil)
+}
+
+// StatusesToWebStatuses is like StatusToWebStatus, but
+// converts a slice of statuses at once, prefetching any
+// counts and interactions needed in a handful of queries.
+// Statuses which fail to convert are logged and omitted.
+//
+// Requesting account can be nil.
+func (c *Converter) StatusesToWebStatuses(
+	ctx context.Context,
+	statuses []*gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) []*apimodel.Status {
+	return c.statusesToFrontend(ctx, statuses, requestingAccount, c.statusToWebStatus)
+}
+
+// statusToWebStatus is like StatusToWebStatus, but
+// uses the given (optional) batch of prefetched data.
+func (c *Converter) statusToWebStatus(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	batch *statusBatch,
+) (*apimodel.Status, error) {
+	webStatus, err := c.statusToWeb(ctx, s, requestingAccount, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.setStatusQuote(ctx, s, requestingAccount, webStatus, batch, c.statusToWeb); err != nil {
+		return nil, err
+	}
+
+	return webStatus, nil
+}
+
+// statusToWeb converts a gts model status into its web
+// representation, without populating any quoted status.
+func (c *Converter) statusToWeb(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	batch *statusBatch,
+) (*apimodel.Status, error) {
+	webStatus, err := c.statusToFrontend(ctx, s, requestingAccount, batch)
 	if err != nil {
 		return nil, err
 	}
@@ -759,12 +980,24 @@ func (c *Converter) StatusToWebStatus(
 		}
 
 		webStatus.WebPollOptions = webPollOptions
-	}
 
-	// Set additional templating
-	// variables on media attachments.
-	for _, a := range webStatus.MediaAttachments {
-		a.Sensitive = webStatus.Sensitive
+		// Poll may have expired without
+		// having been marked closed yet.
+		expired := poll.Expired || s.Poll.Expired()
+
+		// Voters are only counted separately for
+		// multiple-choice polls, for single-choice
+		// polls each voter has exactly one vote.
+		votersCount := totalVotes
+		if poll.VotersCount != nil {
+			votersCount = *poll.VotersCount
+		}
+
+		webStatus.WebPoll = &apimodel.WebPoll{
+			Expired:     expired,
+			ExpiryStr:   pollExpiryStr(s.Poll.ExpiresAt, expired),
+			VotersCount: votersCount,
+		}
 	}
 
 	webStatus.Local = *s.Local
@@ -772,14 +1005,90 @@ func (c *Converter) StatusToWebStatus(
 	return webStatus, nil
 }
 
+// setStatusQuote sets the quoted status (if any) of s on
+// the given frontend status, using convert to convert the
+// quoted status. If the quoted status could not be fetched,
+// or is not visible to the requesting account, the quote is
+// instead marked as unavailable.
+func (c *Converter) setStatusQuote(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	frontendStatus *apimodel.Status,
+	batch *statusBatch,
+	convert func(context.Context, *gtsmodel.Status, *gtsmodel.Account, *statusBatch) (*apimodel.Status, error),
+) error {
+	if s.QuoteOfURI == "" {
+		// Not a quote.
+		return nil
+	}
+
+	if s.QuoteOf == nil {
+		// Quoted status not (yet)
+		// dereferenced, or deleted.
+		frontendStatus.QuoteUnavailable = true
+		return nil
+	}
+
+	visible, err := c.filter.StatusVisible(ctx, requestingAccount, s.QuoteOf)
+	if err != nil {
+		return gtserror.Newf("error checking quoted status visibility: %w", err)
+	}
+
+	if !visible {
+		// Don't leak quoted status
+		// to those who can't see it.
+		frontendStatus.QuoteUnavailable = true
+		return nil
+	}
+
+	frontendStatus.Quote, err = convert(ctx, s.QuoteOf, requestingAccount, batch)
+	if err != nil {
+		return gtserror.Newf("error converting quoted status: %w", err)
+	}
+
+	return nil
+}
+
+// statusesToFrontend converts the given statuses using convert,
+// with a batch of counts and interactions prefetched for them.
+// Statuses which fail to convert are logged and omitted.
+func (c *Converter) statusesToFrontend(
+	ctx context.Context,
+	statuses []*gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+	convert func(context.Context, *gtsmodel.Status, *gtsmodel.Account, *statusBatch) (*apimodel.Status, error),
+) []*apimodel.Status {
+	batch, err := c.statusBatchFor(ctx, statuses, requestingAccount)
+	if err != nil {
+		// Not fatal, just fall
+		// back to per-status queries.
+		log.Errorf(ctx, "error prefetching status batch: %v", err)
+	}
+
+	frontendStatuses := make([]*apimodel.Status, 0, len(statuses))
+	for _, s := range statuses {
+		frontendStatus, err := convert(ctx, s, requestingAccount, batch)
+		if err != nil {
+			log.Errorf(ctx, "error converting status %s: %v", s.ID, err)
+			continue
+		}
+		frontendStatuses = append(frontendStatuses, frontendStatus)
+	}
+
+	return frontendStatuses
+}
+
 // statusToFrontend is a package internal function for
 // parsing a status into its initial frontend representation.
+// Batch of prefetched counts and interactions can be nil.
 //
 // Requesting account can be nil.
 func (c *Converter) statusToFrontend(
 	ctx context.Context,
 	s *gtsmodel.Status,
 	requestingAccount *gtsmodel.Account,
+	batch *statusBatch,
 ) (*apimodel.Status, error) {
 	// Try to populate status struct pointer fields.
 	// We can continue in many cases of partial failure,
@@ -798,27 +1107,42 @@ func (c *Converter) statusToFrontend(
 		log.Errorf(ctx, "error(s) populating status, will continue: %v", err)
 	}
 
-	apiAuthorAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	// Get the viewer-independent part of
+	// the status, converting it if necessary.
+	core, err := c.statusCoreToFrontend(ctx, s, batch)
 	if err != nil {
-		return nil, gtserror.Newf("error converting status author: %w", err)
+		return nil, err
 	}
 
-	repliesCount, err := c.state.DB.CountStatusReplies(ctx, s.ID)
+	// Take a copy of the (possibly cached) core,
+	// so that it's safe to overlay viewer-specific
+	// fields onto, and for callers to modify.
+	apiStatus := copyStatusCore(core)
+
+	// Author account, application and emojis may
+	// change independently of the status, so these
+	// are kept out of the cached core and set here.
+	apiStatus.Account, err = c.AccountToAPIAccountPublic(ctx, s.Account)
 	if err != nil {
-		return nil, gtserror.Newf("error counting replies: %w", err)
+		return nil, gtserror.Newf("error converting status author: %w", err)
 	}
 
-	reblogsCount, err := c.state.DB.CountStatusBoosts(ctx, s.ID)
-	if err != nil {
-		return nil, gtserror.Newf("error counting reblogs: %w", err)
+	if app := s.CreatedWithApplication; app != nil {
+		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
+		if err != nil {
+			return nil, gtserror.Newf(
+				"error converting application %s: %w",
+				s.CreatedWithApplicationID, err,
+			)
+		}
 	}
 
-	favesCount, err := c.state.DB.CountStatusFaves(ctx, s.ID)
+	apiStatus.Emojis, err = c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
 	if err != nil {
-		return nil, gtserror.Newf("error counting faves: %w", err)
+		log.Errorf(ctx, "error converting status emojis: %v", err)
 	}
 
-	interacts, err := c.interactionsWithStatusForAccount(ctx, s, requestingAccount)
+	interacts, err := c.interactionsWithStatusForAccount(ctx, s, requestingAccount, batch)
 	if err != nil {
 		log.Errorf(ctx, "error getting interactions for status %s for account %s: %v", s.ID, requestingAccount.ID, err)
 
@@ -826,24 +1150,178 @@ func (c *Converter) statusToFrontend(
 		interacts = &statusInteractions{}
 	}
 
-	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
-	if err != nil {
-		log.Errorf(ctx, "error converting status attachments: %v", err)
+	apiStatus.Favourited = interacts.Faved
+	apiStatus.Bookmarked = interacts.Bookmarked
+	apiStatus.Muted = interacts.Muted
+	apiStatus.Reblogged = interacts.Reblogged
+	apiStatus.Pinned = interacts.Pinned
+
+	if config.GetStatusesReactionsEnabled() {
+		apiStatus.Reactions, err = c.statusReactionsToAPI(ctx, s, requestingAccount)
+		if err != nil {
+			log.Errorf(ctx, "error converting reactions for status %s: %v", s.ID, err)
+		}
 	}
 
-	apiMentions, err := c.convertMentionsToAPIMentions(ctx, s.Mentions, s.MentionIDs)
-	if err != nil {
-		log.Errorf(ctx, "error converting status mentions: %v", err)
+	if s.BoostOf != nil {
+		reblog, err := c.statusToAPIStatus(ctx, s.BoostOf, requestingAccount, batch)
+		if err != nil {
+			return nil, gtserror.Newf("error converting boosted status: %w", err)
+		}
+
+		apiStatus.Reblog = &apimodel.StatusReblogged{reblog}
+
+		// Boost wrapper takes the content warning of the
+		// boosted status, so that clients rendering only
+		// the wrapper don't show sensitive content openly.
+		apiStatus.Sensitive = reblog.Sensitive
+		apiStatus.SpoilerText = reblog.SpoilerText
 	}
 
-	apiTags, err := c.convertTagsToAPITags(ctx, s.Tags, s.TagIDs)
-	if err != nil {
-		log.Errorf(ctx, "error converting status tags: %v", err)
+	if s.Poll != nil {
+		// Set originating
+		// status on the poll.
+		poll := s.Poll
+		poll.Status = s
+
+		apiStatus.Poll, err = c.PollToAPIPoll(ctx, requestingAccount, poll)
+		if err != nil {
+			return nil, fmt.Errorf("error converting poll: %w", err)
+		}
+	}
+
+	// If web URL is empty for whatever
+	// reason, provide AP URI as fallback.
+	if s.URL == "" {
+		s.URL = s.URI
+	}
+
+	return apiStatus, nil
+}
+
+// statusReactionsToAPI returns the emoji reactions to the given
+// status, grouped by emoji in order of first use, with "me" set
+// for those the (optional) requesting account has reacted with.
+func (c *Converter) statusReactionsToAPI(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) ([]apimodel.StatusReaction, error) {
+	reactions, err := c.state.DB.GetStatusReactions(ctx, s.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting reactions: %w", err)
+	}
+
+	var (
+		apiReactions = make([]apimodel.StatusReaction, 0, len(reactions))
+		indices      = make(map[string]int, len(reactions))
+	)
+
+	for _, reaction := range reactions {
+		// Group custom emojis by ID, as different
+		// instances may share the same shortcode.
+		key := reaction.Content
+		if reaction.IsCustomEmoji() {
+			if reaction.Emoji == nil {
+				// Emoji since deleted.
+				continue
+			}
+			key = reaction.EmojiID
+		}
+
+		i, ok := indices[key]
+		if !ok {
+			apiReaction := apimodel.StatusReaction{Emoji: reaction.Content}
+			if reaction.IsCustomEmoji() {
+				apiReaction.Emoji = reaction.Emoji.Shortcode
+				apiReaction.URL = staticOr(ctx, reaction.Emoji.ImageURL, reaction.Emoji.ImageStaticURL)
+				apiReaction.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			i = len(apiReactions)
+			indices[key] = i
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiReactions[i].Count++
+		if requestingAccount != nil && reaction.AccountID == requestingAccount.ID {
+			apiReactions[i].Me = true
+		}
+	}
+
+	return apiReactions, nil
+}
+
+// statusCoreToFrontend returns the viewer-independent part
+// of the frontend representation of the given (populated)
+// status, ie., everything but interactions of the requesting
+// account, the poll, and any boosted status. Cores are cached
+// by status ID, and reused while the status is not updated,
+// so the author account, application and emojis (which may
+// change independently of the status) are also left unset.
+//
+// The returned status MUST NOT be modified, use copyStatusCore.
+func (c *Converter) statusCoreToFrontend(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	batch *statusBatch,
+) (*apimodel.Status, error) {
+	if cached, ok := c.state.Caches.StatusCore.GetOne("StatusID", s.ID); ok &&
+		cached.UpdatedAt.Equal(s.UpdatedAt) {
+		return cached.Status, nil
 	}
 
-	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
+	repliesCount, reblogsCount, favesCount, err := c.countsForStatus(ctx, s, batch)
 	if err != nil {
-		log.Errorf(ctx, "error converting status emojis: %v", err)
+		return nil, err
+	}
+
+	var (
+		apiAttachments []*apimodel.Attachment
+		apiMentions    []apimodel.Mention
+		apiTags        []apimodel.Tag
+		wg             sync.WaitGroup
+	)
+
+	// Convert attachments, mentions and tags
+	// concurrently, as each may need its own database
+	// round trip if not already populated on the status.
+	// Errors here are logged and never fail the conversion.
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		var err error
+		apiAttachments, err = c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
+		if err != nil {
+			log.Errorf(ctx, "error converting status attachments: %v", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		var err error
+		apiMentions, err = c.convertMentionsToAPIMentions(ctx, s.Mentions, s.MentionIDs)
+		if err != nil {
+			log.Errorf(ctx, "error converting status mentions: %v", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		var err error
+		apiTags, err = c.convertTagsToAPITags(ctx, s.Tags, s.TagIDs)
+		if err != nil {
+			log.Errorf(ctx, "error converting status tags: %v", err)
+		}
+	}()
+
+	wg.Wait()
+
+	// Media inherits the sensitivity of its status,
+	// so clients can hide previews on the media alone.
+	for _, a := range apiAttachments {
+		a.Sensitive = *s.Sensitive
 	}
 
 	apiStatus := &apimodel.Status{
@@ -860,19 +1338,16 @@ func (c *Converter) statusToFrontend(
 		RepliesCount:       repliesCount,
 		ReblogsCount:       reblogsCount,
 		FavouritesCount:    favesCount,
-		Favourited:         interacts.Faved,
-		Bookmarked:         interacts.Bookmarked,
-		Muted:              interacts.Muted,
-		Reblogged:          interacts.Reblogged,
-		Pinned:             interacts.Pinned,
+		LocalOnly:          s.IsLocalOnly(),
+		InteractionPolicy:  statusToAPIInteractionPolicy(s),
 		Content:            s.Content,
-		Reblog:             nil, // Set below.
-		Application:        nil, // Set below.
-		Account:            apiAuthorAccount,
+		Reblog:             nil, // Set by statusToFrontend.
+		Application:        nil, // Set by statusToFrontend.
+		Account:            nil, // Set by statusToFrontend.
 		MediaAttachments:   apiAttachments,
 		Mentions:           apiMentions,
 		Tags:               apiTags,
-		Emojis:             apiEmojis,
+		Emojis:             nil, // Set by statusToFrontend.
 		Card:               nil, // TODO: implement cards
 		Text:               s.Text,
 	}
@@ -890,44 +1365,34 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Language = util.Ptr(s.Language)
 	}
 
-	if s.BoostOf != nil {
-		reblog, err := c.StatusToAPIStatus(ctx, s.BoostOf, requestingAccount)
-		if err != nil {
-			return nil, gtserror.Newf("error converting boosted status: %w", err)
-		}
-
-		apiStatus.Reblog = &apimodel.StatusReblogged{reblog}
-	}
-
-	if app := s.CreatedWithApplication; app != nil {
-		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
-		if err != nil {
-			return nil, gtserror.Newf(
-				"error converting application %s: %w",
-				s.CreatedWithApplicationID, err,
-			)
-		}
-	}
+	// Store core for next time.
+	c.state.Caches.StatusCore.Put(&cache.CachedStatusCore{
+		StatusID:  s.ID,
+		UpdatedAt: s.UpdatedAt,
+		Status:    apiStatus,
+	})
 
-	if s.Poll != nil {
-		// Set originating
-		// status on the poll.
-		poll := s.Poll
-		poll.Status = s
+	return apiStatus, nil
+}
 
-		apiStatus.Poll, err = c.PollToAPIPoll(ctx, requestingAccount, poll)
-		if err != nil {
-			return nil, fmt.Errorf("error converting poll: %w", err)
+// copyStatusCore returns a copy of the given frontend status
+// core that is safe to modify. Nested mention and tag models
+// are shared, while media attachments are copied, as these
+// get modified during API / web normalization.
+func copyStatusCore(core *apimodel.Status) *apimodel.Status {
+	apiStatus := new(apimodel.Status)
+	*apiStatus = *core
+
+	if core.MediaAttachments != nil {
+		apiStatus.MediaAttachments = make([]*apimodel.Attachment, len(core.MediaAttachments))
+		for i, a := range core.MediaAttachments {
+			a2 := new(apimodel.Attachment)
+			*a2 = *a
+			apiStatus.MediaAttachments[i] = a2
 		}
 	}
 
-	// If web URL is empty for whatever
-	// reason, provide AP URI as fallback.
-	if s.URL == "" {
-		s.URL = s.URI
-	}
-
-	return apiStatus, nil
+	return apiStatus
 }
 
 // VisToAPIVis converts a gts visibility into its api equivalent
@@ -974,6 +1439,68 @@ func (c *Converter) InstanceRuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.Admin
 	}
 }
 
+// RelayToAdminAPIRelay converts a gts model relay into its admin api representation.
+func (c *Converter) RelayToAdminAPIRelay(r *gtsmodel.Relay) *apimodel.AdminRelay {
+	return &apimodel.AdminRelay{
+		ID:        r.ID,
+		InboxURL:  r.InboxURI,
+		State:     string(r.State),
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+	}
+}
+
+// InviteToAdminAPIInvite converts a gts model invite into its admin api representation.
+func (c *Converter) InviteToAdminAPIInvite(i *gtsmodel.Invite) *apimodel.AdminInvite {
+	var expiresAt *string
+	if !i.ExpiresAt.IsZero() {
+		e := util.FormatISO8601(i.ExpiresAt)
+		expiresAt = &e
+	}
+
+	return &apimodel.AdminInvite{
+		ID:                 i.ID,
+		Code:               i.Code,
+		CreatedByAccountID: i.CreatedByAccountID,
+		CreatedAt:          util.FormatISO8601(i.CreatedAt),
+		ExpiresAt:          expiresAt,
+		MaxUses:            i.MaxUses,
+		Uses:               i.Uses,
+		Role:               string(i.Role),
+		Expired:            i.Expired() || i.UsedUp(),
+	}
+}
+
+// QuarantinedStatusToAdminAPIQuarantinedStatus converts a gts model quarantined status into its admin api representation.
+func (c *Converter) QuarantinedStatusToAdminAPIQuarantinedStatus(ctx context.Context, q *gtsmodel.QuarantinedStatus) (*apimodel.AdminQuarantinedStatus, error) {
+	statusable, err := ap.ResolveStatusable(ctx, io.NopCloser(bytes.NewReader(q.Data)))
+	if err != nil {
+		return nil, gtserror.Newf("error resolving quarantined status %s: %w", q.ID, err)
+	}
+
+	account, err := c.AccountToAdminAPIAccount(ctx, q.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account with id %s: %w", q.AccountID, err)
+	}
+
+	receivingAccount, err := c.AccountToAdminAPIAccount(ctx, q.ReceivingAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting receiving account with id %s: %w", q.ReceivingAccountID, err)
+	}
+
+	content, _ := ContentToContentLanguage(ctx, ap.ExtractContent(statusable))
+
+	return &apimodel.AdminQuarantinedStatus{
+		ID:               q.ID,
+		CreatedAt:        util.FormatISO8601(q.CreatedAt),
+		URI:              q.URI,
+		Account:          account,
+		ReceivingAccount: receivingAccount,
+		Reason:           q.Reason,
+		SpoilerText:      text.SanitizeToPlaintext(ap.ExtractSummary(statusable)),
+		Content:          text.SanitizeToHTML(content),
+	}, nil
+}
+
 // InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error) {
 	instance := &apimodel.InstanceV1{
@@ -989,7 +1516,7 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
 		ApprovalRequired:     config.GetAccountsApprovalRequired(),
-		InvitesEnabled:       false, // todo: not supported yet
+		InvitesEnabled:       config.GetAccountsInvitesEnabled(),
 		MaxTootChars:         uint(config.GetStatusesMaxChars()),
 		Rules:                c.InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -1005,7 +1532,7 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
+	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.UploadMIMETypes()
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
@@ -1140,7 +1667,7 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
 	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
+	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.UploadMIMETypes()
 	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
 	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
@@ -1263,6 +1790,49 @@ func (c *Converter) NotificationToAPINotification(ctx context.Context, n *gtsmod
 	}, nil
 }
 
+// NotificationRequestToAPINotificationRequest converts a gts notification request into its api representation.
+func (c *Converter) NotificationRequestToAPINotificationRequest(ctx context.Context, r *gtsmodel.NotificationRequest) (*apimodel.NotificationRequest, error) {
+	if r.FromAccount == nil {
+		// Ensure origin account is set.
+		account, err := c.state.DB.GetAccountByID(ctx, r.FromAccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting account %s: %w", r.FromAccountID, err)
+		}
+		r.FromAccount = account
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, r.FromAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account %s: %w", r.FromAccountID, err)
+	}
+
+	return &apimodel.NotificationRequest{
+		ID:                 r.ID,
+		CreatedAt:          util.FormatISO8601(r.CreatedAt),
+		UpdatedAt:          util.FormatISO8601(r.UpdatedAt),
+		Account:            apiAccount,
+		NotificationsCount: strconv.Itoa(r.NotificationsCount),
+	}, nil
+}
+
+// AccountArchiveToAPIAccountArchive converts a gts model account archive into its api (frontend) representation.
+// The download URL and expiry are only set once the archive is complete.
+func (c *Converter) AccountArchiveToAPIAccountArchive(ctx context.Context, a *gtsmodel.AccountArchive) *apimodel.AccountArchive {
+	apiArchive := &apimodel.AccountArchive{
+		ID:            a.ID,
+		CreatedAt:     util.FormatISO8601(a.CreatedAt),
+		State:         a.Stage.String(),
+		StatusesCount: a.StatusesCount,
+	}
+
+	if a.Stage == gtsmodel.AccountArchiveStageComplete {
+		apiArchive.URL = util.Ptr(uris.URIForAccountArchive(a.AccountID, a.ID, a.Token))
+		apiArchive.ExpiresAt = util.Ptr(util.FormatISO8601(a.ExpiresAt))
+	}
+
+	return apiArchive
+}
+
 // DomainPermToAPIDomainPerm converts a gts model domin block or allow into an api domain permission.
 func (c *Converter) DomainPermToAPIDomainPerm(
 	ctx context.Context,
@@ -1440,6 +2010,63 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 	}, nil
 }
 
+// StatusToAPIConversation converts a gts model status with direct visibility into
+// an api model conversation from the perspective of requestingAccount, with the
+// status as the last status of the conversation. The status must be populated.
+func (c *Converter) StatusToAPIConversation(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) (*apimodel.Conversation, error) {
+	apiStatus, err := c.StatusToAPIStatus(ctx, s, requestingAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status: %w", err)
+	}
+
+	// Participants are the author of the
+	// status + all mentioned accounts,
+	// not including the requester.
+	participants := make([]*gtsmodel.Account, 0, 1+len(s.Mentions))
+	participants = append(participants, s.Account)
+	for _, m := range s.Mentions {
+		participants = append(participants, m.TargetAccount)
+	}
+
+	accounts := make([]apimodel.Account, 0, len(participants))
+	seen := make(map[string]struct{}, len(participants))
+	for _, a := range participants {
+		if a == nil || a.ID == requestingAccount.ID {
+			continue
+		}
+
+		if _, ok := seen[a.ID]; ok {
+			continue
+		}
+		seen[a.ID] = struct{}{}
+
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s: %w", a.ID, err)
+		}
+		accounts = append(accounts, *apiAccount)
+	}
+
+	// There's no conversations table, so
+	// use the thread ID where possible so
+	// that replies map to the same ID.
+	id := s.ThreadID
+	if id == "" {
+		id = s.ID
+	}
+
+	return &apimodel.Conversation{
+		ID:         id,
+		Accounts:   accounts,
+		Unread:     s.AccountID != requestingAccount.ID,
+		LastStatus: apiStatus,
+	}, nil
+}
+
 // ListToAPIList converts one gts model list into an api model list, for serving at /api/v1/lists/{id}
 func (c *Converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimodel.List, error) {
 	return &apimodel.List{
@@ -1527,7 +2154,7 @@ func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Accou
 		hasVoted = util.Ptr((isAuthor || len(*ownChoices) > 0))
 	}
 
-	if isAuthor || !*poll.HideCounts {
+	if isAuthor || !util.PtrValueOr(poll.HideCounts, false) {
 		// Only in the case that hide counts is
 		// disabled, or the requester is the author
 		// do we actually populate the vote counts.
@@ -1535,7 +2162,7 @@ func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Accou
 		// If we voted in this poll, we'll have set totalVotes
 		// earlier. Reset here to avoid double counting.
 		totalVotes = 0
-		if *poll.Multiple {
+		if util.PtrValueOr(poll.Multiple, false) {
 			// The total number of voters are only
 			// provided in the case of a multiple
 			// choice poll. All else leaves it nil.
@@ -1545,6 +2172,11 @@ func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Accou
 		// Populate per-vote counts
 		// and overall total vote count.
 		for i, count := range poll.Votes {
+			if i >= len(options) {
+				// Malformed (likely remote) poll
+				// with more counts than options.
+				break
+			}
 			if options[i].VotesCount == nil {
 				options[i].VotesCount = new(int)
 			}
@@ -1575,7 +2207,7 @@ func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Accou
 		ID:          poll.ID,
 		ExpiresAt:   expiresAt,
 		Expired:     poll.Closed(),
-		Multiple:    (*poll.Multiple),
+		Multiple:    util.PtrValueOr(poll.Multiple, false),
 		VotesCount:  totalVotes,
 		VotersCount: totalVoters,
 		Voted:       hasVoted,
@@ -1606,6 +2238,11 @@ func (c *Converter) convertAttachmentsToAPIAttachments(ctx context.Context, atta
 
 	// Convert GTS models to frontend models
 	for _, attachment := range attachments {
+		if attachment == nil {
+			// Missing from db.
+			continue
+		}
+
 		apiAttachment, err := c.AttachmentToAPIAttachment(ctx, attachment)
 		if err != nil {
 			errs.Appendf("error converting attchment %s to api attachment: %w", attachment.ID, err)
@@ -1709,3 +2346,40 @@ func (c *Converter) convertTagsToAPITags(ctx context.Context, tags []*gtsmodel.T
 
 	return apiTags, errs.Combine()
 }
+
+// statusToAPIInteractionPolicy converts the interaction flags and
+// policies of the given status to an API interaction policy, or
+// returns nil if anyone may interact with the status in every way.
+func statusToAPIInteractionPolicy(s *gtsmodel.Status) *apimodel.StatusInteractionPolicy {
+	policy := &apimodel.StatusInteractionPolicy{
+		Replies: interactionPolicyToAPI(s.Replyable, s.ReplyPolicy),
+		Boosts:  interactionPolicyToAPI(s.Boostable, s.BoostPolicy),
+		Likes:   interactionPolicyToAPI(s.Likeable, s.LikePolicy),
+	}
+
+	if policy.Replies == apimodel.InteractionPolicyAnyone &&
+		policy.Boosts == apimodel.InteractionPolicyAnyone &&
+		policy.Likes == apimodel.InteractionPolicyAnyone {
+		// Default, no need to include.
+		return nil
+	}
+
+	return policy
+}
+
+// interactionPolicyToAPI converts a single interaction
+// flag + policy combination to an API policy value.
+func interactionPolicyToAPI(permitted *bool, policy gtsmodel.InteractionPolicy) apimodel.InteractionPolicyValue {
+	if permitted != nil && !*permitted {
+		return apimodel.InteractionPolicyNobody
+	}
+
+	switch policy {
+	case gtsmodel.InteractionPolicyFollowers:
+		return apimodel.InteractionPolicyFollowers
+	case gtsmodel.InteractionPolicyMentioned:
+		return apimodel.InteractionPolicyMentioned
+	default:
+		return apimodel.InteractionPolicyAnyone
+	}
+}
@@ -20,12 +20,21 @@ package typeutils_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -54,8 +63,10 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontend() {
   "url": "http://localhost:8080/@the_mighty_zork",
   "avatar": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg",
   "avatar_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg",
+  "avatar_description": "a green goblin looking nasty",
   "header": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
   "header_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
+  "header_description": "A very old-school screenshot of the original team fortress mod for quake ",
   "followers_count": 2,
   "following_count": 2,
   "statuses_count": 7,
@@ -105,8 +116,10 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
   "url": "http://localhost:8080/@the_mighty_zork",
   "avatar": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg",
   "avatar_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg",
+  "avatar_description": "a green goblin looking nasty",
   "header": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
   "header_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
+  "header_description": "A very old-school screenshot of the original team fortress mod for quake ",
   "followers_count": 2,
   "following_count": 2,
   "statuses_count": 7,
@@ -123,7 +136,20 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "follow_requests_count": 0,
     "also_known_as_uris": [
       "http://localhost:8080/users/1happyturtle"
-    ]
+    ],
+    "expand_spoilers": false,
+    "expand_media": "default",
+    "disable_animation": false,
+    "hide_collections": false,
+    "suppress_follow_request_notifications": false,
+    "suppress_status_update_notifications": false,
+    "auto_accept_followed_back": false,
+    "auto_accept_local": false,
+    "auto_accept_older_than_days": 0,
+    "statuses_auto_delete_after_days": 0,
+    "statuses_auto_delete_keep_pinned": true,
+    "statuses_auto_delete_keep_bookmarked": true,
+    "statuses_auto_delete_keep_direct": true
   },
   "enable_rss": true,
   "role": {
@@ -195,8 +221,10 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendWithEmojiStruct()
   "url": "http://localhost:8080/@the_mighty_zork",
   "avatar": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg",
   "avatar_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg",
+  "avatar_description": "a green goblin looking nasty",
   "header": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
   "header_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
+  "header_description": "A very old-school screenshot of the original team fortress mod for quake ",
   "followers_count": 2,
   "following_count": 2,
   "statuses_count": 7,
@@ -243,8 +271,10 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendWithEmojiIDs() {
   "url": "http://localhost:8080/@the_mighty_zork",
   "avatar": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg",
   "avatar_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg",
+  "avatar_description": "a green goblin looking nasty",
   "header": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
   "header_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
+  "header_description": "A very old-school screenshot of the original team fortress mod for quake ",
   "followers_count": 2,
   "following_count": 2,
   "statuses_count": 7,
@@ -287,8 +317,10 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
   "url": "http://localhost:8080/@the_mighty_zork",
   "avatar": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/original/01F8MH58A357CV5K7R7TJMSH6S.jpg",
   "avatar_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg",
+  "avatar_description": "a green goblin looking nasty",
   "header": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
   "header_static": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg",
+  "header_description": "A very old-school screenshot of the original team fortress mod for quake ",
   "followers_count": 2,
   "following_count": 2,
   "statuses_count": 7,
@@ -302,7 +334,20 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
     "status_content_type": "text/plain",
     "note": "hey yo this is my profile!",
     "fields": [],
-    "follow_requests_count": 0
+    "follow_requests_count": 0,
+    "expand_spoilers": false,
+    "expand_media": "default",
+    "disable_animation": false,
+    "hide_collections": false,
+    "suppress_follow_request_notifications": false,
+    "suppress_status_update_notifications": false,
+    "auto_accept_followed_back": false,
+    "auto_accept_local": false,
+    "auto_accept_older_than_days": 0,
+    "statuses_auto_delete_after_days": 0,
+    "statuses_auto_delete_keep_pinned": true,
+    "statuses_auto_delete_keep_bookmarked": true,
+    "statuses_auto_delete_keep_direct": true
   },
   "enable_rss": true,
   "role": {
@@ -511,7 +556,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
         }
       },
       "description": "Black and white image of some 50's style text saying: Welcome On Board",
-      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj"
+      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj",
+      "sensitive": false
     }
   ],
   "mentions": [],
@@ -617,7 +663,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
         }
       },
       "description": "Photograph of a sloth, Public Domain.",
-      "blurhash": "LNEC{|w}0K9GsEtPM|j[NFbHoeof"
+      "blurhash": "LNEC{|w}0K9GsEtPM|j[NFbHoeof",
+      "sensitive": true
     }
   ],
   "mentions": [
@@ -726,7 +773,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
         }
       },
       "description": "Photograph of a sloth, Public Domain.",
-      "blurhash": "LNEC{|w}0K9GsEtPM|j[NFbHoeof"
+      "blurhash": "LNEC{|w}0K9GsEtPM|j[NFbHoeof",
+      "sensitive": true
     },
     {
       "id": "01HE7ZFX9GKA5ZZVD4FACABSS9",
@@ -738,7 +786,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
       "preview_remote_url": null,
       "meta": null,
       "description": "SVG line art of a sloth, public domain",
-      "blurhash": "L26*j+~qE1RP?wxut7ofRlM{R*of"
+      "blurhash": "L26*j+~qE1RP?wxut7ofRlM{R*of",
+      "sensitive": true
     },
     {
       "id": "01HE88YG74PVAB81PX2XA9F3FG",
@@ -750,7 +799,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
       "preview_remote_url": null,
       "meta": null,
       "description": "Jolly salsa song, public domain.",
-      "blurhash": null
+      "blurhash": null,
+      "sensitive": true
     }
   ],
   "mentions": [
@@ -768,6 +818,317 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToWebStatusPollClosed() {
+	testStatus := suite.testStatuses["remote_account_1_status_2"]
+	requestingAccount := suite.testAccounts["admin_account"]
+
+	apiStatus, err := suite.typeconverter.StatusToWebStatus(context.Background(), testStatus, requestingAccount)
+	suite.NoError(err)
+
+	suite.NotNil(apiStatus.WebPoll)
+	suite.True(apiStatus.WebPoll.Expired)
+	suite.Equal("closed", apiStatus.WebPoll.ExpiryStr)
+	suite.Equal(6, apiStatus.WebPoll.VotersCount)
+	suite.Len(apiStatus.WebPollOptions, 3)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToWebStatusPollOpen() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["remote_account_1_status_2"]
+	testStatus.Poll = &gtsmodel.Poll{
+		ID:         testStatus.PollID,
+		Multiple:   util.Ptr(false),
+		HideCounts: util.Ptr(false),
+		Options:    []string{"yes", "no"},
+		Votes:      []int{3, 1},
+		Voters:     util.Ptr(4),
+		StatusID:   testStatus.ID,
+		ExpiresAt:  time.Now().Add(3*time.Hour + 30*time.Minute),
+	}
+
+	apiStatus, err := suite.typeconverter.StatusToWebStatus(context.Background(), testStatus, nil)
+	suite.NoError(err)
+
+	suite.NotNil(apiStatus.WebPoll)
+	suite.False(apiStatus.WebPoll.Expired)
+	suite.Equal("closes in 3 hours", apiStatus.WebPoll.ExpiryStr)
+
+	// Single-choice poll, so
+	// voters equals votes.
+	suite.Equal(4, apiStatus.WebPoll.VotersCount)
+	suite.Equal("75", apiStatus.WebPollOptions[0].VoteShareStr)
+	suite.Equal("25", apiStatus.WebPollOptions[1].VoteShareStr)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToWebStatusPollNoOptions() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["remote_account_1_status_2"]
+
+	// Malformed remote poll with no options,
+	// but vote counts, and no bool flags set.
+	testStatus.Poll = &gtsmodel.Poll{
+		ID:       testStatus.PollID,
+		Votes:    []int{1, 2},
+		StatusID: testStatus.ID,
+	}
+
+	apiStatus, err := suite.typeconverter.StatusToWebStatus(context.Background(), testStatus, nil)
+	suite.NoError(err)
+
+	suite.NotNil(apiStatus.Poll)
+	suite.Empty(apiStatus.WebPollOptions)
+	suite.NotNil(apiStatus.WebPoll)
+	suite.False(apiStatus.WebPoll.Expired)
+	suite.Equal("open indefinitely", apiStatus.WebPoll.ExpiryStr)
+	suite.Zero(apiStatus.WebPoll.VotersCount)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToWebStatusPollNilVotes() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["remote_account_1_status_2"]
+
+	// Malformed remote poll with
+	// options but no vote counts.
+	testStatus.Poll = &gtsmodel.Poll{
+		ID:         testStatus.PollID,
+		Multiple:   util.Ptr(true),
+		HideCounts: util.Ptr(false),
+		Options:    []string{"one", "two"},
+		StatusID:   testStatus.ID,
+		ClosedAt:   time.Now().Add(-time.Hour),
+	}
+
+	apiStatus, err := suite.typeconverter.StatusToWebStatus(context.Background(), testStatus, nil)
+	suite.NoError(err)
+
+	suite.Len(apiStatus.WebPollOptions, 2)
+	for _, option := range apiStatus.WebPollOptions {
+		suite.Zero(option.VoteShare)
+		suite.Equal("0", option.VoteShareStr)
+	}
+	suite.True(apiStatus.WebPoll.Expired)
+	suite.Equal("closed", apiStatus.WebPoll.ExpiryStr)
+	suite.Zero(apiStatus.WebPoll.VotersCount)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToWebStatusPollMissing() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["remote_account_1_status_2"]
+
+	// Poll ID set, but no
+	// such poll in the db.
+	testStatus.PollID = "01J2M1HPFSS54S60Y0KYV23KJE"
+	testStatus.Poll = nil
+
+	apiStatus, err := suite.typeconverter.StatusToWebStatus(context.Background(), testStatus, nil)
+	suite.NoError(err)
+
+	suite.Nil(apiStatus.Poll)
+	suite.Nil(apiStatus.WebPoll)
+	suite.Nil(apiStatus.WebPollOptions)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToAPIStatusRichContent() {
+	// Content as stored after sanitizing an incoming status.
+	const content = `<figure><figcaption>A <em>cat</em></figcaption></figure>` +
+		`<p><ruby>漢<rp>(</rp><rt>kan</rt><rp>)</rp></ruby> H<sub>2</sub>O E=mc<sup>2</sup></p>` +
+		`<details><summary>spoiler</summary><p>hi</p></details>` +
+		`<table><thead><tr><th scope="col">a</th></tr></thead><tbody><tr><td>b</td></tr></tbody></table>`
+
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["remote_account_1_status_1"]
+	testStatus.Content = content
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, suite.testAccounts["admin_account"])
+	suite.NoError(err)
+	suite.Equal(content, apiStatus.Content)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendOverLimits() {
+	var (
+		ctx       = context.Background()
+		maxChars  = config.GetStatusesMaxChars()
+		maxFiles  = config.GetStatusesMediaMaxFiles()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	// Status created before limits were
+	// lowered, with 3x the allowed content
+	// and attachments. Use a fresh ID so
+	// nothing is served from the cache.
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_1_status_4"]
+	testStatus.ID = id.NewULID()
+	testStatus.Content = strings.Repeat("a", 3*maxChars)
+
+	testStatus.AttachmentIDs = nil
+	testStatus.Attachments = nil
+	for i := 0; i < 3*maxFiles; i++ {
+		attachment := new(gtsmodel.MediaAttachment)
+		*attachment = *suite.testAttachments["local_account_1_status_4_attachment_1"]
+		attachment.ID = id.NewULID()
+		attachment.StatusID = testStatus.ID
+
+		testStatus.AttachmentIDs = append(testStatus.AttachmentIDs, attachment.ID)
+		testStatus.Attachments = append(testStatus.Attachments, attachment)
+	}
+
+	// Conversion should be stable across calls.
+	var prevAPI, prevWeb []byte
+	for i := 0; i < 2; i++ {
+		apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requester)
+		suite.NoError(err)
+		suite.Equal(testStatus.Content, apiStatus.Content)
+		suite.Len(apiStatus.MediaAttachments, 3*maxFiles)
+		for j, a := range apiStatus.MediaAttachments {
+			suite.Equal(testStatus.AttachmentIDs[j], a.ID)
+		}
+
+		webStatus, err := suite.typeconverter.StatusToWebStatus(ctx, testStatus, requester)
+		suite.NoError(err)
+		suite.Equal(testStatus.Content, webStatus.Content)
+		suite.Len(webStatus.MediaAttachments, 3*maxFiles)
+		for j, a := range webStatus.MediaAttachments {
+			suite.Equal(testStatus.AttachmentIDs[j], a.ID)
+		}
+
+		apiJSON, err := json.Marshal(apiStatus)
+		suite.NoError(err)
+		webJSON, err := json.Marshal(webStatus)
+		suite.NoError(err)
+
+		if i > 0 {
+			suite.Equal(string(prevAPI), string(apiJSON))
+			suite.Equal(string(prevWeb), string(webJSON))
+		}
+		prevAPI, prevWeb = apiJSON, webJSON
+	}
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendQuote() {
+	// admin quotes a followers-only status of local_account_1.
+	quoted := suite.testStatuses["local_account_1_status_5"]
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.QuoteOfURI = quoted.URI
+	testStatus.QuoteOfID = quoted.ID
+
+	// local_account_2 follows local_account_1, so can see the quote.
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, suite.testAccounts["local_account_2"])
+	suite.NoError(err)
+	suite.NotNil(apiStatus.Quote)
+	suite.Equal(quoted.ID, apiStatus.Quote.ID)
+	suite.False(apiStatus.QuoteUnavailable)
+
+	// Unauthenticated requesters should not see the quote.
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, nil)
+	suite.NoError(err)
+	suite.Nil(apiStatus.Quote)
+	suite.True(apiStatus.QuoteUnavailable)
+
+	// Nor should the web view.
+	webStatus, err := suite.typeconverter.StatusToWebStatus(context.Background(), testStatus, nil)
+	suite.NoError(err)
+	suite.Nil(webStatus.Quote)
+	suite.True(webStatus.QuoteUnavailable)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPerViewer() {
+	var (
+		ctx        = context.Background()
+		testStatus = suite.testStatuses["admin_account_status_1"]
+		faver      = suite.testAccounts["local_account_1"]
+		viewer     = suite.testAccounts["local_account_2"]
+	)
+
+	// Convert for an account that's faved the status.
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, faver)
+	suite.NoError(err)
+	suite.True(apiStatus.Favourited)
+	suite.Equal(1, apiStatus.FavouritesCount)
+
+	// The cached core should be reused for an account
+	// that hasn't, without leaking the interactions.
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(ctx, testStatus, viewer)
+	suite.NoError(err)
+	suite.False(apiStatus.Favourited)
+	suite.Equal(1, apiStatus.FavouritesCount)
+
+	// Fave the status as the viewer, this should
+	// invalidate the cached core's faves count.
+	faveID := id.NewULID()
+	if err := suite.db.PutStatusFave(ctx, &gtsmodel.StatusFave{
+		ID:              faveID,
+		AccountID:       viewer.ID,
+		TargetAccountID: testStatus.AccountID,
+		StatusID:        testStatus.ID,
+		URI:             "http://localhost:8080/users/" + viewer.Username + "/liked/" + faveID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(ctx, testStatus, viewer)
+	suite.NoError(err)
+	suite.True(apiStatus.Favourited)
+	suite.Equal(2, apiStatus.FavouritesCount)
+}
+
+func (suite *InternalToFrontendTestSuite) TestBoostToFrontendInheritsSensitive() {
+	ctx := context.Background()
+
+	// Take a boost wrapper that doesn't
+	// carry the CW of the boosted status.
+	boost := &gtsmodel.Status{}
+	*boost = *suite.testStatuses["admin_account_status_4"]
+	boost.Sensitive = util.Ptr(false)
+	boost.ContentWarning = ""
+	boost.UpdatedAt = time.Now()
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, boost, nil)
+	suite.NoError(err)
+
+	// Wrapper should take on the boosted status' CW.
+	suite.NotNil(apiStatus.Reblog)
+	suite.Equal(apiStatus.Reblog.Sensitive, apiStatus.Sensitive)
+	suite.Equal(apiStatus.Reblog.SpoilerText, apiStatus.SpoilerText)
+	suite.Equal("introduction post", apiStatus.SpoilerText)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendAuthorStats() {
+	var (
+		ctx        = context.Background()
+		testStatus = suite.testStatuses["admin_account_status_1"]
+		viewer     = suite.testAccounts["local_account_1"]
+	)
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, viewer)
+	suite.NoError(err)
+	followersCount := apiStatus.Account.FollowersCount
+
+	// Update the author's follower count, which
+	// doesn't touch the status or its cached core.
+	if err := suite.db.UpdateAccountStatsFollows(ctx, testStatus.AccountID, 1, 0); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The author account should
+	// still reflect the new count.
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(ctx, testStatus, viewer)
+	suite.NoError(err)
+	suite.Equal(followersCount+1, apiStatus.Account.FollowersCount)
+}
+
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendQuoteNotDereferenced() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.QuoteOfURI = "http://fossbros-anonymous.io/users/foss_satan/statuses/01HQA0ZN1VHZ4V9ZD9TTSHZ3M1"
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, suite.testAccounts["local_account_1"])
+	suite.NoError(err)
+	suite.Nil(apiStatus.Quote)
+	suite.True(apiStatus.QuoteUnavailable)
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage() {
 	testStatus := &gtsmodel.Status{}
 	*testStatus = *suite.testStatuses["admin_account_status_1"]
@@ -858,7 +1219,8 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
         }
       },
       "description": "Black and white image of some 50's style text saying: Welcome On Board",
-      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj"
+      "blurhash": "LNJRdVM{00Rj%Mayt7j[4nWBofRj",
+      "sensitive": false
     }
   ],
   "mentions": [],
@@ -915,7 +1277,8 @@ func (suite *InternalToFrontendTestSuite) TestVideoAttachmentToFrontend() {
     }
   },
   "description": "A cow adorably licking another cow!",
-  "blurhash": null
+  "blurhash": null,
+  "sensitive": false
 }`, string(b))
 }
 
@@ -952,7 +1315,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
   ],
   "registrations": true,
   "approval_required": true,
-  "invites_enabled": false,
+  "invites_enabled": true,
   "configuration": {
     "statuses": {
       "max_characters": 5000,
@@ -1171,6 +1534,34 @@ func (suite *InternalToFrontendTestSuite) TestEmojiToFrontend() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestEmojiToFrontendAnimationDisabled() {
+	config.SetMediaDisableAnimation(true)
+
+	emoji, err := suite.typeconverter.EmojiToAPIEmoji(context.Background(), suite.testEmojis["rainbow"])
+	suite.NoError(err)
+	suite.Equal(emoji.StaticURL, emoji.URL)
+}
+
+func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAnimationDisabled() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Requester has asked for static media.
+	ctx := gtscontext.SetAnimationDisabled(context.Background())
+
+	apiAccount, err := suite.typeconverter.AccountToAPIAccountPublic(ctx, testAccount)
+	suite.NoError(err)
+	suite.Equal("http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/avatar/small/01F8MH58A357CV5K7R7TJMSH6S.jpg", apiAccount.Avatar)
+	suite.Equal(apiAccount.AvatarStatic, apiAccount.Avatar)
+	suite.Equal("http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg", apiAccount.Header)
+	suite.Equal(apiAccount.HeaderStatic, apiAccount.Header)
+
+	// Without the flag the originals are served.
+	apiAccount, err = suite.typeconverter.AccountToAPIAccountPublic(context.Background(), testAccount)
+	suite.NoError(err)
+	suite.NotEqual(apiAccount.AvatarStatic, apiAccount.Avatar)
+	suite.NotEqual(apiAccount.HeaderStatic, apiAccount.Header)
+}
+
 func (suite *InternalToFrontendTestSuite) TestEmojiToFrontendAdmin1() {
 	emoji, err := suite.typeconverter.EmojiToAdminAPIEmoji(context.Background(), suite.testEmojis["rainbow"])
 	suite.NoError(err)
@@ -1720,7 +2111,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
             }
           },
           "description": "tweet from thoughts of dog: i drank. all the water. in my bowl. earlier. but just now. i returned. to the same bowl. and it was. full again.. the bowl. is haunted",
-          "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6"
+          "blurhash": "LARysgM_IU_3~pD%M_Rj_39FIAt6",
+          "sensitive": false
         }
       ],
       "mentions": [],
@@ -1981,6 +2373,7 @@ func (suite *InternalToFrontendTestSuite) TestRelationshipFollowRequested() {
 		URI:             "http://localhost:8080/weeeeeeeeeeeeeeeee",
 		AccountID:       account1.ID,
 		TargetAccountID: account2.ID,
+		Notify:          util.Ptr(true),
 	}
 	if err := suite.db.PutFollowRequest(ctx, followRequest); err != nil {
 		suite.FailNow(err.Error())
@@ -2006,8 +2399,8 @@ func (suite *InternalToFrontendTestSuite) TestRelationshipFollowRequested() {
 	suite.Equal(`{
   "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
   "following": false,
-  "showing_reblogs": false,
-  "notifying": false,
+  "showing_reblogs": true,
+  "notifying": true,
   "followed_by": false,
   "blocking": false,
   "blocked_by": false,
@@ -2055,6 +2448,82 @@ func (suite *InternalToFrontendTestSuite) TestRelationshipFollowRequested() {
 }`, string(b))
 }
 
+// benchmarkStatus returns a copy of a test status with
+// 6 attachments and 10 emojis set by ID only, so that
+// conversion has to fetch each of them from the database.
+func benchmarkStatus(b *testing.B, st *state.State) *gtsmodel.Status {
+	ctx := context.Background()
+
+	attachments := testrig.NewTestAttachments()
+	attachmentIDs := []string{
+		attachments["admin_account_status_1_attachment_1"].ID,
+		attachments["local_account_1_status_4_attachment_1"].ID,
+		attachments["local_account_1_status_4_attachment_2"].ID,
+		attachments["remote_account_1_status_1_attachment_1"].ID,
+		attachments["remote_account_2_status_1_attachment_1"].ID,
+		attachments["remote_account_2_status_1_attachment_2"].ID,
+	}
+
+	emojis := testrig.NewTestEmojis()
+	emojiIDs := []string{
+		emojis["rainbow"].ID,
+		emojis["yell"].ID,
+	}
+
+	// Only two test emojis exist,
+	// so insert copies for the rest.
+	for i := len(emojiIDs); i < 10; i++ {
+		emoji := new(gtsmodel.Emoji)
+		*emoji = *emojis["rainbow"]
+		emoji.ID = id.NewULID()
+		emoji.Shortcode = fmt.Sprintf("rainbow%d", i)
+		emoji.URI = emoji.URI + strconv.Itoa(i)
+		if err := st.DB.PutEmoji(ctx, emoji); err != nil {
+			b.Fatal(err)
+		}
+		emojiIDs = append(emojiIDs, emoji.ID)
+	}
+
+	status := new(gtsmodel.Status)
+	*status = *testrig.NewTestStatuses()["admin_account_status_1"]
+	status.AttachmentIDs = attachmentIDs
+	status.Attachments = nil
+	status.EmojiIDs = emojiIDs
+	status.Emojis = nil
+	return status
+}
+
+func BenchmarkStatusToAPIStatus(b *testing.B) {
+	var st state.State
+	st.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	st.DB = testrig.NewTestDB(&st)
+	st.Storage = testrig.NewInMemoryStorage()
+	testrig.StandardDBSetup(st.DB, nil)
+	defer testrig.StandardDBTeardown(st.DB)
+
+	converter := typeutils.NewConverter(&st)
+	status := benchmarkStatus(b, &st)
+	requester := testrig.NewTestAccounts()["local_account_1"]
+
+	ctx := context.Background()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// Use a fresh copy each time so
+		// nothing is populated in between.
+		s := new(gtsmodel.Status)
+		*s = *status
+
+		if _, err := converter.StatusToAPIStatus(ctx, s, requester); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestInternalToFrontendTestSuite(t *testing.T) {
 	suite.Run(t, new(InternalToFrontendTestSuite))
 }
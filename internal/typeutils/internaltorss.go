@@ -104,22 +104,15 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 	id := s.URL
 
 	// Enclosure -- Describes a media object that is attached to the item.
-	enclosure := &feeds.Enclosure{}
-	// get first attachment if present
-	var attachment *gtsmodel.MediaAttachment
-	if len(s.Attachments) > 0 {
-		attachment = s.Attachments[0]
-	} else if len(s.AttachmentIDs) > 0 {
-		a, err := c.state.DB.GetAttachmentByID(ctx, s.AttachmentIDs[0])
-		if err == nil {
-			attachment = a
+	// RSS only allows one enclosure per item, so use the first image or video.
+	var enclosure *feeds.Enclosure
+	if attachment := c.rssEnclosureAttachment(ctx, s); attachment != nil {
+		enclosure = &feeds.Enclosure{
+			Url:    attachment.URL,
+			Length: strconv.Itoa(attachment.File.FileSize),
+			Type:   attachment.File.ContentType,
 		}
 	}
-	if attachment != nil {
-		enclosure.Type = attachment.File.ContentType
-		enclosure.Length = strconv.Itoa(attachment.File.FileSize)
-		enclosure.Url = attachment.URL
-	}
 
 	// Content
 	apiEmojis := []apimodel.Emoji{}
@@ -167,6 +160,31 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 	}, nil
 }
 
+// rssEnclosureAttachment returns the first image
+// or video attachment of the status, if any.
+func (c *Converter) rssEnclosureAttachment(ctx context.Context, s *gtsmodel.Status) *gtsmodel.MediaAttachment {
+	attachments := s.Attachments
+	if len(attachments) == 0 && len(s.AttachmentIDs) > 0 {
+		var err error
+		attachments, err = c.state.DB.GetAttachmentsByIDs(ctx, s.AttachmentIDs)
+		if err != nil {
+			log.Errorf(ctx, "error getting attachments of status %s: %v", s.ID, err)
+			return nil
+		}
+	}
+
+	for _, attachment := range attachments {
+		switch attachment.Type {
+		case gtsmodel.FileTypeImage,
+			gtsmodel.FileTypeGifv,
+			gtsmodel.FileTypeVideo:
+			return attachment
+		}
+	}
+
+	return nil
+}
+
 // trimTo trims the given `in` string to
 // the length `to`, measured in runes.
 //
@@ -20,19 +20,25 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"html"
 	"net/url"
 	"path"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type statusInteractions struct {
@@ -43,10 +49,192 @@ type statusInteractions struct {
 	Pinned     bool
 }
 
-func (c *Converter) interactionsWithStatusForAccount(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*statusInteractions, error) {
+// statusBatch contains counts and interactions prefetched
+// for a batch of statuses, to prevent having to perform
+// several database queries per status when converting.
+type statusBatch struct {
+	// Counts keyed by status ID. Every
+	// status in the batch has an entry.
+	replies map[string]int
+	boosts  map[string]int
+	faves   map[string]int
+
+	// Sets of status IDs (or thread IDs for muted)
+	// interacted with by the requesting account.
+	// Only set if there was a requesting account.
+	faved      map[string]struct{}
+	boosted    map[string]struct{}
+	bookmarked map[string]struct{}
+	muted      map[string]struct{}
+}
+
+// has returns whether the given status was prefetched in batch.
+func (b *statusBatch) has(s *gtsmodel.Status) bool {
+	if b == nil {
+		return false
+	}
+	_, ok := b.replies[s.ID]
+	return ok
+}
+
+// statusBatchFor prefetches counts and interactions for the
+// given statuses, including any boosted or quoted statuses.
+//
+// Requesting account can be nil.
+func (c *Converter) statusBatchFor(
+	ctx context.Context,
+	statuses []*gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) (*statusBatch, error) {
+	var (
+		statusIDs = make([]string, 0, len(statuses))
+		threadIDs = make([]string, 0, len(statuses))
+	)
+
+	appendIDs := func(s *gtsmodel.Status) {
+		statusIDs = append(statusIDs, s.ID)
+		if s.ThreadID != "" {
+			threadIDs = append(threadIDs, s.ThreadID)
+		}
+	}
+
+	for _, s := range statuses {
+		appendIDs(s)
+		if s.BoostOf != nil {
+			appendIDs(s.BoostOf)
+		}
+		if s.QuoteOf != nil {
+			appendIDs(s.QuoteOf)
+		}
+	}
+
+	// Deduplicate IDs before querying.
+	statusIDs = util.Deduplicate(statusIDs)
+	threadIDs = util.Deduplicate(threadIDs)
+
+	var (
+		batch statusBatch
+		err   error
+	)
+
+	batch.replies, err = c.state.DB.CountStatusRepliesIn(ctx, statusIDs)
+	if err != nil {
+		return nil, gtserror.Newf("error counting replies: %w", err)
+	}
+
+	batch.boosts, err = c.state.DB.CountStatusBoostsIn(ctx, statusIDs)
+	if err != nil {
+		return nil, gtserror.Newf("error counting reblogs: %w", err)
+	}
+
+	batch.faves, err = c.state.DB.CountStatusFavesIn(ctx, statusIDs)
+	if err != nil {
+		return nil, gtserror.Newf("error counting faves: %w", err)
+	}
+
+	if requestingAccount == nil {
+		// No interactions
+		// to prefetch.
+		return &batch, nil
+	}
+
+	faved, err := c.state.DB.GetFavedStatusIDsIn(ctx, statusIDs, requestingAccount.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting faved statuses: %w", err)
+	}
+	batch.faved = toSet(faved)
+
+	boosted, err := c.state.DB.GetBoostedStatusIDsIn(ctx, statusIDs, requestingAccount.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting reblogged statuses: %w", err)
+	}
+	batch.boosted = toSet(boosted)
+
+	bookmarked, err := c.state.DB.GetBookmarkedStatusIDsIn(ctx, statusIDs, requestingAccount.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting bookmarked statuses: %w", err)
+	}
+	batch.bookmarked = toSet(bookmarked)
+
+	muted, err := c.state.DB.GetMutedThreadIDsIn(ctx, threadIDs, requestingAccount.ID)
+	if err != nil {
+		return nil, gtserror.Newf("error getting muted threads: %w", err)
+	}
+	batch.muted = toSet(muted)
+
+	return &batch, nil
+}
+
+// toSet converts the given slice of IDs to a set.
+func toSet(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// animationDisabled returns whether static versions of
+// animated avatars, headers and emojis should be served in
+// place of the originals, either because the instance has
+// disabled animation or the requester has asked for it.
+func animationDisabled(ctx context.Context) bool {
+	return config.GetMediaDisableAnimation() ||
+		gtscontext.AnimationDisabled(ctx)
+}
+
+// staticOr returns staticURL if animation is disabled
+// for this context and staticURL is set, else original.
+func staticOr(ctx context.Context, original string, staticURL string) string {
+	if staticURL != "" && animationDisabled(ctx) {
+		return staticURL
+	}
+	return original
+}
+
+// countsForStatus returns the replies, boosts and faves
+// counts for the given status, using batch if it contains
+// the status, else falling back to querying the database.
+func (c *Converter) countsForStatus(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	batch *statusBatch,
+) (replies int, boosts int, faves int, err error) {
+	if batch.has(s) {
+		return batch.replies[s.ID], batch.boosts[s.ID], batch.faves[s.ID], nil
+	}
+
+	replies, err = c.state.DB.CountStatusReplies(ctx, s.ID)
+	if err != nil {
+		return 0, 0, 0, gtserror.Newf("error counting replies: %w", err)
+	}
+
+	boosts, err = c.state.DB.CountStatusBoosts(ctx, s.ID)
+	if err != nil {
+		return 0, 0, 0, gtserror.Newf("error counting reblogs: %w", err)
+	}
+
+	faves, err = c.state.DB.CountStatusFaves(ctx, s.ID)
+	if err != nil {
+		return 0, 0, 0, gtserror.Newf("error counting faves: %w", err)
+	}
+
+	return replies, boosts, faves, nil
+}
+
+func (c *Converter) interactionsWithStatusForAccount(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account, batch *statusBatch) (*statusInteractions, error) {
 	si := &statusInteractions{}
 
-	if requestingAccount != nil {
+	if requestingAccount != nil && batch.has(s) {
+		// Use prefetched interactions.
+		_, si.Faved = batch.faved[s.ID]
+		_, si.Reblogged = batch.boosted[s.ID]
+		_, si.Muted = batch.muted[s.ThreadID]
+		_, si.Bookmarked = batch.bookmarked[s.ID]
+		if s.AccountID == requestingAccount.ID {
+			si.Pinned = !s.PinnedAt.IsZero()
+		}
+	} else if requestingAccount != nil {
 		faved, err := c.state.DB.IsStatusFavedBy(ctx, s.ID, requestingAccount.ID)
 		if err != nil {
 			return nil, fmt.Errorf("error checking if requesting account has faved status: %s", err)
@@ -109,11 +297,25 @@ func misskeyReportInlineURLs(content string) []*url.URL {
 //	   <li><a href="http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE7ZGJYTSYMXF927GF9353KR.svg" rel="nofollow noreferrer noopener" target="_blank">01HE7ZGJYTSYMXF927GF9353KR.svg</a> [SVG line art of a sloth, public domain]</li>
 //	   <li><a href="http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE892Y8ZS68TQCNPX7J888P3.mp3" rel="nofollow noreferrer noopener" target="_blank">01HE892Y8ZS68TQCNPX7J888P3.mp3</a> [Jolly salsa song, public domain.]</li>
 //	</ul>
+//
+// There's no limit on the number of attachments handled
+// here: statuses created before the configured limits
+// were lowered, or federated in from instances with higher
+// limits, may well have more attachments than we'd allow.
 func placeholdUnknownAttachments(arr []*apimodel.Attachment) (string, []*apimodel.Attachment) {
+	// Work on a copy, so as not to shift
+	// around the elements of caller's slice.
+	arr = slices.Clone(arr)
+
 	// Extract unknown-type attachments into a separate
 	// slice, deleting them from arr in the process.
 	var unknowns []*apimodel.Attachment
 	arr = slices.DeleteFunc(arr, func(elem *apimodel.Attachment) bool {
+		if elem == nil {
+			// Nothing to show.
+			return true
+		}
+
 		unknown := elem.Type == "unknown"
 		if unknown {
 			// Set aside unknown-type attachment.
@@ -151,11 +353,21 @@ func placeholdUnknownAttachments(arr []*apimodel.Attachment) (string, []*apimode
 	note.WriteString(`</i></p>`)
 	note.WriteString(`<ul>`)
 	for _, a := range unknowns {
-		var (
-			remoteURL = *a.RemoteURL
-			base      = path.Base(remoteURL)
-			entry     = fmt.Sprintf(`<a href="%s">%s</a>`, remoteURL, base)
-		)
+		// Prefer linking to the remote
+		// original, but local unknowns
+		// only have our own URL.
+		var link string
+		switch {
+		case a.RemoteURL != nil && *a.RemoteURL != "":
+			link = *a.RemoteURL
+		case a.URL != nil && *a.URL != "":
+			link = *a.URL
+		}
+
+		entry := a.ID
+		if link != "" {
+			entry = fmt.Sprintf(`<a href="%s">%s</a>`, link, path.Base(link))
+		}
 		if d := a.Description; d != nil && *d != "" {
 			entry += ` [` + *d + `]`
 		}
@@ -264,3 +476,178 @@ func ContentToContentLanguage(
 
 	return contentStr, langTagStr
 }
+
+// HistoryDays is the amount of days
+// of usage history given for hashtags
+// and links, including today.
+const HistoryDays = 7
+
+// HistoryStart returns the start of the
+// first day of usage history, given now.
+func HistoryStart(now time.Time) time.Time {
+	today := now.UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, -(HistoryDays - 1))
+}
+
+// UsesToAPIHistory buckets the given uses into per-day usage history
+// over the past HistoryDays days, starting with today (UTC). getUse
+// should return the time of the given use, and the ID of the account
+// that made it. Uses older than the history window are ignored.
+func UsesToAPIHistory[T any](now time.Time, uses []T, getUse func(T) (time.Time, string)) []apimodel.History {
+	type day struct {
+		uses     int
+		accounts map[string]struct{}
+	}
+
+	var (
+		today = now.UTC().Truncate(24 * time.Hour)
+		days  = make([]day, HistoryDays)
+	)
+
+	for _, use := range uses {
+		createdAt, accountID := getUse(use)
+
+		// Days before today; 0 is today.
+		i := int(today.Sub(createdAt.UTC().Truncate(24*time.Hour)) / (24 * time.Hour))
+		if i < 0 || i >= HistoryDays {
+			continue
+		}
+
+		if days[i].accounts == nil {
+			days[i].accounts = make(map[string]struct{})
+		}
+
+		days[i].uses++
+		days[i].accounts[accountID] = struct{}{}
+	}
+
+	history := make([]apimodel.History, HistoryDays)
+	for i, d := range days {
+		history[i] = apimodel.History{
+			Day:      strconv.FormatInt(today.AddDate(0, 0, -i).Unix(), 10),
+			Uses:     strconv.Itoa(d.uses),
+			Accounts: strconv.Itoa(len(d.accounts)),
+		}
+	}
+
+	return history
+}
+
+// nameIsTitle returns whether the name property of
+// the given AS type name should be treated as a title
+// (and therefore rendered into content), rather than
+// as a content warning.
+func nameIsTitle(typeName string) bool {
+	switch typeName {
+	case ap.ObjectArticle,
+		ap.ObjectAudio,
+		ap.ObjectEvent:
+		return true
+	default:
+		return false
+	}
+}
+
+// titleHeading returns the name of given
+// type as an html heading, or empty string.
+func titleHeading(with ap.WithName) string {
+	name := ap.ExtractName(with)
+	if name == "" {
+		return ""
+	}
+	return `<h1>` + html.EscapeString(name) + `</h1>`
+}
+
+// articleContent renders the title of an Article
+// (eg., from write.as) as a heading above its
+// (full, unmodified) html content.
+func articleContent(article ap.Statusable, content string) string {
+	return titleHeading(article) + content
+}
+
+// eventContent renders the title and structured
+// start / end times of an Event (eg., from Mobilizon)
+// into html content, followed by event description.
+func eventContent(event ap.Statusable, content string) string {
+	var b strings.Builder
+	b.WriteString(titleHeading(event))
+
+	var start, end time.Time
+	if withStart, ok := event.(ap.WithStartTime); ok {
+		start = ap.GetStartTime(withStart)
+	}
+	if withEnd, ok := event.(ap.WithEndTime); ok {
+		end = ap.GetEndTime(withEnd)
+	}
+
+	if !start.IsZero() || !end.IsZero() {
+		b.WriteString(`<p>`)
+		if !start.IsZero() {
+			b.WriteString(`Starts: ` + htmlTime(start))
+		}
+		if !start.IsZero() && !end.IsZero() {
+			b.WriteString(`<br>`)
+		}
+		if !end.IsZero() {
+			b.WriteString(`Ends: ` + htmlTime(end))
+		}
+		b.WriteString(`</p>`)
+	}
+
+	b.WriteString(content)
+	return b.String()
+}
+
+// audioContent falls back to rendering the title of
+// an Audio object (eg., from Funkwhale) as content if
+// no content was set, so the status isn't left empty.
+func audioContent(audio ap.Statusable, content string) string {
+	if content != "" {
+		return content
+	}
+	if name := ap.ExtractName(audio); name != "" {
+		return `<p>` + html.EscapeString(name) + `</p>`
+	}
+	return ""
+}
+
+// htmlTime renders the given time as an html time
+// element, in a human readable format in UTC.
+func htmlTime(t time.Time) string {
+	t = t.UTC()
+	return `<time datetime="` + t.Format(time.RFC3339) + `">` +
+		t.Format("Monday, 2 January 2006 15:04 MST") + `</time>`
+}
+
+// pollExpiryStr returns a human-readable
+// description of when a poll closes, eg.,
+// "closes in 3 hours", or "closed".
+func pollExpiryStr(expiresAt time.Time, expired bool) string {
+	switch {
+	case expired:
+		return "closed"
+	case expiresAt.IsZero():
+		return "open indefinitely"
+	}
+
+	until := time.Until(expiresAt)
+	switch {
+	case until < time.Minute:
+		return "closes in less than a minute"
+	case until < time.Hour:
+		return "closes in " + plural(int(until/time.Minute), "minute")
+	case until < 48*time.Hour:
+		return "closes in " + plural(int(until/time.Hour), "hour")
+	default:
+		return "closes in " + plural(int(until/(24*time.Hour)), "day")
+	}
+}
+
+// plural formats n followed by unit,
+// pluralized with "s" if n is not 1.
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
@@ -19,8 +19,11 @@ package typeutils
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -158,3 +161,64 @@ func TestContentToContentLanguage(t *testing.T) {
 		}
 	}
 }
+
+func TestPlaceholdUnknownAttachmentsMany(t *testing.T) {
+	// Well over any sensible configured limit.
+	const count = 30
+
+	arr := make([]*apimodel.Attachment, 0, count+1)
+	for i := 0; i < count; i++ {
+		a := &apimodel.Attachment{
+			ID:   fmt.Sprintf("01HE7ZGJYTSYMXF927GF9353%02d", i),
+			Type: "image",
+		}
+
+		if i%3 == 0 {
+			// Every third one is unknown.
+			a.Type = "unknown"
+			remoteURL := fmt.Sprintf("https://example.org/media/%02d.svg", i)
+			a.RemoteURL = &remoteURL
+		}
+
+		arr = append(arr, a)
+	}
+
+	// Local unknown with no remote URL.
+	localURL := "http://localhost:8080/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/local.mp3"
+	arr[1].Type = "unknown"
+	arr[1].URL = &localURL
+
+	// Attachment missing from the db.
+	arr = append(arr, nil)
+
+	orig := make([]*apimodel.Attachment, len(arr))
+	copy(orig, arr)
+
+	note, known := placeholdUnknownAttachments(arr)
+
+	// 10 remote unknowns, 1 local unknown, 1 nil.
+	if l := len(known); l != count-11 {
+		t.Fatalf("wanted %d known attachments, got %d", count-11, l)
+	}
+
+	for _, a := range known {
+		if a == nil || a.Type == "unknown" {
+			t.Fatalf("unexpected attachment in known: %+v", a)
+		}
+	}
+
+	if c := strings.Count(note, "<li>"); c != 11 {
+		t.Fatalf("wanted 11 list entries in note, got %d: %s", c, note)
+	}
+
+	if !strings.Contains(note, `<a href="`+localURL+`">local.mp3</a>`) {
+		t.Fatalf("local unknown attachment not linked in note: %s", note)
+	}
+
+	// Caller's slice should be untouched.
+	for i := range orig {
+		if arr[i] != orig[i] {
+			t.Fatalf("input slice modified at index %d", i)
+		}
+	}
+}
@@ -27,24 +27,25 @@ import (
 )
 
 const (
-	UsersPath        = "users"         // UsersPath is for serving users info
-	StatusesPath     = "statuses"      // StatusesPath is for serving statuses
-	InboxPath        = "inbox"         // InboxPath represents the activitypub inbox location
-	OutboxPath       = "outbox"        // OutboxPath represents the activitypub outbox location
-	FollowersPath    = "followers"     // FollowersPath represents the activitypub followers location
-	FollowingPath    = "following"     // FollowingPath represents the activitypub following location
-	LikedPath        = "liked"         // LikedPath represents the activitypub liked location
-	CollectionsPath  = "collections"   // CollectionsPath represents the activitypub collections location
-	FeaturedPath     = "featured"      // FeaturedPath represents the activitypub featured location
-	PublicKeyPath    = "main-key"      // PublicKeyPath is for serving an account's public key
-	FollowPath       = "follow"        // FollowPath used to generate the URI for an individual follow or follow request
-	UpdatePath       = "updates"       // UpdatePath is used to generate the URI for an account update
-	BlocksPath       = "blocks"        // BlocksPath is used to generate the URI for a block
-	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
-	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
-	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
-	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
-	TagsPath         = "tags"          // TagsPath represents the activitypub tags location
+	UsersPath          = "users"           // UsersPath is for serving users info
+	StatusesPath       = "statuses"        // StatusesPath is for serving statuses
+	InboxPath          = "inbox"           // InboxPath represents the activitypub inbox location
+	OutboxPath         = "outbox"          // OutboxPath represents the activitypub outbox location
+	FollowersPath      = "followers"       // FollowersPath represents the activitypub followers location
+	FollowingPath      = "following"       // FollowingPath represents the activitypub following location
+	LikedPath          = "liked"           // LikedPath represents the activitypub liked location
+	CollectionsPath    = "collections"     // CollectionsPath represents the activitypub collections location
+	FeaturedPath       = "featured"        // FeaturedPath represents the activitypub featured location
+	PublicKeyPath      = "main-key"        // PublicKeyPath is for serving an account's public key
+	FollowPath         = "follow"          // FollowPath used to generate the URI for an individual follow or follow request
+	UpdatePath         = "updates"         // UpdatePath is used to generate the URI for an account update
+	BlocksPath         = "blocks"          // BlocksPath is used to generate the URI for a block
+	ReportsPath        = "reports"         // ReportsPath is used to generate the URI for a report/flag
+	ConfirmEmailPath   = "confirm_email"   // ConfirmEmailPath is used to generate the URI for an email confirmation link
+	CancelDeletionPath = "cancel_deletion" // CancelDeletionPath is used to generate the URI for an account deletion cancellation link
+	FileserverPath     = "fileserver"      // FileserverPath is a path component for serving attachments + media
+	EmojiPath          = "emoji"           // EmojiPath represents the activitypub emoji location
+	TagsPath           = "tags"            // TagsPath represents the activitypub tags location
 )
 
 // UserURIs contains a bunch of UserURIs and URLs for a user, host, account, etc.
@@ -127,6 +128,14 @@ func GenerateURIForEmailConfirm(token string) string {
 	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ConfirmEmailPath, token)
 }
 
+// GenerateURIForDeletionCancel returns a link for cancelling account deletion -- something like:
+// https://example.org/cancel_deletion?token=490e337c-0162-454f-ac48-4b22bb92a205
+func GenerateURIForDeletionCancel(token string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, CancelDeletionPath, token)
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string) *UserURIs {
 	protocol := config.GetProtocol()
@@ -193,6 +202,22 @@ func URIForAttachment(
 	)
 }
 
+// URIForAccountArchive generates a signed
+// fileserver URI for downloading an account archive.
+//
+// Will produce something like:
+//
+//	"https://example.org/fileserver/01FPST95B8FC3HG3AGCDKPQNQ2/archive/original/01HT0GQ2RSB0DGT2TGSAH5QSQ6.zip?token=490e337c-0162-454f-ac48-4b22bb92a205"
+func URIForAccountArchive(accountID string, archiveID string, token string) string {
+	return URIForAttachment(
+		accountID,
+		"archive",
+		"original",
+		archiveID,
+		"zip",
+	) + "?token=" + url.QueryEscape(token)
+}
+
 // StoragePathForAttachment generates a storage
 // path for an attachment/emoji/header etc.
 //
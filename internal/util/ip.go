@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"net"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+var (
+	// Masks used for truncating IP addresses.
+	ipv4TruncateMask = net.CIDRMask(24, 32)
+	ipv6TruncateMask = net.CIDRMask(48, 128)
+)
+
+// RetainIP returns the given IP address in the form that it may
+// be stored (or shown) in, according to accounts-ip-retention:
+// nil for "none", with the host part zeroed for "truncated", or
+// unchanged for "full". Nil is returned for a nil or invalid IP.
+func RetainIP(ip net.IP) net.IP {
+	if len(ip) != net.IPv4len &&
+		len(ip) != net.IPv6len {
+		return nil
+	}
+
+	switch config.GetAccountsIPRetention() {
+	case config.AccountsIPRetentionFull:
+		return ip
+
+	case config.AccountsIPRetentionTruncated:
+		return TruncateIP(ip)
+
+	default: // none
+		return nil
+	}
+}
+
+// TruncateIP returns only the network part of the
+// given IP address, ie., the first 24 bits of an
+// IPv4 address, or the first 48 bits of an IPv6 one.
+func TruncateIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(ipv4TruncateMask)
+	}
+	return ip.Mask(ipv6TruncateMask)
+}
@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func TestRetainIP(t *testing.T) {
+	for _, test := range []struct {
+		retention string
+		in        net.IP
+		out       string
+	}{
+		{retention: config.AccountsIPRetentionFull, in: net.ParseIP("192.0.2.123"), out: "192.0.2.123"},
+		{retention: config.AccountsIPRetentionFull, in: nil, out: "<nil>"},
+		{retention: config.AccountsIPRetentionTruncated, in: net.ParseIP("192.0.2.123"), out: "192.0.2.0"},
+		{retention: config.AccountsIPRetentionTruncated, in: net.ParseIP("192.0.2.123").To4(), out: "192.0.2.0"},
+		{retention: config.AccountsIPRetentionTruncated, in: net.ParseIP("2001:db8:1234:5678::1"), out: "2001:db8:1234::"},
+		{retention: config.AccountsIPRetentionNone, in: net.ParseIP("192.0.2.123"), out: "<nil>"},
+		{retention: config.AccountsIPRetentionFull, in: net.IP("192.0.2.123"), out: "<nil>"},
+	} {
+		config.SetAccountsIPRetention(test.retention)
+		if out := util.RetainIP(test.in).String(); out != test.out {
+			t.Errorf("RetainIP(%s) with retention %s: expected %s, got %s", test.in, test.retention, test.out, out)
+		}
+	}
+}
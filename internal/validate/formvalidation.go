@@ -21,29 +21,34 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"slices"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	pwv "github.com/wagslane/go-password-validator"
 	"golang.org/x/text/language"
 )
 
 const (
-	maximumPasswordLength         = 72 // 72 bytes is the maximum length afforded by bcrypt. See https://pkg.go.dev/golang.org/x/crypto/bcrypt#GenerateFromPassword.
-	minimumPasswordEntropy        = 60 // Heuristic for password strength. See https://github.com/wagslane/go-password-validator.
-	minimumReasonLength           = 40
-	maximumReasonLength           = 500
-	maximumSiteTitleLength        = 40
-	maximumShortDescriptionLength = 500
-	maximumDescriptionLength      = 5000
-	maximumSiteTermsLength        = 5000
-	maximumUsernameLength         = 64
-	maximumEmojiCategoryLength    = 64
-	maximumProfileFieldLength     = 255
-	maximumProfileFields          = 6
-	maximumListTitleLength        = 200
+	maximumPasswordLength          = 72 // 72 bytes is the maximum length afforded by bcrypt. See https://pkg.go.dev/golang.org/x/crypto/bcrypt#GenerateFromPassword.
+	minimumPasswordEntropy         = 60 // Heuristic for password strength. See https://github.com/wagslane/go-password-validator.
+	minimumReasonLength            = 40
+	maximumReasonLength            = 500
+	maximumSiteTitleLength         = 40
+	maximumShortDescriptionLength  = 500
+	maximumDescriptionLength       = 5000
+	maximumSiteTermsLength         = 5000
+	maximumUsernameLength          = 64
+	maximumEmojiCategoryLength     = 64
+	maximumProfileFieldLength      = 255
+	maximumProfileFields           = 6
+	maximumListTitleLength         = 200
+	maximumAutoAcceptOlderThanDays = 3650 // Ten years.
+	maximumStatusesAutoDeleteDays  = 3650 // Ten years.
 )
 
 // Password returns a helpful error if the given password
@@ -114,21 +119,24 @@ func Language(lang string) (string, error) {
 	return parsed.String(), err
 }
 
-// SignUpReason checks that a sufficient reason is given for a server signup request
+// SignUpReason checks that a sufficient reason is given for a server signup request.
+//
+// If no reason is required, a reason may still be given (it'll be shown to admins),
+// but it shouldn't be too long.
 func SignUpReason(reason string, reasonRequired bool) error {
-	if !reasonRequired {
-		// we don't care!
-		// we're not going to do anything with this text anyway if no reason is required
+	if !reasonRequired && reason == "" {
+		// Fine, nothing given
+		// and nothing needed.
 		return nil
 	}
 
-	if reason == "" {
+	if reasonRequired && reason == "" {
 		return errors.New("no reason provided")
 	}
 
 	length := len([]rune(reason))
 
-	if length < minimumReasonLength {
+	if reasonRequired && length < minimumReasonLength {
 		return fmt.Errorf("reason should be at least %d chars but '%s' was %d", minimumReasonLength, reason, length)
 	}
 
@@ -150,6 +158,17 @@ func Note(note string) error {
 	return nil
 }
 
+// ProfileMediaDescription checks that a given avatar
+// or header description is not longer than the
+// configured maximum media description length.
+func ProfileMediaDescription(description string) error {
+	maxDescriptionChars := config.GetMediaDescriptionMaxChars()
+	if length := len([]rune(description)); length > maxDescriptionChars {
+		return fmt.Errorf("description must be less than %d characters, but submitted description was %d characters", maxDescriptionChars, length)
+	}
+	return nil
+}
+
 // Privacy checks that the desired privacy setting is valid
 func Privacy(privacy string) error {
 	if privacy == "" {
@@ -162,16 +181,73 @@ func Privacy(privacy string) error {
 	return fmt.Errorf("privacy '%s' was not recognized, valid options are 'direct', 'mutuals_only', 'private', 'public', 'unlisted'", privacy)
 }
 
+// BoostVisibility checks that the desired boost visibility is valid. Empty
+// string is allowed, meaning the boosted status' visibility should be used.
+func BoostVisibility(visibility string) error {
+	switch apimodel.Visibility(visibility) {
+	case "", apimodel.VisibilityPublic, apimodel.VisibilityUnlisted, apimodel.VisibilityPrivate:
+		return nil
+	}
+	return fmt.Errorf("boost visibility '%s' was not recognized, valid options are 'public', 'unlisted', 'private'", visibility)
+}
+
 // StatusContentType checks that the desired status format setting is valid.
 func StatusContentType(statusContentType string) error {
 	if statusContentType == "" {
 		return fmt.Errorf("empty string for status format not allowed")
 	}
-	switch apimodel.StatusContentType(statusContentType) {
-	case apimodel.StatusContentTypePlain, apimodel.StatusContentTypeMarkdown:
+	if slices.Contains(apimodel.StatusContentTypesSupported, statusContentType) {
 		return nil
 	}
-	return fmt.Errorf("status content type '%s' was not recognized, valid options are 'text/plain', 'text/markdown'", statusContentType)
+	validOptions := "'" + strings.Join(apimodel.StatusContentTypesSupported, "', '") + "'"
+	return fmt.Errorf("status content type '%s' was not recognized, valid options are %s", statusContentType, validOptions)
+}
+
+// ExpandMedia checks that the desired media display setting is valid.
+func ExpandMedia(expandMedia string) error {
+	if slices.Contains(apimodel.ExpandMediaSupported, expandMedia) {
+		return nil
+	}
+	validOptions := "'" + strings.Join(apimodel.ExpandMediaSupported, "', '") + "'"
+	return fmt.Errorf("expand media '%s' was not recognized, valid options are %s", expandMedia, validOptions)
+}
+
+// AutoAcceptOlderThanDays checks that the desired minimum account
+// age, in days, for automatically accepting follow requests is valid.
+func AutoAcceptOlderThanDays(days int) error {
+	if days < 0 || days > maximumAutoAcceptOlderThanDays {
+		return fmt.Errorf("auto accept older than days must be between 0 and %d, provided: %d", maximumAutoAcceptOlderThanDays, days)
+	}
+	return nil
+}
+
+// StatusesAutoDeleteAfterDays checks that the desired age, in days,
+// after which statuses should be automatically deleted is valid.
+func StatusesAutoDeleteAfterDays(days int) error {
+	if days < 0 || days > maximumStatusesAutoDeleteDays {
+		return fmt.Errorf("statuses auto delete after days must be between 0 and %d, provided: %d", maximumStatusesAutoDeleteDays, days)
+	}
+	return nil
+}
+
+// InteractionPolicy checks that each value in the given status interaction policy is valid.
+func InteractionPolicy(policy *apimodel.StatusInteractionPolicy) error {
+	for name, value := range map[string]apimodel.InteractionPolicyValue{
+		"replies": policy.Replies,
+		"boosts":  policy.Boosts,
+		"likes":   policy.Likes,
+	} {
+		switch value {
+		case "",
+			apimodel.InteractionPolicyAnyone,
+			apimodel.InteractionPolicyFollowers,
+			apimodel.InteractionPolicyMentioned,
+			apimodel.InteractionPolicyNobody:
+			continue
+		}
+		return fmt.Errorf("interaction policy %s value '%s' was not recognized, valid options are 'anyone', 'followers', 'mentioned', 'nobody'", name, value)
+	}
+	return nil
 }
 
 func CustomCSS(customCSS string) error {
@@ -184,6 +260,12 @@ func CustomCSS(customCSS string) error {
 		return fmt.Errorf("custom_css must be less than %d characters, but submitted custom_css was %d characters", maximumCustomCSSLength, length)
 	}
 
+	if !config.GetAccountsCustomCSSAllowRemoteImports() {
+		if _, stripped := text.StripCSSRemoteImports(customCSS, config.GetHost(), config.GetAccountDomain()); stripped {
+			return errors.New("custom_css must not @import stylesheets from other hosts")
+		}
+	}
+
 	return nil
 }
 
@@ -215,8 +215,8 @@ func (suite *ValidationTestSuite) TestValidateReason() {
 	}
 
 	err = validate.SignUpReason(tooLong, false)
-	if suite.NoError(err) {
-		suite.Equal(nil, err)
+	if suite.Error(err) {
+		suite.Equal(errors.New("reason should be no more than 500 chars but given reason was 600"), err)
 	}
 
 	err = validate.SignUpReason(goodReason, false)
@@ -295,6 +295,33 @@ func (suite *ValidationTestSuite) TestValidateCustomCSSEnabled() {
 	suite.NoError(err)
 }
 
+func (suite *ValidationTestSuite) TestValidateCustomCSSRemoteImport() {
+	config.SetAccountsAllowCustomCSS(true)
+	config.SetAccountsCustomCSSLength(10000)
+	config.SetAccountsCustomCSSAllowRemoteImports(false)
+
+	for _, css := range []string{
+		`@import "https://example.org/evil.css";`,
+		`@import url(//example.org/evil.css);`,
+		`body { color: red; } @IMPORT url('http://example.org/evil.css') screen;`,
+	} {
+		err := validate.CustomCSS(css)
+		suite.EqualError(err, "custom_css must not @import stylesheets from other hosts", css)
+	}
+
+	for _, css := range []string{
+		`@import "/assets/dist/status.css";`,
+		`@import url("http://localhost:8080/assets/dist/status.css");`,
+	} {
+		err := validate.CustomCSS(css)
+		suite.NoError(err, css)
+	}
+
+	config.SetAccountsCustomCSSAllowRemoteImports(true)
+	err := validate.CustomCSS(`@import "https://example.org/evil.css";`)
+	suite.NoError(err)
+}
+
 func (suite *ValidationTestSuite) TestValidateCustomCSSTooLong() {
 	config.SetAccountsAllowCustomCSS(true)
 	config.SetAccountsCustomCSSLength(5)
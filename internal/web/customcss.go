@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -55,3 +56,20 @@ func (m *Module) customCSSGETHandler(c *gin.Context) {
 	c.Header(cacheControlHeader, cacheControlNoCache)
 	c.Data(http.StatusOK, textCSSUTF8, []byte(customCSS))
 }
+
+// accountStylesheets appends the stylesheet of the given
+// account's theme to stylesheets, followed by the account's
+// custom CSS if accounts are allowed to set custom CSS on
+// this instance. Unknown themes are skipped, so that the
+// default theme is used instead.
+func (m *Module) accountStylesheets(account *apimodel.Account, stylesheets ...string) []string {
+	if theme := m.processor.Account().ThemeStylesheet(account.Theme); theme != "" {
+		stylesheets = append(stylesheets, theme)
+	}
+
+	if config.GetAccountsAllowCustomCSS() {
+		stylesheets = append(stylesheets, "/@"+account.Username+"/custom.css")
+	}
+
+	return stylesheets
+}
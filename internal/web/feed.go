@@ -20,48 +20,136 @@ package web
 import (
 	"bytes"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 )
 
-const appRSSUTF8 = string(apiutil.AppRSSXML) + "; charset=utf-8"
+// negotiateFeedFormat picks which of the given formats
+// to serve, based on the Accept headers of the request.
+// The first format is served if the caller accepts any.
+func negotiateFeedFormat(c *gin.Context, formats ...account.FeedFormat) (account.FeedFormat, gtserror.WithCode) {
+	offers := make([]string, len(formats))
+	for i, format := range formats {
+		offers[i] = format.ContentType()
+	}
 
-func (m *Module) rssFeedGETHandler(c *gin.Context) {
-	if _, err := apiutil.NegotiateAccept(c, apiutil.AppRSSXML); err != nil {
-		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
-		return
+	contentType, err := apiutil.NegotiateAccept(c, offers...)
+	if err != nil {
+		return nil, gtserror.NewErrorNotAcceptable(err, err.Error())
 	}
 
-	// Fetch + normalize username from URL.
-	username, errWithCode := apiutil.ParseWebUsername(c.Param(apiutil.WebUsernameKey))
-	if errWithCode != nil {
-		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-		return
+	for _, format := range formats {
+		if format.ContentType() == contentType {
+			return format, nil
+		}
 	}
 
-	// Usernames on our instance will always be lowercase.
-	//
-	// todo: https://github.com/superseriousbusiness/gotosocial/issues/1813
-	username = strings.ToLower(username)
-
-	// Retrieve the getRSSFeed function from the processor.
-	// We'll only call the function if we need to, to save db calls.
-	// lastPostAt may be a zero time if account has never posted.
-	getRSSFeed, lastPostAt, errWithCode := m.processor.Account().GetRSSFeedForUsername(c.Request.Context(), username)
-	if errWithCode != nil {
-		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-		return
+	// Should never happen.
+	return formats[0], nil
+}
+
+// accountFeedGETHandler returns a handler serving the feed of the
+// account in the request path, in one of the given formats.
+func (m *Module) accountFeedGETHandler(formats ...account.FeedFormat) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, errWithCode := negotiateFeedFormat(c, formats...)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		// Fetch + normalize username from URL.
+		username, errWithCode := apiutil.ParseWebUsername(c.Param(apiutil.WebUsernameKey))
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		// Usernames on our instance will always be lowercase.
+		//
+		// todo: https://github.com/superseriousbusiness/gotosocial/issues/1813
+		username = strings.ToLower(username)
+
+		limit, errWithCode := parseFeedLimit(c)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		// Retrieve the getFeed function from the processor.
+		// We'll only call the function if we need to, to save db calls.
+		// lastPostAt may be a zero time if account has never posted.
+		getFeed, lastPostAt, errWithCode := m.processor.Account().GetFeedForUsername(c.Request.Context(), username, format, limit)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		m.serveFeed(c, format, limit, getFeed, lastPostAt)
+	}
+}
+
+// instanceFeedGETHandler returns a handler serving the
+// instance-wide feed, in one of the given formats.
+func (m *Module) instanceFeedGETHandler(formats ...account.FeedFormat) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, errWithCode := negotiateFeedFormat(c, formats...)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		limit, errWithCode := parseFeedLimit(c)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		getFeed, lastPostAt, errWithCode := m.processor.Account().GetInstanceFeed(c.Request.Context(), format, limit)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		m.serveFeed(c, format, limit, getFeed, lastPostAt)
 	}
+}
+
+// parseFeedLimit parses the limit query parameter of a feed
+// request, clamping it to the configured instance-rss-max-items.
+func parseFeedLimit(c *gin.Context) (int, gtserror.WithCode) {
+	max := config.GetInstanceRSSMaxItems()
+	return apiutil.ParseLimit(c.Query(apiutil.LimitKey), min(account.FeedLength, max), max, 1)
+}
 
+// serveFeed serves the feed returned by getFeed, using
+// the eTag cache and the given lastPostAt time to respond
+// with 304 Not Modified where possible, without generating
+// the feed.
+func (m *Module) serveFeed(
+	c *gin.Context,
+	format account.FeedFormat,
+	limit int,
+	getFeed account.GetFeed,
+	lastPostAt time.Time,
+) {
 	var (
-		rssFeed string // Stringified rss feed.
+		feed        string // Stringified feed.
+		errWithCode gtserror.WithCode
 
-		cacheKey              = c.Request.URL.Path
+		// Feeds with different formats
+		// or limits have different content.
+		cacheKey = c.Request.URL.Path +
+			"?format=" + format.ContentType() +
+			"&limit=" + strconv.Itoa(limit)
 		cacheEntry, wasCached = m.eTagCache.Get(cacheKey)
 	)
 
@@ -71,14 +159,14 @@ func (m *Module) rssFeedGETHandler(c *gin.Context) {
 		// the cache entry was last generated).
 		//
 		// As such, we need to generate a new ETag, and for that we need
-		// the string representation of the RSS feed.
-		rssFeed, errWithCode = getRSSFeed()
+		// the string representation of the feed.
+		feed, errWithCode = getFeed()
 		if errWithCode != nil {
 			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 			return
 		}
 
-		eTag, err := generateEtag(bytes.NewBufferString(rssFeed))
+		eTag, err := generateEtag(bytes.NewBufferString(feed))
 		if err != nil {
 			apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
 			return
@@ -141,23 +229,23 @@ func (m *Module) rssFeedGETHandler(c *gin.Context) {
 	}
 
 	// At this point we know that the client wants the newest
-	// representation of the RSS feed, either because they didn't
+	// representation of the feed, either because they didn't
 	// submit any 'If-None-Match' / 'If-Modified-Since' cache headers,
 	// or because they did but the account has posted more recently
 	// than the values of the submitted headers would suggest.
 	//
 	// If we had a cache hit earlier, we may not have called the
-	// getRSSFeed function yet; if that's the case then do call it
+	// getFeed function yet; if that's the case then do call it
 	// now because we definitely need it.
-	if rssFeed == "" {
-		rssFeed, errWithCode = getRSSFeed()
+	if feed == "" {
+		feed, errWithCode = getFeed()
 		if errWithCode != nil {
 			apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 			return
 		}
 	}
 
-	c.Data(http.StatusOK, appRSSUTF8, []byte(rssFeed))
+	c.Data(http.StatusOK, format.ContentType()+"; charset=utf-8", []byte(feed))
 }
 
 // unixAfter returns true if the unix value of t1
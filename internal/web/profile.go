@@ -32,6 +32,18 @@ import (
 )
 
 func (m *Module) profileGETHandler(c *gin.Context) {
+	m.serveProfile(c, false)
+}
+
+func (m *Module) profileMediaGETHandler(c *gin.Context) {
+	m.serveProfile(c, true)
+}
+
+// serveProfile serves the web profile of the account
+// targeted in the request URL. If mediaOnly is true, the
+// media tab of the profile is served, which shows only
+// statuses with attachments, and no pinned statuses.
+func (m *Module) serveProfile(c *gin.Context, mediaOnly bool) {
 	ctx := c.Request.Context()
 
 	// We'll need the instance later, and we can also use it
@@ -102,14 +114,16 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		return
 	}
 
-	// Only generate RSS link if account has RSS enabled.
-	var rssFeed string
+	// Only generate feed links if account has RSS enabled.
+	var rssFeed, atomFeed, jsonFeed string
 	if targetAccount.EnableRSS {
 		rssFeed = "/@" + targetAccount.Username + "/feed.rss"
+		atomFeed = "/@" + targetAccount.Username + "/feed.atom"
+		jsonFeed = "/@" + targetAccount.Username + "/feed.json"
 	}
 
-	// Only allow search engines / robots to
-	// index if account is discoverable.
+	// Only allow search engines / robots to index
+	// if account is discoverable, on any tab.
 	var robotsMeta string
 	if targetAccount.Discoverable {
 		robotsMeta = robotsMetaAllowSome
@@ -123,7 +137,7 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		pinnedStatuses []*apimodel.Status
 	)
 
-	if !paging {
+	if !paging && !mediaOnly {
 		// Client opened bare profile (from the top)
 		// so load + display pinned statuses.
 		pinnedStatuses, errWithCode = m.processor.Account().WebStatusesGetPinned(ctx, targetAccount.ID)
@@ -134,7 +148,7 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 	}
 
 	// Get statuses from maxStatusID onwards (or from top if empty string).
-	statusResp, errWithCode := m.processor.Account().WebStatusesGet(ctx, targetAccount.ID, maxStatusID)
+	statusResp, errWithCode := m.processor.Account().WebStatusesGet(ctx, targetAccount.ID, maxStatusID, mediaOnly)
 	if errWithCode != nil {
 		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 		return
@@ -144,20 +158,22 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		Template: "profile.tmpl",
 		Instance: instance,
 		OGMeta:   apiutil.OGBase(instance).WithAccount(targetAccount),
-		Stylesheets: []string{
+		// Theme + custom CSS for this user last in cascade.
+		Stylesheets: m.accountStylesheets(targetAccount,
 			cssFA, cssStatus, cssThread, cssProfile,
-			// Custom CSS for this user last in cascade.
-			"/@" + targetAccount.Username + "/custom.css",
-		},
+		),
 		Javascript: []string{jsFrontend},
 		Extra: map[string]any{
 			"account":          targetAccount,
 			"rssFeed":          rssFeed,
+			"atomFeed":         atomFeed,
+			"jsonFeed":         jsonFeed,
 			"robotsMeta":       robotsMeta,
 			"statuses":         statusResp.Items,
 			"statuses_next":    statusResp.NextLink,
 			"pinned_statuses":  pinnedStatuses,
 			"show_back_to_top": paging,
+			"media_only":       mediaOnly,
 		},
 	}
 
@@ -142,11 +142,10 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		Template: "thread.tmpl",
 		Instance: instance,
 		OGMeta:   apiutil.OGBase(instance).WithStatus(status),
-		Stylesheets: []string{
+		// Theme + custom CSS for the status author last in cascade.
+		Stylesheets: m.accountStylesheets(targetAccount,
 			cssFA, cssStatus, cssThread,
-			// Custom CSS for this user last in cascade.
-			"/@" + targetUsername + "/custom.css",
-		},
+		),
 		Javascript: []string{jsFrontend},
 		Extra: map[string]any{
 			"status":  status,
@@ -31,17 +31,25 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 const (
 	confirmEmailPath   = "/" + uris.ConfirmEmailPath
+	cancelDeletionPath = "/" + uris.CancelDeletionPath
 	profileGroupPath   = "/@:username"
 	statusPath         = "/statuses/:" + apiutil.WebStatusIDKey // leave out the '/@:username' prefix as this will be served within the profile group
+	profileMediaPath   = "/media"                               // leave out the '/@:username' prefix as this will be served within the profile group
 	tagsPath           = "/tags/:" + apiutil.TagNameKey
 	customCSSPath      = profileGroupPath + "/custom.css"
 	rssFeedPath        = profileGroupPath + "/feed.rss"
+	atomFeedPath       = profileGroupPath + "/feed.atom"
+	jsonFeedPath       = profileGroupPath + "/feed.json"
+	instanceRSSPath    = "/feed.rss"
+	instanceAtomPath   = "/feed.atom"
+	instanceJSONPath   = "/feed.json"
 	assetsPathPrefix   = "/assets"
 	distPathPrefix     = assetsPathPrefix + "/dist"
 	settingsPathPrefix = "/settings"
@@ -105,6 +113,7 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 		Directives: []string{"no-store"},
 	}))
 	profileGroup.Handle(http.MethodGet, "", m.profileGETHandler) // use empty path here since it's the base of the group
+	profileGroup.Handle(http.MethodGet, profileMediaPath, m.profileMediaGETHandler)
 	profileGroup.Handle(http.MethodGet, statusPath, m.threadGETHandler)
 
 	// Attach individual web handlers which require no specific middlewares
@@ -112,11 +121,28 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	r.AttachHandler(http.MethodGet, settingsPathPrefix, m.SettingsPanelHandler)
 	r.AttachHandler(http.MethodGet, settingsPanelGlob, m.SettingsPanelHandler)
 	r.AttachHandler(http.MethodGet, customCSSPath, m.customCSSGETHandler)
-	r.AttachHandler(http.MethodGet, rssFeedPath, m.rssFeedGETHandler)
+	r.AttachHandler(http.MethodGet, rssFeedPath, m.accountFeedGETHandler(
+		// The .rss path predates the others,
+		// so also negotiate other formats here.
+		account.FeedFormatRSS,
+		account.FeedFormatAtom,
+		account.FeedFormatJSON,
+	))
+	r.AttachHandler(http.MethodGet, atomFeedPath, m.accountFeedGETHandler(account.FeedFormatAtom))
+	r.AttachHandler(http.MethodGet, jsonFeedPath, m.accountFeedGETHandler(account.FeedFormatJSON))
+	r.AttachHandler(http.MethodGet, instanceRSSPath, m.instanceFeedGETHandler(
+		account.FeedFormatRSS,
+		account.FeedFormatAtom,
+		account.FeedFormatJSON,
+	))
+	r.AttachHandler(http.MethodGet, instanceAtomPath, m.instanceFeedGETHandler(account.FeedFormatAtom))
+	r.AttachHandler(http.MethodGet, instanceJSONPath, m.instanceFeedGETHandler(account.FeedFormatJSON))
 	r.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
+	r.AttachHandler(http.MethodGet, cancelDeletionPath, m.cancelDeletionGETHandler)
 	r.AttachHandler(http.MethodGet, robotsPath, m.robotsGETHandler)
 	r.AttachHandler(http.MethodGet, aboutPath, m.aboutGETHandler)
 	r.AttachHandler(http.MethodGet, domainBlockListPath, m.domainBlockListGETHandler)
+	r.AttachHandler(http.MethodGet, directoryPath, m.directoryGETHandler)
 	r.AttachHandler(http.MethodGet, tagsPath, m.tagGETHandler)
 
 	// Attach redirects from old endpoints to current ones for backwards compatibility
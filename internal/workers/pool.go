@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"sync/atomic"
+
+	"codeberg.org/gruf/go-runners"
+)
+
+// WorkerPool wraps runners.WorkerPool{} to keep
+// track of the number of functions currently being
+// processed, and the total processed since start.
+type WorkerPool struct {
+	runners.WorkerPool
+	inflight  atomic.Int64
+	processed atomic.Uint64
+}
+
+// Enqueue: see runners.WorkerPool{}.Enqueue().
+func (p *WorkerPool) Enqueue(fn runners.WorkerFunc) {
+	p.WorkerPool.Enqueue(p.wrap(fn))
+}
+
+// EnqueueCtx: see runners.WorkerPool{}.EnqueueCtx().
+func (p *WorkerPool) EnqueueCtx(ctx context.Context, fn runners.WorkerFunc) bool {
+	return p.WorkerPool.EnqueueCtx(ctx, p.wrap(fn))
+}
+
+// MustEnqueueCtx: see runners.WorkerPool{}.MustEnqueueCtx().
+func (p *WorkerPool) MustEnqueueCtx(ctx context.Context, fn runners.WorkerFunc) bool {
+	return p.WorkerPool.MustEnqueueCtx(ctx, p.wrap(fn))
+}
+
+// EnqueueNow: see runners.WorkerPool{}.EnqueueNow().
+func (p *WorkerPool) EnqueueNow(fn runners.WorkerFunc) bool {
+	return p.WorkerPool.EnqueueNow(p.wrap(fn))
+}
+
+// InFlight returns the number of
+// functions currently being processed.
+func (p *WorkerPool) InFlight() int {
+	return int(p.inflight.Load())
+}
+
+// Processed returns the total number
+// of functions processed by the pool.
+func (p *WorkerPool) Processed() uint64 {
+	return p.processed.Load()
+}
+
+// wrap wraps the given worker function
+// to update the pool's processing counts.
+func (p *WorkerPool) wrap(fn runners.WorkerFunc) runners.WorkerFunc {
+	if fn == nil {
+		// Let runners
+		// handle this.
+		return nil
+	}
+	return func(ctx context.Context) {
+		p.inflight.Add(1)
+		defer func() {
+			p.inflight.Add(-1)
+			p.processed.Add(1)
+		}()
+		fn(ctx)
+	}
+}
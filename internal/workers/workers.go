@@ -21,8 +21,9 @@ import (
 	"context"
 	"log"
 	"runtime"
+	"time"
 
-	"codeberg.org/gruf/go-runners"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/scheduler"
 )
@@ -33,11 +34,11 @@ type Workers struct {
 
 	// ClientAPI provides a worker pool that handles both
 	// incoming client actions, and our own side-effects.
-	ClientAPI runners.WorkerPool
+	ClientAPI WorkerPool
 
 	// Federator provides a worker pool that handles both
 	// incoming federated actions, and our own side-effects.
-	Federator runners.WorkerPool
+	Federator WorkerPool
 
 	// Enqueue functions for clientAPI / federator worker pools,
 	// these are pointers to Processor{}.Enqueue___() msg functions.
@@ -59,32 +60,76 @@ type Workers struct {
 	ProcessFromFediAPI   func(context.Context, messages.FromFediAPI) error
 
 	// Media manager worker pools.
-	Media runners.WorkerPool
+	Media WorkerPool
 
 	// prevent pass-by-value.
 	_ nocopy
 }
 
-// Start will start all of the contained worker pools (and global scheduler).
+// Start will start all of the contained worker pools (and global scheduler),
+// sized according to configuration, else defaulting to a multiple of GOMAXPROCS.
 func (w *Workers) Start() {
-	// Get currently set GOMAXPROCS.
-	maxprocs := runtime.GOMAXPROCS(0)
-
 	tryUntil("starting scheduler", 5, w.Scheduler.Start)
 
 	tryUntil("starting client API workerpool", 5, func() bool {
-		return w.ClientAPI.Start(4*maxprocs, 400*maxprocs)
+		return w.ClientAPI.Start(poolSize(
+			config.GetAdvancedClientAPIWorkers(),
+			config.GetAdvancedClientAPIQueueSize(),
+			4, 100,
+		))
 	})
 
 	tryUntil("starting federator workerpool", 5, func() bool {
-		return w.Federator.Start(4*maxprocs, 400*maxprocs)
+		return w.Federator.Start(poolSize(
+			config.GetAdvancedFederatorWorkers(),
+			config.GetAdvancedFederatorQueueSize(),
+			4, 100,
+		))
 	})
 
 	tryUntil("starting media workerpool", 5, func() bool {
-		return w.Media.Start(8*maxprocs, 80*maxprocs)
+		return w.Media.Start(poolSize(
+			config.GetAdvancedMediaWorkers(),
+			config.GetAdvancedMediaQueueSize(),
+			8, 10,
+		))
 	})
 }
 
+// Drain waits for all of the contained worker pools to finish processing
+// their queued and in-flight tasks, until the given context is cancelled.
+// Returns the number of tasks still queued or in-flight on return, if any.
+//
+// This should be called on shutdown after new work has stopped coming in,
+// and before Stop(), which would otherwise pass queued tasks a closed context.
+func (w *Workers) Drain(ctx context.Context) int {
+	const interval = 100 * time.Millisecond
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var remaining int
+		for _, pool := range []*WorkerPool{
+			&w.ClientAPI,
+			&w.Federator,
+			&w.Media,
+		} {
+			remaining += pool.Queue() + pool.InFlight()
+		}
+
+		if remaining == 0 {
+			return 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return remaining
+		case <-ticker.C:
+		}
+	}
+}
+
 // Stop will stop all of the contained worker pools (and global scheduler).
 func (w *Workers) Stop() {
 	tryUntil("stopping scheduler", 5, w.Scheduler.Stop)
@@ -93,6 +138,20 @@ func (w *Workers) Stop() {
 	tryUntil("stopping media workerpool", 5, w.Media.Stop)
 }
 
+// poolSize returns the worker count and queue size to start a
+// pool with, from configured values. Where unset (ie., zero), the
+// worker count defaults to given multiple of GOMAXPROCS, and queue
+// size defaults to given multiple of the worker count.
+func poolSize(workers, queue, perCPU, perWorker int) (int, int) {
+	if workers <= 0 {
+		workers = perCPU * runtime.GOMAXPROCS(0)
+	}
+	if queue <= 0 {
+		queue = perWorker * workers
+	}
+	return workers, queue
+}
+
 // nocopy when embedded will signal linter to
 // error on pass-by-value of parent struct.
 type nocopy struct{}
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/workers"
+)
+
+func TestWorkersDrain(t *testing.T) {
+	var w workers.Workers
+	_ = w.ClientAPI.Start(1, 10)
+	_ = w.Federator.Start(1, 10)
+	_ = w.Media.Start(1, 10)
+	defer func() {
+		_ = w.ClientAPI.Stop()
+		_ = w.Federator.Stop()
+		_ = w.Media.Stop()
+	}()
+
+	var done atomic.Int32
+
+	// Queue up more tasks than there
+	// are workers, so some are queued.
+	for i := 0; i < 3; i++ {
+		w.ClientAPI.Enqueue(func(ctx context.Context) {
+			time.Sleep(50 * time.Millisecond)
+			done.Add(1)
+		})
+	}
+
+	// Drain should wait for all tasks
+	// to complete, including in-flight.
+	if remaining := w.Drain(context.Background()); remaining != 0 {
+		t.Fatalf("expected no remaining tasks, got %d", remaining)
+	}
+	if n := done.Load(); n != 3 {
+		t.Fatalf("expected 3 tasks done after drain, got %d", n)
+	}
+	if n := w.ClientAPI.Processed(); n != 3 {
+		t.Fatalf("expected 3 tasks processed, got %d", n)
+	}
+
+	// Drain should give up once ctx is done.
+	block := make(chan struct{})
+	defer close(block)
+	w.Media.Enqueue(func(ctx context.Context) {
+		select {
+		case <-block:
+		case <-ctx.Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if remaining := w.Drain(ctx); remaining != 1 {
+		t.Fatalf("expected 1 remaining task, got %d", remaining)
+	}
+}
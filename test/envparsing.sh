@@ -7,18 +7,34 @@ EXPECT=$(cat << "EOF"
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
     "accounts-approval-required": false,
+    "accounts-custom-css-allow-remote-imports": true,
     "accounts-custom-css-length": 5000,
+    "accounts-deletion-grace-period": 86400000000000,
+    "accounts-directory-show-remote": true,
+    "accounts-invites-enabled": true,
+    "accounts-ip-retention": "truncated",
     "accounts-reason-required": false,
     "accounts-registration-open": true,
+    "advanced-client-api-queue-size": 0,
+    "advanced-client-api-workers": 0,
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
+    "advanced-durable-queue": false,
+    "advanced-federator-queue-size": 0,
+    "advanced-federator-workers": 0,
     "advanced-header-filter-mode": "",
+    "advanced-media-queue-size": 0,
+    "advanced-media-workers": 0,
+    "advanced-rate-limit-auth-requests": 420,
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
         "127.0.0.1/32"
     ],
     "advanced-rate-limit-requests": 6969,
     "advanced-sender-multiplier": -1,
+    "advanced-shutdown-drain-timeout": 30000000000,
+    "advanced-streaming-max-missed-pongs": 3,
+    "advanced-streaming-ping-interval": 15000000000,
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
     "application-name": "gts",
@@ -29,13 +45,17 @@ EXPECT=$(cat << "EOF"
         "application-mem-ratio": 0.1,
         "block-mem-ratio": 3,
         "boost-of-ids-mem-ratio": 3,
+        "deref-failure-mem-ratio": 0.1,
         "emoji-category-mem-ratio": 0.1,
         "emoji-mem-ratio": 3,
+        "finger-mem-ratio": 0.1,
         "follow-ids-mem-ratio": 4,
         "follow-mem-ratio": 2,
         "follow-request-ids-mem-ratio": 2,
         "follow-request-mem-ratio": 2,
+        "idempotency-key-mem-ratio": 0.1,
         "in-reply-to-ids-mem-ratio": 3,
+        "inbox-activity-mem-ratio": 0.5,
         "instance-mem-ratio": 1,
         "list-entry-mem-ratio": 2,
         "list-mem-ratio": 1,
@@ -48,11 +68,16 @@ EXPECT=$(cat << "EOF"
         "poll-vote-ids-mem-ratio": 2,
         "poll-vote-mem-ratio": 2,
         "report-mem-ratio": 1,
+        "status-core-mem-ratio": 3,
         "status-fave-ids-mem-ratio": 3,
         "status-fave-mem-ratio": 2,
         "status-mem-ratio": 5,
+        "status-reaction-ids-mem-ratio": 0.5,
+        "status-reaction-mem-ratio": 0.5,
         "tag-mem-ratio": 2,
         "thread-mute-mem-ratio": 0.2,
+        "timeline-memory-target": 52428800,
+        "timeline-prepared-max": 300,
         "tombstone-mem-ratio": 0.5,
         "user-mem-ratio": 0.25,
         "visibility-mem-ratio": 2,
@@ -61,13 +86,18 @@ EXPECT=$(cat << "EOF"
     "config-path": "internal/config/testdata/test.yaml",
     "db-address": ":memory:",
     "db-database": "gotosocial_prod",
+    "db-full-text-search": true,
     "db-max-open-conns-multiplier": 3,
     "db-password": "hunter2",
     "db-port": 6969,
+    "db-replica-address": "replica.example.org",
+    "db-replica-port": 6970,
     "db-sqlite-busy-timeout": 1000000000,
     "db-sqlite-cache-size": 0,
     "db-sqlite-journal-mode": "DELETE",
+    "db-sqlite-journal-size-limit": 67108864,
     "db-sqlite-synchronous": "FULL",
+    "db-sqlite-wal-autocheckpoint": 500,
     "db-tls-ca-cert": "",
     "db-tls-mode": "disable",
     "db-type": "sqlite",
@@ -75,24 +105,39 @@ EXPECT=$(cat << "EOF"
     "dry-run": true,
     "email": "",
     "host": "example.com",
+    "host-aliases": [
+        "www.example.com",
+        "social.example.com"
+    ],
     "http-client": {
         "allow-ips": [],
         "block-ips": [],
+        "breaker-backoff": 60000000000,
+        "breaker-failures": 5,
         "timeout": 10000000000,
         "tls-insecure-skip-verify": false
     },
     "instance-deliver-to-shared-inboxes": false,
     "instance-expose-peers": true,
+    "instance-expose-public-rss": true,
     "instance-expose-public-timeline": true,
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
+    "instance-federation-account-freshness": 43200000000000,
     "instance-federation-mode": "allowlist",
     "instance-federation-spam-filter": true,
+    "instance-federation-spam-filter-max-local-mentions": 5,
+    "instance-federation-spam-filter-new-account-days": 3,
+    "instance-federation-spam-filter-quarantine-days": 30,
+    "instance-federation-status-freshness": 14400000000000,
+    "instance-federation-thread-backfill-max-items": 50,
     "instance-inject-mastodon-version": true,
     "instance-languages": [
         "nl",
         "en-GB"
     ],
+    "instance-rss-max-items": 50,
+    "instance-trends-enabled": false,
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -107,10 +152,16 @@ EXPECT=$(cat << "EOF"
     "media-cleanup-from": "00:00",
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
+    "media-disable-animation": true,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
     "media-image-max-size": 420,
     "media-remote-cache-days": 30,
+    "media-thumbnail-webp": true,
+    "media-transcode-enabled": true,
+    "media-transcode-ffmpeg-path": "/usr/bin/ffmpeg",
+    "media-transcode-timeout": 600000000000,
+    "media-unattached-max-age": 86400000000000,
     "media-video-max-size": 420,
     "metrics-auth-enabled": false,
     "metrics-auth-password": "",
@@ -122,12 +173,18 @@ EXPECT=$(cat << "EOF"
     "oidc-allowed-groups": [
         "sloths"
     ],
+    "oidc-auto-provision": true,
     "oidc-client-id": "1234",
     "oidc-client-secret": "shhhh its a secret",
+    "oidc-disable-password-login": true,
     "oidc-enabled": true,
+    "oidc-groups-claim": "roles",
     "oidc-idp-name": "sex-haver",
     "oidc-issuer": "whoknows",
     "oidc-link-existing": true,
+    "oidc-moderator-groups": [
+        "fluffy"
+    ],
     "oidc-scopes": [
         "read",
         "write"
@@ -147,15 +204,23 @@ EXPECT=$(cat << "EOF"
     "smtp-username": "sex-haver",
     "software-version": "",
     "statuses-max-chars": 69,
+    "statuses-max-pinned": 5,
     "statuses-media-max-files": 1,
+    "statuses-poll-allow-revote": true,
     "statuses-poll-max-options": 1,
     "statuses-poll-option-max-chars": 50,
+    "statuses-reactions-enabled": true,
     "storage-backend": "local",
     "storage-local-base-path": "/root/store",
+    "storage-local-min-free-space": 1048576,
     "storage-s3-access-key": "minio",
     "storage-s3-bucket": "gts",
     "storage-s3-endpoint": "localhost:9000",
     "storage-s3-proxy": true,
+    "storage-s3-proxy-media-types": [
+        "emoji",
+        "avatar"
+    ],
     "storage-s3-secret-key": "miniostorage",
     "storage-s3-use-ssl": false,
     "syslog-address": "127.0.0.1:6969",
@@ -188,6 +253,7 @@ GTS_APPLICATION_NAME=gts \
 GTS_LANDING_PAGE_USER=admin \
 GTS_HOST=example.com \
 GTS_ACCOUNT_DOMAIN='peepee' \
+GTS_HOST_ALIASES='www.example.com,social.example.com' \
 GTS_PROTOCOL=http \
 GTS_BIND_ADDRESS='127.0.0.1' \
 GTS_PORT=6969 \
@@ -199,10 +265,15 @@ GTS_DB_USER='sex-haver' \
 GTS_DB_PASSWORD='hunter2' \
 GTS_DB_DATABASE='gotosocial_prod' \
 GTS_DB_MAX_OPEN_CONNS_MULTIPLIER=3 \
+GTS_DB_REPLICA_ADDRESS='replica.example.org' \
+GTS_DB_REPLICA_PORT=6970 \
 GTS_DB_SQLITE_JOURNAL_MODE='DELETE' \
 GTS_DB_SQLITE_SYNCHRONOUS='FULL' \
 GTS_DB_SQLITE_CACHE_SIZE=0 \
 GTS_DB_SQLITE_BUSY_TIMEOUT='1s' \
+GTS_DB_SQLITE_JOURNAL_SIZE_LIMIT='64MiB' \
+GTS_DB_SQLITE_WAL_AUTOCHECKPOINT=500 \
+GTS_DB_FULL_TEXT_SEARCH=true \
 GTS_TLS_MODE='' \
 GTS_DB_TLS_CA_CERT='' \
 GTS_WEB_TEMPLATE_BASE_DIR='/root' \
@@ -211,16 +282,30 @@ GTS_INSTANCE_EXPOSE_PEERS=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
+GTS_INSTANCE_EXPOSE_PUBLIC_RSS=true \
+GTS_INSTANCE_RSS_MAX_ITEMS=50 \
 GTS_INSTANCE_FEDERATION_MODE='allowlist' \
 GTS_INSTANCE_FEDERATION_SPAM_FILTER=true \
+GTS_INSTANCE_FEDERATION_SPAM_FILTER_NEW_ACCOUNT_DAYS=3 \
+GTS_INSTANCE_FEDERATION_SPAM_FILTER_MAX_LOCAL_MENTIONS=5 \
+GTS_INSTANCE_FEDERATION_SPAM_FILTER_QUARANTINE_DAYS=30 \
+GTS_INSTANCE_FEDERATION_ACCOUNT_FRESHNESS=12h \
+GTS_INSTANCE_FEDERATION_STATUS_FRESHNESS=4h \
+GTS_INSTANCE_FEDERATION_THREAD_BACKFILL_MAX_ITEMS=50 \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
 GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
+GTS_INSTANCE_TRENDS_ENABLED=false \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
+GTS_ACCOUNTS_CUSTOM_CSS_ALLOW_REMOTE_IMPORTS=true \
+GTS_ACCOUNTS_DELETION_GRACE_PERIOD=24h \
+GTS_ACCOUNTS_DIRECTORY_SHOW_REMOTE=true \
+GTS_ACCOUNTS_IP_RETENTION=truncated \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_APPROVAL_REQUIRED=false \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
+GTS_ACCOUNTS_INVITES_ENABLED=true \
 GTS_MEDIA_IMAGE_MAX_SIZE=420 \
 GTS_MEDIA_VIDEO_MAX_SIZE=420 \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
@@ -228,21 +313,31 @@ GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
+GTS_MEDIA_TRANSCODE_ENABLED=true \
+GTS_MEDIA_TRANSCODE_FFMPEG_PATH='/usr/bin/ffmpeg' \
+GTS_MEDIA_TRANSCODE_TIMEOUT='10m' \
+GTS_MEDIA_DISABLE_ANIMATION=true \
+GTS_MEDIA_THUMBNAIL_WEBP=true \
 GTS_METRICS_AUTH_ENABLED=false \
 GTS_METRICS_ENABLED=false \
 GTS_STORAGE_BACKEND='local' \
 GTS_STORAGE_LOCAL_BASE_PATH='/root/store' \
+GTS_STORAGE_LOCAL_MIN_FREE_SPACE=1048576 \
 GTS_STORAGE_S3_ACCESS_KEY='minio' \
 GTS_STORAGE_S3_SECRET_KEY='miniostorage' \
 GTS_STORAGE_S3_ENDPOINT='localhost:9000' \
 GTS_STORAGE_S3_USE_SSL='false' \
 GTS_STORAGE_S3_PROXY='true' \
+GTS_STORAGE_S3_PROXY_MEDIA_TYPES='emoji,avatar' \
 GTS_STORAGE_S3_BUCKET='gts' \
 GTS_STATUSES_MAX_CHARS=69 \
 GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
 GTS_STATUSES_POLL_OPTIONS_MAX_CHARS=69 \
+GTS_STATUSES_POLL_ALLOW_REVOTE=true \
 GTS_STATUSES_MEDIA_MAX_FILES=1 \
+GTS_STATUSES_MAX_PINNED=5 \
+GTS_STATUSES_REACTIONS_ENABLED=true \
 GTS_LETS_ENCRYPT_ENABLED=false \
 GTS_LETS_ENCRYPT_PORT=8080 \
 GTS_LETS_ENCRYPT_CERT_DIR='/root/certs' \
@@ -257,6 +352,10 @@ GTS_OIDC_SCOPES='read,write' \
 GTS_OIDC_LINK_EXISTING=true \
 GTS_OIDC_ALLOWED_GROUPS='sloths' \
 GTS_OIDC_ADMIN_GROUPS='steamy' \
+GTS_OIDC_MODERATOR_GROUPS='fluffy' \
+GTS_OIDC_GROUPS_CLAIM='roles' \
+GTS_OIDC_AUTO_PROVISION=true \
+GTS_OIDC_DISABLE_PASSWORD_LOGIN=true \
 GTS_SMTP_HOST='example.com' \
 GTS_SMTP_PORT=4269 \
 GTS_SMTP_USERNAME='sex-haver' \
@@ -269,9 +368,12 @@ GTS_SYSLOG_ADDRESS='127.0.0.1:6969' \
 GTS_TRACING_ENDPOINT='localhost:4317' \
 GTS_TRACING_INSECURE_TRANSPORT=true \
 GTS_ADVANCED_COOKIES_SAMESITE='strict' \
+GTS_ADVANCED_RATE_LIMIT_AUTH_REQUESTS=420 \
 GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS="192.0.2.0/24,127.0.0.1/32" \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
+GTS_ADVANCED_STREAMING_MAX_MISSED_PONGS=3 \
+GTS_ADVANCED_STREAMING_PING_INTERVAL='15s' \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
 GTS_REQUEST_ID_HEADER='X-Trace-Id' \
@@ -42,6 +42,7 @@ var testDefaults = config.Configuration{
 	ConfigPath:         "",
 	Host:               "localhost:8080",
 	AccountDomain:      "localhost:8080",
+	HostAliases:        []string{},
 	Protocol:           "http",
 	BindAddress:        "127.0.0.1",
 	Port:               8080,
@@ -60,16 +61,26 @@ var testDefaults = config.Configuration{
 	DbSqliteSynchronous:      "NORMAL",
 	DbSqliteCacheSize:        8 * bytesize.MiB,
 	DbSqliteBusyTimeout:      time.Minute * 5,
+	DbFullTextSearch:         false,
 
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         config.InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   true,
-	InstanceExposePeers:            true,
-	InstanceExposeSuspended:        true,
-	InstanceExposeSuspendedWeb:     true,
-	InstanceDeliverToSharedInboxes: true,
+	InstanceFederationMode:                       config.InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:                 true,
+	InstanceFederationSpamFilterNewAccountDays:   7,
+	InstanceFederationSpamFilterMaxLocalMentions: 2,
+	InstanceFederationSpamFilterQuarantineDays:   7,
+	InstanceFederationAccountFreshness:           6 * time.Hour,
+	InstanceFederationThreadBackfillMaxItems:     100,
+	InstanceFederationStatusFreshness:            2 * time.Hour,
+	InstanceExposePeers:                          true,
+	InstanceExposeSuspended:                      true,
+	InstanceExposeSuspendedWeb:                   true,
+	InstanceExposePublicRSS:                      true,
+	InstanceRSSMaxItems:                          100,
+	InstanceDeliverToSharedInboxes:               true,
+	InstanceTrendsEnabled:                        true,
 	InstanceLanguages: language.Languages{
 		{
 			TagStr: "nl",
@@ -79,11 +90,16 @@ var testDefaults = config.Configuration{
 		},
 	},
 
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   true,
-	AccountsCustomCSSLength:  10000,
+	AccountsRegistrationOpen:            true,
+	AccountsApprovalRequired:            true,
+	AccountsReasonRequired:              true,
+	AccountsInvitesEnabled:              true,
+	AccountsAllowCustomCSS:              true,
+	AccountsCustomCSSLength:             10000,
+	AccountsCustomCSSAllowRemoteImports: false,
+	AccountsDeletionGracePeriod:         0,
+	AccountsDirectoryShowRemote:         false,
+	AccountsIPRetention:                 "full",
 
 	MediaImageMaxSize:        10485760, // 10MiB
 	MediaVideoMaxSize:        41943040, // 40MiB
@@ -94,6 +110,7 @@ var testDefaults = config.Configuration{
 	MediaEmojiRemoteMaxSize:  102400,         // 100KiB
 	MediaCleanupFrom:         "00:00",        // midnight.
 	MediaCleanupEvery:        24 * time.Hour, // 1/day.
+	MediaUnattachedMaxAge:    24 * time.Hour,
 
 	// the testrig only uses in-memory storage, so we can
 	// safely set this value to 'test' to avoid running storage
@@ -105,6 +122,8 @@ var testDefaults = config.Configuration{
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesMaxPinned:          10,
+	StatusesReactionsEnabled:   false,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         0,
@@ -120,6 +139,8 @@ var testDefaults = config.Configuration{
 	OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
 	OIDCLinkExisting:     false,
 	OIDCAdminGroups:      []string{"adminRole"},
+	OIDCModeratorGroups:  []string{"modRole"},
+	OIDCGroupsClaim:      "groups",
 	OIDCAllowedGroups:    []string{"allowedRole"},
 
 	SMTPHost:               "",
@@ -141,10 +162,13 @@ var testDefaults = config.Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    0, // disabled
-	AdvancedThrottlingMultiplier: 0, // disabled
-	AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
+	AdvancedCookiesSamesite:         "lax",
+	AdvancedRateLimitRequests:       0, // disabled
+	AdvancedRateLimitAuthRequests:   0, // disabled
+	AdvancedThrottlingMultiplier:    0, // disabled
+	AdvancedSenderMultiplier:        0, // 1 sender only, regardless of CPU
+	AdvancedStreamingPingInterval:   time.Second * 30,
+	AdvancedStreamingMaxMissedPongs: 2,
 
 	SoftwareVersion: "0.0.0-testrig",
 
@@ -35,6 +35,7 @@ var testModels = []interface{}{
 	&gtsmodel.AccountToEmoji{},
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
+	&gtsmodel.Delivery{},
 	&gtsmodel.DomainBlock{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Follow{},
@@ -50,8 +51,10 @@ var testModels = []interface{}{
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusReaction{},
 	&gtsmodel.StatusBookmark{},
 	&gtsmodel.Tag{},
+	&gtsmodel.TagUse{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
 	&gtsmodel.ThreadToStatus{},
@@ -64,9 +67,18 @@ var testModels = []interface{}{
 	&gtsmodel.Client{},
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
+	&gtsmodel.Relay{},
+	&gtsmodel.Invite{},
+	&gtsmodel.QuarantinedStatus{},
+	&gtsmodel.NotificationRequest{},
+	&gtsmodel.NotificationPermission{},
 	&gtsmodel.Report{},
 	&gtsmodel.Rule{},
 	&gtsmodel.AccountNote{},
+	&gtsmodel.AccountStats{},
+	&gtsmodel.AccountArchive{},
+	&gtsmodel.QueuedMessage{},
+	&gtsmodel.InboxActivity{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
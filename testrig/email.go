@@ -32,10 +32,8 @@ func NewEmailSender(templateBaseDir string, sentEmails map[string]string) email.
 		cfg.WebTemplateBaseDir = templateBaseDir
 	})
 
-	var sendCallback func(toAddress string, message string)
-
-	if sentEmails != nil {
-		sendCallback = func(toAddress string, message string) {
+	sendCallback := func(toAddress string, message string) {
+		if sentEmails != nil {
 			sentEmails[toAddress] = message
 		}
 	}
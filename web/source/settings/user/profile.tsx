@@ -61,7 +61,9 @@ function UserProfileForm({ data: profile }) {
 		- string display_name
 		- string note
 		- file avatar
+		- string avatar_description
 		- file header
+		- string header_description
 		- bool enable_rss
 		- string custom_css (if enabled)
 	*/
@@ -76,7 +78,9 @@ function UserProfileForm({ data: profile }) {
 
 	const form = {
 		avatar: useFileInput("avatar", { withPreview: true }),
+		avatarDescription: useTextInput("avatar_description", { source: profile }),
 		header: useFileInput("header", { withPreview: true }),
+		headerDescription: useTextInput("header_description", { source: profile }),
 		displayName: useTextInput("display_name", { source: profile }),
 		note: useTextInput("note", { source: profile, valueSelector: (p) => p.source?.note }),
 		customCSS: useTextInput("custom_css", { source: profile, nosubmit: !instanceConfig.allowCustomCSS }),
@@ -116,6 +120,11 @@ function UserProfileForm({ data: profile }) {
 							field={form.header}
 							accept="image/*"
 						/>
+						<TextInput
+							field={form.headerDescription}
+							label="Header image description"
+							placeholder="A sunlit field with purple flowers."
+						/>
 					</div>
 					<div>
 						<FileInput
@@ -123,6 +132,11 @@ function UserProfileForm({ data: profile }) {
 							field={form.avatar}
 							accept="image/*"
 						/>
+						<TextInput
+							field={form.avatarDescription}
+							label="Avatar image description"
+							placeholder="A cute drawing of a smiling sloth."
+						/>
 					</div>
 				</div>
 			</div>